@@ -16,10 +16,13 @@ or by running 'ginkgo help'.
 package ginkgo
 
 import (
+	"encoding/json"
 	"fmt"
 	"io"
 	"os"
 	"path/filepath"
+	"reflect"
+	"regexp"
 	"strings"
 	"time"
 
@@ -40,6 +43,7 @@ var suiteConfig = types.NewDefaultSuiteConfig()
 var reporterConfig = types.NewDefaultReporterConfig()
 var suiteDidRun = false
 var outputInterceptor internal.OutputInterceptor
+var outputFilters = internal.NewOutputFilters()
 var client parallel_support.Client
 
 func init() {
@@ -47,6 +51,14 @@ func init() {
 	flagSet, err = types.BuildTestSuiteFlagSet(&suiteConfig, &reporterConfig)
 	exitIfErr(err)
 	GinkgoWriter = internal.NewWriter(os.Stdout)
+
+	// check the protocol before the test binary parses its flags - a mismatched CLI may pass flags this version of Ginkgo doesn't know about
+	if err := types.CheckCLIProtocolVersion(os.Getenv); err != nil {
+		if !types.AllowVersionMismatch(os.Getenv) {
+			exitIfErr(err)
+		}
+		fmt.Fprintln(formatter.ColorableStdErr, formatter.F("{{orange}}Warning: ignoring a Ginkgo version mismatch because %s is set{{/}}", types.AllowVersionMismatchEnvVar))
+	}
 }
 
 func exitIfErr(err error) {
@@ -58,8 +70,32 @@ func exitIfErr(err error) {
 			client.Close()
 		}
 		fmt.Fprintln(formatter.ColorableStdErr, err.Error())
-		os.Exit(1)
+		os.Exit(types.GINKGO_CONFIGURATION_ERROR_EXIT_CODE)
+	}
+}
+
+func renderFocusExplanations(f formatter.Formatter, description string, query string, explanations []internal.FocusExplanation) string {
+	if len(explanations) == 0 {
+		return f.F("{{red}}No specs in {{bold}}%s{{/}}{{red}} match \"%s\"{{/}}\n", description, query)
 	}
+	out := f.F("{{bold}}%d{{/}} specs in {{bold}}%s{{/}} match \"%s\"\n", len(explanations), description, query)
+	for _, explanation := range explanations {
+		out += "\n"
+		if explanation.Included() {
+			out += f.F("{{bold}}%s{{/}} {{green}}[WILL RUN]{{/}}\n", explanation.Text)
+		} else {
+			out += f.F("{{bold}}%s{{/}} {{cyan}}[WILL BE SKIPPED]{{/}}\n", explanation.Text)
+		}
+		out += f.Fi(1, "{{gray}}%s{{/}}\n", explanation.CodeLocation)
+		for _, outcome := range explanation.Outcomes {
+			if outcome.Excluded {
+				out += f.Fi(1, "{{red}}excluded by{{/}} {{bold}}%s{{/}}: %s\n", outcome.Rule, outcome.Reason)
+			} else {
+				out += f.Fi(1, "{{green}}passes{{/}} {{bold}}%s{{/}}: %s\n", outcome.Rule, outcome.Reason)
+			}
+		}
+	}
+	return out
 }
 
 func exitIfErrors(errors []error) {
@@ -73,7 +109,7 @@ func exitIfErrors(errors []error) {
 		for _, err := range errors {
 			fmt.Fprintln(formatter.ColorableStdErr, err.Error())
 		}
-		os.Exit(1)
+		os.Exit(types.GINKGO_CONFIGURATION_ERROR_EXIT_CODE)
 	}
 }
 
@@ -103,6 +139,21 @@ You can learn more at https://onsi.github.io/ginkgo/#logging-output
 */
 var GinkgoWriter GinkgoWriterInterface
 
+/*
+GinkgoWriterWithPrefix returns a GinkgoWriterInterface that tags each line it writes with [prefix] - this is useful when a spec writes from several goroutines.
+
+The returned writer shares GinkgoWriter's buffer, tee writers, and redactions.  Lines are never attributed to the wrong writer - even when a goroutine writes a partial line - so that interleaved output stays readable.
+If you have replaced GinkgoWriter with your own implementation the returned writer tags each line and writes it to your GinkgoWriter instead.
+
+You can learn more at https://onsi.github.io/ginkgo/#logging-output
+*/
+func GinkgoWriterWithPrefix(prefix string) GinkgoWriterInterface {
+	if writer, ok := GinkgoWriter.(*internal.Writer); ok {
+		return writer.WithPrefix(prefix)
+	}
+	return internal.NewPrefixingWriter(GinkgoWriter, prefix)
+}
+
 //The interface by which Ginkgo receives *testing.T
 type GinkgoTestingT interface {
 	Fail()
@@ -147,6 +198,22 @@ func GinkgoParallelProcess() int {
 	return suiteConfig.ParallelProcess
 }
 
+/*
+GinkgoDeadline returns the time by which the currently running node must complete.  This is the earlier of the node's NodeTimeout deadline (if any)
+and the deadline implied by the suite's --timeout.  ok is false if there is no deadline.
+
+Use GinkgoDeadline to bound polling helpers (e.g. Gomega's Eventually) to the time that is actually left.  This avoids a helper outliving the node and producing a confusing pair of timeouts:
+
+	if deadline, ok := GinkgoDeadline(); ok {
+		Eventually(ready, time.Until(deadline)).Should(BeTrue())
+	}
+
+You can learn more at https://onsi.github.io/ginkgo/#bounding-polling-to-the-remaining-time
+*/
+func GinkgoDeadline() (deadline time.Time, ok bool) {
+	return global.Suite.Deadline()
+}
+
 /*
 PauseOutputInterception() pauses Ginkgo's output interception.  This is only relevant
 when running in parallel and output to stdout/stderr is being intercepted.  You generally
@@ -176,6 +243,48 @@ func ResumeOutputInterception() {
 	outputInterceptor.ResumeIntercepting()
 }
 
+/*
+RedactInterceptedOutput registers a filter that replaces every match of pattern in intercepted stdout/stderr output with replacement before the output is stored and reported.  replacement can refer to submatches - see regexp.Regexp.ReplaceAllString.
+
+SuppressInterceptedOutput registers a filter that drops every line of intercepted output that matches pattern.
+
+Use these to keep secrets, or enormous dumps emitted by the system under test, out of Ginkgo's reports.  Filters are applied in the order they are registered and can be registered at any point (e.g. in a BeforeSuite, once a secret is known).  They apply to all output captured after they are registered.
+
+Output is only intercepted when running in parallel.  When running in series stdout and stderr go straight to the console and are not filtered.  GinkgoWriter output is not filtered.
+
+You can learn more at https://onsi.github.io/ginkgo/#filtering-intercepted-output
+*/
+func RedactInterceptedOutput(pattern *regexp.Regexp, replacement string) {
+	outputFilters.Add(internal.OutputFilter{Pattern: pattern, Replacement: replacement})
+}
+
+/*
+GinkgoRedact registers a secret value that Ginkgo should never report.  Every occurrence of value is replaced with [REDACTED] in
+GinkgoWriter output, captured stdout/stderr, failure messages, and report entries before any ReportAfterEach node or reporter sees them.
+
+GinkgoRedactPattern does the same for every match of pattern.
+
+Redactions apply to everything reported after they are registered and can be registered at any point (e.g. in a BeforeSuite once a credential has been fetched).  Redactions only apply to the process that registers them - when running in parallel with SynchronizedBeforeSuite register them in the function that runs on all processes.
+
+You can learn more at https://onsi.github.io/ginkgo/#redacting-secrets
+*/
+func GinkgoRedact(value string) {
+	if value == "" {
+		return
+	}
+	global.Suite.Redact(internal.RedactionForValue(value))
+}
+
+//GinkgoRedactPattern() - see docs for GinkgoRedact()
+func GinkgoRedactPattern(pattern *regexp.Regexp) {
+	global.Suite.Redact(internal.RedactionForPattern(pattern))
+}
+
+//SuppressInterceptedOutput() - see docs for RedactInterceptedOutput()
+func SuppressInterceptedOutput(pattern *regexp.Regexp) {
+	outputFilters.Add(internal.OutputFilter{Pattern: pattern, Suppress: true})
+}
+
 /*
 RunSpecs is the entry point for the Ginkgo spec runner.
 
@@ -220,24 +329,47 @@ func RunSpecs(t GinkgoTestingT, description string, args ...interface{}) bool {
 			reporterConfig = arg
 		case Labels:
 			suiteLabels = append(suiteLabels, arg...)
+		case PhaseOrder:
+			global.Suite.SetPhaseOrder(arg)
+		case SpecNamingRules:
+			global.Suite.SetSpecNamingRules(arg)
 		default:
 			configErrors = append(configErrors, types.GinkgoErrors.UnknownTypePassedToRunSpecs(arg))
 		}
 	}
 	exitIfErrors(configErrors)
 
+	//editors running focused specs should not be told the suite failed
+	if strings.TrimSpace(os.Getenv("GINKGO_EDITOR_INTEGRATION")) != "" {
+		suiteConfig.FocusExitCode = types.FocusExitCodeOff
+	}
+
 	configErrors = types.VetConfig(flagSet, suiteConfig, reporterConfig)
 	if len(configErrors) > 0 {
 		fmt.Fprintf(formatter.ColorableStdErr, formatter.F("{{red}}Ginkgo detected configuration issues:{{/}}\n"))
 		for _, err := range configErrors {
 			fmt.Fprintf(formatter.ColorableStdErr, err.Error())
 		}
-		os.Exit(1)
+		os.Exit(types.GINKGO_CONFIGURATION_ERROR_EXIT_CODE)
+	}
+
+	//the ginkgo CLI computes the seed itself and always passes it in with --seed
+	if !flagSet.WasSet("ginkgo.seed") && suiteConfig.SeedStrategy != "" {
+		seed, err := internal.SeedForStrategy(suiteConfig.SeedStrategy)
+		exitIfErr(err)
+		suiteConfig.RandomSeed = seed
 	}
 
 	var reporter reporters.Reporter
 	if suiteConfig.ParallelTotal == 1 {
-		reporter = reporters.NewDefaultReporter(reporterConfig, formatter.ColorableStdOut)
+		var err error
+		reporter, err = reporters.NewDefaultReporterWithOutputSinks(reporterConfig, formatter.ColorableStdOut)
+		exitIfErr(err)
+		if reporterConfig.IDEProtocol != "" {
+			ideProtocolReporter, err := reporters.NewIDEProtocolReporter(reporterConfig)
+			exitIfErr(err)
+			reporter = reporters.CompositeReporter{reporter, ideProtocolReporter}
+		}
 		outputInterceptor = internal.NoopOutputInterceptor{}
 		client = nil
 	} else {
@@ -250,15 +382,21 @@ func RunSpecs(t GinkgoTestingT, description string, args ...interface{}) bool {
 		default:
 			outputInterceptor = internal.NewOutputInterceptor()
 		}
+		outputInterceptor = internal.NewFilteringOutputInterceptor(outputInterceptor, outputFilters)
 		client = parallel_support.NewClient(suiteConfig.ParallelHost)
 		if !client.Connect() {
 			client = nil
 			exitIfErr(types.GinkgoErrors.UnreachableParallelHost(suiteConfig.ParallelHost))
 		}
 		defer client.Close()
+		exitIfErr(client.Handshake())
+		fingerprint, err := parallel_support.NewProcessFingerprint(suiteConfig)
+		exitIfErr(err)
+		exitIfErr(client.PostProcessFingerprint(fingerprint))
 	}
 
 	writer := GinkgoWriter.(*internal.Writer)
+	writer.SetRedactions(global.Suite.Redactions())
 	if reporterConfig.Verbose && suiteConfig.ParallelTotal == 1 {
 		writer.SetMode(internal.WriterModeStreamAndBuffer)
 	} else {
@@ -277,10 +415,28 @@ func RunSpecs(t GinkgoTestingT, description string, args ...interface{}) bool {
 	suitePath, err = filepath.Abs(suitePath)
 	exitIfErr(err)
 
+	if suiteConfig.DescribeSuite {
+		encoded, err := json.MarshalIndent(global.Suite.Describe(description, suiteLabels, suitePath), "", "  ")
+		exitIfErr(err)
+		fmt.Fprintln(os.Stdout, string(encoded))
+		return true
+	}
+
+	if suiteConfig.ExplainSpec != "" {
+		explanations := global.Suite.Explain(description, suiteLabels, suiteConfig, suiteConfig.ExplainSpec)
+		fmt.Fprint(formatter.ColorableStdOut, renderFocusExplanations(formatter.NewWithNoColorBool(reporterConfig.NoColor), description, suiteConfig.ExplainSpec, explanations))
+		if len(explanations) == 0 {
+			t.Fail()
+		}
+		return len(explanations) > 0
+	}
+
+	flagSet.ValidateDeprecations(deprecationTracker)
+	global.Suite.SetDeprecations(deprecationTracker.TrackedDeprecations())
+
 	passed, hasFocusedTests := global.Suite.Run(description, suiteLabels, suitePath, global.Failer, reporter, writer, outputInterceptor, interrupt_handler.NewInterruptHandler(suiteConfig.Timeout, client), client, suiteConfig)
 	outputInterceptor.Shutdown()
 
-	flagSet.ValidateDeprecations(deprecationTracker)
 	if deprecationTracker.DidTrackDeprecations() {
 		fmt.Fprintln(formatter.ColorableStdErr, deprecationTracker.DeprecationsReport())
 	}
@@ -289,7 +445,15 @@ func RunSpecs(t GinkgoTestingT, description string, args ...interface{}) bool {
 		t.Fail()
 	}
 
-	if passed && hasFocusedTests && strings.TrimSpace(os.Getenv("GINKGO_EDITOR_INTEGRATION")) == "" {
+	//go test would otherwise exit with status 1 - so we exit explicitly to let CI distinguish interrupts and timeouts from spec failures
+	if exitReason := global.Suite.ExitReason(); exitReason.Is(types.ExitReasonInterrupted, types.ExitReasonTimedOut) {
+		if client != nil {
+			client.Close()
+		}
+		os.Exit(exitReason.ExitCode())
+	}
+
+	if passed && hasFocusedTests && suiteConfig.ProgrammaticFocusBehavior() == types.FocusExitCodeFail {
 		fmt.Println("PASS | FOCUSED")
 		os.Exit(types.GINKGO_FOCUS_EXIT_CODE)
 	}
@@ -313,6 +477,69 @@ func Skip(message string, callerSkip ...int) {
 	panic(types.GinkgoErrors.UncaughtGinkgoPanic(cl))
 }
 
+/*
+SkipSuite instructs Ginkgo to skip the entire suite.  It can only be called in a BeforeSuite (or SynchronizedBeforeSuite) and is useful when a
+precondition for the whole suite isn't met - e.g. a required service isn't available in the current environment:
+
+	var _ = BeforeSuite(func() {
+		if os.Getenv("DATABASE_URL") == "" {
+			SkipSuite("DATABASE_URL is not set")
+		}
+	})
+
+Every spec is reported as skipped, reason is recorded in the suite's report (Report.SuiteSkipReason), and the suite succeeds.  Any remaining BeforeSuite nodes
+are not run but AfterSuite nodes and suite-level DeferCleanup callbacks are.
+
+Like Skip, SkipSuite panics to end the BeforeSuite - see Skip for the meaning of callerSkip.
+
+You can learn more here: https://onsi.github.io/ginkgo/#skipping-the-entire-suite-skipsuite
+*/
+func SkipSuite(reason string, callerSkip ...int) {
+	skip := 0
+	if len(callerSkip) > 0 {
+		skip = callerSkip[0]
+	}
+	cl := types.NewCodeLocationWithStackTrace(skip + 1)
+	if err := global.Suite.SkipSuite(reason, cl); err != nil {
+		global.Failer.Fail(err.Error(), cl)
+		panic(types.GinkgoErrors.UncaughtGinkgoPanic(cl))
+	}
+	global.Failer.Skip(reason, cl)
+	panic(types.GinkgoErrors.UncaughtGinkgoPanic(cl))
+}
+
+/*
+StopTryingSignal is the signal returned by GinkgoStopTrying
+*/
+type StopTryingSignal = internal.StopTryingSignal
+
+/*
+GinkgoStopTrying returns a signal that helpers - for example, shared polling utilities - can use to tell Ginkgo that the current spec can't make progress.
+
+The signal is an error, so helpers can return it to their callers.  To deliver the signal to Ginkgo, panic with it (or with an error that wraps it) or call its Now() method:
+
+	func WaitForServer(timeout time.Duration) error {
+		...
+		return GinkgoStopTrying("the server never came up")
+	}
+
+	It("talks to the server", func() {
+		if err := WaitForServer(time.Minute); err != nil {
+			panic(err)
+		}
+		...
+	})
+
+What Ginkgo does with the signal depends on how the spec is decorated.  If the spec is eligible to be retried (via the FlakeAttempts decorator
+or --flake-attempts) and has attempts remaining, the current attempt fails and is retried.  Otherwise the spec is skipped with the signal's message.
+Signals are also honored in goroutines that defer GinkgoRecover().
+
+You can learn more about GinkgoStopTrying here: https://onsi.github.io/ginkgo/#signaling-from-helpers-ginkgostoptrying
+*/
+func GinkgoStopTrying(message string) StopTryingSignal {
+	return internal.NewStopTryingSignal(message, types.NewCodeLocationWithStackTrace(1))
+}
+
 /*
 Fail notifies Ginkgo that the current spec has failed. (Gomega will call Fail for you automatically when an assertion fails.)
 
@@ -331,10 +558,249 @@ func Fail(message string, callerSkip ...int) {
 	}
 
 	cl := types.NewCodeLocationWithStackTrace(skip + 1)
+	global.Suite.RecordFailedAssertion()
 	global.Failer.Fail(message, cl)
 	panic(types.GinkgoErrors.UncaughtGinkgoPanic(cl))
 }
 
+/*
+FailWithPayload behaves like Fail but also attaches a structured payload to the failure - for example, a diff between two protobuf messages.
+
+The payload is rendered for the console using the renderer registered for its type with RegisterFailureRenderer.  If no renderer
+is registered the payload is rendered like a ReportEntry value.  The payload is also JSON-encoded and available, losslessly, on
+SpecReport.Failure.Payload - and, therefore, in reports generated by --json-report.
+
+You can learn more about failure payloads here: https://onsi.github.io/ginkgo/#rendering-structured-failures
+*/
+func FailWithPayload(message string, payload interface{}, callerSkip ...int) {
+	skip := 0
+	if len(callerSkip) > 0 {
+		skip = callerSkip[0]
+	}
+
+	cl := types.NewCodeLocationWithStackTrace(skip + 1)
+	global.Suite.RecordFailedAssertion()
+	global.Failer.FailWithPayload(message, payload, cl)
+	panic(types.GinkgoErrors.UncaughtGinkgoPanic(cl))
+}
+
+/*
+FailWith behaves like Fail but also attaches the error that caused the failure to it.  The failure message is message followed by the error's
+message (e.g. "failed to load the fixtures: open fixtures.json: no such file or directory"), or just the error's message if message is empty.
+
+Structured information about the error - its type, message, and the types of the errors it wraps - is available on SpecReport.Failure.Error
+and, therefore, in reports generated by --json-report.  The error itself is available to ReportAfterEach and custom reporters in the process
+in which the failure occurred via SpecReport.Failure.Error.GetRawError() - so you can triage failures with errors.Is and errors.As.
+
+You can learn more about FailWith here: https://onsi.github.io/ginkgo/#failing-with-errors-failwith
+*/
+func FailWith(err error, message string, callerSkip ...int) {
+	skip := 0
+	if len(callerSkip) > 0 {
+		skip = callerSkip[0]
+	}
+
+	cl := types.NewCodeLocationWithStackTrace(skip + 1)
+	global.Suite.RecordFailedAssertion()
+	global.Failer.FailWithError(message, err, cl)
+	panic(types.GinkgoErrors.UncaughtGinkgoPanic(cl))
+}
+
+/*
+RecordAssertion tells Ginkgo that the current spec has made an assertion.  Ginkgo counts the assertions each spec makes in SpecReport.NumAssertions
+so that --zero-assertion-policy can flag vacuous specs that pass without asserting anything.  Since Ginkgo can only count the assertions that pass
+if they are recorded with RecordAssertion, --zero-assertion-policy only applies to suites that call it: specs that run before the suite (or, when
+running in parallel, the process) first calls RecordAssertion are never flagged.  Call RecordAssertion in a BeforeSuite to apply the policy to every spec.
+
+Ginkgo counts every call to Fail, FailWith, and FailWithPayload - and so every failed assertion made via the fail handler registered with Gomega's
+RegisterFailHandler(Fail).  Gomega does not tell Ginkgo about the assertions that pass, however, so assertion helpers (and matcher libraries that
+integrate with Ginkgo) should call RecordAssertion whenever they make an assertion:
+
+	func ExpectHealthy(service Service) {
+		GinkgoHelper()
+		RecordAssertion()
+		Expect(service.Health()).To(Equal("ok"))
+	}
+
+RecordAssertion can be called from any goroutine.
+
+You can learn more here: https://onsi.github.io/ginkgo/#detecting-specs-without-assertions
+*/
+func RecordAssertion() {
+	global.Suite.RecordAssertion()
+}
+
+/*
+RegisterFailureRenderer registers a renderer for FailWithPayload payloads that have the same type as sample.  The renderer's output
+is emitted beneath the failure message and can include the color codes documented in github.com/onsi/ginkgo/v2/formatter.
+
+RegisterFailureRenderer returns true so that it can be called at the top-level of your suite:
+
+	var _ = RegisterFailureRenderer(&pb.Order{}, renderOrderDiff)
+
+You can learn more about failure payloads here: https://onsi.github.io/ginkgo/#rendering-structured-failures
+*/
+func RegisterFailureRenderer(sample interface{}, renderer func(payload interface{}) string) bool {
+	global.Failer.RegisterFailureRenderer(reflect.TypeOf(sample), renderer)
+	return true
+}
+
+/*
+RequirementChecker checks a named requirement declared with Requires.  It is passed the requirement's constraint - ">=14" for Requires("postgres>=14"), or "" for Requires("docker") -
+and returns an error explaining why the requirement is not met, or nil if it is.
+*/
+type RequirementChecker = internal.RequirementChecker
+
+/*
+RegisterRequirementChecker registers the RequirementChecker for the named requirements called name.  Specs can then declare that they need the requirement with the Requires decorator:
+
+	var _ = RegisterRequirementChecker("docker", func(_ string) error {
+		return exec.Command("docker", "info").Run()
+	})
+
+	It("builds the image", Requires("docker"), func() { ... })
+
+RunSpecs checks each named requirement declared by the specs that will run once - after BeforeSuite - and skips the specs whose requirements are not met.
+Requirements that have no registered checker are not met.  The unmet requirements, and the reasons they were not met, are listed in the suite's report (Report.UnmetRequirements).
+
+RegisterRequirementChecker returns true so that it can be called at the top-level of your suite.
+
+You can learn more here: https://onsi.github.io/ginkgo/#skipping-specs-with-unmet-requirements
+*/
+func RegisterRequirementChecker(name string, checker RequirementChecker) bool {
+	global.Suite.RegisterRequirementChecker(name, checker, types.NewCodeLocation(1))
+	return true
+}
+
+/*
+HealthCheck checks the health of an external dependency the suite relies on - a database, a cluster, a third-party API - and returns an error if it is unhealthy.
+*/
+type HealthCheck = internal.HealthCheck
+
+/*
+RegisterHealthCheck registers a HealthCheck that Ginkgo runs in the background, every interval, from just before BeforeSuite until AfterSuite has run:
+
+	var _ = RegisterHealthCheck("postgres", 5*time.Second, func() error {
+		return db.Ping()
+	})
+
+Ginkgo records the periods during which each health check fails.  Every spec that runs while a health check is failing has the degradation recorded in its
+SpecReport.EnvironmentDegradations and Ginkgo's console reporter lists them alongside the spec's failure - so you can tell infrastructure blips apart from
+product failures when triaging.  Health checks never change the outcome of a spec.
+
+Health checks run concurrently with your specs so they must be safe to call from a separate goroutine and must return errors rather than make assertions.
+When running in parallel each process runs its own health checks.
+
+RegisterHealthCheck returns true so that it can be called at the top-level of your suite.
+
+You can learn more here: https://onsi.github.io/ginkgo/#monitoring-the-health-of-external-dependencies
+*/
+func RegisterHealthCheck(name string, interval time.Duration, check HealthCheck) bool {
+	cl := types.NewCodeLocation(1)
+	if interval <= 0 {
+		exitIfErr(types.GinkgoErrors.InvalidHealthCheckInterval(name, cl))
+	}
+	global.Suite.RegisterHealthCheck(name, interval, check, cl)
+	return true
+}
+
+/*
+PreflightCheck verifies that the environment the suite needs - a cluster, a database, credentials - is ready and returns an error if it is not.
+*/
+type PreflightCheck = internal.PreflightCheck
+
+/*
+RegisterPreflightCheck registers a PreflightCheck that Ginkgo runs before anything else in the suite - before any ReadinessGates, BeforeSuite, or specs:
+
+	var _ = RegisterPreflightCheck("cluster is reachable", func() error {
+		return exec.Command("kubectl", "cluster-info").Run()
+	})
+
+If any preflight check fails the environment is not ready: Ginkgo fails the suite without running any of its nodes and reports the failing checks
+(in the suite's Report.PreflightFailures).  Preflight checks are run in the order they are registered and all of them run, even if an earlier one fails.
+
+When running in parallel only process #1 runs the preflight checks.  The other processes wait for the outcome and, if a check failed, exit straight
+away - so you get a single "environment not ready" report rather than every process timing out in BeforeSuite.
+
+RegisterPreflightCheck returns true so that it can be called at the top-level of your suite.
+
+You can learn more here: https://onsi.github.io/ginkgo/#checking-the-environment-before-the-suite-runs-preflight-checks
+*/
+func RegisterPreflightCheck(name string, check PreflightCheck) bool {
+	global.Suite.RegisterPreflightCheck(name, check, types.NewCodeLocation(1))
+	return true
+}
+
+/*
+AssertionDefaultsHandler applies the passed-in AssertionDefaults to your assertion library and returns a function that restores the library's previous defaults.
+*/
+type AssertionDefaultsHandler = internal.AssertionDefaultsHandler
+
+/*
+RegisterAssertionDefaultsHandler registers the AssertionDefaultsHandler Ginkgo uses to apply the AssertionDefaults declared by each spec.  For Gomega:
+
+	var _ = RegisterAssertionDefaultsHandler(func(defaults AssertionDefaults) func() {
+		if defaults.EventuallyTimeout > 0 {
+			SetDefaultEventuallyTimeout(defaults.EventuallyTimeout)
+		}
+		if defaults.EventuallyPollingInterval > 0 {
+			SetDefaultEventuallyPollingInterval(defaults.EventuallyPollingInterval)
+		}
+		return func() {
+			SetDefaultEventuallyTimeout(time.Second)
+			SetDefaultEventuallyPollingInterval(10 * time.Millisecond)
+		}
+	})
+
+The handler is only called for specs that declare (or inherit) AssertionDefaults.  Registering a second handler replaces the first.
+
+RegisterAssertionDefaultsHandler returns true so that it can be called at the top-level of your suite.
+
+You can learn more here: https://onsi.github.io/ginkgo/#setting-assertion-defaults-assertiondefaults
+*/
+func RegisterAssertionDefaultsHandler(handler AssertionDefaultsHandler) bool {
+	global.Suite.RegisterAssertionDefaultsHandler(handler)
+	return true
+}
+
+/*
+SetAssertionDefaultsForLabel sets the AssertionDefaults for every spec labelled with label - so your timing policy can live alongside your spec taxonomy:
+
+	var _ = SetAssertionDefaultsForLabel("slow", AssertionDefaults{EventuallyTimeout: 5 * time.Minute})
+
+Labels are matched case-insensitively.  If a spec has several labels with defaults, the defaults set last win.  AssertionDefaults decorators on the spec and its containers take precedence over label defaults.
+
+SetAssertionDefaultsForLabel returns true so that it can be called at the top-level of your suite.
+
+You can learn more here: https://onsi.github.io/ginkgo/#setting-assertion-defaults-assertiondefaults
+*/
+func SetAssertionDefaultsForLabel(label string, defaults AssertionDefaults) bool {
+	global.Suite.SetAssertionDefaultsForLabel(label, defaults, types.NewCodeLocation(1))
+	return true
+}
+
+/*
+GinkgoWarn records a non-fatal warning on the current spec.  Use it for conditions that should not fail the spec but that must not go unnoticed - for example, the use of a deprecated fixture or a degraded test environment.
+
+Warnings are recorded in the spec's SpecReport.Warnings and are summarized at the end of the suite by Ginkgo's console reporter and included in the JSON, JUnit, and Teamcity reports.
+
+You can call GinkgoWarn in any Setup or Subject node closure.  Pass callerSkip to attribute the warning to a caller further up the stack (e.g. when calling GinkgoWarn from a helper).
+
+You can learn more about warnings here: https://onsi.github.io/ginkgo/#emitting-warnings
+*/
+func GinkgoWarn(message string, callerSkip ...int) {
+	skip := 0
+	if len(callerSkip) > 0 {
+		skip = callerSkip[0]
+	}
+
+	cl := types.NewCodeLocation(skip + 1)
+	err := global.Suite.AddWarning(types.Warning{Message: message, Location: cl})
+	if err != nil {
+		Fail(fmt.Sprintf("Failed to emit warning:\n%s", err.Error()), skip+1)
+	}
+}
+
 /*
 AbortSuite instructs Ginkgo to fail the current spec and skip all subsequent specs, thereby aborting the suite.
 
@@ -353,6 +819,29 @@ func AbortSuite(message string, callerSkip ...int) {
 	panic(types.GinkgoErrors.UncaughtGinkgoPanic(cl))
 }
 
+/*
+GinkgoHelper marks the function it is called in as a test helper - it is Ginkgo's analog of testing.T.Helper.  When a failure occurs
+in a helper (because it calls Fail or makes a failing Gomega assertion) Ginkgo skips over the helper when it works out where the failure
+occurred and reports the failure at the line that called the helper:
+
+	func expectValidBook(book *books.Book) {
+		GinkgoHelper()
+		Expect(book.Title).NotTo(BeEmpty())
+		Expect(book.Author).NotTo(BeEmpty())
+	}
+
+	It("loads the book", func() {
+		expectValidBook(library.Load("Les Miserables")) // failures are reported here
+	})
+
+Helpers can call other helpers.  GinkgoHelper is a simpler alternative to passing a callerSkip to Fail or an offset to Gomega.
+
+You can learn more here: https://onsi.github.io/ginkgo/#marking-helper-functions-ginkgohelper
+*/
+func GinkgoHelper() {
+	types.MarkAsHelper(1)
+}
+
 /*
 GinkgoRecover should be deferred at the top of any spawned goroutine that (may) call `Fail`
 Since Gomega assertions call fail, you should throw a `defer GinkgoRecover()` at the top of any goroutine that
@@ -372,9 +861,51 @@ func GinkgoRecover() {
 	}
 }
 
+/*
+GinkgoRecoverWith behaves like GinkgoRecover but first passes the recovered value to handler.  Like GinkgoRecover it
+must be deferred at the top of the spawned goroutine:
+
+	go func() {
+		defer GinkgoRecoverWith(func(recovered interface{}) {
+			AddReportEntry("worker", workerID)
+		})
+		...
+	}()
+
+The handler can observe the recovered value, attach context to the spec (e.g. with AddReportEntry), or convert the panic into
+a friendlier failure:
+
+  - if handler returns normally, Ginkgo records the original panic.
+  - if handler calls Fail (or makes a failing Gomega assertion), Ginkgo records that failure instead of the panic.
+  - if handler panics, Ginkgo records the value handler panicked with - this allows you to augment the recovered value.
+
+The handler is only called if a panic occurred.  GinkgoRecoverWith is useful for framework authors wrapping worker pools.
+
+You can learn more about how Ginkgo manages failures here: https://onsi.github.io/ginkgo/#mental-model-how-ginkgo-handles-failure
+*/
+func GinkgoRecoverWith(handler func(recovered interface{})) {
+	e := recover()
+	if e == nil {
+		return
+	}
+	cl := types.NewCodeLocationWithStackTrace(1)
+	defer func() {
+		if augmented := recover(); augmented != nil {
+			e = augmented
+		}
+		//this is a no-op if the handler has already recorded a failure
+		global.Failer.Panic(cl, e)
+	}()
+	handler(e)
+}
+
 // pushNode is used by the various test construction DSL methods to push nodes onto the suite
 // it handles returned errors, emits a detailed error message to help the user learn what they may have done wrong, then exits
 func pushNode(node internal.Node, errors []error) bool {
+	if len(errors) > 0 && global.Suite.RecordNodeErrors(errors) {
+		// the invalid node is dropped - Ginkgo reports its errors, along with any others, once the tree has been built
+		return true
+	}
 	exitIfErrors(errors)
 	exitIfErr(global.Suite.PushNode(node))
 	return true
@@ -497,17 +1028,53 @@ func By(text string, callback ...func()) {
 	}
 }
 
+/*
+Step runs body as a named step of the current spec.
+
+Unlike By, which simply documents a spec, Steps are recorded in the spec's SpecReport (see SpecReport.Steps) along with their outcome, duration, and nesting.
+This allows long workflow specs to produce structured, step-level results in Ginkgo's machine-readable reports:
+
+	It("can check a book out", func() {
+		Step("log in", func() {
+			...
+		})
+		Step("check the book out", func() {
+			Step("find the book", func() { ... })
+			Step("confirm the loan", func() { ... })
+		})
+	})
+
+Step must be called within a Setup or Subject node (It, BeforeEach, etc...).  If body fails (or panics) the Step is marked as failed and the failure goes on to fail the spec as usual.
+Steps that are still running when the spec ends (e.g. because the spec timed out) take on the spec's state.
+
+You can learn more about Step here: https://onsi.github.io/ginkgo/#structuring-long-specs-step
+*/
+func Step(text string, body func()) {
+	cl := types.NewCodeLocation(1)
+	formatter := formatter.NewWithNoColorBool(reporterConfig.NoColor)
+	GinkgoWriter.Println(formatter.F("{{bold}}STEP:{{/}} %s {{gray}}%s{{/}}", text, time.Now().Format(types.GINKGO_TIME_FORMAT)))
+	err := global.Suite.RunStep(text, cl, body)
+	if err != nil {
+		Fail(fmt.Sprintf("Failed to run Step:\n%s", err.Error()), 1)
+	}
+}
+
 /*
 BeforeSuite nodes are suite-level Setup nodes that run just once before any specs are run.
 When running in parallel, each parallel process will call BeforeSuite.
 
-You may only register *one* BeforeSuite handler per test suite.  You typically do so in your bootstrap file at the top level.
+You typically register BeforeSuite in your bootstrap file at the top level.  You may register multiple BeforeSuite nodes (e.g. from shared helper packages) - use the Order decorator to control
+the sequence in which they run.
 
 You cannot nest any other Ginkgo nodes within a BeforeSuite node's closure.
+BeforeSuite can be decorated with NodeTimeout to fail it, rather than hang the suite, if it does not complete in time.
+body may be a func(), or a func(SpecContext) or func(context.Context) whose context is cancelled if the node times out or the suite is interrupted.
 You can learn more here: https://onsi.github.io/ginkgo/#suite-setup-and-cleanup-beforesuite-and-aftersuite
 */
-func BeforeSuite(body func()) bool {
-	return pushNode(internal.NewNode(deprecationTracker, types.NodeTypeBeforeSuite, "", body))
+func BeforeSuite(body interface{}, args ...interface{}) bool {
+	combinedArgs := []interface{}{body}
+	combinedArgs = append(combinedArgs, args...)
+	return pushNode(internal.NewNode(deprecationTracker, types.NodeTypeBeforeSuite, "", combinedArgs...))
 }
 
 /*
@@ -516,13 +1083,34 @@ AfterSuite node closures always run, even if Ginkgo receives an interrupt signal
 
 When running in parallel, each parallel process will call AfterSuite.
 
-You may only register *one* AfterSuite handler per test suite.  You typically do so in your bootstrap file at the top level.
+You typically register AfterSuite in your bootstrap file at the top level.  You may register multiple AfterSuite nodes (e.g. from shared helper packages) - use the Order decorator to control
+the sequence in which they run.
 
 You cannot nest any other Ginkgo nodes within an AfterSuite node's closure.
+AfterSuite can be decorated with NodeTimeout to fail it, rather than hang the suite, if it does not complete in time.
+body may be a func(), or a func(SpecContext) or func(context.Context) whose context is cancelled if the node times out or the suite is interrupted.
 You can learn more here: https://onsi.github.io/ginkgo/#suite-setup-and-cleanup-beforesuite-and-aftersuite
 */
-func AfterSuite(body func()) bool {
-	return pushNode(internal.NewNode(deprecationTracker, types.NodeTypeAfterSuite, "", body))
+func AfterSuite(body interface{}, args ...interface{}) bool {
+	combinedArgs := []interface{}{body}
+	combinedArgs = append(combinedArgs, args...)
+	return pushNode(internal.NewNode(deprecationTracker, types.NodeTypeAfterSuite, "", combinedArgs...))
+}
+
+/*
+ReadinessGate nodes are suite-level nodes that hold off the suite until the environment is ready.  Before BeforeSuite runs, and before any specs run,
+Ginkgo will repeatedly invoke check until it returns nil.  Each unsuccessful attempt is logged to the GinkgoWriter and Ginkgo periodically emits a progress report
+so that you can see what the suite is waiting on without -v.
+
+If check does not return nil within timeout the ReadinessGate fails and Ginkgo skips BeforeSuite, all specs, and AfterSuite.
+
+You may register multiple ReadinessGates per suite; they are run in the order they are defined.  When running in parallel, each parallel process will run every ReadinessGate.
+
+ReadinessGates must be called at the top-level of the suite.
+You can learn more here: https://onsi.github.io/ginkgo/#waiting-for-the-environment-readinessgate
+*/
+func ReadinessGate(check func() error, timeout time.Duration) bool {
+	return pushNode(internal.NewReadinessGateNode(check, timeout, types.NewCodeLocation(1)))
 }
 
 /*
@@ -544,8 +1132,8 @@ The byte array returned by the first function is then passed to the second funct
 You cannot nest any other Ginkgo nodes within an SynchronizedBeforeSuite node's closure.
 You can learn more, and see some examples, here: https://onsi.github.io/ginkgo/#parallel-suite-setup-and-cleanup-synchronizedbeforesuite-and-synchronizedaftersuite
 */
-func SynchronizedBeforeSuite(process1Body func() []byte, allProcessBody func([]byte)) bool {
-	return pushNode(internal.NewSynchronizedBeforeSuiteNode(process1Body, allProcessBody, types.NewCodeLocation(1)))
+func SynchronizedBeforeSuite(process1Body func() []byte, allProcessBody func([]byte), args ...interface{}) bool {
+	return pushNode(internal.NewSynchronizedBeforeSuiteNode(process1Body, allProcessBody, types.NewCodeLocation(1), args...))
 }
 
 /*
@@ -561,8 +1149,8 @@ Note that you can also use DeferCleanup() in SynchronizedBeforeSuite to accompli
 You cannot nest any other Ginkgo nodes within an SynchronizedAfterSuite node's closure.
 You can learn more, and see some examples, here: https://onsi.github.io/ginkgo/#parallel-suite-setup-and-cleanup-synchronizedbeforesuite-and-synchronizedaftersuite
 */
-func SynchronizedAfterSuite(allProcessBody func(), process1Body func()) bool {
-	return pushNode(internal.NewSynchronizedAfterSuiteNode(allProcessBody, process1Body, types.NewCodeLocation(1)))
+func SynchronizedAfterSuite(allProcessBody func(), process1Body func(), args ...interface{}) bool {
+	return pushNode(internal.NewSynchronizedAfterSuiteNode(allProcessBody, process1Body, types.NewCodeLocation(1), args...))
 }
 
 /*
@@ -610,6 +1198,51 @@ func JustAfterEach(args ...interface{}) bool {
 	return pushNode(internal.NewNode(deprecationTracker, types.NodeTypeJustAfterEach, "", args...))
 }
 
+/*
+AroundEach nodes wrap each spec in their container.  The body is passed a runSpec function that runs the entire spec - its BeforeEach, JustBeforeEach, It, JustAfterEach, and AfterEach closures (and any DeferCleanups) - and returns when the spec is done.
+This makes AroundEach a natural home for cross-cutting concerns like tracing spans, database transactions, or instrumentation:
+
+	AroundEach(func(runSpec func()) {
+		span := tracer.Start(CurrentSpecReport().FullText())
+		defer span.End()
+		runSpec()
+	})
+
+When multiple AroundEach nodes are defined in nested Container nodes the outermost AroundEach wraps the innermost.  The body must call runSpec exactly once - the spec fails if it returns without doing so.
+AroundEach nodes are not interrupted directly; when the suite is interrupted the spec's own nodes are interrupted and runSpec returns.
+
+You cannot nest any other Ginkgo nodes within an AroundEach node's closure.
+You can learn more here: https://onsi.github.io/ginkgo/#wrapping-specs-aroundeach
+*/
+func AroundEach(body func(runSpec func())) bool {
+	return pushNode(internal.NewAroundEachNode(body, types.NewCodeLocation(1)))
+}
+
+/*
+RegisterGlobalNode registers a BeforeEach, JustBeforeEach, AfterEach, or JustAfterEach node that applies to every spec in the suite - regardless of where RegisterGlobalNode is called.
+This allows shared infrastructure packages to hook into the lifecycle of every spec without requiring each container to call a helper:
+
+	func init() {
+		RegisterGlobalNode(types.NodeTypeBeforeEach, func() {
+			testdb.Reset()
+		})
+	}
+
+RegisterGlobalNode takes the same arguments (body and decorators) as the corresponding node.  Global nodes behave as though they were defined at the very top of the suite
+and run before any other top-level nodes of the same type.  RegisterGlobalNode must be called before the specs start running.
+
+You can learn more here: https://onsi.github.io/ginkgo/#global-setup-and-cleanup-registerglobalnode
+*/
+func RegisterGlobalNode(nodeType types.NodeType, args ...interface{}) bool {
+	if !nodeType.Is(types.NodeTypesThatCanBeRegisteredGlobally) {
+		exitIfErr(types.GinkgoErrors.InvalidGlobalNodeType(types.NewCodeLocation(1), nodeType))
+	}
+	node, errors := internal.NewNode(deprecationTracker, nodeType, "", args...)
+	exitIfErrors(errors)
+	exitIfErr(global.Suite.PushGlobalNode(node))
+	return true
+}
+
 /*
 BeforeAll nodes are Setup nodes that can occur inside Ordered contaienrs.  They run just once before any specs in the Ordered container run.
 
@@ -657,6 +1290,8 @@ When DeferCleanup is called in BeforeEach, JustBeforeEach, It, AfterEach, or Jus
 When DeferCleanup is called in BeforeAll or AfterAll the registered callback will be invoked when the ordered container completes (i.e. it will behave like an AfterAll node)
 When DeferCleanup is called in BeforeSuite, SynchronizedBeforeSuite, AfterSuite, or SynchronizedAfterSuite the registered callback will be invoked when the suite completes (i.e. it will behave like an AfterSuite node)
 
+Cleanup callbacks run in LIFO order.  You can pass DeferCleanup a CleanupPriority to change this - see CleanupPriority for details.
+
 Note that DeferCleanup does not represent a node but rather dynamically generates the appropriate type of cleanup node based on the context in which it is called.  As such you must call DeferCleanup within a Setup or Subject node, and not within a Container node.
 You can learn more about DeferCleanup here: https://onsi.github.io/ginkgo/#cleaning-up-our-cleanup-code-defercleanup
 */
@@ -666,3 +1301,140 @@ func DeferCleanup(args ...interface{}) {
 	}
 	pushNode(internal.NewCleanupNode(fail, args...))
 }
+
+/*
+CleanupPriority can be passed to DeferCleanup (or RegisterResource) to control when the cleanup callback runs relative to other cleanup callbacks.
+
+Callbacks with a higher priority run after callbacks with a lower priority.  Callbacks with the same priority run in LIFO order.  Callbacks registered
+without a CleanupPriority have priority 0.  This allows shared helpers to ensure their cleanup runs after the cleanup registered by the spec that uses them:
+
+	func StartDatabase() *DB {
+		db := startDatabase()
+		DeferCleanup(CleanupPriority(10), db.Stop) // runs after the spec's cleanup - even cleanup registered later in the spec
+		return db
+	}
+
+Priorities only order callbacks that run at the same point in the spec's lifecycle (e.g. the callbacks registered in a spec's BeforeEach and It nodes).
+
+You can learn more here: https://onsi.github.io/ginkgo/#ordering-cleanup-cleanuppriority
+*/
+type CleanupPriority = internal.CleanupPriority
+
+/*
+OnProcess1 can be passed to DeferCleanup in a suite-level node (BeforeSuite, SynchronizedBeforeSuite, AfterSuite, or SynchronizedAfterSuite) to run the
+cleanup only on parallel process #1, after all the other processes have finished.  This mirrors the semantics of SynchronizedAfterSuite's second function
+without having to write a SynchronizedAfterSuite:
+
+	var _ = SynchronizedBeforeSuite(func() []byte {
+		...
+	}, func(address []byte) {
+		client = db.Connect(string(address))
+		DeferCleanup(OnProcess1, client.DropAllNamespaces) // runs once - on process #1 - after every process is done with the database
+	})
+
+When running in series OnProcess1 has no effect.
+
+You can learn more here: https://onsi.github.io/ginkgo/#process-1-only-cleanup-onprocess1
+*/
+const OnProcess1 = internal.OnProcess1
+
+/*
+Resource describes an externally created resource (e.g. a cloud VM or a Kubernetes namespace) registered with RegisterResource
+*/
+type Resource = types.Resource
+
+/*
+RegisterResource registers an externally created resource along with the callback that cleans it up.  The callback is invoked exactly like
+a callback passed to DeferCleanup - it can return an error and take arguments:
+
+	It("provisions a namespace", func() {
+		name := createNamespace()
+		RegisterResource(Resource{Kind: "namespace", Name: name, SweepCommand: []string{"kubectl", "delete", "namespace", name}}, deleteNamespace, name)
+		...
+	})
+
+When the suite is run with --resource-registry=PATH, Ginkgo records the resource in the registry when it is registered and marks it as released once the cleanup
+callback succeeds.  If the run crashes or is killed before the resource is cleaned up, run `ginkgo sweep PATH` to run the SweepCommand of every orphaned resource.
+
+You can learn more about RegisterResource here: https://onsi.github.io/ginkgo/#sweeping-orphaned-resources
+*/
+func RegisterResource(resource Resource, args ...interface{}) {
+	failed := false
+	fail := func(message string, cl types.CodeLocation) {
+		failed = true
+		global.Failer.Fail(message, cl)
+	}
+	node, errors := internal.NewCleanupNode(fail, args...)
+	if len(errors) == 0 {
+		cleanup, cl := node.Body, node.CodeLocation
+		node.Body = func() {
+			cleanup()
+			if failed {
+				return
+			}
+			if err := global.Suite.ReleaseResource(resource, cl); err != nil {
+				global.Failer.Fail(err.Error(), cl)
+			}
+		}
+	}
+	pushNode(node, errors)
+	if err := global.Suite.RegisterResource(resource, node.CodeLocation); err != nil {
+		global.Failer.Fail(err.Error(), node.CodeLocation)
+	}
+}
+
+/*
+SpecContext is the context passed to goroutines launched with GinkgoGo and to It, BeforeEach, AfterEach, BeforeSuite (and other setup and subject nodes)
+when they are passed a func(SpecContext) or func(context.Context).  It is a context.Context that also provides access to the current SpecReport.
+
+For goroutines launched with GinkgoGo the context is cancelled when a DeferCleanup registered alongside the goroutine would run.  For nodes, the context
+is cancelled when the node times out or the suite is interrupted - Ginkgo then waits up to --grace-period for the node to return.
+
+You can learn more here: https://onsi.github.io/ginkgo/#spec-contexts-and-cancellation
+*/
+type SpecContext = internal.SpecContext
+
+/*
+GinkgoGo launches body in a goroutine that has GinkgoRecover pre-installed and whose lifetime is tied to the current spec.
+
+GinkgoGo registers a cleanup, just like DeferCleanup: when that cleanup runs (e.g. after the AfterEach nodes for a GinkgoGo
+called in a BeforeEach or It) Ginkgo cancels the goroutine's SpecContext and waits for the goroutine to exit.  If it does
+not exit within the grace period (one second by default, override it by passing in a time.Duration) Ginkgo fails the spec and reports where the leaked goroutine was launched.  This prevents goroutines that make assertions from
+outliving their spec and causing flakey failures in unrelated specs.
+
+	It("processes events in the background", func() {
+		GinkgoGo(func(ctx SpecContext) {
+			for {
+				select {
+				case event := <-events:
+					Expect(event.Valid()).To(BeTrue())
+				case <-ctx.Done():
+					return
+				}
+			}
+		})
+		...
+	})
+
+Like DeferCleanup, GinkgoGo must be called within a setup or subject node.  The goroutine runs concurrently with the spec so ctx.SpecReport()
+returns a snapshot of the spec's report taken when GinkgoGo was called.
+
+You can learn more about GinkgoGo here: https://onsi.github.io/ginkgo/#managing-background-goroutines-ginkgogo
+*/
+func GinkgoGo(body func(SpecContext), gracePeriod ...time.Duration) {
+	grace := internal.DefaultGinkgoGoGracePeriod
+	if len(gracePeriod) > 0 {
+		grace = gracePeriod[0]
+	}
+	cl := types.NewCodeLocation(1)
+	var goroutine *internal.GinkgoGoroutine
+	fail := func(message string, cl types.CodeLocation) {
+		global.Failer.Fail(message, cl)
+	}
+	pushNode(internal.NewCleanupNode(fail, cl, func() {
+		if !goroutine.Stop(grace) {
+			fail(goroutine.LeakMessage(grace), goroutine.CodeLocation)
+		}
+	}))
+	goroutine = internal.StartGinkgoGoroutine(global.Suite, cl, body, GinkgoRecover)
+}
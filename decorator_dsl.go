@@ -1,7 +1,10 @@
 package ginkgo
 
 import (
+	"time"
+
 	"github.com/onsi/ginkgo/v2/internal"
+	"github.com/onsi/ginkgo/v2/types"
 )
 
 /*
@@ -20,6 +23,24 @@ You can learn more about decorators here: https://onsi.github.io/ginkgo/#decorat
 */
 type FlakeAttempts = internal.FlakeAttempts
 
+/*
+Retry is a decorator that allows you to retry a flaky spec in-place with exponential backoff.  Ginkgo will run the spec up to `attempts` times until it passes,
+waiting `backoff` before the first retry and doubling the wait before each subsequent retry, up to a maximum of ten minutes.  The outcome of every attempt is recorded in SpecReport.Attempts.
+
+Retry can only be applied to It nodes.  --flake-attempts overrides the number of attempts but the backoff still applies.
+
+You can learn more here: https://onsi.github.io/ginkgo/#retrying-specs-with-backoff
+You can learn more about decorators here: https://onsi.github.io/ginkgo/#decorator-reference
+*/
+func Retry(attempts uint, backoff time.Duration) RetryPolicy {
+	return RetryPolicy{Attempts: int(attempts), Backoff: backoff}
+}
+
+/*
+RetryPolicy is the type for the Retry decorator.  Use Retry(attempts, backoff) to construct a RetryPolicy.
+*/
+type RetryPolicy = internal.RetryPolicy
+
 /*
 Focus is a decorator that allows you to mark a spec or container as focused.  Identical to FIt and FDescribe.
 
@@ -64,6 +85,77 @@ You can learn more about decorators here: https://onsi.github.io/ginkgo/#decorat
 */
 const OncePerOrdered = internal.OncePerOrdered
 
+/*
+Order(int) is a decorator that controls the sequence in which multiple BeforeSuite and AfterSuite nodes run.  Nodes run in ascending Order -
+nodes with the same Order (the default is 0) run in the order in which they were registered.
+
+Order can only be applied to BeforeSuite, AfterSuite, SynchronizedBeforeSuite, and SynchronizedAfterSuite nodes.
+
+You can learn more here: https://onsi.github.io/ginkgo/#multiple-suite-setup-and-cleanup-nodes-the-order-decorator
+You can learn more about decorators here: https://onsi.github.io/ginkgo/#decorator-reference
+*/
+type Order = internal.Order
+
+/*
+SpecPriority(int) is a decorator that allows you to run some specs before others.  Specs with a higher SpecPriority run first - specs without
+a SpecPriority have priority 0 - and specs with the same priority are randomized as usual.  The innermost SpecPriority in a spec's hierarchy wins.
+
+SpecPriority can be applied to container and subject nodes.  It is most useful with --time-box, which runs the highest priority specs first and
+stops starting specs once the time box is exhausted.
+
+You can learn more here: https://onsi.github.io/ginkgo/#prioritizing-specs-specpriority
+You can learn more about decorators here: https://onsi.github.io/ginkgo/#decorator-reference
+*/
+type SpecPriority = internal.SpecPriority
+
+/*
+VerboseOutput is a decorator that allows you to mark a spec or container as verbose.  Ginkgo's console reporter emits these specs, and
+their GinkgoWriter output, as though -v had been set - even when the rest of the suite runs with the default verbosity.
+
+You can learn more here: https://onsi.github.io/ginkgo/#overriding-reporting-for-a-subtree
+You can learn more about decorators here: https://onsi.github.io/ginkgo/#decorator-reference
+*/
+const VerboseOutput = internal.VerboseOutput
+
+/*
+NoCapture is a decorator that allows you to mark a spec or container so that its GinkgoWriter output is streamed to stdout as it is written
+instead of only being emitted when the spec fails.  NoCapture has no effect when running in parallel.
+
+You can learn more here: https://onsi.github.io/ginkgo/#overriding-reporting-for-a-subtree
+You can learn more about decorators here: https://onsi.github.io/ginkgo/#decorator-reference
+*/
+const NoCapture = internal.NoCapture
+
+/*
+SlowSpecThreshold decorates specs and containers with a threshold that overrides --slow-spec-threshold.  Passing specs that take
+longer than the threshold are marked as [SLOW TEST] by Ginkgo's console reporter.  The innermost SlowSpecThreshold in a spec's hierarchy wins.
+
+You can learn more here: https://onsi.github.io/ginkgo/#overriding-reporting-for-a-subtree
+You can learn more about decorators here: https://onsi.github.io/ginkgo/#decorator-reference
+*/
+type SlowSpecThreshold = internal.SlowSpecThreshold
+
+/*
+PollProgressAfter decorates specs and containers with a threshold that overrides --poll-progress-after.  When a node in the spec runs for
+longer than the threshold Ginkgo emits a progress report - including the stack of the goroutine running the node - and emits another each
+time the threshold elapses until the node completes.  The innermost PollProgressAfter in a spec's hierarchy wins.
+
+You can learn more here: https://onsi.github.io/ginkgo/#getting-progress-reports-for-slow-specs-pollprogressafter
+You can learn more about decorators here: https://onsi.github.io/ginkgo/#decorator-reference
+*/
+type PollProgressAfter = internal.PollProgressAfter
+
+/*
+CPUTimeLimit decorates specs and containers with a CPU time limit that overrides --cpu-time-limit.  Ginkgo measures the CPU time (user and system time,
+via getrusage) the process uses while each spec runs - including its setup and cleanup nodes - and fails specs that pass but use more than the limit.
+Unlike NodeTimeout, which limits wall-clock time, CPUTimeLimit catches accidental busy-loops that only show up as slowness on loaded CI machines.
+The innermost CPUTimeLimit in a spec's hierarchy wins.  CPU time is not measured, and the limit is not enforced, on Windows.
+
+You can learn more here: https://onsi.github.io/ginkgo/#limiting-cpu-time-cputimelimit
+You can learn more about decorators here: https://onsi.github.io/ginkgo/#decorator-reference
+*/
+type CPUTimeLimit = internal.CPUTimeLimit
+
 /*
 Label decorates specs with Labels.  Multiple labels can be passed to Label and these can be arbitrary strings but must not include the following characters: "&|!,()/".
 Labels can be applied to container and subject nodes, but not setup nodes.  You can provide multiple Labels to a given node and a spec's labels is the union of all labels in its node hierarchy.
@@ -80,3 +172,181 @@ Labels are the type for spec Label decorators.  Use Label(...) to construct Labe
 You can learn more here: https://onsi.github.io/ginkgo/#spec-labels
 */
 type Labels = internal.Labels
+
+/*
+Phase decorates containers and specs with the suite-level execution phase they belong to.  The phases a suite supports, and the order they run in, are declared by passing a PhaseOrder to RunSpecs.
+
+All specs in a phase complete - across all parallel processes - before any spec in the next phase begins.  Specs within a phase are randomized and parallelized as usual.  Specs that are not decorated with Phase run before all declared phases.
+Phase can be applied to container and subject nodes, but not setup nodes.  The innermost Phase in a spec's node hierarchy wins.  Phase cannot be used within an Ordered container - decorate the Ordered container instead.
+
+You can learn more here: https://onsi.github.io/ginkgo/#execution-phases
+You can learn more about decorators here: https://onsi.github.io/ginkgo/#decorator-reference
+*/
+type Phase = internal.ExecutionPhase
+
+/*
+Requires decorates containers and specs with the host resources they need while they run:
+
+	It("trains the model", Requires(CPU(4), Memory("8Gi"), GPU(1)), func() { ... })
+
+When running in parallel Ginkgo will not start a spec if doing so would exceed the host's capacity (see --capacity-cpu, --capacity-memory, and --capacity-gpu).
+Requirements are recorded in the spec's report.  When requirements are declared at multiple levels of the hierarchy the largest requirement in each dimension wins.
+
+Requires also accepts named requirements - strings like "docker" or "postgres>=14" - that are checked by the RequirementChecker registered for them with RegisterRequirementChecker:
+
+	Describe("the repository", Requires("docker", "postgres>=14"), func() { ... })
+
+Specs whose named requirements are not met are skipped.
+
+You can learn more here: https://onsi.github.io/ginkgo/#declaring-resource-requirements
+and here: https://onsi.github.io/ginkgo/#skipping-specs-with-unmet-requirements
+You can learn more about decorators here: https://onsi.github.io/ginkgo/#decorator-reference
+*/
+func Requires(requirements ...interface{}) Requirements {
+	return Requirements(requirements)
+}
+
+/*
+Requirements are the resource requirements constructed by Requires.  Don't construct Requirements directly - use Requires instead.
+*/
+type Requirements = internal.Requirements
+
+// CPU is a resource requirement for a number of CPUs.  Pass it to Requires.
+type CPU = internal.CPU
+
+// Memory is a resource requirement for an amount of memory, e.g. Memory("512Mi") or Memory("8Gi").  Pass it to Requires.
+type Memory = internal.Memory
+
+// GPU is a resource requirement for a number of GPUs.  Pass it to Requires.
+type GPU = internal.GPU
+
+/*
+RequiresEnv declares the environment variables a container or spec needs in order to run:
+
+	Describe("the payments API", RequiresEnv("PAYMENTS_API_KEY", "PAYMENTS_URL"), func() { ... })
+
+Ginkgo does not set or check these variables.  The declarations are listed by --describe-suite so that tooling can discover what a precompiled suite needs before running it.
+
+You can learn more here: https://onsi.github.io/ginkgo/#describing-a-suite
+You can learn more about decorators here: https://onsi.github.io/ginkgo/#decorator-reference
+*/
+func RequiresEnv(names ...string) RequiredEnv {
+	return RequiredEnv(names)
+}
+
+/*
+RequiredEnv is the type for the RequiresEnv decorator.  Use RequiresEnv(...) to construct RequiredEnv.
+*/
+type RequiredEnv = internal.RequiredEnv
+
+/*
+Annotate attaches a piece of typed metadata to a container or spec:
+
+	Describe("checkout", Annotate("risk", "high"), Annotate("slo-tier", 1), func() { ... })
+
+Unlike Labels, annotations are key-value pairs whose values can be of any type.  They do not participate in filtering.  Instead, a spec's annotations
+(including those of its containers) are recorded in SpecReport.Annotations - so custom reporters, ReportAfterEach nodes, and tooling that reads JSON reports
+can make use of them.  Use AddSpecAnnotation to annotate the running spec at runtime.
+
+You can learn more here: https://onsi.github.io/ginkgo/#annotating-specs
+You can learn more about decorators here: https://onsi.github.io/ginkgo/#decorator-reference
+*/
+func Annotate(key string, value interface{}) Annotation {
+	return Annotation{Key: key, Value: value}
+}
+
+/*
+Annotation is the type for the Annotate decorator.  Use Annotate(key, value) to construct an Annotation.
+*/
+type Annotation = internal.Annotation
+
+/*
+WithEnv sets the environment variable key to value while the decorated node runs and restores the variable's previous value (or unsets it) afterwards:
+
+	Describe("running in production mode", WithEnv("APP_ENV", "production"), func() { ... })
+
+When applied to a container or a spec the variable is set for the entire duration of each spec - including all of its setup and cleanup nodes.
+When applied to a setup node (e.g. BeforeEach) or a suite setup node (e.g. BeforeSuite) the variable is only set while that node runs.
+More specific nodes win: a WithEnv on a spec overrides a WithEnv of the same variable on one of its containers.
+
+You can learn more here: https://onsi.github.io/ginkgo/#setting-environment-variables-withenv
+You can learn more about decorators here: https://onsi.github.io/ginkgo/#decorator-reference
+*/
+func WithEnv(key string, value string) EnvVar {
+	return EnvVar{Key: key, Value: value}
+}
+
+/*
+EnvVar is the type for the WithEnv decorator.  Use WithEnv(key, value) to construct an EnvVar.
+*/
+type EnvVar = internal.EnvVar
+
+/*
+SkipIf decorates containers and specs with a condition that Ginkgo evaluates just before each spec runs.  If the condition returns true the spec is skipped with the passed-in reason:
+
+	Describe("the docker integration", SkipIf(func() bool { _, err := exec.LookPath("docker"); return err != nil }, "docker is not installed"), func() { ... })
+
+Conditions are evaluated outermost container first, and for every spec - cache the result yourself if a condition is expensive to compute.  A condition that panics fails the spec.
+
+You can learn more here: https://onsi.github.io/ginkgo/#skipping-specs-conditionally-skipif
+You can learn more about decorators here: https://onsi.github.io/ginkgo/#decorator-reference
+*/
+func SkipIf(condition func() bool, reason string) SkipCondition {
+	return SkipCondition{Condition: condition, Reason: reason, CodeLocation: types.NewCodeLocation(1)}
+}
+
+/*
+SkipCondition is the type for the SkipIf decorator.  Use SkipIf(condition, reason) to construct a SkipCondition.
+*/
+type SkipCondition = internal.SkipCondition
+
+/*
+AssertionDefaults decorates containers and specs with the default timeouts and polling intervals their asynchronous assertions (e.g. Gomega's Eventually and Consistently) should use:
+
+	Describe("the provisioning API", AssertionDefaults{EventuallyTimeout: 2 * time.Minute, EventuallyPollingInterval: time.Second}, func() { ... })
+
+Ginkgo does not depend on your assertion library so it hands the defaults to the AssertionDefaultsHandler you register with RegisterAssertionDefaultsHandler.
+The handler is called just before each spec runs (its setup and cleanup nodes included) and the function it returns is called after the spec finishes.
+Zero-valued fields are left alone and more specific declarations win: a spec's AssertionDefaults override those of its containers, which override any set for its labels with SetAssertionDefaultsForLabel.
+
+You can learn more here: https://onsi.github.io/ginkgo/#setting-assertion-defaults-assertiondefaults
+You can learn more about decorators here: https://onsi.github.io/ginkgo/#decorator-reference
+*/
+type AssertionDefaults = internal.AssertionDefaults
+
+/*
+NodeTimeout decorates subject nodes, setup nodes, and BeforeSuite, AfterSuite, SynchronizedBeforeSuite, and SynchronizedAfterSuite nodes with a timeout.  If the node does not complete
+within the timeout, Ginkgo marks it as timed out - reporting the stack traces of all running goroutines - and moves on instead of hanging until the suite's --timeout elapses.
+For the Synchronized variants the timeout applies to each function independently.  Nodes that accept a SpecContext see it cancelled when the timeout elapses.
+
+You can set a default timeout for all suite setup and cleanup nodes with --suite-node-timeout.  NodeTimeout takes precedence over the default.
+
+You can learn more here: https://onsi.github.io/ginkgo/#spec-timeouts
+You can learn more about decorators here: https://onsi.github.io/ginkgo/#decorator-reference
+*/
+type NodeTimeout = internal.NodeTimeout
+
+/*
+PhaseOrder declares the suite's execution phases in the order they should run.  Pass it to RunSpecs:
+
+	RunSpecs(t, "Integration Suite", PhaseOrder{"provision", "test", "teardown"})
+
+You can learn more here: https://onsi.github.io/ginkgo/#execution-phases
+*/
+type PhaseOrder = internal.PhaseOrder
+
+/*
+SpecNamingRules declares the naming conventions the texts of the suite's Its and containers must follow.  Pass it to RunSpecs:
+
+	RunSpecs(t, "Books Suite", SpecNamingRules{
+		ItPattern:        `^should `,
+		ContainerPattern: `^[A-Z]`,
+		MaxLength:        80,
+	})
+
+Ginkgo checks every It (including the Its generated by table entries) and container while it builds the spec tree.  If any of them break the rules
+Ginkgo exits with an error listing each violation and its location - without running any specs.
+
+You can learn more here: https://onsi.github.io/ginkgo/#enforcing-spec-naming-conventions
+*/
+type SpecNamingRules = internal.SpecNamingRules
@@ -17,22 +17,53 @@ import (
 )
 
 const GINKGO_VERSION = ginkgo.GINKGO_VERSION
+const OnProcess1 = ginkgo.OnProcess1
 
 type GinkgoWriterInterface = ginkgo.GinkgoWriterInterface
 type GinkgoTestingT = ginkgo.GinkgoTestingT
 type GinkgoTInterface = ginkgo.GinkgoTInterface
+type SpecContext = ginkgo.SpecContext
+type StopTryingSignal = ginkgo.StopTryingSignal
+type PhaseOrder = ginkgo.PhaseOrder
+type SpecNamingRules = ginkgo.SpecNamingRules
+type Resource = ginkgo.Resource
+type CleanupPriority = ginkgo.CleanupPriority
+type RequirementChecker = ginkgo.RequirementChecker
+type HealthCheck = ginkgo.HealthCheck
+type PreflightCheck = ginkgo.PreflightCheck
+type AssertionDefaultsHandler = ginkgo.AssertionDefaultsHandler
 
 var GinkgoWriter = ginkgo.GinkgoWriter
+var GinkgoWriterWithPrefix = ginkgo.GinkgoWriterWithPrefix
 var GinkgoConfiguration = ginkgo.GinkgoConfiguration
 var GinkgoRandomSeed = ginkgo.GinkgoRandomSeed
 var GinkgoParallelProcess = ginkgo.GinkgoParallelProcess
+var GinkgoDeadline = ginkgo.GinkgoDeadline
 var PauseOutputInterception = ginkgo.PauseOutputInterception
 var ResumeOutputInterception = ginkgo.ResumeOutputInterception
+var RedactInterceptedOutput = ginkgo.RedactInterceptedOutput
+var SuppressInterceptedOutput = ginkgo.SuppressInterceptedOutput
+var GinkgoRedact = ginkgo.GinkgoRedact
+var GinkgoRedactPattern = ginkgo.GinkgoRedactPattern
 var RunSpecs = ginkgo.RunSpecs
 var Skip = ginkgo.Skip
+var SkipSuite = ginkgo.SkipSuite
 var Fail = ginkgo.Fail
+var GinkgoStopTrying = ginkgo.GinkgoStopTrying
+var FailWithPayload = ginkgo.FailWithPayload
+var FailWith = ginkgo.FailWith
+var RecordAssertion = ginkgo.RecordAssertion
+var RegisterFailureRenderer = ginkgo.RegisterFailureRenderer
+var RegisterRequirementChecker = ginkgo.RegisterRequirementChecker
+var RegisterHealthCheck = ginkgo.RegisterHealthCheck
+var RegisterPreflightCheck = ginkgo.RegisterPreflightCheck
+var RegisterAssertionDefaultsHandler = ginkgo.RegisterAssertionDefaultsHandler
+var SetAssertionDefaultsForLabel = ginkgo.SetAssertionDefaultsForLabel
+var GinkgoWarn = ginkgo.GinkgoWarn
 var AbortSuite = ginkgo.AbortSuite
+var GinkgoHelper = ginkgo.GinkgoHelper
 var GinkgoRecover = ginkgo.GinkgoRecover
+var GinkgoRecoverWith = ginkgo.GinkgoRecoverWith
 var Describe = ginkgo.Describe
 var FDescribe = ginkgo.FDescribe
 var PDescribe = ginkgo.PDescribe
@@ -44,16 +75,27 @@ var FIt = ginkgo.FIt
 var PIt = ginkgo.PIt
 var XIt = PIt
 var Specify, FSpecify, PSpecify, XSpecify = It, FIt, PIt, XIt
+var SharedExamples = ginkgo.SharedExamples
+var ItBehavesLike = ginkgo.ItBehavesLike
+var FItBehavesLike = ginkgo.FItBehavesLike
+var PItBehavesLike = ginkgo.PItBehavesLike
+var XItBehavesLike = PItBehavesLike
 var By = ginkgo.By
+var Step = ginkgo.Step
 var BeforeSuite = ginkgo.BeforeSuite
 var AfterSuite = ginkgo.AfterSuite
+var ReadinessGate = ginkgo.ReadinessGate
 var SynchronizedBeforeSuite = ginkgo.SynchronizedBeforeSuite
 var SynchronizedAfterSuite = ginkgo.SynchronizedAfterSuite
 var BeforeEach = ginkgo.BeforeEach
 var JustBeforeEach = ginkgo.JustBeforeEach
 var AfterEach = ginkgo.AfterEach
 var JustAfterEach = ginkgo.JustAfterEach
+var AroundEach = ginkgo.AroundEach
+var RegisterGlobalNode = ginkgo.RegisterGlobalNode
 var BeforeAll = ginkgo.BeforeAll
 var AfterAll = ginkgo.AfterAll
 var DeferCleanup = ginkgo.DeferCleanup
+var RegisterResource = ginkgo.RegisterResource
+var GinkgoGo = ginkgo.GinkgoGo
 var GinkgoT = ginkgo.GinkgoT
@@ -18,12 +18,37 @@ import (
 
 type Offset = ginkgo.Offset
 type FlakeAttempts = ginkgo.FlakeAttempts
+type RetryPolicy = ginkgo.RetryPolicy
 type Labels = ginkgo.Labels
+type Phase = ginkgo.Phase
+type NodeTimeout = ginkgo.NodeTimeout
+type SlowSpecThreshold = ginkgo.SlowSpecThreshold
+type PollProgressAfter = ginkgo.PollProgressAfter
+type CPUTimeLimit = ginkgo.CPUTimeLimit
+type Requirements = ginkgo.Requirements
+type CPU = ginkgo.CPU
+type Memory = ginkgo.Memory
+type GPU = ginkgo.GPU
+type RequiredEnv = ginkgo.RequiredEnv
+type Annotation = ginkgo.Annotation
+type SkipCondition = ginkgo.SkipCondition
+type Order = ginkgo.Order
+type SpecPriority = ginkgo.SpecPriority
+type EnvVar = ginkgo.EnvVar
+type AssertionDefaults = ginkgo.AssertionDefaults
 
 const Focus = ginkgo.Focus
 const Pending = ginkgo.Pending
 const Serial = ginkgo.Serial
 const Ordered = ginkgo.Ordered
 const OncePerOrdered = ginkgo.OncePerOrdered
+const VerboseOutput = ginkgo.VerboseOutput
+const NoCapture = ginkgo.NoCapture
 
 var Label = ginkgo.Label
+var Retry = ginkgo.Retry
+var Requires = ginkgo.Requires
+var RequiresEnv = ginkgo.RequiresEnv
+var Annotate = ginkgo.Annotate
+var SkipIf = ginkgo.SkipIf
+var WithEnv = ginkgo.WithEnv
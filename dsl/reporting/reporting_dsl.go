@@ -19,11 +19,16 @@ import (
 type Report = ginkgo.Report
 type SpecReport = ginkgo.SpecReport
 type ReportEntryVisibility = ginkgo.ReportEntryVisibility
+type FailureArtifactCollector = ginkgo.FailureArtifactCollector
+type NetworkCapturer = ginkgo.NetworkCapturer
 
 const ReportEntryVisibilityAlways, ReportEntryVisibilityFailureOrVerbose, ReportEntryVisibilityNever = ginkgo.ReportEntryVisibilityAlways, ginkgo.ReportEntryVisibilityFailureOrVerbose, ginkgo.ReportEntryVisibilityNever
 
 var CurrentSpecReport = ginkgo.CurrentSpecReport
 var AddReportEntry = ginkgo.AddReportEntry
+var AddSpecAnnotation = ginkgo.AddSpecAnnotation
+var RegisterFailureArtifactCollector = ginkgo.RegisterFailureArtifactCollector
+var RegisterNetworkCapture = ginkgo.RegisterNetworkCapture
 
 var ReportBeforeEach = ginkgo.ReportBeforeEach
 var ReportAfterEach = ginkgo.ReportAfterEach
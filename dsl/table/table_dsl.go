@@ -17,11 +17,16 @@ import (
 )
 
 type EntryDescription = ginkgo.EntryDescription
+type GoldenFiles = ginkgo.GoldenFiles
 
 var DescribeTable = ginkgo.DescribeTable
 var FDescribeTable = ginkgo.FDescribeTable
 var PDescribeTable = ginkgo.PDescribeTable
 var XDescribeTable = ginkgo.XDescribeTable
+var DescribeTableSubtree = ginkgo.DescribeTableSubtree
+var FDescribeTableSubtree = ginkgo.FDescribeTableSubtree
+var PDescribeTableSubtree = ginkgo.PDescribeTableSubtree
+var XDescribeTableSubtree = ginkgo.XDescribeTableSubtree
 
 type TableEntry = ginkgo.TableEntry
 
@@ -29,3 +34,12 @@ var Entry = ginkgo.Entry
 var FEntry = ginkgo.FEntry
 var PEntry = ginkgo.PEntry
 var XEntry = ginkgo.XEntry
+var SubtreeEntry = ginkgo.SubtreeEntry
+var FSubtreeEntry = ginkgo.FSubtreeEntry
+var PSubtreeEntry = ginkgo.PSubtreeEntry
+var XSubtreeEntry = ginkgo.XSubtreeEntry
+var EntriesFromJSON = ginkgo.EntriesFromJSON
+var EntriesFromCSV = ginkgo.EntriesFromCSV
+var EntriesFromFuzzCorpus = ginkgo.EntriesFromFuzzCorpus
+var CombinatorialEntries = ginkgo.CombinatorialEntries
+var PairwiseEntries = ginkgo.PairwiseEntries
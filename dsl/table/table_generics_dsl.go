@@ -0,0 +1,52 @@
+//go:build go1.21
+// +build go1.21
+
+package table
+
+import (
+	"github.com/onsi/ginkgo/v2"
+	"github.com/onsi/ginkgo/v2/types"
+)
+
+// generic functions can't be aliased, so these wrap the ginkgo package's generic tables.  They mark themselves as helpers so that the
+// tables and entries they construct are located at the caller's code
+
+type TypedEntry[T any] ginkgo.TypedEntry[T]
+
+func DescribeTableT[T any](description string, body func(T), entries ...TypedEntry[T]) bool {
+	types.MarkAsHelper()
+	return ginkgo.DescribeTableT(description, body, typedEntries(entries)...)
+}
+
+func FDescribeTableT[T any](description string, body func(T), entries ...TypedEntry[T]) bool {
+	types.MarkAsHelper()
+	return ginkgo.FDescribeTableT(description, body, typedEntries(entries)...)
+}
+
+func PDescribeTableT[T any](description string, body func(T), entries ...TypedEntry[T]) bool {
+	types.MarkAsHelper()
+	return ginkgo.PDescribeTableT(description, body, typedEntries(entries)...)
+}
+
+func EntryT[T any](description string, parameter T, decorators ...interface{}) TypedEntry[T] {
+	types.MarkAsHelper()
+	return TypedEntry[T](ginkgo.EntryT(description, parameter, decorators...))
+}
+
+func FEntryT[T any](description string, parameter T, decorators ...interface{}) TypedEntry[T] {
+	types.MarkAsHelper()
+	return TypedEntry[T](ginkgo.FEntryT(description, parameter, decorators...))
+}
+
+func PEntryT[T any](description string, parameter T, decorators ...interface{}) TypedEntry[T] {
+	types.MarkAsHelper()
+	return TypedEntry[T](ginkgo.PEntryT(description, parameter, decorators...))
+}
+
+func typedEntries[T any](entries []TypedEntry[T]) []ginkgo.TypedEntry[T] {
+	out := []ginkgo.TypedEntry[T]{}
+	for _, entry := range entries {
+		out = append(out, ginkgo.TypedEntry[T](entry))
+	}
+	return out
+}
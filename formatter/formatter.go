@@ -5,6 +5,7 @@ import (
 	"os"
 	"regexp"
 	"strings"
+	"unicode/utf8"
 )
 
 // ColorableStdOut and ColorableStdErr enable color output support on Windows
@@ -193,3 +194,69 @@ func (f Formatter) style(s string) string {
 
 	return ""
 }
+
+// asciiEquivalents maps the unicode glyphs commonly found in Ginkgo's output to ASCII.  Variation selectors and zero-width joiners (which are used to compose emoji) are dropped.
+var asciiEquivalents = map[rune]string{
+	'•': "+", '↺': "R", '…': "...",
+	'✓': "v", '✔': "v", '✗': "x", '✘': "x",
+	'→': "->", '←': "<-", '»': ">>", '«': "<<",
+	'–': "-", '—': "-", '‘': "'", '’': "'", '“': `"`, '”': `"`,
+	'\u00a0': " ", '\ufe0e': "", '\ufe0f': "", '\u200d': "",
+}
+
+// ASCII replaces the unicode glyphs commonly found in Ginkgo's output with ASCII equivalents and escapes any other non-ASCII characters (e.g. é becomes \u00e9)
+func ASCII(s string) string {
+	if isASCII(s) {
+		return s
+	}
+	out := &strings.Builder{}
+	for _, r := range s {
+		if r < utf8.RuneSelf {
+			out.WriteRune(r)
+		} else if equivalent, ok := asciiEquivalents[r]; ok {
+			out.WriteString(equivalent)
+		} else if r == utf8.RuneError {
+			out.WriteString("?")
+		} else if r <= 0xFFFF {
+			fmt.Fprintf(out, `\u%04x`, r)
+		} else {
+			fmt.Fprintf(out, `\U%08x`, r)
+		}
+	}
+	return out.String()
+}
+
+func isASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= utf8.RuneSelf {
+			return false
+		}
+	}
+	return true
+}
+
+// controlSequenceRe matches ANSI escape sequences and control characters other than newlines and tabs
+var controlSequenceRe = regexp.MustCompile(`\x1b\[[0-?]*[ -/]*[@-~]|\x1b\][^\x07\x1b]*(?:\x07|\x1b\\)?|\x1b[0-~]|[\x00-\x08\x0b-\x1f\x7f]`)
+
+// StripCursorControl removes carriage returns and any ANSI escape sequences - other than those that set colors and styles - from s.
+// This prevents captured output that moves the cursor or clears the screen (e.g. progress bars) from mangling logs.
+func StripCursorControl(s string) string {
+	if !hasControlCharacters(s) {
+		return s
+	}
+	return controlSequenceRe.ReplaceAllStringFunc(s, func(match string) string {
+		if strings.HasPrefix(match, "\x1b[") && strings.HasSuffix(match, "m") {
+			return match
+		}
+		return ""
+	})
+}
+
+func hasControlCharacters(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if (s[i] < ' ' && s[i] != '\n' && s[i] != '\t') || s[i] == 0x7f {
+			return true
+		}
+	}
+	return false
+}
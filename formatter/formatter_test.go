@@ -127,4 +127,28 @@ var _ = Describe("Formatter", func() {
 			))
 		})
 	})
+
+	Describe("ASCII", func() {
+		It("leaves ASCII strings - including color escape codes - alone", func() {
+			Ω(formatter.ASCII("\x1b[38;5;9mhello\x1b[0m")).Should(Equal("\x1b[38;5;9mhello\x1b[0m"))
+		})
+
+		It("replaces common glyphs with ASCII equivalents and escapes everything else", func() {
+			Ω(formatter.ASCII("• ↺ … ✓ ✗ → “quoted”")).Should(Equal(`+ R ... v x -> "quoted"`))
+			Ω(formatter.ASCII("café 🚀 👍🏽")).Should(Equal(`caf\u00e9 \U0001f680 \U0001f44d\U0001f3fd`))
+			Ω(formatter.ASCII("⚠️")).Should(Equal(`\u26a0`))
+		})
+	})
+
+	Describe("StripCursorControl", func() {
+		It("leaves color and style escape codes alone", func() {
+			Ω(formatter.StripCursorControl("\x1b[1m\x1b[38;5;9mhello\x1b[0m\n\tworld")).Should(Equal("\x1b[1m\x1b[38;5;9mhello\x1b[0m\n\tworld"))
+		})
+
+		It("strips carriage returns, cursor movement, and other control sequences", func() {
+			Ω(formatter.StripCursorControl("10%\r\x1b[2K50%\r\x1b[2K100%\r\n")).Should(Equal("10%50%100%\n"))
+			Ω(formatter.StripCursorControl("\x1b[1A\x1b[?25lhidden cursor\x1b[?25h\x1b7\x1b8")).Should(Equal("hidden cursor"))
+			Ω(formatter.StripCursorControl("\x1b]0;window title\x07bell\x07 back\bspace")).Should(Equal("bell backspace"))
+		})
+	})
 })
@@ -26,7 +26,7 @@ func BuildBuildCommand() command.Command {
 		Command: func(args []string, _ []string) {
 			var errors []error
 			cliConfig, goFlagsConfig, errors = types.VetAndInitializeCLIAndGoConfig(cliConfig, goFlagsConfig)
-			command.AbortIfErrors("Ginkgo detected configuration issues:", errors)
+			command.AbortIfConfigurationErrors(errors)
 
 			buildSpecs(args, cliConfig, goFlagsConfig)
 		},
@@ -1,6 +1,10 @@
 package command
 
-import "fmt"
+import (
+	"fmt"
+
+	"github.com/onsi/ginkgo/v2/types"
+)
 
 type AbortDetails struct {
 	ExitCode  int
@@ -59,3 +63,17 @@ func AbortIfErrors(preamble string, errors []error) {
 		})
 	}
 }
+
+func AbortIfConfigurationErrors(errors []error) {
+	if len(errors) > 0 {
+		out := ""
+		for _, err := range errors {
+			out += err.Error()
+		}
+		Abort(AbortDetails{
+			ExitCode:  types.GINKGO_CONFIGURATION_ERROR_EXIT_CODE,
+			Error:     fmt.Errorf("Ginkgo detected configuration issues:\n%s", out),
+			EmitUsage: false,
+		})
+	}
+}
@@ -0,0 +1,94 @@
+package debug
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/onsi/ginkgo/v2/ginkgo/command"
+	"github.com/onsi/ginkgo/v2/ginkgo/internal"
+	"github.com/onsi/ginkgo/v2/types"
+)
+
+var fileLineRegExp = regexp.MustCompile(`^.+:\d+$`)
+
+func BuildDebugCommand() command.Command {
+	var suiteConfig = types.NewDefaultSuiteConfig()
+	var reporterConfig = types.NewDefaultReporterConfig()
+	var cliConfig = types.NewDefaultCLIConfig()
+	var goFlagsConfig = types.NewDefaultGoFlagsConfig()
+
+	flags, err := types.BuildDebugCommandFlagSet(&suiteConfig, &reporterConfig, &cliConfig, &goFlagsConfig)
+	if err != nil {
+		panic(err)
+	}
+
+	return command.Command{
+		Name:     "debug",
+		Flags:    flags,
+		Usage:    "ginkgo debug <FLAGS> <SPEC> <PACKAGE> -- <PASS-THROUGHS>",
+		ShortDoc: "Compile the suite in <PACKAGE> (or the current directory if left blank) for debugging and run exactly one spec.",
+		Documentation: `<SPEC> is either the location of the spec (e.g. {{bold}}my_test.go:42{{/}}) or a regular expression that matches the spec's full text (as with {{bold}}--focus{{/}}).
+
+The suite is compiled with optimizations and inlining disabled and the spec is run in series with verbose output, without output interception, and without a timeout.  Ginkgo fails the run if <SPEC> does not match exactly one spec.  Pass {{bold}}--delve{{/}} to run the spec under the delve debugger.
+
+Any arguments after -- will be passed to the test.`,
+		DocLink: "debugging-a-single-spec",
+		Command: func(args []string, additionalArgs []string) {
+			if len(args) == 0 {
+				command.AbortWithUsage("ginkgo debug requires a spec to debug")
+			}
+			var errors []error
+			cliConfig, goFlagsConfig, errors = types.VetAndInitializeCLIAndGoConfig(cliConfig, goFlagsConfig)
+			command.AbortIfConfigurationErrors(errors)
+
+			debugSpec(args[0], args[1:], suiteConfig, reporterConfig, cliConfig, goFlagsConfig, additionalArgs)
+		},
+	}
+}
+
+func debugSpec(spec string, args []string, suiteConfig types.SuiteConfig, reporterConfig types.ReporterConfig, cliConfig types.CLIConfig, goFlagsConfig types.GoFlagsConfig, additionalArgs []string) {
+	suites := internal.FindSuites(args, cliConfig, false).WithoutState(internal.TestSuiteStateSkippedByFilter)
+	if len(suites) == 0 {
+		command.AbortWith("Found no test suites")
+	}
+	if len(suites) > 1 {
+		command.AbortWith("ginkgo debug can only debug one suite at a time, but found %d", len(suites))
+	}
+
+	if fileLineRegExp.MatchString(spec) {
+		suiteConfig.FocusFiles = append(suiteConfig.FocusFiles, spec)
+	} else {
+		suiteConfig.FocusStrings = append(suiteConfig.FocusStrings, spec)
+	}
+	suiteConfig.RequireSingleSpec = true
+	suiteConfig.OutputInterceptorMode = "none"
+	if !reporterConfig.VeryVerbose {
+		reporterConfig.Verbose = true
+	}
+	//a zero timeout is not forwarded to the suite so we pass it explicitly to disable the default timeout
+	additionalArgs = append([]string{"--ginkgo.timeout=0s"}, additionalArgs...)
+
+	//disable optimizations and inlining so that debuggers can step through the code
+	goFlagsConfig.GCFlags = "all=-N -l"
+
+	suite := internal.CompileSuite(suites[0], goFlagsConfig)
+	if suite.State.Is(internal.TestSuiteStateFailedToCompile) {
+		fmt.Println(suite.CompilationError.Error())
+		command.AbortWith("Failed to compile %s", suite.PackageName)
+	}
+	if !suite.IsGinkgo {
+		internal.Cleanup(goFlagsConfig, suite)
+		command.AbortWith("%s is not a Ginkgo suite", suite.PackageName)
+	}
+
+	if cliConfig.Delve {
+		suite = internal.RunCompiledSuiteWithDelve(suite, suiteConfig, reporterConfig, goFlagsConfig, additionalArgs)
+	} else {
+		suite = internal.RunCompiledSuite(suite, suiteConfig, reporterConfig, cliConfig, goFlagsConfig, additionalArgs)
+	}
+	internal.Cleanup(goFlagsConfig, suite)
+
+	if suite.State.Is(internal.TestSuiteStateFailureStates...) {
+		command.Abort(command.AbortDetails{ExitCode: 1})
+	}
+}
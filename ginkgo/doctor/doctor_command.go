@@ -0,0 +1,248 @@
+package doctor
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/onsi/ginkgo/v2/formatter"
+	"github.com/onsi/ginkgo/v2/ginkgo/command"
+	"github.com/onsi/ginkgo/v2/ginkgo/internal"
+	"github.com/onsi/ginkgo/v2/ginkgo/unfocus"
+	"github.com/onsi/ginkgo/v2/types"
+)
+
+func BuildDoctorCommand() command.Command {
+	var reporterConfig = types.NewDefaultReporterConfig()
+	var cliConfig = types.NewDefaultCLIConfig()
+
+	flags, err := types.BuildDoctorCommandFlagSet(&reporterConfig, &cliConfig)
+	if err != nil {
+		panic(err)
+	}
+
+	return command.Command{
+		Name:     "doctor",
+		Usage:    "ginkgo doctor <FLAGS>",
+		Flags:    flags,
+		ShortDoc: "Check the environment under the current directory for common Ginkgo problems and suggest fixes",
+		Documentation: `ginkgo doctor checks for mismatches between the Ginkgo CLI and the version of Ginkgo in your go.mod, GOFLAGS that conflict with Ginkgo, leftover programmatic focus, stale build caches and precompiled suites, and misconfigured output directories.
+Pass in the {{bold}}--output-dir{{/}} you use with ginkgo run to have it checked too.`,
+		DocLink: "diagnosing-your-environment",
+		Command: func(_ []string, _ []string) {
+			Doctor(reporterConfig, cliConfig)
+		},
+	}
+}
+
+// A finding is a problem ginkgo doctor detected along with a suggestion for how to fix it
+type finding struct {
+	problem string
+	fix     string
+}
+
+type check struct {
+	description string
+	run         func(cliConfig types.CLIConfig) []finding
+}
+
+var checks = []check{
+	{"Ginkgo CLI and library versions", checkVersions},
+	{"GOFLAGS", checkGOFLAGS},
+	{"Programmatic focus", checkFocus},
+	{"Build cache and precompiled suites", checkBuildCache},
+	{"Output directory", checkOutputDir},
+}
+
+func Doctor(reporterConfig types.ReporterConfig, cliConfig types.CLIConfig) {
+	f := formatter.NewWithNoColorBool(reporterConfig.NoColor)
+	numFindings := 0
+	for _, c := range checks {
+		findings := c.run(cliConfig)
+		if len(findings) == 0 {
+			fmt.Println(f.F("{{green}}✓{{/}} %s", c.description))
+			continue
+		}
+		fmt.Println(f.F("{{red}}✗{{/}} %s", c.description))
+		for _, finding := range findings {
+			fmt.Println(f.Fi(1, "%s", finding.problem))
+			fmt.Println(f.Fi(2, "{{gray}}Fix: %s{{/}}", finding.fix))
+		}
+		numFindings += len(findings)
+	}
+
+	if numFindings > 0 {
+		command.AbortWith("ginkgo doctor found %d %s", numFindings, internal.PluralizedWord("problem", "problems", numFindings))
+	}
+}
+
+func checkVersions(_ types.CLIConfig) []finding {
+	output, err := exec.Command("go", "list", "-m", "-f", "{{.Version}}", "github.com/onsi/ginkgo/v2").CombinedOutput()
+	if err != nil {
+		return []finding{{
+			problem: fmt.Sprintf("Could not determine which version of Ginkgo this module depends on:\n%s", strings.TrimSpace(string(output))),
+			fix:     "run ginkgo doctor from within a module that depends on github.com/onsi/ginkgo/v2",
+		}}
+	}
+	libraryVersion := strings.TrimSpace(string(output))
+	if libraryVersion == "" {
+		// this is Ginkgo's own module
+		return nil
+	}
+	if libraryVersion != "v"+types.VERSION {
+		return []finding{{
+			problem: fmt.Sprintf("The Ginkgo CLI is v%s but this module depends on Ginkgo %s", types.VERSION, libraryVersion),
+			fix:     fmt.Sprintf("go install github.com/onsi/ginkgo/v2/ginkgo@%s", libraryVersion),
+		}}
+	}
+	return nil
+}
+
+// GOFLAGS that Ginkgo manages itself, mapped to the Ginkgo flag to use instead
+var conflictingGOFLAGS = map[string]string{
+	"c":        "ginkgo build",
+	"count":    "--repeat or --until-it-fails",
+	"failfast": "--fail-fast",
+	"json":     "--json-report",
+	"o":        "ginkgo build and --output-dir",
+	"parallel": "-p or --procs",
+	"run":      "--focus",
+	"timeout":  "--timeout",
+	"v":        "-v",
+}
+
+func checkGOFLAGS(_ types.CLIConfig) []finding {
+	goFlags := os.Getenv("GOFLAGS")
+	if output, err := exec.Command("go", "env", "GOFLAGS").Output(); err == nil {
+		goFlags = strings.TrimSpace(string(output))
+	}
+
+	findings := []finding{}
+	for _, goFlag := range strings.Fields(goFlags) {
+		name := strings.SplitN(strings.TrimLeft(goFlag, "-"), "=", 2)[0]
+		if alternative, ok := conflictingGOFLAGS[name]; ok {
+			findings = append(findings, finding{
+				problem: fmt.Sprintf("GOFLAGS contains %s which conflicts with how Ginkgo compiles and runs suites", goFlag),
+				fix:     fmt.Sprintf("remove %s from GOFLAGS (check go env -w too) and use %s instead", goFlag, alternative),
+			})
+		}
+	}
+	return findings
+}
+
+func checkFocus(_ types.CLIConfig) []finding {
+	locations := unfocus.FindFocus(".")
+	if len(locations) == 0 {
+		return nil
+	}
+	return []finding{{
+		problem: "Found programmatic focus at:\n  " + strings.Join(locations, "\n  "),
+		fix:     "run ginkgo unfocus before committing",
+	}}
+}
+
+func checkBuildCache(_ types.CLIConfig) []finding {
+	findings := []finding{}
+
+	goCache := os.Getenv("GOCACHE")
+	if output, err := exec.Command("go", "env", "GOCACHE").Output(); err == nil {
+		goCache = strings.TrimSpace(string(output))
+	}
+	switch {
+	case goCache == "off":
+		findings = append(findings, finding{
+			problem: "GOCACHE is off so every suite and its dependencies are recompiled on every run",
+			fix:     "unset GOCACHE",
+		})
+	case goCache != "" && !isWritableDir(goCache):
+		findings = append(findings, finding{
+			problem: fmt.Sprintf("The build cache at %s is not a writable directory", goCache),
+			fix:     "fix the permissions of the build cache or point GOCACHE at a writable directory",
+		})
+	}
+
+	for _, binary := range findStalePrecompiledSuites(".") {
+		findings = append(findings, finding{
+			problem: fmt.Sprintf("The precompiled suite %s is older than the sources in its package", binary),
+			fix:     "rebuild it with ginkgo build or delete it",
+		})
+	}
+
+	return findings
+}
+
+func checkOutputDir(cliConfig types.CLIConfig) []finding {
+	if cliConfig.OutputDir == "" {
+		return nil
+	}
+	info, err := os.Stat(cliConfig.OutputDir)
+	switch {
+	case os.IsNotExist(err):
+		return []finding{{
+			problem: fmt.Sprintf("The output directory %s does not exist", cliConfig.OutputDir),
+			fix:     fmt.Sprintf("mkdir -p %s", cliConfig.OutputDir),
+		}}
+	case err != nil:
+		return []finding{{
+			problem: fmt.Sprintf("Could not inspect the output directory %s: %s", cliConfig.OutputDir, err.Error()),
+			fix:     "fix the permissions of the output directory",
+		}}
+	case !info.IsDir():
+		return []finding{{
+			problem: fmt.Sprintf("The output directory %s is a file", cliConfig.OutputDir),
+			fix:     "pass a directory to --output-dir",
+		}}
+	case !isWritableDir(cliConfig.OutputDir):
+		return []finding{{
+			problem: fmt.Sprintf("The output directory %s is not writable", cliConfig.OutputDir),
+			fix:     "fix the permissions of the output directory",
+		}}
+	}
+	return nil
+}
+
+func isWritableDir(dir string) bool {
+	f, err := os.CreateTemp(dir, ".ginkgo-doctor")
+	if err != nil {
+		return false
+	}
+	f.Close()
+	os.Remove(f.Name())
+	return true
+}
+
+// findStalePrecompiledSuites returns the .test binaries under path that are older than the newest .go file in their package
+func findStalePrecompiledSuites(path string) []string {
+	stale := []string{}
+	filepath.WalkDir(path, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		if d.IsDir() {
+			if p != path && (d.Name() == "vendor" || strings.HasPrefix(d.Name(), ".")) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if filepath.Ext(p) != ".test" {
+			return nil
+		}
+		binary, err := d.Info()
+		if err != nil || binary.Mode()&0111 == 0 {
+			return nil
+		}
+		sources, _ := filepath.Glob(filepath.Join(filepath.Dir(p), "*.go"))
+		for _, source := range sources {
+			info, err := os.Stat(source)
+			if err == nil && info.ModTime().After(binary.ModTime()) {
+				stale = append(stale, p)
+				break
+			}
+		}
+		return nil
+	})
+	return stale
+}
@@ -0,0 +1,73 @@
+package explain
+
+import (
+	"fmt"
+
+	"github.com/onsi/ginkgo/v2/ginkgo/command"
+	"github.com/onsi/ginkgo/v2/ginkgo/internal"
+	"github.com/onsi/ginkgo/v2/types"
+)
+
+func BuildExplainCommand() command.Command {
+	var suiteConfig = types.NewDefaultSuiteConfig()
+	var reporterConfig = types.NewDefaultReporterConfig()
+	var cliConfig = types.NewDefaultCLIConfig()
+	var goFlagsConfig = types.NewDefaultGoFlagsConfig()
+
+	flags, err := types.BuildExplainCommandFlagSet(&suiteConfig, &reporterConfig, &cliConfig, &goFlagsConfig)
+	if err != nil {
+		panic(err)
+	}
+
+	return command.Command{
+		Name:     "explain",
+		Flags:    flags,
+		Usage:    "ginkgo explain <FLAGS> <SPEC> <PACKAGE> -- <PASS-THROUGHS>",
+		ShortDoc: "Explain why the specs matching <SPEC> in <PACKAGE> (or the current directory if left blank) would or would not run.",
+		Documentation: `<SPEC> is either the location of the spec (e.g. {{bold}}my_test.go:42{{/}}) or a substring of the spec's full text.
+
+Pass ginkgo explain the same focus, skip, and label flags you would pass to ginkgo run.  Ginkgo compiles the suite and, instead of running it, evaluates every filter against the matching specs and reports which filters include or exclude each spec.
+
+Any arguments after -- will be passed to the test.`,
+		DocLink: "explaining-why-a-spec-did-not-run",
+		Command: func(args []string, additionalArgs []string) {
+			if len(args) == 0 {
+				command.AbortWithUsage("ginkgo explain requires a spec to explain")
+			}
+			var errors []error
+			cliConfig, goFlagsConfig, errors = types.VetAndInitializeCLIAndGoConfig(cliConfig, goFlagsConfig)
+			command.AbortIfConfigurationErrors(errors)
+
+			explainSpec(args[0], args[1:], suiteConfig, reporterConfig, cliConfig, goFlagsConfig, additionalArgs)
+		},
+	}
+}
+
+func explainSpec(spec string, args []string, suiteConfig types.SuiteConfig, reporterConfig types.ReporterConfig, cliConfig types.CLIConfig, goFlagsConfig types.GoFlagsConfig, additionalArgs []string) {
+	suites := internal.FindSuites(args, cliConfig, false).WithoutState(internal.TestSuiteStateSkippedByFilter)
+	if len(suites) == 0 {
+		command.AbortWith("Found no test suites")
+	}
+	if len(suites) > 1 {
+		command.AbortWith("ginkgo explain can only explain specs in one suite at a time, but found %d", len(suites))
+	}
+
+	suiteConfig.ExplainSpec = spec
+
+	suite := internal.CompileSuite(suites[0], goFlagsConfig)
+	if suite.State.Is(internal.TestSuiteStateFailedToCompile) {
+		fmt.Println(suite.CompilationError.Error())
+		command.AbortWith("Failed to compile %s", suite.PackageName)
+	}
+	if !suite.IsGinkgo {
+		internal.Cleanup(goFlagsConfig, suite)
+		command.AbortWith("%s is not a Ginkgo suite", suite.PackageName)
+	}
+
+	suite = internal.RunCompiledSuite(suite, suiteConfig, reporterConfig, cliConfig, goFlagsConfig, additionalArgs)
+	internal.Cleanup(goFlagsConfig, suite)
+
+	if suite.State.Is(internal.TestSuiteStateFailureStates...) {
+		command.Abort(command.AbortDetails{ExitCode: 1})
+	}
+}
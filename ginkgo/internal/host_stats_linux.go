@@ -0,0 +1,114 @@
+//go:build linux
+// +build linux
+
+package internal
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+// ReadHostStats samples the host's CPU, load, and memory from /proc along with the CPU and memory used by the processes with the given pids
+func ReadHostStats(pids []int) (HostStats, bool) {
+	stats := HostStats{
+		NumCPU:    runtime.NumCPU(),
+		Processes: map[int]ProcessStats{},
+	}
+
+	content, err := os.ReadFile("/proc/stat")
+	if err != nil {
+		return HostStats{}, false
+	}
+	// cpu  user nice system idle iowait irq softirq steal guest guest_nice
+	fields := strings.Fields(strings.SplitN(string(content), "\n", 2)[0])
+	if len(fields) < 9 || fields[0] != "cpu" {
+		return HostStats{}, false
+	}
+	for i, field := range fields[1:9] {
+		ticks, err := strconv.ParseUint(field, 10, 64)
+		if err != nil {
+			return HostStats{}, false
+		}
+		stats.CPUTicks += ticks
+		if i == 3 || i == 4 {
+			stats.IdleTicks += ticks
+		}
+	}
+
+	content, err = os.ReadFile("/proc/loadavg")
+	if err != nil {
+		return HostStats{}, false
+	}
+	fields = strings.Fields(string(content))
+	if len(fields) == 0 {
+		return HostStats{}, false
+	}
+	stats.LoadAverage, err = strconv.ParseFloat(fields[0], 64)
+	if err != nil {
+		return HostStats{}, false
+	}
+
+	f, err := os.Open("/proc/meminfo")
+	if err != nil {
+		return HostStats{}, false
+	}
+	defer f.Close()
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var name string
+		var kb int64
+		if n, _ := fmt.Sscanf(scanner.Text(), "%s %d kB", &name, &kb); n != 2 {
+			continue
+		}
+		switch name {
+		case "MemTotal:":
+			stats.MemoryTotal = kb * 1024
+		case "MemAvailable:":
+			stats.MemoryAvailable = kb * 1024
+		}
+	}
+
+	for _, pid := range pids {
+		if process, ok := readProcessStats(pid); ok {
+			stats.Processes[pid] = process
+		}
+	}
+
+	return stats, true
+}
+
+func readProcessStats(pid int) (ProcessStats, bool) {
+	content, err := os.ReadFile(fmt.Sprintf("/proc/%d/stat", pid))
+	if err != nil {
+		return ProcessStats{}, false
+	}
+	// the command name can contain spaces so the fields are counted from the parenthesis that closes it, starting with field 3 (state)
+	idx := strings.LastIndex(string(content), ")")
+	if idx == -1 {
+		return ProcessStats{}, false
+	}
+	fields := strings.Fields(string(content)[idx+1:])
+	if len(fields) < 22 {
+		return ProcessStats{}, false
+	}
+	utime, err := strconv.ParseUint(fields[11], 10, 64)
+	if err != nil {
+		return ProcessStats{}, false
+	}
+	stime, err := strconv.ParseUint(fields[12], 10, 64)
+	if err != nil {
+		return ProcessStats{}, false
+	}
+	rss, err := strconv.ParseInt(fields[21], 10, 64)
+	if err != nil {
+		return ProcessStats{}, false
+	}
+	return ProcessStats{
+		CPUTicks: utime + stime,
+		RSS:      rss * int64(os.Getpagesize()),
+	}, true
+}
@@ -0,0 +1,9 @@
+//go:build !linux
+// +build !linux
+
+package internal
+
+// ReadHostStats is not available on this platform - --procs=auto runs specs on all the processes it launches
+func ReadHostStats(pids []int) (HostStats, bool) {
+	return HostStats{}, false
+}
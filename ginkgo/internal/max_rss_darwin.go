@@ -0,0 +1,17 @@
+//go:build darwin
+// +build darwin
+
+package internal
+
+import (
+	"os"
+	"syscall"
+)
+
+// maxRSS returns the peak resident set size, in bytes, of the exited process.  Darwin reports it in bytes.
+func maxRSS(state *os.ProcessState) int64 {
+	if rusage, ok := state.SysUsage().(*syscall.Rusage); ok {
+		return int64(rusage.Maxrss)
+	}
+	return 0
+}
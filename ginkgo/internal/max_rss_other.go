@@ -0,0 +1,11 @@
+//go:build !freebsd && !openbsd && !netbsd && !dragonfly && !linux && !solaris && !darwin
+// +build !freebsd,!openbsd,!netbsd,!dragonfly,!linux,!solaris,!darwin
+
+package internal
+
+import "os"
+
+// maxRSS is not available on this platform
+func maxRSS(state *os.ProcessState) int64 {
+	return 0
+}
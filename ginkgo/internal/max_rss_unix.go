@@ -0,0 +1,17 @@
+//go:build freebsd || openbsd || netbsd || dragonfly || linux || solaris
+// +build freebsd openbsd netbsd dragonfly linux solaris
+
+package internal
+
+import (
+	"os"
+	"syscall"
+)
+
+// maxRSS returns the peak resident set size, in bytes, of the exited process.  These platforms report it in kilobytes.
+func maxRSS(state *os.ProcessState) int64 {
+	if rusage, ok := state.SysUsage().(*syscall.Rusage); ok {
+		return int64(rusage.Maxrss) * 1024
+	}
+	return 0
+}
@@ -0,0 +1,146 @@
+package internal
+
+import (
+	"time"
+
+	"github.com/onsi/ginkgo/v2/internal/parallel_support"
+)
+
+// AUTO_PROCS_SAMPLING_INTERVAL is how often --procs=auto samples the host and the running processes
+var AUTO_PROCS_SAMPLING_INTERVAL = time.Second
+
+// with --procs=auto the initial number of processes assumes each spec needs this much memory until the specs' actual usage can be observed
+const autoProcsInitialMemoryPerProc = 512 << 20
+
+// HostStats is a snapshot of the host's load and of the resources used by the processes running specs.
+// CPU times are cumulative and measured in clock ticks summed across all CPUs
+type HostStats struct {
+	NumCPU    int
+	CPUTicks  uint64
+	IdleTicks uint64
+
+	LoadAverage float64
+
+	MemoryTotal     int64
+	MemoryAvailable int64
+
+	Processes map[int]ProcessStats
+}
+
+type ProcessStats struct {
+	CPUTicks uint64
+	RSS      int64
+}
+
+// ProcsTuner decides how many of the parallel processes launched for --procs=auto should be running specs at any given time.
+// Each process runs one spec at a time, so the CPU and memory a busy process uses are the CPU and memory its spec uses.
+// The tuner compares that with the capacity the host has left: it activates another process when the host has enough idle CPU and
+// available memory to run one more spec like the ones currently running, and idles one when the host is overloaded or running out of memory.
+type ProcsTuner struct {
+	maxProcs int
+	active   int
+
+	previous    HostStats
+	hasPrevious bool
+}
+
+func NewProcsTuner(maxProcs int, stats HostStats, ok bool) *ProcsTuner {
+	active := maxProcs
+	if ok {
+		if byLoad := stats.NumCPU - int(stats.LoadAverage); byLoad < active {
+			active = byLoad
+		}
+		if byMemory := int(stats.MemoryAvailable / autoProcsInitialMemoryPerProc); stats.MemoryAvailable > 0 && byMemory < active {
+			active = byMemory
+		}
+	}
+	if active < 1 {
+		active = 1
+	}
+	return &ProcsTuner{
+		maxProcs:    maxProcs,
+		active:      active,
+		previous:    stats,
+		hasPrevious: ok,
+	}
+}
+
+func (t *ProcsTuner) Active() int {
+	return t.active
+}
+
+// Observe takes a new sample and returns the number of processes that should be running specs
+func (t *ProcsTuner) Observe(stats HostStats) int {
+	previous, hasPrevious := t.previous, t.hasPrevious
+	t.previous, t.hasPrevious = stats, true
+	if !hasPrevious || stats.NumCPU == 0 || stats.CPUTicks <= previous.CPUTicks {
+		return t.active
+	}
+
+	elapsed := float64(stats.CPUTicks - previous.CPUTicks)
+	idleCPUs := float64(stats.IdleTicks-previous.IdleTicks) / elapsed * float64(stats.NumCPU)
+	elapsedPerCPU := elapsed / float64(stats.NumCPU)
+
+	specCPU, specRSS, numBusy := 0.0, int64(0), 0
+	for pid, process := range stats.Processes {
+		previousProcess, ok := previous.Processes[pid]
+		if !ok || process.CPUTicks < previousProcess.CPUTicks {
+			continue
+		}
+		cpu := float64(process.CPUTicks-previousProcess.CPUTicks) / elapsedPerCPU
+		if cpu < 0.01 {
+			//an idle process - it isn't running a spec
+			continue
+		}
+		specCPU += cpu
+		specRSS += process.RSS
+		numBusy += 1
+	}
+
+	lowMemory := stats.MemoryTotal > 0 && stats.MemoryAvailable < stats.MemoryTotal/10
+	overloaded := idleCPUs < 0.05*float64(stats.NumCPU) && stats.LoadAverage > float64(stats.NumCPU)
+	switch {
+	case lowMemory || overloaded:
+		t.active -= 1
+	case numBusy > 0:
+		specCPU, specRSS = specCPU/float64(numBusy), specRSS/int64(numBusy)
+		roomForAnotherSpec := idleCPUs >= specCPU && (stats.MemoryTotal == 0 || stats.MemoryAvailable-specRSS >= stats.MemoryTotal/10)
+		if roomForAnotherSpec && numBusy >= t.active {
+			t.active += 1
+		}
+	}
+
+	if t.active > t.maxProcs {
+		t.active = t.maxProcs
+	}
+	if t.active < 1 {
+		t.active = 1
+	}
+	return t.active
+}
+
+// Run periodically samples the host and the processes running specs and tells the server how many of them should be active.
+// It returns a function that stops the sampling.
+func (t *ProcsTuner) Run(server parallel_support.Server, pids []int) func() {
+	stop := make(chan interface{})
+	done := make(chan interface{})
+	go func() {
+		defer close(done)
+		ticker := time.NewTicker(AUTO_PROCS_SAMPLING_INTERVAL)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				if stats, ok := ReadHostStats(pids); ok {
+					server.SetActiveProcsLimit(t.Observe(stats))
+				}
+			case <-stop:
+				return
+			}
+		}
+	}()
+	return func() {
+		close(stop)
+		<-done
+	}
+}
@@ -0,0 +1,100 @@
+package internal_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	"github.com/onsi/ginkgo/v2/ginkgo/internal"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ProcsTuner", func() {
+	const GB = int64(1 << 30)
+
+	// sample builds the stats for a host with 8 CPUs, elapsed seconds (at 100 ticks per second per CPU) into the run, that has spent idleCPUSeconds idle
+	var sample = func(elapsed int, idleCPUSeconds int, loadAverage float64, availableMemory int64, processes map[int]internal.ProcessStats) internal.HostStats {
+		return internal.HostStats{
+			NumCPU:          8,
+			CPUTicks:        uint64(elapsed * 8 * 100),
+			IdleTicks:       uint64(idleCPUSeconds * 100),
+			LoadAverage:     loadAverage,
+			MemoryTotal:     16 * GB,
+			MemoryAvailable: availableMemory,
+			Processes:       processes,
+		}
+	}
+
+	// processes builds the stats for processes that have each used cpuSeconds and have rss resident bytes
+	var processes = func(cpuSeconds int, rss int64, pids ...int) map[int]internal.ProcessStats {
+		out := map[int]internal.ProcessStats{}
+		for _, pid := range pids {
+			out[pid] = internal.ProcessStats{CPUTicks: uint64(cpuSeconds * 100), RSS: rss}
+		}
+		return out
+	}
+
+	Describe("the initial number of processes", func() {
+		It("runs specs on all the processes when the host is idle and has plenty of memory", func() {
+			Ω(internal.NewProcsTuner(8, sample(0, 0, 0, 12*GB, nil), true).Active()).Should(Equal(8))
+		})
+
+		It("leaves room for the load already on the host", func() {
+			Ω(internal.NewProcsTuner(8, sample(0, 0, 3.5, 12*GB, nil), true).Active()).Should(Equal(5))
+		})
+
+		It("limits the processes to the available memory", func() {
+			Ω(internal.NewProcsTuner(8, sample(0, 0, 0, GB+GB/2, nil), true).Active()).Should(Equal(3))
+		})
+
+		It("always runs specs on at least one process", func() {
+			Ω(internal.NewProcsTuner(8, sample(0, 0, 12, GB/4, nil), true).Active()).Should(Equal(1))
+		})
+
+		It("runs specs on all the processes when the host can't be sampled", func() {
+			Ω(internal.NewProcsTuner(8, internal.HostStats{}, false).Active()).Should(Equal(8))
+		})
+	})
+
+	Describe("adapting as the suite runs", func() {
+		var tuner *internal.ProcsTuner
+		BeforeEach(func() {
+			tuner = internal.NewProcsTuner(8, sample(0, 0, 4, 12*GB, processes(0, 0, 1, 2, 3, 4, 5, 6, 7, 8)), true)
+			Ω(tuner.Active()).Should(Equal(4))
+		})
+
+		It("activates another process when the host has the CPU and memory to run another spec like the ones that are running", func() {
+			Ω(tuner.Observe(sample(1, 4, 4, 12*GB, processes(1, GB, 1, 2, 3, 4)))).Should(Equal(5))
+		})
+
+		It("only activates one process at a time", func() {
+			busy := processes(1, GB, 1, 2, 3, 4)
+			busy[5] = internal.ProcessStats{}
+			Ω(tuner.Observe(sample(1, 4, 4, 12*GB, busy))).Should(Equal(5))
+			Ω(tuner.Observe(sample(2, 7, 4, 12*GB, processes(2, GB, 1, 2, 3, 4, 5)))).Should(Equal(6))
+		})
+
+		It("does not activate another process when the specs use all the idle CPU", func() {
+			Ω(tuner.Observe(sample(1, 0, 4, 12*GB, processes(1, GB, 1, 2, 3, 4)))).Should(Equal(4))
+		})
+
+		It("does not activate another process when another spec would leave the host short on memory", func() {
+			Ω(tuner.Observe(sample(1, 4, 4, 2*GB, processes(1, GB, 1, 2, 3, 4)))).Should(Equal(4))
+		})
+
+		It("does not activate another process when some of the active processes are idle", func() {
+			Ω(tuner.Observe(sample(1, 4, 4, 12*GB, processes(1, GB, 1, 2, 3)))).Should(Equal(4))
+		})
+
+		It("idles a process when the host is overloaded", func() {
+			Ω(tuner.Observe(sample(1, 0, 12, 12*GB, processes(1, GB, 1, 2, 3, 4)))).Should(Equal(3))
+		})
+
+		It("idles a process when the host is running out of memory", func() {
+			Ω(tuner.Observe(sample(1, 4, 4, GB, processes(1, GB, 1, 2, 3, 4)))).Should(Equal(3))
+		})
+
+		It("never activates more processes than were launched, or fewer than one", func() {
+			tuner = internal.NewProcsTuner(1, sample(0, 0, 0, 12*GB, processes(0, 0, 1)), true)
+			Ω(tuner.Observe(sample(1, 7, 0, 12*GB, processes(1, GB, 1)))).Should(Equal(1))
+			Ω(tuner.Observe(sample(2, 7, 12, GB, processes(2, GB, 1)))).Should(Equal(1))
+		})
+	})
+})
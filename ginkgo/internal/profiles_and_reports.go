@@ -2,18 +2,24 @@ package internal
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"regexp"
+	"sort"
 	"strconv"
+	"strings"
 
 	"github.com/google/pprof/profile"
 	"github.com/onsi/ginkgo/v2/reporters"
 	"github.com/onsi/ginkgo/v2/types"
 )
 
+// UNMATCHED_FILTERS_FILE is the file each suite records the filters that matched none of its specs in when the CLI validates the filters across multiple suites
+const UNMATCHED_FILTERS_FILE = "ginkgo-unmatched-filters.json"
+
 func AbsPathForGeneratedAsset(assetName string, suite TestSuite, cliConfig types.CLIConfig, process int) string {
 	suffix := ""
 	if process != 0 {
@@ -26,7 +32,25 @@ func AbsPathForGeneratedAsset(assetName string, suite TestSuite, cliConfig types
 	return filepath.Join(outputDir, suite.NamespacedName()+"_"+assetName+suffix)
 }
 
-func FinalizeProfilesAndReportsForSuites(suites TestSuites, cliConfig types.CLIConfig, suiteConfig types.SuiteConfig, reporterConfig types.ReporterConfig, goFlagsConfig types.GoFlagsConfig) ([]string, error) {
+// AbsPathsForOutputSinks places relative file:// output sinks alongside the suite's other generated assets
+func AbsPathsForOutputSinks(sinks []string, suite TestSuite, cliConfig types.CLIConfig) []string {
+	out := []string{}
+	for _, sink := range sinks {
+		if strings.HasPrefix(sink, "file://") {
+			path, options := strings.TrimPrefix(sink, "file://"), ""
+			if idx := strings.Index(path, ","); idx > -1 {
+				path, options = path[:idx], path[idx:]
+			}
+			if path != "" && !filepath.IsAbs(path) {
+				sink = "file://" + AbsPathForGeneratedAsset(path, suite, cliConfig, 0) + options
+			}
+		}
+		out = append(out, sink)
+	}
+	return out
+}
+
+func FinalizeProfilesAndReportsForSuites(suites TestSuites, cliConfig types.CLIConfig, suiteConfig types.SuiteConfig, reporterConfig types.ReporterConfig, reportPipeline ReportPipeline, goFlagsConfig types.GoFlagsConfig) ([]string, error) {
 	messages := []string{}
 	suitesWithProfiles := suites.WithState(TestSuiteStatePassed, TestSuiteStateFailed) //anything else won't have actually run and generated a profile
 
@@ -75,19 +99,43 @@ func FinalizeProfilesAndReportsForSuites(suites TestSuites, cliConfig types.CLIC
 
 	type reportFormat struct {
 		ReportName   string
+		Filter       types.ReportEntryFilter
 		GenerateFunc func(types.Report, string) error
 		MergeFunc    func([]string, string) ([]string, error)
 	}
 	reportFormats := []reportFormat{}
 	if reporterConfig.JSONReport != "" {
-		reportFormats = append(reportFormats, reportFormat{ReportName: reporterConfig.JSONReport, GenerateFunc: reporters.GenerateJSONReport, MergeFunc: reporters.MergeAndCleanupJSONReports})
+		filter, _ := reporterConfig.JSONReportEntryFilter()
+		reportFormats = append(reportFormats, reportFormat{ReportName: reporterConfig.JSONReport, Filter: filter, GenerateFunc: reporters.GenerateJSONReport, MergeFunc: reporters.MergeAndCleanupJSONReports})
 	}
 	if reporterConfig.JUnitReport != "" {
-		reportFormats = append(reportFormats, reportFormat{ReportName: reporterConfig.JUnitReport, GenerateFunc: reporters.GenerateJUnitReport, MergeFunc: reporters.MergeAndCleanupJUnitReports})
+		filter, _ := reporterConfig.JUnitReportEntryFilter()
+		reportFormats = append(reportFormats, reportFormat{ReportName: reporterConfig.JUnitReport, Filter: filter, GenerateFunc: reporters.GenerateJUnitReport, MergeFunc: reporters.MergeAndCleanupJUnitReports})
 	}
 	if reporterConfig.TeamcityReport != "" {
 		reportFormats = append(reportFormats, reportFormat{ReportName: reporterConfig.TeamcityReport, GenerateFunc: reporters.GenerateTeamcityReport, MergeFunc: reporters.MergeAndCleanupTeamcityReports})
 	}
+	if reporterConfig.TAPReport != "" {
+		reportFormats = append(reportFormats, reportFormat{ReportName: reporterConfig.TAPReport, GenerateFunc: reporters.GenerateTAPReport, MergeFunc: reporters.MergeAndCleanupTAPReports})
+	}
+
+	// Reports generated by the CLI - as opposed to by the suites themselves - are run through the report pipeline.
+	// When the pipeline splits reports we keep track of the names of the splits so we can merge each of them.
+	generatedReports := map[string]bool{}
+	splitNames := map[string]bool{"": len(reportPipeline) == 0}
+	generateReports := func(suite TestSuite, report types.Report) {
+		if report.ResourceUsage == nil {
+			report.ResourceUsage = reportedResourceUsage(suite)
+		}
+		for _, pipelineReport := range reportPipeline.Process(report) {
+			splitNames[pipelineReport.Name] = true
+			for _, format := range reportFormats {
+				path := AbsPathForGeneratedAsset(pipelineReport.FileName(format.ReportName), suite, cliConfig, 0)
+				format.GenerateFunc(pipelineReport.Report.WithFilteredReportEntries(format.Filter), path)
+				generatedReports[path] = true
+			}
+		}
+	}
 
 	// Generate reports for suites that failed to run
 	reportableSuites := suites.ThatAreGinkgoSuites()
@@ -100,35 +148,82 @@ func FinalizeProfilesAndReportsForSuites(suites TestSuites, cliConfig types.CLIC
 		switch suite.State {
 		case TestSuiteStateFailedToCompile:
 			report.SpecialSuiteFailureReasons = append(report.SpecialSuiteFailureReasons, suite.CompilationError.Error())
+			report.ExitReason = types.ExitReasonCompilationError
 		case TestSuiteStateFailedDueToTimeout:
 			report.SpecialSuiteFailureReasons = append(report.SpecialSuiteFailureReasons, TIMEOUT_ELAPSED_FAILURE_REASON)
+			report.ExitReason = types.ExitReasonTimedOut
 		case TestSuiteStateSkippedDueToPriorFailures:
 			report.SpecialSuiteFailureReasons = append(report.SpecialSuiteFailureReasons, PRIOR_FAILURES_FAILURE_REASON)
+			report.ExitReason = types.ExitReasonFailed
 		case TestSuiteStateSkippedDueToEmptyCompilation:
 			report.SpecialSuiteFailureReasons = append(report.SpecialSuiteFailureReasons, EMPTY_SKIP_FAILURE_REASON)
 			report.SuiteSucceeded = true
+			report.ExitReason = types.ExitReasonPassed
 		}
 
-		for _, format := range reportFormats {
-			format.GenerateFunc(report, AbsPathForGeneratedAsset(format.ReportName, suite, cliConfig, 0))
+		generateReports(suite, report)
+	}
+
+	// With a report pipeline, the suites that ran generated a JSON report for the pipeline to process (see ReportPipeline.ReporterConfigForSuites)
+	if len(reportPipeline) > 0 && len(reportFormats) > 0 {
+		for _, suite := range reportableSuites.WithState(TestSuiteStatePassed, TestSuiteStateFailed) {
+			input := AbsPathForGeneratedAsset(REPORT_PIPELINE_INPUT, suite, cliConfig, 0)
+			reports, err := reporters.ReadJSONReport(input)
+			if err != nil {
+				messages = append(messages, fmt.Sprintf("Could not open %s:\n%s", input, err.Error()))
+				continue
+			}
+			os.Remove(input)
+			for _, report := range reports {
+				generateReports(suite, report)
+			}
+		}
+	}
+
+	// Without a report pipeline, the suites that ran generated their own JSON reports - record the resources each suite consumed in them
+	if len(reportPipeline) == 0 && reporterConfig.JSONReport != "" {
+		for _, suite := range reportableSuites.WithState(TestSuiteStatePassed, TestSuiteStateFailed) {
+			usage := reportedResourceUsage(suite)
+			path := AbsPathForGeneratedAsset(reporterConfig.JSONReport, suite, cliConfig, 0)
+			reports, err := reporters.ReadJSONReport(path)
+			if usage == nil || err != nil || len(reports) != 1 {
+				continue //a missing or malformed report is called out when reports are merged
+			}
+			reports[0].ResourceUsage = usage
+			if err := reporters.GenerateJSONReport(reports[0], path); err != nil {
+				return messages, err
+			}
 		}
 	}
 
 	// Merge reports unless we've been asked to keep them separate
 	if !cliConfig.KeepSeparateReports {
-		for _, format := range reportFormats {
-			reports := []string{}
-			for _, suite := range reportableSuites {
-				reports = append(reports, AbsPathForGeneratedAsset(format.ReportName, suite, cliConfig, 0))
-			}
-			dst := format.ReportName
-			if cliConfig.OutputDir != "" {
-				dst = filepath.Join(cliConfig.OutputDir, format.ReportName)
+		names := []string{}
+		for name, ok := range splitNames {
+			if ok {
+				names = append(names, name)
 			}
-			mergeMessages, err := format.MergeFunc(reports, dst)
-			messages = append(messages, mergeMessages...)
-			if err != nil {
-				return messages, err
+		}
+		sort.Strings(names)
+		for _, format := range reportFormats {
+			for _, name := range names {
+				reportName := PipelineReport{Name: name}.FileName(format.ReportName)
+				reports := []string{}
+				for _, suite := range reportableSuites {
+					path := AbsPathForGeneratedAsset(reportName, suite, cliConfig, 0)
+					if len(reportPipeline) == 0 || generatedReports[path] {
+						reports = append(reports, path)
+					}
+				}
+				dst := reportName
+				if cliConfig.OutputDir != "" {
+					dst = filepath.Join(cliConfig.OutputDir, reportName)
+				}
+				mergeMessages, err := format.MergeFunc(reports, dst)
+				messages = append(messages, mergeMessages...)
+				if err != nil {
+					return messages, err
+				}
 			}
 		}
 	}
@@ -136,6 +231,50 @@ func FinalizeProfilesAndReportsForSuites(suites TestSuites, cliConfig types.CLIC
 	return messages, nil
 }
 
+// UnmatchedFiltersAcrossSuites reads - and cleans up - the filters each suite recorded in UNMATCHED_FILTERS_FILE and returns the filters that matched no specs in any of the suites.
+// Suites that exited before recording their unmatched filters are not taken into account.
+func UnmatchedFiltersAcrossSuites(suites TestSuites, cliConfig types.CLIConfig) ([]string, error) {
+	numSuites, counts, filters := 0, map[string]int{}, []string{}
+	for _, suite := range suites.ThatAreGinkgoSuites().WithState(TestSuiteStatePassed, TestSuiteStateFailed) {
+		path := AbsPathForGeneratedAsset(UNMATCHED_FILTERS_FILE, suite, cliConfig, 0)
+		data, err := os.ReadFile(path)
+		if os.IsNotExist(err) {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		os.Remove(path)
+		suiteUnmatchedFilters := []string{}
+		if err := json.Unmarshal(data, &suiteUnmatchedFilters); err != nil {
+			return nil, fmt.Errorf("%s does not contain valid unmatched filters: %w", path, err)
+		}
+		numSuites += 1
+		for _, filter := range suiteUnmatchedFilters {
+			if counts[filter] == 0 {
+				filters = append(filters, filter)
+			}
+			counts[filter] += 1
+		}
+	}
+
+	unmatchedFilters := []string{}
+	for _, filter := range filters {
+		if counts[filter] == numSuites {
+			unmatchedFilters = append(unmatchedFilters, filter)
+		}
+	}
+	return unmatchedFilters, nil
+}
+
+// reportedResourceUsage returns the resources consumed by the suite, or nil if the suite's processes did not run
+func reportedResourceUsage(suite TestSuite) *types.ResourceUsage {
+	if suite.ResourceUsage == (types.ResourceUsage{}) {
+		return nil
+	}
+	usage := suite.ResourceUsage
+	return &usage
+}
+
 //loads each profile, combines them, deletes them, stores them in destination
 func MergeAndCleanupCoverProfiles(profiles []string, destination string) error {
 	combined := &bytes.Buffer{}
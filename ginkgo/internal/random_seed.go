@@ -0,0 +1,14 @@
+package internal
+
+import (
+	"github.com/onsi/ginkgo/v2/internal"
+	"github.com/onsi/ginkgo/v2/types"
+)
+
+// RandomSeed returns the seed for the next run of the suites: --seed, if it was set, and a seed computed with --seed-strategy otherwise
+func RandomSeed(flags types.GinkgoFlagSet, suiteConfig types.SuiteConfig) (int64, error) {
+	if flags.WasSet("seed") {
+		return suiteConfig.RandomSeed, nil
+	}
+	return internal.SeedForStrategy(suiteConfig.SeedStrategy)
+}
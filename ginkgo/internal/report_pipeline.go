@@ -0,0 +1,294 @@
+package internal
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/onsi/ginkgo/v2/internal"
+	"github.com/onsi/ginkgo/v2/reporters/reportquery"
+	"github.com/onsi/ginkgo/v2/types"
+)
+
+// REPORT_PIPELINE_INPUT is the JSON report each suite generates for the report pipeline to process when a pipeline is configured
+const REPORT_PIPELINE_INPUT = "ginkgo-report-pipeline-input.json"
+
+// UNLABELED_REPORT names the report the split-by-label transform produces for specs that have no labels
+const UNLABELED_REPORT = "unlabeled"
+
+// OWNERS_REPORT_ENTRY names the report entry the enrich-owners transform attaches to each spec.  reportquery reads it back via Spec.Owners()
+const OWNERS_REPORT_ENTRY = reportquery.OwnersReportEntry
+
+var reportNameSanitizer = regexp.MustCompile(`[^\w\-]+`)
+
+// configFile is the Ginkgo config file passed to the CLI with --config
+type configFile struct {
+	ReportPipeline []reportTransformConfig `json:"report-pipeline"`
+}
+
+type reportTransformConfig struct {
+	Transform string `json:"transform"`
+
+	// for redact: the regular expressions whose matches are replaced with [REDACTED]
+	Patterns []string `json:"patterns"`
+	// for truncate: the number of bytes captured output and failure messages are truncated to
+	MaxLength int `json:"max-length"`
+	// for enrich-owners: maps paths, relative to the config file, to the owners of the specs defined under them
+	Owners map[string][]string `json:"owners"`
+}
+
+// PipelineReport is a report flowing through a ReportPipeline.  Name is empty until the report is split by the split-by-label transform.
+type PipelineReport struct {
+	Name   string
+	Report types.Report
+}
+
+// FileName returns the name of the file a report requested with reportName is generated in for this PipelineReport (e.g. out.json becomes out.integration.json for the report split out for the "integration" label)
+func (pr PipelineReport) FileName(reportName string) string {
+	if pr.Name == "" {
+		return reportName
+	}
+	ext := filepath.Ext(reportName)
+	return strings.TrimSuffix(reportName, ext) + "." + pr.Name + ext
+}
+
+// ReportTransform is a single step in a ReportPipeline
+type ReportTransform func(reports []PipelineReport) []PipelineReport
+
+// ReportPipeline is the ordered list of transforms the CLI applies to each suite's final report before generating the --json-report, --junit-report, --teamcity-report, and --tap-report reports
+type ReportPipeline []ReportTransform
+
+// LoadReportPipeline loads the report pipeline defined in the Ginkgo config file at path.  It returns an empty pipeline if path is empty.
+func LoadReportPipeline(path string) (ReportPipeline, error) {
+	if path == "" {
+		return ReportPipeline{}, nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	config := configFile{}
+	if err := json.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("%s is not a valid Ginkgo config file: %w", path, err)
+	}
+	root, err := filepath.Abs(filepath.Dir(path))
+	if err != nil {
+		return nil, err
+	}
+
+	pipeline := ReportPipeline{}
+	for i, transformConfig := range config.ReportPipeline {
+		transform, err := transformConfig.build(root)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid report-pipeline transform #%d in %s: %w", i+1, path, err)
+		}
+		pipeline = append(pipeline, transform)
+	}
+	return pipeline, nil
+}
+
+func (c reportTransformConfig) build(root string) (ReportTransform, error) {
+	switch c.Transform {
+	case "redact":
+		if len(c.Patterns) == 0 {
+			return nil, fmt.Errorf("redact requires at least one pattern")
+		}
+		redactions := internal.NewOutputFilters()
+		for _, pattern := range c.Patterns {
+			re, err := regexp.Compile(pattern)
+			if err != nil {
+				return nil, fmt.Errorf("redact pattern %s is not a valid regular expression: %w", pattern, err)
+			}
+			redactions.Add(internal.RedactionForPattern(re))
+		}
+		return mapSpecReports(func(report types.SpecReport) types.SpecReport {
+			return internal.RedactSpecReport(report, redactions)
+		}), nil
+	case "truncate":
+		if c.MaxLength <= 0 {
+			return nil, fmt.Errorf("truncate requires a positive max-length")
+		}
+		return mapSpecReports(func(report types.SpecReport) types.SpecReport {
+			return truncateSpecReport(report, c.MaxLength)
+		}), nil
+	case "enrich-owners":
+		if len(c.Owners) == 0 {
+			return nil, fmt.Errorf("enrich-owners requires owners")
+		}
+		paths := newOwnedPaths(c.Owners)
+		return mapSpecReports(func(report types.SpecReport) types.SpecReport {
+			return enrichSpecReportWithOwners(report, root, paths)
+		}), nil
+	case "split-by-label":
+		return splitByLabel, nil
+	case "":
+		return nil, fmt.Errorf("transform is required")
+	}
+	return nil, fmt.Errorf("unknown transform %s - must be one of redact, truncate, enrich-owners, or split-by-label", c.Transform)
+}
+
+// Process runs report through the pipeline
+func (p ReportPipeline) Process(report types.Report) []PipelineReport {
+	reports := []PipelineReport{{Report: report}}
+	for _, transform := range p {
+		reports = transform(reports)
+	}
+	return reports
+}
+
+// ReporterConfigForSuites returns the reporter configuration suites are run with.  When the pipeline has transforms, suites generate a single unfiltered JSON report for the pipeline to process instead of the requested reports.
+func (p ReportPipeline) ReporterConfigForSuites(reporterConfig types.ReporterConfig) types.ReporterConfig {
+	if len(p) == 0 || !reporterConfig.WillGenerateReport() {
+		return reporterConfig
+	}
+	reporterConfig.JSONReport, reporterConfig.JUnitReport, reporterConfig.TeamcityReport, reporterConfig.TAPReport = REPORT_PIPELINE_INPUT, "", "", ""
+	reporterConfig.JSONReportEntryVisibilities, reporterConfig.JSONReportEntrySkip = nil, nil
+	reporterConfig.JUnitReportEntryVisibilities, reporterConfig.JUnitReportEntrySkip = nil, nil
+	return reporterConfig
+}
+
+func mapSpecReports(f func(types.SpecReport) types.SpecReport) ReportTransform {
+	return func(reports []PipelineReport) []PipelineReport {
+		out := make([]PipelineReport, len(reports))
+		for i, pr := range reports {
+			specReports := make(types.SpecReports, len(pr.Report.SpecReports))
+			for j, specReport := range pr.Report.SpecReports {
+				specReports[j] = f(specReport)
+			}
+			pr.Report.SpecReports = specReports
+			out[i] = pr
+		}
+		return out
+	}
+}
+
+func truncateSpecReport(report types.SpecReport, maxLength int) types.SpecReport {
+	report.CapturedGinkgoWriterOutput = truncate(report.CapturedGinkgoWriterOutput, maxLength)
+	report.CapturedStdOutErr = truncate(report.CapturedStdOutErr, maxLength)
+	report.Failure.Message = truncate(report.Failure.Message, maxLength)
+	if report.AdditionalFailures != nil {
+		additionalFailures := make([]types.AdditionalFailure, len(report.AdditionalFailures))
+		for i, additionalFailure := range report.AdditionalFailures {
+			additionalFailure.Failure.Message = truncate(additionalFailure.Failure.Message, maxLength)
+			additionalFailure.CapturedGinkgoWriterOutput = truncate(additionalFailure.CapturedGinkgoWriterOutput, maxLength)
+			additionalFailures[i] = additionalFailure
+		}
+		report.AdditionalFailures = additionalFailures
+	}
+	return report
+}
+
+func truncate(s string, maxLength int) string {
+	if len(s) <= maxLength {
+		return s
+	}
+	//don't split a multi-byte character
+	n := maxLength
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return fmt.Sprintf("%s\n... [truncated %d bytes]", s[:n], len(s)-n)
+}
+
+// ownedPath is a path, relative to the config file, and the owners of the specs defined under it
+type ownedPath struct {
+	path   string
+	owners []string
+}
+
+// newOwnedPaths normalizes the configured paths and sorts them so that the most specific (i.e. longest) path comes first
+func newOwnedPaths(owners map[string][]string) []ownedPath {
+	byPath := map[string][]string{}
+	for path, pathOwners := range owners {
+		path = strings.Trim(filepath.ToSlash(filepath.Clean(path)), "/")
+		if path == "." {
+			path = ""
+		}
+		byPath[path] = append(byPath[path], pathOwners...)
+	}
+	out := []ownedPath{}
+	for path, pathOwners := range byPath {
+		sort.Strings(pathOwners)
+		out = append(out, ownedPath{path: path, owners: pathOwners})
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if len(out[i].path) != len(out[j].path) {
+			return len(out[i].path) > len(out[j].path)
+		}
+		return out[i].path < out[j].path
+	})
+	return out
+}
+
+func enrichSpecReportWithOwners(report types.SpecReport, root string, paths []ownedPath) types.SpecReport {
+	rel, err := filepath.Rel(root, report.LeafNodeLocation.FileName)
+	if err != nil {
+		return report
+	}
+	rel = filepath.ToSlash(rel)
+	for _, owned := range paths {
+		if owned.path != "" && rel != owned.path && !strings.HasPrefix(rel, owned.path+"/") {
+			continue
+		}
+		if len(owned.owners) == 0 {
+			return report
+		}
+		report.ReportEntries = append(report.ReportEntries[:len(report.ReportEntries):len(report.ReportEntries)], types.ReportEntry{
+			Visibility: types.ReportEntryVisibilityAlways,
+			Time:       report.EndTime,
+			Location:   types.NewCustomCodeLocation("added by the enrich-owners report transform"),
+			Name:       OWNERS_REPORT_ENTRY,
+			Value:      types.WrapEntryValue(strings.Join(owned.owners, ", ")),
+		})
+		return report
+	}
+	return report
+}
+
+// splitByLabel splits each report into one report per label, and one for unlabeled specs.  Reports for suite-level nodes (e.g. BeforeSuite) are included in every split.
+func splitByLabel(reports []PipelineReport) []PipelineReport {
+	out := []PipelineReport{}
+	for _, pr := range reports {
+		suiteLevel := types.SpecReports{}
+		byLabel := map[string]types.SpecReports{}
+		for _, specReport := range pr.Report.SpecReports {
+			if !specReport.LeafNodeType.Is(types.NodeTypeIt) {
+				suiteLevel = append(suiteLevel, specReport)
+				continue
+			}
+			labels := specReport.Labels()
+			if len(labels) == 0 {
+				labels = []string{UNLABELED_REPORT}
+			}
+			for _, label := range labels {
+				name := reportNameSanitizer.ReplaceAllString(label, "_")
+				byLabel[name] = append(byLabel[name], specReport)
+			}
+		}
+		if len(byLabel) == 0 {
+			byLabel[UNLABELED_REPORT] = types.SpecReports{}
+		}
+
+		names := []string{}
+		for name := range byLabel {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			split := pr
+			if split.Name != "" {
+				split.Name = pr.Name + "." + name
+			} else {
+				split.Name = name
+			}
+			split.Report.SpecReports = append(suiteLevel[:len(suiteLevel):len(suiteLevel)], byLabel[name]...)
+			out = append(out, split)
+		}
+	}
+	return out
+}
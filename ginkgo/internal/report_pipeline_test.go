@@ -0,0 +1,216 @@
+package internal_test
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	"github.com/onsi/ginkgo/v2/ginkgo/internal"
+	"github.com/onsi/ginkgo/v2/types"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ReportPipeline", func() {
+	var tmpDir string
+	var report types.Report
+
+	loadPipeline := func(config string) (internal.ReportPipeline, error) {
+		path := filepath.Join(tmpDir, "ginkgo.json")
+		Ω(os.WriteFile(path, []byte(config), 0666)).Should(Succeed())
+		return internal.LoadReportPipeline(path)
+	}
+
+	process := func(config string) []internal.PipelineReport {
+		pipeline, err := loadPipeline(config)
+		Ω(err).ShouldNot(HaveOccurred())
+		return pipeline.Process(report)
+	}
+
+	BeforeEach(func() {
+		tmpDir = GinkgoT().TempDir()
+		report = types.Report{
+			SuiteDescription: "suite",
+			SpecReports: types.SpecReports{
+				{
+					LeafNodeType:     types.NodeTypeBeforeSuite,
+					LeafNodeLocation: types.CodeLocation{FileName: filepath.Join(tmpDir, "suite_test.go")},
+					State:            types.SpecStatePassed,
+				},
+				{
+					LeafNodeType:               types.NodeTypeIt,
+					LeafNodeText:               "A",
+					LeafNodeLabels:             []string{"db"},
+					LeafNodeLocation:           types.CodeLocation{FileName: filepath.Join(tmpDir, "db", "db_test.go")},
+					State:                      types.SpecStateFailed,
+					CapturedGinkgoWriterOutput: "connecting with password=hunter2",
+					Failure:                    types.Failure{Message: "failed with password=hunter2"},
+				},
+				{
+					LeafNodeType:             types.NodeTypeIt,
+					LeafNodeText:             "B",
+					ContainerHierarchyLabels: [][]string{{"db"}},
+					LeafNodeLabels:           []string{"slow queries"},
+					LeafNodeLocation:         types.CodeLocation{FileName: filepath.Join(tmpDir, "db", "migrations", "migrations_test.go")},
+					State:                    types.SpecStatePassed,
+				},
+				{
+					LeafNodeType:     types.NodeTypeIt,
+					LeafNodeText:     "C",
+					LeafNodeLocation: types.CodeLocation{FileName: filepath.Join(tmpDir, "api", "api_test.go")},
+					State:            types.SpecStatePassed,
+				},
+			},
+		}
+	})
+
+	Describe("loading the pipeline", func() {
+		It("returns an empty pipeline when there is no config file", func() {
+			pipeline, err := internal.LoadReportPipeline("")
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(pipeline).Should(BeEmpty())
+			Ω(pipeline.Process(report)).Should(Equal([]internal.PipelineReport{{Report: report}}))
+		})
+
+		It("returns an empty pipeline when the config file does not define one", func() {
+			Ω(process(`{}`)).Should(Equal([]internal.PipelineReport{{Report: report}}))
+		})
+
+		DescribeTable("errors when the config file is invalid",
+			func(config string, expectedError string) {
+				_, err := loadPipeline(config)
+				Ω(err).Should(MatchError(ContainSubstring(expectedError)))
+			},
+			Entry("malformed JSON", `{`, "is not a valid Ginkgo config file"),
+			Entry("missing transform", `{"report-pipeline": [{}]}`, "transform #1 in"),
+			Entry("unknown transform", `{"report-pipeline": [{"transform":"split-by-label"}, {"transform":"shuffle"}]}`, "transform #2 in"),
+			Entry("redact without patterns", `{"report-pipeline": [{"transform":"redact"}]}`, "redact requires at least one pattern"),
+			Entry("redact with an invalid pattern", `{"report-pipeline": [{"transform":"redact", "patterns":["("]}]}`, "is not a valid regular expression"),
+			Entry("truncate without max-length", `{"report-pipeline": [{"transform":"truncate"}]}`, "truncate requires a positive max-length"),
+			Entry("enrich-owners without owners", `{"report-pipeline": [{"transform":"enrich-owners"}]}`, "enrich-owners requires owners"),
+		)
+
+		It("errors when the config file does not exist", func() {
+			_, err := internal.LoadReportPipeline(filepath.Join(tmpDir, "missing.json"))
+			Ω(err).Should(HaveOccurred())
+		})
+	})
+
+	Describe("redact", func() {
+		It("redacts matches in the spec reports", func() {
+			reports := process(`{"report-pipeline": [{"transform":"redact", "patterns":["password=\\S+"]}]}`)
+			Ω(reports).Should(HaveLen(1))
+			Ω(reports[0].Report.SpecReports[1].CapturedGinkgoWriterOutput).Should(Equal("connecting with [REDACTED]"))
+			Ω(reports[0].Report.SpecReports[1].Failure.Message).Should(Equal("failed with [REDACTED]"))
+			Ω(report.SpecReports[1].Failure.Message).Should(Equal("failed with password=hunter2"), "the original report is not modified")
+		})
+	})
+
+	Describe("truncate", func() {
+		It("truncates captured output and failure messages", func() {
+			reports := process(`{"report-pipeline": [{"transform":"truncate", "max-length":10}]}`)
+			Ω(reports[0].Report.SpecReports[1].CapturedGinkgoWriterOutput).Should(Equal("connecting\n... [truncated 22 bytes]"))
+			Ω(reports[0].Report.SpecReports[1].Failure.Message).Should(Equal("failed wit\n... [truncated 18 bytes]"))
+			Ω(reports[0].Report.SpecReports[2]).Should(Equal(report.SpecReports[2]))
+		})
+
+		It("does not split multi-byte characters", func() {
+			report.SpecReports[1].Failure.Message = "ab☃cd"
+			reports := process(`{"report-pipeline": [{"transform":"truncate", "max-length":3}]}`)
+			Ω(reports[0].Report.SpecReports[1].Failure.Message).Should(Equal("ab\n... [truncated 5 bytes]"))
+		})
+	})
+
+	Describe("enrich-owners", func() {
+		It("attaches the owners of the most specific matching path, relative to the config file, to each spec", func() {
+			reports := process(`{"report-pipeline": [{"transform":"enrich-owners", "owners":{
+				".": ["@platform"],
+				"db": ["@db-team", "@dba"],
+				"db/migrations/": ["@migrations"]
+			}}]}`)
+			owners := map[string]string{}
+			for _, specReport := range reports[0].Report.SpecReports {
+				Ω(specReport.ReportEntries).Should(HaveLen(1))
+				Ω(specReport.ReportEntries[0].Name).Should(Equal(internal.OWNERS_REPORT_ENTRY))
+				owners[specReport.LeafNodeText] = specReport.ReportEntries[0].StringRepresentation()
+			}
+			Ω(owners).Should(Equal(map[string]string{
+				"":  "@platform",
+				"A": "@db-team, @dba",
+				"B": "@migrations",
+				"C": "@platform",
+			}))
+		})
+
+		It("leaves specs that match no path alone", func() {
+			reports := process(`{"report-pipeline": [{"transform":"enrich-owners", "owners":{"db": ["@db-team"]}}]}`)
+			Ω(reports[0].Report.SpecReports[1].ReportEntries).Should(HaveLen(1))
+			Ω(reports[0].Report.SpecReports[3].ReportEntries).Should(BeEmpty())
+		})
+	})
+
+	Describe("split-by-label", func() {
+		It("splits the report into one report per label, and one for unlabeled specs, including suite-level nodes in each", func() {
+			reports := process(`{"report-pipeline": [{"transform":"split-by-label"}]}`)
+			texts := map[string][]string{}
+			for _, pipelineReport := range reports {
+				Ω(pipelineReport.Report.SuiteDescription).Should(Equal("suite"))
+				for _, specReport := range pipelineReport.Report.SpecReports {
+					texts[pipelineReport.Name] = append(texts[pipelineReport.Name], specReport.LeafNodeText)
+				}
+			}
+			Ω(reports).Should(HaveLen(3))
+			Ω(texts).Should(Equal(map[string][]string{
+				"db":           {"", "A", "B"},
+				"slow_queries": {"", "B"},
+				"unlabeled":    {"", "C"},
+			}))
+		})
+
+		It("names the files split reports are generated in", func() {
+			reports := process(`{"report-pipeline": [{"transform":"split-by-label"}]}`)
+			Ω(reports[0].FileName("out.json")).Should(Equal("out.db.json"))
+			Ω(reports[1].FileName("out")).Should(Equal("out.slow_queries"))
+			Ω(internal.PipelineReport{}.FileName("out.json")).Should(Equal("out.json"))
+		})
+	})
+
+	It("applies the transforms in order", func() {
+		reports := process(`{"report-pipeline": [
+			{"transform":"split-by-label"},
+			{"transform":"redact", "patterns":["hunter2"]},
+			{"transform":"truncate", "max-length":20}
+		]}`)
+		Ω(reports).Should(HaveLen(3))
+		Ω(reports[0].Report.SpecReports[1].Failure.Message).Should(Equal("failed with password\n... [truncated 11 bytes]"))
+	})
+
+	Describe("ReporterConfigForSuites", func() {
+		var reporterConfig types.ReporterConfig
+
+		BeforeEach(func() {
+			reporterConfig = types.ReporterConfig{
+				JSONReport:                  "out.json",
+				JUnitReport:                 "out.xml",
+				TeamcityReport:              "out.tc",
+				TAPReport:                   "out.tap",
+				JSONReportEntryVisibilities: []string{"always"},
+				JUnitReportEntrySkip:        []string{"noisy"},
+			}
+		})
+
+		It("has the suites generate an unfiltered JSON report for the pipeline to process", func() {
+			pipeline, err := loadPipeline(`{"report-pipeline": [{"transform":"split-by-label"}]}`)
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(pipeline.ReporterConfigForSuites(reporterConfig)).Should(Equal(types.ReporterConfig{
+				JSONReport: internal.REPORT_PIPELINE_INPUT,
+			}))
+		})
+
+		It("leaves the configuration alone when the pipeline is empty or no reports are requested", func() {
+			pipeline, err := loadPipeline(`{"report-pipeline": [{"transform":"split-by-label"}]}`)
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(internal.ReportPipeline{}.ReporterConfigForSuites(reporterConfig)).Should(Equal(reporterConfig))
+			Ω(pipeline.ReporterConfigForSuites(types.ReporterConfig{})).Should(Equal(types.ReporterConfig{}))
+		})
+	})
+})
@@ -21,11 +21,19 @@ import (
 func RunCompiledSuite(suite TestSuite, ginkgoConfig types.SuiteConfig, reporterConfig types.ReporterConfig, cliConfig types.CLIConfig, goFlagsConfig types.GoFlagsConfig, additionalArgs []string) TestSuite {
 	suite.State = TestSuiteStateFailed
 	suite.HasProgrammaticFocus = false
+	suite.ExitReason = types.ExitReasonInvalid
+	suite.ResourceUsage = types.ResourceUsage{}
 
 	if suite.PathToCompiledTest == "" {
 		return suite
 	}
 
+	if ginkgoConfig.UnmatchedFiltersFile != "" {
+		ginkgoConfig.UnmatchedFiltersFile = AbsPathForGeneratedAsset(ginkgoConfig.UnmatchedFiltersFile, suite, cliConfig, 0)
+		// clear out the filters recorded by a previous run in case this run exits before recording its own
+		os.Remove(ginkgoConfig.UnmatchedFiltersFile)
+	}
+
 	if suite.IsGinkgo && cliConfig.ComputedProcs() > 1 {
 		suite = runParallel(suite, ginkgoConfig, reporterConfig, cliConfig, goFlagsConfig, additionalArgs)
 	} else if suite.IsGinkgo {
@@ -41,6 +49,7 @@ func buildAndStartCommand(suite TestSuite, args []string, pipeToStdout bool) (*e
 	buf := &bytes.Buffer{}
 	cmd := exec.Command(suite.PathToCompiledTest, args...)
 	cmd.Dir = suite.Path
+	cmd.Env = append(os.Environ(), types.CLIProtocolEnvironment()...)
 	if pipeToStdout {
 		cmd.Stderr = io.MultiWriter(os.Stdout, buf)
 		cmd.Stdout = os.Stdout
@@ -54,6 +63,14 @@ func buildAndStartCommand(suite TestSuite, args []string, pipeToStdout bool) (*e
 	return cmd, buf
 }
 
+// resourceUsage returns the CPU time and peak memory consumed by the exited process
+func resourceUsage(state *os.ProcessState) types.ResourceUsage {
+	return types.ResourceUsage{
+		CPUTime: state.UserTime() + state.SystemTime(),
+		MaxRSS:  maxRSS(state),
+	}
+}
+
 func checkForNoTestsWarning(buf *bytes.Buffer) bool {
 	if strings.Contains(buf.String(), "warning: no tests to run") {
 		fmt.Fprintf(os.Stderr, `Found no test suites, did you forget to run "ginkgo bootstrap"?`)
@@ -65,11 +82,15 @@ func checkForNoTestsWarning(buf *bytes.Buffer) bool {
 func runGoTest(suite TestSuite, cliConfig types.CLIConfig, goFlagsConfig types.GoFlagsConfig) TestSuite {
 	args, err := types.GenerateGoTestRunArgs(goFlagsConfig)
 	command.AbortIfError("Failed to generate test run arguments", err)
+	start := time.Now()
 	cmd, buf := buildAndStartCommand(suite, args, true)
 
 	cmd.Wait()
 
+	suite.ResourceUsage = resourceUsage(cmd.ProcessState)
+	suite.ResourceUsage.WallTime = time.Since(start)
 	exitStatus := cmd.ProcessState.Sys().(syscall.WaitStatus).ExitStatus()
+	suite.ExitReason = types.ExitReasonForExitCode(exitStatus)
 	passed := (exitStatus == 0) || (exitStatus == types.GINKGO_FOCUS_EXIT_CODE)
 	passed = !(checkForNoTestsWarning(buf) && cliConfig.RequireSuite) && passed
 	if passed {
@@ -106,17 +127,25 @@ func runSerial(suite TestSuite, ginkgoConfig types.SuiteConfig, reporterConfig t
 	if reporterConfig.TeamcityReport != "" {
 		reporterConfig.TeamcityReport = AbsPathForGeneratedAsset(reporterConfig.TeamcityReport, suite, cliConfig, 0)
 	}
+	if reporterConfig.TAPReport != "" {
+		reporterConfig.TAPReport = AbsPathForGeneratedAsset(reporterConfig.TAPReport, suite, cliConfig, 0)
+	}
+	reporterConfig.OutputSinks = AbsPathsForOutputSinks(reporterConfig.OutputSinks, suite, cliConfig)
 
 	args, err := types.GenerateGinkgoTestRunArgs(ginkgoConfig, reporterConfig, goFlagsConfig)
 	command.AbortIfError("Failed to generate test run arguments", err)
 	args = append([]string{"--test.timeout=0"}, args...)
 	args = append(args, additionalArgs...)
 
+	start := time.Now()
 	cmd, buf := buildAndStartCommand(suite, args, true)
 
 	cmd.Wait()
 
+	suite.ResourceUsage = resourceUsage(cmd.ProcessState)
+	suite.ResourceUsage.WallTime = time.Since(start)
 	exitStatus := cmd.ProcessState.Sys().(syscall.WaitStatus).ExitStatus()
+	suite.ExitReason = types.ExitReasonForExitCode(exitStatus)
 	suite.HasProgrammaticFocus = (exitStatus == types.GINKGO_FOCUS_EXIT_CODE)
 	passed := (exitStatus == 0) || (exitStatus == types.GINKGO_FOCUS_EXIT_CODE)
 	passed = !(checkForNoTestsWarning(buf) && cliConfig.RequireSuite) && passed
@@ -129,10 +158,47 @@ func runSerial(suite TestSuite, ginkgoConfig types.SuiteConfig, reporterConfig t
 	return suite
 }
 
+// RunCompiledSuiteWithDelve runs a compiled suite in series under the delve debugger, attached to the terminal
+func RunCompiledSuiteWithDelve(suite TestSuite, ginkgoConfig types.SuiteConfig, reporterConfig types.ReporterConfig, goFlagsConfig types.GoFlagsConfig, additionalArgs []string) TestSuite {
+	suite.State = TestSuiteStateFailed
+	suite.HasProgrammaticFocus = false
+
+	if suite.PathToCompiledTest == "" {
+		return suite
+	}
+
+	dlv, err := exec.LookPath("dlv")
+	command.AbortIfError("Could not find the delve debugger - install it with go install github.com/go-delve/delve/cmd/dlv@latest", err)
+
+	args, err := types.GenerateGinkgoTestRunArgs(ginkgoConfig, reporterConfig, goFlagsConfig)
+	command.AbortIfError("Failed to generate test run arguments", err)
+	args = append([]string{"exec", suite.PathToCompiledTest, "--wd", suite.Path, "--", "--test.timeout=0"}, args...)
+	args = append(args, additionalArgs...)
+
+	cmd := exec.Command(dlv, args...)
+	cmd.Dir = suite.Path
+	cmd.Env = append(os.Environ(), types.CLIProtocolEnvironment()...)
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stdout, os.Stderr
+	err = cmd.Start()
+	command.AbortIfError("Failed to start delve", err)
+	cmd.Wait()
+
+	exitStatus := cmd.ProcessState.Sys().(syscall.WaitStatus).ExitStatus()
+	suite.ExitReason = types.ExitReasonForExitCode(exitStatus)
+	suite.HasProgrammaticFocus = (exitStatus == types.GINKGO_FOCUS_EXIT_CODE)
+	if (exitStatus == 0) || (exitStatus == types.GINKGO_FOCUS_EXIT_CODE) {
+		suite.State = TestSuiteStatePassed
+	}
+
+	return suite
+}
+
 func runParallel(suite TestSuite, ginkgoConfig types.SuiteConfig, reporterConfig types.ReporterConfig, cliConfig types.CLIConfig, goFlagsConfig types.GoFlagsConfig, additionalArgs []string) TestSuite {
 	type procResult struct {
 		passed               bool
 		hasProgrammaticFocus bool
+		exitReason           types.ExitReason
+		resourceUsage        types.ResourceUsage
 	}
 
 	numProcs := cliConfig.ComputedProcs()
@@ -146,11 +212,31 @@ func runParallel(suite TestSuite, ginkgoConfig types.SuiteConfig, reporterConfig
 
 	procResults := make(chan procResult)
 
-	server, err := parallel_support.NewServer(numProcs, reporters.NewDefaultReporter(reporterConfig, formatter.ColorableStdOut))
+	//when running in parallel the CLI writes to the output sinks as only it sees the events from all processes
+	reporterConfig.OutputSinks = AbsPathsForOutputSinks(reporterConfig.OutputSinks, suite, cliConfig)
+	reporter, err := reporters.NewDefaultReporterWithOutputSinks(reporterConfig, formatter.ColorableStdOut)
+	command.AbortIfError("Failed to open output sinks", err)
+	if reporterConfig.IDEProtocol != "" {
+		//when running in parallel the CLI emits the IDE protocol events as only it sees the events from all processes
+		ideProtocolReporter, err := reporters.NewIDEProtocolReporter(reporterConfig)
+		command.AbortIfError("Failed to start the IDE protocol reporter", err)
+		reporter = reporters.CompositeReporter{reporter, ideProtocolReporter}
+	}
+
+	server, err := parallel_support.NewServer(numProcs, reporter)
 	command.AbortIfError("Failed to start parallel spec server", err)
 	server.Start()
 	defer server.Close()
 
+	// with --procs=auto the CLI launches as many processes as there are CPUs and caps how many of them run specs at once
+	var tuner *ProcsTuner
+	if cliConfig.AutoTunedProcs() {
+		stats, ok := ReadHostStats(nil)
+		tuner = NewProcsTuner(numProcs, stats, ok)
+		server.SetActiveProcsLimit(tuner.Active())
+	}
+	pids := []int{}
+
 	if reporterConfig.JSONReport != "" {
 		reporterConfig.JSONReport = AbsPathForGeneratedAsset(reporterConfig.JSONReport, suite, cliConfig, 0)
 	}
@@ -160,7 +246,11 @@ func runParallel(suite TestSuite, ginkgoConfig types.SuiteConfig, reporterConfig
 	if reporterConfig.TeamcityReport != "" {
 		reporterConfig.TeamcityReport = AbsPathForGeneratedAsset(reporterConfig.TeamcityReport, suite, cliConfig, 0)
 	}
+	if reporterConfig.TAPReport != "" {
+		reporterConfig.TAPReport = AbsPathForGeneratedAsset(reporterConfig.TAPReport, suite, cliConfig, 0)
+	}
 
+	start := time.Now()
 	for proc := 1; proc <= numProcs; proc++ {
 		procGinkgoConfig := ginkgoConfig
 		procGinkgoConfig.ParallelProcess, procGinkgoConfig.ParallelTotal, procGinkgoConfig.ParallelHost = proc, numProcs, server.Address()
@@ -194,6 +284,7 @@ func runParallel(suite TestSuite, ginkgoConfig types.SuiteConfig, reporterConfig
 
 		cmd, buf := buildAndStartCommand(suite, args, false)
 		procOutput[proc-1] = buf
+		pids = append(pids, cmd.Process.Pid)
 		server.RegisterAlive(proc, func() bool { return cmd.ProcessState == nil || !cmd.ProcessState.Exited() })
 
 		go func() {
@@ -202,16 +293,29 @@ func runParallel(suite TestSuite, ginkgoConfig types.SuiteConfig, reporterConfig
 			procResults <- procResult{
 				passed:               (exitStatus == 0) || (exitStatus == types.GINKGO_FOCUS_EXIT_CODE),
 				hasProgrammaticFocus: exitStatus == types.GINKGO_FOCUS_EXIT_CODE,
+				exitReason:           types.ExitReasonForExitCode(exitStatus),
+				resourceUsage:        resourceUsage(cmd.ProcessState),
 			}
 		}()
 	}
 
+	if tuner != nil {
+		stopTuning := tuner.Run(server, pids)
+		defer stopTuning()
+	}
+
 	passed := true
 	for proc := 1; proc <= cliConfig.ComputedProcs(); proc++ {
 		result := <-procResults
 		passed = passed && result.passed
 		suite.HasProgrammaticFocus = suite.HasProgrammaticFocus || result.hasProgrammaticFocus
+		suite.ExitReason = suite.ExitReason.Combine(result.exitReason)
+		suite.ResourceUsage.CPUTime += result.resourceUsage.CPUTime
+		if result.resourceUsage.MaxRSS > suite.ResourceUsage.MaxRSS {
+			suite.ResourceUsage.MaxRSS = result.resourceUsage.MaxRSS
+		}
 	}
+	suite.ResourceUsage.WallTime = time.Since(start)
 	if passed {
 		suite.State = TestSuiteStatePassed
 	} else {
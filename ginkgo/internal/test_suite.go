@@ -58,6 +58,10 @@ type TestSuite struct {
 
 	HasProgrammaticFocus bool
 	State                TestSuiteState
+	//ExitReason captures the reason reported by the suite's process(es) when they exited
+	ExitReason types.ExitReason
+	//ResourceUsage captures the resources consumed by the suite's process(es)
+	ResourceUsage types.ResourceUsage
 }
 
 func (ts TestSuite) AbsPath() string {
@@ -88,6 +92,22 @@ func (ts TestSuites) AnyHaveProgrammaticFocus() bool {
 	return false
 }
 
+// ExitReason returns the ExitReason with the highest precedence across all the suites.  Programmatic focus is not taken into account.
+func (ts TestSuites) ExitReason() types.ExitReason {
+	reason := types.ExitReasonPassed
+	for _, suite := range ts {
+		switch suite.State {
+		case TestSuiteStateFailedToCompile:
+			reason = reason.Combine(types.ExitReasonCompilationError)
+		case TestSuiteStateFailedDueToTimeout:
+			reason = reason.Combine(types.ExitReasonTimedOut)
+		case TestSuiteStateFailed:
+			reason = reason.Combine(types.ExitReasonFailed.Combine(suite.ExitReason))
+		}
+	}
+	return reason
+}
+
 func (ts TestSuites) ThatAreGinkgoSuites() TestSuites {
 	out := TestSuites{}
 	for _, suite := range ts {
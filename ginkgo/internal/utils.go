@@ -5,6 +5,8 @@ import (
 	"io"
 	"os"
 	"os/exec"
+	"sort"
+	"time"
 
 	"github.com/onsi/ginkgo/v2/formatter"
 	"github.com/onsi/ginkgo/v2/ginkgo/command"
@@ -84,3 +86,51 @@ func FailedSuitesReport(suites TestSuites, f formatter.Formatter) string {
 	}
 	return out
 }
+
+// ResourceUsageReport lists the wall time, CPU time, and peak memory consumed by each suite that ran - the suites that took the longest come first
+func ResourceUsageReport(suites TestSuites, f formatter.Formatter) string {
+	ran := TestSuites{}
+	for _, suite := range suites {
+		if suite.ResourceUsage.WallTime > 0 {
+			ran = append(ran, suite)
+		}
+	}
+	if len(ran) == 0 {
+		return ""
+	}
+	sort.SliceStable(ran, func(i, j int) bool {
+		return ran[i].ResourceUsage.WallTime > ran[j].ResourceUsage.WallTime
+	})
+
+	out := "Resource usage by suite:\n"
+	maxPackageNameLength := 0
+	for _, suite := range ran {
+		if len(suite.PackageName) > maxPackageNameLength {
+			maxPackageNameLength = len(suite.PackageName)
+		}
+	}
+	packageNameFormatter := fmt.Sprintf("%%%ds", maxPackageNameLength)
+	for _, suite := range ran {
+		usage := suite.ResourceUsage
+		maxRSS := "n/a"
+		if usage.MaxRSS > 0 {
+			maxRSS = fmt.Sprintf("%.1fMB", float64(usage.MaxRSS)/(1024*1024))
+		}
+		out += f.Fi(1, packageNameFormatter+" {{gray}}wall:{{/}} %-10s {{gray}}cpu:{{/}} %-10s {{gray}}max rss:{{/}} %-10s {{gray}}%s{{/}}\n",
+			suite.PackageName, usage.WallTime.Round(time.Millisecond), usage.CPUTime.Round(time.Millisecond), maxRSS, suite.Path)
+	}
+	return out
+}
+
+// UnmatchedFiltersReport lists the filters that did not match any specs in any of the suites that ran
+func UnmatchedFiltersReport(unmatchedFilters []string, failed bool, f formatter.Formatter) string {
+	color := "{{orange}}"
+	if failed {
+		color = "{{red}}"
+	}
+	out := f.F(color + "{{bold}}Some filters did not match any specs in any suite:{{/}}\n")
+	for _, filter := range unmatchedFilters {
+		out += f.Fi(1, color+"%s{{/}}\n", filter)
+	}
+	return out
+}
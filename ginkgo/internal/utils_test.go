@@ -1,13 +1,16 @@
 package internal_test
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	"github.com/onsi/ginkgo/v2/formatter"
 	"github.com/onsi/ginkgo/v2/ginkgo/internal"
+	"github.com/onsi/ginkgo/v2/types"
 	. "github.com/onsi/gomega"
 )
 
@@ -108,4 +111,70 @@ var _ = Describe("Utils", func() {
 			}, "\n")))
 		})
 	})
+
+	Describe("ResourceUsageReport", func() {
+		var f formatter.Formatter
+		BeforeEach(func() {
+			f = formatter.New(formatter.ColorModePassthrough)
+		})
+
+		withUsage := func(suite internal.TestSuite, wallTime time.Duration, cpuTime time.Duration, maxRSS int64) internal.TestSuite {
+			suite.ResourceUsage = types.ResourceUsage{WallTime: wallTime, CPUTime: cpuTime, MaxRSS: maxRSS}
+			return suite
+		}
+
+		It("lists the suites that ran, slowest first", func() {
+			suites := []internal.TestSuite{
+				withUsage(TS("path-A", "package-A", true, internal.TestSuiteStatePassed), 1500*time.Millisecond, 3*time.Second, 50*1024*1024),
+				TS("path-B", "B", true, internal.TestSuiteStateFailedToCompile),
+				withUsage(TS("path-C", "C", false, internal.TestSuiteStateFailed), 12*time.Second, 2*time.Second, 0),
+			}
+
+			Ω(internal.ResourceUsageReport(suites, f)).Should(Equal(strings.Join([]string{
+				"Resource usage by suite:",
+				"          C {{gray}}wall:{{/}} 12s        {{gray}}cpu:{{/}} 2s         {{gray}}max rss:{{/}} n/a        {{gray}}path-C{{/}}",
+				"  package-A {{gray}}wall:{{/}} 1.5s       {{gray}}cpu:{{/}} 3s         {{gray}}max rss:{{/}} 50.0MB     {{gray}}path-A{{/}}",
+				"",
+			}, "\n")))
+		})
+
+		It("is empty when no suites ran", func() {
+			suites := []internal.TestSuite{TS("path-B", "B", true, internal.TestSuiteStateFailedToCompile)}
+			Ω(internal.ResourceUsageReport(suites, f)).Should(BeEmpty())
+		})
+	})
+
+	Describe("UnmatchedFiltersAcrossSuites", func() {
+		var suites internal.TestSuites
+		BeforeEach(func() {
+			suites = internal.TestSuites{}
+			for i, unmatched := range []string{`["--focus=\"Q\"","--skip=\"R\""]`, `["--focus=\"Q\""]`, ""} {
+				dir := GinkgoT().TempDir()
+				suites = append(suites, TS(dir, fmt.Sprintf("package-%d", i), true, internal.TestSuiteStatePassed))
+				if unmatched != "" {
+					Ω(os.WriteFile(filepath.Join(dir, internal.UNMATCHED_FILTERS_FILE), []byte(unmatched), 0666)).Should(Succeed())
+				}
+			}
+		})
+
+		It("returns the filters that matched no specs in any of the suites that recorded their unmatched filters, and cleans up", func() {
+			Ω(internal.UnmatchedFiltersAcrossSuites(suites, types.CLIConfig{})).Should(Equal([]string{`--focus="Q"`}))
+			for _, suite := range suites {
+				Ω(filepath.Join(suite.Path, internal.UNMATCHED_FILTERS_FILE)).ShouldNot(BeAnExistingFile())
+			}
+		})
+
+		It("returns nothing when a filter matched specs in every suite", func() {
+			Ω(os.WriteFile(filepath.Join(suites[1].Path, internal.UNMATCHED_FILTERS_FILE), []byte(`[]`), 0666)).Should(Succeed())
+			Ω(internal.UnmatchedFiltersAcrossSuites(suites, types.CLIConfig{})).Should(BeEmpty())
+		})
+	})
+
+	Describe("UnmatchedFiltersReport", func() {
+		It("lists the filters, in red when they fail the run", func() {
+			f := formatter.New(formatter.ColorModePassthrough)
+			Ω(internal.UnmatchedFiltersReport([]string{`--focus="Q"`}, false, f)).Should(Equal("{{orange}}{{bold}}Some filters did not match any specs in any suite:{{/}}\n  {{orange}}--focus=\"Q\"{{/}}\n"))
+			Ω(internal.UnmatchedFiltersReport([]string{`--focus="Q"`}, true, f)).Should(HavePrefix("{{red}}"))
+		})
+	})
 })
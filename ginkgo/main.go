@@ -6,10 +6,15 @@ import (
 
 	"github.com/onsi/ginkgo/v2/ginkgo/build"
 	"github.com/onsi/ginkgo/v2/ginkgo/command"
+	"github.com/onsi/ginkgo/v2/ginkgo/debug"
+	"github.com/onsi/ginkgo/v2/ginkgo/doctor"
+	"github.com/onsi/ginkgo/v2/ginkgo/explain"
 	"github.com/onsi/ginkgo/v2/ginkgo/generators"
 	"github.com/onsi/ginkgo/v2/ginkgo/labels"
 	"github.com/onsi/ginkgo/v2/ginkgo/outline"
+	"github.com/onsi/ginkgo/v2/ginkgo/report"
 	"github.com/onsi/ginkgo/v2/ginkgo/run"
+	"github.com/onsi/ginkgo/v2/ginkgo/sweep"
 	"github.com/onsi/ginkgo/v2/ginkgo/unfocus"
 	"github.com/onsi/ginkgo/v2/ginkgo/watch"
 	"github.com/onsi/ginkgo/v2/types"
@@ -21,10 +26,15 @@ func GenerateCommands() []command.Command {
 	return []command.Command{
 		watch.BuildWatchCommand(),
 		build.BuildBuildCommand(),
+		debug.BuildDebugCommand(),
+		doctor.BuildDoctorCommand(),
+		explain.BuildExplainCommand(),
 		generators.BuildBootstrapCommand(),
 		generators.BuildGenerateCommand(),
 		labels.BuildLabelsCommand(),
 		outline.BuildOutlineCommand(),
+		report.BuildReportCommand(),
+		sweep.BuildSweepCommand(),
 		unfocus.BuildUnfocusCommand(),
 		BuildVersionCommand(),
 	}
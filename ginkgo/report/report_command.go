@@ -0,0 +1,57 @@
+package report
+
+import (
+	"github.com/onsi/ginkgo/v2/formatter"
+	"github.com/onsi/ginkgo/v2/ginkgo/command"
+	"github.com/onsi/ginkgo/v2/reporters"
+	"github.com/onsi/ginkgo/v2/types"
+)
+
+func BuildReportCommand() command.Command {
+	var reporterConfig = types.NewDefaultReporterConfig()
+	var cliConfig = types.NewDefaultCLIConfig()
+
+	flags, err := types.BuildReportCommandFlagSet(&reporterConfig, &cliConfig)
+	if err != nil {
+		panic(err)
+	}
+
+	return command.Command{
+		Name:     "report",
+		Usage:    "ginkgo report replay <FLAGS> <REPORT>",
+		Flags:    flags,
+		ShortDoc: "Work with the JSON reports generated by --json-report.",
+		Documentation: `{{bold}}ginkgo report replay{{/}} re-renders the runs recorded in <REPORT> through Ginkgo's default console reporter.  Use the usual reporter flags (e.g. {{bold}}-v{{/}}, {{bold}}--no-color{{/}}) to choose how the run is rendered.
+
+By default the run is replayed instantly.  Pass {{bold}}--speed=N{{/}} to reproduce the timing of the original run, sped up by a factor of N.`,
+		DocLink: "replaying-reports",
+		Command: func(args []string, _ []string) {
+			if len(args) == 0 || args[0] != "replay" {
+				command.AbortWithUsage("ginkgo report requires a subcommand - try ginkgo report replay report.json")
+			}
+			//flags can follow the subcommand, so we parse the remaining arguments again
+			args, err := flags.Parse(args[1:])
+			if err != nil {
+				command.AbortWithUsage(err.Error())
+			}
+			Replay(args, reporterConfig, cliConfig)
+		},
+	}
+}
+
+func Replay(args []string, reporterConfig types.ReporterConfig, cliConfig types.CLIConfig) {
+	if len(args) != 1 {
+		command.AbortWithUsage("ginkgo report replay requires exactly one JSON report")
+	}
+
+	reports, err := reporters.ReadJSONReport(args[0])
+	command.AbortIfError("Failed to load report:", err)
+	if len(reports) == 0 {
+		command.AbortWith("%s does not contain any reports", args[0])
+	}
+
+	for _, report := range reports {
+		reporter := reporters.NewDefaultReporter(reporterConfig, formatter.ColorableStdOut)
+		reporters.ReplayReport(reporter, report, cliConfig.ReplaySpeed)
+	}
+}
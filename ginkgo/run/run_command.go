@@ -3,6 +3,7 @@ package run
 import (
 	"fmt"
 	"os"
+	"path/filepath"
 	"strings"
 	"time"
 
@@ -37,13 +38,17 @@ func BuildRunCommand() command.Command {
 		Command: func(args []string, additionalArgs []string) {
 			var errors []error
 			cliConfig, goFlagsConfig, errors = types.VetAndInitializeCLIAndGoConfig(cliConfig, goFlagsConfig)
-			command.AbortIfErrors("Ginkgo detected configuration issues:", errors)
+			command.AbortIfConfigurationErrors(errors)
+
+			reportPipeline, err := internal.LoadReportPipeline(cliConfig.ConfigFile)
+			command.AbortIfError("Failed to load --config:", err)
 
 			runner := &SpecRunner{
 				cliConfig:      cliConfig,
 				goFlagsConfig:  goFlagsConfig,
 				suiteConfig:    suiteConfig,
 				reporterConfig: reporterConfig,
+				reportPipeline: reportPipeline,
 				flags:          flags,
 
 				interruptHandler: interruptHandler,
@@ -57,6 +62,7 @@ func BuildRunCommand() command.Command {
 type SpecRunner struct {
 	suiteConfig    types.SuiteConfig
 	reporterConfig types.ReporterConfig
+	reportPipeline internal.ReportPipeline
 	cliConfig      types.CLIConfig
 	goFlagsConfig  types.GoFlagsConfig
 	flags          types.GinkgoFlagSet
@@ -84,10 +90,25 @@ func (r *SpecRunner) RunSpecs(args []string, additionalArgs []string) {
 		command.AbortWith("Found no test suites")
 	}
 
-	if len(suites) > 1 && !r.flags.WasSet("succinct") && r.reporterConfig.Verbosity().LT(types.VerbosityLevelVerbose) {
+	if len(suites) > 1 && !r.flags.WasSet("succinct") && r.reporterConfig.Verbosity().Is(types.VerbosityLevelNormal) {
 		r.reporterConfig.Succinct = true
 	}
 
+	if r.suiteConfig.ReplayParallelSchedule != "" {
+		//suites run in their own directory so we resolve the path to the recorded report relative to the current working directory
+		r.suiteConfig.ReplayParallelSchedule, _ = filepath.Abs(r.suiteConfig.ReplayParallelSchedule)
+	}
+
+	if r.suiteConfig.ResourceRegistry != "" {
+		//suites run in their own directory so we resolve the path to the resource registry relative to the current working directory - this way all the suites share one registry
+		r.suiteConfig.ResourceRegistry, _ = filepath.Abs(r.suiteConfig.ResourceRegistry)
+	}
+
+	if r.suiteConfig.FilterValidationBehavior() != types.ValidateFiltersOff && len(suites) > 1 {
+		//a filter only needs to match specs in one of the suites so the suites record the filters they did not match and we validate the filters once they have all run
+		r.suiteConfig.UnmatchedFiltersFile = internal.UNMATCHED_FILTERS_FILE
+	}
+
 	t := time.Now()
 	var endTime time.Time
 	if r.suiteConfig.Timeout > 0 {
@@ -97,9 +118,9 @@ func (r *SpecRunner) RunSpecs(args []string, additionalArgs []string) {
 	iteration := 0
 OUTER_LOOP:
 	for {
-		if !r.flags.WasSet("seed") {
-			r.suiteConfig.RandomSeed = time.Now().Unix()
-		}
+		seed, err := internal.RandomSeed(r.flags, r.suiteConfig)
+		command.AbortIfError("Failed to compute the random seed:", err)
+		r.suiteConfig.RandomSeed = seed
 		if r.cliConfig.RandomizeSuites && len(suites) > 1 {
 			suites = suites.ShuffledCopy(r.suiteConfig.RandomSeed)
 		}
@@ -148,7 +169,7 @@ OUTER_LOOP:
 				}
 			}
 
-			suites[suiteIdx] = internal.RunCompiledSuite(suites[suiteIdx], r.suiteConfig, r.reporterConfig, r.cliConfig, r.goFlagsConfig, additionalArgs)
+			suites[suiteIdx] = internal.RunCompiledSuite(suites[suiteIdx], r.suiteConfig, r.reportPipeline.ReporterConfigForSuites(r.reporterConfig), r.cliConfig, r.goFlagsConfig, additionalArgs)
 		}
 
 		if suites.CountWithState(internal.TestSuiteStateFailureStates...) > 0 {
@@ -170,15 +191,28 @@ OUTER_LOOP:
 
 	internal.Cleanup(r.goFlagsConfig, suites...)
 
-	messages, err := internal.FinalizeProfilesAndReportsForSuites(suites, r.cliConfig, r.suiteConfig, r.reporterConfig, r.goFlagsConfig)
+	messages, err := internal.FinalizeProfilesAndReportsForSuites(suites, r.cliConfig, r.suiteConfig, r.reporterConfig, r.reportPipeline, r.goFlagsConfig)
 	command.AbortIfError("could not finalize profiles:", err)
 	for _, message := range messages {
 		fmt.Println(message)
 	}
 
 	fmt.Printf("\nGinkgo ran %d %s in %s\n", len(suites), internal.PluralizedWord("suite", "suites", len(suites)), time.Since(t))
+	if len(suites) > 1 {
+		fmt.Fprint(formatter.ColorableStdOut, internal.ResourceUsageReport(suites, formatter.NewWithNoColorBool(r.reporterConfig.NoColor)))
+	}
 
-	if suites.CountWithState(internal.TestSuiteStateFailureStates...) == 0 {
+	filtersFailed := false
+	if r.suiteConfig.UnmatchedFiltersFile != "" {
+		unmatchedFilters, err := internal.UnmatchedFiltersAcrossSuites(suites, r.cliConfig)
+		command.AbortIfError("could not validate filters:", err)
+		if len(unmatchedFilters) > 0 {
+			filtersFailed = r.suiteConfig.FilterValidationBehavior() == types.ValidateFiltersFail
+			fmt.Fprint(formatter.ColorableStdOut, internal.UnmatchedFiltersReport(unmatchedFilters, filtersFailed, formatter.NewWithNoColorBool(r.reporterConfig.NoColor)))
+		}
+	}
+
+	if suites.CountWithState(internal.TestSuiteStateFailureStates...) == 0 && !filtersFailed {
 		if suites.AnyHaveProgrammaticFocus() && strings.TrimSpace(os.Getenv("GINKGO_EDITOR_INTEGRATION")) == "" {
 			fmt.Printf("Test Suite Passed\n")
 			fmt.Printf("Detected Programmatic Focus - setting exit status to %d\n", types.GINKGO_FOCUS_EXIT_CODE)
@@ -193,8 +227,16 @@ OUTER_LOOP:
 			fmt.Fprintln(formatter.ColorableStdOut,
 				internal.FailedSuitesReport(suites, formatter.NewWithNoColorBool(r.reporterConfig.NoColor)))
 		}
+		exitReason := suites.ExitReason()
+		if filtersFailed {
+			fmt.Printf("Filters matched no specs and --validate-filters=fail is set\n")
+			exitReason = exitReason.Combine(types.ExitReasonFailed)
+		}
 		fmt.Printf("Test Suite Failed\n")
-		command.Abort(command.AbortDetails{ExitCode: 1})
+		if exitReason != types.ExitReasonFailed {
+			fmt.Printf("Detected %s - setting exit status to %d\n", exitReason, exitReason.ExitCode())
+		}
+		command.Abort(command.AbortDetails{ExitCode: exitReason.ExitCode()})
 	}
 }
 
@@ -0,0 +1,85 @@
+package sweep
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/onsi/ginkgo/v2/formatter"
+	"github.com/onsi/ginkgo/v2/ginkgo/command"
+	"github.com/onsi/ginkgo/v2/types"
+)
+
+func BuildSweepCommand() command.Command {
+	var cliConfig = types.NewDefaultCLIConfig()
+
+	flags, err := types.BuildSweepCommandFlagSet(&cliConfig)
+	if err != nil {
+		panic(err)
+	}
+
+	return command.Command{
+		Name:     "sweep",
+		Usage:    "ginkgo sweep <FLAGS> <RESOURCE-REGISTRY>",
+		Flags:    flags,
+		ShortDoc: "Delete the resources orphaned by crashed runs that are recorded in the passed-in resource registry.",
+		Documentation: `Suites run with {{bold}}--resource-registry=PATH{{/}} record the resources registered with {{bold}}RegisterResource{{/}} in the registry at PATH.
+ginkgo sweep runs the sweep command of every resource that was registered but never cleaned up.`,
+		DocLink: "sweeping-orphaned-resources",
+		Command: func(args []string, _ []string) {
+			Sweep(args, cliConfig)
+		},
+	}
+}
+
+func Sweep(args []string, cliConfig types.CLIConfig) {
+	if len(args) != 1 {
+		command.AbortWithUsage("ginkgo sweep requires exactly one resource registry")
+	}
+	path := args[0]
+
+	orphans, err := types.LoadOrphanedResources(path)
+	command.AbortIfError("Failed to load resource registry:", err)
+	if len(orphans) == 0 {
+		fmt.Println("No orphaned resources found")
+		return
+	}
+
+	numFailures := 0
+	for _, orphan := range orphans {
+		fmt.Println(formatter.F("{{bold}}%s{{/}} {{gray}}registered by \"%s\" at %s{{/}}", orphan.Resource, orphan.SpecText, orphan.CodeLocation))
+		sweepCommand := orphan.Resource.SweepCommand
+		if len(sweepCommand) == 0 {
+			fmt.Println(formatter.Fi(1, "{{red}}No sweep command was registered - you must delete this resource manually{{/}}"))
+			numFailures += 1
+			continue
+		}
+		if cliConfig.SweepDryRun {
+			fmt.Println(formatter.Fi(1, "{{gray}}Would run: %s{{/}}", strings.Join(sweepCommand, " ")))
+			continue
+		}
+
+		output, err := exec.Command(sweepCommand[0], sweepCommand[1:]...).CombinedOutput()
+		if err != nil {
+			fmt.Println(formatter.Fi(1, "{{red}}Failed to sweep: %s{{/}}", err.Error()))
+			if len(output) > 0 {
+				fmt.Println(formatter.Fi(1, "%s", output))
+			}
+			numFailures += 1
+			continue
+		}
+
+		err = types.AppendToResourceRegistry(path, types.ResourceRecord{
+			Event:    types.ResourceEventReleased,
+			Resource: orphan.Resource,
+			Time:     time.Now(),
+		})
+		command.AbortIfError("Failed to update resource registry:", err)
+		fmt.Println(formatter.Fi(1, "{{green}}Swept{{/}}"))
+	}
+
+	if numFailures > 0 {
+		command.AbortWith("Failed to sweep %d of %d orphaned resources", numFailures, len(orphans))
+	}
+}
@@ -52,6 +52,29 @@ func unfocusSpecs() {
 	wg.Wait()
 }
 
+// FindFocus returns the file:line locations of the programmatic focus under path without modifying any files
+func FindFocus(path string) []string {
+	goFiles := make(chan string)
+	go func() {
+		unfocusDir(goFiles, path)
+		close(goFiles)
+	}()
+
+	locations := []string{}
+	for goFile := range goFiles {
+		fset := token.NewFileSet()
+		file, err := parser.ParseFile(fset, goFile, nil, 0)
+		if err != nil {
+			continue
+		}
+		for _, elimination := range scanForFocus(file) {
+			position := fset.Position(file.Pos() + token.Pos(elimination[0]))
+			locations = append(locations, fmt.Sprintf("%s:%d", goFile, position.Line))
+		}
+	}
+	return locations
+}
+
 func unfocusDir(goFiles chan string, path string) {
 	files, err := os.ReadDir(path)
 	if err != nil {
@@ -0,0 +1,86 @@
+package watch
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+
+	"github.com/onsi/ginkgo/v2/formatter"
+	"github.com/onsi/ginkgo/v2/ginkgo/internal"
+)
+
+const interactiveHelp = `{{bold}}Interactive commands:{{/}}
+  {{bold}}r{{/}}             re-run the suites that failed in the last run
+  {{bold}}a{{/}}             re-run all the suites from the last run
+  {{bold}}v{{/}}             toggle verbose output
+  {{bold}}f <regexp>{{/}}    only run specs whose text matches <regexp> - {{bold}}f{{/}} on its own clears the focus filter
+  {{bold}}h{{/}}             print this help`
+
+// readInteractiveCommands reads commands from in, one per line, and sends them down the returned channel
+func readInteractiveCommands(in io.Reader) chan string {
+	commands := make(chan string)
+	go func() {
+		scanner := bufio.NewScanner(in)
+		for scanner.Scan() {
+			commands <- strings.TrimSpace(scanner.Text())
+		}
+	}()
+	return commands
+}
+
+// handleInteractiveCommand applies the passed-in command and returns the suites it wants re-run, if any
+func (w *SpecWatcher) handleInteractiveCommand(command string) internal.TestSuites {
+	coloredStream := formatter.ColorableStdOut
+	name, arg := command, ""
+	if idx := strings.Index(command, " "); idx > -1 {
+		name, arg = command[:idx], strings.TrimSpace(command[idx+1:])
+	}
+
+	switch name {
+	case "":
+		return nil
+	case "r":
+		failedSuites := w.lastRun.WithState(internal.TestSuiteStateFailureStates...)
+		if len(failedSuites) == 0 {
+			fmt.Fprintln(coloredStream, formatter.F("{{green}}The last run had no failures to re-run{{/}}"))
+		}
+		return failedSuites
+	case "a":
+		if len(w.lastRun) == 0 {
+			fmt.Fprintln(coloredStream, formatter.F("{{orange}}No suites have run yet{{/}}"))
+		}
+		return w.lastRun
+	case "v":
+		w.reporterConfig.Verbose = !w.reporterConfig.Verbose
+		w.reporterConfig.VeryVerbose = false
+		if w.reporterConfig.Verbose {
+			w.reporterConfig.Quiet = false
+			fmt.Fprintln(coloredStream, formatter.F("{{green}}Verbose output is on{{/}}"))
+		} else {
+			// verbose output turns off succinct and quiet mode, so restore them if they were asked for
+			w.reporterConfig.Succinct = w.flags.WasSet("succinct")
+			w.reporterConfig.Quiet = w.flags.WasSet("quiet")
+			fmt.Fprintln(coloredStream, formatter.F("{{green}}Verbose output is off{{/}}"))
+		}
+	case "f":
+		if arg == "" {
+			w.suiteConfig.FocusStrings = []string{}
+			fmt.Fprintln(coloredStream, formatter.F("{{green}}Cleared the focus filter{{/}}"))
+			break
+		}
+		if _, err := regexp.Compile(arg); err != nil {
+			fmt.Fprintln(coloredStream, formatter.F("{{red}}Invalid focus filter %s: %s{{/}}", arg, err.Error()))
+			break
+		}
+		w.suiteConfig.FocusStrings = []string{arg}
+		fmt.Fprintln(coloredStream, formatter.F("{{green}}Will only run specs matching %s{{/}}", arg))
+	case "h", "help", "?":
+		fmt.Fprintln(coloredStream, formatter.F(interactiveHelp))
+	default:
+		fmt.Fprintln(coloredStream, formatter.F("{{red}}Unknown command %s{{/}}", name))
+		fmt.Fprintln(coloredStream, formatter.F(interactiveHelp))
+	}
+	return nil
+}
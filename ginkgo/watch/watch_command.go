@@ -2,6 +2,8 @@ package watch
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
 	"regexp"
 	"time"
 
@@ -35,13 +37,17 @@ func BuildWatchCommand() command.Command {
 		Command: func(args []string, additionalArgs []string) {
 			var errors []error
 			cliConfig, goFlagsConfig, errors = types.VetAndInitializeCLIAndGoConfig(cliConfig, goFlagsConfig)
-			command.AbortIfErrors("Ginkgo detected configuration issues:", errors)
+			command.AbortIfConfigurationErrors(errors)
+
+			reportPipeline, err := internal.LoadReportPipeline(cliConfig.ConfigFile)
+			command.AbortIfError("Failed to load --config:", err)
 
 			watcher := &SpecWatcher{
 				cliConfig:      cliConfig,
 				goFlagsConfig:  goFlagsConfig,
 				suiteConfig:    suiteConfig,
 				reporterConfig: reporterConfig,
+				reportPipeline: reportPipeline,
 				flags:          flags,
 
 				interruptHandler: interruptHandler,
@@ -55,11 +61,15 @@ func BuildWatchCommand() command.Command {
 type SpecWatcher struct {
 	suiteConfig    types.SuiteConfig
 	reporterConfig types.ReporterConfig
+	reportPipeline internal.ReportPipeline
 	cliConfig      types.CLIConfig
 	goFlagsConfig  types.GoFlagsConfig
 	flags          types.GinkgoFlagSet
 
 	interruptHandler *interrupt_handler.InterruptHandler
+
+	// lastRun holds the suites from the most recent run.  In interactive mode their compiled binaries are kept around so they can be re-run without recompiling.
+	lastRun internal.TestSuites
 }
 
 func (w *SpecWatcher) WatchSpecs(args []string, additionalArgs []string) {
@@ -69,6 +79,11 @@ func (w *SpecWatcher) WatchSpecs(args []string, additionalArgs []string) {
 		command.AbortWith("Found no test suites")
 	}
 
+	if w.suiteConfig.ResourceRegistry != "" {
+		//suites run in their own directory so we resolve the path to the resource registry relative to the current working directory - this way all the suites share one registry
+		w.suiteConfig.ResourceRegistry, _ = filepath.Abs(w.suiteConfig.ResourceRegistry)
+	}
+
 	fmt.Printf("Identified %d test %s.  Locating dependencies to a depth of %d (this may take a while)...\n", len(suites), internal.PluralizedWord("suite", "suites", len(suites)), w.cliConfig.Depth)
 	deltaTracker := NewDeltaTracker(w.cliConfig.Depth, regexp.MustCompile(w.cliConfig.WatchRegExp))
 	delta, errors := deltaTracker.Delta(suites)
@@ -82,15 +97,30 @@ func (w *SpecWatcher) WatchSpecs(args []string, additionalArgs []string) {
 		fmt.Printf("Failed to watch %s: %s\n", suite.PackageName, err)
 	}
 
+	var commands chan string
+	if w.cliConfig.Interactive {
+		commands = readInteractiveCommands(os.Stdin)
+		defer func() { internal.Cleanup(w.goFlagsConfig, w.lastRun...) }()
+		fmt.Fprintln(formatter.ColorableStdOut, formatter.F(interactiveHelp))
+	}
+
 	if len(suites) == 1 {
 		w.updateSeed()
-		w.compileAndRun(suites[0], additionalArgs)
+		w.lastRun = internal.TestSuites{w.compileAndRun(suites[0], additionalArgs)}
 	}
 
 	ticker := time.NewTicker(time.Second)
 
 	for {
 		select {
+		case command := <-commands:
+			suites := w.handleInteractiveCommand(command)
+			if len(suites) == 0 {
+				break
+			}
+			if !w.runSuites(suites, nil, additionalArgs) {
+				return
+			}
 		case <-ticker.C:
 			suites := internal.FindSuites(args, w.cliConfig, false).WithoutState(internal.TestSuiteStateSkippedByFilter)
 			delta, _ := deltaTracker.Delta(suites)
@@ -124,25 +154,8 @@ func (w *SpecWatcher) WatchSpecs(args []string, additionalArgs []string) {
 				break
 			}
 
-			w.updateSeed()
-			w.computeSuccinctMode(len(suites))
-			for idx := range suites {
-				if w.interruptHandler.Status().Interrupted {
-					return
-				}
-				deltaTracker.WillRun(suites[idx])
-				suites[idx] = w.compileAndRun(suites[idx], additionalArgs)
-			}
-			color := "{{green}}"
-			if suites.CountWithState(internal.TestSuiteStateFailureStates...) > 0 {
-				color = "{{red}}"
-			}
-			fmt.Fprintln(coloredStream, formatter.F(color+"\nDone.  Resuming watch...{{/}}"))
-
-			messages, err := internal.FinalizeProfilesAndReportsForSuites(suites, w.cliConfig, w.suiteConfig, w.reporterConfig, w.goFlagsConfig)
-			command.AbortIfError("could not finalize profiles:", err)
-			for _, message := range messages {
-				fmt.Println(message)
+			if !w.runSuites(suites, deltaTracker, additionalArgs) {
+				return
 			}
 		case <-w.interruptHandler.Status().Channel:
 			return
@@ -150,6 +163,57 @@ func (w *SpecWatcher) WatchSpecs(args []string, additionalArgs []string) {
 	}
 }
 
+// runSuites compiles and runs the passed-in suites and reports back whether watching should continue
+func (w *SpecWatcher) runSuites(suites internal.TestSuites, deltaTracker *DeltaTracker, additionalArgs []string) bool {
+	w.updateSeed()
+	w.computeSuccinctMode(len(suites))
+	if w.cliConfig.Interactive {
+		// the compiled binaries of suites that are about to be recompiled will be overwritten, so we only need to clean up the rest
+		internal.Cleanup(w.goFlagsConfig, suitesNotIn(w.lastRun, suites)...)
+	}
+	for idx := range suites {
+		if w.interruptHandler.Status().Interrupted {
+			return false
+		}
+		if deltaTracker != nil {
+			deltaTracker.WillRun(suites[idx])
+		}
+		suites[idx] = w.compileAndRun(suites[idx], additionalArgs)
+	}
+	w.lastRun = suites
+
+	coloredStream := formatter.ColorableStdOut
+	color := "{{green}}"
+	if suites.CountWithState(internal.TestSuiteStateFailureStates...) > 0 {
+		color = "{{red}}"
+	}
+	fmt.Fprintln(coloredStream, formatter.F(color+"\nDone.  Resuming watch...{{/}}"))
+
+	messages, err := internal.FinalizeProfilesAndReportsForSuites(suites, w.cliConfig, w.suiteConfig, w.reporterConfig, w.reportPipeline, w.goFlagsConfig)
+	command.AbortIfError("could not finalize profiles:", err)
+	for _, message := range messages {
+		fmt.Println(message)
+	}
+	return true
+}
+
+func suitesNotIn(suites internal.TestSuites, others internal.TestSuites) internal.TestSuites {
+	out := internal.TestSuites{}
+	for _, suite := range suites {
+		found := false
+		for _, other := range others {
+			if other.Path == suite.Path {
+				found = true
+				break
+			}
+		}
+		if !found {
+			out = append(out, suite)
+		}
+	}
+	return out
+}
+
 func (w *SpecWatcher) compileAndRun(suite internal.TestSuite, additionalArgs []string) internal.TestSuite {
 	suite = internal.CompileSuite(suite, w.goFlagsConfig)
 	if suite.State.Is(internal.TestSuiteStateFailedToCompile) {
@@ -159,13 +223,15 @@ func (w *SpecWatcher) compileAndRun(suite internal.TestSuite, additionalArgs []s
 	if w.interruptHandler.Status().Interrupted {
 		return suite
 	}
-	suite = internal.RunCompiledSuite(suite, w.suiteConfig, w.reporterConfig, w.cliConfig, w.goFlagsConfig, additionalArgs)
-	internal.Cleanup(w.goFlagsConfig, suite)
+	suite = internal.RunCompiledSuite(suite, w.suiteConfig, w.reportPipeline.ReporterConfigForSuites(w.reporterConfig), w.cliConfig, w.goFlagsConfig, additionalArgs)
+	if !w.cliConfig.Interactive {
+		internal.Cleanup(w.goFlagsConfig, suite)
+	}
 	return suite
 }
 
 func (w *SpecWatcher) computeSuccinctMode(numSuites int) {
-	if w.reporterConfig.Verbosity().GTE(types.VerbosityLevelVerbose) {
+	if w.reporterConfig.Verbose || w.reporterConfig.VeryVerbose || w.reporterConfig.Quiet {
 		w.reporterConfig.Succinct = false
 		return
 	}
@@ -184,7 +250,7 @@ func (w *SpecWatcher) computeSuccinctMode(numSuites int) {
 }
 
 func (w *SpecWatcher) updateSeed() {
-	if !w.flags.WasSet("seed") {
-		w.suiteConfig.RandomSeed = time.Now().Unix()
-	}
+	seed, err := internal.RandomSeed(w.flags, w.suiteConfig)
+	command.AbortIfError("Failed to compute the random seed:", err)
+	w.suiteConfig.RandomSeed = seed
 }
@@ -0,0 +1,26 @@
+package output_filter_fixture_test
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestOutputFilterFixture(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "OutputFilterFixture Suite")
+}
+
+var _ = BeforeSuite(func() {
+	RedactInterceptedOutput(regexp.MustCompile(`password=\w+`), "password=<REDACTED>")
+	SuppressInterceptedOutput(regexp.MustCompile(`^HEXDUMP`))
+})
+
+var _ = It("filters intercepted output", func() {
+	fmt.Println("logging in with password=hunter2")
+	fmt.Println("HEXDUMP 0xdeadbeef 0xdeadbeef 0xdeadbeef")
+	fmt.Println("done")
+})
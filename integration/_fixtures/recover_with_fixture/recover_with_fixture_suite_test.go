@@ -0,0 +1,13 @@
+package recover_with_fixture_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestRecoverWithFixture(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "RecoverWithFixture Suite")
+}
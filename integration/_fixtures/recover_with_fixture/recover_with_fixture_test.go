@@ -0,0 +1,43 @@
+package recover_with_fixture_test
+
+import (
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+)
+
+var _ = Describe("recovering panics in goroutines with GinkgoRecoverWith", func() {
+	runInGoroutine := func(handler func(interface{}), body func()) {
+		done := make(chan interface{})
+		go func() {
+			defer close(done)
+			defer GinkgoRecoverWith(handler)
+			body()
+		}()
+		<-done
+	}
+
+	It("observes the panic", func() {
+		runInGoroutine(func(recovered interface{}) {
+			AddReportEntry("observed", recovered)
+		}, func() {
+			panic("an observed panic")
+		})
+	})
+
+	It("converts the panic into a failure", func() {
+		runInGoroutine(func(recovered interface{}) {
+			Fail(fmt.Sprintf("worker crashed: %v", recovered))
+		}, func() {
+			panic("a converted panic")
+		})
+	})
+
+	It("augments the panic", func() {
+		runInGoroutine(func(recovered interface{}) {
+			panic(fmt.Sprintf("worker 3: %v", recovered))
+		}, func() {
+			panic("an augmented panic")
+		})
+	})
+})
@@ -0,0 +1,29 @@
+package resource_registry_fixture_test
+
+import (
+	"os"
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestResourceRegistryFixture(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "ResourceRegistryFixture Suite")
+}
+
+func fileResource(name string) Resource {
+	return Resource{Kind: "file", Name: name, SweepCommand: []string{"touch", "swept-" + name}}
+}
+
+var _ = Describe("resources", Ordered, func() {
+	It("cleans up after itself", func() {
+		RegisterResource(fileResource("cleaned"), func() {})
+	})
+
+	It("crashes before cleaning up", func() {
+		RegisterResource(fileResource("orphaned"), func() {})
+		os.Exit(1)
+	})
+})
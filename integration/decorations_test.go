@@ -29,7 +29,7 @@ some decorated tests
 
 	It("exits with a clear error if decorations are misconfigured", func() {
 		session := startGinkgo(fm.PathTo("decorations", "invalid_decorations"), "-v", "--no-color")
-		Eventually(session).Should(gexec.Exit(1))
+		Eventually(session).Should(gexec.Exit(types.GINKGO_CONFIGURATION_ERROR_EXIT_CODE))
 		Ω(session).Should(gbytes.Say("Invalid Combination of Decorators: Focused and Pending"))
 	})
 })
@@ -1,6 +1,8 @@
 package integration_test
 
 import (
+	"strings"
+
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"github.com/onsi/gomega/gexec"
@@ -21,4 +23,24 @@ var _ = Describe("Deprecations", func() {
 		Ω(contents).Should(ContainSubstring("--stream is deprecated"))
 		Ω(contents).Should(ContainSubstring("--randomizeAllSpecs is deprecated"))
 	})
+
+	It("includes the deprecations, their code locations, and links to the migration docs in the JSON report", func() {
+		session := startGinkgo(fm.PathTo("deprecated_features"), "--json-report=out.json")
+		Eventually(session).Should(gexec.Exit(0))
+
+		reports := fm.LoadJSONReports("deprecated_features", "out.json")
+		Ω(reports).Should(HaveLen(1))
+		deprecations := reports[0].Deprecations
+		messages := []string{}
+		for _, deprecation := range deprecations {
+			messages = append(messages, deprecation.Message)
+			if strings.HasPrefix(deprecation.Message, "Measure is deprecated") {
+				Ω(deprecation.DocLink).Should(Equal("https://onsi.github.io/ginkgo/MIGRATING_TO_V2#removed-measure"))
+				Ω(deprecation.CodeLocations).ShouldNot(BeEmpty())
+				Ω(deprecation.CodeLocations[0].FileName).Should(HaveSuffix("deprecated_features_fixture_suite_test.go"))
+			}
+		}
+		Ω(messages).Should(ContainElement(HavePrefix("You are passing a Done channel to a test node")))
+		Ω(messages).Should(ContainElement(HavePrefix("Measure is deprecated")))
+	})
 })
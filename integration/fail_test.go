@@ -4,6 +4,8 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"github.com/onsi/gomega/gexec"
+
+	"github.com/onsi/ginkgo/v2/types"
 )
 
 var _ = Describe("Failing Specs", func() {
@@ -40,6 +42,24 @@ var _ = Describe("Failing Specs", func() {
 		})
 	})
 
+	Describe("when goroutines recover their panics with GinkgoRecoverWith", func() {
+		BeforeEach(func() {
+			fm.MountFixture("recover_with")
+		})
+
+		It("hands each panic to the handler before failing the spec", func() {
+			session := startGinkgo(fm.PathTo("recover_with"), "--no-color")
+			Eventually(session).Should(gexec.Exit(1))
+			output := string(session.Out.Contents())
+
+			Ω(output).Should(ContainSubstring("an observed panic"))
+			Ω(output).Should(ContainSubstring("worker crashed: a converted panic"))
+			Ω(output).Should(ContainSubstring("worker 3: an augmented panic"))
+
+			Ω(output).Should(ContainSubstring("0 Passed | 3 Failed"))
+		})
+	})
+
 	Describe("when the tests are incorrectly structured", func() {
 		BeforeEach(func() {
 			fm.MountFixture("malformed")
@@ -47,7 +67,7 @@ var _ = Describe("Failing Specs", func() {
 
 		It("exits early with a helpful error message", func() {
 			session := startGinkgo(fm.PathTo("malformed"), "--no-color")
-			Eventually(session).Should(gexec.Exit(1))
+			Eventually(session).Should(gexec.Exit(types.GINKGO_CONFIGURATION_ERROR_EXIT_CODE))
 			output := string(session.Out.Contents())
 
 			Ω(output).Should(ContainSubstring("Ginkgo detected an issue with your spec structure"))
@@ -56,7 +76,7 @@ var _ = Describe("Failing Specs", func() {
 
 		It("emits the error message even if running in parallel", func() {
 			session := startGinkgo(fm.PathTo("malformed"), "--no-color", "--procs=2")
-			Eventually(session).Should(gexec.Exit(1))
+			Eventually(session).Should(gexec.Exit(types.GINKGO_CONFIGURATION_ERROR_EXIT_CODE))
 			output := string(session.Out.Contents()) + string(session.Err.Contents())
 
 			Ω(output).Should(ContainSubstring("Ginkgo detected an issue with your spec structure"))
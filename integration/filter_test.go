@@ -89,11 +89,34 @@ var _ = Describe("Filter", func() {
 
 	It("errors if the file-filter format is wrong", func() {
 		session := startGinkgo(fm.PathTo("filter"), "--focus-file=foo:bar", "--skip-file=")
-		Eventually(session).Should(gexec.Exit(1))
+		Eventually(session).Should(gexec.Exit(types.GINKGO_CONFIGURATION_ERROR_EXIT_CODE))
 		Ω(session).Should(gbytes.Say("Invalid File Filter"))
 		Ω(session).Should(gbytes.Say("Invalid File Filter"))
 	})
 
+	Describe("Validating filters across multiple suites", func() {
+		BeforeEach(func() {
+			fm.MountFixture("passing_ginkgo_tests")
+			fm.MountFixture("more_ginkgo_tests")
+		})
+
+		It("only flags the filters that match no specs in any of the suites", func() {
+			session := startGinkgo(fm.TmpDir, "--no-color", "--validate-filters=fail", "--focus=proxy", "--focus=always", "passing_ginkgo_tests", "more_ginkgo_tests")
+			Eventually(session).Should(gexec.Exit(0))
+			Ω(session).ShouldNot(gbytes.Say("did not match any specs"))
+		})
+
+		It("fails the run once all the suites have run when a filter matches no specs in any of the suites", func() {
+			session := startGinkgo(fm.TmpDir, "--no-color", "--validate-filters=fail", "--focus=proxy", "--focus=chekout", "passing_ginkgo_tests", "more_ginkgo_tests")
+			Eventually(session).Should(gexec.Exit(1))
+			Ω(session).Should(gbytes.Say("Ginkgo ran 2 suites"))
+			Ω(session).Should(gbytes.Say("Some filters did not match any specs in any suite:"))
+			Ω(session).Should(gbytes.Say(`--focus="chekout"`))
+			Ω(session).Should(gbytes.Say("Test Suite Failed"))
+			Ω(fm.PathTo("passing_ginkgo_tests", "ginkgo-unmatched-filters.json")).ShouldNot(BeAnExistingFile())
+		})
+	})
+
 	Describe("Listing labels", func() {
 		BeforeEach(func() {
 			fm.MountFixture("labels")
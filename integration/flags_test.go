@@ -41,6 +41,18 @@ var _ = Describe("Flags Specs", func() {
 		Ω(orders[1]).Should(BeNumerically("<", orders[2]))
 	})
 
+	It("honors --focus-exit-code", func() {
+		session := startGinkgo(fm.PathTo("flags"), "--no-color", "--focus-exit-code=warn")
+		Eventually(session).Should(gexec.Exit(0))
+		output := string(session.Out.Contents())
+		Ω(output).Should(ContainSubstring("Detected Programmatic Focus - the suite will not fail because --focus-exit-code=warn is set"))
+
+		session = startGinkgo(fm.PathTo("flags"), "--no-color", "--focus-exit-code=off")
+		Eventually(session).Should(gexec.Exit(0))
+		output = string(session.Out.Contents())
+		Ω(output).ShouldNot(ContainSubstring("Detected Programmatic Focus"))
+	})
+
 	It("should fail when there are pending tests and it is passed --fail-on-pending", func() {
 		session := startGinkgo(fm.PathTo("flags"), "--no-color", "--fail-on-pending")
 		Eventually(session).Should(gexec.Exit(1))
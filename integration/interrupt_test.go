@@ -32,7 +32,7 @@ var _ = Describe("Interrupt and Timeout", func() {
 			session.Interrupt()
 			Eventually(session).Should(gbytes.Say("Sleeping again..."))
 			session.Interrupt()
-			Eventually(session, 1000).Should(gexec.Exit(1))
+			Eventually(session, 1000).Should(gexec.Exit(types.GINKGO_INTERRUPTED_EXIT_CODE))
 		})
 
 		It("should emit the contents of the GinkgoWriter", func() {
@@ -65,7 +65,7 @@ var _ = Describe("Interrupt and Timeout", func() {
 			fm.MountFixture("hanging")
 
 			session = startGinkgo(fm.PathTo("hanging"), "--no-color", "--timeout=5s")
-			Eventually(session).Should(gexec.Exit(1))
+			Eventually(session).Should(gexec.Exit(types.GINKGO_TIMED_OUT_EXIT_CODE))
 		})
 
 		It("should report where and why the suite was interrupted", func() {
@@ -92,7 +92,7 @@ var _ = Describe("Interrupt and Timeout", func() {
 			fm.MountFixture("timeout")
 			session := startGinkgo(fm.PathTo("timeout"), "--no-color", "-r", "--timeout=10s", "--keep-going", "--json-report=out.json")
 			Eventually(session).Should(gbytes.Say("TimeoutA Suite"))
-			Eventually(session, "15s").Should(gexec.Exit(1))
+			Eventually(session, "15s").Should(gexec.Exit(types.GINKGO_TIMED_OUT_EXIT_CODE))
 			Ω(session).Should(gbytes.Say(`timeout_D ./timeout_D \[Suite did not run because the timeout elapsed\]`))
 
 			data := []byte(fm.ContentOf("timeout", "out.json"))
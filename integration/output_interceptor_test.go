@@ -6,6 +6,8 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"github.com/onsi/gomega/gexec"
+
+	"github.com/onsi/ginkgo/v2/types"
 )
 
 var _ = Describe("OutputInterceptor", func() {
@@ -47,4 +49,23 @@ var _ = Describe("OutputInterceptor", func() {
 			Ω(report.SpecReports[0].CapturedStdOutErr).Should(Equal("CAPTURED OUTPUT A\nCAPTURED OUTPUT B\n"))
 		})
 	})
+
+	Context("filtering intercepted output", func() {
+		BeforeEach(func() {
+			fm.MountFixture("output_filter")
+		})
+
+		It("redacts and suppresses intercepted output before it is reported", func() {
+			sess := startGinkgo(fm.PathTo("output_filter"), "--no-color", "--procs=2", "-v", "--json-report=report.json")
+			Eventually(sess).Should(gexec.Exit(0))
+
+			output := string(sess.Out.Contents())
+			Ω(output).Should(ContainSubstring("logging in with password=<REDACTED>"))
+			Ω(output).ShouldNot(ContainSubstring("hunter2"))
+			Ω(output).ShouldNot(ContainSubstring("HEXDUMP"))
+
+			report := fm.LoadJSONReports("output_filter", "report.json")[0]
+			Ω(report.SpecReports.WithLeafNodeType(types.NodeTypeIt)[0].CapturedStdOutErr).Should(Equal("logging in with password=<REDACTED>\ndone\n"))
+		})
+	})
 })
@@ -7,6 +7,8 @@ import (
 	. "github.com/onsi/gomega"
 	"github.com/onsi/gomega/gbytes"
 	"github.com/onsi/gomega/gexec"
+
+	"github.com/onsi/ginkgo/v2/types"
 )
 
 func extractRandomSeeds(content string) []string {
@@ -94,7 +96,7 @@ var _ = Describe("Repeat", func() {
 
 		It("errors out early", func() {
 			session := startGinkgo(fm.PathTo("eventually_failing"), "--repeat=3", "--until-it-fails", "--no-color")
-			Eventually(session).Should(gexec.Exit(1))
+			Eventually(session).Should(gexec.Exit(types.GINKGO_CONFIGURATION_ERROR_EXIT_CODE))
 			Ω(session.Err).Should(gbytes.Say("--repeat and --until-it-fails are both set"))
 		})
 	})
@@ -275,7 +275,7 @@ var _ = Describe("Reporting", func() {
 		Context("the default behavior", func() {
 			BeforeEach(func() {
 				session := startGinkgo(fm.PathTo("reporting"), "--no-color", "-r", "--keep-going", "--procs=2", "--json-report=out.json", "--junit-report=out.xml", "--teamcity-report=out.tc", "-seed=17")
-				Eventually(session).Should(gexec.Exit(1))
+				Eventually(session).Should(gexec.Exit(types.GINKGO_COMPILATION_ERROR_EXIT_CODE))
 				Ω(session).ShouldNot(gbytes.Say("Could not open"))
 			})
 
@@ -293,12 +293,23 @@ var _ = Describe("Reporting", func() {
 				checkTeamcitySubpackageReport(fm.ContentOf("reporting", "out.tc"))
 				checkTeamcityFailedCompilationReport(fm.ContentOf("reporting", "out.tc"))
 			})
+
+			It("records the resources each suite's processes consumed in the json report", func() {
+				reports := fm.LoadJSONReports("reporting", "out.json")
+				for _, idx := range []int{0, 2} {
+					Ω(reports[idx].ResourceUsage).ShouldNot(BeNil())
+					Ω(reports[idx].ResourceUsage.WallTime).Should(BeNumerically(">", 0))
+					Ω(reports[idx].ResourceUsage.CPUTime).Should(BeNumerically(">", 0))
+					Ω(reports[idx].ResourceUsage.MaxRSS).Should(BeNumerically(">", 0))
+				}
+				Ω(reports[1].ResourceUsage).Should(BeNil())
+			})
 		})
 
 		Context("with -output-dir", func() {
 			BeforeEach(func() {
 				session := startGinkgo(fm.PathTo("reporting"), "--no-color", "-r", "--keep-going", "--procs=2", "--json-report=out.json", "--junit-report=out.xml", "--teamcity-report=out.tc", "--output-dir=./reports", "-seed=17")
-				Eventually(session).Should(gexec.Exit(1))
+				Eventually(session).Should(gexec.Exit(types.GINKGO_COMPILATION_ERROR_EXIT_CODE))
 				Ω(session).ShouldNot(gbytes.Say("Could not open"))
 			})
 
@@ -321,7 +332,7 @@ var _ = Describe("Reporting", func() {
 		Context("with -keep-separate-reports", func() {
 			BeforeEach(func() {
 				session := startGinkgo(fm.PathTo("reporting"), "--no-color", "-r", "--keep-going", "--procs=2", "--json-report=out.json", "--junit-report=out.xml", "--teamcity-report=out.tc", "--keep-separate-reports", "-seed=17")
-				Eventually(session).Should(gexec.Exit(1))
+				Eventually(session).Should(gexec.Exit(types.GINKGO_COMPILATION_ERROR_EXIT_CODE))
 				Ω(session).ShouldNot(gbytes.Say("Could not open"))
 			})
 
@@ -353,7 +364,7 @@ var _ = Describe("Reporting", func() {
 		Context("with -keep-separate-reports and -output-dir", func() {
 			BeforeEach(func() {
 				session := startGinkgo(fm.PathTo("reporting"), "--no-color", "-r", "--keep-going", "--procs=2", "--json-report=out.json", "--junit-report=out.xml", "--teamcity-report=out.tc", "--keep-separate-reports", "--output-dir=./reports", "-seed=17")
-				Eventually(session).Should(gexec.Exit(1))
+				Eventually(session).Should(gexec.Exit(types.GINKGO_COMPILATION_ERROR_EXIT_CODE))
 				Ω(session).ShouldNot(gbytes.Say("Could not open"))
 			})
 
@@ -380,10 +391,49 @@ var _ = Describe("Reporting", func() {
 			})
 		})
 
+		Context("with a report pipeline configured in the -config file", func() {
+			BeforeEach(func() {
+				fm.WriteFile("reporting", "ginkgo.json", `{"report-pipeline": [
+					{"transform": "redact", "patterns": ["ginkgo-writer"]},
+					{"transform": "enrich-owners", "owners": {"reporting_sub_package": ["@sub-team"]}},
+					{"transform": "split-by-label"}
+				]}`)
+				session := startGinkgo(fm.PathTo("reporting"), "--no-color", "-r", "--keep-going", "--procs=2", "--json-report=out.json", "--junit-report=out.xml", "--config=ginkgo.json", "-seed=17")
+				Eventually(session).Should(gexec.Exit(types.GINKGO_COMPILATION_ERROR_EXIT_CODE))
+				Ω(session).ShouldNot(gbytes.Say("Could not open"))
+			})
+
+			It("applies the transforms, in order, before generating the reports", func() {
+				Ω(fm.PathTo("reporting", "out.json")).ShouldNot(BeAnExistingFile())
+				Ω(fm.PathTo("reporting", "ginkgo-report-pipeline-input.json")).ShouldNot(BeAnExistingFile())
+
+				reports := fm.LoadJSONReports("reporting", "out.dog.json")
+				Ω(reports).Should(HaveLen(1))
+				specReports := Reports(reports[0].SpecReports)
+				Ω(specReports.WithLeafNodeType(types.NodeTypeIt)).Should(HaveLen(1))
+				Ω(specReports.Find("is labelled")).Should(HavePassed())
+				Ω(specReports.FindByLeafNodeType(types.NodeTypeBeforeSuite)).Should(HavePassed())
+				Ω(fm.LoadJUnitReport("reporting", "out.dog.xml").TestSuites).Should(HaveLen(1))
+
+				reports = fm.LoadJSONReports("reporting", "out.unlabeled.json")
+				Ω(reports).Should(HaveLen(3))
+				specReports = Reports(reports[0].SpecReports)
+				Ω(specReports.WithLeafNodeType(types.NodeTypeIt)).Should(HaveLen(5))
+				Ω(specReports.Find("fails")).Should(HaveFailed("fail!", CapturedGinkgoWriterOutput("some [REDACTED] output")))
+				Ω(specReports.Find("fails").ReportEntries).Should(BeEmpty())
+				Ω(reports[1].SpecialSuiteFailureReasons).Should(ContainElement(ContainSubstring("Failed to compile malformed_sub_package")))
+				subPackageSpec := Reports(reports[2].SpecReports).Find("passes here too")
+				Ω(subPackageSpec.ReportEntries).Should(HaveLen(1))
+				Ω(subPackageSpec.ReportEntries[0].Name).Should(Equal("Owners"))
+				Ω(subPackageSpec.ReportEntries[0].StringRepresentation()).Should(Equal("@sub-team"))
+				Ω(fm.LoadJUnitReport("reporting", "out.unlabeled.xml").TestSuites).Should(HaveLen(3))
+			})
+		})
+
 		Context("when keep-going is not set and a suite fails", func() {
 			BeforeEach(func() {
 				session := startGinkgo(fm.PathTo("reporting"), "--no-color", "-r", "--procs=2", "--json-report=out.json", "--junit-report=out.xml", "--teamcity-report=out.tc", "-coverprofile=cover.out", "-cpuprofile=cpu.out", "-seed=17", "--output-dir=./reports")
-				Eventually(session).Should(gexec.Exit(1))
+				Eventually(session).Should(gexec.Exit(types.GINKGO_COMPILATION_ERROR_EXIT_CODE))
 				Ω(session).ShouldNot(gbytes.Say("Could not open"))
 			})
 
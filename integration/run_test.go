@@ -70,6 +70,15 @@ var _ = Describe("Running Specs", func() {
 			Ω(output).Should(ContainSubstring("Running Suite: More_ginkgo_tests Suite"))
 			Ω(output).Should(ContainSubstring("Test Suite Passed"))
 		})
+
+		It("summarizes the resources each suite consumed", func() {
+			session := startGinkgo(fm.TmpDir, "--no-color", "passing_ginkgo_tests", "more_ginkgo_tests")
+			Eventually(session).Should(gexec.Exit(0))
+
+			Ω(session).Should(gbytes.Say("Resource usage by suite:"))
+			Ω(session).Should(gbytes.Say(`_ginkgo_tests wall: \S+\s+cpu: \S+\s+max rss: \S+\s+\S+_ginkgo_tests`))
+			Ω(session).Should(gbytes.Say(`_ginkgo_tests wall: \S+\s+cpu: \S+\s+max rss: \S+\s+\S+_ginkgo_tests`))
+		})
 	})
 
 	Context("when passed a number of packages to run, some of which have focused tests", func() {
@@ -249,7 +258,7 @@ var _ = Describe("Running Specs", func() {
 
 		It("should fail", func() {
 			session := startGinkgo(fm.PathTo("does_not_compile"), "--no-color")
-			Eventually(session).Should(gexec.Exit(1))
+			Eventually(session).Should(gexec.Exit(types.GINKGO_COMPILATION_ERROR_EXIT_CODE))
 			output := string(session.Out.Contents())
 
 			Ω(output).Should(ContainSubstring("Failed to compile"))
@@ -289,6 +298,21 @@ var _ = Describe("Running Specs", func() {
 				Ω(output).Should(ContainSubstring("Test Suite Passed"))
 			})
 		})
+
+		Context("with --procs=auto", func() {
+			It("launches a process per CPU and runs all the specs", func() {
+				nodes := runtime.NumCPU()
+				if nodes == 1 {
+					Skip("Can't test parallel testings with 1 CPU")
+				}
+				session := startGinkgo(fm.PathTo("passing_ginkgo_tests"), "--no-color", "-succinct", "--procs=auto")
+				Eventually(session).Should(gexec.Exit(0))
+				output := string(session.Out.Contents())
+
+				Ω(output).Should(MatchRegexp(`\[\d+\] Passing_ginkgo_tests Suite - 4/4 specs - %d procs [%s]{4} SUCCESS! \d+(\.\d+)?[muµ]?s`, nodes, regexp.QuoteMeta(denoter)))
+				Ω(output).Should(ContainSubstring("Test Suite Passed"))
+			})
+		})
 	})
 
 	Context("when running in parallel and there are specs marked Serial", Label("slow"), func() {
@@ -315,7 +339,7 @@ var _ = Describe("Running Specs", func() {
 		It("always preserve spec order within ordered contexts", func() {
 			By("running a carefully crafted test without the ordered decorator")
 			session := startGinkgo(fm.PathTo("ordered"), "--no-color", "--procs=2", "-v", "--randomize-all", "--fail-fast", "--", "--no-ordered")
-			Eventually(session).Should(gexec.Exit(1))
+			Eventually(session).Should(gexec.Exit(types.GINKGO_CONFIGURATION_ERROR_EXIT_CODE))
 
 			By("running a carefully crafted test with the ordered decorator")
 			session = startGinkgo(fm.PathTo("ordered"), "--no-color", "--procs=2", "-v", "--randomize-all", "--fail-fast")
@@ -386,7 +410,7 @@ var _ = Describe("Running Specs", func() {
 
 			It("should fail and stop running tests", func() {
 				session := startGinkgo(fm.TmpDir, "--no-color", "no_tagged_tests", "passing_ginkgo_tests", "does_not_compile", "more_ginkgo_tests")
-				Eventually(session).Should(gexec.Exit(1))
+				Eventually(session).Should(gexec.Exit(types.GINKGO_COMPILATION_ERROR_EXIT_CODE))
 				output := string(session.Out.Contents())
 
 				outputLines := strings.Split(output, "\n")
@@ -406,7 +430,7 @@ var _ = Describe("Running Specs", func() {
 
 			It("should soldier on", func() {
 				session := startGinkgo(fm.TmpDir, "--no-color", "-keep-going", "no_tagged_tests", "passing_ginkgo_tests", "does_not_compile", "failing_ginkgo_tests", "more_ginkgo_tests")
-				Eventually(session).Should(gexec.Exit(1))
+				Eventually(session).Should(gexec.Exit(types.GINKGO_COMPILATION_ERROR_EXIT_CODE))
 				output := string(session.Out.Contents())
 
 				outputLines := strings.Split(output, "\n")
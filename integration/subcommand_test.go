@@ -7,6 +7,7 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	"github.com/onsi/ginkgo/v2/types"
 	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
 	"github.com/onsi/gomega/gexec"
 )
 
@@ -481,6 +482,174 @@ var _ = Describe("Subcommand", func() {
 		})
 	})
 
+	Describe("ginkgo debug", func() {
+		BeforeEach(func() {
+			fm.MountFixture("passing_ginkgo_tests")
+		})
+
+		//ginkgo debug disables optimizations for all packages, so the first compilation can take a while
+
+		It("runs the single spec matching the passed-in text, verbosely", func() {
+			session := startGinkgo(fm.PathTo("passing_ginkgo_tests"), "debug", "--no-color", "proxy strings")
+			Eventually(session, "2m").Should(gexec.Exit(0))
+			output := string(session.Out.Contents())
+
+			Ω(output).Should(ContainSubstring("should proxy strings"))
+			Ω(output).ShouldNot(ContainSubstring("should proxy integers"))
+			Ω(output).Should(ContainSubstring("Ran 1 of 4 Specs"))
+		})
+
+		It("runs the single spec at the passed-in location", func() {
+			session := startGinkgo(fm.PathTo("passing_ginkgo_tests"), "debug", "--no-color", "passing_ginkgo_tests_test.go:14")
+			Eventually(session, "2m").Should(gexec.Exit(0))
+			output := string(session.Out.Contents())
+
+			Ω(output).Should(ContainSubstring("should proxy integers"))
+			Ω(output).Should(ContainSubstring("Ran 1 of 4 Specs"))
+		})
+
+		It("fails if more than one spec matches", func() {
+			session := startGinkgo(fm.PathTo("passing_ginkgo_tests"), "debug", "--no-color", "should")
+			Eventually(session, "2m").Should(gexec.Exit(1))
+			output := string(session.Out.Contents())
+
+			Ω(output).Should(ContainSubstring("More than one spec matched"))
+			Ω(output).Should(ContainSubstring("should proxy strings"))
+			Ω(output).Should(ContainSubstring("Ran 0 of 4 Specs"))
+		})
+
+		It("fails if no spec is passed in", func() {
+			session := startGinkgo(fm.PathTo("passing_ginkgo_tests"), "debug")
+			Eventually(session).Should(gexec.Exit(1))
+			Ω(session.Err).Should(gbytes.Say("ginkgo debug requires a spec to debug"))
+		})
+	})
+
+	Describe("ginkgo explain", func() {
+		BeforeEach(func() {
+			fm.MountFixture("passing_ginkgo_tests")
+		})
+
+		It("explains which filters include or exclude the matching specs, without running them", func() {
+			session := startGinkgo(fm.PathTo("passing_ginkgo_tests"), "explain", "--no-color", "--focus=integers", "proxy")
+			Eventually(session).Should(gexec.Exit(0))
+			output := string(session.Out.Contents())
+
+			Ω(output).Should(ContainSubstring(`2 specs in Passing_ginkgo_tests Suite match "proxy"`))
+			Ω(output).Should(ContainSubstring("should proxy strings [WILL BE SKIPPED]"))
+			Ω(output).Should(ContainSubstring("should proxy integers [WILL RUN]"))
+			Ω(output).Should(ContainSubstring(`excluded by --focus="integers": the spec's full text does not match`))
+			Ω(output).ShouldNot(ContainSubstring("Ran "))
+		})
+
+		It("fails if no spec matches", func() {
+			session := startGinkgo(fm.PathTo("passing_ginkgo_tests"), "explain", "--no-color", "unicorns")
+			Eventually(session).Should(gexec.Exit(1))
+			Ω(session.Out).Should(gbytes.Say(`No specs in Passing_ginkgo_tests Suite match "unicorns"`))
+		})
+	})
+
+	Describe("ginkgo report replay", func() {
+		BeforeEach(func() {
+			fm.MountFixture("failing_ginkgo_tests")
+			session := startGinkgo(fm.PathTo("failing_ginkgo_tests"), "--no-color", "--json-report=report.json")
+			Eventually(session).Should(gexec.Exit(1))
+		})
+
+		It("re-renders the saved run through the default reporter", func() {
+			session := startGinkgo(fm.PathTo("failing_ginkgo_tests"), "report", "replay", "--no-color", "-v", "report.json")
+			Eventually(session).Should(gexec.Exit(0))
+			output := string(session.Out.Contents())
+
+			Ω(output).Should(ContainSubstring("Running Suite: Failing_ginkgo_tests Suite"))
+			Ω(output).Should(ContainSubstring("should pass"))
+			Ω(output).Should(ContainSubstring("[FAILED]"))
+			Ω(output).Should(ContainSubstring("failing_ginkgo_tests_test.go:11"))
+			Ω(output).Should(ContainSubstring("Ran 2 of 2 Specs"))
+			Ω(output).Should(ContainSubstring("FAIL! -- 1 Passed | 1 Failed"))
+		})
+
+		It("fails when the report cannot be read", func() {
+			session := startGinkgo(fm.PathTo("failing_ginkgo_tests"), "report", "replay", "missing.json")
+			Eventually(session).Should(gexec.Exit(1))
+			Ω(session.Err).Should(gbytes.Say("Failed to load report"))
+		})
+
+		It("requires the replay subcommand", func() {
+			session := startGinkgo(fm.PathTo("failing_ginkgo_tests"), "report", "report.json")
+			Eventually(session).Should(gexec.Exit(1))
+			Ω(session.Err).Should(gbytes.Say("ginkgo report requires a subcommand"))
+		})
+	})
+
+	Describe("ginkgo sweep", func() {
+		BeforeEach(func() {
+			fm.MountFixture("resource_registry")
+			session := startGinkgo(fm.PathTo("resource_registry"), "--no-color", "--resource-registry=registry.jsonl")
+			Eventually(session).Should(gexec.Exit(1))
+		})
+
+		It("lists the orphaned resources when run with --dry-run", func() {
+			session := startGinkgo(fm.PathTo("resource_registry"), "sweep", "--dry-run", "registry.jsonl")
+			Eventually(session).Should(gexec.Exit(0))
+			output := string(session.Out.Contents())
+
+			Ω(output).Should(ContainSubstring("file/orphaned"))
+			Ω(output).Should(ContainSubstring("Would run: touch swept-orphaned"))
+			Ω(output).ShouldNot(ContainSubstring("file/cleaned"))
+			Ω(fm.PathTo("resource_registry", "swept-orphaned")).ShouldNot(BeAnExistingFile())
+		})
+
+		It("runs the sweep command of every orphaned resource, and only sweeps them once", func() {
+			session := startGinkgo(fm.PathTo("resource_registry"), "sweep", "registry.jsonl")
+			Eventually(session).Should(gexec.Exit(0))
+			Ω(session.Out.Contents()).Should(ContainSubstring("file/orphaned"))
+			Ω(fm.PathTo("resource_registry", "swept-orphaned")).Should(BeAnExistingFile())
+			Ω(fm.PathTo("resource_registry", "swept-cleaned")).ShouldNot(BeAnExistingFile())
+
+			session = startGinkgo(fm.PathTo("resource_registry"), "sweep", "registry.jsonl")
+			Eventually(session).Should(gexec.Exit(0))
+			Ω(session.Out.Contents()).Should(ContainSubstring("No orphaned resources found"))
+		})
+
+		It("resolves a relative registry path relative to the current working directory, not the suite's package", func() {
+			session := startGinkgo(fm.TmpDir, "--no-color", "--resource-registry=shared-registry.jsonl", "resource_registry")
+			Eventually(session).Should(gexec.Exit(1))
+			Ω(fm.PathTo("resource_registry", "shared-registry.jsonl")).ShouldNot(BeAnExistingFile())
+
+			session = startGinkgo(fm.TmpDir, "sweep", "--dry-run", "shared-registry.jsonl")
+			Eventually(session).Should(gexec.Exit(0))
+			Ω(session.Out.Contents()).Should(ContainSubstring("file/orphaned"))
+		})
+	})
+
+	Describe("ginkgo doctor", func() {
+		It("reports leftover focus and a missing output directory, and suggests fixes", func() {
+			fm.MountFixture("focused")
+
+			session := startGinkgo(fm.PathTo("focused"), "doctor", "--no-color", "--output-dir=missing")
+			Eventually(session).Should(gexec.Exit(1))
+			output := string(session.Out.Contents())
+
+			Ω(output).Should(ContainSubstring("✓ Ginkgo CLI and library versions"))
+			Ω(output).Should(ContainSubstring("✗ Programmatic focus"))
+			Ω(output).Should(ContainSubstring("focused_fixture_test.go:"))
+			Ω(output).Should(ContainSubstring("Fix: run ginkgo unfocus before committing"))
+			Ω(output).Should(ContainSubstring("✗ Output directory"))
+			Ω(output).Should(ContainSubstring("Fix: mkdir -p missing"))
+			Ω(session.Err.Contents()).Should(ContainSubstring("found 2 problems"))
+		})
+
+		It("succeeds when there is nothing to fix", func() {
+			fm.MountFixture("passing_ginkgo_tests")
+			fm.MkEmpty("output")
+
+			session := startGinkgo(fm.PathTo("passing_ginkgo_tests"), "doctor", "--no-color", "--output-dir="+fm.AbsPathTo("output"))
+			Eventually(session).Should(gexec.Exit(0))
+			Ω(session.Out.Contents()).ShouldNot(ContainSubstring("✗"))
+		})
+	})
+
 	Describe("ginkgo version", func() {
 		It("should print out the version info", func() {
 			session := startGinkgo("", "version")
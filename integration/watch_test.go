@@ -1,6 +1,7 @@
 package integration_test
 
 import (
+	"io"
 	"os"
 	"path/filepath"
 	"time"
@@ -242,4 +243,44 @@ var _ = Describe("Watch", Label("SLOW"), func() {
 			Eventually(session).Should(gbytes.Say("D Suite"))
 		})
 	})
+
+	Describe("interactive mode", func() {
+		var stdin io.WriteCloser
+
+		BeforeEach(func() {
+			cmd := ginkgoCommand(fm.PathTo("watch"), "watch", "-succinct", "-interactive", "A")
+			var err error
+			stdin, err = cmd.StdinPipe()
+			Ω(err).ShouldNot(HaveOccurred())
+			session, err = gexec.Start(cmd, GinkgoWriter, GinkgoWriter)
+			Ω(err).ShouldNot(HaveOccurred())
+			Eventually(session).Should(gbytes.Say("Interactive commands"))
+			Eventually(session).Should(gbytes.Say("A Suite"))
+		})
+
+		AfterEach(func() {
+			stdin.Close()
+		})
+
+		It("re-runs suites, toggles verbosity, and changes the focus filter on command", func() {
+			stdin.Write([]byte("r\n"))
+			Eventually(session).Should(gbytes.Say("The last run had no failures to re-run"))
+
+			stdin.Write([]byte("f nothing-matches-this\n"))
+			Eventually(session).Should(gbytes.Say("Will only run specs matching nothing-matches-this"))
+			stdin.Write([]byte("a\n"))
+			Eventually(session).Should(gbytes.Say(`0/1 specs`))
+
+			stdin.Write([]byte("f\n"))
+			Eventually(session).Should(gbytes.Say("Cleared the focus filter"))
+			stdin.Write([]byte("v\n"))
+			Eventually(session).Should(gbytes.Say("Verbose output is on"))
+			stdin.Write([]byte("a\n"))
+			Eventually(session).Should(gbytes.Say(`should do it`))
+			Eventually(session).Should(gbytes.Say(`Ran 1 of 1 Specs`))
+
+			stdin.Write([]byte("bogus\n"))
+			Eventually(session).Should(gbytes.Say("Unknown command bogus"))
+		})
+	})
 })
@@ -0,0 +1,61 @@
+package internal
+
+import (
+	"strings"
+
+	"github.com/onsi/ginkgo/v2/types"
+)
+
+// AssertionDefaultsHandler applies the passed-in defaults to the assertion library (e.g. by calling Gomega's SetDefaultEventuallyTimeout) and returns a function that restores the library's previous defaults
+type AssertionDefaultsHandler func(defaults AssertionDefaults) (restore func())
+
+type labelAssertionDefaults struct {
+	label        string
+	defaults     AssertionDefaults
+	codeLocation types.CodeLocation
+}
+
+// RegisterAssertionDefaultsHandler registers the handler Ginkgo calls to apply the assertion defaults of each spec.  Registering a second handler replaces the first.
+func (suite *Suite) RegisterAssertionDefaultsHandler(handler AssertionDefaultsHandler) {
+	suite.assertionDefaultsHandler = handler
+}
+
+// SetAssertionDefaultsForLabel sets the assertion defaults for specs labelled with label.  Labels are matched case-insensitively.
+func (suite *Suite) SetAssertionDefaultsForLabel(label string, defaults AssertionDefaults, cl types.CodeLocation) {
+	suite.labelAssertionDefaults = append(suite.labelAssertionDefaults, labelAssertionDefaults{label: strings.ToLower(label), defaults: defaults, codeLocation: cl})
+}
+
+// assertionDefaultsFor resolves the assertion defaults for spec.  Label defaults are applied first - in the order they were set - followed by the
+// AssertionDefaults decorators of the spec's containers, outermost first, and of the spec itself.  So more specific declarations win.
+func (suite *Suite) assertionDefaultsFor(spec Spec) AssertionDefaults {
+	out := AssertionDefaults{}
+	if len(suite.labelAssertionDefaults) > 0 {
+		labels := map[string]bool{}
+		for _, label := range spec.Nodes.UnionOfLabels() {
+			labels[strings.ToLower(label)] = true
+		}
+		for _, labelDefaults := range suite.labelAssertionDefaults {
+			if labels[labelDefaults.label] {
+				out = out.MergedWith(labelDefaults.defaults)
+			}
+		}
+	}
+	return out.MergedWith(spec.Nodes.WithType(types.NodeTypesForContainerAndIt).AssertionDefaults())
+}
+
+// applyAssertionDefaults hands the spec's assertion defaults to the registered AssertionDefaultsHandler and returns a function that restores the previous defaults.
+// Nothing happens if no handler is registered or if the spec declares no defaults.
+func (suite *Suite) applyAssertionDefaults(spec Spec) func() {
+	if suite.assertionDefaultsHandler == nil {
+		return func() {}
+	}
+	defaults := suite.assertionDefaultsFor(spec)
+	if defaults.IsZero() {
+		return func() {}
+	}
+	restore := suite.assertionDefaultsHandler(defaults)
+	if restore == nil {
+		return func() {}
+	}
+	return restore
+}
@@ -0,0 +1,59 @@
+package internal
+
+import (
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/onsi/ginkgo/v2/types"
+)
+
+/*
+When Ginkgo runs in chaos mode (--chaos) the ChaosMonkey perturbs the suite by pausing for a random amount of time
+before each spec node and, optionally, by failing spec nodes at random.  This allows users to validate that their suite is robust to timing variance
+and that their cleanup code behaves correctly when setup fails.
+
+The ChaosMonkey is seeded with the suite's random seed (and parallel process) so that a chaotic run can be reproduced with --seed.
+*/
+type ChaosMonkey struct {
+	maxDelay    time.Duration
+	failureRate float64
+	r           *rand.Rand
+	lock        *sync.Mutex
+}
+
+type ChaosPerturbation struct {
+	Delay          time.Duration
+	InjectFailure  bool
+	FailureMessage string
+}
+
+func NewChaosMonkey(suiteConfig types.SuiteConfig) *ChaosMonkey {
+	return &ChaosMonkey{
+		maxDelay:    suiteConfig.ChaosMaxDelay,
+		failureRate: suiteConfig.ChaosFailureRate,
+		r:           rand.New(rand.NewSource(suiteConfig.RandomSeed + int64(suiteConfig.ParallelProcess))),
+		lock:        &sync.Mutex{},
+	}
+}
+
+// Perturbation returns the perturbation to apply before running the passed-in node.  Only nodes with a type in types.NodeTypesForChaos are perturbed.
+func (monkey *ChaosMonkey) Perturbation(node Node) ChaosPerturbation {
+	if !node.NodeType.Is(types.NodeTypesForChaos) {
+		return ChaosPerturbation{}
+	}
+
+	monkey.lock.Lock()
+	defer monkey.lock.Unlock()
+
+	perturbation := ChaosPerturbation{}
+	if monkey.maxDelay > 0 {
+		perturbation.Delay = time.Duration(monkey.r.Int63n(int64(monkey.maxDelay)))
+	}
+	if monkey.failureRate > 0 && monkey.r.Float64() < monkey.failureRate {
+		perturbation.InjectFailure = true
+		perturbation.FailureMessage = fmt.Sprintf("Chaos: simulated failure injected into [%s] by --chaos", node.NodeType)
+	}
+	return perturbation
+}
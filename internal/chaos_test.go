@@ -0,0 +1,61 @@
+package internal_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/onsi/ginkgo/v2/internal"
+	"github.com/onsi/ginkgo/v2/types"
+)
+
+var _ = Describe("ChaosMonkey", func() {
+	var conf types.SuiteConfig
+	BeforeEach(func() {
+		conf = types.SuiteConfig{RandomSeed: 17, ParallelProcess: 1, Chaos: true, ChaosMaxDelay: time.Second, ChaosFailureRate: 0.5}
+	})
+
+	perturbations := func(monkey *internal.ChaosMonkey) []internal.ChaosPerturbation {
+		out := []internal.ChaosPerturbation{}
+		for i := 0; i < 20; i++ {
+			out = append(out, monkey.Perturbation(N(ntBef)))
+		}
+		return out
+	}
+
+	It("perturbs spec nodes with delays below the max delay and with failures at the configured rate", func() {
+		numFailures := 0
+		for _, perturbation := range perturbations(internal.NewChaosMonkey(conf)) {
+			Ω(perturbation.Delay).Should(BeNumerically(">=", 0))
+			Ω(perturbation.Delay).Should(BeNumerically("<", time.Second))
+			if perturbation.InjectFailure {
+				numFailures += 1
+				Ω(perturbation.FailureMessage).Should(Equal("Chaos: simulated failure injected into [BeforeEach] by --chaos"))
+			}
+		}
+		Ω(numFailures).Should(BeNumerically(">", 0))
+		Ω(numFailures).Should(BeNumerically("<", 20))
+	})
+
+	It("is deterministic for a given seed and parallel process", func() {
+		Ω(perturbations(internal.NewChaosMonkey(conf))).Should(Equal(perturbations(internal.NewChaosMonkey(conf))))
+
+		otherConf := conf
+		otherConf.ParallelProcess = 2
+		Ω(perturbations(internal.NewChaosMonkey(conf))).ShouldNot(Equal(perturbations(internal.NewChaosMonkey(otherConf))))
+	})
+
+	It("does not perturb nodes that aren't spec nodes", func() {
+		monkey := internal.NewChaosMonkey(conf)
+		Ω(monkey.Perturbation(N(types.NodeTypeBeforeSuite))).Should(BeZero())
+		Ω(monkey.Perturbation(N(types.NodeTypeAfterSuite))).Should(BeZero())
+	})
+
+	It("does not perturb nodes when there is no delay and no failure rate", func() {
+		conf.ChaosMaxDelay, conf.ChaosFailureRate = 0, 0
+		for _, perturbation := range perturbations(internal.NewChaosMonkey(conf)) {
+			Ω(perturbation).Should(BeZero())
+		}
+	})
+})
@@ -0,0 +1,11 @@
+//go:build !freebsd && !openbsd && !netbsd && !dragonfly && !darwin && !linux && !solaris
+// +build !freebsd,!openbsd,!netbsd,!dragonfly,!darwin,!linux,!solaris
+
+package internal
+
+import "time"
+
+// processCPUTime is not available on this platform
+func processCPUTime() (time.Duration, bool) {
+	return 0, false
+}
@@ -0,0 +1,18 @@
+//go:build freebsd || openbsd || netbsd || dragonfly || darwin || linux || solaris
+// +build freebsd openbsd netbsd dragonfly darwin linux solaris
+
+package internal
+
+import (
+	"syscall"
+	"time"
+)
+
+// processCPUTime returns the user and system CPU time the current process has used so far
+func processCPUTime() (time.Duration, bool) {
+	rusage := syscall.Rusage{}
+	if err := syscall.Getrusage(syscall.RUSAGE_SELF, &rusage); err != nil {
+		return 0, false
+	}
+	return time.Duration(rusage.Utime.Nano() + rusage.Stime.Nano()), true
+}
@@ -0,0 +1,39 @@
+package internal
+
+import (
+	"sort"
+
+	"github.com/onsi/ginkgo/v2/types"
+)
+
+// DescribeSuite generates the machine-readable description of the suite emitted by --describe-suite
+func DescribeSuite(specs Specs, description string, suiteLabels Labels, suitePath string) types.SuiteDescription {
+	out := types.SuiteDescription{
+		GinkgoVersion:    types.VERSION,
+		SuiteDescription: description,
+		SuitePath:        suitePath,
+		SuiteLabels:      []string(suiteLabels),
+		TotalSpecs:       len(specs),
+		SpecsByLabel:     map[string]int{},
+		RequiredEnv:      []string{},
+	}
+	if out.SuiteLabels == nil {
+		out.SuiteLabels = []string{}
+	}
+
+	requiredEnv := map[string]bool{}
+	for _, spec := range specs {
+		for _, label := range UnionOfLabels(suiteLabels, spec.Nodes.UnionOfLabels()) {
+			out.SpecsByLabel[label] += 1
+		}
+		for _, name := range spec.Nodes.UnionOfRequiredEnv() {
+			requiredEnv[name] = true
+		}
+	}
+	for name := range requiredEnv {
+		out.RequiredEnv = append(out.RequiredEnv, name)
+	}
+	sort.Strings(out.RequiredEnv)
+
+	return out
+}
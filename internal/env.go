@@ -0,0 +1,31 @@
+package internal
+
+import "os"
+
+// setEnv sets the passed-in environment variables, in order, and returns a function that restores the environment to its previous state.
+// Ginkgo only ever runs one spec at a time in a given process so - whatever order the specs run in - each spec sees the environment its own nodes ask for.
+func setEnv(vars []EnvVar) func() {
+	if len(vars) == 0 {
+		return func() {}
+	}
+	type previousValue struct {
+		key    string
+		value  string
+		wasSet bool
+	}
+	previousValues := make([]previousValue, len(vars))
+	for i, envVar := range vars {
+		value, wasSet := os.LookupEnv(envVar.Key)
+		previousValues[i] = previousValue{key: envVar.Key, value: value, wasSet: wasSet}
+		os.Setenv(envVar.Key, envVar.Value)
+	}
+	return func() {
+		for i := len(previousValues) - 1; i >= 0; i-- {
+			if previousValues[i].wasSet {
+				os.Setenv(previousValues[i].key, previousValues[i].value)
+			} else {
+				os.Unsetenv(previousValues[i].key)
+			}
+		}
+	}
+}
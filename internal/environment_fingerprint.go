@@ -0,0 +1,70 @@
+package internal
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+
+	"github.com/onsi/ginkgo/v2/types"
+)
+
+// NewEnvironmentFingerprint captures a fingerprint of the environment the suite at suitePath is running in.
+// The values of types.DefaultFingerprintEnvVars and of envVarNames are captured if they are set.
+func NewEnvironmentFingerprint(suitePath string, envVarNames []string) types.EnvironmentFingerprint {
+	fingerprint := types.EnvironmentFingerprint{
+		GoVersion:  runtime.Version(),
+		GOOS:       runtime.GOOS,
+		GOARCH:     runtime.GOARCH,
+		NumCPU:     runtime.NumCPU(),
+		GOMAXPROCS: runtime.GOMAXPROCS(0),
+	}
+	fingerprint.Hostname, _ = os.Hostname()
+
+	for _, name := range append(append([]string{}, types.DefaultFingerprintEnvVars...), envVarNames...) {
+		if value, ok := os.LookupEnv(name); ok {
+			if fingerprint.EnvVars == nil {
+				fingerprint.EnvVars = map[string]string{}
+			}
+			fingerprint.EnvVars[name] = value
+		}
+	}
+
+	fingerprint.GitCommit, fingerprint.GitDirty = gitStatus(suitePath)
+
+	return fingerprint
+}
+
+type gitStatusResult struct {
+	commit string
+	dirty  bool
+}
+
+// the git status is only computed once per directory as shelling out to git is comparatively slow
+var gitStatusCache = map[string]gitStatusResult{}
+var gitStatusCacheLock = &sync.Mutex{}
+
+func gitStatus(dir string) (string, bool) {
+	if dir == "" {
+		dir = "."
+	}
+	dir, _ = filepath.Abs(dir)
+
+	gitStatusCacheLock.Lock()
+	defer gitStatusCacheLock.Unlock()
+	if result, ok := gitStatusCache[dir]; ok {
+		return result.commit, result.dirty
+	}
+
+	result := gitStatusResult{}
+	commit, err := exec.Command("git", "-C", dir, "rev-parse", "HEAD").Output()
+	if err == nil {
+		result.commit = strings.TrimSpace(string(commit))
+		status, err := exec.Command("git", "-C", dir, "status", "--porcelain").Output()
+		result.dirty = err == nil && len(strings.TrimSpace(string(status))) > 0
+	}
+	gitStatusCache[dir] = result
+	return result.commit, result.dirty
+}
@@ -0,0 +1,39 @@
+package internal_test
+
+import (
+	"runtime"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/onsi/ginkgo/v2/internal"
+)
+
+var _ = Describe("EnvironmentFingerprint", func() {
+	It("captures details about the go runtime and machine", func() {
+		fingerprint := internal.NewEnvironmentFingerprint(".", nil)
+		Ω(fingerprint.GoVersion).Should(Equal(runtime.Version()))
+		Ω(fingerprint.GOOS).Should(Equal(runtime.GOOS))
+		Ω(fingerprint.GOARCH).Should(Equal(runtime.GOARCH))
+		Ω(fingerprint.NumCPU).Should(Equal(runtime.NumCPU()))
+		Ω(fingerprint.GOMAXPROCS).Should(Equal(runtime.GOMAXPROCS(0)))
+	})
+
+	It("captures the default and requested environment variables, if they are set", func() {
+		GinkgoT().Setenv("GOFLAGS", "-mod=mod")
+		GinkgoT().Setenv("GINKGO_FINGERPRINT_TEST_VAR", "hello")
+		GinkgoT().Setenv("GINKGO_FINGERPRINT_TEST_IGNORED_VAR", "ignored")
+
+		fingerprint := internal.NewEnvironmentFingerprint(".", []string{"GINKGO_FINGERPRINT_TEST_VAR", "GINKGO_FINGERPRINT_TEST_MISSING_VAR"})
+		Ω(fingerprint.EnvVars).Should(HaveKeyWithValue("GOFLAGS", "-mod=mod"))
+		Ω(fingerprint.EnvVars).Should(HaveKeyWithValue("GINKGO_FINGERPRINT_TEST_VAR", "hello"))
+		Ω(fingerprint.EnvVars).ShouldNot(HaveKey("GINKGO_FINGERPRINT_TEST_MISSING_VAR"))
+		Ω(fingerprint.EnvVars).ShouldNot(HaveKey("GINKGO_FINGERPRINT_TEST_IGNORED_VAR"))
+	})
+
+	It("leaves the git information empty when the suite is not in a git repository", func() {
+		fingerprint := internal.NewEnvironmentFingerprint(GinkgoT().TempDir(), nil)
+		Ω(fingerprint.GitCommit).Should(BeEmpty())
+		Ω(fingerprint.GitDirty).Should(BeFalse())
+	})
+})
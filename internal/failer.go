@@ -1,23 +1,54 @@
 package internal
 
 import (
+	"encoding/json"
 	"fmt"
+	"reflect"
 	"sync"
 
 	"github.com/onsi/ginkgo/v2/types"
 )
 
+type FailureRenderer func(payload interface{}) string
+
 type Failer struct {
-	lock    *sync.Mutex
-	failure types.Failure
-	state   types.SpecState
+	lock              *sync.Mutex
+	failure           types.Failure
+	state             types.SpecState
+	renderers         map[reflect.Type]FailureRenderer
+	retryOnStopTrying bool
+	// the goroutines of nodes Ginkgo stopped waiting for - anything they report is ignored so that it can't leak into the node that is running now
+	abandonedGoroutines map[uint64]bool
 }
 
 func NewFailer() *Failer {
 	return &Failer{
-		lock:  &sync.Mutex{},
-		state: types.SpecStatePassed,
+		lock:      &sync.Mutex{},
+		state:               types.SpecStatePassed,
+		renderers:           map[reflect.Type]FailureRenderer{},
+		abandonedGoroutines: map[uint64]bool{},
+	}
+}
+
+func (f *Failer) RegisterFailureRenderer(payloadType reflect.Type, renderer FailureRenderer) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	f.renderers[payloadType] = renderer
+}
+
+func (f *Failer) renderPayload(payload interface{}) *types.FailurePayload {
+	out := &types.FailurePayload{
+		Type: fmt.Sprintf("%T", payload),
+	}
+	if asJSON, err := json.Marshal(payload); err == nil {
+		out.AsJSON = string(asJSON)
 	}
+	if renderer, ok := f.renderers[reflect.TypeOf(payload)]; ok {
+		out.Representation = renderer(payload)
+	} else {
+		out.Representation = types.WrapEntryValue(payload).String()
+	}
+	return out
 }
 
 func (f *Failer) GetState() types.SpecState {
@@ -32,9 +63,41 @@ func (f *Failer) GetFailure() types.Failure {
 	return f.failure
 }
 
+// SetRetryOnStopTrying configures how StopTrying signals are handled: as failures (so that the spec is retried) if retry is true and as skips otherwise
+func (f *Failer) SetRetryOnStopTrying(retry bool) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	f.retryOnStopTrying = retry
+}
+
+// Abandon discards whatever the node running on the goroutine with the passed-in ID has reported so far, and ignores anything it reports from now on
+func (f *Failer) Abandon(goroutineID uint64) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	f.state = types.SpecStatePassed
+	f.failure = types.Failure{}
+	if goroutineID != 0 {
+		f.abandonedGoroutines[goroutineID] = true
+	}
+}
+
+func (f *Failer) isCallerAbandoned() bool {
+	return len(f.abandonedGoroutines) > 0 && f.abandonedGoroutines[currentGoroutineID()]
+}
+
 func (f *Failer) Panic(location types.CodeLocation, forwardedPanic interface{}) {
 	f.lock.Lock()
 	defer f.lock.Unlock()
+	if f.isCallerAbandoned() {
+		return
+	}
+
+	if signal, ok := AsStopTryingSignal(forwardedPanic); ok {
+		//we use the message of the outermost error so that any context added by wrapping the signal is preserved
+		f.stopTrying(fmt.Sprintf("%v", forwardedPanic), signal.CodeLocation)
+		return
+	}
 
 	if f.state == types.SpecStatePassed {
 		f.state = types.SpecStatePanicked
@@ -43,12 +106,18 @@ func (f *Failer) Panic(location types.CodeLocation, forwardedPanic interface{})
 			Location:       location,
 			ForwardedPanic: fmt.Sprintf("%v", forwardedPanic),
 		}
+		if forwardedPanic != nil {
+			f.failure.Panic = types.NewPanicValue(forwardedPanic)
+		}
 	}
 }
 
 func (f *Failer) Fail(message string, location types.CodeLocation) {
 	f.lock.Lock()
 	defer f.lock.Unlock()
+	if f.isCallerAbandoned() {
+		return
+	}
 
 	if f.state == types.SpecStatePassed {
 		f.state = types.SpecStateFailed
@@ -59,9 +128,67 @@ func (f *Failer) Fail(message string, location types.CodeLocation) {
 	}
 }
 
+func (f *Failer) FailWithPayload(message string, payload interface{}, location types.CodeLocation) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	if f.isCallerAbandoned() {
+		return
+	}
+
+	if f.state == types.SpecStatePassed {
+		f.state = types.SpecStateFailed
+		f.failure = types.Failure{
+			Message:  message,
+			Location: location,
+			Payload:  f.renderPayload(payload),
+		}
+	}
+}
+
+// FailWithError fails with message followed by the error's message and captures the error on the failure
+func (f *Failer) FailWithError(message string, err error, location types.CodeLocation) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	if f.isCallerAbandoned() {
+		return
+	}
+
+	if f.state == types.SpecStatePassed {
+		f.state = types.SpecStateFailed
+		f.failure = types.Failure{
+			Message:  message,
+			Location: location,
+		}
+		if err != nil {
+			f.failure.Error = types.NewFailureError(err)
+			if message == "" {
+				f.failure.Message = err.Error()
+			} else {
+				f.failure.Message = message + ": " + err.Error()
+			}
+		}
+	}
+}
+
+func (f *Failer) stopTrying(message string, location types.CodeLocation) {
+	if f.state == types.SpecStatePassed {
+		f.state = types.SpecStateSkipped
+		if f.retryOnStopTrying {
+			f.state = types.SpecStateFailed
+		}
+		f.failure = types.Failure{
+			Message:  message,
+			Location: location,
+		}
+	}
+}
+
 func (f *Failer) Skip(message string, location types.CodeLocation) {
 	f.lock.Lock()
 	defer f.lock.Unlock()
+	if f.isCallerAbandoned() {
+		return
+	}
 
 	if f.state == types.SpecStatePassed {
 		f.state = types.SpecStateSkipped
@@ -75,6 +202,9 @@ func (f *Failer) Skip(message string, location types.CodeLocation) {
 func (f *Failer) AbortSuite(message string, location types.CodeLocation) {
 	f.lock.Lock()
 	defer f.lock.Unlock()
+	if f.isCallerAbandoned() {
+		return
+	}
 
 	if f.state == types.SpecStatePassed {
 		f.state = types.SpecStateAborted
@@ -88,6 +218,10 @@ func (f *Failer) AbortSuite(message string, location types.CodeLocation) {
 func (f *Failer) Drain() (types.SpecState, types.Failure) {
 	f.lock.Lock()
 	defer f.lock.Unlock()
+	if f.isCallerAbandoned() {
+		delete(f.abandonedGoroutines, currentGoroutineID())
+		return types.SpecStatePassed, types.Failure{}
+	}
 
 	failure := f.failure
 	outcome := f.state
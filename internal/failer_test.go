@@ -1,6 +1,10 @@
 package internal_test
 
 import (
+	"fmt"
+	"os"
+	"reflect"
+
 	. "github.com/onsi/ginkgo/v2"
 	"github.com/onsi/ginkgo/v2/internal"
 	"github.com/onsi/ginkgo/v2/types"
@@ -54,6 +58,75 @@ var _ = Describe("Failer", func() {
 		})
 	})
 
+	Describe("when told of a failure with a payload", func() {
+		type diff struct {
+			Field    string
+			Expected int
+			Actual   int
+		}
+
+		It("records the payload's type, JSON encoding, and default representation", func() {
+			failer.FailWithPayload("something failed", diff{"count", 1, 2}, clA)
+			state, failure := failer.Drain()
+			Ω(state).Should(Equal(types.SpecStateFailed))
+			Ω(failure).Should(Equal(types.Failure{
+				Message:  "something failed",
+				Location: clA,
+				Payload: &types.FailurePayload{
+					Type:           "internal_test.diff",
+					AsJSON:         `{"Field":"count","Expected":1,"Actual":2}`,
+					Representation: "{Field:count Expected:1 Actual:2}",
+				},
+			}))
+		})
+
+		It("renders the payload with the renderer registered for its type", func() {
+			failer.RegisterFailureRenderer(reflect.TypeOf(diff{}), func(payload interface{}) string {
+				d := payload.(diff)
+				return fmt.Sprintf("{{red}}%s: -%d +%d{{/}}", d.Field, d.Expected, d.Actual)
+			})
+			failer.FailWithPayload("something failed", diff{"count", 1, 2}, clA)
+			_, failure := failer.Drain()
+			Ω(failure.Payload.Representation).Should(Equal("{{red}}count: -1 +2{{/}}"))
+
+			failer.FailWithPayload("something failed", &diff{"count", 1, 2}, clA)
+			_, failure = failer.Drain()
+			Ω(failure.Payload.Type).Should(Equal("*internal_test.diff"))
+			Ω(failure.Payload.Representation).Should(Equal("&{Field:count Expected:1 Actual:2}"), "renderers are registered per type")
+		})
+	})
+
+	Describe("when told of a failure with an error", func() {
+		It("appends the error's message to the failure message and records the error's type and wrapped types", func() {
+			err := fmt.Errorf("loading fixtures: %w", os.ErrNotExist)
+			failer.FailWithError("setup failed", err, clA)
+			state, failure := failer.Drain()
+			Ω(state).Should(Equal(types.SpecStateFailed))
+			Ω(failure.Message).Should(Equal("setup failed: loading fixtures: file does not exist"))
+			Ω(failure.Location).Should(Equal(clA))
+			Ω(failure.Error.Type).Should(Equal("*fmt.wrapError"))
+			Ω(failure.Error.Error).Should(Equal("loading fixtures: file does not exist"))
+			Ω(failure.Error.WrappedTypes).Should(Equal([]string{"*errors.errorString"}))
+			Ω(failure.Error.GetRawError()).Should(BeIdenticalTo(err))
+		})
+
+		It("uses the error's message as the failure message when no message is provided", func() {
+			failer.FailWithError("", os.ErrNotExist, clA)
+			_, failure := failer.Drain()
+			Ω(failure.Message).Should(Equal("file does not exist"))
+			Ω(failure.Error.WrappedTypes).Should(BeEmpty())
+		})
+
+		It("behaves like Fail when the error is nil", func() {
+			failer.FailWithError("setup failed", nil, clA)
+			_, failure := failer.Drain()
+			Ω(failure).Should(Equal(types.Failure{
+				Message:  "setup failed",
+				Location: clA,
+			}))
+		})
+	})
+
 	Describe("when told to skip", func() {
 		Context("when no failure has occurred", func() {
 			It("registers the test as skipped", func() {
@@ -126,7 +199,32 @@ var _ = Describe("Failer", func() {
 				Message:        "Test Panicked",
 				Location:       clA,
 				ForwardedPanic: "17",
+				Panic:          types.NewPanicValue(17),
 			}))
+			Ω(failure.Panic.Type).Should(Equal("int"))
+			Ω(failure.Panic.GetRawValue()).Should(Equal(17))
+		})
+
+		It("captures the Error() of error panics and identifies runtime errors", func() {
+			failer.Drain()
+
+			var m map[string]int
+			func() {
+				defer func() {
+					failer.Panic(clB, recover())
+				}()
+				m["a"] = 1
+			}()
+			_, failure := failer.Drain()
+			Ω(failure.Panic.Type).Should(Equal("runtime.plainError"))
+			Ω(failure.Panic.Error).Should(Equal("assignment to entry in nil map"))
+			Ω(failure.Panic.IsRuntimeError).Should(BeTrue())
+
+			failer.Panic(clA, fmt.Errorf("domain error"))
+			_, failure = failer.Drain()
+			Ω(failure.Panic.Type).Should(Equal("*errors.errorString"))
+			Ω(failure.Panic.Error).Should(Equal("domain error"))
+			Ω(failure.Panic.IsRuntimeError).Should(BeFalse())
 		})
 
 		Context("when told of another panic", func() {
@@ -139,11 +237,40 @@ var _ = Describe("Failer", func() {
 					Message:        "Test Panicked",
 					Location:       clA,
 					ForwardedPanic: "17",
+					Panic:          types.NewPanicValue(17),
 				}))
 			})
 		})
 	})
 
+	Describe("when told to panic with a StopTrying signal", func() {
+		var signal internal.StopTryingSignal
+		BeforeEach(func() {
+			signal = internal.NewStopTryingSignal("giving up", clB)
+		})
+
+		It("records a skip by default", func() {
+			failer.Panic(clA, signal)
+			state, failure := failer.Drain()
+			Ω(state).Should(Equal(types.SpecStateSkipped))
+			Ω(failure).Should(Equal(types.Failure{
+				Message:  "giving up",
+				Location: clB,
+			}))
+		})
+
+		It("records a failure when configured to retry, preserving the message of any wrapping error", func() {
+			failer.SetRetryOnStopTrying(true)
+			failer.Panic(clA, fmt.Errorf("polling: %w", signal))
+			state, failure := failer.Drain()
+			Ω(state).Should(Equal(types.SpecStateFailed))
+			Ω(failure).Should(Equal(types.Failure{
+				Message:  "polling: giving up",
+				Location: clB,
+			}))
+		})
+	})
+
 	Context("when drained", func() {
 		BeforeEach(func() {
 			failer.Fail("something failed", clA)
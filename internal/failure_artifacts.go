@@ -0,0 +1,126 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+
+	"github.com/onsi/ginkgo/v2/types"
+)
+
+// FailureArtifactCollector is implemented by integrations (e.g. browser drivers) that can capture artifacts like screenshots when a spec fails
+type FailureArtifactCollector interface {
+	// CollectFailureArtifacts writes its artifacts to artifactsDir and returns their paths.  Relative paths are taken to be relative to artifactsDir.
+	CollectFailureArtifacts(ctx SpecContext, artifactsDir string) ([]string, error)
+}
+
+type registeredFailureArtifactCollector struct {
+	collector    FailureArtifactCollector
+	codeLocation types.CodeLocation
+}
+
+// failureArtifactStates are the states in which Ginkgo calls the registered FailureArtifactCollectors
+const failureArtifactStates = types.SpecStateFailed | types.SpecStatePanicked | types.SpecStateTimedout
+
+var unsafeArtifactDirCharacters = regexp.MustCompile(`[^a-zA-Z0-9_\-]+`)
+
+const maxArtifactDirNameLength = 64
+
+// RegisterFailureArtifactCollector registers a collector that is called whenever a spec fails
+func (suite *Suite) RegisterFailureArtifactCollector(collector FailureArtifactCollector, cl types.CodeLocation) {
+	suite.failureArtifactCollectors = append(suite.failureArtifactCollectors, registeredFailureArtifactCollector{collector: collector, codeLocation: cl})
+}
+
+// collectFailureArtifacts calls the registered FailureArtifactCollectors and records the artifacts they collect in the current spec's report.
+// Collectors that fail are recorded as warnings - they never change the outcome of the spec.
+func (suite *Suite) collectFailureArtifacts() {
+	if len(suite.failureArtifactCollectors) == 0 {
+		return
+	}
+	dir, err := suite.artifactsDirForCurrentSpec()
+	if err != nil {
+		suite.currentSpecReport.Warnings = append(suite.currentSpecReport.Warnings, types.Warning{
+			Message:  fmt.Sprintf("Ginkgo could not create a directory for failure artifacts:\n%s", err.Error()),
+			Location: suite.failureArtifactCollectors[0].codeLocation,
+		})
+		return
+	}
+	for _, registered := range suite.failureArtifactCollectors {
+		var paths []string
+		err := suite.callIntegration(func() (err error) {
+			paths, err = registered.collector.CollectFailureArtifacts(NewSpecContext(context.Background(), suite), dir)
+			return err
+		})
+		if err != nil {
+			suite.currentSpecReport.Warnings = append(suite.currentSpecReport.Warnings, types.Warning{
+				Message:  fmt.Sprintf("FailureArtifactCollector failed to collect artifacts:\n%s", err.Error()),
+				Location: registered.codeLocation,
+			})
+		}
+		suite.currentSpecReport.FailureArtifacts = append(suite.currentSpecReport.FailureArtifacts, absoluteArtifactPaths(dir, paths)...)
+	}
+}
+
+// callIntegration calls f - a call into a FailureArtifactCollector, NetworkCapturer, RequirementChecker, or PreflightCheck - and turns any panic into an error
+func (suite *Suite) callIntegration(f func() error) (err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			// integrations that call Fail (e.g. via a failed Gomega assertion) leave a failure behind - we report it, rather than the panic it raised
+			if state, failure := suite.failer.Drain(); state.Is(types.SpecStateFailureStates) {
+				err = fmt.Errorf("%s", failure.Message)
+			} else {
+				err = fmt.Errorf("panicked: %v", e)
+			}
+		}
+	}()
+	return f()
+}
+
+func absoluteArtifactPaths(dir string, paths []string) []string {
+	out := make([]string, len(paths))
+	for i, path := range paths {
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(dir, path)
+		}
+		out[i] = path
+	}
+	return out
+}
+
+// artifactsDirForCurrentSpec returns a directory, unique to this attempt at running the current spec, in --artifacts-dir (or a temporary directory if it isn't set)
+// The directory is only created the first time it is asked for.
+func (suite *Suite) artifactsDirForCurrentSpec() (string, error) {
+	if suite.currentSpecArtifactsDir != "" {
+		return suite.currentSpecArtifactsDir, nil
+	}
+	if suite.artifactsDir == "" {
+		if suite.config.ArtifactsDir == "" {
+			dir, err := os.MkdirTemp("", "ginkgo-artifacts")
+			if err != nil {
+				return "", err
+			}
+			suite.artifactsDir = dir
+		} else {
+			dir, err := filepath.Abs(suite.config.ArtifactsDir)
+			if err != nil {
+				return "", err
+			}
+			if err := os.MkdirAll(dir, 0755); err != nil {
+				return "", err
+			}
+			suite.artifactsDir = dir
+		}
+	}
+	name := unsafeArtifactDirCharacters.ReplaceAllString(suite.currentSpecReport.FullText(), "_")
+	if len(name) > maxArtifactDirNameLength {
+		name = name[:maxArtifactDirNameLength]
+	}
+	dir, err := os.MkdirTemp(suite.artifactsDir, name+"-")
+	if err != nil {
+		return "", err
+	}
+	suite.currentSpecArtifactsDir = dir
+	return dir, nil
+}
@@ -1,6 +1,11 @@
 package internal
 
 import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/rand"
+	"os"
 	"regexp"
 	"strings"
 
@@ -58,22 +63,63 @@ func ApplyNestedFocusPolicyToTree(tree *TreeNode) {
 	*Note:* specs with pending nodes are Skipped when created by NewSpec.
 */
 func ApplyFocusToSpecs(specs Specs, description string, suiteLabels Labels, suiteConfig types.SuiteConfig) (Specs, bool) {
+	rules, hasProgrammaticFocus := focusRules(specs, description, suiteLabels, suiteConfig)
+
+	// skip specs if any rule excludes them.  note that we do nothing if no rule excludes the spec to avoid overwriting skip status established by the node's pending status
+	processedSpecs := Specs{}
+	for _, spec := range specs {
+		for _, rule := range rules {
+			if rule.excludes(spec) {
+				spec.Skip = true
+				break
+			}
+		}
+		processedSpecs = append(processedSpecs, spec)
+	}
+
+	return processedSpecs, hasProgrammaticFocus
+}
+
+// focusRule is a single rule in Ginkgo's focus policy.  explain describes why the rule did or did not exclude the spec - it is only used by ginkgo explain.
+type focusRule struct {
+	name     string
+	excludes func(spec Spec) bool
+	explain  func(spec Spec, excluded bool) string
+}
+
+func focusRules(specs Specs, description string, suiteLabels Labels, suiteConfig types.SuiteConfig) ([]focusRule, bool) {
 	focusString := strings.Join(suiteConfig.FocusStrings, "|")
 	skipString := strings.Join(suiteConfig.SkipStrings, "|")
 
 	hasFocusCLIFlags := focusString != "" || skipString != "" || len(suiteConfig.SkipFiles) > 0 || len(suiteConfig.FocusFiles) > 0 || suiteConfig.LabelFilter != ""
 
-	type SkipCheck func(spec Spec) bool
-
 	// by default, skip any specs marked pending
-	skipChecks := []SkipCheck{func(spec Spec) bool { return spec.Nodes.HasNodeMarkedPending() }}
+	rules := []focusRule{{
+		name:     "Pending",
+		excludes: func(spec Spec) bool { return spec.Nodes.HasNodeMarkedPending() },
+		explain: func(spec Spec, excluded bool) string {
+			if excluded {
+				return "the spec, or one of its containers, is marked Pending"
+			}
+			return "the spec is not marked Pending"
+		},
+	}}
 	hasProgrammaticFocus := false
 
 	if !hasFocusCLIFlags {
 		// check for programmatic focus
 		for _, spec := range specs {
 			if spec.Nodes.HasNodeMarkedFocus() && !spec.Nodes.HasNodeMarkedPending() {
-				skipChecks = append(skipChecks, func(spec Spec) bool { return !spec.Nodes.HasNodeMarkedFocus() })
+				rules = append(rules, focusRule{
+					name:     "Programmatic Focus",
+					excludes: func(spec Spec) bool { return !spec.Nodes.HasNodeMarkedFocus() },
+					explain: func(spec Spec, excluded bool) string {
+						if excluded {
+							return "other specs are focused with FIt, FDescribe, etc. but this spec is not"
+						}
+						return "the spec, or one of its containers, is focused"
+					},
+				})
 				hasProgrammaticFocus = true
 				break
 			}
@@ -82,44 +128,251 @@ func ApplyFocusToSpecs(specs Specs, description string, suiteLabels Labels, suit
 
 	if suiteConfig.LabelFilter != "" {
 		labelFilter, _ := types.ParseLabelFilter(suiteConfig.LabelFilter)
-		skipChecks = append(skipChecks, func(spec Spec) bool { 
-			return !labelFilter(UnionOfLabels(suiteLabels, spec.Nodes.UnionOfLabels())) 
+		labels := func(spec Spec) []string { return UnionOfLabels(suiteLabels, spec.Nodes.UnionOfLabels()) }
+		rules = append(rules, focusRule{
+			name:     fmt.Sprintf("--label-filter=\"%s\"", suiteConfig.LabelFilter),
+			excludes: func(spec Spec) bool { return !labelFilter(labels(spec)) },
+			explain: func(spec Spec, excluded bool) string {
+				if excluded {
+					return fmt.Sprintf("the spec's labels [%s] do not match", strings.Join(labels(spec), ", "))
+				}
+				return fmt.Sprintf("the spec's labels [%s] match", strings.Join(labels(spec), ", "))
+			},
 		})
 	}
 
 	if len(suiteConfig.FocusFiles) > 0 {
 		focusFilters, _ := types.ParseFileFilters(suiteConfig.FocusFiles)
-		skipChecks = append(skipChecks, func(spec Spec) bool { return !focusFilters.Matches(spec.Nodes.CodeLocations()) })
+		rules = append(rules, focusRule{
+			name:     fmt.Sprintf("--focus-file=%s", strings.Join(suiteConfig.FocusFiles, ",")),
+			excludes: func(spec Spec) bool { return !focusFilters.Matches(spec.Nodes.CodeLocations()) },
+			explain: func(spec Spec, excluded bool) string {
+				if excluded {
+					return "none of the spec's code locations match"
+				}
+				return "one of the spec's code locations matches"
+			},
+		})
 	}
 
 	if len(suiteConfig.SkipFiles) > 0 {
 		skipFilters, _ := types.ParseFileFilters(suiteConfig.SkipFiles)
-		skipChecks = append(skipChecks, func(spec Spec) bool { return skipFilters.Matches(spec.Nodes.CodeLocations()) })
+		rules = append(rules, focusRule{
+			name:     fmt.Sprintf("--skip-file=%s", strings.Join(suiteConfig.SkipFiles, ",")),
+			excludes: func(spec Spec) bool { return skipFilters.Matches(spec.Nodes.CodeLocations()) },
+			explain: func(spec Spec, excluded bool) string {
+				if excluded {
+					return "one of the spec's code locations matches"
+				}
+				return "none of the spec's code locations match"
+			},
+		})
 	}
 
 	if focusString != "" {
 		// skip specs that don't match the focus string
 		re := regexp.MustCompile(focusString)
-		skipChecks = append(skipChecks, func(spec Spec) bool { return !re.MatchString(description + " " + spec.Text()) })
+		rules = append(rules, focusRule{
+			name:     fmt.Sprintf("--focus=\"%s\"", focusString),
+			excludes: func(spec Spec) bool { return !re.MatchString(description + " " + spec.Text()) },
+			explain: func(spec Spec, excluded bool) string {
+				if excluded {
+					return "the spec's full text does not match"
+				}
+				return "the spec's full text matches"
+			},
+		})
 	}
 
 	if skipString != "" {
 		// skip specs that match the skip string
 		re := regexp.MustCompile(skipString)
-		skipChecks = append(skipChecks, func(spec Spec) bool { return re.MatchString(description + " " + spec.Text()) })
+		rules = append(rules, focusRule{
+			name:     fmt.Sprintf("--skip=\"%s\"", skipString),
+			excludes: func(spec Spec) bool { return re.MatchString(description + " " + spec.Text()) },
+			explain: func(spec Spec, excluded bool) string {
+				if excluded {
+					return "the spec's full text matches"
+				}
+				return "the spec's full text does not match"
+			},
+		})
 	}
 
-	// skip specs if shouldSkip() is true.  note that we do nothing if shouldSkip() is false to avoid overwriting skip status established by the node's pending status
-	processedSpecs := Specs{}
+	if suiteConfig.TableSample > 0 && suiteConfig.TableSample < 1 {
+		// skip the table entries that are not in the sample
+		sampled := sampleTableEntries(specs, suiteConfig.TableSample, suiteConfig.TableSamplingSeed())
+		rules = append(rules, focusRule{
+			name: fmt.Sprintf("--table-sample=%g", suiteConfig.TableSample),
+			excludes: func(spec Spec) bool {
+				for _, node := range spec.Nodes {
+					if node.MarkedTableEntry && !sampled[node.ID] {
+						return true
+					}
+				}
+				return false
+			},
+			explain: func(spec Spec, excluded bool) string {
+				if excluded {
+					return fmt.Sprintf("the spec's table entry was not sampled with seed %d", suiteConfig.TableSamplingSeed())
+				}
+				return "the spec is not a table entry, or its table entry was sampled"
+			},
+		})
+	}
+
+	return rules, hasProgrammaticFocus
+}
+
+// sampleTableEntries returns the IDs of the table entry nodes to run for --table-sample.  Each table's entries are sampled separately and at least one entry of each table is run.
+// The sample only depends on the seed and the structure of the tree, so every parallel process picks the same entries.
+func sampleTableEntries(specs Specs, fraction float64, seed int64) map[uint]bool {
+	tables, entriesByTable, seen := []uint{}, map[uint][]uint{}, map[uint]bool{}
 	for _, spec := range specs {
-		for _, skipCheck := range skipChecks {
-			if skipCheck(spec) {
-				spec.Skip = true
-				break
+		containers := spec.Nodes.WithType(types.NodeTypeContainer)
+		for _, node := range spec.Nodes {
+			if !node.MarkedTableEntry || seen[node.ID] {
+				continue
 			}
+			seen[node.ID] = true
+			table := containers.FirstWithNestingLevel(node.NestingLevel - 1).ID
+			if _, ok := entriesByTable[table]; !ok {
+				tables = append(tables, table)
+			}
+			entriesByTable[table] = append(entriesByTable[table], node.ID)
 		}
-		processedSpecs = append(processedSpecs, spec)
 	}
 
-	return processedSpecs, hasProgrammaticFocus
+	r := rand.New(rand.NewSource(seed))
+	sampled := map[uint]bool{}
+	for _, table := range tables {
+		entries := entriesByTable[table]
+		// the epsilon keeps floating point error from rounding e.g. 0.3 * 10 up to 4
+		n := int(math.Ceil(fraction*float64(len(entries)) - 1e-9))
+		for _, idx := range r.Perm(len(entries))[:max(n, 1)] {
+			sampled[entries[idx]] = true
+		}
+	}
+	return sampled
+}
+
+/*
+	UnmatchedFilters returns the filter expressions passed in via the CLI that do not match any of the specs.  Pending specs still count as matches.
+	Each --focus, --skip, --focus-file, and --skip-file expression is checked on its own; the --label-filter is checked as a whole.
+*/
+func UnmatchedFilters(specs Specs, description string, suiteLabels Labels, suiteConfig types.SuiteConfig) []string {
+	unmatched := []string{}
+	anySpec := func(matches func(spec Spec) bool) bool {
+		for _, spec := range specs {
+			if matches(spec) {
+				return true
+			}
+		}
+		return false
+	}
+
+	if suiteConfig.LabelFilter != "" {
+		labelFilter, _ := types.ParseLabelFilter(suiteConfig.LabelFilter)
+		if !anySpec(func(spec Spec) bool { return labelFilter(UnionOfLabels(suiteLabels, spec.Nodes.UnionOfLabels())) }) {
+			unmatched = append(unmatched, fmt.Sprintf("--label-filter=\"%s\"", suiteConfig.LabelFilter))
+		}
+	}
+
+	for _, flag := range []struct {
+		name    string
+		filters []string
+	}{{"focus-file", suiteConfig.FocusFiles}, {"skip-file", suiteConfig.SkipFiles}} {
+		for _, filter := range flag.filters {
+			fileFilters, _ := types.ParseFileFilters([]string{filter})
+			if !anySpec(func(spec Spec) bool { return fileFilters.Matches(spec.Nodes.CodeLocations()) }) {
+				unmatched = append(unmatched, fmt.Sprintf("--%s=%s", flag.name, filter))
+			}
+		}
+	}
+
+	for _, flag := range []struct {
+		name    string
+		filters []string
+	}{{"focus", suiteConfig.FocusStrings}, {"skip", suiteConfig.SkipStrings}} {
+		for _, filter := range flag.filters {
+			re := regexp.MustCompile(filter)
+			if !anySpec(func(spec Spec) bool { return re.MatchString(description + " " + spec.Text()) }) {
+				unmatched = append(unmatched, fmt.Sprintf("--%s=\"%s\"", flag.name, filter))
+			}
+		}
+	}
+
+	return unmatched
+}
+
+// WriteUnmatchedFilters records the filters that did not match any of the suite's specs at path so that the CLI can validate the filters across all the suites it runs
+func WriteUnmatchedFilters(path string, unmatched []string) error {
+	data, err := json.Marshal(unmatched)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+// FocusRuleOutcome records whether a single rule in Ginkgo's focus policy excluded a spec, and why
+type FocusRuleOutcome struct {
+	Rule     string
+	Excluded bool
+	Reason   string
+}
+
+// FocusExplanation explains why Ginkgo's focus policy includes or excludes a spec
+type FocusExplanation struct {
+	Text         string
+	CodeLocation types.CodeLocation
+	Outcomes     []FocusRuleOutcome
+}
+
+// Included returns true if no rule excluded the spec
+func (e FocusExplanation) Included() bool {
+	for _, outcome := range e.Outcomes {
+		if outcome.Excluded {
+			return false
+		}
+	}
+	return true
+}
+
+var fileLineRegExp = regexp.MustCompile(`^.+:\d+$`)
+
+/*
+	ExplainFocus evaluates every rule in Ginkgo's focus policy against the specs that match query and reports the outcome of each rule.
+	Unlike ApplyFocusToSpecs it does not stop at the first rule that excludes a spec - so users can see all the rules they would need to change to run it.
+
+	query is either a code location (e.g. my_test.go:42) that matches one of the spec's nodes or a substring of the spec's full text.
+*/
+func ExplainFocus(specs Specs, description string, suiteLabels Labels, suiteConfig types.SuiteConfig, query string) []FocusExplanation {
+	matches := func(spec Spec) bool { return strings.Contains(description+" "+spec.Text(), query) }
+	if fileLineRegExp.MatchString(query) {
+		if locationFilters, err := types.ParseFileFilters([]string{query}); err == nil {
+			matches = func(spec Spec) bool { return locationFilters.Matches(spec.Nodes.CodeLocations()) }
+		}
+	}
+
+	rules, _ := focusRules(specs, description, suiteLabels, suiteConfig)
+	explanations := []FocusExplanation{}
+	for _, spec := range specs {
+		if !matches(spec) {
+			continue
+		}
+		explanation := FocusExplanation{
+			Text:         spec.Text(),
+			CodeLocation: spec.FirstNodeWithType(types.NodeTypeIt).CodeLocation,
+		}
+		for _, rule := range rules {
+			excluded := rule.excludes(spec)
+			explanation.Outcomes = append(explanation.Outcomes, FocusRuleOutcome{
+				Rule:     rule.name,
+				Excluded: excluded,
+				Reason:   rule.explain(spec, excluded),
+			})
+		}
+		explanations = append(explanations, explanation)
+	}
+	return explanations
 }
@@ -295,4 +295,106 @@ var _ = Describe("Focus", func() {
 			})
 		})
 	})
+
+	Describe("UnmatchedFilters", func() {
+		var specs Specs
+		var conf types.SuiteConfig
+		BeforeEach(func() {
+			specs = Specs{
+				S(N("dog", CL("file_a", 1), Label("brown"))),
+				S(N("cat", CL("file_b", 3), Label("white"))),
+				S(N("fish", CL("file_b", 17), Pending, Label("wet"))),
+			}
+			conf = types.SuiteConfig{}
+		})
+
+		It("returns nothing when no filters are set", func() {
+			Ω(internal.UnmatchedFilters(specs, "Suite", Labels{}, conf)).Should(BeEmpty())
+		})
+
+		It("returns nothing when every filter matches a spec, counting pending specs", func() {
+			conf.FocusStrings = []string{"dog", "fish"}
+			conf.SkipStrings = []string{"cat"}
+			conf.FocusFiles = []string{"file_a"}
+			conf.SkipFiles = []string{"file_b:17"}
+			conf.LabelFilter = "wet || SuiteLabel"
+			Ω(internal.UnmatchedFilters(specs, "Suite", Labels{}, conf)).Should(BeEmpty())
+		})
+
+		It("checks each expression on its own and reports the ones that match no specs", func() {
+			conf.FocusStrings = []string{"dog", "dgo"}
+			conf.SkipStrings = []string{"cta"}
+			conf.FocusFiles = []string{"file_a", "file_c"}
+			conf.SkipFiles = []string{"file_b:18"}
+			conf.LabelFilter = "brown && white"
+			Ω(internal.UnmatchedFilters(specs, "Suite", Labels{}, conf)).Should(Equal([]string{
+				`--label-filter="brown && white"`,
+				"--focus-file=file_c",
+				"--skip-file=file_b:18",
+				`--focus="dgo"`,
+				`--skip="cta"`,
+			}))
+		})
+
+		It("takes suite labels and the suite description into account", func() {
+			conf.LabelFilter = "SuiteLabel"
+			conf.FocusStrings = []string{"^Suite dog$"}
+			Ω(internal.UnmatchedFilters(specs, "Suite", Labels{"SuiteLabel"}, conf)).Should(BeEmpty())
+		})
+	})
+
+	Describe("ExplainFocus", func() {
+		var specs Specs
+		var conf types.SuiteConfig
+		BeforeEach(func() {
+			specs = Specs{
+				S(N("dog", CL("file_a", 1), Label("brown"))),
+				S(N("dog cat", CL("file_b", 3), Label("white"))),
+				S(N("fish", CL("file_b", 17), Pending)),
+			}
+			conf = types.SuiteConfig{}
+		})
+
+		It("evaluates every rule against the specs whose text contains the query", func() {
+			conf.LabelFilter = "brown"
+			conf.SkipStrings = []string{"cat"}
+			explanations := internal.ExplainFocus(specs, "Suite", Labels{}, conf, "cat")
+			Ω(explanations).Should(HaveLen(1))
+			Ω(explanations[0].Text).Should(Equal("dog cat"))
+			Ω(explanations[0].CodeLocation).Should(Equal(CL("file_b", 3)))
+			Ω(explanations[0].Included()).Should(BeFalse())
+			Ω(explanations[0].Outcomes).Should(Equal([]internal.FocusRuleOutcome{
+				{Rule: "Pending", Excluded: false, Reason: "the spec is not marked Pending"},
+				{Rule: `--label-filter="brown"`, Excluded: true, Reason: "the spec's labels [white] do not match"},
+				{Rule: `--skip="cat"`, Excluded: true, Reason: "the spec's full text matches"},
+			}))
+
+			explanations = internal.ExplainFocus(specs, "Suite", Labels{}, conf, "dog")
+			Ω(explanations).Should(HaveLen(2))
+			Ω(explanations[0].Included()).Should(BeTrue())
+			Ω(explanations[1].Included()).Should(BeFalse())
+		})
+
+		It("matches specs by location when the query is a file:line location", func() {
+			explanations := internal.ExplainFocus(specs, "Suite", Labels{}, conf, "file_b:17")
+			Ω(explanations).Should(HaveLen(1))
+			Ω(explanations[0].Text).Should(Equal("fish"))
+			Ω(explanations[0].Outcomes).Should(Equal([]internal.FocusRuleOutcome{
+				{Rule: "Pending", Excluded: true, Reason: "the spec, or one of its containers, is marked Pending"},
+			}))
+		})
+
+		It("explains programmatic focus", func() {
+			specs = append(specs, S(N("pony", Focus)))
+			explanations := internal.ExplainFocus(specs, "Suite", Labels{}, conf, "o")
+			Ω(explanations).Should(HaveLen(3))
+			Ω(explanations[0].Outcomes).Should(ContainElement(internal.FocusRuleOutcome{Rule: "Programmatic Focus", Excluded: true, Reason: "other specs are focused with FIt, FDescribe, etc. but this spec is not"}))
+			Ω(explanations[2].Text).Should(Equal("pony"))
+			Ω(explanations[2].Included()).Should(BeTrue())
+		})
+
+		It("returns nothing when no spec matches", func() {
+			Ω(internal.ExplainFocus(specs, "Suite", Labels{}, conf, "unicorn")).Should(BeEmpty())
+		})
+	})
 })
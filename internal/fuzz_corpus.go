@@ -0,0 +1,187 @@
+package internal
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+const fuzzCorpusHeader = "go test fuzz v1"
+
+var fuzzCorpusTypes = map[string]reflect.Type{
+	"bool":    reflect.TypeOf(false),
+	"string":  reflect.TypeOf(""),
+	"byte":    reflect.TypeOf(byte(0)),
+	"rune":    reflect.TypeOf(rune(0)),
+	"int":     reflect.TypeOf(int(0)),
+	"int8":    reflect.TypeOf(int8(0)),
+	"int16":   reflect.TypeOf(int16(0)),
+	"int32":   reflect.TypeOf(int32(0)),
+	"int64":   reflect.TypeOf(int64(0)),
+	"uint":    reflect.TypeOf(uint(0)),
+	"uint8":   reflect.TypeOf(uint8(0)),
+	"uint16":  reflect.TypeOf(uint16(0)),
+	"uint32":  reflect.TypeOf(uint32(0)),
+	"uint64":  reflect.TypeOf(uint64(0)),
+	"float32": reflect.TypeOf(float32(0)),
+	"float64": reflect.TypeOf(float64(0)),
+}
+
+// ParseFuzzCorpusEntry parses a file from a Go fuzz corpus (e.g. testdata/fuzz/FuzzX/...) and returns the values it contains, in order.
+// The file must use the "go test fuzz v1" encoding: a header line followed by one value per line, written as a Go conversion such as int(1) or []byte("a").
+func ParseFuzzCorpusEntry(data []byte) ([]interface{}, error) {
+	lines := strings.Split(string(data), "\n")
+	if strings.TrimSpace(lines[0]) != fuzzCorpusHeader {
+		return nil, fmt.Errorf("missing %q header", fuzzCorpusHeader)
+	}
+	values := []interface{}{}
+	for i, line := range lines[1:] {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		value, err := parseFuzzCorpusValue(line)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", i+2, err)
+		}
+		values = append(values, value)
+	}
+	return values, nil
+}
+
+func parseFuzzCorpusValue(line string) (interface{}, error) {
+	expr, err := parser.ParseExpr(line)
+	if err != nil {
+		return nil, err
+	}
+	call, ok := expr.(*ast.CallExpr)
+	if !ok || len(call.Args) != 1 {
+		return nil, fmt.Errorf("expected a conversion such as int(1), got %s", line)
+	}
+	arg := call.Args[0]
+
+	switch fun := call.Fun.(type) {
+	case *ast.ArrayType:
+		if elt, ok := fun.Elt.(*ast.Ident); !ok || fun.Len != nil || (elt.Name != "byte" && elt.Name != "uint8") {
+			return nil, fmt.Errorf("unsupported type in %s", line)
+		}
+		s, err := fuzzCorpusLiteral(arg, token.STRING)
+		if err != nil {
+			return nil, err
+		}
+		s, err = strconv.Unquote(s)
+		return []byte(s), err
+	case *ast.SelectorExpr:
+		// NaNs and other special floats are written as math.Float64frombits(0x...)
+		if pkg, ok := fun.X.(*ast.Ident); !ok || pkg.Name != "math" {
+			return nil, fmt.Errorf("unsupported function in %s", line)
+		}
+		lit, err := fuzzCorpusLiteral(arg, token.INT)
+		if err != nil {
+			return nil, err
+		}
+		switch fun.Sel.Name {
+		case "Float64frombits":
+			bits, err := strconv.ParseUint(lit, 0, 64)
+			return math.Float64frombits(bits), err
+		case "Float32frombits":
+			bits, err := strconv.ParseUint(lit, 0, 32)
+			return math.Float32frombits(uint32(bits)), err
+		}
+		return nil, fmt.Errorf("unsupported function in %s", line)
+	case *ast.Ident:
+		t, ok := fuzzCorpusTypes[fun.Name]
+		if !ok {
+			return nil, fmt.Errorf("unsupported type in %s", line)
+		}
+		value := reflect.New(t).Elem()
+		switch t.Kind() {
+		case reflect.Bool:
+			ident, ok := arg.(*ast.Ident)
+			if !ok || (ident.Name != "true" && ident.Name != "false") {
+				return nil, fmt.Errorf("expected true or false, got %s", line)
+			}
+			value.SetBool(ident.Name == "true")
+		case reflect.String:
+			s, err := fuzzCorpusLiteral(arg, token.STRING)
+			if err != nil {
+				return nil, err
+			}
+			if s, err = strconv.Unquote(s); err != nil {
+				return nil, err
+			}
+			value.SetString(s)
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			lit, err := fuzzCorpusIntegerLiteral(arg)
+			if err != nil {
+				return nil, err
+			}
+			n, err := strconv.ParseInt(lit, 0, t.Bits())
+			if err != nil {
+				return nil, err
+			}
+			value.SetInt(n)
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			lit, err := fuzzCorpusIntegerLiteral(arg)
+			if err != nil {
+				return nil, err
+			}
+			n, err := strconv.ParseUint(lit, 0, t.Bits())
+			if err != nil {
+				return nil, err
+			}
+			value.SetUint(n)
+		case reflect.Float32, reflect.Float64:
+			lit, err := fuzzCorpusLiteral(arg, token.INT, token.FLOAT)
+			if err != nil {
+				return nil, err
+			}
+			f, err := strconv.ParseFloat(lit, t.Bits())
+			if err != nil {
+				return nil, err
+			}
+			value.SetFloat(f)
+		}
+		return value.Interface(), nil
+	}
+	return nil, fmt.Errorf("unsupported type in %s", line)
+}
+
+// fuzzCorpusIntegerLiteral returns the text of an integer literal.  Bytes and runes may also be written as character literals, e.g. byte('a')
+func fuzzCorpusIntegerLiteral(arg ast.Expr) (string, error) {
+	if lit, err := fuzzCorpusLiteral(arg, token.CHAR); err == nil {
+		s, err := strconv.Unquote(lit)
+		if err != nil {
+			return "", err
+		}
+		r, size := utf8.DecodeRuneInString(s)
+		if r == utf8.RuneError && size == 1 {
+			// a byte that isn't valid UTF-8, e.g. '\xff'
+			r = rune(s[0])
+		}
+		return strconv.Itoa(int(r)), nil
+	}
+	return fuzzCorpusLiteral(arg, token.INT)
+}
+
+// fuzzCorpusLiteral returns the text of a basic literal of one of the passed-in kinds, including its sign if it is negated
+func fuzzCorpusLiteral(arg ast.Expr, kinds ...token.Token) (string, error) {
+	sign := ""
+	if unary, ok := arg.(*ast.UnaryExpr); ok && (unary.Op == token.SUB || unary.Op == token.ADD) {
+		sign, arg = unary.Op.String(), unary.X
+	}
+	if lit, ok := arg.(*ast.BasicLit); ok {
+		for _, kind := range kinds {
+			if lit.Kind == kind && (sign == "" || kind == token.INT || kind == token.FLOAT) {
+				return sign + lit.Value, nil
+			}
+		}
+	}
+	return "", fmt.Errorf("unexpected value %T", arg)
+}
@@ -0,0 +1,55 @@
+package internal_test
+
+import (
+	"math"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/onsi/ginkgo/v2/internal"
+)
+
+var _ = Describe("ParseFuzzCorpusEntry", func() {
+	It("parses each of the types the go tool writes to fuzz corpora", func() {
+		values, err := internal.ParseFuzzCorpusEntry([]byte(`go test fuzz v1
+[]byte("a\x00b")
+string("hello\n")
+bool(true)
+byte('a')
+byte('\xff')
+rune('☃')
+int(-17)
+int8(-128)
+int16(0x10)
+int32(7)
+int64(9223372036854775807)
+uint(3)
+uint8(255)
+uint16(1)
+uint32(2)
+uint64(18446744073709551615)
+float32(1.5)
+float64(-2)
+math.Float64frombits(0x7ff0000000000000)
+`))
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(values).Should(Equal([]interface{}{
+			[]byte("a\x00b"), "hello\n", true, byte('a'), byte(0xff), '☃',
+			-17, int8(-128), int16(16), int32(7), int64(math.MaxInt64),
+			uint(3), uint8(255), uint16(1), uint32(2), uint64(math.MaxUint64),
+			float32(1.5), float64(-2), math.Inf(1),
+		}))
+	})
+
+	It("errors if the header is missing", func() {
+		_, err := internal.ParseFuzzCorpusEntry([]byte("int(1)\n"))
+		Ω(err).Should(MatchError(ContainSubstring(`missing "go test fuzz v1" header`)))
+	})
+
+	It("errors on values it can't parse, reporting the line", func() {
+		for _, line := range []string{"int(\"a\")", "int8(300)", "complex128(1)", "bool(1)", "[]int(1)", "os.Exit(1)", "1"} {
+			_, err := internal.ParseFuzzCorpusEntry([]byte("go test fuzz v1\nint(1)\n" + line + "\n"))
+			Ω(err).Should(MatchError(HavePrefix("line 3: ")), line)
+		}
+	})
+})
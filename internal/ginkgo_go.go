@@ -0,0 +1,52 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/onsi/ginkgo/v2/types"
+)
+
+const DefaultGinkgoGoGracePeriod = time.Second
+
+// GinkgoGoroutine tracks a goroutine launched by GinkgoGo
+type GinkgoGoroutine struct {
+	CodeLocation types.CodeLocation
+
+	cancel context.CancelFunc
+	done   chan interface{}
+}
+
+// StartGinkgoGoroutine launches body in a goroutine with recoverFn deferred.  The goroutine's SpecContext is cancelled when Stop is called.
+// The goroutine runs concurrently with the spec runner so its SpecContext reports a snapshot of the spec report taken when the goroutine is launched.
+func StartGinkgoGoroutine(suite *Suite, cl types.CodeLocation, body func(SpecContext), recoverFn func()) *GinkgoGoroutine {
+	ctx, cancel := context.WithCancel(context.Background())
+	goroutine := &GinkgoGoroutine{
+		CodeLocation: cl,
+		cancel:       cancel,
+		done:         make(chan interface{}),
+	}
+	specContext := newSpecContextWithReport(ctx, suite, suite.CurrentSpecReport())
+	go func() {
+		defer close(goroutine.done)
+		defer recoverFn()
+		body(specContext)
+	}()
+	return goroutine
+}
+
+// Stop cancels the goroutine's SpecContext and waits up to gracePeriod for it to exit.  Stop returns false if the goroutine did not exit in time.
+func (g *GinkgoGoroutine) Stop(gracePeriod time.Duration) bool {
+	g.cancel()
+	select {
+	case <-g.done:
+		return true
+	case <-time.After(gracePeriod):
+		return false
+	}
+}
+
+func (g *GinkgoGoroutine) LeakMessage(gracePeriod time.Duration) string {
+	return fmt.Sprintf("The goroutine launched by GinkgoGo at %s did not exit within %s of its SpecContext being cancelled.\nMake sure the goroutine returns when <-ctx.Done() is closed - leaked goroutines that make assertions cause flakey failures in unrelated specs.", g.CodeLocation, gracePeriod)
+}
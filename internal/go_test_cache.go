@@ -0,0 +1,11 @@
+package internal
+
+import "flag"
+
+// IsGoTestCacheable reports whether go test is recording the current run for its test cache.
+// go test only passes -test.testlogfile to the test binary when the run is eligible for caching,
+// in which case later invocations of go test may replay the results without running the suite.
+func IsGoTestCacheable() bool {
+	testLogFile := flag.Lookup("test.testlogfile")
+	return testLogFile != nil && testLogFile.Value.String() != ""
+}
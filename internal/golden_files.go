@@ -0,0 +1,65 @@
+package internal
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// GoldenFileName returns the name of the golden file for the table entry with the passed-in description
+func GoldenFileName(description string) string {
+	name := strings.Trim(unsafeArtifactDirCharacters.ReplaceAllString(description, "_"), "_")
+	if name == "" {
+		name = "entry"
+	}
+	return name + ".golden"
+}
+
+// FormatGoldenValue renders a value returned by a table's body the way it is stored in a golden file.
+// Strings and byte slices are stored as-is - anything else is stored as indented JSON.
+func FormatGoldenValue(value interface{}) ([]byte, error) {
+	switch value := value.(type) {
+	case string:
+		return []byte(value), nil
+	case []byte:
+		return value, nil
+	}
+	data, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
+
+// CompareWithGoldenFile returns an error if actual does not match the contents of the golden file at path.
+// When running with --update-snapshots it (re)writes the golden file instead.
+func (suite *Suite) CompareWithGoldenFile(path string, actual []byte) error {
+	if suite.config.UpdateSnapshots {
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(path, actual, 0644); err != nil {
+			return err
+		}
+		fmt.Fprintf(suite.writer, "Ginkgo: updated golden file %s\n", path)
+		return nil
+	}
+	expected, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return fmt.Errorf("Golden file %s does not exist.  Run with --update-snapshots to create it.", path)
+	}
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(expected, actual) {
+		return fmt.Errorf("Value does not match golden file %s.  Run with --update-snapshots to update it.\nExpected:\n%s\nActual:\n%s", path, indentGoldenValue(expected), indentGoldenValue(actual))
+	}
+	return nil
+}
+
+func indentGoldenValue(value []byte) string {
+	return "    " + strings.ReplaceAll(strings.TrimSuffix(string(value), "\n"), "\n", "\n    ")
+}
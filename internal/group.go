@@ -2,6 +2,7 @@ package internal
 
 import (
 	"fmt"
+	"sync/atomic"
 	"time"
 
 	"github.com/onsi/ginkgo/v2/types"
@@ -93,31 +94,41 @@ type group struct {
 	specs          Specs
 	runOncePairs   map[uint]runOncePairs
 	runOnceTracker map[runOncePair]types.SpecState
+	// pendingAfterPairs tracks the run-once after nodes (e.g. AfterAll) whose containers have started running but that have not run yet
+	pendingAfterPairs map[runOncePair]bool
 
 	succeeded bool
 }
 
 func newGroup(suite *Suite) *group {
 	return &group{
-		suite:          suite,
-		runOncePairs:   map[uint]runOncePairs{},
-		runOnceTracker: map[runOncePair]types.SpecState{},
-		succeeded:      true,
+		suite:             suite,
+		runOncePairs:      map[uint]runOncePairs{},
+		runOnceTracker:    map[runOncePair]types.SpecState{},
+		pendingAfterPairs: map[runOncePair]bool{},
+		succeeded:         true,
 	}
 }
 
 func (g *group) initialReportForSpec(spec Spec) types.SpecReport {
+	containers, it := spec.Nodes.WithType(types.NodeTypeContainer), spec.FirstNodeWithType(types.NodeTypeIt)
 	return types.SpecReport{
-		ContainerHierarchyTexts:     spec.Nodes.WithType(types.NodeTypeContainer).Texts(),
-		ContainerHierarchyLocations: spec.Nodes.WithType(types.NodeTypeContainer).CodeLocations(),
-		ContainerHierarchyLabels:    spec.Nodes.WithType(types.NodeTypeContainer).Labels(),
-		LeafNodeLocation:            spec.FirstNodeWithType(types.NodeTypeIt).CodeLocation,
+		ContainerHierarchyTexts:     containers.Texts(),
+		ContainerHierarchyLocations: containers.CodeLocations(),
+		ContainerHierarchyLabels:    containers.Labels(),
+		LeafNodeLocation:            it.CodeLocation,
 		LeafNodeType:                types.NodeTypeIt,
-		LeafNodeText:                spec.FirstNodeWithType(types.NodeTypeIt).Text,
-		LeafNodeLabels:              []string(spec.FirstNodeWithType(types.NodeTypeIt).Labels),
+		LeafNodeText:                it.Text,
+		LeafNodeLabels:              []string(it.Labels),
 		ParallelProcess:             g.suite.config.ParallelProcess,
 		IsSerial:                    spec.Nodes.HasNodeMarkedSerial(),
 		IsInOrderedContainer:        !spec.Nodes.FirstNodeMarkedOrdered().IsZero(),
+		VerboseOutput:               spec.Nodes.HasNodeMarkedVerboseOutput(),
+		SlowSpecThreshold:           spec.Nodes.SlowSpecThreshold(),
+		PollProgressAfter:           spec.Nodes.PollProgressAfter(),
+		ResourceRequirements:        spec.Nodes.ResourceRequirements(),
+		Annotations:                 spec.Nodes.Annotations(),
+		SourceFiles:                 spec.Nodes.SourceFiles(),
 	}
 }
 
@@ -131,6 +142,9 @@ func (g *group) evaluateSkipStatus(spec Spec) (types.SpecState, types.Failure) {
 	if g.suite.interruptHandler.Status().Interrupted || g.suite.skipAll {
 		return types.SpecStateSkipped, types.Failure{}
 	}
+	if g.suite.timeBoxExhausted() {
+		return types.SpecStateSkipped, g.suite.failureForLeafNodeWithMessage(spec.FirstNodeWithType(types.NodeTypeIt), types.TimeBoxSkipMessage)
+	}
 	if !g.succeeded {
 		return types.SpecStateSkipped, g.suite.failureForLeafNodeWithMessage(spec.FirstNodeWithType(types.NodeTypeIt),
 			"Spec skipped because an earlier spec in an ordered container failed")
@@ -145,9 +159,40 @@ func (g *group) evaluateSkipStatus(spec Spec) (types.SpecState, types.Failure) {
 	if g.suite.config.DryRun {
 		return types.SpecStatePassed, types.Failure{}
 	}
+	for _, requirement := range spec.Nodes.NamedRequirements() {
+		if reason, unmet := g.suite.unmetRequirements[requirement]; unmet {
+			return types.SpecStateSkipped, g.suite.failureForLeafNodeWithMessage(spec.FirstNodeWithType(types.NodeTypeIt),
+				fmt.Sprintf("Spec skipped because requirement %s is not met: %s", requirement, reason))
+		}
+	}
+	for _, node := range spec.Nodes {
+		for _, skipCondition := range node.SkipConditions {
+			skip, forwardedPanic := evaluateSkipCondition(skipCondition)
+			if !skip && forwardedPanic == "" {
+				continue
+			}
+			failure := g.suite.failureForLeafNodeWithMessage(spec.FirstNodeWithType(types.NodeTypeIt), skipCondition.Reason)
+			failure.Location = skipCondition.CodeLocation
+			if forwardedPanic != "" {
+				failure.Message, failure.ForwardedPanic = "SkipIf condition panicked", forwardedPanic
+				return types.SpecStatePanicked, failure
+			}
+			return types.SpecStateSkipped, failure
+		}
+	}
 	return g.suite.currentSpecReport.State, g.suite.currentSpecReport.Failure
 }
 
+// evaluateSkipCondition evaluates a SkipIf condition, recovering from - and returning - any panic
+func evaluateSkipCondition(skipCondition SkipCondition) (skip bool, forwardedPanic string) {
+	defer func() {
+		if e := recover(); e != nil {
+			forwardedPanic = fmt.Sprintf("%v", e)
+		}
+	}()
+	return skipCondition.Condition(), ""
+}
+
 func (g *group) isLastSpecWithPair(specID uint, pair runOncePair) bool {
 	lastSpecID := uint(0)
 	for idx := range g.specs {
@@ -163,8 +208,64 @@ func (g *group) isLastSpecWithPair(specID uint, pair runOncePair) bool {
 }
 
 func (g *group) attemptSpec(isFinalAttempt bool, spec Spec) {
-	interruptStatus := g.suite.interruptHandler.Status()
+	g.suite.failer.SetRetryOnStopTrying(!isFinalAttempt)
+	defer g.suite.failer.SetRetryOnStopTrying(false)
+
+	// each attempt gets its own artifacts directory, and its own network captures
+	g.suite.currentSpecArtifactsDir = ""
+	stopNetworkCaptures := g.suite.startNetworkCaptures()
+	defer stopNetworkCaptures()
+
+	// environment variables set on the spec's containers (and on the spec itself) apply to all of its nodes
+	restoreEnv := setEnv(spec.Nodes.WithType(types.NodeTypesForContainerAndIt).Env())
+	defer restoreEnv()
+	restoreAssertionDefaults := g.suite.applyAssertionDefaults(spec)
+	defer restoreAssertionDefaults()
+
+	aroundEachNodes := spec.Nodes.WithType(types.NodeTypeAroundEach).SortedByAscendingNestingLevel()
+	g.runAroundEachNodes(aroundEachNodes, spec, func() {
+		g.runSpecNodes(isFinalAttempt, spec)
+	})
+}
+
+// runAroundEachNodes runs the outermost AroundEach node and hands it a runSpec function that runs the remaining AroundEach nodes and, finally, the spec itself
+// AroundEach nodes are not passed the interrupt channel: they must wait for the spec they wrap to finish, and the spec's own nodes are interrupted as usual
+func (g *group) runAroundEachNodes(nodes Nodes, spec Spec, runSpec func()) {
+	if len(nodes) == 0 {
+		runSpec()
+		return
+	}
+
+	node, didRunSpec := nodes[0], false
+	node.Body = func() {
+		node.AroundEachBody(func() {
+			if didRunSpec {
+				return
+			}
+			didRunSpec = true
+			g.runAroundEachNodes(nodes[1:], spec, runSpec)
+			g.suite.currentNode = node
+		})
+		if !didRunSpec {
+			g.suite.failer.Fail("AroundEach returned without calling runSpec so the spec never ran", node.CodeLocation)
+		}
+	}
+
+	state, failure := g.suite.runNode(node, nil, spec.Nodes.BestTextFor(node))
+	g.suite.currentSpecReport.RunTime = time.Since(g.suite.currentSpecReport.StartTime)
+	if state == types.SpecStatePassed {
+		return
+	}
+	if !didRunSpec || g.suite.currentSpecReport.State == types.SpecStatePassed {
+		g.suite.currentSpecReport.State, g.suite.currentSpecReport.Failure = state, failure
+		return
+	}
+	g.suite.currentSpecReport.AdditionalFailures = append(g.suite.currentSpecReport.AdditionalFailures, types.AdditionalFailure{State: state, Failure: failure})
+}
 
+// runSpecNodes runs the spec's setup nodes, subject, and cleanup nodes
+func (g *group) runSpecNodes(isFinalAttempt bool, spec Spec) {
+	interruptStatus := g.suite.interruptHandler.Status()
 	pairs := g.runOncePairs[spec.SubjectID()]
 
 	nodes := spec.Nodes.WithType(types.NodeTypeBeforeAll)
@@ -189,6 +290,20 @@ func (g *group) attemptSpec(isFinalAttempt bool, spec Spec) {
 		}
 	}
 
+	// the run-once after nodes of the containers we entered are now pending - until they run, they must run should the suite be interrupted or aborted
+	for _, node := range spec.Nodes.WithType(types.NodeTypeAfterAll | types.NodeTypeAfterEach | types.NodeTypeJustAfterEach) {
+		if pair := pairs.runOncePairFor(node.ID); !pair.isZero() && (terminatingNode.IsZero() || node.NestingLevel <= terminatingNode.NestingLevel) {
+			g.pendingAfterPairs[pair] = true
+		}
+	}
+
+	// failure artifacts are collected as soon as the spec fails - before any cleanup nodes have a chance to tear down the state they capture
+	collectedFailureArtifacts := false
+	if g.suite.currentSpecReport.State.Is(failureArtifactStates) {
+		g.suite.collectFailureArtifacts()
+		collectedFailureArtifacts = true
+	}
+
 	afterNodeWasRun := map[uint]bool{}
 	includeDeferCleanups := false
 	for {
@@ -237,9 +352,9 @@ func (g *group) attemptSpec(isFinalAttempt bool, spec Spec) {
 				if !terminatingPair.isZero() && terminatingNode.NestingLevel == node.NestingLevel {
 					return true //...or, a run-once node at our nesting level was skipped which means this is our last chance to run
 				}
-			case types.SpecStateFailed, types.SpecStatePanicked: // the spec has failed...
-				if isFinalAttempt {
-					return true //...if this was the last attempt then we're the last spec to run and so the AfterNode should run
+			case types.SpecStateFailed, types.SpecStatePanicked, types.SpecStateTimedout: // the spec has failed...
+				if isFinalAttempt || !g.suite.allowsRetry() {
+					return true //...if this was the last attempt (or --retry-on won't let us retry) then we're the last spec to run and so the AfterNode should run
 				}
 				if !terminatingPair.isZero() { // ...and it failed in a run-once.  which will be running again
 					if node.NodeType.Is(types.NodeTypeCleanupAfterEach | types.NodeTypeCleanupAfterAll) {
@@ -260,57 +375,191 @@ func (g *group) attemptSpec(isFinalAttempt bool, spec Spec) {
 
 		for _, node := range nodes {
 			afterNodeWasRun[node.ID] = true
+			delete(g.pendingAfterPairs, pairs.runOncePairFor(node.ID))
+			outputOffset := g.suite.writer.Len()
 			state, failure := g.suite.runNode(node, g.suite.interruptHandler.Status().Channel, spec.Nodes.BestTextFor(node))
 			g.suite.currentSpecReport.RunTime = time.Since(g.suite.currentSpecReport.StartTime)
-			if g.suite.currentSpecReport.State == types.SpecStatePassed || state == types.SpecStateAborted {
+			if state == types.SpecStatePassed {
+				continue
+			}
+			additionalFailure := types.AdditionalFailure{
+				State:                      state,
+				Failure:                    failure,
+				CapturedGinkgoWriterOutput: g.nodeOutputSince(outputOffset),
+			}
+			if g.suite.currentSpecReport.State == types.SpecStatePassed {
+				g.suite.currentSpecReport.State = state
+				g.suite.currentSpecReport.Failure = failure
+				if !collectedFailureArtifacts && state.Is(failureArtifactStates) {
+					g.suite.collectFailureArtifacts()
+					collectedFailureArtifacts = true
+				}
+				continue
+			}
+			if state == types.SpecStateAborted {
+				//aborting takes precedence - we preserve the failure it displaces as an additional failure
+				additionalFailure = types.AdditionalFailure{State: g.suite.currentSpecReport.State, Failure: g.suite.currentSpecReport.Failure}
 				g.suite.currentSpecReport.State = state
 				g.suite.currentSpecReport.Failure = failure
 			}
+			g.suite.currentSpecReport.AdditionalFailures = append(g.suite.currentSpecReport.AdditionalFailures, additionalFailure)
 		}
 		includeDeferCleanups = true
 	}
 
 }
 
+// runIsEnding returns true if the remaining specs will be skipped because the suite has been interrupted or aborted, or has run out of time
+func (g *group) runIsEnding() bool {
+	return g.suite.interruptHandler.Status().Interrupted || g.suite.skipAll || g.suite.timeBoxExhausted()
+}
+
+// hasPendingCleanup returns true if the group has started running the specs in an ordered container but has not yet run the container's AfterAll nodes
+// or the DeferCleanups registered by its BeforeAll and AfterAll nodes
+func (g *group) hasPendingCleanup() bool {
+	return len(g.pendingAfterPairs) > 0 || len(g.suite.cleanupNodes.WithType(types.NodeTypeCleanupAfterAll)) > 0
+}
+
+// runPendingCleanup runs the pending AfterAll (and OncePerOrdered AfterEach) nodes and DeferCleanups when the run ends partway through an ordered container.
+// spec is the first spec that will be skipped: the outcome of the cleanup is recorded on its report
+func (g *group) runPendingCleanup(spec Spec) {
+	pairs := g.runOncePairs[spec.SubjectID()]
+	isPending := func(node Node) bool {
+		return g.pendingAfterPairs[pairs.runOncePairFor(node.ID)]
+	}
+
+	restoreEnv := setEnv(spec.Nodes.WithType(types.NodeTypesForContainerAndIt).Env())
+	defer restoreEnv()
+	restoreAssertionDefaults := g.suite.applyAssertionDefaults(spec)
+	defer restoreAssertionDefaults()
+	g.suite.writer.Truncate()
+	g.suite.outputInterceptor.StartInterceptingOutput()
+
+	nodes := spec.Nodes.WithType(types.NodeTypeAfterEach)
+	nodes = append(nodes, spec.Nodes.WithType(types.NodeTypeAfterAll)...).SortedByDescendingNestingLevel()
+	nodes = append(spec.Nodes.WithType(types.NodeTypeJustAfterEach).SortedByDescendingNestingLevel(), nodes...).Filter(isPending)
+	g.pendingAfterPairs = map[runOncePair]bool{}
+	for {
+		for _, node := range nodes {
+			state, failure := g.suite.runNode(node, g.suite.interruptHandler.Status().Channel, spec.Nodes.BestTextFor(node))
+			if state == types.SpecStatePassed {
+				continue
+			}
+			if g.suite.currentSpecReport.State.Is(types.SpecStateSkipped) {
+				g.suite.currentSpecReport.State, g.suite.currentSpecReport.Failure = state, failure
+				continue
+			}
+			g.suite.currentSpecReport.AdditionalFailures = append(g.suite.currentSpecReport.AdditionalFailures, types.AdditionalFailure{State: state, Failure: failure})
+		}
+		// the DeferCleanups run last - and running them may register more DeferCleanups
+		nodes = g.suite.cleanupNodes.WithType(types.NodeTypeCleanupAfterAll).Reverse()
+		if len(nodes) == 0 {
+			break
+		}
+	}
+
+	g.suite.currentSpecReport.EndTime = time.Now()
+	g.suite.currentSpecReport.RunTime = g.suite.currentSpecReport.EndTime.Sub(g.suite.currentSpecReport.StartTime)
+	g.suite.currentSpecReport.CapturedGinkgoWriterOutput += g.suite.writer.String()
+	g.suite.currentSpecReport.CapturedStdOutErr += g.suite.outputInterceptor.StopInterceptingAndReturnOutput()
+}
+
+// nodeOutputSince returns the GinkgoWriter output emitted since offset
+func (g *group) nodeOutputSince(offset int) string {
+	output := g.suite.writer.Bytes()
+	if offset > len(output) {
+		return ""
+	}
+	return string(output[offset:])
+}
+
 func (g *group) run(specs Specs) {
 	g.specs = specs
 	for _, spec := range g.specs {
 		g.runOncePairs[spec.SubjectID()] = runOncePairsForSpec(spec)
 	}
 
-	for _, spec := range g.specs {
+	for i, spec := range g.specs {
 		g.suite.currentSpecReport = g.initialReportForSpec(spec)
+		if g.suite.currentSpecReport.IsInOrderedContainer {
+			g.suite.currentSpecReport.OrderedContainerStep, g.suite.currentSpecReport.OrderedContainerSteps = i+1, len(g.specs)
+		}
 		g.suite.currentSpecReport.State, g.suite.currentSpecReport.Failure = g.evaluateSkipStatus(spec)
 		g.suite.reporter.WillRun(g.suite.currentSpecReport)
 		g.suite.reportEach(spec, types.NodeTypeReportBeforeEach)
 
 		skip := g.suite.config.DryRun || g.suite.currentSpecReport.State.Is(types.SpecStateFailureStates|types.SpecStateSkipped|types.SpecStatePending)
 
+		if !skip {
+			g.suite.waitForSpecRatePermit(spec)
+		}
 		g.suite.currentSpecReport.StartTime = time.Now()
+		if skip && g.hasPendingCleanup() && g.runIsEnding() && !g.suite.config.SkipOrderedCleanupOnAbort {
+			g.runPendingCleanup(spec)
+		}
 		if !skip {
+			restoreWriterMode := g.suite.streamGinkgoWriterOutputFor(spec)
 			maxAttempts := max(1, spec.FlakeAttempts())
 			if g.suite.config.FlakeAttempts > 0 {
 				maxAttempts = g.suite.config.FlakeAttempts
 			}
 			for attempt := 0; attempt < maxAttempts; attempt++ {
+				backoff := spec.RetryBackoff(attempt)
+				if !g.suite.waitForRetryBackoff(backoff) {
+					break
+				}
 				g.suite.currentSpecReport.NumAttempts = attempt + 1
+				g.suite.currentSpecReport.AdditionalFailures = nil
+				atomic.StoreInt64(&g.suite.currentSpecAssertions, 0)
+				g.suite.currentSpecReport.Steps = nil
 				g.suite.writer.Truncate()
 				g.suite.outputInterceptor.StartInterceptingOutput()
-				if attempt > 0 {
+				if attempt > 0 && backoff > 0 {
+					fmt.Fprintf(g.suite.writer, "\nGinkgo: Attempt #%d Failed.  Retrying after %s...\n", attempt, backoff)
+				} else if attempt > 0 {
 					fmt.Fprintf(g.suite.writer, "\nGinkgo: Attempt #%d Failed.  Retrying...\n", attempt)
 				}
+				attemptStartTime := time.Now()
 
+				cpuTimeAtStart, measuredCPUTime := processCPUTime()
 				g.attemptSpec(attempt == maxAttempts-1, spec)
+				g.suite.currentSpecReport.NumAssertions = int(atomic.LoadInt64(&g.suite.currentSpecAssertions))
+				if cpuTimeAtEnd, ok := processCPUTime(); ok && measuredCPUTime {
+					g.suite.currentSpecReport.CPUTime = cpuTimeAtEnd - cpuTimeAtStart
+					g.suite.checkCPUTimeLimit(spec)
+				}
 
 				g.suite.currentSpecReport.EndTime = time.Now()
 				g.suite.currentSpecReport.RunTime = g.suite.currentSpecReport.EndTime.Sub(g.suite.currentSpecReport.StartTime)
-				g.suite.currentSpecReport.CapturedGinkgoWriterOutput += string(g.suite.writer.Bytes())
-				g.suite.currentSpecReport.CapturedStdOutErr += g.suite.outputInterceptor.StopInterceptingAndReturnOutput()
+				g.suite.finishAbandonedSteps()
+				ginkgoWriterOutput, stdOutErr := g.suite.writer.String(), g.suite.outputInterceptor.StopInterceptingAndReturnOutput()
+				g.suite.currentSpecReport.CapturedGinkgoWriterOutput += ginkgoWriterOutput
+				g.suite.currentSpecReport.CapturedStdOutErr += stdOutErr
+				if maxAttempts > 1 {
+					g.suite.currentSpecReport.Attempts = append(g.suite.currentSpecReport.Attempts, types.SpecAttempt{
+						Attempt:                    attempt + 1,
+						State:                      g.suite.currentSpecReport.State,
+						Backoff:                    backoff,
+						StartTime:                  attemptStartTime,
+						EndTime:                    g.suite.currentSpecReport.EndTime,
+						RunTime:                    g.suite.currentSpecReport.EndTime.Sub(attemptStartTime),
+						Failure:                    g.suite.currentSpecReport.Failure,
+						AdditionalFailures:         g.suite.currentSpecReport.AdditionalFailures,
+						CapturedGinkgoWriterOutput: ginkgoWriterOutput,
+						CapturedStdOutErr:          stdOutErr,
+					})
+				}
 
 				if g.suite.currentSpecReport.State.Is(types.SpecStatePassed | types.SpecStateSkipped | types.SpecStateAborted | types.SpecStateInterrupted) {
 					break
 				}
+				if attempt < maxAttempts-1 && !g.suite.allowsRetry() {
+					g.suite.currentSpecReport.CapturedGinkgoWriterOutput += fmt.Sprintf("\nGinkgo: Attempt #%d Failed.  Not retrying %s failures (see --retry-on).\n", attempt+1, g.suite.currentSpecReport.FailureCategory())
+					break
+				}
 			}
+			restoreWriterMode()
+			g.suite.checkForZeroAssertions(spec)
 		}
 
 		g.suite.reportEach(spec, types.NodeTypeReportAfterEach)
@@ -349,6 +598,15 @@ func (g *group) oldRun(specs Specs) {
 			ParallelProcess:             suite.config.ParallelProcess,
 			IsSerial:                    spec.Nodes.HasNodeMarkedSerial(),
 			IsInOrderedContainer:        !spec.Nodes.FirstNodeMarkedOrdered().IsZero(),
+			VerboseOutput:               spec.Nodes.HasNodeMarkedVerboseOutput(),
+			SlowSpecThreshold:           spec.Nodes.SlowSpecThreshold(),
+			PollProgressAfter:           spec.Nodes.PollProgressAfter(),
+			ResourceRequirements:        spec.Nodes.ResourceRequirements(),
+			Annotations:                 spec.Nodes.Annotations(),
+			SourceFiles:                 spec.Nodes.SourceFiles(),
+		}
+		if suite.currentSpecReport.IsInOrderedContainer {
+			suite.currentSpecReport.OrderedContainerStep, suite.currentSpecReport.OrderedContainerSteps = i+1, len(specs)
 		}
 
 		skip := spec.Skip
@@ -474,7 +732,7 @@ func (g *group) oldRun(specs Specs) {
 					if terminatingNode.NodeType.Is(types.NodeTypeBeforeAll) && terminatingNode.NestingLevel == n.NestingLevel {
 						return true //...or, a BeforeAll was skipped and it's at our nesting level, so our subgroup is going to skip
 					}
-				case types.SpecStateFailed, types.SpecStatePanicked: // the spec has failed...
+				case types.SpecStateFailed, types.SpecStatePanicked, types.SpecStateTimedout: // the spec has failed...
 					if isFinalAttempt {
 						return true //...if this was the last attempt then we're the last spec to run and so the AfterNode should run
 					}
@@ -525,7 +783,7 @@ func (g *group) oldRun(specs Specs) {
 
 			suite.currentSpecReport.EndTime = time.Now()
 			suite.currentSpecReport.RunTime = suite.currentSpecReport.EndTime.Sub(suite.currentSpecReport.StartTime)
-			suite.currentSpecReport.CapturedGinkgoWriterOutput += string(suite.writer.Bytes())
+			suite.currentSpecReport.CapturedGinkgoWriterOutput += suite.writer.String()
 			suite.currentSpecReport.CapturedStdOutErr += suite.outputInterceptor.StopInterceptingAndReturnOutput()
 
 			if suite.currentSpecReport.State.Is(types.SpecStatePassed | types.SpecStateSkipped | types.SpecStateAborted | types.SpecStateInterrupted) {
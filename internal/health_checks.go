@@ -0,0 +1,120 @@
+package internal
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/onsi/ginkgo/v2/types"
+)
+
+// HealthCheck is run periodically, in the background, while the suite runs.  It returns an error if the environment the suite depends on is unhealthy.
+type HealthCheck func() error
+
+type registeredHealthCheck struct {
+	name         string
+	interval     time.Duration
+	check        HealthCheck
+	codeLocation types.CodeLocation
+}
+
+func (suite *Suite) RegisterHealthCheck(name string, interval time.Duration, check HealthCheck, cl types.CodeLocation) {
+	suite.healthChecks = append(suite.healthChecks, registeredHealthCheck{name: name, interval: interval, check: check, codeLocation: cl})
+}
+
+// startHealthMonitor starts running the registered health checks in the background and returns a function that stops them
+func (suite *Suite) startHealthMonitor() func() {
+	if len(suite.healthChecks) == 0 || suite.config.DryRun {
+		return func() {}
+	}
+	suite.healthMonitor = newHealthMonitor()
+	for _, healthCheck := range suite.healthChecks {
+		go suite.healthMonitor.run(healthCheck)
+	}
+	return suite.healthMonitor.stop
+}
+
+// recordEnvironmentDegradations records the periods during which a health check was failing while the current spec ran
+func (suite *Suite) recordEnvironmentDegradations() {
+	if suite.healthMonitor == nil || suite.currentSpecReport.State.Is(types.SpecStateSkipped|types.SpecStatePending) {
+		return
+	}
+	end := suite.currentSpecReport.EndTime
+	if end.IsZero() {
+		end = time.Now()
+	}
+	suite.currentSpecReport.EnvironmentDegradations = suite.healthMonitor.degradationsDuring(suite.currentSpecReport.StartTime, end)
+}
+
+// healthMonitor runs health checks in the background and records the periods during which they fail
+type healthMonitor struct {
+	lock         *sync.Mutex
+	degradations []types.EnvironmentDegradation
+	// failing maps the name of each failing health check to the index of its ongoing degradation
+	failing map[string]int
+	done    chan interface{}
+}
+
+func newHealthMonitor() *healthMonitor {
+	return &healthMonitor{
+		lock:    &sync.Mutex{},
+		failing: map[string]int{},
+		done:    make(chan interface{}),
+	}
+}
+
+func (m *healthMonitor) run(healthCheck registeredHealthCheck) {
+	ticker := time.NewTicker(healthCheck.interval)
+	defer ticker.Stop()
+	for {
+		err := runHealthCheck(healthCheck.check)
+		m.record(healthCheck.name, err, time.Now())
+		select {
+		case <-ticker.C:
+		case <-m.done:
+			return
+		}
+	}
+}
+
+// stop does not wait for running health checks to return - a check that hangs must not hang the suite
+func (m *healthMonitor) stop() {
+	close(m.done)
+}
+
+func (m *healthMonitor) record(name string, err error, t time.Time) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	idx, failing := m.failing[name]
+	if err != nil && !failing {
+		m.failing[name] = len(m.degradations)
+		m.degradations = append(m.degradations, types.EnvironmentDegradation{HealthCheck: name, Message: err.Error(), StartTime: t})
+	} else if err == nil && failing {
+		m.degradations[idx].EndTime = t
+		delete(m.failing, name)
+	}
+}
+
+// degradationsDuring returns the degradations that overlap the period from start to end
+func (m *healthMonitor) degradationsDuring(start time.Time, end time.Time) []types.EnvironmentDegradation {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	var out []types.EnvironmentDegradation
+	for _, degradation := range m.degradations {
+		if degradation.StartTime.After(end) || (!degradation.EndTime.IsZero() && degradation.EndTime.Before(start)) {
+			continue
+		}
+		out = append(out, degradation)
+	}
+	return out
+}
+
+// runHealthCheck turns a panicking health check into a failing one.  Health checks run in the background so, unlike other integrations, they never interact with the Failer.
+func runHealthCheck(check HealthCheck) (err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = fmt.Errorf("panicked: %v", e)
+		}
+	}()
+	return check()
+}
@@ -0,0 +1,74 @@
+package internal_integration_test
+
+import (
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	. "github.com/onsi/ginkgo/v2/internal/test_helpers"
+	"github.com/onsi/ginkgo/v2/types"
+)
+
+var _ = Describe("Annotations", func() {
+	type owner struct {
+		Team string
+	}
+
+	annotationValue := func(value interface{}, found bool) interface{} {
+		Ω(found).Should(BeTrue())
+		return value
+	}
+
+	BeforeEach(func() {
+		success, _ := RunFixture("annotations", func() {
+			Describe("checkout", Annotate("risk", "high"), Annotate("owner", owner{Team: "payments"}), func() {
+				It("A", Annotate("slo-tier", 1), rt.T("A"))
+				It("B", Annotate("risk", "low"), func() {
+					AddSpecAnnotation("risk", "critical")
+					AddSpecAnnotation("attempt-id", "abc")
+				})
+			})
+			It("C", rt.T("C"))
+			ReportAfterEach(func(report SpecReport) {
+				if report.LeafNodeText == "A" {
+					AddSpecAnnotation("reported", true)
+				}
+			})
+		})
+		Ω(success).Should(BeTrue())
+		Ω(rt).Should(HaveTracked("A", "C"))
+	})
+
+	It("records the annotations of the spec and its containers, outermost first", func() {
+		annotations := reporter.Did.Find("A").Annotations
+		Ω(annotations.Keys()).Should(Equal([]string{"risk", "owner", "slo-tier", "reported"}))
+		Ω(annotationValue(annotations.Get("risk"))).Should(Equal("high"))
+		Ω(annotationValue(annotations.Get("owner"))).Should(Equal(owner{Team: "payments"}))
+		Ω(annotationValue(annotations.Get("slo-tier"))).Should(Equal(1))
+		Ω(annotations[0].Location.FileName).Should(HaveSuffix("annotations_test.go"))
+
+		Ω(reporter.Did.Find("C").Annotations).Should(BeEmpty())
+	})
+
+	It("lets more specific annotations, and annotations added at runtime, override less specific ones", func() {
+		annotations := reporter.Did.Find("B").Annotations
+		Ω(annotationValue(annotations.Get("risk"))).Should(Equal("critical"))
+		Ω(annotationValue(annotations.Get("attempt-id"))).Should(Equal("abc"))
+		_, ok := annotations.Get("slo-tier")
+		Ω(ok).Should(BeFalse())
+	})
+
+	It("lets ReportAfterEach nodes annotate the spec", func() {
+		Ω(annotationValue(reporter.Did.Find("A").Annotations.Get("reported"))).Should(BeTrue())
+	})
+
+	It("encodes the annotations into the JSON report", func() {
+		encoded, err := json.Marshal(reporter.Did.Find("A"))
+		Ω(err).ShouldNot(HaveOccurred())
+		var decoded types.SpecReport
+		Ω(json.Unmarshal(encoded, &decoded)).Should(Succeed())
+		Ω(annotationValue(decoded.Annotations.Get("owner"))).Should(Equal(map[string]interface{}{"Team": "payments"}))
+		Ω(annotationValue(decoded.Annotations.Get("slo-tier"))).Should(Equal(float64(1)))
+	})
+})
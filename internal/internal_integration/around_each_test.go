@@ -0,0 +1,192 @@
+package internal_integration_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	"github.com/onsi/ginkgo/v2/internal/interrupt_handler"
+	. "github.com/onsi/ginkgo/v2/internal/test_helpers"
+	"github.com/onsi/ginkgo/v2/types"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("AroundEach", func() {
+	around := func(name string) func(func()) {
+		return func(runSpec func()) {
+			rt.Run(name + "-before")
+			runSpec()
+			rt.Run(name + "-after")
+		}
+	}
+
+	Describe("when AroundEach nodes are nested", func() {
+		BeforeEach(func() {
+			success, _ := RunFixture("nested around each", func() {
+				AroundEach(around("outer"))
+				BeforeEach(rt.T("bef.1"))
+				AfterEach(rt.T("aft.1"))
+				Describe("container", func() {
+					AroundEach(around("inner"))
+					JustBeforeEach(rt.T("jbef"))
+					It("A", func() {
+						rt.Run("A")
+						DeferCleanup(rt.T("cleanup"))
+					})
+					JustAfterEach(rt.T("jaft"))
+				})
+				It("B", rt.T("B"))
+			})
+			Ω(success).Should(BeTrue())
+		})
+
+		It("wraps each spec - including its setup and cleanup - outermost AroundEach first", func() {
+			Ω(rt).Should(HaveTracked(
+				"outer-before", "inner-before", "bef.1", "jbef", "A", "jaft", "aft.1", "cleanup", "inner-after", "outer-after",
+				"outer-before", "bef.1", "B", "aft.1", "outer-after",
+			))
+			Ω(reporter.Did.Find("A")).Should(HavePassed())
+			Ω(reporter.Did.Find("B")).Should(HavePassed())
+		})
+	})
+
+	Describe("when the spec fails", func() {
+		BeforeEach(func() {
+			success, _ := RunFixture("failing spec", func() {
+				AroundEach(around("around"))
+				It("A", rt.T("A", func() { F("boom") }))
+				AfterEach(rt.T("aft"))
+			})
+			Ω(success).Should(BeFalse())
+		})
+
+		It("still runs the rest of the AroundEach and reports the spec's failure", func() {
+			Ω(rt).Should(HaveTracked("around-before", "A", "aft", "around-after"))
+			Ω(reporter.Did.Find("A")).Should(HaveFailed("boom", types.FailureNodeIsLeafNode))
+		})
+	})
+
+	Describe("when the AroundEach fails before running the spec", func() {
+		BeforeEach(func() {
+			success, _ := RunFixture("failing around each", func() {
+				AroundEach(func(runSpec func()) {
+					rt.Run("around")
+					F("no database")
+					runSpec()
+				})
+				It("A", rt.T("A"))
+				AfterEach(rt.T("aft"))
+			})
+			Ω(success).Should(BeFalse())
+		})
+
+		It("does not run the spec and reports the AroundEach's failure", func() {
+			Ω(rt).Should(HaveTracked("around"))
+			Ω(reporter.Did.Find("A")).Should(HaveFailed("no database", FailureNodeType(types.NodeTypeAroundEach), types.FailureNodeAtTopLevel))
+		})
+	})
+
+	Describe("when the AroundEach fails after running the spec", func() {
+		BeforeEach(func() {
+			success, _ := RunFixture("failing around each after the spec", func() {
+				AroundEach(func(runSpec func()) {
+					runSpec()
+					F("transaction did not roll back")
+				})
+				Describe("container", func() {
+					It("A", rt.T("A"))
+					It("B", rt.T("B", func() { F("boom") }))
+				})
+			})
+			Ω(success).Should(BeFalse())
+		})
+
+		It("fails passing specs with the AroundEach's failure", func() {
+			Ω(rt).Should(HaveTracked("A", "B"))
+			Ω(reporter.Did.Find("A")).Should(HaveFailed("transaction did not roll back", FailureNodeType(types.NodeTypeAroundEach)))
+		})
+
+		It("records the AroundEach's failure as an additional failure on failing specs", func() {
+			specB := reporter.Did.Find("B")
+			Ω(specB).Should(HaveFailed("boom"))
+			Ω(specB.AdditionalFailures).Should(HaveLen(1))
+			Ω(specB.AdditionalFailures[0].Failure.Message).Should(Equal("transaction did not roll back"))
+			Ω(specB.AdditionalFailures[0].Failure.FailureNodeType).Should(Equal(types.NodeTypeAroundEach))
+		})
+	})
+
+	Describe("when the AroundEach does not call runSpec", func() {
+		BeforeEach(func() {
+			success, _ := RunFixture("around each that forgets to run the spec", func() {
+				AroundEach(func(runSpec func()) {
+					rt.Run("around")
+				})
+				It("A", rt.T("A"))
+			})
+			Ω(success).Should(BeFalse())
+		})
+
+		It("fails the spec", func() {
+			Ω(rt).Should(HaveTracked("around"))
+			Ω(reporter.Did.Find("A")).Should(HaveFailed("AroundEach returned without calling runSpec so the spec never ran", FailureNodeType(types.NodeTypeAroundEach)))
+		})
+	})
+
+	Describe("when the AroundEach calls runSpec more than once", func() {
+		BeforeEach(func() {
+			success, _ := RunFixture("around each that runs the spec twice", func() {
+				AroundEach(func(runSpec func()) {
+					runSpec()
+					runSpec()
+				})
+				It("A", rt.T("A"))
+			})
+			Ω(success).Should(BeTrue())
+		})
+
+		It("only runs the spec once", func() {
+			Ω(rt).Should(HaveTracked("A"))
+		})
+	})
+
+	Describe("when the spec is retried", func() {
+		BeforeEach(func() {
+			attempts := 0
+			success, _ := RunFixture("flaky spec", func() {
+				AroundEach(around("around"))
+				It("A", func() {
+					rt.Run("A")
+					attempts += 1
+					if attempts < 2 {
+						F("flake")
+					}
+				}, FlakeAttempts(2))
+			})
+			Ω(success).Should(BeTrue())
+		})
+
+		It("wraps each attempt", func() {
+			Ω(rt).Should(HaveTracked("around-before", "A", "around-after", "around-before", "A", "around-after"))
+			Ω(reporter.Did.Find("A")).Should(HavePassed(NumAttempts(2)))
+		})
+	})
+
+	Describe("when the suite is interrupted while the spec is running", func() {
+		BeforeEach(func() {
+			success, _ := RunFixture("interrupted spec", func() {
+				AroundEach(around("around"))
+				It("A", func(ctx SpecContext) {
+					rt.Run("A")
+					interruptHandler.Interrupt(interrupt_handler.InterruptCauseSignal)
+					<-ctx.Done()
+				})
+				AfterEach(rt.T("aft"))
+				It("B", rt.T("B"))
+			})
+			Ω(success).Should(BeFalse())
+		})
+
+		It("interrupts the spec, runs its cleanup, and lets the AroundEach finish", func() {
+			Ω(rt).Should(HaveTracked("around-before", "A", "aft", "around-after"))
+			Ω(reporter.Did.Find("A")).Should(HaveBeenInterrupted(interrupt_handler.InterruptCauseSignal))
+			Ω(reporter.Did.Find("B")).Should(HaveBeenSkipped())
+		})
+	})
+})
@@ -0,0 +1,102 @@
+package internal_integration_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/ginkgo/v2/internal/test_helpers"
+	"github.com/onsi/ginkgo/v2/types"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("counting assertions", func() {
+	var success bool
+	var currentAssertions []int
+	JustBeforeEach(func() {
+		currentAssertions = []int{}
+		success, _ = RunFixture("assertions", func() {
+			BeforeSuite(func() {
+				// opts the suite in to --zero-assertion-policy before any spec runs
+				RecordAssertion()
+			})
+			It("asserts", func() {
+				RecordAssertion()
+				RecordAssertion()
+				currentAssertions = append(currentAssertions, CurrentSpecReport().NumAssertions)
+			})
+			It("asserts in a goroutine", func() {
+				done := make(chan interface{})
+				go func() {
+					RecordAssertion()
+					close(done)
+				}()
+				<-done
+			})
+			It("fails", func() {
+				RecordAssertion()
+				Fail("boom")
+			})
+			It("asserts nothing", func() {})
+			It("is skipped", func() {
+				Skip("not today")
+			})
+			PIt("is pending")
+		})
+	})
+
+	It("counts the assertions each spec makes, including calls to Fail", func() {
+		Ω(currentAssertions).Should(Equal([]int{2}))
+		Ω(reporter.Did.Find("asserts").NumAssertions).Should(Equal(2))
+		Ω(reporter.Did.Find("asserts in a goroutine").NumAssertions).Should(Equal(1))
+		Ω(reporter.Did.Find("fails").NumAssertions).Should(Equal(2))
+		Ω(reporter.Did.Find("asserts nothing").NumAssertions).Should(Equal(0))
+	})
+
+	It("does not flag specs without assertions by default", func() {
+		Ω(reporter.Did.Find("asserts nothing")).Should(HavePassed())
+		Ω(reporter.Did.Find("asserts nothing").Warnings).Should(BeEmpty())
+		Ω(reporter.End).Should(BeASuiteSummary(false, NSpecs(6), NPassed(3), NFailed(1), NSkipped(1), NPending(1)))
+	})
+
+	Context("when the zero assertion policy is warn", func() {
+		BeforeEach(func() {
+			conf.ZeroAssertionPolicy = types.ZeroAssertionPolicyWarn
+		})
+
+		It("warns about passing specs that made no assertions", func() {
+			warnings := reporter.Did.Find("asserts nothing").Warnings
+			Ω(warnings).Should(HaveLen(1))
+			Ω(warnings[0].Message).Should(ContainSubstring("This spec passed without making any assertions"))
+			Ω(reporter.Did.Find("asserts nothing")).Should(HavePassed())
+			Ω(reporter.Did.Find("asserts").Warnings).Should(BeEmpty())
+			Ω(reporter.Did.Find("is skipped").Warnings).Should(BeEmpty())
+		})
+	})
+
+	Context("when the zero assertion policy is fail", func() {
+		BeforeEach(func() {
+			conf.ZeroAssertionPolicy = types.ZeroAssertionPolicyFail
+		})
+
+		It("fails passing specs that made no assertions", func() {
+			Ω(success).Should(BeFalse())
+			Ω(reporter.Did.Find("asserts nothing")).Should(HaveFailed(ContainSubstring("This spec passed without making any assertions"), types.FailureNodeIsLeafNode))
+			Ω(reporter.Did.Find("asserts")).Should(HavePassed())
+			Ω(reporter.Did.Find("is skipped")).Should(HaveBeenSkippedWithMessage("not today"))
+			Ω(reporter.End).Should(BeASuiteSummary(false, NSpecs(6), NPassed(2), NFailed(2), NSkipped(1), NPending(1)))
+		})
+
+		It("does not apply to suites that never call RecordAssertion, since Ginkgo can't see the assertions that pass", func() {
+			success, _ := RunFixture("gomega only", func() {
+				It("asserts with Gomega", func() {
+					Ω(true).Should(BeTrue())
+				})
+				It("fails", func() {
+					Fail("boom")
+				})
+			})
+			Ω(success).Should(BeFalse())
+			Ω(reporter.Did.Find("asserts with Gomega")).Should(HavePassed())
+			Ω(reporter.Did.Find("asserts with Gomega").Warnings).Should(BeEmpty())
+			Ω(reporter.Did.Find("fails")).Should(HaveFailed("boom"))
+		})
+	})
+})
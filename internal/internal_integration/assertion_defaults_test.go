@@ -0,0 +1,85 @@
+package internal_integration_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("AssertionDefaults", func() {
+	var current AssertionDefaults
+	var observed map[string]AssertionDefaults
+	observe := func(name string) func() {
+		return func() {
+			rt.Run(name)
+			observed[name] = current
+		}
+	}
+
+	BeforeEach(func() {
+		current = AssertionDefaults{}
+		observed = map[string]AssertionDefaults{}
+	})
+
+	fixture := func() {
+		SetAssertionDefaultsForLabel("slow", AssertionDefaults{EventuallyTimeout: time.Minute, ConsistentlyDuration: time.Second})
+		Describe("outer", AssertionDefaults{EventuallyTimeout: time.Hour, EventuallyPollingInterval: time.Second}, func() {
+			BeforeEach(func() { observe("bef-" + CurrentSpecReport().LeafNodeText)() })
+			It("A", observe("A"))
+			It("B", AssertionDefaults{EventuallyPollingInterval: time.Millisecond}, observe("B"))
+			It("C", Label("SLOW"), observe("C"))
+		})
+		It("D", Label("slow"), observe("D"))
+		It("E", observe("E"))
+	}
+
+	Context("when a handler is registered", func() {
+		var handled []AssertionDefaults
+		BeforeEach(func() {
+			handled = []AssertionDefaults{}
+			success, _ := RunFixture("assertion defaults", func() {
+				RegisterAssertionDefaultsHandler(func(defaults AssertionDefaults) func() {
+					handled = append(handled, defaults)
+					current = defaults
+					return func() { current = AssertionDefaults{} }
+				})
+				fixture()
+			})
+			Ω(success).Should(BeTrue())
+		})
+
+		It("hands each spec's merged defaults to the handler for the whole spec and restores them afterwards", func() {
+			Ω(rt.TrackedRuns()).Should(ConsistOf("bef-A", "A", "bef-B", "B", "bef-C", "C", "D", "E"))
+			Ω(observed).Should(Equal(map[string]AssertionDefaults{
+				"bef-A": {EventuallyTimeout: time.Hour, EventuallyPollingInterval: time.Second},
+				"A":     {EventuallyTimeout: time.Hour, EventuallyPollingInterval: time.Second},
+				"bef-B": {EventuallyTimeout: time.Hour, EventuallyPollingInterval: time.Millisecond},
+				"B":     {EventuallyTimeout: time.Hour, EventuallyPollingInterval: time.Millisecond},
+				"bef-C": {EventuallyTimeout: time.Hour, EventuallyPollingInterval: time.Second, ConsistentlyDuration: time.Second},
+				"C":     {EventuallyTimeout: time.Hour, EventuallyPollingInterval: time.Second, ConsistentlyDuration: time.Second},
+				"D":     {EventuallyTimeout: time.Minute, ConsistentlyDuration: time.Second},
+				"E":     {},
+			}))
+			Ω(current).Should(BeZero())
+		})
+
+		It("does not call the handler for specs without defaults", func() {
+			Ω(handled).Should(HaveLen(4))
+		})
+	})
+
+	Context("when no handler is registered", func() {
+		BeforeEach(func() {
+			success, _ := RunFixture("assertion defaults without a handler", fixture)
+			Ω(success).Should(BeTrue())
+		})
+
+		It("leaves the assertion library alone", func() {
+			Ω(rt.TrackedRuns()).Should(ConsistOf("bef-A", "A", "bef-B", "B", "bef-C", "C", "D", "E"))
+			for _, defaults := range observed {
+				Ω(defaults).Should(BeZero())
+			}
+		})
+	})
+})
@@ -0,0 +1,37 @@
+package internal_integration_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	"github.com/onsi/ginkgo/v2/types"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Binary output", func() {
+	BeforeEach(func() {
+		success, _ := RunFixture("binary output", func() {
+			It("emits text", func() {
+				outputInterceptor.AppendInterceptedOutput("hello\n")
+				writer.Println("world")
+			})
+
+			It("emits binary", func() {
+				outputInterceptor.AppendInterceptedOutput("\x00\x01\x02\xff")
+				writer.Println("world")
+			})
+		})
+		Ω(success).Should(BeTrue())
+	})
+
+	It("leaves text output inline", func() {
+		report := reporter.Did.Find("emits text")
+		Ω(report.CapturedStdOutErr).Should(Equal("hello\n"))
+		Ω(report.Attachments).Should(BeEmpty())
+	})
+
+	It("moves binary output into an attachment before reporters see it", func() {
+		report := reporter.Did.Find("emits binary")
+		Ω(report.CapturedStdOutErr).Should(ContainSubstring("4 bytes of binary output"))
+		Ω(report.CapturedGinkgoWriterOutput).Should(Equal("world\n"))
+		Ω(report.Attachments).Should(Equal([]types.Attachment{{Name: types.AttachmentNameStdOutErr, Data: []byte("\x00\x01\x02\xff")}}))
+	})
+})
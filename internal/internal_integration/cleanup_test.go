@@ -68,6 +68,42 @@ var _ = Describe("Cleanup", func() {
 		})
 	})
 
+	Context("when cleanup is given a priority", func() {
+		BeforeEach(func() {
+			success, _ := RunFixture("cleanup priority", func() {
+				BeforeSuite(func() {
+					DeferCleanup(CleanupPriority(-1), rt.Run, "C-BS-low")
+					DeferCleanup(rt.Run, "C-BS")
+				})
+				Context("container", Ordered, func() {
+					BeforeAll(func() {
+						DeferCleanup(CleanupPriority(5), rt.Run, "C-BA-high")
+						DeferCleanup(rt.Run, "C-BA")
+					})
+					BeforeEach(func() {
+						DeferCleanup(CleanupPriority(10), rt.Run, "C-BE-high")
+						DeferCleanup(CleanupPriority(-5), rt.Run, "C-BE-low")
+						DeferCleanup(rt.Run, "C-BE")
+					})
+					It("A", func() {
+						DeferCleanup(rt.Run, "C-A-1")
+						DeferCleanup(CleanupPriority(10), rt.Run, "C-A-high")
+						DeferCleanup(rt.Run, "C-A-2")
+					})
+				})
+			})
+			Ω(success).Should(BeTrue())
+		})
+
+		It("runs cleanup in ascending order of priority - and in LIFO order for cleanup with the same priority", func() {
+			Ω(rt).Should(HaveTracked(
+				"C-BE-low", "C-A-2", "C-A-1", "C-BE", "C-A-high", "C-BE-high",
+				"C-BA", "C-BA-high",
+				"C-BS-low", "C-BS",
+			))
+		})
+	})
+
 	Context("when cleanup fails", func() {
 		Context("because of a failed assertion", func() {
 			BeforeEach(func() {
@@ -245,6 +281,65 @@ var _ = Describe("Cleanup", func() {
 			})
 		})
 
+		Context("cleanup is added with OnProcess1", func() {
+			fixture := func() {
+				SynchronizedBeforeSuite(func() []byte {
+					rt.Run("BS1")
+					return nil
+				}, func(_ []byte) {
+					rt.Run("BS2")
+					DeferCleanup(rt.Run, "C-BS2")
+					DeferCleanup(OnProcess1, rt.Run, "C-BS2-proc1")
+				})
+				BeforeSuite(func() {
+					DeferCleanup(OnProcess1, rt.Run, "C-BS-proc1")
+				})
+				It("A", rt.T("A"))
+			}
+
+			Context("when running in serial", func() {
+				It("runs the cleanup like any other suite-level cleanup", func() {
+					success, _ := RunFixture("OnProcess1 cleanup in serial", fixture)
+					Ω(success).Should(BeTrue())
+					Ω(rt).Should(HaveTracked("BS1", "BS2", "A", "C-BS-proc1", "C-BS2-proc1", "C-BS2"))
+				})
+			})
+
+			Context("when running in parallel", func() {
+				BeforeEach(func() {
+					SetUpForParallel(2)
+				})
+
+				Context("as process #1", func() {
+					It("runs the cleanup only _after_ the other processes have finished", func() {
+						done := make(chan interface{})
+						go func() {
+							defer GinkgoRecover()
+							success, _ := RunFixture("OnProcess1 cleanup in parallel on process 1", fixture)
+							Ω(success).Should(BeTrue())
+							close(done)
+						}()
+
+						Eventually(rt).Should(HaveTracked("BS1", "BS2", "A"))
+						Consistently(rt).Should(HaveTracked("BS1", "BS2", "A"))
+						close(exitChannels[2])
+						Eventually(rt).Should(HaveTracked("BS1", "BS2", "A", "C-BS-proc1", "C-BS2-proc1", "C-BS2"))
+						Eventually(done).Should(BeClosed())
+					})
+				})
+
+				Context("as process #2", func() {
+					It("does not run the cleanup", func() {
+						conf.ParallelProcess = 2
+						client.PostSynchronizedBeforeSuiteCompleted(types.SpecStatePassed, nil)
+						success, _ := RunFixture("OnProcess1 cleanup in parallel on process 2", fixture)
+						Ω(success).Should(BeTrue())
+						Ω(rt).Should(HaveTracked("BS2", "A", "C-BS2"))
+					})
+				})
+			})
+		})
+
 		Context("cleanup is added in an AfterAll that is called because an AfterEach has caused the non-final spec in an ordered group to fail", func() {
 			BeforeEach(func() {
 				success, _ := RunFixture("cleanup in hairy edge case", func() {
@@ -0,0 +1,92 @@
+package internal_integration_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/ginkgo/v2/internal/test_helpers"
+	"github.com/onsi/ginkgo/v2/types"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("when config.AfterSuiteFailurePolicy is set", func() {
+	var attempts int
+	var fixture func()
+	BeforeEach(func() {
+		attempts = 0
+		fixture = func() {
+			It("A", rt.T("A"))
+			AfterSuite(rt.T("after-suite", func() {
+				attempts += 1
+				writer.Printf("attempt %d\n", attempts)
+				if attempts == 1 {
+					F("flaky cleanup")
+				}
+			}))
+		}
+	})
+
+	Context("by default", func() {
+		It("fails the suite when the AfterSuite fails", func() {
+			success, _ := RunFixture("default policy", fixture)
+			Ω(success).Should(BeFalse())
+			Ω(rt).Should(HaveTracked("A", "after-suite"))
+			afterSuite := reporter.Did.FindByLeafNodeType(types.NodeTypeAfterSuite)
+			Ω(afterSuite).Should(HaveFailed("flaky cleanup", NumAttempts(1)))
+			Ω(afterSuite.FailureIgnored).Should(BeFalse())
+		})
+	})
+
+	Context("with warn", func() {
+		BeforeEach(func() {
+			conf.AfterSuiteFailurePolicy = "warn"
+		})
+
+		It("reports the failure but does not fail the suite", func() {
+			success, _ := RunFixture("warn policy", fixture)
+			Ω(success).Should(BeTrue())
+			Ω(rt).Should(HaveTracked("A", "after-suite"))
+			afterSuite := reporter.Did.FindByLeafNodeType(types.NodeTypeAfterSuite)
+			Ω(afterSuite).Should(HaveFailed("flaky cleanup"))
+			Ω(afterSuite.FailureIgnored).Should(BeTrue())
+			Ω(reporter.End).Should(BeASuiteSummary(true, NSpecs(1), NPassed(1)))
+		})
+
+		It("does not ignore failures in other suite nodes", func() {
+			success, _ := RunFixture("warn policy with failing BeforeSuite", func() {
+				BeforeSuite(rt.T("before-suite", func() { F("boom") }))
+				It("A", rt.T("A"))
+			})
+			Ω(success).Should(BeFalse())
+			Ω(reporter.Did.FindByLeafNodeType(types.NodeTypeBeforeSuite).FailureIgnored).Should(BeFalse())
+		})
+	})
+
+	Context("with retry", func() {
+		BeforeEach(func() {
+			conf.AfterSuiteFailurePolicy = "retry"
+		})
+
+		It("runs the AfterSuite once more and passes if the retry passes", func() {
+			success, _ := RunFixture("retry policy", fixture)
+			Ω(success).Should(BeTrue())
+			Ω(rt).Should(HaveTracked("A", "after-suite", "after-suite"))
+			afterSuite := reporter.Did.FindByLeafNodeType(types.NodeTypeAfterSuite)
+			Ω(afterSuite).Should(HavePassed(NumAttempts(2)))
+			Ω(afterSuite.CapturedGinkgoWriterOutput).Should(Equal("attempt 1\n\nGinkgo: Attempt #1 Failed.  Retrying...\nattempt 2\n"))
+			Ω(afterSuite.Attempts).Should(HaveLen(2))
+			Ω(afterSuite.Attempts[0].State).Should(Equal(types.SpecStateFailed))
+			Ω(afterSuite.Attempts[0].Failure.Message).Should(Equal("flaky cleanup"))
+			Ω(afterSuite.Attempts[0].CapturedGinkgoWriterOutput).Should(Equal("attempt 1\n"))
+			Ω(afterSuite.Attempts[1].State).Should(Equal(types.SpecStatePassed))
+		})
+
+		It("fails the suite if the retry fails too", func() {
+			success, _ := RunFixture("retry policy with persistent failure", func() {
+				It("A", rt.T("A"))
+				SynchronizedAfterSuite(rt.T("all-procs", func() { F("boom") }), rt.T("proc-1"))
+			})
+			Ω(success).Should(BeFalse())
+			Ω(rt).Should(HaveTracked("A", "all-procs", "proc-1", "all-procs", "proc-1"))
+			Ω(reporter.Did.FindByLeafNodeType(types.NodeTypeSynchronizedAfterSuite)).Should(HaveFailed("boom", NumAttempts(2)))
+		})
+	})
+})
@@ -0,0 +1,68 @@
+package internal_integration_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/ginkgo/v2/internal/test_helpers"
+	"github.com/onsi/ginkgo/v2/types"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("when config.Chaos is enabled", func() {
+	BeforeEach(func() {
+		conf.Chaos = true
+		conf.RandomSeed = 17
+	})
+
+	Context("with a max delay", func() {
+		BeforeEach(func() {
+			conf.ChaosMaxDelay = 20 * time.Millisecond
+			success, _ := RunFixture("chaotic delays", func() {
+				BeforeSuite(rt.T("before-suite"))
+				Describe("container", func() {
+					BeforeEach(rt.T("bef"))
+					It("A", rt.T("A"))
+					It("B", rt.T("B"))
+					AfterEach(rt.T("aft"))
+				})
+			})
+			Ω(success).Should(BeTrue())
+		})
+
+		It("runs all the nodes", func() {
+			Ω(rt).Should(HaveTracked("before-suite", "bef", "A", "aft", "bef", "B", "aft"))
+			Ω(reporter.Did.Find("A")).Should(HavePassed())
+			Ω(reporter.Did.Find("B")).Should(HavePassed())
+		})
+
+		It("pauses before each spec node and logs the pauses to the GinkgoWriter", func() {
+			output := reporter.Did.Find("A").CapturedGinkgoWriterOutput
+			Ω(output).Should(MatchRegexp(`\[CHAOS\] Pausing for \S+ before \[BeforeEach\]\n\[CHAOS\] Pausing for \S+ before \[It\]\n\[CHAOS\] Pausing for \S+ before \[AfterEach\]\n`))
+		})
+
+		It("does not perturb suite-level nodes", func() {
+			Ω(reporter.Did.FindByLeafNodeType(types.NodeTypeBeforeSuite).CapturedGinkgoWriterOutput).Should(BeEmpty())
+		})
+	})
+
+	Context("with a failure rate", func() {
+		BeforeEach(func() {
+			conf.ChaosFailureRate = 1
+			success, _ := RunFixture("chaotic failures", func() {
+				Describe("container", func() {
+					BeforeEach(rt.T("bef"))
+					It("A", rt.T("A"))
+					AfterEach(rt.T("aft"))
+				})
+			})
+			Ω(success).Should(BeFalse())
+		})
+
+		It("fails nodes instead of running them", func() {
+			Ω(rt).Should(HaveTrackedNothing())
+			Ω(reporter.Did.Find("A")).Should(HaveFailed("Chaos: simulated failure injected into [BeforeEach] by --chaos", FailureNodeType(types.NodeTypeBeforeEach)))
+			Ω(reporter.Did.Find("A").CapturedGinkgoWriterOutput).Should(Equal("[CHAOS] Injecting a simulated failure into [BeforeEach]\n[CHAOS] Injecting a simulated failure into [AfterEach]\n"))
+		})
+	})
+})
@@ -5,6 +5,7 @@ import (
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/ginkgo/v2/internal/test_helpers"
+	"github.com/onsi/ginkgo/v2/types"
 	. "github.com/onsi/gomega"
 )
 
@@ -65,5 +66,22 @@ var _ = Describe("when config.FlakeAttempts is greater than 1", func() {
 			Ω(reporter.Did.Find("C")).Should(HaveFailed("C - 2", NumAttempts(2),
 				CapturedGinkgoWriterOutput("C - attempt #1\n\nGinkgo: Attempt #1 Failed.  Retrying...\nC - attempt #2\n")))
 		})
+
+		It("records the history of each attempt", func() {
+			Ω(reporter.Did.Find("B").Attempts).Should(HaveLen(1))
+			Ω(reporter.Did.Find("B").Attempts[0]).Should(HaveField("State", types.SpecStatePassed))
+
+			attempts := reporter.Did.Find("C").Attempts
+			Ω(attempts).Should(HaveLen(2))
+			Ω(attempts[0].Attempt).Should(Equal(1))
+			Ω(attempts[0].State).Should(Equal(types.SpecStateFailed))
+			Ω(attempts[0].Failure.Message).Should(Equal("C - 1"))
+			Ω(attempts[0].CapturedGinkgoWriterOutput).Should(Equal("C - attempt #1\n"))
+			Ω(attempts[1].Attempt).Should(Equal(2))
+			Ω(attempts[1].State).Should(Equal(types.SpecStateFailed))
+			Ω(attempts[1].Failure.Message).Should(Equal("C - 2"))
+			Ω(attempts[1].CapturedGinkgoWriterOutput).Should(Equal("\nGinkgo: Attempt #1 Failed.  Retrying...\nC - attempt #2\n"))
+			Ω(attempts[1].StartTime).ShouldNot(BeTemporally("<", attempts[0].EndTime))
+		})
 	})
 })
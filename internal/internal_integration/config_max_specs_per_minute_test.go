@@ -0,0 +1,65 @@
+package internal_integration_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/ginkgo/v2/internal/test_helpers"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("when config.MaxSpecsPerMinute is set", func() {
+	var startTimes map[string]time.Time
+	track := func(text string) func() {
+		return rt.T(text, func() { startTimes[text] = time.Now() })
+	}
+
+	BeforeEach(func() {
+		startTimes = map[string]time.Time{}
+	})
+
+	Context("with a global limit", func() {
+		BeforeEach(func() {
+			conf.MaxSpecsPerMinute = 600
+			success, _ := RunFixture("global rate limit", func() {
+				Describe("container", func() {
+					It("A", track("A"))
+					It("B", track("B"))
+					It("C", track("C"))
+					PIt("D", track("D"))
+				})
+			})
+			Ω(success).Should(BeTrue())
+		})
+
+		It("spaces the specs out", func() {
+			Ω(rt).Should(HaveTracked("A", "B", "C"))
+			Ω(startTimes["B"].Sub(startTimes["A"])).Should(BeNumerically(">=", 90*time.Millisecond))
+			Ω(startTimes["C"].Sub(startTimes["B"])).Should(BeNumerically(">=", 90*time.Millisecond))
+		})
+
+		It("does not count the wait towards the spec's run time", func() {
+			Ω(reporter.Did.Find("B").RunTime).Should(BeNumerically("<", 50*time.Millisecond))
+		})
+	})
+
+	Context("with a label limit", func() {
+		BeforeEach(func() {
+			conf.MaxSpecsPerMinuteByLabel = []string{"slow:300"}
+			success, _ := RunFixture("label rate limit", func() {
+				Describe("container", func() {
+					It("A", Label("slow"), track("A"))
+					It("B", track("B"))
+					It("C", Label("SLOW"), track("C"))
+				})
+			})
+			Ω(success).Should(BeTrue())
+		})
+
+		It("only spaces out specs with that label", func() {
+			Ω(rt).Should(HaveTracked("A", "B", "C"))
+			Ω(startTimes["B"].Sub(startTimes["A"])).Should(BeNumerically("<", 100*time.Millisecond))
+			Ω(startTimes["C"].Sub(startTimes["A"])).Should(BeNumerically(">=", 190*time.Millisecond))
+		})
+	})
+})
@@ -0,0 +1,74 @@
+package internal_integration_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("when config.PinLabelToProcess is set", func() {
+	var fixture func()
+	BeforeEach(func() {
+		fixture = func() {
+			It("A", rt.T("A"))
+			It("B", Label("UI"), rt.T("B"))
+			Context("ordered", Ordered, func() {
+				It("C", rt.T("C"))
+				It("D", Label("ui"), rt.T("D"))
+			})
+			It("E", rt.T("E"))
+			It("F", Label("device"), rt.T("F"))
+		}
+		conf.PinLabelToProcess = []string{"ui:1", "device:2"}
+		SetUpForParallel(2)
+	})
+
+	Context("on the process the label is pinned to", func() {
+		BeforeEach(func() {
+			conf.ParallelProcess = 1
+			close(exitChannels[2])
+			success, _ := RunFixture("pinned to process 1", fixture)
+			Ω(success).Should(BeTrue())
+		})
+
+		It("runs the pinned specs first and then joins in running the shared specs", func() {
+			runs := rt.TrackedRuns()
+			Ω(runs).Should(HaveLen(5))
+			Ω(runs[:3]).Should(ConsistOf("B", "C", "D"))
+			Ω(runs[3:]).Should(ConsistOf("A", "E"))
+		})
+
+		It("does not record the pinned specs in the parallel schedule", func() {
+			Ω(reporter.End.ParallelSchedule.NumGroups).Should(Equal(2))
+		})
+	})
+
+	Context("on other processes", func() {
+		BeforeEach(func() {
+			conf.ParallelProcess = 2
+			close(exitChannels[1])
+			success, _ := RunFixture("pinned to process 2", fixture)
+			Ω(success).Should(BeTrue())
+		})
+
+		It("never runs specs pinned to another process", func() {
+			runs := rt.TrackedRuns()
+			Ω(runs).Should(HaveLen(3))
+			Ω(runs[0]).Should(Equal("F"))
+			Ω(runs[1:]).Should(ConsistOf("A", "E"))
+			Ω(reporter.Did.Names()).Should(ConsistOf("F", "A", "E"))
+		})
+	})
+
+	Context("when running in series", func() {
+		BeforeEach(func() {
+			conf.ParallelTotal = 1
+			conf.ParallelProcess = 1
+			success, _ := RunFixture("in series", fixture)
+			Ω(success).Should(BeTrue())
+		})
+
+		It("runs all the specs", func() {
+			Ω(rt.TrackedRuns()).Should(ConsistOf("A", "B", "C", "D", "E", "F"))
+		})
+	})
+})
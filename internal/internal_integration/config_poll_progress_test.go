@@ -0,0 +1,67 @@
+package internal_integration_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	"github.com/onsi/ginkgo/v2/types"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Polling for progress", func() {
+	Describe("when a spec runs for longer than its PollProgressAfter decoration", func() {
+		BeforeEach(func() {
+			success, _ := RunFixture("poll progress after decoration", func() {
+				Describe("container", PollProgressAfter(20*time.Millisecond), func() {
+					BeforeEach(rt.T("bef"))
+					It("A", rt.T("A", func() { time.Sleep(110 * time.Millisecond) }))
+					It("B", rt.T("B"))
+				})
+				It("C", rt.T("C", func() { time.Sleep(50 * time.Millisecond) }))
+			})
+			Ω(success).Should(BeTrue())
+		})
+
+		It("emits progress reports - with the stack of the goroutine running the node - until the node completes", func() {
+			Ω(len(reporter.ProgressReports)).Should(BeNumerically(">=", 2))
+			for _, report := range reporter.ProgressReports {
+				Ω(report.ContainerHierarchyTexts).Should(Equal([]string{"container"}))
+				Ω(report.LeafNodeText).Should(Equal("A"))
+				Ω(report.CurrentNodeType).Should(Equal(types.NodeTypeIt))
+				Ω(report.CurrentNodeText).Should(Equal("A"))
+				Ω(report.Time).Should(BeTemporally(">", report.CurrentNodeStartTime))
+				Ω(report.NodeGoroutineStack).Should(HavePrefix("goroutine "))
+				Ω(report.NodeGoroutineStack).Should(ContainSubstring("time.Sleep"))
+				Ω(report.NodeGoroutineStack).Should(ContainSubstring("config_poll_progress_test.go"))
+			}
+		})
+
+		It("records the progress reports in the spec's report", func() {
+			Ω(reporter.Did.Find("A").ProgressReports).Should(Equal(reporter.ProgressReports))
+			Ω(reporter.Did.Find("B").ProgressReports).Should(BeEmpty())
+			Ω(reporter.Did.Find("C").ProgressReports).Should(BeEmpty())
+		})
+	})
+
+	Describe("when config.PollProgressAfter is set", func() {
+		BeforeEach(func() {
+			conf.PollProgressAfter = 20 * time.Millisecond
+			success, _ := RunFixture("poll progress after config", func() {
+				Describe("container", func() {
+					It("A", rt.T("A", func() { time.Sleep(50 * time.Millisecond) }))
+					It("B", PollProgressAfter(time.Hour), rt.T("B", func() { time.Sleep(50 * time.Millisecond) }))
+				})
+			})
+			Ω(success).Should(BeTrue())
+		})
+
+		It("emits progress reports for specs that do not override it", func() {
+			Ω(reporter.ProgressReports).ShouldNot(BeEmpty())
+			for _, report := range reporter.ProgressReports {
+				Ω(report.LeafNodeText).Should(Equal("A"))
+			}
+			Ω(reporter.Did.Find("A").ProgressReports).ShouldNot(BeEmpty())
+			Ω(reporter.Did.Find("B").ProgressReports).Should(BeEmpty())
+		})
+	})
+})
@@ -0,0 +1,172 @@
+package internal_integration_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/ginkgo/v2/internal/test_helpers"
+	"github.com/onsi/ginkgo/v2/types"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Recording and replaying parallel schedules", func() {
+	var fixture func()
+	var reportPath string
+
+	writeReports := func(reports ...types.Report) {
+		data, err := json.Marshal(reports)
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(os.WriteFile(reportPath, data, 0644)).Should(Succeed())
+	}
+
+	BeforeEach(func() {
+		dir, err := os.MkdirTemp("", "ginkgo-schedule")
+		Ω(err).ShouldNot(HaveOccurred())
+		DeferCleanup(os.RemoveAll, dir)
+		reportPath = filepath.Join(dir, "report.json")
+
+		fixture = func() {
+			Context("container", func() {
+				It("A", rt.T("A"))
+				It("B", rt.T("B"))
+				It("C", Serial, rt.T("C"))
+				It("D", rt.T("D"))
+				It("E", rt.T("E"))
+				It("F", Serial, rt.T("F"))
+				It("G", rt.T("G"))
+			})
+		}
+		conf.RandomSeed = 17
+		SetUpForParallel(2)
+	})
+
+	Context("when running in parallel", func() {
+		BeforeEach(func() {
+			conf.ParallelProcess = 2
+			close(exitChannels[1])
+			success, _ := RunFixture("recording", fixture)
+			Ω(success).Should(BeTrue())
+		})
+
+		It("records the groups each process ran, in order", func() {
+			Ω(rt).Should(HaveTracked("A", "B", "D", "E", "G"))
+			Ω(reporter.End.ParallelSchedule).Should(Equal(types.ParallelSchedule{
+				RandomSeed:    17,
+				ParallelTotal: 2,
+				NumGroups:     5,
+				GroupIndices:  map[int][]int{2: {0, 1, 2, 3, 4}},
+			}))
+		})
+	})
+
+	Context("when running in series", func() {
+		BeforeEach(func() {
+			conf.ParallelTotal = 1
+			conf.ParallelProcess = 1
+			success, _ := RunFixture("not recording", fixture)
+			Ω(success).Should(BeTrue())
+		})
+
+		It("does not record a schedule", func() {
+			Ω(reporter.End.ParallelSchedule.IsZero()).Should(BeTrue())
+		})
+	})
+
+	Describe("replaying a schedule", func() {
+		var schedule types.ParallelSchedule
+		BeforeEach(func() {
+			schedule = types.ParallelSchedule{
+				RandomSeed:    17,
+				ParallelTotal: 2,
+				NumGroups:     5,
+				GroupIndices:  map[int][]int{1: {3, 0}, 2: {4, 1, 2}},
+			}
+			conf.ReplayParallelSchedule = reportPath
+		})
+
+		Context("as a non-primary process", func() {
+			BeforeEach(func() {
+				writeReports(
+					types.Report{SuitePath: "/path/to/other-suite", SuiteDescription: "other", ParallelSchedule: types.ParallelSchedule{RandomSeed: 17, ParallelTotal: 2, NumGroups: 5}},
+					types.Report{SuitePath: "/path/to/suite", SuiteDescription: "replay", ParallelSchedule: schedule},
+				)
+				conf.ParallelProcess = 2
+				close(exitChannels[1])
+				success, _ := RunFixture("replay", fixture)
+				Ω(success).Should(BeTrue())
+			})
+
+			It("runs the groups recorded for the process, in the recorded order", func() {
+				Ω(rt).Should(HaveTracked("G", "B", "D"))
+			})
+
+			It("records the replayed schedule", func() {
+				Ω(reporter.End.ParallelSchedule.GroupIndices).Should(Equal(map[int][]int{2: {4, 1, 2}}))
+			})
+		})
+
+		Context("as the primary process", func() {
+			BeforeEach(func() {
+				//the recorded run happened on another machine, so the suite is identified by its description
+				writeReports(types.Report{SuitePath: "/ci/path/to/suite", SuiteDescription: "replay", ParallelSchedule: schedule})
+				conf.ParallelProcess = 1
+				close(exitChannels[2])
+				success, _ := RunFixture("replay", fixture)
+				Ω(success).Should(BeTrue())
+			})
+
+			It("runs the groups recorded for the process and then runs the serial specs", func() {
+				Ω(rt).Should(HaveTracked("E", "A", "C", "F"))
+			})
+		})
+
+		Context("when the schedule was recorded with a different seed", func() {
+			BeforeEach(func() {
+				schedule.RandomSeed = 3
+				writeReports(types.Report{SuitePath: "/path/to/suite", SuiteDescription: "replay", ParallelSchedule: schedule})
+				conf.ParallelProcess = 2
+				close(exitChannels[1])
+				success, _ := RunFixture("replay", fixture)
+				Ω(success).Should(BeFalse())
+			})
+
+			It("fails the suite without running any specs", func() {
+				Ω(rt).Should(HaveTrackedNothing())
+				Ω(reporter.End.SpecialSuiteFailureReasons).Should(HaveLen(1))
+				Ω(reporter.End.SpecialSuiteFailureReasons[0]).Should(ContainSubstring("Parallel schedule does not match this run"))
+				Ω(reporter.End.SpecialSuiteFailureReasons[0]).Should(ContainSubstring("--seed=3 --procs=2"))
+			})
+		})
+
+		Context("when the report does not contain the suite", func() {
+			BeforeEach(func() {
+				writeReports(types.Report{SuitePath: "/path/to/other-suite", SuiteDescription: "other", ParallelSchedule: schedule})
+				conf.ParallelProcess = 2
+				close(exitChannels[1])
+				success, _ := RunFixture("replay", fixture)
+				Ω(success).Should(BeFalse())
+			})
+
+			It("fails the suite without running any specs", func() {
+				Ω(rt).Should(HaveTrackedNothing())
+				Ω(reporter.End.SpecialSuiteFailureReasons[0]).Should(ContainSubstring("does not contain a report for the suite \"replay\""))
+			})
+		})
+
+		Context("when the report does not exist", func() {
+			BeforeEach(func() {
+				conf.ParallelProcess = 2
+				close(exitChannels[1])
+				success, _ := RunFixture("replay", fixture)
+				Ω(success).Should(BeFalse())
+			})
+
+			It("fails the suite without running any specs", func() {
+				Ω(rt).Should(HaveTrackedNothing())
+				Ω(reporter.End.SpecialSuiteFailureReasons[0]).Should(ContainSubstring("Could not replay parallel schedule"))
+			})
+		})
+	})
+})
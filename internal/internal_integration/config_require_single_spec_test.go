@@ -0,0 +1,65 @@
+package internal_integration_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/ginkgo/v2/internal/test_helpers"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("when config.RequireSingleSpec is set", func() {
+	var fixture func()
+	BeforeEach(func() {
+		conf.RequireSingleSpec = true
+		fixture = func() {
+			BeforeSuite(rt.T("before-suite"))
+			Describe("container", func() {
+				It("A", rt.T("A"))
+				It("B", rt.T("B"))
+				It("C", rt.T("C"))
+			})
+		}
+	})
+
+	Context("when exactly one spec matches", func() {
+		BeforeEach(func() {
+			conf.FocusStrings = []string{"B"}
+			success, _ := RunFixture("single spec", fixture)
+			Ω(success).Should(BeTrue())
+		})
+
+		It("runs the spec", func() {
+			Ω(rt).Should(HaveTracked("before-suite", "B"))
+			Ω(reporter.End).Should(BeASuiteSummary(true, NSpecs(3), NWillRun(1), NPassed(1), NSkipped(2)))
+		})
+	})
+
+	Context("when more than one spec matches", func() {
+		BeforeEach(func() {
+			conf.FocusStrings = []string{"A|C"}
+			success, _ := RunFixture("multiple specs", fixture)
+			Ω(success).Should(BeFalse())
+		})
+
+		It("fails the suite without running anything and lists the matching specs", func() {
+			Ω(rt).Should(HaveTrackedNothing())
+			Ω(reporter.End.SpecialSuiteFailureReasons).Should(HaveLen(1))
+			Ω(reporter.End.SpecialSuiteFailureReasons[0]).Should(ContainSubstring("More than one spec matched"))
+			Ω(reporter.End.SpecialSuiteFailureReasons[0]).Should(ContainSubstring("container A"))
+			Ω(reporter.End.SpecialSuiteFailureReasons[0]).Should(ContainSubstring("container C"))
+			Ω(reporter.End.SpecialSuiteFailureReasons[0]).ShouldNot(ContainSubstring("container B"))
+		})
+	})
+
+	Context("when no specs match", func() {
+		BeforeEach(func() {
+			conf.FocusStrings = []string{"D"}
+			success, _ := RunFixture("no specs", fixture)
+			Ω(success).Should(BeFalse())
+		})
+
+		It("fails the suite without running anything", func() {
+			Ω(rt).Should(HaveTrackedNothing())
+			Ω(reporter.End.SpecialSuiteFailureReasons[0]).Should(ContainSubstring("No specs matched"))
+		})
+	})
+})
@@ -0,0 +1,50 @@
+package internal_integration_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/ginkgo/v2/internal/test_helpers"
+	"github.com/onsi/ginkgo/v2/types"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("when config.TimeBox is set", func() {
+	BeforeEach(func() {
+		conf.TimeBox = 50 * time.Millisecond
+		success, _ := RunFixture("time box", func() {
+			Describe("a container", func() {
+				BeforeEach(rt.T("bef"))
+				It("A", rt.T("A"))
+				It("B", SpecPriority(10), rt.T("B"))
+				It("C", SpecPriority(5), rt.T("C", func() { time.Sleep(100 * time.Millisecond) }))
+				It("D", rt.T("D"))
+				PIt("E", rt.T("E"))
+				AfterEach(rt.T("aft"))
+			})
+			AfterSuite(rt.T("after-suite"))
+		})
+		Ω(success).Should(BeTrue())
+	})
+
+	It("runs the highest priority specs first and does not start any specs once the time box is exhausted", func() {
+		Ω(rt).Should(HaveTracked(
+			"bef", "B", "aft",
+			"bef", "C", "aft",
+			"after-suite",
+		))
+	})
+
+	It("reports the specs it did not reach as skipped due to the time box", func() {
+		Ω(reporter.Did.Find("B")).Should(HavePassed())
+		Ω(reporter.Did.Find("C")).Should(HavePassed())
+		Ω(reporter.Did.Find("A")).Should(HaveBeenSkippedWithMessage(types.TimeBoxSkipMessage))
+		Ω(reporter.Did.Find("D")).Should(HaveBeenSkippedWithMessage(types.TimeBoxSkipMessage))
+		Ω(reporter.Did.Find("E")).Should(BePending())
+		Ω(reporter.End.SpecReports.CountNotRunDueToTimeBox()).Should(Equal(2))
+	})
+
+	It("reports the correct statistics", func() {
+		Ω(reporter.End).Should(BeASuiteSummary(true, NSpecs(5), NPassed(2), NSkipped(2), NPending(1)))
+	})
+})
@@ -0,0 +1,83 @@
+package internal_integration_test
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/ginkgo/v2/internal/test_helpers"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("when config.ValidateFilters is set", func() {
+	var fixture func()
+	BeforeEach(func() {
+		fixture = func() {
+			BeforeSuite(rt.T("before-suite"))
+			It("A", Label("integration"), rt.T("A"))
+			It("B", rt.T("B"))
+		}
+		conf.FocusStrings = []string{"A", "Q"}
+		conf.LabelFilter = "intergation || integration"
+	})
+
+	Context("by default", func() {
+		It("does not validate the filters", func() {
+			success, _ := RunFixture("no validation", fixture)
+			Ω(success).Should(BeTrue())
+			Ω(rt).Should(HaveTracked("before-suite", "A"))
+			Ω(reporter.End.UnmatchedFilters).Should(BeEmpty())
+		})
+	})
+
+	Context("with warn", func() {
+		BeforeEach(func() {
+			conf.ValidateFilters = "warn"
+		})
+
+		It("records the filters that matched no specs but runs the suite", func() {
+			success, _ := RunFixture("warn", fixture)
+			Ω(success).Should(BeTrue())
+			Ω(rt).Should(HaveTracked("before-suite", "A"))
+			Ω(reporter.End.UnmatchedFilters).Should(Equal([]string{`--focus="Q"`}))
+		})
+	})
+
+	Context("with fail", func() {
+		BeforeEach(func() {
+			conf.ValidateFilters = "fail"
+		})
+
+		It("fails the suite without running anything", func() {
+			success, _ := RunFixture("fail", fixture)
+			Ω(success).Should(BeFalse())
+			Ω(rt).Should(HaveTrackedNothing())
+			Ω(reporter.End.UnmatchedFilters).Should(Equal([]string{`--focus="Q"`}))
+			Ω(reporter.End.SpecialSuiteFailureReasons).Should(ContainElement("Filters matched no specs and --validate-filters=fail is set"))
+		})
+
+		It("passes when every filter matches a spec", func() {
+			conf.FocusStrings = []string{"A", "B"}
+			success, _ := RunFixture("fail, all matched", fixture)
+			Ω(success).Should(BeTrue())
+			Ω(rt).Should(HaveTracked("before-suite", "A"))
+		})
+	})
+
+	Context("when the CLI validates the filters across multiple suites", func() {
+		var path string
+		BeforeEach(func() {
+			conf.ValidateFilters = "fail"
+			path = filepath.Join(GinkgoT().TempDir(), "unmatched-filters.json")
+			conf.UnmatchedFiltersFile = path
+		})
+
+		It("records the filters that matched no specs for the CLI and runs the suite", func() {
+			success, _ := RunFixture("validated by the CLI", fixture)
+			Ω(success).Should(BeTrue())
+			Ω(rt).Should(HaveTracked("before-suite", "A"))
+			Ω(reporter.End.UnmatchedFilters).Should(BeEmpty())
+			Ω(os.ReadFile(path)).Should(MatchJSON(`["--focus=\"Q\""]`))
+		})
+	})
+})
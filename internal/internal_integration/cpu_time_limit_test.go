@@ -0,0 +1,62 @@
+package internal_integration_test
+
+import (
+	"runtime"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/ginkgo/v2/internal/test_helpers"
+	"github.com/onsi/ginkgo/v2/types"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("limiting CPU time", func() {
+	spin := func(duration time.Duration) func() {
+		return func() {
+			start := time.Now()
+			for time.Since(start) < duration {
+			}
+		}
+	}
+
+	BeforeEach(func() {
+		if runtime.GOOS == "windows" {
+			Skip("CPU time is not measured on windows")
+		}
+	})
+
+	JustBeforeEach(func() {
+		RunFixture("cpu time limit", func() {
+			It("sleeps", func() { time.Sleep(100 * time.Millisecond) })
+			It("spins", spin(100*time.Millisecond))
+			Describe("with a generous limit", CPUTimeLimit(time.Hour), func() {
+				It("spins generously", spin(100*time.Millisecond))
+				It("spins strictly", CPUTimeLimit(10*time.Millisecond), spin(100*time.Millisecond))
+			})
+		})
+	})
+
+	It("records the CPU time each spec used", func() {
+		Ω(reporter.Did.Find("spins").CPUTime).Should(BeNumerically(">=", 50*time.Millisecond))
+		Ω(reporter.Did.Find("sleeps").CPUTime).Should(BeNumerically("<", 50*time.Millisecond))
+	})
+
+	It("enforces CPUTimeLimit decorations, with the innermost winning", func() {
+		Ω(reporter.Did.Find("spins")).Should(HavePassed())
+		Ω(reporter.Did.Find("spins generously")).Should(HavePassed())
+		Ω(reporter.Did.Find("spins strictly")).Should(HaveFailed(ContainSubstring("exceeding its CPU time limit of 10ms"), types.FailureNodeIsLeafNode))
+	})
+
+	Context("when --cpu-time-limit is set", func() {
+		BeforeEach(func() {
+			conf.CPUTimeLimit = 20 * time.Millisecond
+		})
+
+		It("fails passing specs that use more CPU time than the limit, unless a decoration overrides it", func() {
+			Ω(reporter.Did.Find("sleeps")).Should(HavePassed())
+			Ω(reporter.Did.Find("spins")).Should(HaveFailed(ContainSubstring("This spec used"), ContainSubstring("exceeding its CPU time limit of 20ms")))
+			Ω(reporter.Did.Find("spins generously")).Should(HavePassed())
+			Ω(reporter.End).Should(BeASuiteSummary(false, NSpecs(4), NPassed(2), NFailed(2)))
+		})
+	})
+})
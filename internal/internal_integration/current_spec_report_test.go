@@ -47,6 +47,7 @@ var _ = Describe("CurrentSpecReport", func() {
 
 			Context("an ordered container", Ordered, func() {
 				It("C", logCurrentSpecReport("C"))
+				It("C2", logCurrentSpecReport("C2"))
 			})
 
 			Context("an serial spec", func() {
@@ -99,6 +100,15 @@ var _ = Describe("CurrentSpecReport", func() {
 		Ω(specs["D"].IsInOrderedContainer).Should(BeFalse())
 	})
 
+	It("captures the step of specs in ordered containers", func() {
+		Ω(specs["C"].OrderedContainerStep).Should(Equal(1))
+		Ω(specs["C"].OrderedContainerSteps).Should(Equal(2))
+		Ω(specs["C2"].OrderedContainerStep).Should(Equal(2))
+		Ω(specs["C2"].OrderedContainerSteps).Should(Equal(2))
+		Ω(specs["D"].OrderedContainerStep).Should(BeZero())
+		Ω(specs["D"].OrderedContainerSteps).Should(BeZero())
+	})
+
 	It("captures test details correctly", func() {
 		spec := specs["aft-A"]
 		Ω(spec.ContainerHierarchyTexts).Should(Equal([]string{"a passing test"}))
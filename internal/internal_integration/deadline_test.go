@@ -0,0 +1,90 @@
+package internal_integration_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("GinkgoDeadline", func() {
+	type observation struct {
+		deadline time.Time
+		ok       bool
+		at       time.Time
+	}
+	var observations map[string]observation
+
+	observe := func(name string) func() {
+		return func() {
+			deadline, ok := GinkgoDeadline()
+			observations[name] = observation{deadline, ok, time.Now()}
+		}
+	}
+
+	BeforeEach(func() {
+		observations = map[string]observation{}
+	})
+
+	Context("when there is no suite timeout and no NodeTimeout", func() {
+		BeforeEach(func() {
+			conf.Timeout = 0
+			success, _ := RunFixture("no deadline", func() {
+				BeforeSuite(observe("before-suite"))
+				It("A", observe("A"))
+			})
+			Ω(success).Should(BeTrue())
+		})
+
+		It("reports that there is no deadline", func() {
+			Ω(observations["before-suite"].ok).Should(BeFalse())
+			Ω(observations["A"].ok).Should(BeFalse())
+		})
+	})
+
+	Context("when there is a suite timeout", func() {
+		var start time.Time
+		BeforeEach(func() {
+			conf.Timeout = time.Hour
+			start = time.Now()
+			success, _ := RunFixture("suite deadline", func() {
+				BeforeSuite(observe("before-suite"), NodeTimeout(time.Minute))
+				AfterSuite(observe("after-suite"), NodeTimeout(2*time.Hour))
+				It("A", observe("A"))
+			})
+			Ω(success).Should(BeTrue())
+		})
+
+		It("reports the suite's deadline when no NodeTimeout applies", func() {
+			Ω(observations["A"].ok).Should(BeTrue())
+			Ω(observations["A"].deadline).Should(BeTemporally("~", start.Add(time.Hour), time.Second))
+		})
+
+		It("reports the NodeTimeout deadline when it is earlier than the suite's deadline", func() {
+			Ω(observations["before-suite"].ok).Should(BeTrue())
+			Ω(observations["before-suite"].deadline).Should(BeTemporally("~", observations["before-suite"].at.Add(time.Minute), time.Second))
+		})
+
+		It("reports the suite's deadline when it is earlier than the NodeTimeout deadline", func() {
+			Ω(observations["after-suite"].ok).Should(BeTrue())
+			Ω(observations["after-suite"].deadline).Should(BeTemporally("~", start.Add(time.Hour), time.Second))
+		})
+	})
+
+	Context("when only a NodeTimeout applies", func() {
+		BeforeEach(func() {
+			conf.Timeout = 0
+			success, _ := RunFixture("node deadline", func() {
+				BeforeSuite(observe("before-suite"), NodeTimeout(time.Minute))
+				It("A", observe("A"))
+			})
+			Ω(success).Should(BeTrue())
+		})
+
+		It("reports the NodeTimeout deadline while the node runs, and clears it afterwards", func() {
+			Ω(observations["before-suite"].ok).Should(BeTrue())
+			Ω(observations["before-suite"].deadline).Should(BeTemporally("~", observations["before-suite"].at.Add(time.Minute), time.Second))
+			Ω(observations["A"].ok).Should(BeFalse())
+		})
+	})
+})
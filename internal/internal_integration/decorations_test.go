@@ -2,6 +2,7 @@ package internal_integration_test
 
 import (
 	. "github.com/onsi/ginkgo/v2"
+	"github.com/onsi/ginkgo/v2/internal"
 	. "github.com/onsi/ginkgo/v2/internal/test_helpers"
 	. "github.com/onsi/gomega"
 
@@ -62,3 +63,32 @@ var _ = Describe("Decorations test", func() {
 		})
 	})
 })
+
+var _ = Describe("Validating decorators", func() {
+	It("reports every invalid decorator in the suite at once, with its location, when the tree is built", func() {
+		suite := internal.NewSuite()
+		var err error
+		WithSuite(suite, func() {
+			BeforeEach(rt.T("bef"), FlakeAttempts(2))
+			Describe("container", Ordered, func() {
+				It("A", Serial, rt.T("A"))
+				AfterEach(rt.T("aft"), Focus)
+			})
+			BeforeAll(rt.T("before-all"))
+			It("B", rt.T("B"))
+			err = suite.BuildTree()
+		})
+
+		Ω(rt).Should(HaveTrackedNothing())
+		Ω(err).Should(BeAssignableToTypeOf(types.SpecStructureErrors{}))
+		errs := err.(types.SpecStructureErrors)
+		Ω(errs).Should(HaveLen(4))
+		Ω(errs[0]).Should(MatchError(types.GinkgoErrors.InvalidDecoratorForNodeType(errs[0].(types.GinkgoError).CodeLocation, types.NodeTypeBeforeEach, "FlakeAttempts")))
+		Ω(errs[1]).Should(MatchError(types.GinkgoErrors.SetupNodeNotInOrderedContainer(errs[1].(types.GinkgoError).CodeLocation, types.NodeTypeBeforeAll)))
+		Ω(errs[2]).Should(MatchError(types.GinkgoErrors.InvalidSerialNodeInNonSerialOrderedContainer(errs[2].(types.GinkgoError).CodeLocation, types.NodeTypeIt)))
+		Ω(errs[3]).Should(MatchError(types.GinkgoErrors.InvalidDecoratorForNodeType(errs[3].(types.GinkgoError).CodeLocation, types.NodeTypeAfterEach, "Focus")))
+		for _, e := range errs {
+			Ω(e.(types.GinkgoError).CodeLocation.FileName).Should(HaveSuffix("decorations_test.go"))
+		}
+	})
+})
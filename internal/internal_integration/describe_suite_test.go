@@ -0,0 +1,37 @@
+package internal_integration_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	"github.com/onsi/ginkgo/v2/internal"
+	"github.com/onsi/ginkgo/v2/types"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Describing a suite", func() {
+	var description types.SuiteDescription
+	BeforeEach(func() {
+		suite := internal.NewSuite()
+		WithSuite(suite, func() {
+			Describe("database", Label("db"), RequiresEnv("DATABASE_URL"), func() {
+				It("A", Label("slow"), func() {})
+				It("B", RequiresEnv("DATABASE_PASSWORD", "DATABASE_URL"), func() {})
+			})
+			It("C", Label("slow"), func() {})
+			PIt("D", func() {})
+			Ω(suite.BuildTree()).Should(Succeed())
+			description = suite.Describe("my suite", Label("TopLevelLabel"), "/path/to/suite")
+		})
+	})
+
+	It("describes the suite without running it", func() {
+		Ω(description).Should(Equal(types.SuiteDescription{
+			GinkgoVersion:    types.VERSION,
+			SuiteDescription: "my suite",
+			SuitePath:        "/path/to/suite",
+			SuiteLabels:      []string{"TopLevelLabel"},
+			TotalSpecs:       4,
+			SpecsByLabel:     map[string]int{"TopLevelLabel": 4, "db": 2, "slow": 2},
+			RequiredEnv:      []string{"DATABASE_PASSWORD", "DATABASE_URL"},
+		}))
+	})
+})
@@ -1,6 +1,9 @@
 package internal_integration_test
 
 import (
+	"errors"
+	"fmt"
+
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
@@ -326,6 +329,150 @@ var _ = Describe("handling test failures", func() {
 				Ω(rt).Should(HaveTracked("bef-1", "aft-1"))
 			})
 		})
+
+		Describe("when multiple AfterEach and DeferCleanup nodes fail", func() {
+			var clA, clB types.CodeLocation
+			BeforeEach(func() {
+				clA = types.CodeLocation{FileName: "A"}
+				clB = types.CodeLocation{FileName: "B"}
+				success, _ := RunFixture("cascading cleanup failures", func() {
+					It("the test", rt.T("it", func() {
+						DeferCleanup(rt.T("cleanup", func() {
+							writer.Write([]byte("run C"))
+							panic("boom")
+						}))
+						F("fail-A", clA)
+					}))
+					AfterEach(rt.T("aft-1", func() {
+						writer.Write([]byte("run B"))
+						F("fail-B", clB)
+					}))
+					AfterEach(rt.T("aft-2"))
+				})
+				Ω(success).Should(BeFalse())
+			})
+
+			It("runs all the cleanup nodes and reports the first failure as the spec's failure", func() {
+				Ω(rt).Should(HaveTracked("it", "aft-1", "aft-2", "cleanup"))
+				specReport := reporter.Did.Find("the test")
+				Ω(specReport).Should(HaveFailed("fail-A", clA), CapturedGinkgoWriterOutput("run Brun C"))
+			})
+
+			It("records every subsequent failure as an additional failure, with its own location and output", func() {
+				additionalFailures := reporter.Did.Find("the test").AdditionalFailures
+				Ω(additionalFailures).Should(HaveLen(2))
+
+				Ω(additionalFailures[0].State).Should(Equal(types.SpecStateFailed))
+				Ω(additionalFailures[0].Failure.Message).Should(Equal("fail-B"))
+				Ω(additionalFailures[0].Failure.Location).Should(Equal(clB))
+				Ω(additionalFailures[0].Failure.FailureNodeType).Should(Equal(types.NodeTypeAfterEach))
+				Ω(additionalFailures[0].CapturedGinkgoWriterOutput).Should(Equal("run B"))
+
+				Ω(additionalFailures[1].State).Should(Equal(types.SpecStatePanicked))
+				Ω(additionalFailures[1].Failure.ForwardedPanic).Should(Equal("boom"))
+				Ω(additionalFailures[1].Failure.FailureNodeType).Should(Equal(types.NodeTypeCleanupAfterEach))
+				Ω(additionalFailures[1].CapturedGinkgoWriterOutput).Should(Equal("run C"))
+			})
+		})
+	})
+
+	Describe("when tests panic", func() {
+		BeforeEach(func() {
+			success, _ := RunFixture("typed panics", func() {
+				Context("container", func() {
+					It("nil-pointer", func() {
+						var report *types.SpecReport
+						_ = report.LeafNodeText
+					})
+					It("domain", func() {
+						panic(fmt.Errorf("domain error"))
+					})
+					It("in a goroutine", func() {
+						done := make(chan interface{})
+						go func() {
+							defer close(done)
+							defer func() {
+								failer.Panic(cl, recover())
+							}()
+							panic("boom")
+						}()
+						<-done
+					})
+				})
+			})
+			Ω(success).Should(BeFalse())
+		})
+
+		It("preserves the panic value's type and error", func() {
+			nilPointer := reporter.Did.Find("nil-pointer").Failure.Panic
+			Ω(nilPointer.Type).Should(Equal("runtime.errorString"))
+			Ω(nilPointer.Error).Should(ContainSubstring("nil pointer dereference"))
+			Ω(nilPointer.IsRuntimeError).Should(BeTrue())
+
+			domain := reporter.Did.Find("domain").Failure.Panic
+			Ω(domain.Type).Should(Equal("*errors.errorString"))
+			Ω(domain.Error).Should(Equal("domain error"))
+			Ω(domain.IsRuntimeError).Should(BeFalse())
+			Ω(domain.GetRawValue()).Should(MatchError("domain error"))
+
+			inAGoroutine := reporter.Did.Find("in a goroutine")
+			Ω(inAGoroutine).Should(HavePanicked("boom"))
+			Ω(inAGoroutine.Failure.Panic.Type).Should(Equal("string"))
+			Ω(inAGoroutine.Failure.Panic.GetRawValue()).Should(Equal("boom"))
+		})
+	})
+
+	Describe("when a test fails with a payload", func() {
+		BeforeEach(func() {
+			success, _ := RunFixture("failed with payload", func() {
+				It("A", func() {
+					failer.FailWithPayload("fail", map[string]int{"count": 2}, cl)
+					panic("panic to simulate how ginkgo's FailWithPayload works")
+				})
+			})
+			Ω(success).Should(BeFalse())
+		})
+
+		It("carries the payload into the spec report", func() {
+			specReport := reporter.Did.Find("A")
+			Ω(specReport).Should(HaveFailed("fail", cl))
+			Ω(specReport.Failure.Payload).Should(Equal(&types.FailurePayload{
+				Type:           "map[string]int",
+				AsJSON:         `{"count":2}`,
+				Representation: "map[count:2]",
+			}))
+		})
+	})
+
+	Describe("when a test fails with an error", func() {
+		var errNotFound = errors.New("not found")
+		var rawErrors []error
+
+		BeforeEach(func() {
+			rawErrors = []error{}
+			success, _ := RunFixture("failed with error", func() {
+				It("A", func() {
+					failer.FailWithError("loading", fmt.Errorf("fixture: %w", errNotFound), cl)
+					panic("panic to simulate how ginkgo's FailWith works")
+				})
+				ReportAfterEach(func(report SpecReport) {
+					rawErrors = append(rawErrors, report.Failure.Error.GetRawError())
+				})
+			})
+			Ω(success).Should(BeFalse())
+		})
+
+		It("carries the error into the spec report", func() {
+			specReport := reporter.Did.Find("A")
+			Ω(specReport).Should(HaveFailed("loading: fixture: not found", cl))
+			Ω(specReport.Failure.Error.Type).Should(Equal("*fmt.wrapError"))
+			Ω(specReport.Failure.Error.WrappedTypes).Should(Equal([]string{"*errors.errorString"}))
+		})
+
+		It("makes the raw error available to ReportAfterEach", func() {
+			Ω(rawErrors).Should(HaveLen(1))
+			Ω(rawErrors[0]).Should(MatchError(errNotFound))
+		})
 	})
 
 	Describe("when there are multiple tests that fail", func() {
@@ -349,3 +496,46 @@ var _ = Describe("handling test failures", func() {
 		})
 	})
 })
+
+var _ = Describe("failures in helper functions", func() {
+	var locations map[string]types.CodeLocation
+
+	BeforeEach(func() {
+		locations = map[string]types.CodeLocation{}
+		failingHelper := func() {
+			GinkgoHelper()
+			Fail("helper")
+		}
+		nestedHelper := func() {
+			GinkgoHelper()
+			failingHelper()
+		}
+		gomegaHelper := func() {
+			GinkgoHelper()
+			Ω(1).Should(Equal(2))
+		}
+		tHelper := func() {
+			t := GinkgoT(1)
+			t.Helper()
+			t.Fatalf("t-helper")
+		}
+		success, _ := RunFixture("failing helpers", func() {
+			Describe("container", func() {
+				It("A", func() { locations["A"] = types.NewCodeLocation(0); failingHelper() })
+				It("B", func() { locations["B"] = types.NewCodeLocation(0); nestedHelper() })
+				It("C", func() { locations["C"] = types.NewCodeLocation(0); gomegaHelper() })
+				It("D", func() { locations["D"] = types.NewCodeLocation(0); tHelper() })
+			})
+		})
+		Ω(success).Should(BeFalse())
+	})
+
+	It("reports the failure at the line that called the helper", func() {
+		for _, name := range []string{"A", "B", "C", "D"} {
+			report := reporter.Did.Find(name)
+			Ω(report).Should(HaveFailed())
+			Ω(report.Failure.Location.FileName).Should(Equal(locations[name].FileName), name)
+			Ω(report.Failure.Location.LineNumber).Should(Equal(locations[name].LineNumber), name)
+		}
+	})
+})
@@ -0,0 +1,84 @@
+package internal_integration_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/ginkgo/v2/internal/test_helpers"
+	"github.com/onsi/ginkgo/v2/types"
+	. "github.com/onsi/gomega"
+)
+
+type fakeArtifactCollector struct {
+	name string
+	err  error
+	dirs []string
+}
+
+func (c *fakeArtifactCollector) CollectFailureArtifacts(ctx SpecContext, artifactsDir string) ([]string, error) {
+	rt.Run(c.name + "-" + ctx.SpecReport().LeafNodeText)
+	c.dirs = append(c.dirs, artifactsDir)
+	if c.err != nil {
+		return nil, c.err
+	}
+	return []string{c.name + ".png"}, os.WriteFile(filepath.Join(artifactsDir, c.name+".png"), []byte("screenshot"), 0644)
+}
+
+var _ = Describe("Failure artifact collectors", func() {
+	var screenshotter, broken *fakeArtifactCollector
+
+	BeforeEach(func() {
+		var err error
+		conf.ArtifactsDir, err = os.MkdirTemp("", "ginkgo-artifacts-test")
+		Ω(err).ShouldNot(HaveOccurred())
+		DeferCleanup(os.RemoveAll, conf.ArtifactsDir)
+
+		screenshotter = &fakeArtifactCollector{name: "screenshot"}
+		broken = &fakeArtifactCollector{name: "broken", err: errors.New("no browser")}
+		success, _ := RunFixture("failure artifacts", func() {
+			RegisterFailureArtifactCollector(screenshotter)
+			RegisterFailureArtifactCollector(broken)
+			Describe("container", func() {
+				It("A", rt.T("A"))
+				It("B", rt.T("B", func() { F("boom") }))
+				Describe("nested container", func() {
+					It("C", rt.T("C"))
+					AfterEach(rt.T("aft", func() { F("cleanup failed") }))
+				})
+				AfterEach(rt.T("outer-aft"))
+			})
+		})
+		Ω(success).Should(BeFalse())
+	})
+
+	It("calls the collectors as soon as a spec fails - before its cleanup nodes run - and never for passing specs", func() {
+		Ω(rt).Should(HaveTracked(
+			"A", "outer-aft",
+			"B", "screenshot-B", "broken-B", "outer-aft",
+			"C", "aft", "screenshot-C", "broken-C", "outer-aft",
+		))
+		Ω(reporter.Did.Find("A").FailureArtifacts).Should(BeEmpty())
+	})
+
+	It("gives each failing spec its own directory under --artifacts-dir and records the artifacts in the spec's report", func() {
+		Ω(screenshotter.dirs).Should(HaveLen(2))
+		Ω(screenshotter.dirs[0]).ShouldNot(Equal(screenshotter.dirs[1]))
+		for _, dir := range screenshotter.dirs {
+			Ω(filepath.Dir(dir)).Should(Equal(conf.ArtifactsDir))
+		}
+
+		specB := reporter.Did.Find("B")
+		Ω(specB).Should(HaveFailed("boom"))
+		Ω(specB.FailureArtifacts).Should(Equal([]string{filepath.Join(screenshotter.dirs[0], "screenshot.png")}))
+		Ω(specB.FailureArtifacts[0]).Should(BeAnExistingFile())
+	})
+
+	It("reports collectors that fail as warnings without changing the outcome of the spec", func() {
+		specC := reporter.Did.Find("C")
+		Ω(specC).Should(HaveFailed("cleanup failed", FailureNodeType(types.NodeTypeAfterEach)))
+		Ω(specC.Warnings).Should(HaveLen(1))
+		Ω(specC.Warnings[0].Message).Should(ContainSubstring("no browser"))
+	})
+})
@@ -0,0 +1,44 @@
+package internal_integration_test
+
+import (
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Fingerprinting failures", func() {
+	BeforeEach(func() {
+		connect := func(port int) {
+			F(fmt.Sprintf("dial tcp 127.0.0.1:%d: connection refused", port))
+		}
+
+		success, _ := RunFixture("failure fingerprints", func() {
+			It("A", func() { connect(4021) })
+			It("B", func() { connect(39877) })
+			It("C", func() { F("something else") })
+			It("D", func() { Skip("not today") })
+			It("E", func() {})
+		})
+		Ω(success).Should(BeFalse())
+	})
+
+	It("gives specs that fail in the same way the same fingerprint", func() {
+		Ω(reporter.Did.Find("A").FailureFingerprint).ShouldNot(BeEmpty())
+		Ω(reporter.Did.Find("B").FailureFingerprint).Should(Equal(reporter.Did.Find("A").FailureFingerprint))
+		Ω(reporter.Did.Find("C").FailureFingerprint).ShouldNot(BeEmpty())
+		Ω(reporter.Did.Find("C").FailureFingerprint).ShouldNot(Equal(reporter.Did.Find("A").FailureFingerprint))
+	})
+
+	It("does not fingerprint specs that did not fail", func() {
+		Ω(reporter.Did.Find("D").FailureFingerprint).Should(BeEmpty())
+		Ω(reporter.Did.Find("E").FailureFingerprint).Should(BeEmpty())
+	})
+
+	It("can group the failures by fingerprint", func() {
+		groups := reporter.End.SpecReports.GroupByFailureFingerprint()
+		Ω(groups).Should(HaveLen(2))
+		Ω(groups[0].SpecReports).Should(HaveLen(2))
+		Ω([]string{groups[0].SpecReports[0].LeafNodeText, groups[0].SpecReports[1].LeafNodeText}).Should(ConsistOf("A", "B"))
+	})
+})
@@ -32,6 +32,7 @@ var _ = Describe("Focus", func() {
 			It("should not report that the suite hasProgrammaticFocus", func() {
 				Ω(reporter.Begin.SuiteHasProgrammaticFocus).Should(BeFalse())
 				Ω(reporter.End.SuiteHasProgrammaticFocus).Should(BeFalse())
+				Ω(reporter.End.ExitReason).Should(Equal(types.ExitReasonPassed))
 			})
 
 			It("does not run the pending tests", func() {
@@ -101,6 +102,7 @@ var _ = Describe("Focus", func() {
 		It("should report that the suite hasProgrammaticFocus", func() {
 			Ω(reporter.Begin.SuiteHasProgrammaticFocus).Should(BeTrue())
 			Ω(reporter.End.SuiteHasProgrammaticFocus).Should(BeTrue())
+			Ω(reporter.End.ExitReason).Should(Equal(types.ExitReasonFocused))
 		})
 
 		It("should run the focused tests, honoring the nested focus policy", func() {
@@ -118,6 +120,25 @@ var _ = Describe("Focus", func() {
 		})
 	})
 
+	Describe("with programmatic focus and config.FocusExitCode", func() {
+		DescribeTable("reporting the exit reason", func(focusExitCode string, expectedExitReason types.ExitReason) {
+			conf.FocusExitCode = focusExitCode
+			success, hasProgrammaticFocus := RunFixture("focused tests", func() {
+				It("A", rt.T("A"))
+				FIt("B", rt.T("B"))
+			})
+			Ω(success).Should(BeTrue())
+			Ω(hasProgrammaticFocus).Should(BeTrue())
+			Ω(reporter.End.SuiteHasProgrammaticFocus).Should(BeTrue())
+			Ω(reporter.End.ExitReason).Should(Equal(expectedExitReason))
+		},
+			Entry("by default", "", types.ExitReasonFocused),
+			Entry("when set to fail", "fail", types.ExitReasonFocused),
+			Entry("when set to warn", "warn", types.ExitReasonPassed),
+			Entry("when set to off", "off", types.ExitReasonPassed),
+		)
+	})
+
 	Describe("with config.FocusStrings and config.SkipStrings", func() {
 		BeforeEach(func() {
 			conf.FocusStrings = []string{"blue", "green"}
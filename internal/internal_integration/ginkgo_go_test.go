@@ -0,0 +1,88 @@
+package internal_integration_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/ginkgo/v2/internal/test_helpers"
+	"github.com/onsi/ginkgo/v2/types"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("GinkgoGo", func() {
+	Context("when the goroutines exit when their SpecContext is cancelled", func() {
+		var reportedText string
+		BeforeEach(func() {
+			success, _ := RunFixture("ginkgo go happy path", func() {
+				Context("container", func() {
+					BeforeEach(rt.T("BE", func() {
+						GinkgoGo(func(ctx SpecContext) {
+							<-ctx.Done()
+							rt.Run("G-BE")
+						})
+					}))
+					It("A", rt.T("A", func() {
+						GinkgoGo(func(ctx SpecContext) {
+							reportedText = ctx.SpecReport().LeafNodeText
+							<-ctx.Done()
+							rt.Run("G-A")
+						})
+						DeferCleanup(rt.Run, "C-A")
+					}))
+					AfterEach(rt.T("AE"))
+				})
+			})
+			Ω(success).Should(BeTrue())
+		})
+
+		It("cancels and waits for the goroutines when the node that launched them is cleaned up", func() {
+			Ω(rt).Should(HaveTracked("BE", "A", "AE", "C-A", "G-A", "G-BE"))
+			Ω(reporter.Did.Find("A")).Should(HavePassed())
+			Ω(reportedText).Should(Equal("A"))
+		})
+	})
+
+	Context("when a goroutine fails", func() {
+		BeforeEach(func() {
+			success, _ := RunFixture("ginkgo go failure", func() {
+				It("A", func() {
+					done := make(chan interface{})
+					GinkgoGo(func(ctx SpecContext) {
+						defer close(done)
+						F("boom", cl)
+					})
+					<-done
+				})
+			})
+			Ω(success).Should(BeFalse())
+		})
+
+		It("recovers the failure and reports it", func() {
+			Ω(reporter.Did.Find("A")).Should(HaveFailed("boom", cl))
+		})
+	})
+
+	Context("when a goroutine does not exit", func() {
+		var release chan interface{}
+		BeforeEach(func() {
+			release = make(chan interface{})
+			success, _ := RunFixture("ginkgo go leak", func() {
+				It("A", func() {
+					GinkgoGo(func(ctx SpecContext) {
+						<-release
+					}, 50*time.Millisecond)
+				})
+				It("B", func() {})
+			})
+			close(release)
+			Ω(success).Should(BeFalse())
+		})
+
+		It("fails the spec and reports where the leaked goroutine was launched", func() {
+			report := reporter.Did.Find("A")
+			Ω(report).Should(HaveFailed(ContainSubstring("did not exit within 50ms"), FailureNodeType(types.NodeTypeCleanupAfterEach)))
+			Ω(report.Failure.Location.FileName).Should(HaveSuffix("ginkgo_go_test.go"))
+			Ω(reporter.Did.Find("B")).Should(HavePassed())
+		})
+	})
+})
@@ -0,0 +1,56 @@
+package internal_integration_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/ginkgo/v2/internal/test_helpers"
+	"github.com/onsi/ginkgo/v2/types"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Global nodes", func() {
+	Describe("when global nodes are registered in different containers", func() {
+		BeforeEach(func() {
+			success, _ := RunFixture("global nodes", func() {
+				BeforeEach(rt.T("bef"))
+				RegisterGlobalNode(types.NodeTypeBeforeEach, rt.T("global-bef"))
+				Describe("container A", func() {
+					It("A", rt.T("A"))
+				})
+				Describe("container B", func() {
+					RegisterGlobalNode(types.NodeTypeJustBeforeEach, rt.T("global-jbef"))
+					RegisterGlobalNode(types.NodeTypeAfterEach, rt.T("global-aft"))
+					AfterEach(rt.T("aft-B"))
+					It("B", rt.T("B"))
+				})
+				It("C", rt.T("C"))
+			})
+			Ω(success).Should(BeTrue())
+		})
+
+		It("applies global nodes to every spec, regardless of the container they were registered in, ahead of other top-level nodes", func() {
+			Ω(rt).Should(HaveTracked(
+				"global-bef", "bef", "global-jbef", "A", "global-aft",
+				"global-bef", "bef", "global-jbef", "C", "global-aft",
+				"global-bef", "bef", "global-jbef", "B", "aft-B", "global-aft",
+			))
+			Ω(reporter.End).Should(BeASuiteSummary(true, NSpecs(3), NPassed(3)))
+		})
+	})
+
+	Describe("when a global node is decorated with OncePerOrdered", func() {
+		BeforeEach(func() {
+			success, _ := RunFixture("global once per ordered", func() {
+				Describe("container", Ordered, func() {
+					RegisterGlobalNode(types.NodeTypeBeforeEach, rt.T("global-bef"), OncePerOrdered)
+					It("A", rt.T("A"))
+					It("B", rt.T("B"))
+				})
+			})
+			Ω(success).Should(BeTrue())
+		})
+
+		It("runs once around each Ordered container", func() {
+			Ω(rt).Should(HaveTracked("global-bef", "A", "B"))
+		})
+	})
+})
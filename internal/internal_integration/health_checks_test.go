@@ -0,0 +1,91 @@
+package internal_integration_test
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/ginkgo/v2/internal/test_helpers"
+	"github.com/onsi/ginkgo/v2/types"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Health checks", func() {
+	var lock *sync.Mutex
+	var unhealthy bool
+	setUnhealthy := func(value bool) {
+		lock.Lock()
+		defer lock.Unlock()
+		unhealthy = value
+	}
+
+	BeforeEach(func() {
+		lock, unhealthy = &sync.Mutex{}, false
+		success, _ := RunFixture("health checks", func() {
+			RegisterHealthCheck("database", 5*time.Millisecond, func() error {
+				lock.Lock()
+				defer lock.Unlock()
+				if unhealthy {
+					return errors.New("connection refused")
+				}
+				return nil
+			})
+			RegisterHealthCheck("healthy", 5*time.Millisecond, func() error { return nil })
+			BeforeSuite(rt.T("before-suite"))
+			Describe("suite", func() {
+				It("A", rt.T("A", func() { time.Sleep(20 * time.Millisecond) }))
+				It("B", rt.T("B", func() {
+					setUnhealthy(true)
+					time.Sleep(30 * time.Millisecond)
+					F("boom")
+				}))
+				AfterEach(func() {
+					if CurrentSpecReport().LeafNodeText == "B" {
+						setUnhealthy(false)
+						time.Sleep(30 * time.Millisecond)
+					}
+				})
+				It("C", rt.T("C", func() { time.Sleep(20 * time.Millisecond) }))
+			})
+		})
+		Ω(success).Should(BeFalse())
+	})
+
+	It("records the periods during which a health check failed on the specs that ran during them", func() {
+		Ω(rt).Should(HaveTracked("before-suite", "A", "B", "C"))
+		Ω(reporter.Did.Find("A").EnvironmentDegradations).Should(BeEmpty())
+		Ω(reporter.Did.Find("C").EnvironmentDegradations).Should(BeEmpty())
+
+		report := reporter.Did.Find("B")
+		Ω(report).Should(HaveFailed("boom"))
+		Ω(report.EnvironmentDegradations).Should(HaveLen(1))
+		degradation := report.EnvironmentDegradations[0]
+		Ω(degradation.HealthCheck).Should(Equal("database"))
+		Ω(degradation.Message).Should(Equal("connection refused"))
+		Ω(degradation.StartTime).Should(BeTemporally(">=", report.StartTime))
+		Ω(degradation.EndTime).Should(BeTemporally(">", degradation.StartTime))
+		Ω(degradation.EndTime).Should(BeTemporally("<=", report.EndTime))
+	})
+})
+
+var _ = Describe("Health checks that panic", func() {
+	BeforeEach(func() {
+		success, _ := RunFixture("panicking health check", func() {
+			RegisterHealthCheck("flaky", 5*time.Millisecond, func() error {
+				panic("boom")
+			})
+			It("A", rt.T("A", func() { time.Sleep(20 * time.Millisecond) }))
+		})
+		Ω(success).Should(BeTrue())
+	})
+
+	It("are treated as failing and never change the outcome of the spec", func() {
+		Ω(reporter.Did.Find("A")).Should(HavePassed())
+		Ω(reporter.Did.Find("A").EnvironmentDegradations).Should(Equal([]types.EnvironmentDegradation{{
+			HealthCheck: "flaky",
+			Message:     "panicked: boom",
+			StartTime:   reporter.Did.Find("A").EnvironmentDegradations[0].StartTime,
+		}}))
+	})
+})
@@ -43,6 +43,10 @@ var _ = Describe("When a test suite is interrupted", func() {
 		It("reports the correct special failure reason", func() {
 			Ω(reporter.End.SpecialSuiteFailureReasons).Should(ContainElement("Interrupted by Timeout"))
 		})
+
+		It("reports that the suite timed out", func() {
+			Ω(reporter.End.ExitReason).Should(Equal(types.ExitReasonTimedOut))
+		})
 	})
 
 	Describe("when it is interrupted in a test", func() {
@@ -101,6 +105,24 @@ var _ = Describe("When a test suite is interrupted", func() {
 		It("reports the correct statistics", func() {
 			Ω(reporter.End).Should(BeASuiteSummary(false, NSpecs(4), NWillRun(4), NPassed(1), NSkipped(2), NFailed(1)))
 			Ω(reporter.End.SpecialSuiteFailureReasons).Should(ContainElement("Interrupted by Timeout"))
+			Ω(reporter.End.ExitReason).Should(Equal(types.ExitReasonTimedOut))
+		})
+	})
+
+	Describe("when it is interrupted by a signal", func() {
+		BeforeEach(func() {
+			success, _ := RunFixture("interrupted test", func() {
+				It("A", rt.T("A", func() {
+					interruptHandler.Interrupt(interrupt_handler.InterruptCauseSignal)
+					time.Sleep(time.Hour)
+				}))
+			})
+			Ω(success).Should(Equal(false))
+		})
+
+		It("reports that the suite was interrupted", func() {
+			Ω(reporter.End.SpecialSuiteFailureReasons).Should(ContainElement("Interrupted by User"))
+			Ω(reporter.End.ExitReason).Should(Equal(types.ExitReasonInterrupted))
 		})
 	})
 })
@@ -0,0 +1,78 @@
+package internal_integration_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/ginkgo/v2/internal/test_helpers"
+	"github.com/onsi/ginkgo/v2/types"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Multiple BeforeSuite and AfterSuite nodes", func() {
+	Describe("when all the nodes pass", func() {
+		BeforeEach(func() {
+			success, _ := RunFixture("multiple suite nodes", func() {
+				BeforeSuite(rt.T("before-suite-A"))
+				BeforeSuite(rt.T("before-suite-B"), Order(-1))
+				BeforeSuite(rt.T("before-suite-C"))
+				It("A", rt.T("A"))
+				AfterSuite(rt.T("after-suite-A"), Order(1))
+				AfterSuite(rt.T("after-suite-B"))
+			})
+			Ω(success).Should(BeTrue())
+		})
+
+		It("runs the nodes in ascending Order - and in the order they were registered when their Order is the same", func() {
+			Ω(rt).Should(HaveTracked(
+				"before-suite-B", "before-suite-A", "before-suite-C",
+				"A",
+				"after-suite-B", "after-suite-A",
+			))
+		})
+
+		It("reports on each node", func() {
+			Ω(reporter.Did.WithLeafNodeType(types.NodeTypeBeforeSuite)).Should(HaveLen(3))
+			Ω(reporter.Did.WithLeafNodeType(types.NodeTypeAfterSuite)).Should(HaveLen(2))
+		})
+	})
+
+	Describe("when a node fails", func() {
+		BeforeEach(func() {
+			success, _ := RunFixture("failing suite nodes", func() {
+				BeforeSuite(rt.T("before-suite-A", func() { F("boom") }))
+				BeforeSuite(rt.T("before-suite-B"))
+				It("A", rt.T("A"))
+				AfterSuite(rt.T("after-suite-A", func() { F("bam") }))
+				AfterSuite(rt.T("after-suite-B"))
+			})
+			Ω(success).Should(BeFalse())
+		})
+
+		It("does not run the remaining BeforeSuite nodes or the specs, but does run all the AfterSuite nodes", func() {
+			Ω(rt).Should(HaveTracked("before-suite-A", "after-suite-A", "after-suite-B"))
+			Ω(reporter.Did.WithLeafNodeType(types.NodeTypeBeforeSuite)).Should(HaveLen(1))
+			Ω(reporter.Did.FindByLeafNodeType(types.NodeTypeBeforeSuite)).Should(HaveFailed("boom"))
+			Ω(reporter.Did.WithLeafNodeType(types.NodeTypeAfterSuite).WithState(types.SpecStateFailed)).Should(HaveLen(1))
+			Ω(reporter.Did.WithLeafNodeType(types.NodeTypeAfterSuite).WithState(types.SpecStatePassed)).Should(HaveLen(1))
+		})
+	})
+
+	Describe("when combined with a SynchronizedBeforeSuite", func() {
+		BeforeEach(func() {
+			success, _ := RunFixture("mixed suite nodes", func() {
+				BeforeSuite(rt.T("before-suite"), Order(1))
+				SynchronizedBeforeSuite(func() []byte {
+					rt.Run("sbs-proc-1")
+					return nil
+				}, func(_ []byte) {
+					rt.Run("sbs-all-procs")
+				})
+				It("A", rt.T("A"))
+			})
+			Ω(success).Should(BeTrue())
+		})
+
+		It("orders them alongside the BeforeSuite nodes", func() {
+			Ω(rt).Should(HaveTracked("sbs-proc-1", "sbs-all-procs", "before-suite", "A"))
+		})
+	})
+})
@@ -0,0 +1,120 @@
+package internal_integration_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/ginkgo/v2/internal/test_helpers"
+	"github.com/onsi/ginkgo/v2/types"
+	. "github.com/onsi/gomega"
+)
+
+type fakeNetworkCapturer struct {
+	stopErr  error
+	captures int
+	dir      string
+}
+
+func (c *fakeNetworkCapturer) StartCapture(ctx SpecContext, artifactsDir string) error {
+	rt.Run("start-" + ctx.SpecReport().LeafNodeText)
+	c.dir = artifactsDir
+	return nil
+}
+
+func (c *fakeNetworkCapturer) StopCapture(ctx SpecContext) ([]string, error) {
+	rt.Run("stop-" + ctx.SpecReport().LeafNodeText)
+	if c.stopErr != nil {
+		return nil, c.stopErr
+	}
+	c.captures += 1
+	name := "capture.pcap"
+	return []string{name}, os.WriteFile(filepath.Join(c.dir, name), []byte("traffic"), 0644)
+}
+
+var _ = Describe("Network captures", func() {
+	var capturer *fakeNetworkCapturer
+
+	BeforeEach(func() {
+		var err error
+		conf.ArtifactsDir, err = os.MkdirTemp("", "ginkgo-captures-test")
+		Ω(err).ShouldNot(HaveOccurred())
+		DeferCleanup(os.RemoveAll, conf.ArtifactsDir)
+		capturer = &fakeNetworkCapturer{}
+	})
+
+	Describe("when specs match the capture's label filter", func() {
+		BeforeEach(func() {
+			attempts := 0
+			success, _ := RunFixture("network captures", func() {
+				RegisterNetworkCapture("network", capturer)
+				Describe("container", func() {
+					BeforeEach(rt.T("bef"))
+					It("A", Label("network"), rt.T("A"))
+					It("B", rt.T("B"))
+					It("C", Label("network"), FlakeAttempts(2), func() {
+						rt.Run("C")
+						attempts += 1
+						if attempts < 2 {
+							F("flake")
+						}
+					})
+					AfterEach(rt.T("aft"))
+				})
+			})
+			Ω(success).Should(BeTrue())
+		})
+
+		It("captures traffic around the matching specs - including their setup and cleanup", func() {
+			Ω(rt).Should(HaveTracked(
+				"start-A", "bef", "A", "aft", "stop-A",
+				"bef", "B", "aft",
+				"start-C", "bef", "C", "aft", "stop-C",
+				"start-C", "bef", "C", "aft", "stop-C",
+			))
+		})
+
+		It("records a capture for each attempt in the spec's report", func() {
+			Ω(reporter.Did.Find("B").NetworkCaptures).Should(BeEmpty())
+
+			specA := reporter.Did.Find("A")
+			Ω(specA.NetworkCaptures).Should(HaveLen(1))
+			Ω(filepath.Dir(filepath.Dir(specA.NetworkCaptures[0]))).Should(Equal(conf.ArtifactsDir))
+			Ω(specA.NetworkCaptures[0]).Should(BeAnExistingFile())
+
+			specC := reporter.Did.Find("C")
+			Ω(specC.NetworkCaptures).Should(HaveLen(2))
+			Ω(specC.NetworkCaptures[0]).ShouldNot(Equal(specC.NetworkCaptures[1]))
+		})
+	})
+
+	Describe("when the capturer fails", func() {
+		BeforeEach(func() {
+			capturer.stopErr = errors.New("tcpdump exited")
+			success, _ := RunFixture("failing network capture", func() {
+				RegisterNetworkCapture("network", capturer)
+				It("A", Label("network"), rt.T("A"))
+			})
+			Ω(success).Should(BeTrue())
+		})
+
+		It("reports a warning without changing the outcome of the spec", func() {
+			specA := reporter.Did.Find("A")
+			Ω(specA).Should(HavePassed())
+			Ω(specA.Warnings).Should(HaveLen(1))
+			Ω(specA.Warnings[0].Message).Should(ContainSubstring("tcpdump exited"))
+			Ω(specA.NetworkCaptures).Should(BeEmpty())
+		})
+	})
+
+	It("captures specs that fail", func() {
+		success, _ := RunFixture("failing spec", func() {
+			RegisterNetworkCapture("network", capturer)
+			It("A", Label("network"), rt.T("A", func() { F("boom") }))
+		})
+		Ω(success).Should(BeFalse())
+		Ω(reporter.Did.Find("A")).Should(HaveFailed("boom", types.FailureNodeIsLeafNode))
+		Ω(reporter.Did.Find("A").NetworkCaptures).Should(HaveLen(1))
+	})
+})
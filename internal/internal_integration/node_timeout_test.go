@@ -0,0 +1,118 @@
+package internal_integration_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	. "github.com/onsi/ginkgo/v2/internal/test_helpers"
+	"github.com/onsi/ginkgo/v2/types"
+)
+
+var _ = Describe("Node timeouts", func() {
+	Describe("when an It decorated with NodeTimeout does not complete in time", func() {
+		BeforeEach(func() {
+			success, _ := RunFixture("hung it", func() {
+				BeforeEach(rt.T("bef"))
+				It("A", func(ctx SpecContext) {
+					rt.Run("A")
+					writer.Println("waiting for the cluster")
+					<-ctx.Done()
+				}, NodeTimeout(50*time.Millisecond))
+				It("B", rt.T("B"), NodeTimeout(time.Minute))
+				AfterEach(rt.T("aft"))
+			})
+			Ω(success).Should(BeFalse())
+		})
+
+		It("times out the spec, still runs its cleanup, and moves on to the next spec", func() {
+			Ω(rt).Should(HaveTracked("bef", "A", "aft", "bef", "B", "aft"))
+			Ω(reporter.End).Should(BeASuiteSummary(false, NSpecs(2), NPassed(1), NFailed(1)))
+			Ω(reporter.Did.Find("B")).Should(HavePassed())
+		})
+
+		It("reports the timeout along with stack traces and the node's output", func() {
+			specA := reporter.Did.Find("A")
+			Ω(specA).Should(HaveTimedOut(ContainSubstring("It timed out after 50ms"), CapturedGinkgoWriterOutput("waiting for the cluster\n"), types.FailureNodeIsLeafNode))
+			Ω(specA.Failure.Message).Should(ContainSubstring("Here's a stack trace of all running goroutines:"))
+			Ω(specA.Failure.Location).Should(Equal(specA.LeafNodeLocation))
+		})
+	})
+
+	Describe("when a setup node decorated with NodeTimeout does not complete in time", func() {
+		BeforeEach(func() {
+			success, _ := RunFixture("hung before each", func() {
+				Describe("container", func() {
+					BeforeEach(func(ctx SpecContext) {
+						rt.Run("bef")
+						<-ctx.Done()
+					}, NodeTimeout(50*time.Millisecond))
+					It("A", rt.T("A"))
+					AfterEach(rt.T("aft"))
+				})
+			})
+			Ω(success).Should(BeFalse())
+		})
+
+		It("times out the spec without running the subject and still runs the AfterEach", func() {
+			Ω(rt).Should(HaveTracked("bef", "aft"))
+			Ω(reporter.Did.Find("A")).Should(HaveTimedOut(ContainSubstring("BeforeEach timed out after 50ms"), FailureNodeType(types.NodeTypeBeforeEach), types.FailureNodeInContainer))
+		})
+	})
+
+	Describe("when a node that does not accept a SpecContext times out and keeps running", func() {
+		BeforeEach(func() {
+			release := make(chan interface{})
+			success, _ := RunFixture("abandoned node", func() {
+				Describe("container", func() {
+					It("A", func() {
+						rt.Run("A")
+						<-release
+						rt.Run("A-late")
+						Fail("A's late failure")
+					}, NodeTimeout(50*time.Millisecond))
+					It("B", func() {
+						defer func() {
+							// let A's abandoned goroutine report its failure and finish while B is running
+							close(release)
+							time.Sleep(100 * time.Millisecond)
+						}()
+						rt.Run("B")
+						Fail("B's failure")
+					})
+					It("C", rt.T("C"))
+				})
+			})
+			Ω(success).Should(BeFalse())
+		})
+
+		It("ignores what the abandoned node reports so that it can't change the outcome of the specs that follow", func() {
+			Ω(rt).Should(HaveTracked("A", "B", "A-late", "C"))
+			Ω(reporter.Did.Find("A")).Should(HaveTimedOut(ContainSubstring("It timed out after 50ms")))
+			Ω(reporter.Did.Find("B")).Should(HaveFailed("B's failure"))
+			Ω(reporter.Did.Find("C")).Should(HavePassed())
+		})
+	})
+
+	Describe("when a timed out spec has flake attempts remaining", func() {
+		BeforeEach(func() {
+			attempts := 0
+			success, _ := RunFixture("flaky timeout", func() {
+				It("A", func(ctx SpecContext) {
+					rt.Run("A")
+					attempts += 1
+					if attempts < 2 {
+						<-ctx.Done()
+					}
+				}, NodeTimeout(50*time.Millisecond), FlakeAttempts(2))
+			})
+			Ω(success).Should(BeTrue())
+		})
+
+		It("retries the spec", func() {
+			Ω(rt).Should(HaveTracked("A", "A"))
+			Ω(reporter.Did.Find("A")).Should(HavePassed(NumAttempts(2)))
+		})
+	})
+})
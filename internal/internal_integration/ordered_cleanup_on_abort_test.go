@@ -0,0 +1,143 @@
+package internal_integration_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/onsi/ginkgo/v2/internal/interrupt_handler"
+	. "github.com/onsi/ginkgo/v2/internal/test_helpers"
+	"github.com/onsi/ginkgo/v2/types"
+)
+
+var _ = Describe("Cleaning up Ordered containers when the run ends partway through", func() {
+	interruptAfter := func(text string) func(SpecReport) {
+		return func(report SpecReport) {
+			if report.LeafNodeText == text {
+				// the fake interrupt handler registers interrupts asynchronously - wait for it so that the run ends before the next spec starts
+				interrupted := interruptHandler.Status().Channel
+				interruptHandler.Interrupt(interrupt_handler.InterruptCauseSignal)
+				<-interrupted
+			}
+		}
+	}
+
+	BeforeEach(func() {
+		conf.GracePeriod = time.Second
+	})
+
+	Context("when the suite is interrupted between the specs of an ordered container", func() {
+		BeforeEach(func() {
+			success, _ := RunFixture("interrupted between specs", func() {
+				Context("container", Ordered, func() {
+					BeforeAll(rt.T("BA", DC("DC-BA")))
+					It("A", rt.T("A"))
+					It("B", rt.T("B"))
+					It("C", rt.T("C"))
+					AfterAll(rt.T("AA", DC("DC-AA")))
+				})
+				ReportAfterEach(interruptAfter("A"))
+			})
+			Ω(success).Should(BeFalse())
+		})
+
+		It("still runs the pending AfterAlls and DeferCleanups", func() {
+			Ω(rt).Should(HaveTracked("BA", "A", "AA", "DC-AA", "DC-BA"))
+		})
+
+		It("skips the remaining specs", func() {
+			Ω(reporter.Did.Find("A")).Should(HavePassed())
+			Ω(reporter.Did.Find("B")).Should(HaveBeenSkipped())
+			Ω(reporter.Did.Find("C")).Should(HaveBeenSkipped())
+		})
+	})
+
+	Context("when a ReportAfterEach aborts the run between the specs of an ordered container", func() {
+		BeforeEach(func() {
+			success, _ := RunFixture("aborted between specs", func() {
+				Context("container", Ordered, func() {
+					BeforeAll(rt.T("BA", DC("DC-BA")))
+					It("A", rt.T("A"))
+					It("B", rt.T("B"))
+				})
+				ReportAfterEach(func(report SpecReport) {
+					if report.LeafNodeText == "A" {
+						Abort("abort!")
+					}
+				})
+			})
+			Ω(success).Should(BeFalse())
+		})
+
+		It("runs the DeferCleanups registered in the BeforeAll", func() {
+			Ω(rt).Should(HaveTracked("BA", "A", "DC-BA"))
+			Ω(reporter.Did.Find("A")).Should(HaveAborted("abort!"))
+			Ω(reporter.Did.Find("B")).Should(HaveBeenSkipped())
+		})
+	})
+
+	Context("when the pending cleanup fails", func() {
+		BeforeEach(func() {
+			success, _ := RunFixture("failing cleanup", func() {
+				Context("container", Ordered, func() {
+					BeforeAll(rt.T("BA"))
+					It("A", rt.T("A"))
+					It("B", rt.T("B"))
+					AfterAll(rt.T("AA", func() {
+						writer.Println("tearing down")
+						F("teardown failed")
+					}))
+				})
+				ReportAfterEach(interruptAfter("A"))
+			})
+			Ω(success).Should(BeFalse())
+		})
+
+		It("reports the failure on the first skipped spec", func() {
+			Ω(rt).Should(HaveTracked("BA", "A", "AA"))
+			Ω(reporter.Did.Find("B")).Should(HaveFailed("teardown failed", FailureNodeType(types.NodeTypeAfterAll), CapturedGinkgoWriterOutput("tearing down\n")))
+		})
+	})
+
+	Context("when the container has already been cleaned up", func() {
+		BeforeEach(func() {
+			success, _ := RunFixture("cleaned up", func() {
+				Context("container", Ordered, func() {
+					BeforeAll(rt.T("BA", DC("DC-BA")))
+					It("A", rt.T("A"))
+					AfterAll(rt.T("AA"))
+				})
+				It("B", rt.T("B"))
+				ReportAfterEach(interruptAfter("A"))
+			})
+			Ω(success).Should(BeFalse())
+		})
+
+		It("does not run the cleanup again", func() {
+			Ω(rt).Should(HaveTracked("BA", "A", "AA", "DC-BA"))
+			Ω(reporter.Did.Find("B")).Should(HaveBeenSkipped())
+		})
+	})
+
+	Context("with --skip-ordered-cleanup-on-abort", func() {
+		BeforeEach(func() {
+			conf.SkipOrderedCleanupOnAbort = true
+			success, _ := RunFixture("skip cleanup", func() {
+				Context("container", Ordered, func() {
+					BeforeAll(rt.T("BA", DC("DC-BA")))
+					It("A", rt.T("A"))
+					It("B", rt.T("B"))
+					AfterAll(rt.T("AA"))
+				})
+				ReportAfterEach(interruptAfter("A"))
+			})
+			Ω(success).Should(BeFalse())
+		})
+
+		It("skips the pending cleanup", func() {
+			Ω(rt).Should(HaveTracked("BA", "A"))
+			Ω(reporter.Did.Find("B")).Should(HaveBeenSkipped())
+		})
+	})
+})
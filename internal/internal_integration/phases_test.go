@@ -0,0 +1,189 @@
+package internal_integration_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	"github.com/onsi/ginkgo/v2/internal"
+	. "github.com/onsi/ginkgo/v2/internal/test_helpers"
+	"github.com/onsi/ginkgo/v2/types"
+	. "github.com/onsi/gomega"
+)
+
+func RunFixtureWithPhaseOrder(phaseOrder PhaseOrder, description string, callback func()) (bool, error) {
+	suite := internal.NewSuite()
+	suite.SetPhaseOrder(phaseOrder)
+	var success bool
+	var err error
+	WithSuite(suite, func() {
+		callback()
+		err = suite.BuildTree()
+		if err != nil {
+			return
+		}
+		success, _ = suite.Run(description, Label("TopLevelLabel"), "/path/to/suite", failer, reporter, writer, outputInterceptor, interruptHandler, client, conf)
+	})
+	return success, err
+}
+
+var _ = Describe("Execution Phases", func() {
+	var fixture func()
+	var phaseOrder PhaseOrder
+
+	BeforeEach(func() {
+		phaseOrder = PhaseOrder{"provision", "teardown"}
+		fixture = func() {
+			Describe("teardown", Phase("teardown"), func() {
+				It("T1", rt.T("T1"))
+				It("T2", Serial, rt.T("T2"))
+			})
+			It("U1", rt.T("U1"))
+			Describe("provision", Phase("provision"), func() {
+				It("P1", rt.T("P1"))
+				It("P2", Serial, rt.T("P2"))
+				It("P3", rt.T("P3"))
+			})
+			It("T3", Phase("teardown"), rt.T("T3"))
+		}
+	})
+
+	Context("when running in series", func() {
+		It("runs unphased specs first and then each phase in order", func() {
+			for conf.RandomSeed = 1; conf.RandomSeed < 5; conf.RandomSeed += 1 {
+				rt.Reset()
+				success, err := RunFixtureWithPhaseOrder(phaseOrder, "phases", fixture)
+				Ω(err).ShouldNot(HaveOccurred())
+				Ω(success).Should(BeTrue())
+
+				runs := rt.TrackedRuns()
+				Ω(runs).Should(HaveLen(7))
+				Ω(runs[0]).Should(Equal("U1"))
+				Ω(runs[1:4]).Should(ConsistOf("P1", "P2", "P3"))
+				Ω(runs[4:]).Should(ConsistOf("T1", "T2", "T3"))
+			}
+		})
+
+		It("allows the phases to appear in any order", func() {
+			success, err := RunFixtureWithPhaseOrder(PhaseOrder{"teardown", "provision"}, "phases", fixture)
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(success).Should(BeTrue())
+
+			runs := rt.TrackedRuns()
+			Ω(runs[0]).Should(Equal("U1"))
+			Ω(runs[1:4]).Should(ConsistOf("T1", "T2", "T3"))
+			Ω(runs[4:]).Should(ConsistOf("P1", "P2", "P3"))
+		})
+	})
+
+	Context("when running in parallel", func() {
+		BeforeEach(func() {
+			SetUpForParallel(2)
+		})
+
+		Describe("when running as proc 1", func() {
+			BeforeEach(func() {
+				conf.ParallelProcess = 1
+			})
+
+			It("waits for the other procs to reach the end of each phase, and runs the phase's serial specs before moving on", func() {
+				done := make(chan interface{})
+				go func() {
+					defer GinkgoRecover()
+					success, err := RunFixtureWithPhaseOrder(phaseOrder, "phases", fixture)
+					Ω(err).ShouldNot(HaveOccurred())
+					Ω(success).Should(BeTrue())
+					close(done)
+				}()
+				Eventually(rt).Should(HaveTracked("U1"))
+				Consistently(rt).Should(HaveTracked("U1"))
+				close(exitChannels[2])
+				Eventually(done).Should(BeClosed())
+
+				runs := rt.TrackedRuns()
+				Ω(runs).Should(HaveLen(7))
+				Ω(runs[1:3]).Should(ConsistOf("P1", "P3"))
+				Ω(runs[3]).Should(Equal("P2"))
+				Ω(runs[4:6]).Should(ConsistOf("T1", "T3"))
+				Ω(runs[6]).Should(Equal("T2"))
+			})
+		})
+
+		Describe("when running as a non-primary proc", func() {
+			BeforeEach(func() {
+				conf.ParallelProcess = 2
+			})
+
+			It("waits for proc 1 to release each phase and never runs the serial specs", func() {
+				done := make(chan interface{})
+				go func() {
+					defer GinkgoRecover()
+					success, err := RunFixtureWithPhaseOrder(phaseOrder, "phases", fixture)
+					Ω(err).ShouldNot(HaveOccurred())
+					Ω(success).Should(BeTrue())
+					close(done)
+				}()
+				Eventually(rt).Should(HaveTracked("U1"))
+				Consistently(rt).Should(HaveTracked("U1"))
+
+				Ω(client.PostPhaseReleased(0)).Should(Succeed())
+				Eventually(rt.TrackedRuns).Should(HaveLen(3))
+				Consistently(rt.TrackedRuns).Should(HaveLen(3))
+				Ω(rt.TrackedRuns()[1:]).Should(ConsistOf("P1", "P3"))
+
+				Ω(client.PostPhaseReleased(1)).Should(Succeed())
+				Eventually(done).Should(BeClosed())
+				Ω(rt.TrackedRuns()[3:]).Should(ConsistOf("T1", "T3"))
+			})
+
+			It("fails the suite if proc 1 disappears before releasing the phase", func() {
+				close(exitChannels[1])
+				success, err := RunFixtureWithPhaseOrder(phaseOrder, "phases", fixture)
+				Ω(err).ShouldNot(HaveOccurred())
+				Ω(success).Should(BeFalse())
+				Ω(rt).Should(HaveTracked("U1"))
+				Ω(reporter.End.SpecialSuiteFailureReasons).Should(ContainElement(ContainSubstring(types.GinkgoErrors.ExecutionPhaseDisappearedOnProc1().Error())))
+			})
+		})
+	})
+
+	Describe("validation", func() {
+		It("errors when a spec uses a phase that is not in the phase order", func() {
+			_, err := RunFixtureWithPhaseOrder(PhaseOrder{"provision"}, "phases", fixture)
+			Ω(err).Should(HaveOccurred())
+			Ω(err).Should(MatchError(ContainSubstring("Unknown Execution Phase")))
+			Ω(rt).Should(HaveTrackedNothing())
+		})
+
+		It("errors when a phase is used but no phase order is declared", func() {
+			_, err := RunFixtureWithPhaseOrder(nil, "phases", fixture)
+			Ω(err).Should(HaveOccurred())
+			Ω(err).Should(MatchError(ContainSubstring("Unknown Execution Phase")))
+		})
+
+		It("errors when the phase order lists a phase more than once", func() {
+			_, err := RunFixtureWithPhaseOrder(PhaseOrder{"provision", "teardown", "provision"}, "phases", fixture)
+			Ω(err).Should(MatchError(types.GinkgoErrors.DuplicatePhaseInPhaseOrder("provision")))
+		})
+
+		It("errors when a phase is declared within an Ordered container", func() {
+			_, err := RunFixtureWithPhaseOrder(phaseOrder, "phases", func() {
+				Describe("ordered", Ordered, func() {
+					It("A", Phase("provision"), rt.T("A"))
+				})
+			})
+			Ω(err).Should(HaveOccurred())
+			Ω(err.(types.GinkgoError).Heading).Should(Equal("Phase decorator in Ordered Container"))
+		})
+
+		It("allows Ordered containers to be decorated with a phase", func() {
+			success, err := RunFixtureWithPhaseOrder(phaseOrder, "phases", func() {
+				It("B", Phase("teardown"), rt.T("B"))
+				Describe("ordered", Ordered, Phase("provision"), func() {
+					It("A1", rt.T("A1"))
+					It("A2", rt.T("A2"))
+				})
+			})
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(success).Should(BeTrue())
+			Ω(rt).Should(HaveTracked("A1", "A2", "B"))
+		})
+	})
+})
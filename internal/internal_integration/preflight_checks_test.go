@@ -0,0 +1,138 @@
+package internal_integration_test
+
+import (
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	. "github.com/onsi/ginkgo/v2/internal/test_helpers"
+	"github.com/onsi/ginkgo/v2/types"
+)
+
+var _ = Describe("RegisterPreflightCheck", func() {
+	var fixture func()
+	BeforeEach(func() {
+		fixture = func() {
+			RegisterPreflightCheck("cluster", func() error { rt.Run("cluster"); return nil })
+			RegisterPreflightCheck("database", func() error { rt.Run("database"); return fmt.Errorf("connection refused") })
+			RegisterPreflightCheck("credentials", func() error { rt.Run("credentials"); panic("no credentials") })
+			BeforeSuite(rt.T("before-suite"))
+			It("A", rt.T("A"))
+			AfterSuite(rt.T("after-suite"))
+		}
+	})
+
+	Describe("when the preflight checks pass", func() {
+		BeforeEach(func() {
+			success, _ := RunFixture("happy preflight checks", func() {
+				RegisterPreflightCheck("cluster", func() error { rt.Run("cluster"); return nil })
+				RegisterPreflightCheck("database", func() error { rt.Run("database"); return nil })
+				BeforeSuite(rt.T("before-suite"))
+				It("A", rt.T("A"))
+				AfterSuite(rt.T("after-suite"))
+			})
+			Ω(success).Should(BeTrue())
+		})
+
+		It("runs the checks, in order, before the BeforeSuite", func() {
+			Ω(rt).Should(HaveTracked("cluster", "database", "before-suite", "A", "after-suite"))
+		})
+
+		It("does not report any failures", func() {
+			Ω(reporter.End.PreflightFailures).Should(BeEmpty())
+			Ω(reporter.End).Should(BeASuiteSummary(true, NSpecs(1), NPassed(1)))
+		})
+	})
+
+	Describe("when a preflight check fails", func() {
+		BeforeEach(func() {
+			success, _ := RunFixture("unhappy preflight checks", fixture)
+			Ω(success).Should(BeFalse())
+		})
+
+		It("runs all the checks and nothing else", func() {
+			Ω(rt).Should(HaveTracked("cluster", "database", "credentials"))
+		})
+
+		It("reports the failing checks", func() {
+			Ω(reporter.End.PreflightFailures).Should(Equal([]types.PreflightFailure{
+				{Check: "database", Message: "connection refused", Location: reporter.End.PreflightFailures[0].Location},
+				{Check: "credentials", Message: "panicked: no credentials", Location: reporter.End.PreflightFailures[1].Location},
+			}))
+			Ω(reporter.End.PreflightFailures[0].Location.FileName).Should(HaveSuffix("preflight_checks_test.go"))
+			Ω(reporter.End.SpecialSuiteFailureReasons).Should(ContainElement("Environment not ready: preflight checks failed"))
+			Ω(reporter.End).Should(BeASuiteSummary(false, NSpecs(1), NWillRun(1), NPassed(0), NSkipped(0)))
+		})
+	})
+
+	Describe("when a failing preflight check's message contains a secret", func() {
+		BeforeEach(func() {
+			success, _ := RunFixture("preflight check with secrets", func() {
+				GinkgoRedact("hunter2")
+				RegisterPreflightCheck("vault", func() error { return fmt.Errorf("token hunter2 was rejected") })
+				It("A", rt.T("A"))
+			})
+			Ω(success).Should(BeFalse())
+		})
+
+		It("redacts the message", func() {
+			Ω(reporter.End.PreflightFailures).Should(HaveLen(1))
+			Ω(reporter.End.PreflightFailures[0].Message).Should(Equal("token [REDACTED] was rejected"))
+		})
+	})
+
+	Describe("when running in parallel", func() {
+		BeforeEach(func() {
+			SetUpForParallel(2)
+		})
+
+		Describe("as process #1", func() {
+			BeforeEach(func() {
+				conf.ParallelProcess = 1
+				success, _ := RunFixture("unhappy preflight checks", fixture)
+				Ω(success).Should(BeFalse())
+			})
+
+			It("runs the checks and shares the failures with the other processes", func() {
+				Ω(rt).Should(HaveTracked("cluster", "database", "credentials"))
+				failures, err := client.BlockUntilPreflightCompleted()
+				Ω(err).ShouldNot(HaveOccurred())
+				Ω(failures).Should(Equal(reporter.End.PreflightFailures))
+				Ω(failures).Should(HaveLen(2))
+			})
+		})
+
+		Describe("as a non-primary process", func() {
+			BeforeEach(func() {
+				conf.ParallelProcess = 2
+			})
+
+			It("does not run the checks and gives up straight away when process #1 reports a failure", func() {
+				Ω(client.PostPreflightCompleted([]types.PreflightFailure{{Check: "database", Message: "connection refused"}})).Should(Succeed())
+				success, _ := RunFixture("unhappy preflight checks", fixture)
+				Ω(success).Should(BeFalse())
+				Ω(rt).Should(HaveTrackedNothing())
+				Ω(reporter.End.SpecialSuiteFailureReasons).Should(ContainElement("Environment not ready: preflight checks failed"))
+			})
+
+			It("runs the suite when process #1 reports that the checks passed", func() {
+				Ω(client.PostPreflightCompleted(nil)).Should(Succeed())
+				success, _ := RunFixture("happy preflight checks", func() {
+					RegisterPreflightCheck("cluster", func() error { rt.Run("cluster"); return nil })
+					It("A", rt.T("A"))
+				})
+				Ω(success).Should(BeTrue())
+				Ω(rt).Should(HaveTracked("A"))
+			})
+
+			It("fails the suite if process #1 disappears before running the checks", func() {
+				close(exitChannels[1])
+				success, _ := RunFixture("unhappy preflight checks", fixture)
+				Ω(success).Should(BeFalse())
+				Ω(rt).Should(HaveTrackedNothing())
+				Ω(reporter.End.SpecialSuiteFailureReasons).Should(ContainElement(types.GinkgoErrors.PreflightChecksDisappearedOnProc1().Error()))
+			})
+		})
+	})
+})
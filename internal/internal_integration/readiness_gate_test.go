@@ -0,0 +1,135 @@
+package internal_integration_test
+
+import (
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	. "github.com/onsi/ginkgo/v2/internal/test_helpers"
+	"github.com/onsi/ginkgo/v2/types"
+)
+
+var _ = Describe("ReadinessGate", func() {
+	Describe("when the gates are satisfied immediately", func() {
+		BeforeEach(func() {
+			success, _ := RunFixture("happy readiness gates", func() {
+				ReadinessGate(func() error { rt.Run("gate-1"); return nil }, time.Second)
+				ReadinessGate(func() error { rt.Run("gate-2"); return nil }, time.Second)
+				BeforeSuite(rt.T("before-suite"))
+				It("A", rt.T("A"))
+				AfterSuite(rt.T("after-suite"))
+			})
+			Ω(success).Should(BeTrue())
+		})
+
+		It("runs the gates, in order, before the BeforeSuite", func() {
+			Ω(rt).Should(HaveTracked("gate-1", "gate-2", "before-suite", "A", "after-suite"))
+		})
+
+		It("reports on the gates", func() {
+			gates := reporter.Did.WithLeafNodeType(types.NodeTypeReadinessGate)
+			Ω(gates).Should(HaveLen(2))
+			Ω(gates[0]).Should(HavePassed(CapturedGinkgoWriterOutput("")))
+			Ω(gates[1]).Should(HavePassed())
+			Ω(reporter.End).Should(BeASuiteSummary(true, NSpecs(1), NPassed(1)))
+		})
+	})
+
+	Describe("when the gate is eventually satisfied", func() {
+		BeforeEach(func() {
+			attempts := 0
+			success, _ := RunFixture("eventually ready", func() {
+				ReadinessGate(func() error {
+					rt.Run("gate")
+					attempts += 1
+					if attempts < 3 {
+						return fmt.Errorf("not ready %d", attempts)
+					}
+					return nil
+				}, 500*time.Millisecond)
+				It("A", rt.T("A"))
+			})
+			Ω(success).Should(BeTrue())
+		})
+
+		It("polls until the check succeeds, then runs the specs", func() {
+			Ω(rt).Should(HaveTracked("gate", "gate", "gate", "A"))
+		})
+
+		It("emits progress to the GinkgoWriter", func() {
+			output := reporter.Did.FindByLeafNodeType(types.NodeTypeReadinessGate).CapturedGinkgoWriterOutput
+			Ω(output).Should(ContainSubstring("ReadinessGate not yet satisfied (attempt 1, "))
+			Ω(output).Should(ContainSubstring("): not ready 1\n"))
+			Ω(output).Should(ContainSubstring("ReadinessGate not yet satisfied (attempt 2, "))
+			Ω(output).Should(ContainSubstring("): not ready 2\n"))
+			Ω(output).Should(ContainSubstring("ReadinessGate satisfied after 3 attempts"))
+		})
+
+		It("emits progress through the reporter so that it is visible without -v", func() {
+			Ω(reporter.ProgressReports).Should(HaveLen(2))
+			for _, report := range reporter.ProgressReports {
+				Ω(report.CurrentNodeType).Should(Equal(types.NodeTypeReadinessGate))
+			}
+			Ω(reporter.ProgressReports[0].Message).Should(HavePrefix("ReadinessGate not yet satisfied (attempt 1, "))
+			Ω(reporter.ProgressReports[0].Message).Should(HaveSuffix("): not ready 1"))
+			Ω(reporter.ProgressReports[1].Message).Should(HavePrefix("ReadinessGate satisfied after 3 attempts"))
+		})
+	})
+
+	Describe("when the gate is never satisfied", func() {
+		BeforeEach(func() {
+			success, _ := RunFixture("never ready", func() {
+				ReadinessGate(func() error { rt.Run("gate-1"); return fmt.Errorf("boom") }, 50*time.Millisecond)
+				ReadinessGate(func() error { rt.Run("gate-2"); return nil }, time.Second)
+				BeforeSuite(rt.T("before-suite"))
+				It("A", rt.T("A"))
+				It("B", rt.T("B"))
+				AfterSuite(rt.T("after-suite"))
+			})
+			Ω(success).Should(BeFalse())
+		})
+
+		It("fails the gate with the last error", func() {
+			gate := reporter.Did.FindByLeafNodeType(types.NodeTypeReadinessGate)
+			Ω(gate.State).Should(Equal(types.SpecStateFailed))
+			Ω(gate.Failure.Message).Should(Equal("ReadinessGate was not satisfied within 50ms.\nLast error: boom"))
+			Ω(gate.Failure.Location).Should(Equal(gate.LeafNodeLocation))
+			Ω(gate.Failure.FailureNodeContext).Should(Equal(types.FailureNodeIsLeafNode))
+		})
+
+		It("skips subsequent gates, the BeforeSuite, the specs, and the AfterSuite", func() {
+			runs := rt.TrackedRuns()
+			Ω(len(runs)).Should(BeNumerically(">", 1))
+			Ω(runs).ShouldNot(ContainElement(Not(Equal("gate-1"))))
+			Ω(reporter.Did.WithLeafNodeType(types.NodeTypeAfterSuite)).Should(BeEmpty())
+		})
+
+		It("reports a suite failure", func() {
+			Ω(reporter.End).Should(BeASuiteSummary(false, NSpecs(2), NPassed(0), NFailed(0)))
+		})
+	})
+
+	Describe("when the gate is never satisfied on process #1 of a parallel run", func() {
+		BeforeEach(func() {
+			SetUpForParallel(2)
+			conf.ParallelProcess = 1
+			success, _ := RunFixture("never ready in parallel", func() {
+				ReadinessGate(func() error { rt.Run("gate"); return fmt.Errorf("boom") }, 50*time.Millisecond)
+				SynchronizedBeforeSuite(func() []byte { rt.Run("before-suite-proc-1"); return nil }, func(_ []byte) { rt.Run("before-suite-all-procs") })
+				It("A", rt.T("A"))
+				SynchronizedAfterSuite(rt.T("after-suite-all-procs"), rt.T("after-suite-proc-1"))
+			})
+			Ω(success).Should(BeFalse())
+		})
+
+		It("tells the other processes not to wait for the SynchronizedBeforeSuite", func() {
+			state, data, err := client.BlockUntilSynchronizedBeforeSuiteData()
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(state).Should(Equal(types.SpecStateFailed))
+			Ω(data).Should(BeNil())
+			Ω(rt.TrackedRuns()).ShouldNot(ContainElement(Not(Equal("gate"))))
+		})
+	})
+})
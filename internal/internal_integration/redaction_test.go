@@ -0,0 +1,92 @@
+package internal_integration_test
+
+import (
+	"regexp"
+
+	. "github.com/onsi/ginkgo/v2"
+	"github.com/onsi/ginkgo/v2/types"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Redacting secrets", func() {
+	var reportAfterEachSawSecret bool
+
+	BeforeEach(func() {
+		reportAfterEachSawSecret = false
+		success, _ := RunFixture("redaction", func() {
+			BeforeSuite(func() {
+				GinkgoRedact("hunter2")
+				GinkgoRedactPattern(regexp.MustCompile(`Bearer \S+`))
+			})
+
+			It("writes secrets", func() {
+				writer.Println("logging in with hunter2")
+				outputInterceptor.AppendInterceptedOutput("Authorization: Bearer abc123\n")
+				AddReportEntry("credentials", "user:hunter2")
+			})
+
+			It("fails with secrets", func() {
+				F("expected hunter2 to equal Bearer xyz")
+			})
+
+			It("panics with secrets", func() {
+				panic("hunter2")
+			})
+
+			It("fails in a step with secrets", func() {
+				Step("login", func() {
+					F("password was hunter2")
+				})
+			})
+
+			It("writes secrets before they are registered", func() {
+				writer.Println("sekrit")
+				GinkgoRedact("sekrit")
+			})
+
+			ReportAfterEach(func(report types.SpecReport) {
+				for _, s := range []string{report.CapturedGinkgoWriterOutput, report.CapturedStdOutErr, report.Failure.Message, report.Failure.ForwardedPanic} {
+					if regexp.MustCompile(`hunter2|abc123|sekrit`).MatchString(s) {
+						reportAfterEachSawSecret = true
+					}
+				}
+			})
+		})
+		Ω(success).Should(BeFalse())
+	})
+
+	It("redacts captured output and report entries", func() {
+		report := reporter.Did.Find("writes secrets")
+		Ω(report.CapturedGinkgoWriterOutput).Should(Equal("logging in with [REDACTED]\n"))
+		Ω(report.CapturedStdOutErr).Should(Equal("Authorization: [REDACTED]\n"))
+		Ω(report.ReportEntries[0].Value.String()).Should(Equal("user:[REDACTED]"))
+	})
+
+	It("redacts failure messages and panics", func() {
+		Ω(reporter.Did.Find("fails with secrets").Failure.Message).Should(Equal("expected [REDACTED] to equal [REDACTED]"))
+		Ω(reporter.Did.Find("panics with secrets").Failure.ForwardedPanic).Should(Equal("[REDACTED]"))
+	})
+
+	It("redacts the messages of failed steps", func() {
+		report := reporter.Did.Find("fails in a step with secrets")
+		Ω(report.Failure.Message).Should(Equal("password was [REDACTED]"))
+		Ω(report.Steps).Should(HaveLen(1))
+		Ω(report.Steps[0].Message).Should(Equal("password was [REDACTED]"))
+	})
+
+	It("redacts output emitted before the value was registered", func() {
+		Ω(reporter.Did.Find("writes secrets before they are registered").CapturedGinkgoWriterOutput).Should(Equal("[REDACTED]\n"))
+	})
+
+	It("redacts the report before ReportAfterEach nodes see it", func() {
+		Ω(reportAfterEachSawSecret).Should(BeFalse())
+	})
+
+	It("redacts the reports in the end-of-suite report", func() {
+		Ω(reporter.End.SpecReports.WithLeafNodeType(types.NodeTypeIt)).Should(HaveLen(5))
+		for _, report := range reporter.End.SpecReports {
+			Ω(report.CapturedGinkgoWriterOutput).ShouldNot(ContainSubstring("hunter2"))
+			Ω(report.Failure.Message).ShouldNot(ContainSubstring("hunter2"))
+		}
+	})
+})
@@ -0,0 +1,58 @@
+package internal_integration_test
+
+import (
+	"bytes"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/onsi/ginkgo/v2/internal"
+	. "github.com/onsi/ginkgo/v2/internal/test_helpers"
+)
+
+var _ = Describe("Overriding reporting for a subtree", func() {
+	var streamed *bytes.Buffer
+	BeforeEach(func() {
+		streamed = &bytes.Buffer{}
+		writer = internal.NewWriter(streamed)
+		writer.SetMode(internal.WriterModeBufferOnly)
+
+		success, _ := RunFixture("reporting overrides", func() {
+			Describe("e2e", VerboseOutput, NoCapture, SlowSpecThreshold(time.Minute), func() {
+				It("A", func() {
+					rt.Run("A")
+					writer.Println("A-output")
+				})
+				Describe("smoke", SlowSpecThreshold(10*time.Second), func() {
+					It("B", func() {
+						rt.Run("B")
+						writer.Println("B-output")
+					})
+				})
+			})
+			It("C", func() {
+				rt.Run("C")
+				writer.Println("C-output")
+			})
+		})
+		Ω(success).Should(BeTrue())
+		Ω(rt).Should(HaveTracked("A", "B", "C"))
+	})
+
+	It("records VerboseOutput and the innermost SlowSpecThreshold on the spec reports", func() {
+		Ω(reporter.Did.Find("A").VerboseOutput).Should(BeTrue())
+		Ω(reporter.Did.Find("A").SlowSpecThreshold).Should(Equal(time.Minute))
+		Ω(reporter.Did.Find("B").VerboseOutput).Should(BeTrue())
+		Ω(reporter.Did.Find("B").SlowSpecThreshold).Should(Equal(10 * time.Second))
+		Ω(reporter.Did.Find("C").VerboseOutput).Should(BeFalse())
+		Ω(reporter.Did.Find("C").SlowSpecThreshold).Should(BeZero())
+	})
+
+	It("streams the GinkgoWriter output of NoCapture specs and restores buffering afterwards", func() {
+		Ω(streamed.String()).Should(Equal("A-output\nB-output\n"))
+		Ω(reporter.Did.Find("A")).Should(HavePassed(CapturedGinkgoWriterOutput("A-output\n")))
+		Ω(reporter.Did.Find("C")).Should(HavePassed(CapturedGinkgoWriterOutput("C-output\n")))
+		Ω(writer.Mode()).Should(Equal(internal.WriterModeBufferOnly))
+	})
+})
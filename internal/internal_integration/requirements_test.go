@@ -0,0 +1,75 @@
+package internal_integration_test
+
+import (
+	"errors"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/ginkgo/v2/internal/test_helpers"
+	"github.com/onsi/ginkgo/v2/types"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Named requirements", func() {
+	var checkedConstraints []string
+
+	BeforeEach(func() {
+		checkedConstraints = []string{}
+		success, _ := RunFixture("named requirements", func() {
+			RegisterRequirementChecker("docker", func(constraint string) error {
+				rt.Run("check-docker")
+				checkedConstraints = append(checkedConstraints, constraint)
+				return nil
+			})
+			RegisterRequirementChecker("postgres", func(constraint string) error {
+				rt.Run("check-postgres")
+				checkedConstraints = append(checkedConstraints, constraint)
+				if constraint == ">=14" {
+					return errors.New("found postgres 12")
+				}
+				return nil
+			})
+			RegisterRequirementChecker("kafka", func(_ string) error {
+				rt.Run("check-kafka")
+				panic("boom")
+			})
+			BeforeSuite(rt.T("before-suite"))
+			Describe("suite", func() {
+				Describe("container", Requires("docker"), func() {
+					BeforeEach(rt.T("bef"))
+					It("A", rt.T("A"))
+					It("B", Requires("postgres>=14"), rt.T("B"))
+					It("C", Requires("postgres>=12"), rt.T("C"))
+				})
+				It("D", Requires("redis"), rt.T("D"))
+				It("E", Requires("kafka", CPU(1)), rt.T("E"))
+				PIt("F", Requires("memcached"), rt.T("F"))
+			})
+		})
+		Ω(success).Should(BeTrue())
+	})
+
+	It("checks each requirement once, after BeforeSuite, and skips the specs whose requirements are not met", func() {
+		Ω(rt).Should(HaveTracked(
+			"before-suite",
+			"check-docker", "check-postgres", "check-postgres", "check-kafka",
+			"bef", "A",
+			"bef", "C",
+		))
+		Ω(checkedConstraints).Should(Equal([]string{"", ">=14", ">=12"}))
+
+		Ω(reporter.Did.Find("A")).Should(HavePassed())
+		Ω(reporter.Did.Find("B")).Should(HaveBeenSkippedWithMessage("Spec skipped because requirement postgres>=14 is not met: found postgres 12"))
+		Ω(reporter.Did.Find("C")).Should(HavePassed())
+		Ω(reporter.Did.Find("D")).Should(HaveBeenSkippedWithMessage(`Spec skipped because requirement redis is not met: no RequirementChecker is registered for "redis"`))
+		Ω(reporter.Did.Find("E")).Should(HaveBeenSkippedWithMessage("Spec skipped because requirement kafka is not met: panicked: boom"))
+		Ω(reporter.Did.Find("F")).Should(BePending())
+	})
+
+	It("lists the unmet requirements in the suite's report", func() {
+		Ω(reporter.End.UnmetRequirements).Should(Equal(types.UnmetRequirements{
+			{Requirement: "postgres>=14", Reason: "found postgres 12"},
+			{Requirement: "redis", Reason: `no RequirementChecker is registered for "redis"`},
+			{Requirement: "kafka", Reason: "panicked: boom"},
+		}))
+	})
+})
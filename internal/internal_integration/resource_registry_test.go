@@ -0,0 +1,71 @@
+package internal_integration_test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/ginkgo/v2/internal/test_helpers"
+	"github.com/onsi/ginkgo/v2/types"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("RegisterResource", func() {
+	var fixture func()
+	BeforeEach(func() {
+		fixture = func() {
+			Describe("container", func() {
+				It("A", func() {
+					RegisterResource(Resource{Kind: "vm", Name: "a"}, rt.T("cleanup-a"))
+					rt.Run("A")
+				})
+				It("B", func() {
+					RegisterResource(Resource{Kind: "vm", Name: "b"}, func() error {
+						rt.Run("cleanup-b")
+						return fmt.Errorf("boom")
+					})
+					rt.Run("B")
+				})
+				It("C", func() {
+					RegisterResource(Resource{Kind: "vm", Name: "c"}, func(name string) { rt.Run("cleanup-" + name) }, "c")
+					rt.Run("C")
+				})
+			})
+		}
+	})
+
+	It("invokes the cleanup callbacks after the spec, like DeferCleanup", func() {
+		success, _ := RunFixture("resources", fixture)
+		Ω(success).Should(BeFalse())
+		Ω(rt).Should(HaveTracked("A", "cleanup-a", "B", "cleanup-b", "C", "cleanup-c"))
+		Ω(reporter.Did.Find("B")).Should(HaveFailed("DeferCleanup callback returned error: boom"))
+	})
+
+	Context("when a resource registry is configured", func() {
+		var path string
+		BeforeEach(func() {
+			path = filepath.Join(GinkgoT().TempDir(), "registry.jsonl")
+			conf.ResourceRegistry = path
+		})
+
+		It("records the registered resources and only releases resources whose cleanup succeeded", func() {
+			RunFixture("resources", fixture)
+			orphans, err := types.LoadOrphanedResources(path)
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(orphans).Should(HaveLen(1))
+			Ω(orphans[0].Resource).Should(Equal(types.Resource{Kind: "vm", Name: "b"}))
+			Ω(orphans[0].SpecText).Should(Equal("container B"))
+			Ω(orphans[0].ParallelProcess).Should(Equal(1))
+		})
+
+		It("fails the spec if the registry cannot be written to", func() {
+			conf.ResourceRegistry = filepath.Join(path, "does-not-exist", "registry.jsonl")
+			success, _ := RunFixture("resources", fixture)
+			Ω(success).Should(BeFalse())
+			Ω(reporter.Did.Find("A")).Should(HaveFailed(ContainSubstring("failed to open resource registry")))
+			_, err := os.Stat(conf.ResourceRegistry)
+			Ω(err).Should(HaveOccurred())
+		})
+	})
+})
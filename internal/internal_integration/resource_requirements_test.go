@@ -0,0 +1,36 @@
+package internal_integration_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/ginkgo/v2/internal/test_helpers"
+	"github.com/onsi/ginkgo/v2/types"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Resource requirements", func() {
+	BeforeEach(func() {
+		success, _ := RunFixture("resource requirements", func() {
+			Describe("heavy container", Requires(CPU(4), Memory("8Gi")), func() {
+				It("A", rt.T("A"))
+				It("B", Requires(CPU(2), GPU(1)), rt.T("B"))
+			})
+			It("C", rt.T("C"))
+		})
+		Ω(success).Should(BeTrue())
+	})
+
+	It("runs the specs", func() {
+		Ω(rt).Should(HaveTracked("A", "B", "C"))
+	})
+
+	It("reports the largest requirement in each dimension across the spec's hierarchy", func() {
+		Ω(reporter.Did.Find("A").ResourceRequirements).Should(Equal(types.ResourceRequirements{CPU: 4, MemoryBytes: 8 << 30}))
+		Ω(reporter.Did.Find("B").ResourceRequirements).Should(Equal(types.ResourceRequirements{CPU: 4, MemoryBytes: 8 << 30, GPU: 1}))
+		Ω(reporter.Did.Find("C").ResourceRequirements.IsZero()).Should(BeTrue())
+	})
+
+	It("includes the requirements in the report's JSON only when they are set", func() {
+		Ω(reporter.Did.Find("A").MarshalJSON()).Should(ContainSubstring(`"ResourceRequirements":{"CPU":4,"MemoryBytes":8589934592}`))
+		Ω(reporter.Did.Find("C").MarshalJSON()).ShouldNot(ContainSubstring("ResourceRequirements"))
+	})
+})
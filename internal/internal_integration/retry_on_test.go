@@ -0,0 +1,100 @@
+package internal_integration_test
+
+import (
+	"errors"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/ginkgo/v2/internal/test_helpers"
+	"github.com/onsi/ginkgo/v2/types"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("retrying only some failures with --retry-on", func() {
+	JustBeforeEach(func() {
+		success, _ := RunFixture("retry on", func() {
+			It("assertion", FlakeAttempts(3), rt.T("assertion", func() {
+				F("assertion")
+			}))
+			It("error", FlakeAttempts(3), rt.T("error", func() {
+				failer.FailWithError("dialing", errors.New("connection refused"), cl)
+				panic("panic to simulate how ginkgo's FailWith works")
+			}))
+			It("timeout", func(ctx SpecContext) {
+				rt.Run("timeout")
+				<-ctx.Done()
+			}, NodeTimeout(20*time.Millisecond), FlakeAttempts(2))
+			It("network", Label("Network"), FlakeAttempts(2), rt.T("network", func() {
+				F("network")
+			}))
+			Describe("ordered", Ordered, FlakeAttempts(2), func() {
+				It("ordered", rt.T("ordered", func() {
+					F("ordered")
+				}))
+				AfterAll(rt.T("after-all"))
+			})
+		})
+		Ω(success).Should(BeFalse())
+	})
+
+	Context("by default", func() {
+		It("retries every failure", func() {
+			Ω(reporter.Did.Find("assertion")).Should(HaveFailed("assertion", NumAttempts(3)))
+			Ω(reporter.Did.Find("error")).Should(HaveFailed("dialing: connection refused", NumAttempts(3)))
+			Ω(reporter.Did.Find("timeout")).Should(HaveTimedOut(NumAttempts(2)))
+			Ω(reporter.Did.Find("network")).Should(HaveFailed("network", NumAttempts(2)))
+		})
+	})
+
+	Context("when a global policy is set", func() {
+		BeforeEach(func() {
+			conf.RetryOn = []string{"!assertion"}
+		})
+
+		It("only retries the failures the policy allows", func() {
+			Ω(reporter.Did.Find("assertion")).Should(HaveFailed("assertion", NumAttempts(1)))
+			Ω(reporter.Did.Find("error")).Should(HaveFailed("dialing: connection refused", NumAttempts(3)))
+			Ω(reporter.Did.Find("timeout")).Should(HaveTimedOut(NumAttempts(2)))
+			Ω(reporter.Did.Find("network")).Should(HaveFailed("network", NumAttempts(1)))
+		})
+
+		It("notes why the spec was not retried and records the attempt", func() {
+			report := reporter.Did.Find("assertion")
+			Ω(report.CapturedGinkgoWriterOutput).Should(ContainSubstring("Ginkgo: Attempt #1 Failed.  Not retrying assertion failures (see --retry-on)."))
+			Ω(report.Attempts).Should(HaveLen(1))
+			Ω(report.FailureCategory()).Should(Equal(types.FailureCategoryAssertion))
+		})
+
+		It("still runs the AfterAll of an Ordered container whose spec won't be retried", func() {
+			Ω(rt).Should(HaveRun("after-all"))
+			Ω(reporter.Did.Find("ordered")).Should(HaveFailed("ordered", NumAttempts(1)))
+		})
+	})
+
+	Context("when a label policy is set", func() {
+		BeforeEach(func() {
+			conf.RetryOn = []string{"!assertion", "network:assertion"}
+		})
+
+		It("applies the label policy, in place of the global policy, to specs with that label", func() {
+			Ω(reporter.Did.Find("network")).Should(HaveFailed("network", NumAttempts(2)))
+			Ω(reporter.Did.Find("assertion")).Should(HaveFailed("assertion", NumAttempts(1)))
+			Ω(reporter.Did.Find("error")).Should(HaveFailed("dialing: connection refused", NumAttempts(3)))
+		})
+	})
+
+	Context("when a policy lists failure fingerprints", func() {
+		var fingerprint string
+		BeforeEach(func() {
+			fingerprint = types.Failure{Message: "assertion", Location: cl}.Fingerprint()
+			conf.RetryOn = []string{"timeout," + fingerprint}
+		})
+
+		It("retries failures with those fingerprints", func() {
+			Ω(reporter.Did.Find("assertion").FailureFingerprint).Should(Equal(fingerprint))
+			Ω(reporter.Did.Find("assertion")).Should(HaveFailed("assertion", NumAttempts(3)))
+			Ω(reporter.Did.Find("error")).Should(HaveFailed("dialing: connection refused", NumAttempts(1)))
+			Ω(reporter.Did.Find("timeout")).Should(HaveTimedOut(NumAttempts(2)))
+		})
+	})
+})
@@ -0,0 +1,69 @@
+package internal_integration_test
+
+import (
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/ginkgo/v2/internal/test_helpers"
+	"github.com/onsi/ginkgo/v2/types"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("the Retry decorator", func() {
+	var success bool
+	var runTimes []time.Time
+	JustBeforeEach(func() {
+		var counterA, counterB int
+		runTimes = []time.Time{}
+
+		success, _ = RunFixture("retry with backoff", func() {
+			It("A", Retry(3, 20*time.Millisecond), rt.T("A", func() {
+				runTimes = append(runTimes, time.Now())
+				counterA += 1
+				writer.Printf("A - attempt #%d\n", counterA)
+				if counterA < 3 {
+					F(fmt.Sprintf("A - %d", counterA))
+				}
+			}))
+			It("B", Retry(2, 10*time.Millisecond), rt.T("B", func() {
+				counterB += 1
+				F(fmt.Sprintf("B - %d", counterB))
+			}))
+		})
+	})
+
+	It("retries the spec, backing off exponentially between attempts", func() {
+		Ω(rt).Should(HaveTracked("A", "A", "A", "B", "B"))
+		Ω(runTimes).Should(HaveLen(3))
+		Ω(runTimes[1].Sub(runTimes[0])).Should(BeNumerically(">=", 20*time.Millisecond))
+		Ω(runTimes[2].Sub(runTimes[1])).Should(BeNumerically(">=", 40*time.Millisecond))
+		Ω(reporter.Did.Find("A")).Should(HavePassed(NumAttempts(3),
+			CapturedGinkgoWriterOutput("A - attempt #1\n\nGinkgo: Attempt #1 Failed.  Retrying after 20ms...\nA - attempt #2\n\nGinkgo: Attempt #2 Failed.  Retrying after 40ms...\nA - attempt #3\n")))
+	})
+
+	It("records the outcome and backoff of each attempt", func() {
+		attempts := reporter.Did.Find("A").Attempts
+		Ω(attempts).Should(HaveLen(3))
+		Ω(attempts[0]).Should(And(HaveField("State", types.SpecStateFailed), HaveField("Backoff", time.Duration(0)), HaveField("Failure.Message", "A - 1")))
+		Ω(attempts[1]).Should(And(HaveField("State", types.SpecStateFailed), HaveField("Backoff", 20*time.Millisecond), HaveField("Failure.Message", "A - 2")))
+		Ω(attempts[2]).Should(And(HaveField("State", types.SpecStatePassed), HaveField("Backoff", 40*time.Millisecond)))
+	})
+
+	It("fails the spec if it does not pass within the allotted attempts", func() {
+		Ω(success).Should(BeFalse())
+		Ω(reporter.Did.Find("B")).Should(HaveFailed("B - 2", NumAttempts(2)))
+		Ω(reporter.End).Should(BeASuiteSummary(NSpecs(2), NFailed(1), NPassed(1), NFlaked(1)))
+	})
+
+	Context("when --flake-attempts is set", func() {
+		BeforeEach(func() {
+			conf.FlakeAttempts = 4
+		})
+
+		It("overrides the number of attempts but keeps the backoff", func() {
+			Ω(reporter.Did.Find("B")).Should(HaveFailed("B - 4", NumAttempts(4)))
+			Ω(reporter.Did.Find("B").Attempts[3].Backoff).Should(Equal(40 * time.Millisecond))
+		})
+	})
+})
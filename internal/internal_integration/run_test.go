@@ -1,6 +1,7 @@
 package internal_integration_test
 
 import (
+	"runtime"
 	"time"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -80,6 +81,12 @@ var _ = Describe("Running Tests in Series - the happy path", func() {
 			))
 		})
 
+		It("captures the environment fingerprint", func() {
+			Ω(reporter.Begin.Environment.GoVersion).Should(Equal(runtime.Version()))
+			Ω(reporter.Begin.Environment.NumCPU).Should(Equal(runtime.NumCPU()))
+			Ω(reporter.End.Environment).Should(Equal(reporter.Begin.Environment))
+		})
+
 		It("reports the suite summary correctly when complete", func() {
 			Ω(reporter.End).Should(SatisfyAll(
 				HaveField("SuitePath", "/path/to/suite"),
@@ -0,0 +1,61 @@
+package internal_integration_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	. "github.com/onsi/ginkgo/v2/internal/test_helpers"
+	"github.com/onsi/ginkgo/v2/types"
+)
+
+var _ = Describe("Shared examples", func() {
+	BeforeEach(func() {
+		success, _ := RunFixture("shared examples", func() {
+			Describe("container", func() {
+				ItBehavesLike("a store", "memory", 1)
+				Describe("disk", func() {
+					BeforeEach(rt.T("disk-bef"))
+					ItBehavesLike("a store", "disk", 2, Label("slow"))
+				})
+				PItBehavesLike("a store", "tape", 3)
+			})
+			SharedExamples("a store", func(kind string, n int) {
+				var store string
+				BeforeEach(func() {
+					rt.Run("bef-" + kind)
+					store = kind
+				})
+				It("stores", func() {
+					rt.Run("stores-" + store)
+				})
+				It("counts", func() {
+					rt.Run("counts-" + store)
+					if n > 1 {
+						F("too many")
+					}
+				})
+			})
+		})
+		Ω(success).Should(BeFalse())
+	})
+
+	It("expands the shared examples into each container, passing in the arguments", func() {
+		Ω(rt).Should(HaveTracked(
+			"bef-memory", "stores-memory",
+			"bef-memory", "counts-memory",
+			"disk-bef", "bef-disk", "stores-disk",
+			"disk-bef", "bef-disk", "counts-disk",
+		))
+	})
+
+	It("nests the shared examples in a 'behaves like' container with any decorators passed to ItBehavesLike", func() {
+		specs := reporter.Did.WithLeafNodeType(types.NodeTypeIt)
+		Ω(specs).Should(HaveLen(6))
+		Ω(specs[0].ContainerHierarchyTexts).Should(Equal([]string{"container", "behaves like a store"}))
+		Ω(specs[2].ContainerHierarchyTexts).Should(Equal([]string{"container", "disk", "behaves like a store"}))
+		Ω(specs[2].Labels()).Should(Equal([]string{"slow"}))
+		Ω(specs[3]).Should(HaveFailed("too many"))
+		Ω(specs[4]).Should(BePending())
+		Ω(specs[5]).Should(BePending())
+	})
+})
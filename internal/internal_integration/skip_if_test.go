@@ -0,0 +1,47 @@
+package internal_integration_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/ginkgo/v2/internal/test_helpers"
+	"github.com/onsi/ginkgo/v2/types"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("SkipIf", func() {
+	BeforeEach(func() {
+		success, _ := RunFixture("skip if", func() {
+			Describe("container", SkipIf(func() bool { rt.Run("outer-condition"); return false }, "never"), func() {
+				BeforeEach(rt.T("bef"))
+				It("A", SkipIf(func() bool { rt.Run("A-condition"); return true }, "no docker"), rt.T("A"))
+				It("B", SkipIf(func() bool { rt.Run("B-condition"); return false }, "no docker"), rt.T("B"))
+				It("C", SkipIf(func() bool { panic("boom") }, "no docker"), rt.T("C"))
+				It("D", rt.T("D"))
+				AfterEach(rt.T("aft"))
+			})
+		})
+		Ω(success).Should(BeFalse())
+	})
+
+	It("evaluates the conditions, outermost first, just before each spec runs", func() {
+		Ω(rt).Should(HaveTracked(
+			"outer-condition", "A-condition",
+			"outer-condition", "B-condition", "bef", "B", "aft",
+			"outer-condition",
+			"outer-condition", "bef", "D", "aft",
+		))
+	})
+
+	It("skips specs whose condition is true, reporting the reason", func() {
+		specA := reporter.Did.Find("A")
+		Ω(specA).Should(HaveBeenSkippedWithMessage("no docker"))
+		Ω(reporter.Did.Find("B")).Should(HavePassed())
+		Ω(reporter.Did.Find("D")).Should(HavePassed())
+	})
+
+	It("reports conditions that panic", func() {
+		specC := reporter.Did.Find("C")
+		Ω(specC.State).Should(Equal(types.SpecStatePanicked))
+		Ω(specC.Failure.Message).Should(Equal("SkipIf condition panicked"))
+		Ω(specC.Failure.ForwardedPanic).Should(Equal("boom"))
+	})
+})
@@ -80,4 +80,86 @@ var _ = Describe("Skip", func() {
 			Ω(reporter.End.SpecialSuiteFailureReasons).Should(ContainElement("Suite skipped in BeforeSuite"))
 		})
 	})
+
+	Context("when SkipSuite() is called in BeforeSuite", func() {
+		BeforeEach(func() {
+			success, _ := RunFixture("SkipSuite() BeforeSuite", func() {
+				BeforeSuite(func() {
+					rt.Run("befs")
+					SkipSuite("no database available")
+				})
+				BeforeSuite(rt.T("befs-2"))
+				Describe("container to ensure order", func() {
+					It("A", rt.T("A"))
+					It("B", rt.T("B"))
+				})
+				AfterSuite(rt.T("afs"))
+			})
+
+			Ω(success).Should(BeTrue())
+		})
+
+		It("skips all the specs - and any remaining BeforeSuite nodes - but still runs AfterSuite", func() {
+			Ω(rt).Should(HaveTracked("befs", "afs"))
+			Ω(reporter.Did.FindByLeafNodeType(types.NodeTypeBeforeSuite)).Should(HaveBeenSkippedWithMessage("no database available"))
+			Ω(reporter.Did.Find("A")).Should(HaveBeenSkipped())
+			Ω(reporter.Did.Find("B")).Should(HaveBeenSkipped())
+		})
+
+		It("records the reason in the suite's report rather than as a failure reason", func() {
+			Ω(reporter.End).Should(BeASuiteSummary(true, NPassed(0), NSkipped(2), NSpecs(2), NWillRun(2)))
+			Ω(reporter.End.SuiteSkipReason).Should(Equal("no database available"))
+			Ω(reporter.End.SpecialSuiteFailureReasons).Should(BeEmpty())
+		})
+	})
+
+	Context("when SkipSuite() is called outside of BeforeSuite", func() {
+		It("fails the node", func() {
+			success, _ := RunFixture("SkipSuite() in It", func() {
+				It("A", func() {
+					SkipSuite("nope")
+				})
+				It("B", rt.T("B"))
+			})
+
+			Ω(success).Should(BeFalse())
+			Ω(reporter.Did.Find("A")).Should(HaveFailed("SkipSuite outside of BeforeSuite"))
+			Ω(reporter.Did.Find("B")).Should(HavePassed())
+			Ω(reporter.End.SuiteSkipReason).Should(BeEmpty())
+		})
+	})
+
+	Context("when SkipSuite() is called in SynchronizedBeforeSuite while running in parallel", func() {
+		BeforeEach(func() {
+			SetUpForParallel(2)
+		})
+
+		fixture := func() {
+			SynchronizedBeforeSuite(func() []byte {
+				rt.Run("sbs-proc-1")
+				SkipSuite("no database available")
+				return nil
+			}, func(_ []byte) {
+				rt.Run("sbs-all-procs")
+			})
+			It("A", rt.T("A"))
+		}
+
+		It("reports the reason on process #1", func() {
+			success, _ := RunFixture("SkipSuite() in SBS on process 1", fixture)
+			Ω(success).Should(BeTrue())
+			Ω(rt).Should(HaveTracked("sbs-proc-1"))
+			Ω(reporter.End.SuiteSkipReason).Should(Equal("no database available"))
+		})
+
+		It("shares the reason with the other processes", func() {
+			conf.ParallelProcess = 2
+			client.PostSynchronizedBeforeSuiteCompleted(types.SpecStateSkipped, []byte("no database available"))
+			success, _ := RunFixture("SkipSuite() in SBS on process 2", fixture)
+			Ω(success).Should(BeTrue())
+			Ω(rt).Should(HaveTrackedNothing())
+			Ω(reporter.Did.Find("A")).Should(HaveBeenSkipped())
+			Ω(reporter.End.SuiteSkipReason).Should(Equal("no database available"))
+		})
+	})
 })
@@ -0,0 +1,44 @@
+package internal_integration_test
+
+import (
+	"path/filepath"
+	"runtime"
+	"sort"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	. "github.com/onsi/ginkgo/v2/internal/test_helpers"
+	"github.com/onsi/ginkgo/v2/types"
+)
+
+var _ = Describe("Recording the source files of each spec", func() {
+	var thisFile string
+	helperLocation := types.CodeLocation{FileName: "/src/shared/helpers.go", LineNumber: 12}
+
+	BeforeEach(func() {
+		_, thisFile, _, _ = runtime.Caller(0)
+		thisFile = filepath.Clean(thisFile)
+
+		success, _ := RunFixture("source files", func() {
+			Describe("container", func() {
+				BeforeEach(rt.T("bef"), helperLocation)
+				It("A", rt.T("A"))
+			})
+			It("B", rt.T("B"))
+			It("C", Pending, rt.T("C"))
+		})
+		Ω(success).Should(BeTrue())
+		Ω(rt).Should(HaveTracked("bef", "A", "B"))
+	})
+
+	It("records the files that define the spec's containers, setup nodes, and subject", func() {
+		Ω(reporter.Did.Find("A").SourceFiles).Should(ConsistOf("/src/shared/helpers.go", thisFile))
+		Ω(sort.StringsAreSorted(reporter.Did.Find("A").SourceFiles)).Should(BeTrue())
+		Ω(reporter.Did.Find("B").SourceFiles).Should(Equal([]string{thisFile}))
+	})
+
+	It("records them for specs that do not run", func() {
+		Ω(reporter.Did.Find("C").SourceFiles).Should(Equal([]string{thisFile}))
+	})
+})
@@ -0,0 +1,104 @@
+package internal_integration_test
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	"github.com/onsi/ginkgo/v2/internal/interrupt_handler"
+	. "github.com/onsi/ginkgo/v2/internal/test_helpers"
+	"github.com/onsi/ginkgo/v2/types"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Nodes that accept a SpecContext", func() {
+	BeforeEach(func() {
+		conf.GracePeriod = time.Second
+	})
+
+	It("passes in a SpecContext that provides the current SpecReport", func() {
+		var text string
+		success, _ := RunFixture("spec context", func() {
+			BeforeEach(func(ctx SpecContext) {
+				rt.Run("bef")
+				Ω(ctx.Err()).Should(BeNil())
+			})
+			It("A", func(ctx SpecContext) {
+				rt.Run("A")
+				text = ctx.SpecReport().LeafNodeText
+			})
+			AfterEach(func(ctx context.Context) {
+				rt.Run("aft")
+				Ω(ctx.Err()).Should(BeNil())
+			})
+		})
+		Ω(success).Should(BeTrue())
+		Ω(rt).Should(HaveTracked("bef", "A", "aft"))
+		Ω(text).Should(Equal("A"))
+	})
+
+	Describe("when the suite is interrupted", func() {
+		BeforeEach(func() {
+			success, _ := RunFixture("interrupted spec context", func() {
+				It("A", func(ctx SpecContext) {
+					rt.Run("A")
+					interruptHandler.Interrupt(interrupt_handler.InterruptCauseSignal)
+					<-ctx.Done()
+					rt.Run("A-cleanup")
+				})
+				It("B", rt.T("B"))
+			})
+			Ω(success).Should(BeFalse())
+		})
+
+		It("cancels the context and waits for the node to clean up before moving on", func() {
+			Ω(rt).Should(HaveTracked("A", "A-cleanup"))
+			Ω(reporter.Did.Find("A")).Should(HaveBeenInterrupted(interrupt_handler.InterruptCauseSignal))
+			Ω(reporter.Did.Find("B")).Should(HaveBeenSkipped())
+		})
+	})
+
+	Describe("when a node times out", func() {
+		var ctxErr error
+		BeforeEach(func() {
+			success, _ := RunFixture("timed out spec context", func() {
+				BeforeSuite(func(ctx context.Context) {
+					rt.Run("before-suite")
+					<-ctx.Done()
+					ctxErr = ctx.Err()
+					rt.Run("before-suite-cleanup")
+				}, NodeTimeout(50*time.Millisecond))
+				It("A", rt.T("A"))
+			})
+			Ω(success).Should(BeFalse())
+		})
+
+		It("cancels the context when the deadline passes", func() {
+			Ω(rt).Should(HaveTracked("before-suite", "before-suite-cleanup"))
+			Ω(ctxErr).Should(Equal(context.DeadlineExceeded))
+			Ω(reporter.Did.FindByLeafNodeType(types.NodeTypeBeforeSuite)).Should(HaveTimedOut(ContainSubstring("BeforeSuite timed out after 50ms")))
+		})
+	})
+
+	Describe("when a node does not exit within the grace period", func() {
+		var hang chan interface{}
+		BeforeEach(func() {
+			hang = make(chan interface{})
+			DeferCleanup(func() { close(hang) })
+			conf.GracePeriod = 50 * time.Millisecond
+			success, _ := RunFixture("stuck spec context", func() {
+				BeforeSuite(func(ctx SpecContext) {
+					rt.Run("before-suite")
+					<-hang
+				}, NodeTimeout(50*time.Millisecond))
+				It("A", rt.T("A"))
+			})
+			Ω(success).Should(BeFalse())
+		})
+
+		It("gives up on the node and says so in the failure", func() {
+			Ω(rt).Should(HaveTracked("before-suite"))
+			Ω(reporter.Did.FindByLeafNodeType(types.NodeTypeBeforeSuite)).Should(HaveTimedOut(ContainSubstring("BeforeSuite did not exit within the 50ms grace period after its SpecContext was cancelled")))
+		})
+	})
+})
@@ -0,0 +1,110 @@
+package internal_integration_test
+
+import (
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	"github.com/onsi/ginkgo/v2/internal"
+	. "github.com/onsi/gomega"
+
+	. "github.com/onsi/ginkgo/v2/internal/test_helpers"
+	"github.com/onsi/ginkgo/v2/types"
+)
+
+func RunFixtureWithSpecNamingRules(rules SpecNamingRules, description string, callback func()) (bool, error) {
+	suite := internal.NewSuite()
+	suite.SetSpecNamingRules(rules)
+	var success bool
+	var err error
+	WithSuite(suite, func() {
+		callback()
+		err = suite.BuildTree()
+		if err != nil {
+			return
+		}
+		success, _ = suite.Run(description, Label("TopLevelLabel"), "/path/to/suite", failer, reporter, writer, outputInterceptor, interruptHandler, client, conf)
+	})
+	return success, err
+}
+
+var _ = Describe("SpecNamingRules", func() {
+	var rules SpecNamingRules
+	BeforeEach(func() {
+		rules = SpecNamingRules{ItPattern: `^should `, ContainerPattern: `^[A-Z]`, MaxLength: 30}
+	})
+
+	Context("when every text follows the rules", func() {
+		It("runs the suite", func() {
+			success, err := RunFixtureWithSpecNamingRules(rules, "naming", func() {
+				Describe("Books", func() {
+					It("should have a title", rt.T("A"))
+					DescribeTable("Authors", func(name string) { rt.Run(name) },
+						Entry("should split first names", "first"),
+					)
+				})
+			})
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(success).Should(BeTrue())
+			Ω(rt).Should(HaveTracked("A", "first"))
+		})
+	})
+
+	Context("when texts break the rules", func() {
+		It("reports every violation, with its location, and runs nothing", func() {
+			_, err := RunFixtureWithSpecNamingRules(rules, "naming", func() {
+				Describe("books", func() {
+					It("has a title", rt.T("A"))
+					It("should have an author with a very long name", rt.T("B"))
+					It("should have pages", rt.T("C"))
+					DescribeTable("Authors", func(name string) { rt.Run(name) },
+						Entry("splits first names", "first"),
+					)
+				})
+			})
+			Ω(rt).Should(HaveTrackedNothing())
+
+			Ω(err).Should(BeAssignableToTypeOf(types.SpecStructureErrors{}))
+			errs := err.(types.SpecStructureErrors)
+			Ω(errs).Should(HaveLen(4))
+			messages := []string{}
+			for _, e := range errs {
+				Ω(e.(types.GinkgoError).Heading).Should(Equal("Spec Naming Violation"))
+				Ω(e.(types.GinkgoError).CodeLocation.FileName).Should(HaveSuffix("spec_naming_test.go"))
+				messages = append(messages, e.(types.GinkgoError).Message)
+			}
+			Ω(messages).Should(ConsistOf(
+				ContainSubstring(`[Container] node has the text "books" but, according to the SpecNamingRules passed to RunSpecs, its text must match /^[A-Z]/.`),
+				ContainSubstring(`[It] node has the text "has a title" but, according to the SpecNamingRules passed to RunSpecs, its text must match /^should /.`),
+				ContainSubstring(`its text must be at most 30 characters long - it is 43.`),
+				ContainSubstring(`[It] node has the text "splits first names"`),
+			))
+		})
+
+		It("combines the violations of a single node", func() {
+			_, err := RunFixtureWithSpecNamingRules(rules, "naming", func() {
+				It(strings.Repeat("x", 31), rt.T("A"))
+			})
+			Ω(err).Should(HaveOccurred())
+			Ω(err.(types.GinkgoError).Message).Should(ContainSubstring("its text must match /^should / and must be at most 30 characters long - it is 31."))
+		})
+	})
+
+	It("only enforces the rules that are set", func() {
+		success, err := RunFixtureWithSpecNamingRules(SpecNamingRules{MaxLength: 5}, "naming", func() {
+			Describe("books", func() {
+				It("A", rt.T("A"))
+			})
+		})
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(success).Should(BeTrue())
+	})
+
+	It("errors when a pattern is not a valid regular expression", func() {
+		_, err := RunFixtureWithSpecNamingRules(SpecNamingRules{ContainerPattern: `(`}, "naming", func() {
+			It("A", rt.T("A"))
+		})
+		Ω(err).Should(HaveOccurred())
+		Ω(err.(types.GinkgoError).Heading).Should(Equal("Invalid SpecNamingRules"))
+		Ω(rt).Should(HaveTrackedNothing())
+	})
+})
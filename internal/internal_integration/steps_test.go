@@ -0,0 +1,123 @@
+package internal_integration_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/ginkgo/v2/internal/test_helpers"
+	"github.com/onsi/ginkgo/v2/types"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Steps", func() {
+	stepSummaries := func(steps []types.SpecStep) []string {
+		out := []string{}
+		for _, step := range steps {
+			out = append(out, step.Text+":"+step.State.String())
+		}
+		return out
+	}
+
+	Describe("when steps pass and fail", func() {
+		BeforeEach(func() {
+			attempts := 0
+			success, _ := RunFixture("steps", func() {
+				Describe("container", func() {
+					BeforeEach(func() {
+						Step("setup", rt.T("setup"))
+					})
+					It("A", func() {
+						Step("log in", rt.T("log in"))
+						Step("check out", func() {
+							Step("find the book", rt.T("find the book"))
+							Step("confirm the loan", func() {
+								rt.Run("confirm the loan")
+								F("no loans today")
+							})
+							Step("never runs", rt.T("never runs"))
+						})
+					})
+					It("B", func() {
+						Step("panics", func() {
+							panic("boom")
+						})
+					})
+					It("C", FlakeAttempts(2), func() {
+						attempts += 1
+						Step("flaky", func() {
+							if attempts == 1 {
+								F("flake")
+							}
+						})
+					})
+				})
+			})
+			Ω(success).Should(BeFalse())
+		})
+
+		It("runs the steps immediately", func() {
+			Ω(rt).Should(HaveTracked("setup", "log in", "find the book", "confirm the loan", "setup", "setup", "setup"))
+		})
+
+		It("records each step, its nesting, and its outcome in the order the steps started", func() {
+			specA := reporter.Did.Find("A")
+			Ω(specA).Should(HaveFailed("no loans today"))
+			Ω(stepSummaries(specA.Steps)).Should(Equal([]string{"setup:passed", "log in:passed", "check out:failed", "find the book:passed", "confirm the loan:failed"}))
+			Ω(specA.Steps[2].NestingLevel).Should(Equal(0))
+			Ω(specA.Steps[3].NestingLevel).Should(Equal(1))
+			Ω(specA.Steps[4].NestingLevel).Should(Equal(1))
+			Ω(specA.Steps[2].Message).Should(Equal("no loans today"))
+			Ω(specA.Steps[4].Message).Should(Equal("no loans today"))
+			for _, step := range specA.Steps {
+				Ω(step.EndTime).ShouldNot(BeTemporally("<", step.StartTime))
+				Ω(step.Location.FileName).Should(HaveSuffix("steps_test.go"))
+			}
+		})
+
+		It("records panics", func() {
+			specB := reporter.Did.Find("B")
+			Ω(specB).Should(HavePanicked("boom"))
+			Ω(stepSummaries(specB.Steps)).Should(Equal([]string{"setup:passed", "panics:panicked"}))
+			Ω(specB.Steps[1].Message).Should(Equal("boom"))
+		})
+
+		It("only records the steps from the last attempt", func() {
+			specC := reporter.Did.Find("C")
+			Ω(specC).Should(HavePassed())
+			Ω(stepSummaries(specC.Steps)).Should(Equal([]string{"setup:passed", "flaky:passed"}))
+		})
+	})
+
+	Describe("when a spec times out while a step is running", func() {
+		BeforeEach(func() {
+			conf.GracePeriod = 50 * time.Millisecond
+			success, _ := RunFixture("abandoned steps", func() {
+				It("A", NodeTimeout(time.Millisecond*50), func(ctx SpecContext) {
+					Step("hangs", func() {
+						<-ctx.Done()
+						time.Sleep(time.Millisecond * 200)
+					})
+				})
+			})
+			Ω(success).Should(BeFalse())
+		})
+
+		It("gives the step the spec's state", func() {
+			specA := reporter.Did.Find("A")
+			Ω(specA.State).Should(Equal(types.SpecStateTimedout))
+			Ω(stepSummaries(specA.Steps)).Should(Equal([]string{"hangs:timedout"}))
+		})
+	})
+
+	It("can be used in suite-level nodes", func() {
+		success, _ := RunFixture("steps in suite nodes", func() {
+			BeforeSuite(func() {
+				Step("in a suite node", rt.T("in a suite node"))
+			})
+			It("A", func() {})
+		})
+		Ω(success).Should(BeTrue())
+		Ω(rt).Should(HaveTracked("in a suite node"))
+		Ω(stepSummaries(reporter.Did.FindByLeafNodeType(types.NodeTypeBeforeSuite).Steps)).Should(Equal([]string{"in a suite node:passed"}))
+	})
+})
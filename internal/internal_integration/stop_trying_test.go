@@ -0,0 +1,86 @@
+package internal_integration_test
+
+import (
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/ginkgo/v2/internal/test_helpers"
+	"github.com/onsi/ginkgo/v2/types"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("GinkgoStopTrying", func() {
+	var pollUntilReady func(attempts *int, readyOn int) error
+	BeforeEach(func() {
+		pollUntilReady = func(attempts *int, readyOn int) error {
+			*attempts += 1
+			if *attempts < readyOn {
+				return fmt.Errorf("polling: %w", GinkgoStopTrying(fmt.Sprintf("not ready after attempt %d", *attempts)))
+			}
+			return nil
+		}
+	})
+
+	Context("when the spec is not eligible to be retried", func() {
+		BeforeEach(func() {
+			var attempts int
+			success, _ := RunFixture("stop trying skips", func() {
+				Context("container", func() {
+					It("A", rt.T("A", func() {
+						if err := pollUntilReady(&attempts, 2); err != nil {
+							panic(err)
+						}
+						rt.Run("A-ready")
+					}))
+					It("B", rt.T("B", func() {
+						GinkgoStopTrying("B gave up").Now()
+					}))
+					It("C", rt.T("C"))
+				})
+			})
+			Ω(success).Should(BeTrue())
+		})
+
+		It("skips the spec with the signal's message", func() {
+			Ω(rt).Should(HaveTracked("A", "B", "C"))
+			Ω(reporter.Did.Find("A")).Should(HaveBeenSkippedWithMessage("polling: not ready after attempt 1"))
+			Ω(reporter.Did.Find("B")).Should(HaveBeenSkippedWithMessage("B gave up"))
+			Ω(reporter.Did.Find("B").Failure.Location.FileName).Should(HaveSuffix("stop_trying_test.go"))
+			Ω(reporter.Did.Find("C")).Should(HavePassed())
+			Ω(reporter.End).Should(BeASuiteSummary(true, NSpecs(3), NPassed(1), NSkipped(2)))
+		})
+	})
+
+	Context("when the spec is decorated with FlakeAttempts", func() {
+		BeforeEach(func() {
+			var attemptsA, attemptsB int
+			success, _ := RunFixture("stop trying retries", func() {
+				Context("container", func() {
+					It("A", FlakeAttempts(3), rt.T("A", func() {
+						if err := pollUntilReady(&attemptsA, 2); err != nil {
+							panic(err)
+						}
+						rt.Run("A-ready")
+					}))
+					It("B", FlakeAttempts(2), rt.T("B", func() {
+						if err := pollUntilReady(&attemptsB, 5); err != nil {
+							panic(err)
+						}
+						rt.Run("B-ready")
+					}))
+				})
+			})
+			Ω(success).Should(BeTrue())
+		})
+
+		It("retries the spec while it has attempts remaining", func() {
+			Ω(rt).Should(HaveTracked("A", "A", "A-ready", "B", "B"))
+			Ω(reporter.Did.Find("A")).Should(HavePassed(NumAttempts(2)))
+			Ω(reporter.Did.Find("A").Attempts[0].State).Should(Equal(types.SpecStateFailed))
+		})
+
+		It("skips the spec on its final attempt", func() {
+			Ω(reporter.Did.Find("B")).Should(HaveBeenSkippedWithMessage("polling: not ready after attempt 2", NumAttempts(2)))
+		})
+	})
+})
@@ -0,0 +1,121 @@
+package internal_integration_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	. "github.com/onsi/ginkgo/v2/internal/test_helpers"
+	"github.com/onsi/ginkgo/v2/types"
+)
+
+var _ = Describe("Suite node timeouts", func() {
+	Describe("when a BeforeSuite decorated with NodeTimeout does not complete in time", func() {
+		BeforeEach(func() {
+			// the timed out node's goroutine is abandoned, so it gets a channel of its own that is closed once the spec is done
+			hang := make(chan interface{})
+			DeferCleanup(func() { close(hang) })
+			success, _ := RunFixture("hung before suite", func() {
+				BeforeSuite(func() {
+					rt.Run("before-suite")
+					writer.Println("connecting to the database")
+					<-hang
+				}, NodeTimeout(50*time.Millisecond))
+				It("A", rt.T("A"))
+				It("B", rt.T("B"))
+				AfterSuite(rt.T("after-suite"))
+			})
+			Ω(success).Should(BeFalse())
+		})
+
+		It("fails the BeforeSuite, skips the specs, and still runs the AfterSuite", func() {
+			Ω(rt).Should(HaveTracked("before-suite", "after-suite"))
+			Ω(reporter.End).Should(BeASuiteSummary(false, NSpecs(2), NPassed(0), NFailed(0)))
+		})
+
+		It("reports the timeout along with stack traces and the node's output", func() {
+			beforeSuite := reporter.Did.FindByLeafNodeType(types.NodeTypeBeforeSuite)
+			Ω(beforeSuite).Should(HaveTimedOut(ContainSubstring("BeforeSuite timed out after 50ms"), CapturedGinkgoWriterOutput("connecting to the database\n")))
+			Ω(beforeSuite.Failure.Message).Should(ContainSubstring("Here's a stack trace of all running goroutines:"))
+			Ω(beforeSuite.Failure.Location).Should(Equal(beforeSuite.LeafNodeLocation))
+		})
+	})
+
+	Describe("when a suite node that does not accept a SpecContext times out and keeps running", func() {
+		BeforeEach(func() {
+			release := make(chan interface{})
+			success, _ := RunFixture("abandoned after suite", func() {
+				It("A", rt.T("A"))
+				AfterSuite(func() {
+					rt.Run("after-suite-A")
+					<-release
+					rt.Run("after-suite-A-late")
+					Fail("after-suite-A's late failure")
+				}, NodeTimeout(50*time.Millisecond))
+				AfterSuite(func() {
+					defer func() {
+						// let the abandoned AfterSuite report its failure and finish while this one is running
+						close(release)
+						time.Sleep(100 * time.Millisecond)
+					}()
+					rt.Run("after-suite-B")
+					Fail("after-suite-B's failure")
+				})
+			})
+			Ω(success).Should(BeFalse())
+		})
+
+		It("ignores what the abandoned node reports so that it can't change the outcome of the nodes that follow", func() {
+			Ω(rt).Should(HaveTracked("A", "after-suite-A", "after-suite-B", "after-suite-A-late"))
+			afterSuites := reporter.Did.WithLeafNodeType(types.NodeTypeAfterSuite)
+			Ω(afterSuites).Should(HaveLen(2))
+			Ω(afterSuites[0]).Should(HaveTimedOut(ContainSubstring("AfterSuite timed out after 50ms")))
+			Ω(afterSuites[1]).Should(HaveFailed("after-suite-B's failure"))
+		})
+	})
+
+	Describe("when --suite-node-timeout is set", func() {
+		BeforeEach(func() {
+			conf.SuiteNodeTimeout = 50 * time.Millisecond
+		})
+
+		It("applies the timeout to suite setup and cleanup nodes", func() {
+			hang := make(chan interface{})
+			DeferCleanup(func() { close(hang) })
+			success, _ := RunFixture("hung synchronized after suite", func() {
+				It("A", rt.T("A"))
+				SynchronizedAfterSuite(func() {
+					rt.Run("all-procs")
+					<-hang
+				}, rt.T("proc-1"))
+			})
+			Ω(success).Should(BeFalse())
+			Ω(rt).Should(HaveTracked("A", "all-procs", "proc-1"))
+			Ω(reporter.Did.FindByLeafNodeType(types.NodeTypeSynchronizedAfterSuite)).Should(HaveTimedOut(ContainSubstring("SynchronizedAfterSuite timed out after 50ms")))
+		})
+
+		It("does not apply the timeout to specs", func() {
+			success, _ := RunFixture("slow spec", func() {
+				It("A", func() {
+					time.Sleep(100 * time.Millisecond)
+					rt.Run("A")
+				})
+			})
+			Ω(success).Should(BeTrue())
+			Ω(rt).Should(HaveTracked("A"))
+		})
+
+		It("is overridden by the NodeTimeout decorator", func() {
+			success, _ := RunFixture("slow before suite", func() {
+				BeforeSuite(func() {
+					time.Sleep(100 * time.Millisecond)
+					rt.Run("before-suite")
+				}, NodeTimeout(time.Minute))
+				It("A", rt.T("A"))
+			})
+			Ω(success).Should(BeTrue())
+			Ω(rt).Should(HaveTracked("before-suite", "A"))
+		})
+	})
+})
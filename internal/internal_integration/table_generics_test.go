@@ -0,0 +1,79 @@
+//go:build go1.21
+// +build go1.21
+
+package internal_integration_test
+
+import (
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	"github.com/onsi/ginkgo/v2/dsl/table"
+	. "github.com/onsi/gomega"
+
+	. "github.com/onsi/ginkgo/v2/internal/test_helpers"
+	"github.com/onsi/ginkgo/v2/types"
+)
+
+var _ = Describe("Type-safe table driven tests", func() {
+	type addition struct {
+		a, b, expected int
+	}
+
+	Context("when used via the ginkgo package", func() {
+		BeforeEach(func() {
+			success, _ := RunFixture("typed table", func() {
+				DescribeTableT("addition", func(c addition) {
+					rt.Run(CurrentSpecReport().LeafNodeText)
+					if c.a+c.b != c.expected {
+						F(fmt.Sprintf("%d + %d != %d", c.a, c.b, c.expected))
+					}
+				},
+					EntryT("A", addition{1, 2, 3}),
+					EntryT("B", addition{1, 1, 3}, Label("wrong")),
+					EntryT("", addition{2, 2, 4}),
+					PEntryT("D", addition{0, 0, 0}),
+				)
+				DescribeTableT("labels", func(labels Labels) {
+					rt.Run(fmt.Sprint(labels))
+				},
+					EntryT("E", Labels{"passed", "to", "the", "body"}),
+				)
+				PDescribeTableT("pending table", func(s string) { rt.Run(s) },
+					EntryT("F", "f"),
+				)
+			})
+			Ω(success).Should(BeFalse())
+		})
+
+		It("runs each entry with its parameter", func() {
+			Ω(rt).Should(HaveTracked("A", "B", "Entry: {2 2 4}", "[passed to the body]"))
+		})
+
+		It("names entries without a description after their parameter", func() {
+			Ω(reporter.Did.Find("Entry: {2 2 4}")).Should(HavePassed())
+		})
+
+		It("applies the entries' decorators", func() {
+			Ω(reporter.Did.Find("B")).Should(HaveFailed("1 + 1 != 3"))
+			Ω(reporter.Did.Find("B").Labels()).Should(ConsistOf("wrong"))
+			Ω(reporter.Did.Find("D")).Should(BePending())
+			Ω(reporter.Did.Find("F")).Should(BePending())
+			Ω(reporter.Did.Find("A").LeafNodeType).Should(Equal(types.NodeTypeIt))
+			Ω(reporter.Did.Find("A").LeafNodeLocation.FileName).Should(HaveSuffix("table_generics_test.go"))
+		})
+	})
+
+	Context("when used via the dsl/table package", func() {
+		It("locates the table and its entries at the caller", func() {
+			success, _ := RunFixture("dsl typed table", func() {
+				table.DescribeTableT("dsl", func(s string) { rt.Run(s) },
+					table.EntryT("G", "g"),
+				)
+			})
+			Ω(success).Should(BeTrue())
+			Ω(rt).Should(HaveTracked("g"))
+			Ω(reporter.Did.Find("G").LeafNodeLocation.FileName).Should(HaveSuffix("table_generics_test.go"))
+			Ω(reporter.Did.Find("G").ContainerHierarchyLocations[0].FileName).Should(HaveSuffix("table_generics_test.go"))
+		})
+	})
+})
@@ -2,7 +2,10 @@ package internal_integration_test
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
 	"strings"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -400,6 +403,124 @@ var _ = Describe("Table driven tests", func() {
 		})
 	})
 
+	Describe("entries with synthetic code locations", func() {
+		BeforeEach(func() {
+			success, _ := RunFixture("table with synthetic code locations", func() {
+				DescribeTable("data-driven", bodyFunc,
+					Entry("A", 1, 1, types.NewSyntheticCodeLocation("testdata/cases.csv", 2)),
+					Entry("B", 1, 2, types.NewSyntheticCodeLocation("testdata/cases.csv", 3)),
+				)
+			})
+			Ω(success).Should(BeFalse())
+		})
+
+		It("uses the synthetic code location as the location of the entry's spec", func() {
+			Ω(reporter.Did.Find("A").LeafNodeLocation).Should(Equal(types.NewSyntheticCodeLocation("testdata/cases.csv", 2)))
+			Ω(reporter.Did.Find("B").LeafNodeLocation).Should(Equal(types.NewSyntheticCodeLocation("testdata/cases.csv", 3)))
+		})
+
+		It("attributes failures in the entry to the synthetic code location", func() {
+			Ω(reporter.Did.Find("B")).Should(HaveFailed("fail", types.NodeTypeIt, types.FailureNodeIsLeafNode))
+			Ω(reporter.Did.Find("B").Failure.FailureNodeLocation).Should(Equal(types.NewSyntheticCodeLocation("testdata/cases.csv", 3)))
+		})
+	})
+
+	Describe("loading entries from fixture files", func() {
+		var dir string
+		writeFixture := func(name string, content string) string {
+			path := filepath.Join(dir, name)
+			Ω(os.WriteFile(path, []byte(content), 0644)).Should(Succeed())
+			return path
+		}
+
+		type point struct {
+			X, Y int
+		}
+
+		BeforeEach(func() {
+			var err error
+			dir, err = os.MkdirTemp("", "ginkgo-table-fixtures")
+			Ω(err).ShouldNot(HaveOccurred())
+			DeferCleanup(os.RemoveAll, dir)
+
+			jsonFixture := writeFixture("entries.json", `[
+				{"description": "json-A", "parameters": [1, "a", {"X": 1, "Y": 2}, 1.5, 2.5]},
+				[2, "b", {"X": 3}],
+				{"description": "json-wrong-type", "parameters": ["one", "c", {}]}
+			]`)
+			csvFixture := writeFixture("entries.csv", "a,b,description,p,floats\n"+
+				"1,a,csv-A,\"{\"\"X\"\": 1, \"\"Y\"\": 2}\",1.5\n"+
+				"2,b,,{},\n"+
+				"one,c,csv-wrong-type,{},\n")
+
+			success, _ := RunFixture("table with fixtures", func() {
+				DescribeTable("fixtures", func(a int, b string, p point, floats ...float64) {
+					rt.RunWithData(CurrentSpecReport().LeafNodeText, "a", a, "b", b, "p", p, "floats", floats)
+				},
+					func(a int, b string, p point, floats ...float64) string {
+						return fmt.Sprintf("generated %d %s", a, b)
+					},
+					EntriesFromJSON(jsonFixture, Label("json")),
+					EntriesFromCSV(csvFixture, Label("csv")),
+					Entry("inline", 3, "c", point{}),
+				)
+			})
+			Ω(success).Should(BeFalse())
+		})
+
+		It("generates an entry for each element in the fixture files", func() {
+			Ω(reporter.Did.Names()).Should(Equal([]string{"json-A", "generated 2 b", "json-wrong-type", "csv-A", "generated 2 b", "csv-wrong-type", "inline"}))
+			Ω(rt).Should(HaveTracked("json-A", "generated 2 b", "csv-A", "generated 2 b", "inline"))
+		})
+
+		It("decodes the parameters into the types the body expects", func() {
+			Ω(rt.DataFor("json-A")).Should(Equal(map[string]interface{}{"a": 1, "b": "a", "p": point{X: 1, Y: 2}, "floats": []float64{1.5, 2.5}}))
+			Ω(rt.DataFor("csv-A")).Should(Equal(map[string]interface{}{"a": 1, "b": "a", "p": point{X: 1, Y: 2}, "floats": []float64{1.5}}))
+		})
+
+		It("applies the decorators to every entry loaded from the fixture", func() {
+			Ω(reporter.Did.Find("json-A").Labels()).Should(ConsistOf("json"))
+			Ω(reporter.Did.Find("csv-A").Labels()).Should(ConsistOf("csv"))
+			Ω(reporter.Did.Find("inline").Labels()).Should(BeEmpty())
+		})
+
+		It("reports entries whose parameters cannot be decoded as having panicked", func() {
+			Ω(reporter.Did.Find("json-wrong-type")).Should(HavePanicked("Parameter #1 loaded from the fixture file could not be converted to <int>"))
+			Ω(reporter.Did.Find("csv-wrong-type")).Should(HavePanicked("Parameter #1 loaded from the fixture file could not be converted to <int>"))
+		})
+	})
+
+	Describe("loading entries from a fuzz corpus", func() {
+		var corpus string
+		BeforeEach(func() {
+			var err error
+			corpus, err = os.MkdirTemp("", "ginkgo-fuzz-corpus")
+			Ω(err).ShouldNot(HaveOccurred())
+			DeferCleanup(os.RemoveAll, corpus)
+			Ω(os.WriteFile(filepath.Join(corpus, "1a2b"), []byte("go test fuzz v1\n[]byte(\"abc\")\nint(3)\n"), 0644)).Should(Succeed())
+			Ω(os.WriteFile(filepath.Join(corpus, "9f8e"), []byte("go test fuzz v1\n[]byte(\"\\x00\")\nint(-1)\n"), 0644)).Should(Succeed())
+			Ω(os.Mkdir(filepath.Join(corpus, "ignored-directory"), 0755)).Should(Succeed())
+
+			success, _ := RunFixture("table with fuzz corpus", func() {
+				DescribeTable("fuzz regressions", func(input []byte, n int) {
+					rt.RunWithData(CurrentSpecReport().LeafNodeText, "input", input, "n", n)
+					if n < 0 {
+						F("negative")
+					}
+				}, EntriesFromFuzzCorpus(corpus, Label("fuzz")))
+			})
+			Ω(success).Should(BeFalse())
+		})
+
+		It("generates an entry named after each corpus file, passing it the file's values", func() {
+			Ω(reporter.Did.Names()).Should(Equal([]string{"1a2b", "9f8e"}))
+			Ω(rt.DataFor("1a2b")).Should(Equal(map[string]interface{}{"input": []byte("abc"), "n": 3}))
+			Ω(rt.DataFor("9f8e")).Should(Equal(map[string]interface{}{"input": []byte{0}, "n": -1}))
+			Ω(reporter.Did.Find("9f8e")).Should(HaveFailed("negative"))
+			Ω(reporter.Did.Find("1a2b").Labels()).Should(ConsistOf("fuzz"))
+		})
+	})
+
 	Describe("support for decorators", func() {
 		BeforeEach(func() {
 			success, _ := RunFixture("flaky table", func() {
@@ -441,4 +562,387 @@ var _ = Describe("Table driven tests", func() {
 			Ω(reporter.Did.Find("D")).Should(HavePassed(NumAttempts(3)))
 		})
 	})
+
+	Describe("generating combinations of entries", func() {
+		var combinations [][]interface{}
+		var runTable = func(entries []TableEntry) {
+			combinations = [][]interface{}{}
+			success, _ := RunFixture("combinatorial table", func() {
+				DescribeTable("combinations", func(params ...interface{}) {
+					combinations = append(combinations, params)
+				}, entries)
+			})
+			Ω(success).Should(BeTrue())
+		}
+
+		Describe("CombinatorialEntries", func() {
+			It("generates the cartesian product of the dimensions, with the first dimension varying slowest", func() {
+				runTable(CombinatorialEntries([]interface{}{"linux", "darwin"}, []interface{}{1, 2, 3}))
+				Ω(combinations).Should(Equal([][]interface{}{
+					{"linux", 1}, {"linux", 2}, {"linux", 3},
+					{"darwin", 1}, {"darwin", 2}, {"darwin", 3},
+				}))
+				Ω(reporter.Did.Names()).Should(ContainElement("Entry: darwin, 2"))
+			})
+
+			It("generates nothing if a dimension is empty", func() {
+				Ω(CombinatorialEntries([]interface{}{"linux"}, []interface{}{})).Should(BeEmpty())
+				Ω(CombinatorialEntries()).Should(BeEmpty())
+			})
+		})
+
+		Describe("PairwiseEntries", func() {
+			var dimensions [][]interface{}
+			BeforeEach(func() {
+				dimensions = [][]interface{}{
+					{"linux", "darwin", "windows"},
+					{"amd64", "arm64", "386"},
+					{"go1.20", "go1.21", "go1.22"},
+					{true, false},
+					{"sqlite", "postgres", "mysql", "mssql"},
+				}
+			})
+
+			It("covers every pair of values from any two dimensions with far fewer entries than the full product", func() {
+				runTable(PairwiseEntries(dimensions...))
+				Ω(len(combinations)).Should(BeNumerically("<=", 20))
+
+				for i := range dimensions {
+					for j := i + 1; j < len(dimensions); j++ {
+						for _, a := range dimensions[i] {
+							for _, b := range dimensions[j] {
+								Ω(combinations).Should(ContainElement(SatisfyAll(
+									WithTransform(func(c []interface{}) interface{} { return c[i] }, Equal(a)),
+									WithTransform(func(c []interface{}) interface{} { return c[j] }, Equal(b)),
+								)), "expected the pair %v and %v to be covered", a, b)
+							}
+						}
+					}
+				}
+			})
+
+			It("is deterministic", func() {
+				runTable(PairwiseEntries(dimensions...))
+				first := combinations
+				runTable(PairwiseEntries(dimensions...))
+				Ω(combinations).Should(Equal(first))
+			})
+
+			It("generates the full product when there are fewer than three dimensions", func() {
+				runTable(PairwiseEntries([]interface{}{"a", "b"}, []interface{}{1, 2}))
+				Ω(combinations).Should(Equal([][]interface{}{{"a", 1}, {"a", 2}, {"b", 1}, {"b", 2}}))
+			})
+
+			It("generates nothing if a dimension is empty", func() {
+				Ω(PairwiseEntries([]interface{}{"a"}, []interface{}{1}, []interface{}{})).Should(BeEmpty())
+			})
+		})
+	})
+
+	Describe("decorating individual entries", func() {
+		BeforeEach(func() {
+			conf.GracePeriod = time.Second
+			success, _ := RunFixture("table with decorated entries", func() {
+				DescribeTable("decorated entries", func(ctx SpecContext, wait bool) {
+					name := CurrentSpecReport().LeafNodeText
+					rt.Run(name)
+					if wait {
+						<-ctx.Done()
+						rt.Run("cancelled: " + name)
+					}
+				},
+					Entry("A", false),
+					Entry("B", Label("slow"), Serial, false),
+					Entry("C", NodeTimeout(50*time.Millisecond), true),
+				)
+			})
+			Ω(success).Should(BeFalse())
+		})
+
+		It("passes the spec's context to the table body", func() {
+			Ω(rt).Should(HaveTracked("A", "B", "C", "cancelled: C"))
+		})
+
+		It("applies the decorators to the decorated entry only", func() {
+			Ω(reporter.Did.Find("A").Labels()).Should(BeEmpty())
+			Ω(reporter.Did.Find("A").IsSerial).Should(BeFalse())
+			Ω(reporter.Did.Find("B").Labels()).Should(ConsistOf("slow"))
+			Ω(reporter.Did.Find("B").IsSerial).Should(BeTrue())
+			Ω(reporter.Did.Find("C")).Should(HaveTimedOut())
+			Ω(reporter.End).Should(BeASuiteSummary(false, NSpecs(3), NPassed(2), NFailed(1)))
+		})
+	})
+
+	Describe("timing out subtree entries", func() {
+		BeforeEach(func() {
+			conf.GracePeriod = time.Second
+			success, _ := RunFixture("table with a timed out subtree entry", func() {
+				DescribeTable("timed out subtree entries", func(ctx SpecContext, name string) {
+					rt.Run(name)
+					<-ctx.Done()
+					rt.Run("cancelled: " + name)
+				},
+					SubtreeEntry("A", "A", NodeTimeout(50*time.Millisecond), Label("slow"), func() {
+						It("is not bounded by the entry's timeout", func() {
+							rt.Run("inner")
+						})
+					}),
+				)
+			})
+			Ω(success).Should(BeFalse())
+		})
+
+		It("applies the entry's NodeTimeout to the spec generated for the table's body", func() {
+			Ω(rt).Should(HaveTracked("inner", "A", "cancelled: A"))
+			Ω(reporter.Did.Find("is not bounded by the entry's timeout")).Should(HavePassed())
+			Ω(reporter.Did.Find("")).Should(HaveTimedOut())
+			Ω(reporter.Did.Find("").Labels()).Should(ConsistOf("slow"))
+		})
+	})
+
+	Describe("sampling entries with --table-sample", func() {
+		fixture := func() {
+			entries := []TableEntry{}
+			for i := 0; i < 10; i++ {
+				entries = append(entries, Entry(fmt.Sprintf("ten-%d", i), i, i))
+			}
+			DescribeTable("ten", bodyFunc, entries)
+			DescribeTable("two", bodyFunc, Entry("two-a", 1, 1), Entry("two-b", 2, 2))
+			DescribeTableSubtree("subtree", func(n int) {
+				It(fmt.Sprintf("x-%d", n), rt.T(fmt.Sprintf("x-%d", n)))
+				It(fmt.Sprintf("y-%d", n), rt.T(fmt.Sprintf("y-%d", n)))
+			}, Entry("s1", 1), Entry("s2", 2), Entry("s3", 3), Entry("s4", 4))
+			It("not a table", rt.T("not a table"))
+		}
+
+		BeforeEach(func() {
+			conf.TableSample = 0.3
+			success, _ := RunFixture("sampled tables", fixture)
+			Ω(success).Should(BeTrue())
+		})
+
+		It("runs a sample of each table's entries - and at least one entry per table - skipping the rest", func() {
+			tracked := rt.TrackedRuns()
+			count := func(prefix string) int {
+				n := 0
+				for _, run := range tracked {
+					if strings.HasPrefix(run, prefix) {
+						n += 1
+					}
+				}
+				return n
+			}
+			Ω(count("ten-")).Should(Equal(3))
+			Ω(count("two-")).Should(Equal(1))
+			Ω(count("x-")).Should(Equal(2))
+			Ω(count("y-")).Should(Equal(2))
+			Ω(tracked).Should(ContainElement("not a table"))
+			Ω(reporter.End).Should(BeASuiteSummary(true, NSpecs(21), NWillRun(9), NPassed(9), NSkipped(12)))
+		})
+
+		It("runs all the specs of a sampled DescribeTableSubtree entry", func() {
+			for _, run := range rt.TrackedRuns() {
+				if strings.HasPrefix(run, "x-") {
+					Ω(rt.TrackedRuns()).Should(ContainElement("y-" + strings.TrimPrefix(run, "x-")))
+				}
+			}
+		})
+
+		It("samples the same entries when given the same seed", func() {
+			sample := rt.TrackedRuns()
+			rt.Reset()
+			RunFixture("sampled tables again", fixture)
+			Ω(rt.TrackedRuns()).Should(Equal(sample))
+		})
+	})
+
+	Describe("DescribeTableSubtree", func() {
+		BeforeEach(func() {
+			success, _ := RunFixture("table subtree", func() {
+				DescribeTableSubtree("backends",
+					func(backend string, healthy bool) {
+						var connected string
+						BeforeEach(func() {
+							rt.Run("bef-" + backend)
+							connected = backend
+						})
+						It("stores", func() {
+							rt.Run("stores-" + connected)
+						})
+						It("reports its health", func() {
+							rt.Run("health-" + connected)
+							if !healthy {
+								F("unhealthy")
+							}
+						})
+					},
+					Entry("memory", "memory", true),
+					Entry(func(backend string, healthy bool) string { return "on " + backend }, "disk", false, Label("slow")),
+					PEntry("tape", "tape", true),
+				)
+			})
+			Ω(success).Should(BeFalse())
+		})
+
+		It("generates a container for each entry, passing the entry's parameters to the table body", func() {
+			Ω(rt).Should(HaveTracked(
+				"bef-memory", "stores-memory",
+				"bef-memory", "health-memory",
+				"bef-disk", "stores-disk",
+				"bef-disk", "health-disk",
+			))
+		})
+
+		It("reports on the specs appropriately", func() {
+			specs := reporter.Did.WithLeafNodeType(types.NodeTypeIt)
+			Ω(specs).Should(HaveLen(6))
+			Ω(specs[0].ContainerHierarchyTexts).Should(Equal([]string{"backends", "memory"}))
+			Ω(specs[2].ContainerHierarchyTexts).Should(Equal([]string{"backends", "on disk"}))
+			Ω(specs[2].Labels()).Should(Equal([]string{"slow"}))
+			Ω(specs[3]).Should(HaveFailed("unhealthy"))
+			Ω(specs[4]).Should(BePending())
+			Ω(reporter.End).Should(BeASuiteSummary(false, NSpecs(6), NPassed(3), NFailed(1), NPending(2)))
+		})
+	})
+
+	Describe("SubtreeEntry", func() {
+		BeforeEach(func() {
+			success, _ := RunFixture("subtree entries", func() {
+				DescribeTable("importing",
+					func(path string, pages int) {
+						rt.Run(fmt.Sprintf("import-%s-%d", path, pages))
+					},
+					Entry("plain", "plain.pdf", 3),
+					SubtreeEntry("encrypted", "encrypted.pdf", 2, Label("crypto"), func() {
+						BeforeEach(func() {
+							rt.Run("set-password")
+						})
+						It("records the decryption", func() {
+							rt.Run("decrypted")
+						})
+					}),
+					SubtreeEntry("corrupt", "corrupt.pdf", 0, func(path string, pages int) {
+						BeforeEach(func() {
+							rt.Run("corrupt-" + path)
+						})
+					}),
+					PSubtreeEntry("pending", "pending.pdf", 1, func() {
+						It("never runs", func() {
+							rt.Run("pending")
+						})
+					}),
+				)
+
+				DescribeTableSubtree("backends",
+					func(backend string) {
+						It("stores", func() {
+							rt.Run("stores-" + backend)
+						})
+					},
+					SubtreeEntry("disk", "disk", func(backend string) {
+						It("syncs", func() {
+							rt.Run("syncs-" + backend)
+						})
+					}),
+				)
+			})
+			Ω(success).Should(BeTrue())
+		})
+
+		It("runs the entry's setup and specs followed by the table's body as a spec in the entry's container", func() {
+			Ω(rt).Should(HaveTracked(
+				"import-plain.pdf-3",
+				"set-password", "decrypted",
+				"set-password", "import-encrypted.pdf-2",
+				"corrupt-corrupt.pdf", "import-corrupt.pdf-0",
+				"syncs-disk", "stores-disk",
+			))
+		})
+
+		It("reports on the specs appropriately", func() {
+			specs := reporter.Did.WithLeafNodeType(types.NodeTypeIt)
+			Ω(specs).Should(HaveLen(8))
+			Ω(specs[1].ContainerHierarchyTexts).Should(Equal([]string{"importing", "encrypted"}))
+			Ω(specs[1].LeafNodeText).Should(Equal("records the decryption"))
+			Ω(specs[1].Labels()).Should(Equal([]string{"crypto"}))
+			Ω(specs[2].FullText()).Should(Equal("importing encrypted"))
+			Ω(specs[2].Labels()).Should(Equal([]string{"crypto"}))
+			Ω(specs[4]).Should(BePending())
+			Ω(reporter.End).Should(BeASuiteSummary(true, NSpecs(8), NPassed(6), NPending(2)))
+		})
+	})
+
+	Describe("GoldenFiles", func() {
+		var dir string
+		readGolden := func(name string) string {
+			content, err := os.ReadFile(filepath.Join(dir, name))
+			Ω(err).ShouldNot(HaveOccurred())
+			return string(content)
+		}
+
+		type point struct {
+			X, Y int
+		}
+
+		BeforeEach(func() {
+			var err error
+			dir, err = os.MkdirTemp("", "ginkgo-golden-files")
+			Ω(err).ShouldNot(HaveOccurred())
+			DeferCleanup(os.RemoveAll, dir)
+
+			Ω(os.WriteFile(filepath.Join(dir, "a_string.golden"), []byte("hello"), 0644)).Should(Succeed())
+			Ω(os.WriteFile(filepath.Join(dir, "a_point.golden"), []byte("{\n  \"X\": 1,\n  \"Y\": 2\n}\n"), 0644)).Should(Succeed())
+			Ω(os.WriteFile(filepath.Join(dir, "mismatch.golden"), []byte("expected"), 0644)).Should(Succeed())
+			Ω(os.WriteFile(filepath.Join(dir, "shout.golden"), []byte("SHOUT"), 0644)).Should(Succeed())
+		})
+
+		JustBeforeEach(func() {
+			RunFixture("golden files", func() {
+				DescribeTable("values",
+					func(v interface{}) interface{} {
+						return v
+					},
+					GoldenFiles(dir),
+					Entry("a string", "hello"),
+					Entry("a point!", point{1, 2}),
+					Entry("mismatch", "actual"),
+					Entry("missing", "new"),
+				)
+				DescribeTable("strings",
+					func(ctx SpecContext, s string) string {
+						return strings.ToUpper(s)
+					},
+					GoldenFiles(dir),
+					Entry("shout", "shout"),
+				)
+			})
+		})
+
+		It("compares the value each entry's body returns with the entry's golden file", func() {
+			Ω(reporter.Did.Find("a string")).Should(HavePassed())
+			Ω(reporter.Did.Find("a point!")).Should(HavePassed())
+			Ω(reporter.Did.Find("shout")).Should(HavePassed())
+			Ω(reporter.Did.Find("mismatch")).Should(HaveFailed(And(
+				ContainSubstring("Value does not match golden file %s", filepath.Join(dir, "mismatch.golden")),
+				ContainSubstring("Expected:\n    expected\nActual:\n    actual"),
+			)))
+			Ω(reporter.Did.Find("missing")).Should(HaveFailed(ContainSubstring("Golden file %s does not exist.  Run with --update-snapshots to create it.", filepath.Join(dir, "missing.golden"))))
+		})
+
+		Context("when running with --update-snapshots", func() {
+			BeforeEach(func() {
+				conf.UpdateSnapshots = true
+			})
+
+			It("writes the golden files instead", func() {
+				for _, report := range reporter.Did.WithLeafNodeType(types.NodeTypeIt) {
+					Ω(report).Should(HavePassed())
+				}
+				Ω(readGolden("mismatch.golden")).Should(Equal("actual"))
+				Ω(readGolden("missing.golden")).Should(Equal("new"))
+				Ω(readGolden("a_point.golden")).Should(Equal("{\n  \"X\": 1,\n  \"Y\": 2\n}\n"))
+				Ω(reporter.Did.Find("missing").CapturedGinkgoWriterOutput).Should(ContainSubstring("Ginkgo: updated golden file %s", filepath.Join(dir, "missing.golden")))
+			})
+		})
+	})
 })
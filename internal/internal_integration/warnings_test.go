@@ -0,0 +1,56 @@
+package internal_integration_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	. "github.com/onsi/ginkgo/v2/internal/test_helpers"
+	"github.com/onsi/ginkgo/v2/types"
+)
+
+var _ = Describe("Warnings", func() {
+	warnFromHelper := func(message string) {
+		GinkgoWarn(message, 1)
+	}
+
+	BeforeEach(func() {
+		success, _ := RunFixture("warnings", func() {
+			BeforeSuite(func() {
+				GinkgoWarn("suite-warning")
+			})
+			It("A", func() {
+				GinkgoWarn("warning-1")
+				warnFromHelper("warning-2")
+			})
+			It("B", func() {})
+			It("C", func() {
+				GinkgoWarn("warning-before-failure")
+				F("fail")
+			})
+		})
+		Ω(success).Should(BeFalse())
+	})
+
+	It("records warnings on the spec that emitted them without affecting its outcome", func() {
+		Ω(reporter.Did.Find("A").State).Should(Equal(types.SpecStatePassed))
+		warnings := reporter.Did.Find("A").Warnings
+		Ω(warnings).Should(HaveLen(2))
+		Ω(warnings[0].Message).Should(Equal("warning-1"))
+		Ω(warnings[0].Location.FileName).Should(HaveSuffix("warnings_test.go"))
+		Ω(warnings[1].Message).Should(Equal("warning-2"))
+		Ω(warnings[1].Location.LineNumber).Should(Equal(warnings[0].Location.LineNumber+1), "callerSkip attributes the warning to the helper's caller")
+
+		Ω(reporter.Did.Find("B").Warnings).Should(BeEmpty())
+		Ω(reporter.Did.FindByLeafNodeType(types.NodeTypeBeforeSuite).Warnings[0].Message).Should(Equal("suite-warning"))
+	})
+
+	It("keeps warnings emitted by failing specs", func() {
+		Ω(reporter.Did.Find("C").State).Should(Equal(types.SpecStateFailed))
+		Ω(reporter.Did.Find("C").Warnings[0].Message).Should(Equal("warning-before-failure"))
+	})
+
+	It("includes the warnings in the end-of-suite report", func() {
+		Ω(reporter.End).Should(BeASuiteSummary(false, NSpecs(3), NPassed(2), NFailed(1)))
+		Ω(reporter.End.SpecReports.CountOfWarnings()).Should(Equal(4))
+	})
+})
@@ -0,0 +1,111 @@
+package internal_integration_test
+
+import (
+	"os"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/ginkgo/v2/internal/test_helpers"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("WithEnv", func() {
+	var observed map[string]string
+	observe := func(name string) func() {
+		return func() {
+			rt.Run(name)
+			value, ok := os.LookupEnv("GINKGO_WITH_ENV_TEST")
+			if !ok {
+				value = "<unset>"
+			}
+			observed[name] = value
+		}
+	}
+
+	BeforeEach(func() {
+		observed = map[string]string{}
+		os.Unsetenv("GINKGO_WITH_ENV_TEST")
+	})
+
+	Describe("when applied to containers and specs", func() {
+		BeforeEach(func() {
+			os.Setenv("GINKGO_WITH_ENV_TEST", "original")
+			DeferCleanup(os.Unsetenv, "GINKGO_WITH_ENV_TEST")
+			success, _ := RunFixture("with env", func() {
+				Describe("outer", WithEnv("GINKGO_WITH_ENV_TEST", "outer"), func() {
+					BeforeEach(func() { observe("bef-" + CurrentSpecReport().LeafNodeText)() })
+					It("A", observe("A"))
+					It("B", WithEnv("GINKGO_WITH_ENV_TEST", "B"), observe("B"))
+					AfterEach(func() { observe("aft-" + CurrentSpecReport().LeafNodeText)() })
+				})
+				It("C", observe("C"))
+			})
+			Ω(success).Should(BeTrue())
+		})
+
+		It("sets the variables for the whole spec - with the innermost value winning - and restores them afterwards", func() {
+			Ω(rt).Should(HaveTracked("bef-A", "A", "aft-A", "bef-B", "B", "aft-B", "C"))
+			Ω(observed).Should(Equal(map[string]string{
+				"bef-A": "outer",
+				"A":     "outer",
+				"aft-A": "outer",
+				"bef-B": "B",
+				"B":     "B",
+				"aft-B": "B",
+				"C":     "original",
+			}))
+			Ω(os.Getenv("GINKGO_WITH_ENV_TEST")).Should(Equal("original"))
+		})
+	})
+
+	Describe("when applied to setup nodes", func() {
+		BeforeEach(func() {
+			success, _ := RunFixture("with env on setup nodes", func() {
+				BeforeSuite(WithEnv("GINKGO_WITH_ENV_TEST", "suite"), observe("before-suite"))
+				SynchronizedBeforeSuite(func() []byte {
+					observe("sbs-proc-1")()
+					return nil
+				}, func(_ []byte) {
+					observe("sbs-all-procs")()
+				}, WithEnv("GINKGO_WITH_ENV_TEST", "sbs"))
+				BeforeEach(WithEnv("GINKGO_WITH_ENV_TEST", "bef"), observe("bef"))
+				It("A", observe("A"))
+			})
+			Ω(success).Should(BeTrue())
+		})
+
+		It("sets the variables only while the node runs and unsets variables that were not set before", func() {
+			Ω(observed).Should(Equal(map[string]string{
+				"before-suite":  "suite",
+				"sbs-proc-1":    "sbs",
+				"sbs-all-procs": "sbs",
+				"bef":           "bef",
+				"A":             "<unset>",
+			}))
+			_, ok := os.LookupEnv("GINKGO_WITH_ENV_TEST")
+			Ω(ok).Should(BeFalse())
+		})
+	})
+
+	Describe("when specs are randomized", func() {
+		BeforeEach(func() {
+			conf.RandomizeAllSpecs = true
+			conf.RandomSeed = 17
+			success, _ := RunFixture("randomized with env", func() {
+				It("A", WithEnv("GINKGO_WITH_ENV_TEST", "A"), observe("A"))
+				It("B", observe("B"))
+				It("C", WithEnv("GINKGO_WITH_ENV_TEST", "C"), observe("C"))
+				It("D", observe("D"))
+			})
+			Ω(success).Should(BeTrue())
+		})
+
+		It("gives each spec the environment its own nodes ask for", func() {
+			Ω(observed).Should(Equal(map[string]string{
+				"A": "A",
+				"B": "<unset>",
+				"C": "C",
+				"D": "<unset>",
+			}))
+		})
+	})
+})
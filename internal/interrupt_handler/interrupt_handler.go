@@ -198,6 +198,12 @@ func (handler *InterruptHandler) InterruptMessageWithStackTraces() string {
 		return out
 	}
 	out += "Here's a stack trace of all running goroutines:\n"
+	out += StackTracesOfAllGoroutines()
+	return out
+}
+
+// StackTracesOfAllGoroutines returns the (indented) stack traces of all running goroutines
+func StackTracesOfAllGoroutines() string {
 	buf := make([]byte, 8192)
 	for {
 		n := runtime.Stack(buf, true)
@@ -207,6 +213,5 @@ func (handler *InterruptHandler) InterruptMessageWithStackTraces() string {
 		}
 		buf = make([]byte, 2*len(buf))
 	}
-	out += formatter.Fi(1, "%s", string(buf))
-	return out
+	return formatter.Fi(1, "%s", string(buf))
 }
@@ -0,0 +1,77 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/onsi/ginkgo/v2/types"
+)
+
+// NetworkCapturer is implemented by integrations that can capture network traffic (e.g. with a packet capture or an HTTP proxy) around individual specs
+type NetworkCapturer interface {
+	// StartCapture starts capturing traffic.  Captures should be saved in artifactsDir.
+	StartCapture(ctx SpecContext, artifactsDir string) error
+	// StopCapture stops capturing traffic and returns the paths of the captures it saved.  Relative paths are taken to be relative to artifactsDir.
+	StopCapture(ctx SpecContext) ([]string, error)
+}
+
+type registeredNetworkCapturer struct {
+	capturer     NetworkCapturer
+	filter       types.LabelFilter
+	codeLocation types.CodeLocation
+}
+
+// RegisterNetworkCapturer registers a capturer that runs around every spec whose labels match filter
+func (suite *Suite) RegisterNetworkCapturer(capturer NetworkCapturer, filter types.LabelFilter, cl types.CodeLocation) {
+	suite.networkCapturers = append(suite.networkCapturers, registeredNetworkCapturer{capturer: capturer, filter: filter, codeLocation: cl})
+}
+
+// startNetworkCaptures starts the registered NetworkCapturers that match the current spec's labels and returns a function that stops them.
+// Captures that fail to start or stop are recorded as warnings - they never change the outcome of the spec.
+func (suite *Suite) startNetworkCaptures() func() {
+	if len(suite.networkCapturers) == 0 {
+		return func() {}
+	}
+	labels := suite.currentSpecReport.Labels()
+	started := []registeredNetworkCapturer{}
+	for _, registered := range suite.networkCapturers {
+		if !registered.filter(labels) {
+			continue
+		}
+		dir, err := suite.artifactsDirForCurrentSpec()
+		if err != nil {
+			suite.addNetworkCaptureWarning(registered, "Ginkgo could not create a directory for network captures", err)
+			break
+		}
+		err = suite.callIntegration(func() error {
+			return registered.capturer.StartCapture(NewSpecContext(context.Background(), suite), dir)
+		})
+		if err != nil {
+			suite.addNetworkCaptureWarning(registered, "NetworkCapturer failed to start capturing", err)
+			continue
+		}
+		started = append(started, registered)
+	}
+
+	return func() {
+		for i := len(started) - 1; i >= 0; i-- {
+			registered := started[i]
+			var paths []string
+			err := suite.callIntegration(func() (err error) {
+				paths, err = registered.capturer.StopCapture(NewSpecContext(context.Background(), suite))
+				return err
+			})
+			if err != nil {
+				suite.addNetworkCaptureWarning(registered, "NetworkCapturer failed to stop capturing", err)
+			}
+			suite.currentSpecReport.NetworkCaptures = append(suite.currentSpecReport.NetworkCaptures, absoluteArtifactPaths(suite.currentSpecArtifactsDir, paths)...)
+		}
+	}
+}
+
+func (suite *Suite) addNetworkCaptureWarning(registered registeredNetworkCapturer, message string, err error) {
+	suite.currentSpecReport.Warnings = append(suite.currentSpecReport.Warnings, types.Warning{
+		Message:  fmt.Sprintf("%s:\n%s", message, err.Error()),
+		Location: registered.codeLocation,
+	})
+}
@@ -1,15 +1,21 @@
 package internal
 
 import (
+	"context"
 	"fmt"
 	"reflect"
 	"sort"
+	"strings"
 
 	"sync"
+	"time"
 
 	"github.com/onsi/ginkgo/v2/types"
 )
 
+var specContextType = reflect.TypeOf((*SpecContext)(nil)).Elem()
+var contextType = reflect.TypeOf((*context.Context)(nil)).Elem()
+
 var _global_node_id_counter = uint(0)
 var _global_id_mutex = &sync.Mutex{}
 
@@ -26,10 +32,12 @@ type Node struct {
 	ID       uint
 	NodeType types.NodeType
 
-	Text         string
-	Body         func()
-	CodeLocation types.CodeLocation
-	NestingLevel int
+	Text string
+	Body func()
+	//BodyWithContext is set instead of Body for nodes that were passed func(SpecContext) or func(context.Context)
+	BodyWithContext func(SpecContext)
+	CodeLocation    types.CodeLocation
+	NestingLevel    int
 
 	SynchronizedBeforeSuiteProc1Body    func() []byte
 	SynchronizedBeforeSuiteAllProcsBody func([]byte)
@@ -40,15 +48,41 @@ type Node struct {
 	ReportEachBody       func(types.SpecReport)
 	ReportAfterSuiteBody func(types.Report)
 
+	AroundEachBody func(runSpec func())
+
+	ReadinessGateCheck   func() error
+	ReadinessGateTimeout time.Duration
+
 	MarkedFocus          bool
 	MarkedPending        bool
 	MarkedSerial         bool
 	MarkedOrdered        bool
 	MarkedOncePerOrdered bool
+	MarkedVerboseOutput  bool
+	MarkedNoCapture      bool
+	MarkedTableEntry     bool
 	FlakeAttempts        int
+	RetryPolicy          RetryPolicy
 	Labels               Labels
+	Phase                string
+	NodeTimeout          time.Duration
+	SlowSpecThreshold    time.Duration
+	PollProgressAfter    time.Duration
+	CPUTimeLimit         time.Duration
+	ResourceRequirements types.ResourceRequirements
+	NamedRequirements    []string
+	RequiredEnv          RequiredEnv
+	Annotations          []Annotation
+	SkipConditions       []SkipCondition
+	Env                  []EnvVar
+	AssertionDefaults    AssertionDefaults
+	Order                int
+	SpecPriority         int
+	HasSpecPriority      bool
 
 	NodeIDWhereCleanupWasGenerated uint
+	CleanupPriority                CleanupPriority
+	CleanupOnProcess1              bool
 }
 
 // Decoration Types
@@ -57,17 +91,98 @@ type pendingType bool
 type serialType bool
 type orderedType bool
 type honorsOrderedType bool
+type verboseOutputType bool
+type noCaptureType bool
+type onProcess1Type bool
+type tableEntryType bool
 
 const Focus = focusType(true)
 const Pending = pendingType(true)
 const Serial = serialType(true)
 const Ordered = orderedType(true)
 const OncePerOrdered = honorsOrderedType(true)
+const VerboseOutput = verboseOutputType(true)
+const NoCapture = noCaptureType(true)
+
+// TableEntryNode is passed by the table DSL to the nodes it generates for each table entry
+const TableEntryNode = tableEntryType(true)
+
+// OnProcess1 is passed to DeferCleanup to run suite-level cleanup only on parallel process #1
+const OnProcess1 = onProcess1Type(true)
 
 type FlakeAttempts uint
+
+// RetryPolicy is constructed by the Retry decorator
+type RetryPolicy struct {
+	Attempts int
+	Backoff  time.Duration
+}
+
 type Offset uint
 type Done chan<- interface{} // Deprecated Done Channel for asynchronous testing
 type Labels []string
+type ExecutionPhase string
+type PhaseOrder []string
+type NodeTimeout time.Duration
+type SlowSpecThreshold time.Duration
+type PollProgressAfter time.Duration
+type CPUTimeLimit time.Duration
+type CPU int
+type Memory string
+type GPU int
+type Requirements []interface{}
+type RequiredEnv []string
+type CleanupPriority int
+type Order int
+type SpecPriority int
+
+type Annotation struct {
+	Key   string
+	Value interface{}
+}
+
+// EnvVar is constructed by the WithEnv decorator
+type EnvVar struct {
+	Key   string
+	Value string
+}
+
+// AssertionDefaults is used directly as a decorator.  Zero-valued fields leave the corresponding default alone
+type AssertionDefaults struct {
+	EventuallyTimeout           time.Duration
+	EventuallyPollingInterval   time.Duration
+	ConsistentlyDuration        time.Duration
+	ConsistentlyPollingInterval time.Duration
+}
+
+// IsZero returns true if a does not set any defaults
+func (a AssertionDefaults) IsZero() bool {
+	return a == AssertionDefaults{}
+}
+
+// MergedWith returns a with the non-zero fields of other taking precedence
+func (a AssertionDefaults) MergedWith(other AssertionDefaults) AssertionDefaults {
+	if other.EventuallyTimeout > 0 {
+		a.EventuallyTimeout = other.EventuallyTimeout
+	}
+	if other.EventuallyPollingInterval > 0 {
+		a.EventuallyPollingInterval = other.EventuallyPollingInterval
+	}
+	if other.ConsistentlyDuration > 0 {
+		a.ConsistentlyDuration = other.ConsistentlyDuration
+	}
+	if other.ConsistentlyPollingInterval > 0 {
+		a.ConsistentlyPollingInterval = other.ConsistentlyPollingInterval
+	}
+	return a
+}
+
+// SkipCondition is constructed by the SkipIf decorator
+type SkipCondition struct {
+	Condition    func() bool
+	Reason       string
+	CodeLocation types.CodeLocation
+}
 
 func UnionOfLabels(labels ...Labels) Labels {
 	out := Labels{}
@@ -114,10 +229,42 @@ func isDecoration(arg interface{}) bool {
 		return true
 	case t == reflect.TypeOf(OncePerOrdered):
 		return true
+	case t == reflect.TypeOf(VerboseOutput):
+		return true
+	case t == reflect.TypeOf(NoCapture):
+		return true
+	case t == reflect.TypeOf(SlowSpecThreshold(0)):
+		return true
+	case t == reflect.TypeOf(CPUTimeLimit(0)):
+		return true
+	case t == reflect.TypeOf(PollProgressAfter(0)):
+		return true
 	case t == reflect.TypeOf(FlakeAttempts(0)):
 		return true
+	case t == reflect.TypeOf(RetryPolicy{}):
+		return true
 	case t == reflect.TypeOf(Labels{}):
 		return true
+	case t == reflect.TypeOf(ExecutionPhase("")):
+		return true
+	case t == reflect.TypeOf(NodeTimeout(0)):
+		return true
+	case t == reflect.TypeOf(Order(0)):
+		return true
+	case t == reflect.TypeOf(SpecPriority(0)):
+		return true
+	case t == reflect.TypeOf(Requirements{}):
+		return true
+	case t == reflect.TypeOf(RequiredEnv{}):
+		return true
+	case t == reflect.TypeOf(Annotation{}):
+		return true
+	case t == reflect.TypeOf(EnvVar{}):
+		return true
+	case t == reflect.TypeOf(SkipCondition{}):
+		return true
+	case t == reflect.TypeOf(AssertionDefaults{}):
+		return true
 	case t.Kind() == reflect.Slice && isSliceOfDecorations(arg):
 		return true
 	default:
@@ -205,11 +352,43 @@ func NewNode(deprecationTracker *types.DeprecationTracker, nodeType types.NodeTy
 			if !nodeType.Is(types.NodeTypeBeforeEach | types.NodeTypeJustBeforeEach | types.NodeTypeAfterEach | types.NodeTypeJustAfterEach) {
 				appendError(types.GinkgoErrors.InvalidDecoratorForNodeType(node.CodeLocation, nodeType, "OncePerOrdered"))
 			}
+		case t == reflect.TypeOf(VerboseOutput):
+			node.MarkedVerboseOutput = bool(arg.(verboseOutputType))
+			if !nodeType.Is(types.NodeTypesForContainerAndIt) {
+				appendError(types.GinkgoErrors.InvalidDecoratorForNodeType(node.CodeLocation, nodeType, "VerboseOutput"))
+			}
+		case t == reflect.TypeOf(NoCapture):
+			node.MarkedNoCapture = bool(arg.(noCaptureType))
+			if !nodeType.Is(types.NodeTypesForContainerAndIt) {
+				appendError(types.GinkgoErrors.InvalidDecoratorForNodeType(node.CodeLocation, nodeType, "NoCapture"))
+			}
+		case t == reflect.TypeOf(TableEntryNode):
+			node.MarkedTableEntry = bool(arg.(tableEntryType))
+		case t == reflect.TypeOf(SlowSpecThreshold(0)):
+			node.SlowSpecThreshold = time.Duration(arg.(SlowSpecThreshold))
+			if !nodeType.Is(types.NodeTypesForContainerAndIt) {
+				appendError(types.GinkgoErrors.InvalidDecoratorForNodeType(node.CodeLocation, nodeType, "SlowSpecThreshold"))
+			}
+		case t == reflect.TypeOf(CPUTimeLimit(0)):
+			node.CPUTimeLimit = time.Duration(arg.(CPUTimeLimit))
+			if !nodeType.Is(types.NodeTypesForContainerAndIt) {
+				appendError(types.GinkgoErrors.InvalidDecoratorForNodeType(node.CodeLocation, nodeType, "CPUTimeLimit"))
+			}
+		case t == reflect.TypeOf(PollProgressAfter(0)):
+			node.PollProgressAfter = time.Duration(arg.(PollProgressAfter))
+			if !nodeType.Is(types.NodeTypesForContainerAndIt) {
+				appendError(types.GinkgoErrors.InvalidDecoratorForNodeType(node.CodeLocation, nodeType, "PollProgressAfter"))
+			}
 		case t == reflect.TypeOf(FlakeAttempts(0)):
 			node.FlakeAttempts = int(arg.(FlakeAttempts))
 			if !nodeType.Is(types.NodeTypesForContainerAndIt) {
 				appendError(types.GinkgoErrors.InvalidDecoratorForNodeType(node.CodeLocation, nodeType, "FlakeAttempts"))
 			}
+		case t == reflect.TypeOf(RetryPolicy{}):
+			node.RetryPolicy = arg.(RetryPolicy)
+			if !nodeType.Is(types.NodeTypeIt) {
+				appendError(types.GinkgoErrors.InvalidDecoratorForNodeType(node.CodeLocation, nodeType, "Retry"))
+			}
 		case t == reflect.TypeOf(Labels{}):
 			if !nodeType.Is(types.NodeTypesForContainerAndIt) {
 				appendError(types.GinkgoErrors.InvalidDecoratorForNodeType(node.CodeLocation, nodeType, "Label"))
@@ -222,21 +401,87 @@ func NewNode(deprecationTracker *types.DeprecationTracker, nodeType types.NodeTy
 					appendError(err)
 				}
 			}
+		case t == reflect.TypeOf(ExecutionPhase("")):
+			node.Phase = string(arg.(ExecutionPhase))
+			if !nodeType.Is(types.NodeTypesForContainerAndIt) {
+				appendError(types.GinkgoErrors.InvalidDecoratorForNodeType(node.CodeLocation, nodeType, "Phase"))
+			}
+		case t == reflect.TypeOf(NodeTimeout(0)):
+			node.NodeTimeout = time.Duration(arg.(NodeTimeout))
+			if !nodeType.Is(types.NodeTypesThatAcceptNodeTimeouts) {
+				appendError(types.GinkgoErrors.InvalidDecoratorForNodeType(node.CodeLocation, nodeType, "NodeTimeout"))
+			}
+		case t == reflect.TypeOf(Order(0)):
+			node.Order = int(arg.(Order))
+			if !nodeType.Is(types.NodeTypesForSuiteSetupAndCleanup) {
+				appendError(types.GinkgoErrors.InvalidDecoratorForNodeType(node.CodeLocation, nodeType, "Order"))
+			}
+		case t == reflect.TypeOf(SpecPriority(0)):
+			node.SpecPriority, node.HasSpecPriority = int(arg.(SpecPriority)), true
+			if !nodeType.Is(types.NodeTypesForContainerAndIt) {
+				appendError(types.GinkgoErrors.InvalidDecoratorForNodeType(node.CodeLocation, nodeType, "SpecPriority"))
+			}
+		case t == reflect.TypeOf(Requirements{}):
+			if !nodeType.Is(types.NodeTypesForContainerAndIt) {
+				appendError(types.GinkgoErrors.InvalidDecoratorForNodeType(node.CodeLocation, nodeType, "Requires"))
+			}
+			requirements, namedRequirements, err := parseRequirements(arg.(Requirements))
+			if err != nil {
+				appendError(types.GinkgoErrors.InvalidResourceRequirement(node.CodeLocation, nodeType, err.Error()))
+			}
+			node.ResourceRequirements = node.ResourceRequirements.Max(requirements)
+			node.NamedRequirements = append(node.NamedRequirements, namedRequirements...)
+		case t == reflect.TypeOf(RequiredEnv{}):
+			if !nodeType.Is(types.NodeTypesForContainerAndIt) {
+				appendError(types.GinkgoErrors.InvalidDecoratorForNodeType(node.CodeLocation, nodeType, "RequiresEnv"))
+			}
+			node.RequiredEnv = append(node.RequiredEnv, arg.(RequiredEnv)...)
+		case t == reflect.TypeOf(Annotation{}):
+			if !nodeType.Is(types.NodeTypesForContainerAndIt) {
+				appendError(types.GinkgoErrors.InvalidDecoratorForNodeType(node.CodeLocation, nodeType, "Annotate"))
+			}
+			node.Annotations = append(node.Annotations, arg.(Annotation))
+		case t == reflect.TypeOf(EnvVar{}):
+			if !nodeType.Is(types.NodeTypesThatAcceptEnv) {
+				appendError(types.GinkgoErrors.InvalidDecoratorForNodeType(node.CodeLocation, nodeType, "WithEnv"))
+			}
+			node.Env = append(node.Env, arg.(EnvVar))
+		case t == reflect.TypeOf(AssertionDefaults{}):
+			if !nodeType.Is(types.NodeTypesForContainerAndIt) {
+				appendError(types.GinkgoErrors.InvalidDecoratorForNodeType(node.CodeLocation, nodeType, "AssertionDefaults"))
+			}
+			node.AssertionDefaults = node.AssertionDefaults.MergedWith(arg.(AssertionDefaults))
+		case t == reflect.TypeOf(SkipCondition{}):
+			if !nodeType.Is(types.NodeTypesForContainerAndIt) {
+				appendError(types.GinkgoErrors.InvalidDecoratorForNodeType(node.CodeLocation, nodeType, "SkipIf"))
+			}
+			skipCondition := arg.(SkipCondition)
+			if skipCondition.Condition == nil {
+				appendError(types.GinkgoErrors.InvalidSkipIfCondition(skipCondition.CodeLocation))
+			}
+			node.SkipConditions = append(node.SkipConditions, skipCondition)
 		case t.Kind() == reflect.Func:
-			if node.Body != nil {
+			if node.HasBody() {
 				appendError(types.GinkgoErrors.MultipleBodyFunctions(node.CodeLocation, nodeType))
 				trackedFunctionError = true
 				break
 			}
-			isValid := (t.NumOut() == 0) && (t.NumIn() <= 1) && (t.NumIn() == 0 || t.In(0) == reflect.TypeOf(make(Done)))
+			acceptsContext := nodeType.Is(types.NodeTypesThatAcceptContexts) && t.NumIn() == 1 && (t.In(0) == specContextType || t.In(0) == contextType)
+			isValid := (t.NumOut() == 0) && (t.NumIn() <= 1) && (t.NumIn() == 0 || t.In(0) == reflect.TypeOf(make(Done)) || acceptsContext)
 			if !isValid {
 				appendError(types.GinkgoErrors.InvalidBodyType(t, node.CodeLocation, nodeType))
 				trackedFunctionError = true
 				break
 			}
-			if t.NumIn() == 0 {
+			switch {
+			case t.NumIn() == 0:
 				node.Body = arg.(func())
-			} else {
+			case t.In(0) == specContextType:
+				node.BodyWithContext = arg.(func(SpecContext))
+			case t.In(0) == contextType:
+				body := arg.(func(context.Context))
+				node.BodyWithContext = func(ctx SpecContext) { body(ctx) }
+			default:
 				deprecationTracker.TrackDeprecation(types.Deprecations.Async(), node.CodeLocation)
 				deprecatedAsyncBody := arg.(func(Done))
 				node.Body = func() { deprecatedAsyncBody(make(Done)) }
@@ -251,7 +496,7 @@ func NewNode(deprecationTracker *types.DeprecationTracker, nodeType types.NodeTy
 		appendError(types.GinkgoErrors.InvalidDeclarationOfFocusedAndPending(node.CodeLocation, nodeType))
 	}
 
-	if node.Body == nil && !node.MarkedPending && !trackedFunctionError {
+	if !node.HasBody() && !node.MarkedPending && !trackedFunctionError {
 		appendError(types.GinkgoErrors.MissingBodyFunction(node.CodeLocation, nodeType))
 	}
 	for _, arg := range remainingArgs {
@@ -265,24 +510,80 @@ func NewNode(deprecationTracker *types.DeprecationTracker, nodeType types.NodeTy
 	return node, errors
 }
 
-func NewSynchronizedBeforeSuiteNode(proc1Body func() []byte, allProcsBody func([]byte), codeLocation types.CodeLocation) (Node, []error) {
-	return Node{
+// parseRequirements splits the arguments passed to Requires into host resource requirements (CPU, Memory, and GPU) and named requirements (e.g. "docker" or "postgres>=14")
+func parseRequirements(requirements Requirements) (types.ResourceRequirements, []string, error) {
+	out, named := types.ResourceRequirements{}, []string{}
+	for _, requirement := range requirements {
+		switch v := requirement.(type) {
+		case CPU:
+			if v <= 0 {
+				return out, named, fmt.Errorf("CPU(%d)", v)
+			}
+			out.CPU += int(v)
+		case GPU:
+			if v <= 0 {
+				return out, named, fmt.Errorf("GPU(%d)", v)
+			}
+			out.GPU += int(v)
+		case Memory:
+			bytes, err := types.ParseMemoryQuantity(string(v))
+			if err != nil {
+				return out, named, err
+			}
+			out.MemoryBytes += bytes
+		case string:
+			if name, _ := ParseNamedRequirement(v); name == "" {
+				return out, named, fmt.Errorf("%q", v)
+			}
+			named = append(named, strings.TrimSpace(v))
+		default:
+			return out, named, fmt.Errorf("%#v", requirement)
+		}
+	}
+	return out, named, nil
+}
+
+func NewSynchronizedBeforeSuiteNode(proc1Body func() []byte, allProcsBody func([]byte), codeLocation types.CodeLocation, args ...interface{}) (Node, []error) {
+	node := Node{
 		ID:                                  UniqueNodeID(),
 		NodeType:                            types.NodeTypeSynchronizedBeforeSuite,
 		SynchronizedBeforeSuiteProc1Body:    proc1Body,
 		SynchronizedBeforeSuiteAllProcsBody: allProcsBody,
 		CodeLocation:                        codeLocation,
-	}, nil
+	}
+	return applySuiteNodeDecorations(node, args)
 }
 
-func NewSynchronizedAfterSuiteNode(allProcsBody func(), proc1Body func(), codeLocation types.CodeLocation) (Node, []error) {
-	return Node{
+func NewSynchronizedAfterSuiteNode(allProcsBody func(), proc1Body func(), codeLocation types.CodeLocation, args ...interface{}) (Node, []error) {
+	node := Node{
 		ID:                                 UniqueNodeID(),
 		NodeType:                           types.NodeTypeSynchronizedAfterSuite,
 		SynchronizedAfterSuiteAllProcsBody: allProcsBody,
 		SynchronizedAfterSuiteProc1Body:    proc1Body,
 		CodeLocation:                       codeLocation,
-	}, nil
+	}
+	return applySuiteNodeDecorations(node, args)
+}
+
+// applySuiteNodeDecorations applies the decorations supported by the Synchronized suite nodes (which don't go through NewNode)
+func applySuiteNodeDecorations(node Node, args []interface{}) (Node, []error) {
+	errors := []error{}
+	for _, arg := range unrollInterfaceSlice(args) {
+		switch v := arg.(type) {
+		case NodeTimeout:
+			node.NodeTimeout = time.Duration(v)
+		case Order:
+			node.Order = int(v)
+		case EnvVar:
+			node.Env = append(node.Env, v)
+		default:
+			errors = append(errors, types.GinkgoErrors.UnknownDecorator(node.CodeLocation, node.NodeType, arg))
+		}
+	}
+	if len(errors) > 0 {
+		return Node{}, errors
+	}
+	return node, nil
 }
 
 func NewReportBeforeEachNode(body func(types.SpecReport), codeLocation types.CodeLocation) (Node, []error) {
@@ -315,6 +616,32 @@ func NewReportAfterSuiteNode(text string, body func(types.Report), codeLocation
 	}, nil
 }
 
+func NewAroundEachNode(body func(runSpec func()), codeLocation types.CodeLocation) (Node, []error) {
+	if body == nil {
+		return Node{}, []error{types.GinkgoErrors.InvalidAroundEach(codeLocation)}
+	}
+	return Node{
+		ID:             UniqueNodeID(),
+		NodeType:       types.NodeTypeAroundEach,
+		AroundEachBody: body,
+		CodeLocation:   codeLocation,
+		NestingLevel:   -1,
+	}, nil
+}
+
+func NewReadinessGateNode(check func() error, timeout time.Duration, codeLocation types.CodeLocation) (Node, []error) {
+	if check == nil || timeout <= 0 {
+		return Node{}, []error{types.GinkgoErrors.InvalidReadinessGate(codeLocation)}
+	}
+	return Node{
+		ID:                   UniqueNodeID(),
+		NodeType:             types.NodeTypeReadinessGate,
+		ReadinessGateCheck:   check,
+		ReadinessGateTimeout: timeout,
+		CodeLocation:         codeLocation,
+	}, nil
+}
+
 func NewCleanupNode(fail func(string, types.CodeLocation), args ...interface{}) (Node, []error) {
 	baseOffset := 2
 	node := Node{
@@ -330,6 +657,10 @@ func NewCleanupNode(fail func(string, types.CodeLocation), args ...interface{})
 			node.CodeLocation = types.NewCodeLocation(baseOffset + int(arg.(Offset)))
 		case t == reflect.TypeOf(types.CodeLocation{}):
 			node.CodeLocation = arg.(types.CodeLocation)
+		case t == reflect.TypeOf(CleanupPriority(0)):
+			node.CleanupPriority = arg.(CleanupPriority)
+		case t == reflect.TypeOf(OnProcess1):
+			node.CleanupOnProcess1 = bool(arg.(onProcess1Type))
 		default:
 			remainingArgs = append(remainingArgs, arg)
 		}
@@ -357,6 +688,11 @@ func NewCleanupNode(fail func(string, types.CodeLocation), args ...interface{})
 	return node, nil
 }
 
+// HasBody returns true if the node was passed a body function, with or without a context
+func (n Node) HasBody() bool {
+	return n.Body != nil || n.BodyWithContext != nil
+}
+
 func (n Node) IsZero() bool {
 	return n.ID == 0
 }
@@ -504,6 +840,17 @@ func (n Nodes) WithinNestingLevel(deepestNestingLevel int) Nodes {
 	return out
 }
 
+// SortedByOrder returns the nodes sorted by their Order decoration.  Nodes with the same Order retain their relative order.
+func (n Nodes) SortedByOrder() Nodes {
+	out := make(Nodes, len(n))
+	copy(out, n)
+	sort.SliceStable(out, func(i int, j int) bool {
+		return out[i].Order < out[j].Order
+	})
+
+	return out
+}
+
 func (n Nodes) SortedByDescendingNestingLevel() Nodes {
 	out := make(Nodes, len(n))
 	copy(out, n)
@@ -575,6 +922,87 @@ func (n Nodes) UnionOfLabels() []string {
 	return out
 }
 
+// UnionOfRequiredEnv returns the environment variables declared with RequiresEnv anywhere in the nodes
+func (n Nodes) UnionOfRequiredEnv() []string {
+	out := []string{}
+	seen := map[string]bool{}
+	for i := range n {
+		for _, name := range n[i].RequiredEnv {
+			if !seen[name] {
+				seen[name] = true
+				out = append(out, name)
+			}
+		}
+	}
+	return out
+}
+
+// ResourceRequirements returns the resources required by the nodes.  Requirements declared at different levels of the hierarchy don't add up: the largest requirement in each dimension wins.
+// Annotations returns the annotations attached to the nodes, outermost first, so that the most specific annotation of a key comes last
+func (n Nodes) Annotations() types.SpecAnnotations {
+	var out types.SpecAnnotations
+	for i := range n {
+		for _, annotation := range n[i].Annotations {
+			out = append(out, types.SpecAnnotation{
+				Key:      annotation.Key,
+				Value:    types.WrapEntryValue(annotation.Value),
+				Location: n[i].CodeLocation,
+			})
+		}
+	}
+	return out
+}
+
+// Env returns the environment variables the nodes set with WithEnv, outermost first, so that the most specific value of a variable is set last
+func (n Nodes) Env() []EnvVar {
+	var out []EnvVar
+	for i := range n {
+		out = append(out, n[i].Env...)
+	}
+	return out
+}
+
+// AssertionDefaults returns the assertion defaults the nodes declare, merged so that the defaults of more deeply nested nodes take precedence
+func (n Nodes) AssertionDefaults() AssertionDefaults {
+	out := AssertionDefaults{}
+	for _, node := range n.SortedByAscendingNestingLevel() {
+		out = out.MergedWith(node.AssertionDefaults)
+	}
+	return out
+}
+
+// NamedRequirements returns the named requirements declared with Requires anywhere in the nodes, outermost first and without duplicates
+func (n Nodes) NamedRequirements() []string {
+	out := []string{}
+	seen := map[string]bool{}
+	for i := range n {
+		for _, requirement := range n[i].NamedRequirements {
+			if !seen[requirement] {
+				seen[requirement] = true
+				out = append(out, requirement)
+			}
+		}
+	}
+	return out
+}
+
+func (n Nodes) ResourceRequirements() types.ResourceRequirements {
+	out := types.ResourceRequirements{}
+	for i := range n {
+		out = out.Max(n[i].ResourceRequirements)
+	}
+	return out
+}
+
+func (n Nodes) Phase() string {
+	for i := len(n) - 1; i >= 0; i-- {
+		if n[i].Phase != "" {
+			return n[i].Phase
+		}
+	}
+	return ""
+}
+
 func (n Nodes) CodeLocations() []types.CodeLocation {
 	out := make([]types.CodeLocation, len(n))
 	for i := range n {
@@ -583,6 +1011,22 @@ func (n Nodes) CodeLocations() []types.CodeLocation {
 	return out
 }
 
+// SourceFiles returns the sorted, de-duplicated file names of the nodes' code locations
+func (n Nodes) SourceFiles() []string {
+	seen := map[string]bool{}
+	out := []string{}
+	for i := range n {
+		fileName := n[i].CodeLocation.FileName
+		if fileName == "" || seen[fileName] {
+			continue
+		}
+		seen[fileName] = true
+		out = append(out, fileName)
+	}
+	sort.Strings(out)
+	return out
+}
+
 func (n Nodes) BestTextFor(node Node) string {
 	if node.Text != "" {
 		return node.Text
@@ -633,6 +1077,64 @@ func (n Nodes) HasNodeMarkedSerial() bool {
 	return false
 }
 
+func (n Nodes) HasNodeMarkedVerboseOutput() bool {
+	for i := range n {
+		if n[i].MarkedVerboseOutput {
+			return true
+		}
+	}
+	return false
+}
+
+func (n Nodes) HasNodeMarkedNoCapture() bool {
+	for i := range n {
+		if n[i].MarkedNoCapture {
+			return true
+		}
+	}
+	return false
+}
+
+// SlowSpecThreshold returns the innermost SlowSpecThreshold decoration, or 0 if there is none
+func (n Nodes) SlowSpecThreshold() time.Duration {
+	for i := len(n) - 1; i >= 0; i-- {
+		if n[i].SlowSpecThreshold > 0 {
+			return n[i].SlowSpecThreshold
+		}
+	}
+	return 0
+}
+
+// CPUTimeLimit returns the innermost CPUTimeLimit decoration, or 0 if there is none
+func (n Nodes) CPUTimeLimit() time.Duration {
+	for i := len(n) - 1; i >= 0; i-- {
+		if n[i].CPUTimeLimit > 0 {
+			return n[i].CPUTimeLimit
+		}
+	}
+	return 0
+}
+
+// PollProgressAfter returns the innermost PollProgressAfter decoration, or 0 if there is none
+func (n Nodes) PollProgressAfter() time.Duration {
+	for i := len(n) - 1; i >= 0; i-- {
+		if n[i].PollProgressAfter > 0 {
+			return n[i].PollProgressAfter
+		}
+	}
+	return 0
+}
+
+// SpecPriority returns the innermost SpecPriority decoration, or 0 if there is none
+func (n Nodes) SpecPriority() int {
+	for i := len(n) - 1; i >= 0; i-- {
+		if n[i].HasSpecPriority {
+			return n[i].SpecPriority
+		}
+	}
+	return 0
+}
+
 func (n Nodes) FirstNodeMarkedOrdered() Node {
 	for i := range n {
 		if n[i].MarkedOrdered {
@@ -650,7 +1152,7 @@ func unrollInterfaceSlice(args interface{}) []interface{} {
 	out := []interface{}{}
 	for i := 0; i < v.Len(); i++ {
 		el := reflect.ValueOf(v.Index(i).Interface())
-		if el.Kind() == reflect.Slice && el.Type() != reflect.TypeOf(Labels{}) {
+		if el.Kind() == reflect.Slice && el.Type() != reflect.TypeOf(Labels{}) && el.Type() != reflect.TypeOf(Requirements{}) && el.Type() != reflect.TypeOf(RequiredEnv{}) {
 			out = append(out, unrollInterfaceSlice(el.Interface())...)
 		} else {
 			out = append(out, v.Index(i).Interface())
@@ -1,6 +1,7 @@
 package internal_test
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"reflect"
@@ -44,6 +45,8 @@ var _ = Describe("Partitioning Decorations", func() {
 			Label("D"),
 			[]interface{}{},
 			FlakeAttempts(1),
+			Phase("provision"),
+			NodeTimeout(time.Second),
 			true,
 		)
 
@@ -58,6 +61,8 @@ var _ = Describe("Partitioning Decorations", func() {
 			Label("A", "B", "C"),
 			Label("D"),
 			FlakeAttempts(1),
+			Phase("provision"),
+			NodeTimeout(time.Second),
 		}))
 
 		Ω(remaining).Should(Equal([]interface{}{
@@ -273,6 +278,47 @@ var _ = Describe("Constructing nodes", func() {
 		})
 	})
 
+	Describe("The Order decoration", func() {
+		It("is zero by default", func() {
+			node, errors := internal.NewNode(dt, types.NodeTypeBeforeSuite, "", body)
+			Ω(node.Order).Should(Equal(0))
+			ExpectAllWell(errors)
+		})
+		It("sets the Order field on suite setup and cleanup nodes", func() {
+			node, errors := internal.NewNode(dt, types.NodeTypeBeforeSuite, "", body, Order(-1))
+			Ω(node.Order).Should(Equal(-1))
+			ExpectAllWell(errors)
+
+			node, errors = internal.NewNode(dt, types.NodeTypeAfterSuite, "", body, Order(3))
+			Ω(node.Order).Should(Equal(3))
+			ExpectAllWell(errors)
+		})
+		It("cannot be applied to other nodes", func() {
+			node, errors := internal.NewNode(dt, ntIt, "text", body, cl, Order(2))
+			Ω(node).Should(BeZero())
+			Ω(errors).Should(ConsistOf(types.GinkgoErrors.InvalidDecoratorForNodeType(cl, ntIt, "Order")))
+			Ω(dt.DidTrackDeprecations()).Should(BeFalse())
+		})
+	})
+
+	Describe("The Retry decoration", func() {
+		It("sets the RetryPolicy field", func() {
+			node, errors := internal.NewNode(dt, ntIt, "text", body, Retry(3, time.Second))
+			Ω(node.RetryPolicy).Should(Equal(internal.RetryPolicy{Attempts: 3, Backoff: time.Second}))
+			ExpectAllWell(errors)
+		})
+		It("cannot be applied to containers or setup nodes", func() {
+			node, errors := internal.NewNode(dt, ntCon, "text", body, cl, Retry(3, time.Second))
+			Ω(node).Should(BeZero())
+			Ω(errors).Should(ConsistOf(types.GinkgoErrors.InvalidDecoratorForNodeType(cl, ntCon, "Retry")))
+
+			node, errors = internal.NewNode(dt, ntBef, "", body, cl, Retry(3, time.Second))
+			Ω(node).Should(BeZero())
+			Ω(errors).Should(ConsistOf(types.GinkgoErrors.InvalidDecoratorForNodeType(cl, ntBef, "Retry")))
+			Ω(dt.DidTrackDeprecations()).Should(BeFalse())
+		})
+	})
+
 	Describe("The Label decoration", func() {
 		It("has no labels by default", func() {
 			node, errors := internal.NewNode(dt, ntIt, "text", body)
@@ -314,6 +360,291 @@ var _ = Describe("Constructing nodes", func() {
 		})
 	})
 
+	Describe("The Phase decoration", func() {
+		It("has no phase by default", func() {
+			node, errors := internal.NewNode(dt, ntIt, "text", body)
+			Ω(node.Phase).Should(BeEmpty())
+			ExpectAllWell(errors)
+		})
+
+		It("can be applied to specs and containers", func() {
+			node, errors := internal.NewNode(dt, ntIt, "text", body, Phase("provision"))
+			Ω(node.Phase).Should(Equal("provision"))
+			ExpectAllWell(errors)
+
+			node, errors = internal.NewNode(dt, ntCon, "text", body, Phase("teardown"))
+			Ω(node.Phase).Should(Equal("teardown"))
+			ExpectAllWell(errors)
+		})
+
+		It("cannot be applied to non-container/it nodes", func() {
+			node, errors := internal.NewNode(dt, ntBef, "", body, cl, Phase("provision"))
+			Ω(node).Should(BeZero())
+			Ω(errors).Should(ConsistOf(types.GinkgoErrors.InvalidDecoratorForNodeType(cl, ntBef, "Phase")))
+			Ω(dt.DidTrackDeprecations()).Should(BeFalse())
+		})
+	})
+
+	Describe("The NodeTimeout decoration", func() {
+		It("can be applied to BeforeSuite and AfterSuite", func() {
+			node, errors := internal.NewNode(dt, types.NodeTypeBeforeSuite, "", body, NodeTimeout(time.Minute))
+			Ω(node.NodeTimeout).Should(Equal(time.Minute))
+			ExpectAllWell(errors)
+
+			node, errors = internal.NewNode(dt, types.NodeTypeAfterSuite, "", body, NodeTimeout(time.Second))
+			Ω(node.NodeTimeout).Should(Equal(time.Second))
+			ExpectAllWell(errors)
+		})
+
+		It("can be applied to Its and setup nodes", func() {
+			node, errors := internal.NewNode(dt, ntIt, "text", body, NodeTimeout(time.Minute))
+			Ω(node.NodeTimeout).Should(Equal(time.Minute))
+			ExpectAllWell(errors)
+
+			for _, nodeType := range []types.NodeType{ntBef, types.NodeTypeJustBeforeEach, ntAf, types.NodeTypeJustAfterEach, types.NodeTypeBeforeAll, types.NodeTypeAfterAll} {
+				node, errors = internal.NewNode(dt, nodeType, "", body, NodeTimeout(time.Second))
+				Ω(node.NodeTimeout).Should(Equal(time.Second))
+				ExpectAllWell(errors)
+			}
+		})
+
+		It("cannot be applied to other nodes", func() {
+			node, errors := internal.NewNode(dt, ntCon, "text", body, cl, NodeTimeout(time.Minute))
+			Ω(node).Should(BeZero())
+			Ω(errors).Should(ConsistOf(types.GinkgoErrors.InvalidDecoratorForNodeType(cl, ntCon, "NodeTimeout")))
+			Ω(dt.DidTrackDeprecations()).Should(BeFalse())
+		})
+	})
+
+	Describe("The Requires decoration", func() {
+		It("records the resource requirements on Its and containers", func() {
+			node, errors := internal.NewNode(dt, ntIt, "text", body, Requires(CPU(4), Memory("8Gi"), GPU(1)))
+			Ω(node.ResourceRequirements).Should(Equal(types.ResourceRequirements{CPU: 4, MemoryBytes: 8 << 30, GPU: 1}))
+			ExpectAllWell(errors)
+
+			node, errors = internal.NewNode(dt, ntCon, "text", body, Requires(Memory("512Mi")))
+			Ω(node.ResourceRequirements).Should(Equal(types.ResourceRequirements{MemoryBytes: 512 << 20}))
+			ExpectAllWell(errors)
+		})
+
+		It("records named requirements alongside the resource requirements", func() {
+			node, errors := internal.NewNode(dt, ntIt, "text", body, Requires("docker", CPU(2), " postgres>=14 "))
+			Ω(node.ResourceRequirements).Should(Equal(types.ResourceRequirements{CPU: 2}))
+			Ω(node.NamedRequirements).Should(Equal([]string{"docker", "postgres>=14"}))
+			ExpectAllWell(errors)
+		})
+
+		It("sums repeated requirements within a single Requires", func() {
+			node, errors := internal.NewNode(dt, ntIt, "text", body, Requires(CPU(2), CPU(2)))
+			Ω(node.ResourceRequirements).Should(Equal(types.ResourceRequirements{CPU: 4}))
+			ExpectAllWell(errors)
+		})
+
+		It("errors when passed invalid requirements", func() {
+			node, errors := internal.NewNode(dt, ntIt, "text", body, cl, Requires(CPU(0)))
+			Ω(node).Should(BeZero())
+			Ω(errors).Should(ConsistOf(types.GinkgoErrors.InvalidResourceRequirement(cl, ntIt, "CPU(0)")))
+
+			node, errors = internal.NewNode(dt, ntIt, "text", body, cl, Requires(Memory("lots")))
+			Ω(node).Should(BeZero())
+			_, parseErr := types.ParseMemoryQuantity("lots")
+			Ω(errors).Should(ConsistOf(types.GinkgoErrors.InvalidResourceRequirement(cl, ntIt, parseErr.Error())))
+
+			node, errors = internal.NewNode(dt, ntIt, "text", body, cl, Requires(">=14"))
+			Ω(node).Should(BeZero())
+			Ω(errors).Should(ConsistOf(types.GinkgoErrors.InvalidResourceRequirement(cl, ntIt, `">=14"`)))
+
+			node, errors = internal.NewNode(dt, ntIt, "text", body, cl, Requires(3.5))
+			Ω(node).Should(BeZero())
+			Ω(errors).Should(ConsistOf(types.GinkgoErrors.InvalidResourceRequirement(cl, ntIt, "3.5")))
+		})
+
+		It("cannot be applied to setup nodes", func() {
+			node, errors := internal.NewNode(dt, ntBef, "", body, cl, Requires(CPU(1)))
+			Ω(node).Should(BeZero())
+			Ω(errors).Should(ConsistOf(types.GinkgoErrors.InvalidDecoratorForNodeType(cl, ntBef, "Requires")))
+			Ω(dt.DidTrackDeprecations()).Should(BeFalse())
+		})
+	})
+
+	Describe("The RequiresEnv decoration", func() {
+		It("records the required environment variables on Its and containers", func() {
+			node, errors := internal.NewNode(dt, ntIt, "text", body, RequiresEnv("A", "B"), RequiresEnv("C"))
+			Ω(node.RequiredEnv).Should(Equal(internal.RequiredEnv{"A", "B", "C"}))
+			ExpectAllWell(errors)
+
+			node, errors = internal.NewNode(dt, ntCon, "text", body, RequiresEnv("A"))
+			Ω(node.RequiredEnv).Should(Equal(internal.RequiredEnv{"A"}))
+			ExpectAllWell(errors)
+		})
+
+		It("cannot be applied to setup nodes", func() {
+			node, errors := internal.NewNode(dt, ntBef, "", body, cl, RequiresEnv("A"))
+			Ω(node).Should(BeZero())
+			Ω(errors).Should(ConsistOf(types.GinkgoErrors.InvalidDecoratorForNodeType(cl, ntBef, "RequiresEnv")))
+			Ω(dt.DidTrackDeprecations()).Should(BeFalse())
+		})
+	})
+
+	Describe("The reporting override decorations", func() {
+		It("can be applied to Its and containers", func() {
+			node, errors := internal.NewNode(dt, ntIt, "text", body, VerboseOutput, NoCapture, SlowSpecThreshold(time.Minute), PollProgressAfter(time.Hour))
+			Ω(node.MarkedVerboseOutput).Should(BeTrue())
+			Ω(node.MarkedNoCapture).Should(BeTrue())
+			Ω(node.SlowSpecThreshold).Should(Equal(time.Minute))
+			Ω(node.PollProgressAfter).Should(Equal(time.Hour))
+			ExpectAllWell(errors)
+
+			node, errors = internal.NewNode(dt, ntCon, "text", body, VerboseOutput, NoCapture, SlowSpecThreshold(time.Second), PollProgressAfter(time.Millisecond))
+			Ω(node.MarkedVerboseOutput).Should(BeTrue())
+			Ω(node.MarkedNoCapture).Should(BeTrue())
+			Ω(node.SlowSpecThreshold).Should(Equal(time.Second))
+			Ω(node.PollProgressAfter).Should(Equal(time.Millisecond))
+			ExpectAllWell(errors)
+		})
+
+		It("cannot be applied to setup nodes", func() {
+			node, errors := internal.NewNode(dt, ntBef, "", body, cl, VerboseOutput, NoCapture, SlowSpecThreshold(time.Minute), PollProgressAfter(time.Hour))
+			Ω(node).Should(BeZero())
+			Ω(errors).Should(ConsistOf(
+				types.GinkgoErrors.InvalidDecoratorForNodeType(cl, ntBef, "VerboseOutput"),
+				types.GinkgoErrors.InvalidDecoratorForNodeType(cl, ntBef, "NoCapture"),
+				types.GinkgoErrors.InvalidDecoratorForNodeType(cl, ntBef, "SlowSpecThreshold"),
+				types.GinkgoErrors.InvalidDecoratorForNodeType(cl, ntBef, "PollProgressAfter"),
+			))
+			Ω(dt.DidTrackDeprecations()).Should(BeFalse())
+		})
+	})
+
+	Describe("The Annotate decoration", func() {
+		It("records the annotations on Its and containers", func() {
+			node, errors := internal.NewNode(dt, ntIt, "text", body, Annotate("risk", "high"), Annotate("slo-tier", 1))
+			Ω(node.Annotations).Should(Equal([]internal.Annotation{{Key: "risk", Value: "high"}, {Key: "slo-tier", Value: 1}}))
+			ExpectAllWell(errors)
+
+			node, errors = internal.NewNode(dt, ntCon, "text", body, Annotate("risk", "low"))
+			Ω(node.Annotations).Should(Equal([]internal.Annotation{{Key: "risk", Value: "low"}}))
+			ExpectAllWell(errors)
+		})
+
+		It("cannot be applied to setup nodes", func() {
+			node, errors := internal.NewNode(dt, ntBef, "", body, cl, Annotate("risk", "high"))
+			Ω(node).Should(BeZero())
+			Ω(errors).Should(ConsistOf(types.GinkgoErrors.InvalidDecoratorForNodeType(cl, ntBef, "Annotate")))
+			Ω(dt.DidTrackDeprecations()).Should(BeFalse())
+		})
+	})
+
+	Describe("The SpecPriority decoration", func() {
+		It("records the priority on Its and containers", func() {
+			node, errors := internal.NewNode(dt, ntIt, "text", body, SpecPriority(3))
+			Ω(node.SpecPriority).Should(Equal(3))
+			Ω(node.HasSpecPriority).Should(BeTrue())
+			ExpectAllWell(errors)
+
+			node, errors = internal.NewNode(dt, ntCon, "text", body, SpecPriority(0))
+			Ω(node.SpecPriority).Should(Equal(0))
+			Ω(node.HasSpecPriority).Should(BeTrue())
+			ExpectAllWell(errors)
+
+			node, errors = internal.NewNode(dt, ntIt, "text", body)
+			Ω(node.HasSpecPriority).Should(BeFalse())
+			ExpectAllWell(errors)
+		})
+
+		It("cannot be applied to setup nodes", func() {
+			node, errors := internal.NewNode(dt, ntBef, "", body, cl, SpecPriority(3))
+			Ω(node).Should(BeZero())
+			Ω(errors).Should(ConsistOf(types.GinkgoErrors.InvalidDecoratorForNodeType(cl, ntBef, "SpecPriority")))
+			Ω(dt.DidTrackDeprecations()).Should(BeFalse())
+		})
+	})
+
+	Describe("The WithEnv decoration", func() {
+		It("records the environment variables on containers, Its, and setup nodes", func() {
+			node, errors := internal.NewNode(dt, ntIt, "text", body, WithEnv("REGION", "eu"), WithEnv("TIER", "gold"))
+			Ω(node.Env).Should(Equal([]internal.EnvVar{{Key: "REGION", Value: "eu"}, {Key: "TIER", Value: "gold"}}))
+			ExpectAllWell(errors)
+
+			node, errors = internal.NewNode(dt, ntCon, "text", body, WithEnv("REGION", "us"))
+			Ω(node.Env).Should(Equal([]internal.EnvVar{{Key: "REGION", Value: "us"}}))
+			ExpectAllWell(errors)
+
+			node, errors = internal.NewNode(dt, ntBef, "", body, WithEnv("REGION", "ap"))
+			Ω(node.Env).Should(Equal([]internal.EnvVar{{Key: "REGION", Value: "ap"}}))
+			ExpectAllWell(errors)
+
+			node, errors = internal.NewNode(dt, types.NodeTypeBeforeSuite, "", body, WithEnv("REGION", "ap"))
+			Ω(node.Env).Should(Equal([]internal.EnvVar{{Key: "REGION", Value: "ap"}}))
+			ExpectAllWell(errors)
+		})
+	})
+
+	Describe("The CPUTimeLimit decoration", func() {
+		It("records the limit on Its and containers", func() {
+			node, errors := internal.NewNode(dt, ntIt, "text", body, CPUTimeLimit(time.Second))
+			Ω(node.CPUTimeLimit).Should(Equal(time.Second))
+			ExpectAllWell(errors)
+
+			node, errors = internal.NewNode(dt, ntCon, "text", body, CPUTimeLimit(time.Minute))
+			Ω(node.CPUTimeLimit).Should(Equal(time.Minute))
+			ExpectAllWell(errors)
+		})
+
+		It("cannot be applied to setup nodes", func() {
+			node, errors := internal.NewNode(dt, ntBef, "", body, cl, CPUTimeLimit(time.Second))
+			Ω(node).Should(BeZero())
+			Ω(errors).Should(ConsistOf(types.GinkgoErrors.InvalidDecoratorForNodeType(cl, ntBef, "CPUTimeLimit")))
+			Ω(dt.DidTrackDeprecations()).Should(BeFalse())
+		})
+	})
+
+	Describe("The AssertionDefaults decoration", func() {
+		It("records the assertion defaults on Its and containers, merging multiple decorations", func() {
+			node, errors := internal.NewNode(dt, ntIt, "text", body, AssertionDefaults{EventuallyTimeout: time.Minute}, AssertionDefaults{EventuallyPollingInterval: time.Second})
+			Ω(node.AssertionDefaults).Should(Equal(AssertionDefaults{EventuallyTimeout: time.Minute, EventuallyPollingInterval: time.Second}))
+			ExpectAllWell(errors)
+
+			node, errors = internal.NewNode(dt, ntCon, "text", body, AssertionDefaults{ConsistentlyDuration: time.Second})
+			Ω(node.AssertionDefaults).Should(Equal(AssertionDefaults{ConsistentlyDuration: time.Second}))
+			ExpectAllWell(errors)
+		})
+
+		It("cannot be applied to setup nodes", func() {
+			node, errors := internal.NewNode(dt, ntBef, "", body, cl, AssertionDefaults{EventuallyTimeout: time.Minute})
+			Ω(node).Should(BeZero())
+			Ω(errors).Should(ConsistOf(types.GinkgoErrors.InvalidDecoratorForNodeType(cl, ntBef, "AssertionDefaults")))
+			Ω(dt.DidTrackDeprecations()).Should(BeFalse())
+		})
+	})
+
+	Describe("The SkipIf decoration", func() {
+		It("records the skip conditions on Its and containers", func() {
+			skipCondition := internal.SkipCondition{Condition: func() bool { return true }, Reason: "no docker", CodeLocation: cl}
+			node, errors := internal.NewNode(dt, ntIt, "text", body, skipCondition)
+			Ω(node.SkipConditions).Should(HaveLen(1))
+			Ω(node.SkipConditions[0].Reason).Should(Equal("no docker"))
+			ExpectAllWell(errors)
+
+			node, errors = internal.NewNode(dt, ntCon, "text", body, skipCondition, skipCondition)
+			Ω(node.SkipConditions).Should(HaveLen(2))
+			ExpectAllWell(errors)
+		})
+
+		It("cannot be applied to setup nodes", func() {
+			node, errors := internal.NewNode(dt, ntBef, "", body, cl, internal.SkipCondition{Condition: func() bool { return true }})
+			Ω(node).Should(BeZero())
+			Ω(errors).Should(ConsistOf(types.GinkgoErrors.InvalidDecoratorForNodeType(cl, ntBef, "SkipIf")))
+			Ω(dt.DidTrackDeprecations()).Should(BeFalse())
+		})
+
+		It("requires a condition", func() {
+			node, errors := internal.NewNode(dt, ntIt, "text", body, internal.SkipCondition{Reason: "no docker", CodeLocation: cl})
+			Ω(node).Should(BeZero())
+			Ω(errors).Should(ConsistOf(types.GinkgoErrors.InvalidSkipIfCondition(cl)))
+		})
+	})
+
 	Describe("passing in functions", func() {
 		It("works when a single function is passed in", func() {
 			node, errors := internal.NewNode(dt, ntIt, "text", body, cl)
@@ -334,6 +665,35 @@ var _ = Describe("Constructing nodes", func() {
 			Ω(dt.DeprecationsReport()).Should(ContainSubstring(types.Deprecations.Async().Message))
 		})
 
+		It("accepts functions that take a SpecContext", func() {
+			var received internal.SpecContext
+			node, errors := internal.NewNode(dt, ntIt, "text", func(ctx internal.SpecContext) {
+				received = ctx
+			}, cl)
+			ExpectAllWell(errors)
+			Ω(node.Body).Should(BeNil())
+			Ω(node.HasBody()).Should(BeTrue())
+			sc := internal.NewSpecContext(context.Background(), internal.NewSuite())
+			node.BodyWithContext(sc)
+			Ω(received).Should(Equal(sc))
+		})
+
+		It("accepts functions that take a context.Context", func() {
+			node, errors := internal.NewNode(dt, types.NodeTypeBeforeEach, "", func(ctx context.Context) {
+				didRun = true
+			}, cl)
+			ExpectAllWell(errors)
+			node.BodyWithContext(internal.NewSpecContext(context.Background(), nil))
+			Ω(didRun).Should(BeTrue())
+		})
+
+		It("errors if a context-accepting function is passed to a node that does not accept contexts", func() {
+			f := func(ctx context.Context) {}
+			node, errors := internal.NewNode(dt, ntCon, "text", f, cl)
+			Ω(node).Should(BeZero())
+			Ω(errors).Should(ConsistOf(types.GinkgoErrors.InvalidBodyType(reflect.TypeOf(f), cl, ntCon)))
+		})
+
 		It("errors if more than one function is provided", func() {
 			node, errors := internal.NewNode(dt, ntIt, "text", body, body, cl)
 			Ω(node).Should(BeZero())
@@ -425,6 +785,18 @@ var _ = Describe("Node", func() {
 				Ω(node.CodeLocation).Should(Equal(cl))
 				Ω(node.NestingLevel).Should(Equal(0))
 			})
+
+			It("supports the NodeTimeout decoration", func() {
+				node, errors := internal.NewSynchronizedBeforeSuiteNode(func() []byte { return nil }, func(_ []byte) {}, cl, NodeTimeout(time.Minute))
+				Ω(errors).Should(BeEmpty())
+				Ω(node.NodeTimeout).Should(Equal(time.Minute))
+			})
+
+			It("errors when passed any other decoration", func() {
+				node, errors := internal.NewSynchronizedBeforeSuiteNode(func() []byte { return nil }, func(_ []byte) {}, cl, Focus)
+				Ω(node).Should(BeZero())
+				Ω(errors).Should(ConsistOf(types.GinkgoErrors.UnknownDecorator(cl, types.NodeTypeSynchronizedBeforeSuite, Focus)))
+			})
 		})
 
 		Describe("NewSynchronizedAfterSuiteNode", func() {
@@ -447,6 +819,12 @@ var _ = Describe("Node", func() {
 				Ω(node.CodeLocation).Should(Equal(cl))
 				Ω(node.NestingLevel).Should(Equal(0))
 			})
+
+			It("supports the NodeTimeout decoration", func() {
+				node, errors := internal.NewSynchronizedAfterSuiteNode(func() {}, func() {}, cl, NodeTimeout(time.Minute))
+				Ω(errors).Should(BeEmpty())
+				Ω(node.NodeTimeout).Should(Equal(time.Minute))
+			})
 		})
 
 		Describe("NewReportBeforeEachNode", func() {
@@ -503,6 +881,58 @@ var _ = Describe("Node", func() {
 			})
 		})
 
+		Describe("NewAroundEachNode", func() {
+			It("returns a correctly configured node", func() {
+				var didRunSpec bool
+				body := func(runSpec func()) { runSpec() }
+
+				node, errors := internal.NewAroundEachNode(body, cl)
+				Ω(errors).Should(BeEmpty())
+				Ω(node.ID).Should(BeNumerically(">", 0))
+				Ω(node.NodeType).Should(Equal(types.NodeTypeAroundEach))
+
+				node.AroundEachBody(func() { didRunSpec = true })
+				Ω(didRunSpec).Should(BeTrue())
+
+				Ω(node.CodeLocation).Should(Equal(cl))
+				Ω(node.NestingLevel).Should(Equal(-1))
+			})
+
+			It("errors when passed a nil body", func() {
+				node, errors := internal.NewAroundEachNode(nil, cl)
+				Ω(node).Should(BeZero())
+				Ω(errors).Should(ConsistOf(types.GinkgoErrors.InvalidAroundEach(cl)))
+			})
+		})
+
+		Describe("NewReadinessGateNode", func() {
+			It("returns a correctly configured node", func() {
+				var didRun bool
+				check := func() error { didRun = true; return nil }
+				node, errors := internal.NewReadinessGateNode(check, time.Minute, cl)
+				Ω(errors).Should(BeEmpty())
+				Ω(node.ID).Should(BeNumerically(">", 0))
+				Ω(node.NodeType).Should(Equal(types.NodeTypeReadinessGate))
+				Ω(node.ReadinessGateTimeout).Should(Equal(time.Minute))
+
+				Ω(node.ReadinessGateCheck()).Should(Succeed())
+				Ω(didRun).Should(BeTrue())
+
+				Ω(node.CodeLocation).Should(Equal(cl))
+				Ω(node.NestingLevel).Should(Equal(0))
+			})
+
+			It("errors when passed a nil check or a non-positive timeout", func() {
+				node, errors := internal.NewReadinessGateNode(nil, time.Minute, cl)
+				Ω(node).Should(BeZero())
+				Ω(errors).Should(ConsistOf(types.GinkgoErrors.InvalidReadinessGate(cl)))
+
+				node, errors = internal.NewReadinessGateNode(func() error { return nil }, 0, cl)
+				Ω(node).Should(BeZero())
+				Ω(errors).Should(ConsistOf(types.GinkgoErrors.InvalidReadinessGate(cl)))
+			})
+		})
+
 		Describe("NewCleanupNode", func() {
 			var capturedFailure string
 			var capturedCL types.CodeLocation
@@ -615,6 +1045,34 @@ var _ = Describe("Node", func() {
 				})
 			})
 
+			Context("when passed a CleanupPriority", func() {
+				It("records the priority and does not pass it to the function", func() {
+					didRun := false
+					node, errs := internal.NewCleanupNode(failFunc, cl, internal.CleanupPriority(10), func() {
+						didRun = true
+					})
+					Ω(node.CleanupPriority).Should(Equal(internal.CleanupPriority(10)))
+					Ω(errs).Should(BeEmpty())
+
+					node.Body()
+					Ω(didRun).Should(BeTrue())
+				})
+			})
+
+			Context("when passed OnProcess1", func() {
+				It("records it and does not pass it to the function", func() {
+					didRun := false
+					node, errs := internal.NewCleanupNode(failFunc, cl, internal.OnProcess1, func() {
+						didRun = true
+					})
+					Ω(node.CleanupOnProcess1).Should(BeTrue())
+					Ω(errs).Should(BeEmpty())
+
+					node.Body()
+					Ω(didRun).Should(BeTrue())
+				})
+			})
+
 			Context("controlling the cleanup's code location", func() {
 				It("computes its own when one is not provided", func() {
 					node, errs := func() (internal.Node, []error) {
@@ -928,6 +1386,18 @@ var _ = Describe("Nodes", func() {
 		})
 	})
 
+	Describe("ResourceRequirements", func() {
+		It("takes the largest requirement in each dimension across the hierarchy", func() {
+			nodes := Nodes{
+				N(ntCon, Requires(CPU(2), Memory("1Gi"))),
+				N(ntCon),
+				N(ntIt, Requires(CPU(1), GPU(1))),
+			}
+			Ω(nodes.ResourceRequirements()).Should(Equal(types.ResourceRequirements{CPU: 2, MemoryBytes: 1 << 30, GPU: 1}))
+			Ω(Nodes{N(ntIt)}.ResourceRequirements().IsZero()).Should(BeTrue())
+		})
+	})
+
 	Describe("Labels and UnionOfLabels", func() {
 		var nodes Nodes
 		BeforeEach(func() {
@@ -950,6 +1420,28 @@ var _ = Describe("Nodes", func() {
 		})
 	})
 
+	Describe("Phase", func() {
+		It("returns the innermost phase", func() {
+			Ω(Nodes{N(Phase("a")), N(), N(Phase("b")), N()}.Phase()).Should(Equal("b"))
+		})
+
+		It("returns the empty string when no node declares a phase", func() {
+			Ω(Nodes{N(), N(Label("A"))}.Phase()).Should(BeEmpty())
+		})
+	})
+
+	Describe("SpecPriority", func() {
+		It("returns the innermost priority - even if it is 0", func() {
+			Ω(Nodes{N(ntCon, SpecPriority(3)), N(ntCon), N(ntIt, SpecPriority(5))}.SpecPriority()).Should(Equal(5))
+			Ω(Nodes{N(ntCon, SpecPriority(3)), N(ntIt, SpecPriority(0))}.SpecPriority()).Should(Equal(0))
+			Ω(Nodes{N(ntCon, SpecPriority(3)), N(ntIt)}.SpecPriority()).Should(Equal(3))
+		})
+
+		It("returns 0 when no node declares a priority", func() {
+			Ω(Nodes{N(ntCon), N(ntIt)}.SpecPriority()).Should(Equal(0))
+		})
+	})
+
 	Describe("CodeLocation", func() {
 		var nodes Nodes
 		var cl1, cl2 types.CodeLocation
@@ -964,6 +1456,22 @@ var _ = Describe("Nodes", func() {
 		})
 	})
 
+	Describe("SourceFiles", func() {
+		It("returns the sorted, distinct file names of the nodes' code locations", func() {
+			nodes := Nodes{
+				N(types.CodeLocation{FileName: "/b_test.go", LineNumber: 3}),
+				N(types.CodeLocation{FileName: "/a_test.go", LineNumber: 10}),
+				N(types.CodeLocation{FileName: "/b_test.go", LineNumber: 17}),
+				N(types.CodeLocation{}),
+			}
+			Ω(nodes.SourceFiles()).Should(Equal([]string{"/a_test.go", "/b_test.go"}))
+		})
+
+		It("returns an empty slice when there are no nodes", func() {
+			Ω(Nodes{}.SourceFiles()).Should(BeEmpty())
+		})
+	})
+
 	Describe("BestTextFor", func() {
 		var nIt, nBef1, nBef2 Node
 		var nodes Nodes
@@ -3,6 +3,7 @@ package internal
 import (
 	"math/rand"
 	"sort"
+	"strings"
 
 	"github.com/onsi/ginkgo/v2/types"
 )
@@ -100,6 +101,19 @@ func OrderSpecs(specs Specs, suiteConfig types.SuiteConfig) (GroupedSpecIndices,
 		}
 	}
 
+	// specs decorated with a higher SpecPriority run first.  The sort is stable so specs with the same priority keep their randomized order.
+	// an Ordered container runs as a single group, so the whole container takes on the highest priority of any of its specs
+	groupPriority := func(specIndices SpecIndices) int {
+		priority := specs[specIndices[0]].Nodes.SpecPriority()
+		for _, idx := range specIndices[1:] {
+			priority = max(priority, specs[idx].Nodes.SpecPriority())
+		}
+		return priority
+	}
+	sort.SliceStable(orderedGroups, func(i, j int) bool {
+		return groupPriority(orderedGroups[i]) > groupPriority(orderedGroups[j])
+	})
+
 	// If we're running in series, we're done.
 	if suiteConfig.ParallelTotal == 1 {
 		return orderedGroups, GroupedSpecIndices{}
@@ -119,3 +133,45 @@ func OrderSpecs(specs Specs, suiteConfig types.SuiteConfig) (GroupedSpecIndices,
 
 	return parallelizableGroups, serialGroups
 }
+
+// PartitionPinnedGroups splits out the groups that carry a label pinned to a parallel process with --pin-label-to-process.
+// It returns the groups that can run on any process and the groups pinned to the passed-in process.  Groups pinned to other processes are dropped.
+// A group that carries labels pinned to several processes is pinned to the lowest-numbered one.
+func PartitionPinnedGroups(specs Specs, groups GroupedSpecIndices, pins map[string]int, process int) (GroupedSpecIndices, GroupedSpecIndices) {
+	if len(pins) == 0 {
+		return groups, GroupedSpecIndices{}
+	}
+	sharedGroups, pinnedGroups := GroupedSpecIndices{}, GroupedSpecIndices{}
+	for _, specIndices := range groups {
+		pinnedProcess := 0
+		for _, idx := range specIndices {
+			for _, label := range specs[idx].Nodes.UnionOfLabels() {
+				if p, ok := pins[strings.ToLower(label)]; ok && (pinnedProcess == 0 || p < pinnedProcess) {
+					pinnedProcess = p
+				}
+			}
+		}
+		if pinnedProcess == 0 {
+			sharedGroups = append(sharedGroups, specIndices)
+		} else if pinnedProcess == process {
+			pinnedGroups = append(pinnedGroups, specIndices)
+		}
+	}
+	return sharedGroups, pinnedGroups
+}
+
+// PartitionGroupsByPhase splits the ordered groups into one set of groups per execution phase, in the order the phases appear in phaseOrder.
+// The relative order of the groups within each phase is preserved.
+func PartitionGroupsByPhase(specs Specs, groups GroupedSpecIndices, phaseOrder PhaseOrder) []GroupedSpecIndices {
+	phaseIndices := map[string]int{}
+	out := make([]GroupedSpecIndices, len(phaseOrder))
+	for i, phase := range phaseOrder {
+		phaseIndices[phase] = i
+		out[i] = GroupedSpecIndices{}
+	}
+	for _, specIndices := range groups {
+		i := phaseIndices[specs[specIndices[0]].Nodes.Phase()]
+		out[i] = append(out[i], specIndices)
+	}
+	return out
+}
@@ -214,6 +214,36 @@ var _ = Describe("OrderSpecs", func() {
 		})
 	})
 
+	Context("when specs are decorated with SpecPriority", func() {
+		BeforeEach(func() {
+			con1 := N(ntCon, SpecPriority(5))
+			con2 := N(ntCon, Ordered)
+			specs = Specs{
+				S(N("A", ntIt)),
+				S(N("B", ntIt, SpecPriority(-1))),
+				S(con1, N("C", ntIt)),
+				S(con1, N("D", ntIt, SpecPriority(0))),
+				S(con2, N("E", ntIt)),
+				S(con2, N("F", ntIt, SpecPriority(10))),
+				S(N("G", ntIt)),
+			}
+			conf.RandomizeAllSpecs = true
+		})
+
+		It("runs specs with a higher priority first, preserving the randomized order of specs with the same priority", func() {
+			orders := map[string]bool{}
+			for conf.RandomSeed = 1; conf.RandomSeed < 10; conf.RandomSeed += 1 {
+				groupedSpecIndices, _ := internal.OrderSpecs(specs, conf)
+				texts := getTexts(specs, groupedSpecIndices).Join()
+				Ω(texts).Should(HavePrefix("EFC"))
+				Ω(texts).Should(HaveSuffix("B"))
+				Ω(strings.Split(texts[3:6], "")).Should(ConsistOf("A", "D", "G"))
+				orders[texts] = true
+			}
+			Ω(len(orders)).Should(BeNumerically(">", 1))
+		})
+	})
+
 	Context("when there are serial specs", func() {
 		BeforeEach(func() {
 			con1 := N(ntCon, Ordered, Serial)
@@ -277,3 +307,65 @@ var _ = Describe("OrderSpecs", func() {
 		})
 	})
 })
+
+var _ = Describe("PartitionGroupsByPhase", func() {
+	It("splits the groups by phase, following the phase order and preserving the order within each phase", func() {
+		provision := N(ntCon, Phase("provision"))
+		teardown := N(ntCon, Phase("teardown"))
+		specs := Specs{
+			S(teardown, N("A", ntIt)),
+			S(N("B", ntIt)),
+			S(provision, N("C", ntIt)),
+			S(teardown, N("D", ntIt)),
+			S(provision, N("E", ntIt, Phase("teardown"))),
+			S(provision, N("F", ntIt)),
+		}
+		groups := internal.GroupedSpecIndices{{5}, {4}, {3}, {2}, {1}, {0}}
+
+		partitions := internal.PartitionGroupsByPhase(specs, groups, internal.PhaseOrder{"", "provision", "test", "teardown"})
+		Ω(partitions).Should(HaveLen(4))
+		Ω(getTexts(specs, partitions[0])).Should(Equal(SpecTexts{"B"}))
+		Ω(getTexts(specs, partitions[1])).Should(Equal(SpecTexts{"F", "C"}))
+		Ω(partitions[2]).Should(BeEmpty())
+		Ω(getTexts(specs, partitions[3])).Should(Equal(SpecTexts{"E", "D", "A"}))
+	})
+})
+
+var _ = Describe("PartitionPinnedGroups", func() {
+	var specs Specs
+	var groups internal.GroupedSpecIndices
+	BeforeEach(func() {
+		ui := N(ntCon, Label("UI"))
+		specs = Specs{
+			S(ui, N("A", ntIt)),
+			S(N("B", ntIt)),
+			S(N("C", ntIt, Label("device"))),
+			S(ui, N("D", ntIt, Label("device"))),
+			S(N("E", ntIt)),
+			S(N("F", ntIt, Label("device"))),
+		}
+		groups = internal.GroupedSpecIndices{{0}, {1}, {2}, {3}, {4, 5}}
+	})
+
+	It("leaves the groups alone when nothing is pinned", func() {
+		shared, pinned := internal.PartitionPinnedGroups(specs, groups, map[string]int{}, 1)
+		Ω(shared).Should(Equal(groups))
+		Ω(pinned).Should(BeEmpty())
+	})
+
+	It("returns the shared groups and the groups pinned to the passed-in process, matching labels case-insensitively", func() {
+		pins := map[string]int{"ui": 1, "device": 2}
+
+		shared, pinned := internal.PartitionPinnedGroups(specs, groups, pins, 1)
+		Ω(getTexts(specs, shared)).Should(Equal(SpecTexts{"B"}))
+		Ω(getTexts(specs, pinned)).Should(Equal(SpecTexts{"A", "D"}))
+
+		shared, pinned = internal.PartitionPinnedGroups(specs, groups, pins, 2)
+		Ω(getTexts(specs, shared)).Should(Equal(SpecTexts{"B"}))
+		Ω(getTexts(specs, pinned)).Should(Equal(SpecTexts{"C", "E", "F"}))
+
+		shared, pinned = internal.PartitionPinnedGroups(specs, groups, pins, 3)
+		Ω(getTexts(specs, shared)).Should(Equal(SpecTexts{"B"}))
+		Ω(pinned).Should(BeEmpty())
+	})
+})
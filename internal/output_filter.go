@@ -0,0 +1,104 @@
+package internal
+
+import (
+	"io"
+	"regexp"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+/*
+An OutputFilter rewrites intercepted stdout/stderr output before it is stored and reported.
+
+If Suppress is true every line that matches Pattern is dropped.  Otherwise every match of Pattern is replaced with Replacement (which can refer to submatches with $1, ${name}, etc. - see regexp.Regexp.ReplaceAllString)
+*/
+type OutputFilter struct {
+	Pattern     *regexp.Regexp
+	Replacement string
+	Suppress    bool
+}
+
+func (f OutputFilter) Apply(s string) string {
+	if !f.Suppress {
+		return f.Pattern.ReplaceAllString(s, f.Replacement)
+	}
+	lines := strings.SplitAfter(s, "\n")
+	out := &strings.Builder{}
+	for _, line := range lines {
+		if f.Pattern.MatchString(strings.TrimSuffix(line, "\n")) {
+			continue
+		}
+		out.WriteString(line)
+	}
+	return out.String()
+}
+
+/*
+OutputFilters is the set of OutputFilters registered with the suite.  Filters are applied in the order they were added.
+*/
+type OutputFilters struct {
+	lock    *sync.Mutex
+	filters []OutputFilter
+	//len is checked on every write to the GinkgoWriter so it is maintained separately, and accessed atomically, to keep the common case of no filters lock-free
+	len int32
+}
+
+func NewOutputFilters() *OutputFilters {
+	return &OutputFilters{lock: &sync.Mutex{}}
+}
+
+func (f *OutputFilters) Add(filter OutputFilter) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	f.filters = append(f.filters, filter)
+	atomic.StoreInt32(&f.len, int32(len(f.filters)))
+}
+
+func (f *OutputFilters) Len() int {
+	return int(atomic.LoadInt32(&f.len))
+}
+
+func (f *OutputFilters) Apply(s string) string {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	for _, filter := range f.filters {
+		s = filter.Apply(s)
+	}
+	return s
+}
+
+/*
+NewFilteringOutputInterceptor wraps interceptor so that the output it returns, and the output it forwards, passes through filters first.
+
+Forwarded output is filtered one write at a time so a match that straddles two writes will not be filtered in the forwarded stream.  The returned output is always filtered in full.
+*/
+func NewFilteringOutputInterceptor(interceptor OutputInterceptor, filters *OutputFilters) OutputInterceptor {
+	return &filteringOutputInterceptor{
+		OutputInterceptor: interceptor,
+		filters:           filters,
+	}
+}
+
+type filteringOutputInterceptor struct {
+	OutputInterceptor
+	filters *OutputFilters
+}
+
+func (interceptor *filteringOutputInterceptor) StartInterceptingOutputAndForwardTo(w io.Writer) {
+	interceptor.OutputInterceptor.StartInterceptingOutputAndForwardTo(filteringWriter{w: w, filters: interceptor.filters})
+}
+
+func (interceptor *filteringOutputInterceptor) StopInterceptingAndReturnOutput() string {
+	return interceptor.filters.Apply(interceptor.OutputInterceptor.StopInterceptingAndReturnOutput())
+}
+
+type filteringWriter struct {
+	w       io.Writer
+	filters *OutputFilters
+}
+
+func (w filteringWriter) Write(p []byte) (int, error) {
+	_, err := w.w.Write([]byte(w.filters.Apply(string(p))))
+	return len(p), err
+}
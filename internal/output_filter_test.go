@@ -0,0 +1,77 @@
+package internal_test
+
+import (
+	"regexp"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+
+	"github.com/onsi/ginkgo/v2/internal"
+	"github.com/onsi/ginkgo/v2/internal/test_helpers"
+)
+
+var _ = Describe("OutputFilters", func() {
+	var filters *internal.OutputFilters
+
+	BeforeEach(func() {
+		filters = internal.NewOutputFilters()
+	})
+
+	It("does nothing when no filters are registered", func() {
+		Ω(filters.Apply("hello\nworld\n")).Should(Equal("hello\nworld\n"))
+	})
+
+	It("redacts matches", func() {
+		filters.Add(internal.OutputFilter{Pattern: regexp.MustCompile(`token=(\w+)`), Replacement: "token=<REDACTED>"})
+		Ω(filters.Apply("token=abc and token=def\n")).Should(Equal("token=<REDACTED> and token=<REDACTED>\n"))
+	})
+
+	It("supports submatch references in the replacement", func() {
+		filters.Add(internal.OutputFilter{Pattern: regexp.MustCompile(`user=(\w+) password=\w+`), Replacement: "user=$1 password=***"})
+		Ω(filters.Apply("user=bob password=hunter2")).Should(Equal("user=bob password=***"))
+	})
+
+	It("suppresses matching lines", func() {
+		filters.Add(internal.OutputFilter{Pattern: regexp.MustCompile(`^DUMP`), Suppress: true})
+		Ω(filters.Apply("a\nDUMP 0xdeadbeef\nb\nDUMP")).Should(Equal("a\nb\n"))
+	})
+
+	It("applies filters in the order they were added", func() {
+		filters.Add(internal.OutputFilter{Pattern: regexp.MustCompile(`secret`), Replacement: "DROP ME"})
+		filters.Add(internal.OutputFilter{Pattern: regexp.MustCompile(`DROP ME`), Suppress: true})
+		Ω(filters.Apply("keep\nthe secret\n")).Should(Equal("keep\n"))
+	})
+
+	Describe("the filtering output interceptor", func() {
+		var fake *test_helpers.FakeOutputInterceptor
+		var interceptor internal.OutputInterceptor
+
+		BeforeEach(func() {
+			filters.Add(internal.OutputFilter{Pattern: regexp.MustCompile(`secret`), Replacement: "***"})
+			fake = test_helpers.NewFakeOutputInterceptor()
+			interceptor = internal.NewFilteringOutputInterceptor(fake, filters)
+		})
+
+		It("filters the returned output", func() {
+			interceptor.StartInterceptingOutput()
+			fake.AppendInterceptedOutput("my secret\n")
+			Ω(interceptor.StopInterceptingAndReturnOutput()).Should(Equal("my ***\n"))
+		})
+
+		It("filters forwarded output", func() {
+			buffer := gbytes.NewBuffer()
+			interceptor.StartInterceptingOutputAndForwardTo(buffer)
+			fake.AppendInterceptedOutput("my secret\n")
+			Ω(interceptor.StopInterceptingAndReturnOutput()).Should(Equal("my ***\n"))
+			Ω(buffer.Contents()).Should(Equal([]byte("my ***\n")))
+		})
+
+		It("applies filters registered after interception started", func() {
+			interceptor.StartInterceptingOutput()
+			fake.AppendInterceptedOutput("my secret\nDUMP\n")
+			filters.Add(internal.OutputFilter{Pattern: regexp.MustCompile(`DUMP`), Suppress: true})
+			Ω(interceptor.StopInterceptingAndReturnOutput()).Should(Equal("my ***\n"))
+		})
+	})
+})
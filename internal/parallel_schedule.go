@@ -0,0 +1,64 @@
+package internal
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/onsi/ginkgo/v2/types"
+)
+
+/*
+LoadParallelSchedule loads the parallel schedule recorded for a suite from a JSON report generated with --json-report.
+
+JSON reports can contain reports for many suites.  The suite is identified by its path and, since the recorded run may have happened on a different machine (e.g. on CI), by its description if no report matches the path.
+*/
+func LoadParallelSchedule(path string, suitePath string, suiteDescription string) (types.ParallelSchedule, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return types.ParallelSchedule{}, types.GinkgoErrors.ParallelScheduleUnavailable(path, err.Error())
+	}
+	reports := []types.Report{}
+	err = json.Unmarshal(data, &reports)
+	if err != nil {
+		return types.ParallelSchedule{}, types.GinkgoErrors.ParallelScheduleUnavailable(path, fmt.Sprintf("The file is not a valid Ginkgo JSON report: %s", err.Error()))
+	}
+
+	candidates := []types.Report{}
+	for _, report := range reports {
+		if report.SuitePath == suitePath {
+			candidates = []types.Report{report}
+			break
+		}
+		if report.SuiteDescription == suiteDescription {
+			candidates = append(candidates, report)
+		}
+	}
+	if len(candidates) == 0 {
+		return types.ParallelSchedule{}, types.GinkgoErrors.ParallelScheduleUnavailable(path, fmt.Sprintf("The report does not contain a report for the suite \"%s\" at %s.", suiteDescription, suitePath))
+	}
+	if len(candidates) > 1 {
+		return types.ParallelSchedule{}, types.GinkgoErrors.ParallelScheduleUnavailable(path, fmt.Sprintf("The report contains more than one report for the suite \"%s\" and none of them are at %s.", suiteDescription, suitePath))
+	}
+	if candidates[0].ParallelSchedule.IsZero() {
+		return types.ParallelSchedule{}, types.GinkgoErrors.ParallelScheduleUnavailable(path, fmt.Sprintf("The report for the suite \"%s\" does not contain a parallel schedule.  Parallel schedules are only recorded when running in parallel.", suiteDescription))
+	}
+	return candidates[0].ParallelSchedule, nil
+}
+
+// MakeParallelScheduleReplayCounter returns a counter that emits the groups recorded for the passed-in process, in order.
+// Once the recorded groups are exhausted it emits numGroups to signal that there are no more groups to run.
+func MakeParallelScheduleReplayCounter(schedule types.ParallelSchedule, process int, numGroups int) func() (int, error) {
+	groupIndices := schedule.GroupIndices[process]
+	idx := -1
+	return func() (int, error) {
+		idx += 1
+		if idx >= len(groupIndices) {
+			return numGroups, nil
+		}
+		if groupIndices[idx] < 0 || groupIndices[idx] >= numGroups {
+			return 0, fmt.Errorf("the parallel schedule refers to group %d but there are only %d groups", groupIndices[idx], numGroups)
+		}
+		return groupIndices[idx], nil
+	}
+}
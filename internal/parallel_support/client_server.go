@@ -15,13 +15,32 @@ type BeforeSuiteState struct {
 	State types.SpecState
 }
 
+// PreflightResult carries the outcome of the preflight checks process #1 runs to the other processes
+type PreflightResult struct {
+	Completed bool
+	Failures  []types.PreflightFailure
+}
+
 type ParallelIndexCounter struct {
 	Index int
 }
 
+// CounterRequest identifies the process asking for the next group of specs to run and the number of groups in the suite.
+// The server uses this to tell when a process is busy running a group so that it can cap the number of groups that run at once.
+type CounterRequest struct {
+	Process   int
+	NumGroups int
+}
+
+type PhaseBarrier struct {
+	Process int
+	Phase   int
+}
+
 var ErrorGone = fmt.Errorf("gone")
 var ErrorFailed = fmt.Errorf("failed")
 var ErrorEarly = fmt.Errorf("early")
+var ErrorInterrupted = fmt.Errorf("interrupted")
 
 var POLLING_INTERVAL = 50 * time.Millisecond
 
@@ -33,25 +52,46 @@ type Server interface {
 	GetSuiteDone() chan interface{}
 	GetOutputDestination() io.Writer
 	SetOutputDestination(io.Writer)
+	SetActiveProcsLimit(limit int)
 }
 
 type Client interface {
 	Connect() bool
 	Close() error
 
+	Handshake() error
+	PostProcessFingerprint(fingerprint ProcessFingerprint) error
 	PostSuiteWillBegin(report types.Report) error
 	PostDidRun(report types.SpecReport) error
+	PostEmitProgressReport(report types.ProgressReport) error
 	PostSuiteDidEnd(report types.Report) error
 	PostSynchronizedBeforeSuiteCompleted(state types.SpecState, data []byte) error
 	BlockUntilSynchronizedBeforeSuiteData() (types.SpecState, []byte, error)
+	PostPreflightCompleted(failures []types.PreflightFailure) error
+	BlockUntilPreflightCompleted() ([]types.PreflightFailure, error)
 	BlockUntilNonprimaryProcsHaveFinished() error
 	BlockUntilAggregatedNonprimaryProcsReport() (types.Report, error)
-	FetchNextCounter() (int, error)
+	FetchNextCounter(process int, numGroups int) (int, error)
+	FetchSpecRateDelay(labels []string, limits map[string]int) (time.Duration, error)
+	BlockUntilNonprimaryProcsReachedPhase(phase int) error
+	BlockUntilPhaseReleased(process int, phase int) error
+	PostPhaseReleased(phase int) error
+	BlockUntilResourcesAcquired(request ResourceRequest, interruptChannel <-chan interface{}) error
+	PostResourcesReleased(request ResourceRequest) error
 	PostAbort() error
 	ShouldAbort() bool
 	Write(p []byte) (int, error)
 }
 
+// checkProtocolVersion turns a mismatch between the client and server protocol versions into an error
+// A serverProtocolVersion of 0 means the server predates version negotiation
+func checkProtocolVersion(serverProtocolVersion int) error {
+	if serverProtocolVersion == types.PROTOCOL_VERSION || types.AllowVersionMismatch(os.Getenv) {
+		return nil
+	}
+	return types.GinkgoErrors.ParallelProtocolMismatch(serverProtocolVersion)
+}
+
 func NewServer(parallelTotal int, reporter reporters.Reporter) (Server, error) {
 	if os.Getenv("GINKGO_PARALLEL_PROTOCOL") == "HTTP" {
 		return newHttpServer(parallelTotal, reporter)
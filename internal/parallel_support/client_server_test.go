@@ -192,6 +192,22 @@ var _ = Describe("The Parallel Support Client & Server", func() {
 				})
 			})
 
+			Describe("Progress reports", func() {
+				It("forwards them to the attached reporter immediately", func() {
+					progressReport := types.ProgressReport{
+						ParallelProcess:    2,
+						LeafNodeText:       "A",
+						CurrentNodeType:    types.NodeTypeIt,
+						NodeGoroutineStack: "goroutine 17 [sleep]:",
+					}
+					Ω(client.PostEmitProgressReport(progressReport)).Should(Succeed())
+					Ω(reporter.ProgressReports).Should(HaveLen(1))
+					Ω(reporter.ProgressReports[0].LeafNodeText).Should(Equal("A"))
+					Ω(reporter.ProgressReports[0].CurrentNodeType).Should(Equal(types.NodeTypeIt))
+					Ω(reporter.ProgressReports[0].NodeGoroutineStack).Should(Equal("goroutine 17 [sleep]:"))
+				})
+			})
+
 			Describe("Streaming output", func() {
 				It("is configured to stream to stdout", func() {
 					server, err := parallel_support.NewServer(3, reporter)
@@ -364,12 +380,259 @@ var _ = Describe("The Parallel Support Client & Server", func() {
 					})
 				})
 
+				Describe("Synchronizing execution phases", func() {
+					It("blocks proc 1 until all non-primary procs reach the phase, and blocks the other procs until proc 1 releases it", func() {
+						proc1Done := make(chan interface{})
+						go func() {
+							defer GinkgoRecover()
+							Ω(client.BlockUntilNonprimaryProcsReachedPhase(1)).Should(Succeed())
+							close(proc1Done)
+						}()
+
+						proc2Done := make(chan interface{})
+						go func() {
+							defer GinkgoRecover()
+							Ω(client.BlockUntilPhaseReleased(2, 1)).Should(Succeed())
+							close(proc2Done)
+						}()
+						Consistently(proc1Done).ShouldNot(BeClosed())
+
+						close(proc3Exited)
+						Eventually(proc1Done).Should(BeClosed())
+						Consistently(proc2Done).ShouldNot(BeClosed())
+
+						Ω(client.PostPhaseReleased(1)).Should(Succeed())
+						Eventually(proc2Done).Should(BeClosed())
+					})
+
+					It("treats procs that have reached a later phase as having reached earlier phases, and earlier phases as released once a later phase is released", func() {
+						go client.BlockUntilPhaseReleased(2, 3)
+						go client.BlockUntilPhaseReleased(3, 2)
+						Eventually(func() error { return client.BlockUntilNonprimaryProcsReachedPhase(2) }).Should(Succeed())
+
+						Ω(client.PostPhaseReleased(3)).Should(Succeed())
+						Ω(client.BlockUntilPhaseReleased(2, 1)).Should(Succeed())
+					})
+
+					Context("when proc 1 disappears before releasing the phase", func() {
+						It("returns a meaningful error", func() {
+							close(proc1Exited)
+							Ω(client.BlockUntilPhaseReleased(2, 0)).Should(MatchError(types.GinkgoErrors.ExecutionPhaseDisappearedOnProc1()))
+						})
+					})
+				})
+
+				Describe("Sharing the preflight check results", func() {
+					It("blocks until proc 1 reports back, then passes the failures along", func() {
+						done := make(chan interface{})
+						go func() {
+							defer GinkgoRecover()
+							failures, err := client.BlockUntilPreflightCompleted()
+							Ω(err).ShouldNot(HaveOccurred())
+							Ω(failures).Should(ConsistOf(types.PreflightFailure{Check: "database", Message: "connection refused"}))
+							close(done)
+						}()
+						Consistently(done).ShouldNot(BeClosed())
+
+						Ω(client.PostPreflightCompleted([]types.PreflightFailure{{Check: "database", Message: "connection refused"}})).Should(Succeed())
+						Eventually(done).Should(BeClosed())
+					})
+
+					It("reports no failures when the checks pass", func() {
+						Ω(client.PostPreflightCompleted(nil)).Should(Succeed())
+						failures, err := client.BlockUntilPreflightCompleted()
+						Ω(err).ShouldNot(HaveOccurred())
+						Ω(failures).Should(BeEmpty())
+					})
+
+					Context("when proc 1 disappears before reporting back", func() {
+						It("returns a meaningful error", func() {
+							close(proc1Exited)
+							failures, err := client.BlockUntilPreflightCompleted()
+							Ω(failures).Should(BeEmpty())
+							Ω(err).Should(MatchError(types.GinkgoErrors.PreflightChecksDisappearedOnProc1()))
+						})
+					})
+				})
+
 				Describe("Fetching counters", func() {
 					It("returns ascending counters", func() {
-						Ω(client.FetchNextCounter()).Should(Equal(0))
-						Ω(client.FetchNextCounter()).Should(Equal(1))
-						Ω(client.FetchNextCounter()).Should(Equal(2))
-						Ω(client.FetchNextCounter()).Should(Equal(3))
+						Ω(client.FetchNextCounter(1, 10)).Should(Equal(0))
+						Ω(client.FetchNextCounter(2, 10)).Should(Equal(1))
+						Ω(client.FetchNextCounter(3, 10)).Should(Equal(2))
+						Ω(client.FetchNextCounter(1, 10)).Should(Equal(3))
+					})
+
+					Context("when the number of active procs is limited", func() {
+						BeforeEach(func() {
+							server.SetActiveProcsLimit(1)
+						})
+
+						It("idles procs that ask for more work while the busy procs are running groups", func() {
+							Ω(client.FetchNextCounter(1, 10)).Should(Equal(0))
+
+							fetched := make(chan int)
+							go func() {
+								defer GinkgoRecover()
+								counter, err := client.FetchNextCounter(2, 10)
+								Ω(err).ShouldNot(HaveOccurred())
+								fetched <- counter
+							}()
+							Consistently(fetched, 200*time.Millisecond).ShouldNot(Receive())
+
+							Ω(client.FetchNextCounter(1, 10)).Should(Equal(1), "proc 1 finished its group and can start another")
+							Consistently(fetched, 200*time.Millisecond).ShouldNot(Receive())
+
+							server.SetActiveProcsLimit(2)
+							Eventually(fetched).Should(Receive(Equal(2)))
+						})
+
+						It("does not idle procs once the groups have run out", func() {
+							Ω(client.FetchNextCounter(1, 1)).Should(Equal(0))
+							Ω(client.FetchNextCounter(2, 1)).Should(Equal(1))
+							Ω(client.FetchNextCounter(3, 1)).Should(Equal(2))
+						})
+
+						It("does not count procs that have exited or are waiting at a phase barrier as busy", func() {
+							Ω(client.FetchNextCounter(2, 10)).Should(Equal(0))
+							close(proc2Exited)
+							Ω(client.FetchNextCounter(3, 10)).Should(Equal(1))
+
+							go client.BlockUntilPhaseReleased(3, 0)
+							Eventually(func() (int, error) { return client.FetchNextCounter(1, 10) }).Should(Equal(2))
+						})
+					})
+				})
+
+				Describe("Fetching spec rate delays", func() {
+					It("spaces out specs according to the global and label limits", func() {
+						limits := map[string]int{"": 60, "db": 6}
+						Ω(client.FetchSpecRateDelay(nil, limits)).Should(BeNumerically("~", 0, 100*time.Millisecond))
+						Ω(client.FetchSpecRateDelay(nil, limits)).Should(BeNumerically("~", time.Second, 100*time.Millisecond))
+						Ω(client.FetchSpecRateDelay([]string{"DB"}, limits)).Should(BeNumerically("~", 2*time.Second, 100*time.Millisecond))
+						Ω(client.FetchSpecRateDelay([]string{"db"}, limits)).Should(BeNumerically("~", 12*time.Second, 100*time.Millisecond))
+						Ω(client.FetchSpecRateDelay([]string{"other"}, limits)).Should(BeNumerically("~", 13*time.Second, 100*time.Millisecond))
+					})
+
+					It("does not delay specs when there are no applicable limits", func() {
+						limits := map[string]int{"db": 1}
+						Ω(client.FetchSpecRateDelay([]string{"other"}, limits)).Should(BeZero())
+						Ω(client.FetchSpecRateDelay([]string{"other"}, limits)).Should(BeZero())
+					})
+				})
+
+				Describe("Acquiring resources", func() {
+					var capacity types.ResourceRequirements
+					BeforeEach(func() {
+						capacity = types.ResourceRequirements{CPU: 4, MemoryBytes: 1 << 30}
+					})
+
+					It("blocks until the requested resources fit within the capacity", func() {
+						big := parallel_support.ResourceRequest{Process: 1, Requirements: types.ResourceRequirements{CPU: 3}, Capacity: capacity}
+						small := parallel_support.ResourceRequest{Process: 2, Requirements: types.ResourceRequirements{CPU: 1, MemoryBytes: 512 << 20}, Capacity: capacity}
+						Ω(client.BlockUntilResourcesAcquired(big, nil)).Should(Succeed())
+						Ω(client.BlockUntilResourcesAcquired(small, nil)).Should(Succeed())
+
+						acquired := make(chan interface{})
+						go func() {
+							defer GinkgoRecover()
+							Ω(client.BlockUntilResourcesAcquired(small, nil)).Should(Succeed())
+							close(acquired)
+						}()
+						Consistently(acquired, 200*time.Millisecond).ShouldNot(BeClosed())
+
+						Ω(client.PostResourcesReleased(small)).Should(Succeed())
+						Eventually(acquired).Should(BeClosed())
+					})
+
+					It("clamps requests that exceed the capacity so that they can run on their own", func() {
+						huge := parallel_support.ResourceRequest{Process: 1, Requirements: types.ResourceRequirements{CPU: 16, GPU: 2}, Capacity: capacity}
+						Ω(client.BlockUntilResourcesAcquired(huge, nil)).Should(Succeed())
+						Ω(client.PostResourcesReleased(huge)).Should(Succeed())
+						Ω(client.BlockUntilResourcesAcquired(huge, nil)).Should(Succeed())
+					})
+
+					It("releases the resources held by a process that has exited", func() {
+						held := parallel_support.ResourceRequest{Process: 2, Requirements: types.ResourceRequirements{CPU: 3}, Capacity: capacity}
+						wanted := parallel_support.ResourceRequest{Process: 3, Requirements: types.ResourceRequirements{CPU: 2}, Capacity: capacity}
+						Ω(client.BlockUntilResourcesAcquired(held, nil)).Should(Succeed())
+
+						acquired := make(chan interface{})
+						go func() {
+							defer GinkgoRecover()
+							Ω(client.BlockUntilResourcesAcquired(wanted, nil)).Should(Succeed())
+							close(acquired)
+						}()
+						Consistently(acquired, 200*time.Millisecond).ShouldNot(BeClosed())
+
+						close(proc2Exited)
+						Eventually(acquired).Should(BeClosed())
+					})
+
+					It("stops waiting when interrupted", func() {
+						held := parallel_support.ResourceRequest{Process: 1, Requirements: types.ResourceRequirements{CPU: 4}, Capacity: capacity}
+						Ω(client.BlockUntilResourcesAcquired(held, nil)).Should(Succeed())
+
+						interruptChannel := make(chan interface{})
+						errC := make(chan error)
+						go func() {
+							errC <- client.BlockUntilResourcesAcquired(held, interruptChannel)
+						}()
+						Consistently(errC, 200*time.Millisecond).ShouldNot(Receive())
+
+						close(interruptChannel)
+						Eventually(errC).Should(Receive(Equal(parallel_support.ErrorInterrupted)))
+					})
+				})
+
+				Describe("Handshaking", func() {
+					It("succeeds when the client and server speak the same protocol", func() {
+						Ω(client.Handshake()).Should(Succeed())
+					})
+				})
+
+				Describe("Verifying process fingerprints", func() {
+					var fingerprint parallel_support.ProcessFingerprint
+					BeforeEach(func() {
+						config := types.NewDefaultSuiteConfig()
+						config.RandomSeed = 17
+						config.FocusStrings = []string{"dolphin"}
+						config.ParallelTotal = 3
+						config.ParallelProcess = 1
+						config.ParallelHost = server.Address()
+						var err error
+						fingerprint, err = parallel_support.NewProcessFingerprint(config)
+						Ω(err).ShouldNot(HaveOccurred())
+						Ω(fingerprint.Process).Should(Equal(1))
+						Ω(fingerprint.BinaryHash).ShouldNot(BeEmpty())
+						Ω(client.PostProcessFingerprint(fingerprint)).Should(Succeed())
+					})
+
+					It("accepts processes running the same binary and configuration", func() {
+						fingerprint.Process = 2
+						Ω(client.PostProcessFingerprint(fingerprint)).Should(Succeed())
+						Ω(client.ShouldAbort()).Should(BeFalse())
+					})
+
+					It("rejects processes running a different binary and tells the other processes to abort", func() {
+						fingerprint.Process = 2
+						fingerprint.BinaryHash = "stale"
+						err := client.PostProcessFingerprint(fingerprint)
+						Ω(err).Should(HaveOccurred())
+						Ω(err.Error()).Should(ContainSubstring("Parallel process #2 is not running the same suite as parallel process #1"))
+						Ω(err.Error()).Should(ContainSubstring("test binary: sha256 stale vs"))
+						Ω(client.ShouldAbort()).Should(BeTrue())
+					})
+
+					It("rejects processes running with a different configuration", func() {
+						fingerprint.Process = 3
+						fingerprint.Config.RandomSeed = 18
+						fingerprint.Config.FocusStrings = nil
+						err := client.PostProcessFingerprint(fingerprint)
+						Ω(err).Should(HaveOccurred())
+						Ω(err.Error()).Should(ContainSubstring("RandomSeed: 18 vs 17"))
+						Ω(err.Error()).Should(ContainSubstring("FocusStrings: [] vs [dolphin]"))
+						Ω(client.ShouldAbort()).Should(BeTrue())
 					})
 				})
 
@@ -48,6 +48,9 @@ func (client *httpClient) post(path string, data interface{}) error {
 		return err
 	}
 	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusTooEarly {
+		return ErrorEarly
+	}
 	if resp.StatusCode != http.StatusOK {
 		return fmt.Errorf("received unexpected status code %d", resp.StatusCode)
 	}
@@ -82,6 +85,49 @@ func (client *httpClient) poll(path string, data interface{}) error {
 	}
 }
 
+func (client *httpClient) Handshake() error {
+	encoded, err := json.Marshal(types.PROTOCOL_VERSION)
+	if err != nil {
+		return err
+	}
+	resp, err := http.Post(client.serverHost+"/handshake", "application/json", bytes.NewBuffer(encoded))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		return checkProtocolVersion(0)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("received unexpected status code %d", resp.StatusCode)
+	}
+	var protocolVersion int
+	if err := json.NewDecoder(resp.Body).Decode(&protocolVersion); err != nil {
+		return err
+	}
+	return checkProtocolVersion(protocolVersion)
+}
+
+func (client *httpClient) PostProcessFingerprint(fingerprint ProcessFingerprint) error {
+	encoded, err := json.Marshal(fingerprint)
+	if err != nil {
+		return err
+	}
+	resp, err := http.Post(client.serverHost+"/process-fingerprint", "application/json", bytes.NewBuffer(encoded))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("received unexpected status code %d", resp.StatusCode)
+	}
+	var reference ProcessFingerprint
+	if err := json.NewDecoder(resp.Body).Decode(&reference); err != nil {
+		return err
+	}
+	return checkDrift(fingerprint, reference)
+}
+
 func (client *httpClient) PostSuiteWillBegin(report types.Report) error {
 	return client.post("/suite-will-begin", report)
 }
@@ -90,6 +136,10 @@ func (client *httpClient) PostDidRun(report types.SpecReport) error {
 	return client.post("/did-run", report)
 }
 
+func (client *httpClient) PostEmitProgressReport(report types.ProgressReport) error {
+	return client.post("/progress-report", report)
+}
+
 func (client *httpClient) PostSuiteDidEnd(report types.Report) error {
 	return client.post("/suite-did-end", report)
 }
@@ -111,6 +161,19 @@ func (client *httpClient) BlockUntilSynchronizedBeforeSuiteData() (types.SpecSta
 	return beforeSuiteState.State, beforeSuiteState.Data, err
 }
 
+func (client *httpClient) PostPreflightCompleted(failures []types.PreflightFailure) error {
+	return client.post("/preflight-completed", PreflightResult{Completed: true, Failures: failures})
+}
+
+func (client *httpClient) BlockUntilPreflightCompleted() ([]types.PreflightFailure, error) {
+	var preflightResult PreflightResult
+	err := client.poll("/preflight-result", &preflightResult)
+	if err == ErrorGone {
+		return nil, types.GinkgoErrors.PreflightChecksDisappearedOnProc1()
+	}
+	return preflightResult.Failures, err
+}
+
 func (client *httpClient) BlockUntilNonprimaryProcsHaveFinished() error {
 	return client.poll("/have-nonprimary-procs-finished", nil)
 }
@@ -124,12 +187,68 @@ func (client *httpClient) BlockUntilAggregatedNonprimaryProcsReport() (types.Rep
 	return report, err
 }
 
-func (client *httpClient) FetchNextCounter() (int, error) {
+func (client *httpClient) FetchNextCounter(process int, numGroups int) (int, error) {
 	var counter ParallelIndexCounter
-	err := client.poll("/counter", &counter)
+	err := client.poll(fmt.Sprintf("/counter?process=%d&groups=%d", process, numGroups), &counter)
 	return counter.Index, err
 }
 
+func (client *httpClient) FetchSpecRateDelay(labels []string, limits map[string]int) (time.Duration, error) {
+	encoded, err := json.Marshal(SpecRateRequest{Labels: labels, Limits: limits})
+	if err != nil {
+		return 0, err
+	}
+	resp, err := http.Post(client.serverHost+"/spec-rate-delay", "application/json", bytes.NewBuffer(encoded))
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("received unexpected status code %d", resp.StatusCode)
+	}
+	var delay time.Duration
+	err = json.NewDecoder(resp.Body).Decode(&delay)
+	return delay, err
+}
+
+func (client *httpClient) BlockUntilNonprimaryProcsReachedPhase(phase int) error {
+	return client.poll(fmt.Sprintf("/nonprimary-procs-reached-phase?phase=%d", phase), nil)
+}
+
+func (client *httpClient) BlockUntilPhaseReleased(process int, phase int) error {
+	err := client.post("/reached-phase", PhaseBarrier{Process: process, Phase: phase})
+	if err != nil {
+		return err
+	}
+	err = client.poll(fmt.Sprintf("/phase-released?phase=%d", phase), nil)
+	if err == ErrorGone {
+		return types.GinkgoErrors.ExecutionPhaseDisappearedOnProc1()
+	}
+	return err
+}
+
+func (client *httpClient) PostPhaseReleased(phase int) error {
+	return client.post("/release-phase", phase)
+}
+
+func (client *httpClient) BlockUntilResourcesAcquired(request ResourceRequest, interruptChannel <-chan interface{}) error {
+	for {
+		err := client.post("/acquire-resources", request)
+		if err != ErrorEarly {
+			return err
+		}
+		select {
+		case <-time.After(POLLING_INTERVAL):
+		case <-interruptChannel:
+			return ErrorInterrupted
+		}
+	}
+}
+
+func (client *httpClient) PostResourcesReleased(request ResourceRequest) error {
+	return client.post("/release-resources", request)
+}
+
 func (client *httpClient) PostAbort() error {
 	return client.post("/abort", nil)
 }
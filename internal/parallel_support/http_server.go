@@ -12,6 +12,8 @@ import (
 	"io"
 	"net"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/onsi/ginkgo/v2/reporters"
 	"github.com/onsi/ginkgo/v2/types"
@@ -47,15 +49,27 @@ func (server *httpServer) Start() {
 	//streaming endpoints
 	mux.HandleFunc("/suite-will-begin", server.specSuiteWillBegin)
 	mux.HandleFunc("/did-run", server.didRun)
+	mux.HandleFunc("/progress-report", server.emitProgressReport)
 	mux.HandleFunc("/suite-did-end", server.specSuiteDidEnd)
 	mux.HandleFunc("/emit-output", server.emitOutput)
 
 	//synchronization endpoints
+	mux.HandleFunc("/handshake", server.handleHandshake)
+	mux.HandleFunc("/process-fingerprint", server.handleProcessFingerprint)
 	mux.HandleFunc("/before-suite-completed", server.handleBeforeSuiteCompleted)
 	mux.HandleFunc("/before-suite-state", server.handleBeforeSuiteState)
+	mux.HandleFunc("/preflight-completed", server.handlePreflightCompleted)
+	mux.HandleFunc("/preflight-result", server.handlePreflightResult)
 	mux.HandleFunc("/have-nonprimary-procs-finished", server.handleHaveNonprimaryProcsFinished)
 	mux.HandleFunc("/aggregated-nonprimary-procs-report", server.handleAggregatedNonprimaryProcsReport)
 	mux.HandleFunc("/counter", server.handleCounter)
+	mux.HandleFunc("/spec-rate-delay", server.handleSpecRateDelay)
+	mux.HandleFunc("/reached-phase", server.handleReachedPhase)
+	mux.HandleFunc("/acquire-resources", server.handleAcquireResources)
+	mux.HandleFunc("/release-resources", server.handleReleaseResources)
+	mux.HandleFunc("/nonprimary-procs-reached-phase", server.handleNonprimaryProcsReachedPhase)
+	mux.HandleFunc("/release-phase", server.handleReleasePhase)
+	mux.HandleFunc("/phase-released", server.handlePhaseReleased)
 	mux.HandleFunc("/up", server.handleUp)
 	mux.HandleFunc("/abort", server.handleAbort)
 
@@ -84,6 +98,10 @@ func (server *httpServer) SetOutputDestination(w io.Writer) {
 	server.handler.outputDestination = w
 }
 
+func (server *httpServer) SetActiveProcsLimit(limit int) {
+	server.handler.setActiveProcsLimit(limit)
+}
+
 func (server *httpServer) RegisterAlive(node int, alive func() bool) {
 	server.handler.registerAlive(node, alive)
 }
@@ -137,6 +155,14 @@ func (server *httpServer) didRun(writer http.ResponseWriter, request *http.Reque
 	server.handleError(server.handler.DidRun(report, voidReceiver), writer)
 }
 
+func (server *httpServer) emitProgressReport(writer http.ResponseWriter, request *http.Request) {
+	var report types.ProgressReport
+	if !server.decode(writer, request, &report) {
+		return
+	}
+	server.handleError(server.handler.EmitProgressReport(report, voidReceiver), writer)
+}
+
 func (server *httpServer) specSuiteDidEnd(writer http.ResponseWriter, request *http.Request) {
 	var report types.Report
 	if !server.decode(writer, request, &report) {
@@ -155,6 +181,28 @@ func (server *httpServer) emitOutput(writer http.ResponseWriter, request *http.R
 	server.handleError(server.handler.EmitOutput(output, &n), writer)
 }
 
+func (server *httpServer) handleHandshake(writer http.ResponseWriter, request *http.Request) {
+	var clientProtocolVersion, protocolVersion int
+	if !server.decode(writer, request, &clientProtocolVersion) {
+		return
+	}
+	if server.handleError(server.handler.Handshake(clientProtocolVersion, &protocolVersion), writer) {
+		return
+	}
+	json.NewEncoder(writer).Encode(protocolVersion)
+}
+
+func (server *httpServer) handleProcessFingerprint(writer http.ResponseWriter, request *http.Request) {
+	var fingerprint, reference ProcessFingerprint
+	if !server.decode(writer, request, &fingerprint) {
+		return
+	}
+	if server.handleError(server.handler.RegisterFingerprint(fingerprint, &reference), writer) {
+		return
+	}
+	json.NewEncoder(writer).Encode(reference)
+}
+
 func (server *httpServer) handleBeforeSuiteCompleted(writer http.ResponseWriter, request *http.Request) {
 	var beforeSuiteState BeforeSuiteState
 	if !server.decode(writer, request, &beforeSuiteState) {
@@ -172,6 +220,23 @@ func (server *httpServer) handleBeforeSuiteState(writer http.ResponseWriter, req
 	json.NewEncoder(writer).Encode(beforeSuiteState)
 }
 
+func (server *httpServer) handlePreflightCompleted(writer http.ResponseWriter, request *http.Request) {
+	var preflightResult PreflightResult
+	if !server.decode(writer, request, &preflightResult) {
+		return
+	}
+
+	server.handleError(server.handler.PreflightCompleted(preflightResult, voidReceiver), writer)
+}
+
+func (server *httpServer) handlePreflightResult(writer http.ResponseWriter, request *http.Request) {
+	var preflightResult PreflightResult
+	if server.handleError(server.handler.PreflightResult(voidSender, &preflightResult), writer) {
+		return
+	}
+	json.NewEncoder(writer).Encode(preflightResult)
+}
+
 func (server *httpServer) handleHaveNonprimaryProcsFinished(writer http.ResponseWriter, request *http.Request) {
 	if server.handleError(server.handler.HaveNonprimaryProcsFinished(voidSender, voidReceiver), writer) {
 		return
@@ -188,13 +253,98 @@ func (server *httpServer) handleAggregatedNonprimaryProcsReport(writer http.Resp
 }
 
 func (server *httpServer) handleCounter(writer http.ResponseWriter, request *http.Request) {
+	process, err := strconv.Atoi(request.URL.Query().Get("process"))
+	if err != nil {
+		writer.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	numGroups, err := strconv.Atoi(request.URL.Query().Get("groups"))
+	if err != nil {
+		writer.WriteHeader(http.StatusBadRequest)
+		return
+	}
 	var n int
-	if server.handleError(server.handler.Counter(voidSender, &n), writer) {
+	if server.handleError(server.handler.Counter(CounterRequest{Process: process, NumGroups: numGroups}, &n), writer) {
 		return
 	}
 	json.NewEncoder(writer).Encode(ParallelIndexCounter{Index: n})
 }
 
+func (server *httpServer) handleSpecRateDelay(writer http.ResponseWriter, request *http.Request) {
+	var specRateRequest SpecRateRequest
+	if !server.decode(writer, request, &specRateRequest) {
+		return
+	}
+	var delay time.Duration
+	if server.handleError(server.handler.SpecRateDelay(specRateRequest, &delay), writer) {
+		return
+	}
+	json.NewEncoder(writer).Encode(delay)
+}
+
+func (server *httpServer) handleAcquireResources(writer http.ResponseWriter, request *http.Request) {
+	var resourceRequest ResourceRequest
+	if !server.decode(writer, request, &resourceRequest) {
+		return
+	}
+	server.handleError(server.handler.AcquireResources(resourceRequest, voidReceiver), writer)
+}
+
+func (server *httpServer) handleReleaseResources(writer http.ResponseWriter, request *http.Request) {
+	var resourceRequest ResourceRequest
+	if !server.decode(writer, request, &resourceRequest) {
+		return
+	}
+	server.handleError(server.handler.ReleaseResources(resourceRequest, voidReceiver), writer)
+}
+
+func (server *httpServer) phaseFromQuery(writer http.ResponseWriter, request *http.Request) (int, bool) {
+	phase, err := strconv.Atoi(request.URL.Query().Get("phase"))
+	if err != nil {
+		writer.WriteHeader(http.StatusBadRequest)
+		return 0, false
+	}
+	return phase, true
+}
+
+func (server *httpServer) handleReachedPhase(writer http.ResponseWriter, request *http.Request) {
+	var barrier PhaseBarrier
+	if !server.decode(writer, request, &barrier) {
+		return
+	}
+	server.handleError(server.handler.ReachedPhase(barrier, voidReceiver), writer)
+}
+
+func (server *httpServer) handleNonprimaryProcsReachedPhase(writer http.ResponseWriter, request *http.Request) {
+	phase, ok := server.phaseFromQuery(writer, request)
+	if !ok {
+		return
+	}
+	if server.handleError(server.handler.NonprimaryProcsReachedPhase(phase, voidReceiver), writer) {
+		return
+	}
+	writer.WriteHeader(http.StatusOK)
+}
+
+func (server *httpServer) handleReleasePhase(writer http.ResponseWriter, request *http.Request) {
+	var phase int
+	if !server.decode(writer, request, &phase) {
+		return
+	}
+	server.handleError(server.handler.ReleasePhase(phase, voidReceiver), writer)
+}
+
+func (server *httpServer) handlePhaseReleased(writer http.ResponseWriter, request *http.Request) {
+	phase, ok := server.phaseFromQuery(writer, request)
+	if !ok {
+		return
+	}
+	if server.handleError(server.handler.PhaseReleased(phase, voidReceiver), writer) {
+		return
+	}
+	writer.WriteHeader(http.StatusOK)
+}
+
 func (server *httpServer) handleUp(writer http.ResponseWriter, request *http.Request) {
 	writer.WriteHeader(http.StatusOK)
 }
@@ -0,0 +1,98 @@
+package parallel_support
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"sync"
+
+	"github.com/onsi/ginkgo/v2/types"
+)
+
+// ProcessFingerprint identifies the test binary and suite configuration a parallel process is running with.
+// Every process registers its fingerprint with the server so that stale binaries and mismatched flags are caught before any specs run.
+type ProcessFingerprint struct {
+	Process    int
+	BinaryHash string
+	Config     types.SuiteConfig
+}
+
+// NewProcessFingerprint computes the fingerprint of the current process.  The parallel settings that are expected
+// to differ between processes are cleared from the captured SuiteConfig.
+func NewProcessFingerprint(config types.SuiteConfig) (ProcessFingerprint, error) {
+	binaryHash, err := currentBinaryHash()
+	if err != nil {
+		return ProcessFingerprint{}, err
+	}
+	process := config.ParallelProcess
+	config.ParallelProcess = 0
+	config.ParallelHost = ""
+	return ProcessFingerprint{
+		Process:    process,
+		BinaryHash: binaryHash,
+		Config:     config,
+	}, nil
+}
+
+// Drift returns a human-readable description of every way in which the fingerprint differs from reference.
+// An empty slice means the two processes are running the same binary with the same configuration.
+func (f ProcessFingerprint) Drift(reference ProcessFingerprint) []string {
+	drift := []string{}
+	if f.BinaryHash != reference.BinaryHash {
+		drift = append(drift, fmt.Sprintf("test binary: sha256 %s vs %s", shortHash(f.BinaryHash), shortHash(reference.BinaryHash)))
+	}
+
+	// values are compared by their printed representation as the transport may decode empty slices as nil
+	value, referenceValue := reflect.ValueOf(f.Config), reflect.ValueOf(reference.Config)
+	for i := 0; i < value.NumField(); i++ {
+		a, b := fmt.Sprintf("%v", value.Field(i).Interface()), fmt.Sprintf("%v", referenceValue.Field(i).Interface())
+		if a != b {
+			drift = append(drift, fmt.Sprintf("%s: %s vs %s", value.Type().Field(i).Name, a, b))
+		}
+	}
+	return drift
+}
+
+func checkDrift(fingerprint ProcessFingerprint, reference ProcessFingerprint) error {
+	if drift := fingerprint.Drift(reference); len(drift) > 0 {
+		return types.GinkgoErrors.ParallelProcessDrift(fingerprint.Process, reference.Process, drift)
+	}
+	return nil
+}
+
+func shortHash(hash string) string {
+	if len(hash) > 12 {
+		return hash[:12]
+	}
+	return hash
+}
+
+// the binary is only hashed once per process as test binaries can be large
+var binaryHash string
+var binaryHashErr error
+var binaryHashOnce = &sync.Once{}
+
+func currentBinaryHash() (string, error) {
+	binaryHashOnce.Do(func() {
+		var path string
+		path, binaryHashErr = os.Executable()
+		if binaryHashErr != nil {
+			return
+		}
+		var f *os.File
+		f, binaryHashErr = os.Open(path)
+		if binaryHashErr != nil {
+			return
+		}
+		defer f.Close()
+		hash := sha256.New()
+		if _, binaryHashErr = io.Copy(hash, f); binaryHashErr != nil {
+			return
+		}
+		binaryHash = hex.EncodeToString(hash.Sum(nil))
+	})
+	return binaryHash, binaryHashErr
+}
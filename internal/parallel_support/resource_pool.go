@@ -0,0 +1,76 @@
+package parallel_support
+
+import (
+	"sync"
+
+	"github.com/onsi/ginkgo/v2/types"
+)
+
+type ResourceRequest struct {
+	Process      int
+	Requirements types.ResourceRequirements
+	Capacity     types.ResourceRequirements
+}
+
+/*
+ResourcePool tracks the host resources held by running specs so that specs that declare requirements with the Requires decorator don't oversubscribe the host.
+
+When running in parallel a single ResourcePool lives in the server so that the capacity is shared across all processes.
+*/
+type ResourcePool struct {
+	lock  *sync.Mutex
+	inUse types.ResourceRequirements
+	held  map[int]types.ResourceRequirements
+}
+
+func NewResourcePool() *ResourcePool {
+	return &ResourcePool{
+		lock: &sync.Mutex{},
+		held: map[int]types.ResourceRequirements{},
+	}
+}
+
+// TryAcquire acquires the requested resources and returns true if they fit within the remaining capacity.
+// Requirements that exceed the total capacity are clamped to it so that the spec can still run - once nothing else holds the resources.
+func (pool *ResourcePool) TryAcquire(request ResourceRequest) bool {
+	requirements := request.Requirements.ClampedTo(request.Capacity)
+	pool.lock.Lock()
+	defer pool.lock.Unlock()
+	if !pool.inUse.Add(requirements).FitsWithin(request.Capacity) {
+		return false
+	}
+	pool.inUse = pool.inUse.Add(requirements)
+	pool.held[request.Process] = pool.held[request.Process].Add(requirements)
+	return true
+}
+
+// Release returns resources acquired with TryAcquire to the pool.  It must be passed the same request.
+func (pool *ResourcePool) Release(request ResourceRequest) {
+	requirements := request.Requirements.ClampedTo(request.Capacity)
+	pool.lock.Lock()
+	defer pool.lock.Unlock()
+	pool.inUse = pool.inUse.Subtract(requirements)
+	pool.held[request.Process] = pool.held[request.Process].Subtract(requirements)
+	if pool.held[request.Process].IsZero() {
+		delete(pool.held, request.Process)
+	}
+}
+
+// ReleaseDeadProcesses returns everything held by processes that isAlive reports have exited to the pool - a process that crashes mid-spec never releases its resources itself
+func (pool *ResourcePool) ReleaseDeadProcesses(isAlive func(process int) bool) {
+	pool.lock.Lock()
+	defer pool.lock.Unlock()
+	for process, requirements := range pool.held {
+		if process > 0 && !isAlive(process) {
+			pool.inUse = pool.inUse.Subtract(requirements)
+			delete(pool.held, process)
+		}
+	}
+}
+
+// InUse returns the resources currently held by running specs
+func (pool *ResourcePool) InUse() types.ResourceRequirements {
+	pool.lock.Lock()
+	defer pool.lock.Unlock()
+	return pool.inUse
+}
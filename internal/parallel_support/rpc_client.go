@@ -2,6 +2,7 @@ package parallel_support
 
 import (
 	"net/rpc"
+	"strings"
 	"time"
 
 	"github.com/onsi/ginkgo/v2/types"
@@ -36,8 +37,12 @@ func (client *rpcClient) Close() error {
 }
 
 func (client *rpcClient) poll(method string, data interface{}) error {
+	return client.pollWithArgs(method, voidSender, data)
+}
+
+func (client *rpcClient) pollWithArgs(method string, args interface{}, data interface{}) error {
 	for {
-		err := client.client.Call(method, voidSender, data)
+		err := client.client.Call(method, args, data)
 		if err == nil {
 			return nil
 		}
@@ -54,6 +59,27 @@ func (client *rpcClient) poll(method string, data interface{}) error {
 	}
 }
 
+func (client *rpcClient) Handshake() error {
+	var protocolVersion int
+	err := client.client.Call("Server.Handshake", types.PROTOCOL_VERSION, &protocolVersion)
+	if err != nil && strings.Contains(err.Error(), "can't find method") {
+		return checkProtocolVersion(0)
+	}
+	if err != nil {
+		return err
+	}
+	return checkProtocolVersion(protocolVersion)
+}
+
+func (client *rpcClient) PostProcessFingerprint(fingerprint ProcessFingerprint) error {
+	var reference ProcessFingerprint
+	err := client.client.Call("Server.RegisterFingerprint", fingerprint, &reference)
+	if err != nil {
+		return err
+	}
+	return checkDrift(fingerprint, reference)
+}
+
 func (client *rpcClient) PostSuiteWillBegin(report types.Report) error {
 	return client.client.Call("Server.SpecSuiteWillBegin", report, voidReceiver)
 }
@@ -62,6 +88,10 @@ func (client *rpcClient) PostDidRun(report types.SpecReport) error {
 	return client.client.Call("Server.DidRun", report, voidReceiver)
 }
 
+func (client *rpcClient) PostEmitProgressReport(report types.ProgressReport) error {
+	return client.client.Call("Server.EmitProgressReport", report, voidReceiver)
+}
+
 func (client *rpcClient) PostSuiteDidEnd(report types.Report) error {
 	return client.client.Call("Server.SpecSuiteDidEnd", report, voidReceiver)
 }
@@ -89,6 +119,19 @@ func (client *rpcClient) BlockUntilSynchronizedBeforeSuiteData() (types.SpecStat
 	return beforeSuiteState.State, beforeSuiteState.Data, err
 }
 
+func (client *rpcClient) PostPreflightCompleted(failures []types.PreflightFailure) error {
+	return client.client.Call("Server.PreflightCompleted", PreflightResult{Completed: true, Failures: failures}, voidReceiver)
+}
+
+func (client *rpcClient) BlockUntilPreflightCompleted() ([]types.PreflightFailure, error) {
+	var preflightResult PreflightResult
+	err := client.poll("Server.PreflightResult", &preflightResult)
+	if err == ErrorGone {
+		return nil, types.GinkgoErrors.PreflightChecksDisappearedOnProc1()
+	}
+	return preflightResult.Failures, err
+}
+
 func (client *rpcClient) BlockUntilNonprimaryProcsHaveFinished() error {
 	return client.poll("Server.HaveNonprimaryProcsFinished", voidReceiver)
 }
@@ -102,12 +145,56 @@ func (client *rpcClient) BlockUntilAggregatedNonprimaryProcsReport() (types.Repo
 	return report, err
 }
 
-func (client *rpcClient) FetchNextCounter() (int, error) {
+func (client *rpcClient) FetchNextCounter(process int, numGroups int) (int, error) {
 	var counter int
-	err := client.client.Call("Server.Counter", voidSender, &counter)
+	err := client.pollWithArgs("Server.Counter", CounterRequest{Process: process, NumGroups: numGroups}, &counter)
 	return counter, err
 }
 
+func (client *rpcClient) FetchSpecRateDelay(labels []string, limits map[string]int) (time.Duration, error) {
+	var delay time.Duration
+	err := client.client.Call("Server.SpecRateDelay", SpecRateRequest{Labels: labels, Limits: limits}, &delay)
+	return delay, err
+}
+
+func (client *rpcClient) BlockUntilNonprimaryProcsReachedPhase(phase int) error {
+	return client.pollWithArgs("Server.NonprimaryProcsReachedPhase", phase, voidReceiver)
+}
+
+func (client *rpcClient) BlockUntilPhaseReleased(process int, phase int) error {
+	err := client.client.Call("Server.ReachedPhase", PhaseBarrier{Process: process, Phase: phase}, voidReceiver)
+	if err != nil {
+		return err
+	}
+	err = client.pollWithArgs("Server.PhaseReleased", phase, voidReceiver)
+	if err == ErrorGone {
+		return types.GinkgoErrors.ExecutionPhaseDisappearedOnProc1()
+	}
+	return err
+}
+
+func (client *rpcClient) PostPhaseReleased(phase int) error {
+	return client.client.Call("Server.ReleasePhase", phase, voidReceiver)
+}
+
+func (client *rpcClient) BlockUntilResourcesAcquired(request ResourceRequest, interruptChannel <-chan interface{}) error {
+	for {
+		err := client.client.Call("Server.AcquireResources", request, voidReceiver)
+		if err == nil || err.Error() != ErrorEarly.Error() {
+			return err
+		}
+		select {
+		case <-time.After(POLLING_INTERVAL):
+		case <-interruptChannel:
+			return ErrorInterrupted
+		}
+	}
+}
+
+func (client *rpcClient) PostResourcesReleased(request ResourceRequest) error {
+	return client.client.Call("Server.ReleaseResources", request, voidReceiver)
+}
+
 func (client *rpcClient) PostAbort() error {
 	return client.client.Call("Server.Abort", voidSender, voidReceiver)
 }
@@ -70,6 +70,10 @@ func (server *RPCServer) SetOutputDestination(w io.Writer) {
 	server.handler.outputDestination = w
 }
 
+func (server *RPCServer) SetActiveProcsLimit(limit int) {
+	server.handler.setActiveProcsLimit(limit)
+}
+
 func (server *RPCServer) RegisterAlive(node int, alive func() bool) {
 	server.handler.registerAlive(node, alive)
 }
@@ -4,6 +4,7 @@ import (
 	"io"
 	"os"
 	"sync"
+	"time"
 
 	"github.com/onsi/ginkgo/v2/reporters"
 	"github.com/onsi/ginkgo/v2/types"
@@ -24,10 +25,18 @@ type ServerHandler struct {
 	alives            []func() bool
 	lock              *sync.Mutex
 	beforeSuiteState  BeforeSuiteState
+	preflightResult   PreflightResult
 	parallelTotal     int
 	counter           int
 	counterLock       *sync.Mutex
+	activeProcsLimit  int
+	busyProcs         map[int]bool
+	specRateLimiter   *SpecRateLimiter
+	resourcePool      *ResourcePool
 	shouldAbort       bool
+	reachedPhases     map[int]int
+	releasedPhase     int
+	fingerprint       *ProcessFingerprint
 
 	numSuiteDidBegins int
 	numSuiteDidEnds   int
@@ -40,14 +49,38 @@ func newServerHandler(parallelTotal int, reporter reporters.Reporter) *ServerHan
 		reporter:          reporter,
 		lock:              &sync.Mutex{},
 		counterLock:       &sync.Mutex{},
+		busyProcs:         map[int]bool{},
+		specRateLimiter:   NewSpecRateLimiter(),
+		resourcePool:      NewResourcePool(),
 		alives:            make([]func() bool, parallelTotal),
 		beforeSuiteState:  BeforeSuiteState{Data: nil, State: types.SpecStateInvalid},
 		parallelTotal:     parallelTotal,
 		outputDestination: os.Stdout,
 		done:              make(chan interface{}),
+		reachedPhases:     map[int]int{},
+		releasedPhase:     -1,
 	}
 }
 
+func (handler *ServerHandler) Handshake(_ int, protocolVersion *int) error {
+	*protocolVersion = types.PROTOCOL_VERSION
+	return nil
+}
+
+func (handler *ServerHandler) RegisterFingerprint(fingerprint ProcessFingerprint, reference *ProcessFingerprint) error {
+	handler.lock.Lock()
+	defer handler.lock.Unlock()
+	if handler.fingerprint == nil {
+		handler.fingerprint = &fingerprint
+	}
+	*reference = *handler.fingerprint
+	// a drifted process will exit - tell everyone else to stop too rather than let them run a mismatched suite
+	if len(fingerprint.Drift(*reference)) > 0 {
+		handler.shouldAbort = true
+	}
+	return nil
+}
+
 func (handler *ServerHandler) SpecSuiteWillBegin(report types.Report, _ *Void) error {
 	handler.lock.Lock()
 	defer handler.lock.Unlock()
@@ -83,6 +116,15 @@ func (handler *ServerHandler) DidRun(report types.SpecReport, _ *Void) error {
 	return nil
 }
 
+func (handler *ServerHandler) EmitProgressReport(report types.ProgressReport, _ *Void) error {
+	handler.lock.Lock()
+	defer handler.lock.Unlock()
+
+	handler.reporter.EmitProgressReport(report)
+
+	return nil
+}
+
 func (handler *ServerHandler) SpecSuiteDidEnd(report types.Report, _ *Void) error {
 	handler.lock.Lock()
 	defer handler.lock.Unlock()
@@ -156,6 +198,29 @@ func (handler *ServerHandler) BeforeSuiteState(_ Void, beforeSuiteState *BeforeS
 	return nil
 }
 
+func (handler *ServerHandler) PreflightCompleted(preflightResult PreflightResult, _ *Void) error {
+	handler.lock.Lock()
+	defer handler.lock.Unlock()
+	handler.preflightResult = preflightResult
+	handler.preflightResult.Completed = true
+	return nil
+}
+
+func (handler *ServerHandler) PreflightResult(_ Void, preflightResult *PreflightResult) error {
+	proc1IsAlive := handler.procIsAlive(1)
+	handler.lock.Lock()
+	defer handler.lock.Unlock()
+	if !handler.preflightResult.Completed {
+		if proc1IsAlive {
+			return ErrorEarly
+		} else {
+			return ErrorGone
+		}
+	}
+	*preflightResult = handler.preflightResult
+	return nil
+}
+
 func (handler *ServerHandler) HaveNonprimaryProcsFinished(_ Void, _ *Void) error {
 	if handler.haveNonprimaryProcsFinished() {
 		return nil
@@ -179,14 +244,114 @@ func (handler *ServerHandler) AggregatedNonprimaryProcsReport(_ Void, report *ty
 	}
 }
 
-func (handler *ServerHandler) Counter(_ Void, counter *int) error {
+// setActiveProcsLimit caps the number of processes that can run a group of specs at once.  Processes that ask for
+// more work while the cap is reached idle until a busy process finishes its group.  A limit of 0 removes the cap.
+func (handler *ServerHandler) setActiveProcsLimit(limit int) {
 	handler.counterLock.Lock()
 	defer handler.counterLock.Unlock()
+	handler.activeProcsLimit = limit
+}
+
+// a process is busy from the time it is handed a group until it asks for the next one or stops to wait on a barrier
+func (handler *ServerHandler) markIdle(proc int) {
+	handler.counterLock.Lock()
+	defer handler.counterLock.Unlock()
+	delete(handler.busyProcs, proc)
+}
+
+func (handler *ServerHandler) Counter(request CounterRequest, counter *int) error {
+	handler.counterLock.Lock()
+	defer handler.counterLock.Unlock()
+	delete(handler.busyProcs, request.Process)
+	// once the groups run out there's nothing to throttle - processes must be free to pick up the end of the suite
+	if handler.counter >= request.NumGroups {
+		*counter = handler.counter
+		handler.counter++
+		return nil
+	}
+	if handler.activeProcsLimit > 0 {
+		numBusy := 0
+		for proc := range handler.busyProcs {
+			if handler.procIsAlive(proc) {
+				numBusy += 1
+			}
+		}
+		if numBusy >= handler.activeProcsLimit {
+			return ErrorEarly
+		}
+	}
 	*counter = handler.counter
 	handler.counter++
+	handler.busyProcs[request.Process] = true
+	return nil
+}
+
+func (handler *ServerHandler) SpecRateDelay(request SpecRateRequest, delay *time.Duration) error {
+	*delay = handler.specRateLimiter.Reserve(request.Labels, request.Limits)
+	return nil
+}
+
+func (handler *ServerHandler) AcquireResources(request ResourceRequest, _ *Void) error {
+	handler.resourcePool.ReleaseDeadProcesses(handler.procIsAlive)
+	if handler.resourcePool.TryAcquire(request) {
+		return nil
+	}
+	return ErrorEarly
+}
+
+func (handler *ServerHandler) ReleaseResources(request ResourceRequest, _ *Void) error {
+	handler.resourcePool.Release(request)
+	return nil
+}
+
+func (handler *ServerHandler) ReachedPhase(barrier PhaseBarrier, _ *Void) error {
+	handler.markIdle(barrier.Process)
+	handler.lock.Lock()
+	defer handler.lock.Unlock()
+	if reached, ok := handler.reachedPhases[barrier.Process]; !ok || barrier.Phase > reached {
+		handler.reachedPhases[barrier.Process] = barrier.Phase
+	}
+	return nil
+}
+
+func (handler *ServerHandler) NonprimaryProcsReachedPhase(phase int, _ *Void) error {
+	handler.markIdle(1)
+	for i := 2; i <= handler.parallelTotal; i++ {
+		handler.lock.Lock()
+		reached, ok := handler.reachedPhases[i]
+		handler.lock.Unlock()
+		if ok && reached >= phase {
+			continue
+		}
+		if handler.procIsAlive(i) {
+			return ErrorEarly
+		}
+	}
+	return nil
+}
+
+func (handler *ServerHandler) ReleasePhase(phase int, _ *Void) error {
+	handler.lock.Lock()
+	defer handler.lock.Unlock()
+	if phase > handler.releasedPhase {
+		handler.releasedPhase = phase
+	}
 	return nil
 }
 
+func (handler *ServerHandler) PhaseReleased(phase int, _ *Void) error {
+	proc1IsAlive := handler.procIsAlive(1)
+	handler.lock.Lock()
+	defer handler.lock.Unlock()
+	if handler.releasedPhase >= phase {
+		return nil
+	}
+	if proc1IsAlive {
+		return ErrorEarly
+	}
+	return ErrorGone
+}
+
 func (handler *ServerHandler) Abort(_ Void, _ *Void) error {
 	handler.lock.Lock()
 	defer handler.lock.Unlock()
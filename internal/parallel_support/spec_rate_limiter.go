@@ -0,0 +1,62 @@
+package parallel_support
+
+import (
+	"strings"
+	"sync"
+	"time"
+)
+
+type SpecRateRequest struct {
+	Labels []string
+	Limits map[string]int
+}
+
+/*
+SpecRateLimiter spaces out spec starts so that no more than the configured number of specs start per minute.
+
+Limits are keyed by lowercased label, with the global limit keyed by the empty string.  A spec is subject to the global limit and to the limit of each of its labels.
+When running in parallel a single SpecRateLimiter lives in the server so that the limits apply across all processes.
+*/
+type SpecRateLimiter struct {
+	lock     *sync.Mutex
+	nextSlot map[string]time.Time
+}
+
+func NewSpecRateLimiter() *SpecRateLimiter {
+	return &SpecRateLimiter{
+		lock:     &sync.Mutex{},
+		nextSlot: map[string]time.Time{},
+	}
+}
+
+// Reserve reserves a slot for a spec with the passed-in labels and returns how long the spec must wait before starting
+func (limiter *SpecRateLimiter) Reserve(labels []string, limits map[string]int) time.Duration {
+	keys := []string{}
+	if limits[""] > 0 {
+		keys = append(keys, "")
+	}
+	for _, label := range labels {
+		label = strings.ToLower(label)
+		if label != "" && limits[label] > 0 {
+			keys = append(keys, label)
+		}
+	}
+	if len(keys) == 0 {
+		return 0
+	}
+
+	limiter.lock.Lock()
+	defer limiter.lock.Unlock()
+
+	now := time.Now()
+	start := now
+	for _, key := range keys {
+		if limiter.nextSlot[key].After(start) {
+			start = limiter.nextSlot[key]
+		}
+	}
+	for _, key := range keys {
+		limiter.nextSlot[key] = start.Add(time.Minute / time.Duration(limits[key]))
+	}
+	return start.Sub(now)
+}
@@ -0,0 +1,54 @@
+package internal
+
+import (
+	"github.com/onsi/ginkgo/v2/types"
+)
+
+// PreflightCheck verifies that the environment the suite needs is ready.  It returns an error if it is not.
+type PreflightCheck func() error
+
+type registeredPreflightCheck struct {
+	name         string
+	check        PreflightCheck
+	codeLocation types.CodeLocation
+}
+
+func (suite *Suite) RegisterPreflightCheck(name string, check PreflightCheck, cl types.CodeLocation) {
+	suite.preflightChecks = append(suite.preflightChecks, registeredPreflightCheck{name: name, check: check, codeLocation: cl})
+}
+
+// runPreflightChecks runs the registered preflight checks before anything else in the suite runs.  When running in parallel process #1 runs
+// the checks and shares the outcome with the other processes so that, when the environment is not ready, every process gives up straight away.
+func (suite *Suite) runPreflightChecks(numSpecsThatWillBeRun int) {
+	if numSpecsThatWillBeRun == 0 || len(suite.preflightChecks) == 0 || suite.config.DryRun {
+		return
+	}
+
+	var failures []types.PreflightFailure
+	if suite.config.ParallelProcess == 1 {
+		for _, preflightCheck := range suite.preflightChecks {
+			if err := suite.callIntegration(preflightCheck.check); err != nil {
+				failures = append(failures, types.PreflightFailure{Check: preflightCheck.name, Message: suite.redactions.Apply(err.Error()), Location: preflightCheck.codeLocation})
+			}
+		}
+		if suite.isRunningInParallel() {
+			suite.client.PostPreflightCompleted(failures)
+		}
+		suite.report.PreflightFailures = failures
+	} else {
+		var err error
+		failures, err = suite.client.BlockUntilPreflightCompleted()
+		if err != nil {
+			suite.report.SpecialSuiteFailureReasons = append(suite.report.SpecialSuiteFailureReasons, err.Error())
+			suite.report.SuiteSucceeded = false
+			suite.environmentNotReady = true
+			return
+		}
+	}
+
+	if len(failures) > 0 {
+		suite.report.SpecialSuiteFailureReasons = append(suite.report.SpecialSuiteFailureReasons, "Environment not ready: preflight checks failed")
+		suite.report.SuiteSucceeded = false
+		suite.environmentNotReady = true
+	}
+}
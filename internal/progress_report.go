@@ -0,0 +1,85 @@
+package internal
+
+import (
+	"bytes"
+	"fmt"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/onsi/ginkgo/v2/types"
+)
+
+// pollProgressAfter returns the current spec's PollProgressAfter decoration, if set, and --poll-progress-after otherwise
+func (suite *Suite) pollProgressAfter() time.Duration {
+	if suite.currentSpecReport.PollProgressAfter > 0 {
+		return suite.currentSpecReport.PollProgressAfter
+	}
+	return suite.config.PollProgressAfter
+}
+
+// newProgressReport identifies the current spec and the passed-in node.  The report is completed by generateProgressReport each time the node is polled.
+func (suite *Suite) newProgressReport(node Node, nodeStartTime time.Time) types.ProgressReport {
+	return types.ProgressReport{
+		ParallelProcess:         suite.config.ParallelProcess,
+		ContainerHierarchyTexts: suite.currentSpecReport.ContainerHierarchyTexts,
+		LeafNodeText:            suite.currentSpecReport.LeafNodeText,
+		LeafNodeLocation:        suite.currentSpecReport.LeafNodeLocation,
+		SpecStartTime:           suite.currentSpecReport.StartTime,
+		OrderedContainerStep:    suite.currentSpecReport.OrderedContainerStep,
+		OrderedContainerSteps:   suite.currentSpecReport.OrderedContainerSteps,
+		CurrentNodeType:         node.NodeType,
+		CurrentNodeText:         node.Text,
+		CurrentNodeLocation:     node.CodeLocation,
+		CurrentNodeStartTime:    nodeStartTime,
+	}
+}
+
+// generateProgressReport stamps the passed-in report with the current time and the stack of the goroutine running the node
+func generateProgressReport(report types.ProgressReport, nodeGoroutineID uint64) types.ProgressReport {
+	report.Time = time.Now()
+	report.NodeGoroutineStack = goroutineStack(nodeGoroutineID)
+	return report
+}
+
+func (suite *Suite) emitProgressReport(report types.ProgressReport) {
+	suite.reporter.EmitProgressReport(report)
+	if suite.isRunningInParallel() {
+		suite.client.PostEmitProgressReport(report)
+	}
+}
+
+// currentGoroutineID parses the ID of the calling goroutine out of the header of its stack trace (e.g. "goroutine 42 [running]:")
+func currentGoroutineID() uint64 {
+	buf := make([]byte, 64)
+	buf = bytes.TrimPrefix(buf[:runtime.Stack(buf, false)], []byte("goroutine "))
+	if idx := bytes.IndexByte(buf, ' '); idx > 0 {
+		buf = buf[:idx]
+	}
+	id, _ := strconv.ParseUint(string(buf), 10, 64)
+	return id
+}
+
+// goroutineStack returns the stack trace of the goroutine with the passed-in ID, or "" if that goroutine is no longer running
+func goroutineStack(id uint64) string {
+	if id == 0 {
+		return ""
+	}
+	buf := make([]byte, 8192)
+	for {
+		n := runtime.Stack(buf, true)
+		if n < len(buf) {
+			buf = buf[:n]
+			break
+		}
+		buf = make([]byte, 2*len(buf))
+	}
+	header := fmt.Sprintf("goroutine %d [", id)
+	for _, stack := range strings.Split(string(buf), "\n\n") {
+		if strings.HasPrefix(stack, header) {
+			return strings.TrimSpace(stack)
+		}
+	}
+	return ""
+}
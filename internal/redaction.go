@@ -0,0 +1,133 @@
+package internal
+
+import (
+	"regexp"
+
+	"github.com/onsi/ginkgo/v2/types"
+)
+
+const REDACTED = "[REDACTED]"
+
+// RedactionForValue returns a redaction that replaces every occurrence of value with REDACTED
+func RedactionForValue(value string) OutputFilter {
+	return RedactionForPattern(regexp.MustCompile(regexp.QuoteMeta(value)))
+}
+
+// RedactionForPattern returns a redaction that replaces every match of pattern with REDACTED
+func RedactionForPattern(pattern *regexp.Regexp) OutputFilter {
+	return OutputFilter{Pattern: pattern, Replacement: REDACTED}
+}
+
+/*
+RedactSpecReport applies redactions to everything in report that can carry user-provided content: captured GinkgoWriter and stdout/stderr output, failure messages and panics, additional failures, per-attempt output and failures, warnings, annotations, report entries, step messages, environment degradations, and the rerun command.
+*/
+func RedactSpecReport(report types.SpecReport, redactions *OutputFilters) types.SpecReport {
+	if redactions.Len() == 0 {
+		return report
+	}
+	redact := redactions.Apply
+
+	report.CapturedGinkgoWriterOutput = redact(report.CapturedGinkgoWriterOutput)
+	report.CapturedStdOutErr = redact(report.CapturedStdOutErr)
+	report.Failure = redactFailure(report.Failure, redact)
+	report.AdditionalFailures = redactAdditionalFailures(report.AdditionalFailures, redact)
+
+	if report.Attempts != nil {
+		attempts := make(types.SpecAttempts, len(report.Attempts))
+		for i, attempt := range report.Attempts {
+			attempt.CapturedGinkgoWriterOutput = redact(attempt.CapturedGinkgoWriterOutput)
+			attempt.CapturedStdOutErr = redact(attempt.CapturedStdOutErr)
+			attempt.Failure = redactFailure(attempt.Failure, redact)
+			attempt.AdditionalFailures = redactAdditionalFailures(attempt.AdditionalFailures, redact)
+			attempts[i] = attempt
+		}
+		report.Attempts = attempts
+	}
+
+	if report.Warnings != nil {
+		warnings := make([]types.Warning, len(report.Warnings))
+		for i, warning := range report.Warnings {
+			warning.Message = redact(warning.Message)
+			warnings[i] = warning
+		}
+		report.Warnings = warnings
+	}
+
+	if report.Annotations != nil {
+		annotations := make(types.SpecAnnotations, len(report.Annotations))
+		for i, annotation := range report.Annotations {
+			annotation.Value = annotation.Value.Redacted(redact)
+			annotations[i] = annotation
+		}
+		report.Annotations = annotations
+	}
+
+	if report.Steps != nil {
+		steps := make([]types.SpecStep, len(report.Steps))
+		for i, step := range report.Steps {
+			step.Message = redact(step.Message)
+			steps[i] = step
+		}
+		report.Steps = steps
+	}
+
+	if report.EnvironmentDegradations != nil {
+		degradations := make([]types.EnvironmentDegradation, len(report.EnvironmentDegradations))
+		for i, degradation := range report.EnvironmentDegradations {
+			degradation.Message = redact(degradation.Message)
+			degradations[i] = degradation
+		}
+		report.EnvironmentDegradations = degradations
+	}
+
+	report.RerunCommand = redact(report.RerunCommand)
+
+	if report.ReportEntries != nil {
+		entries := make(types.ReportEntries, len(report.ReportEntries))
+		for i, entry := range report.ReportEntries {
+			entry.Value = entry.Value.Redacted(redact)
+			entries[i] = entry
+		}
+		report.ReportEntries = entries
+	}
+
+	return report
+}
+
+func redactFailure(failure types.Failure, redact func(string) string) types.Failure {
+	if failure.IsZero() {
+		return failure
+	}
+	failure.Message = redact(failure.Message)
+	failure.ForwardedPanic = redact(failure.ForwardedPanic)
+	if failure.Error != nil {
+		failureError := *failure.Error
+		failureError.Error = redact(failureError.Error)
+		failure.Error = &failureError
+	}
+	if failure.Panic != nil {
+		panicValue := *failure.Panic
+		panicValue.Error = redact(panicValue.Error)
+		failure.Panic = &panicValue
+	}
+	if failure.Payload != nil {
+		payload := *failure.Payload
+		payload.AsJSON = redact(payload.AsJSON)
+		payload.Representation = redact(payload.Representation)
+		failure.Payload = &payload
+	}
+	return failure
+}
+
+func redactAdditionalFailures(additionalFailures []types.AdditionalFailure, redact func(string) string) []types.AdditionalFailure {
+	if additionalFailures == nil {
+		return nil
+	}
+	out := make([]types.AdditionalFailure, len(additionalFailures))
+	for i, additionalFailure := range additionalFailures {
+		additionalFailure.Failure = redactFailure(additionalFailure.Failure, redact)
+		additionalFailure.CapturedGinkgoWriterOutput = redact(additionalFailure.CapturedGinkgoWriterOutput)
+		out[i] = additionalFailure
+	}
+	return out
+}
@@ -0,0 +1,100 @@
+package internal_test
+
+import (
+	"errors"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/onsi/ginkgo/v2/internal"
+	"github.com/onsi/ginkgo/v2/types"
+)
+
+var _ = Describe("RedactSpecReport", func() {
+	var redactions *internal.OutputFilters
+	const secret, redacted = "token hunter2", "token [REDACTED]"
+
+	BeforeEach(func() {
+		redactions = internal.NewOutputFilters()
+		redactions.Add(internal.RedactionForValue("hunter2"))
+	})
+
+	It("returns the report as is when there are no redactions", func() {
+		report := types.SpecReport{CapturedGinkgoWriterOutput: secret}
+		Ω(internal.RedactSpecReport(report, internal.NewOutputFilters())).Should(Equal(report))
+	})
+
+	failureWith := func(failure types.Failure) types.Failure {
+		failure.Message = secret
+		failure.Panic = types.NewPanicValue(errors.New(secret))
+		failure.Error = types.NewFailureError(errors.New(secret))
+		failure.Payload = &types.FailurePayload{AsJSON: `"` + secret + `"`, Representation: secret}
+		return failure
+	}
+	failureFields := func(failure types.Failure) []string {
+		return []string{failure.Message, failure.Panic.Error, failure.Error.Error, failure.Payload.Representation}
+	}
+
+	DescribeTable("redacts every field that can carry user-provided content, without modifying the original report",
+		func(report types.SpecReport, fields func(types.SpecReport) []string) {
+			for _, field := range fields(report) {
+				Ω(field).Should(ContainSubstring(secret))
+			}
+			for _, field := range fields(internal.RedactSpecReport(report, redactions)) {
+				Ω(field).ShouldNot(ContainSubstring("hunter2"))
+				Ω(field).Should(ContainSubstring(redacted))
+			}
+		},
+		Entry("captured output",
+			types.SpecReport{CapturedGinkgoWriterOutput: secret, CapturedStdOutErr: secret},
+			func(r types.SpecReport) []string { return []string{r.CapturedGinkgoWriterOutput, r.CapturedStdOutErr} },
+		),
+		Entry("the failure",
+			types.SpecReport{Failure: failureWith(types.Failure{ForwardedPanic: secret})},
+			func(r types.SpecReport) []string { return append(failureFields(r.Failure), r.Failure.ForwardedPanic) },
+		),
+		Entry("additional failures",
+			types.SpecReport{AdditionalFailures: []types.AdditionalFailure{{Failure: failureWith(types.Failure{}), CapturedGinkgoWriterOutput: secret}}},
+			func(r types.SpecReport) []string {
+				return append(failureFields(r.AdditionalFailures[0].Failure), r.AdditionalFailures[0].CapturedGinkgoWriterOutput)
+			},
+		),
+		Entry("attempts",
+			types.SpecReport{Attempts: types.SpecAttempts{{
+				Failure:                    failureWith(types.Failure{}),
+				AdditionalFailures:         []types.AdditionalFailure{{Failure: failureWith(types.Failure{})}},
+				CapturedGinkgoWriterOutput: secret,
+				CapturedStdOutErr:          secret,
+			}}},
+			func(r types.SpecReport) []string {
+				attempt := r.Attempts[0]
+				fields := append(failureFields(attempt.Failure), failureFields(attempt.AdditionalFailures[0].Failure)...)
+				return append(fields, attempt.CapturedGinkgoWriterOutput, attempt.CapturedStdOutErr)
+			},
+		),
+		Entry("warnings",
+			types.SpecReport{Warnings: []types.Warning{{Message: secret}}},
+			func(r types.SpecReport) []string { return []string{r.Warnings[0].Message} },
+		),
+		Entry("annotations",
+			types.SpecReport{Annotations: types.SpecAnnotations{{Key: "credentials", Value: types.WrapEntryValue(secret)}}},
+			func(r types.SpecReport) []string { return []string{r.Annotations[0].Value.String()} },
+		),
+		Entry("report entries",
+			types.SpecReport{ReportEntries: types.ReportEntries{{Name: "credentials", Value: types.WrapEntryValue(secret)}}},
+			func(r types.SpecReport) []string { return []string{r.ReportEntries[0].Value.String()} },
+		),
+		Entry("step messages",
+			types.SpecReport{Steps: []types.SpecStep{{Text: "login", State: types.SpecStateFailed, Message: secret}}},
+			func(r types.SpecReport) []string { return []string{r.Steps[0].Message} },
+		),
+		Entry("environment degradations",
+			types.SpecReport{EnvironmentDegradations: []types.EnvironmentDegradation{{HealthCheck: "vault", Message: secret}}},
+			func(r types.SpecReport) []string { return []string{r.EnvironmentDegradations[0].Message} },
+		),
+		Entry("the rerun command",
+			types.SpecReport{RerunCommand: "API_" + secret + " ginkgo --focus=foo"},
+			func(r types.SpecReport) []string { return []string{r.RerunCommand} },
+		),
+	)
+})
@@ -0,0 +1,72 @@
+package internal
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/onsi/ginkgo/v2/types"
+)
+
+// RequirementChecker is called with the constraint of a named requirement (e.g. ">=14" for "postgres>=14", or "" for "docker") and returns an error if the requirement is not met
+type RequirementChecker func(constraint string) error
+
+type registeredRequirementChecker struct {
+	checker      RequirementChecker
+	codeLocation types.CodeLocation
+}
+
+var namedRequirementRegExp = regexp.MustCompile(`^([a-zA-Z0-9_\-./]+)(.*)$`)
+
+// ParseNamedRequirement splits a named requirement like "postgres>=14" into its name ("postgres") and its constraint (">=14").  The name is empty if the requirement is malformed.
+func ParseNamedRequirement(requirement string) (name string, constraint string) {
+	match := namedRequirementRegExp.FindStringSubmatch(strings.TrimSpace(requirement))
+	if match == nil {
+		return "", ""
+	}
+	return match[1], strings.TrimSpace(match[2])
+}
+
+// RegisterRequirementChecker registers the checker for the named requirements called name.  Registering a second checker for the same name replaces the first.
+func (suite *Suite) RegisterRequirementChecker(name string, checker RequirementChecker, cl types.CodeLocation) {
+	if suite.requirementCheckers == nil {
+		suite.requirementCheckers = map[string]registeredRequirementChecker{}
+	}
+	suite.requirementCheckers[name] = registeredRequirementChecker{checker: checker, codeLocation: cl}
+}
+
+// checkRequirements checks - once - each named requirement declared by the specs that will run and records the requirements that are not met in the suite's report.
+// The specs that declare unmet requirements are skipped when they come up to run.
+func (suite *Suite) checkRequirements(specs Specs) {
+	suite.unmetRequirements = map[string]string{}
+	if suite.config.DryRun || suite.skipAll {
+		return
+	}
+	checked := map[string]bool{}
+	for _, spec := range specs {
+		if spec.Skip || spec.Nodes.HasNodeMarkedPending() {
+			continue
+		}
+		for _, requirement := range spec.Nodes.NamedRequirements() {
+			if checked[requirement] {
+				continue
+			}
+			checked[requirement] = true
+			if err := suite.checkRequirement(requirement); err != nil {
+				suite.unmetRequirements[requirement] = err.Error()
+				suite.report.UnmetRequirements = append(suite.report.UnmetRequirements, types.UnmetRequirement{Requirement: requirement, Reason: err.Error()})
+			}
+		}
+	}
+}
+
+func (suite *Suite) checkRequirement(requirement string) error {
+	name, constraint := ParseNamedRequirement(requirement)
+	registered, ok := suite.requirementCheckers[name]
+	if !ok {
+		return fmt.Errorf("no RequirementChecker is registered for \"%s\"", name)
+	}
+	return suite.callIntegration(func() error {
+		return registered.checker(constraint)
+	})
+}
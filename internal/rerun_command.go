@@ -0,0 +1,46 @@
+package internal
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/onsi/ginkgo/v2/types"
+)
+
+var shellSafe = regexp.MustCompile(`^[a-zA-Z0-9_\-./:=,@+%]+$`)
+
+// RerunCommand returns a command that reruns just the spec described by report - with the same seed, the same relevant flags, and the environment variables captured in env
+func RerunCommand(suitePath string, config types.SuiteConfig, env map[string]string, report types.SpecReport) string {
+	args := []string{}
+
+	envKeys := []string{}
+	for key := range env {
+		envKeys = append(envKeys, key)
+	}
+	sort.Strings(envKeys)
+	for _, key := range envKeys {
+		args = append(args, key+"="+shellQuote(env[key]))
+	}
+
+	args = append(args, "ginkgo", fmt.Sprintf("--seed=%d", config.RandomSeed))
+	if config.RandomizeAllSpecs {
+		args = append(args, "--randomize-all")
+	}
+	if report.LeafNodeType.Is(types.NodeTypeIt) {
+		args = append(args, "--focus-file="+shellQuote(fmt.Sprintf("%s:%d", report.LeafNodeLocation.FileName, report.LeafNodeLocation.LineNumber)))
+	}
+	if suitePath != "" {
+		args = append(args, shellQuote(suitePath))
+	}
+
+	return strings.Join(args, " ")
+}
+
+func shellQuote(s string) string {
+	if shellSafe.MatchString(s) {
+		return s
+	}
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
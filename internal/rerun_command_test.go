@@ -0,0 +1,46 @@
+package internal_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/onsi/ginkgo/v2/internal"
+	"github.com/onsi/ginkgo/v2/types"
+)
+
+var _ = Describe("RerunCommand", func() {
+	var report types.SpecReport
+	var config types.SuiteConfig
+
+	BeforeEach(func() {
+		report = types.SpecReport{
+			LeafNodeType:     types.NodeTypeIt,
+			LeafNodeLocation: types.CodeLocation{FileName: "/path/to/suite/books_test.go", LineNumber: 17},
+		}
+		config = types.SuiteConfig{RandomSeed: 1138}
+	})
+
+	It("focuses on the spec and uses the same seed", func() {
+		Ω(internal.RerunCommand("/path/to/suite", config, nil, report)).Should(Equal("ginkgo --seed=1138 --focus-file=/path/to/suite/books_test.go:17 /path/to/suite"))
+	})
+
+	It("includes --randomize-all if it was set", func() {
+		config.RandomizeAllSpecs = true
+		Ω(internal.RerunCommand("/path/to/suite", config, nil, report)).Should(Equal("ginkgo --seed=1138 --randomize-all --focus-file=/path/to/suite/books_test.go:17 /path/to/suite"))
+	})
+
+	It("includes the environment, sorted by name", func() {
+		env := map[string]string{"TZ": "UTC", "GOFLAGS": "-mod=vendor -count=1"}
+		Ω(internal.RerunCommand("/path/to/suite", config, env, report)).Should(Equal("GOFLAGS='-mod=vendor -count=1' TZ=UTC ginkgo --seed=1138 --focus-file=/path/to/suite/books_test.go:17 /path/to/suite"))
+	})
+
+	It("quotes paths that need quoting", func() {
+		report.LeafNodeLocation.FileName = "/path/to/my suite/books_test.go"
+		Ω(internal.RerunCommand("/path/to/my suite", config, nil, report)).Should(Equal("ginkgo --seed=1138 --focus-file='/path/to/my suite/books_test.go:17' '/path/to/my suite'"))
+	})
+
+	It("reruns the whole suite for suite-level nodes", func() {
+		report.LeafNodeType = types.NodeTypeBeforeSuite
+		Ω(internal.RerunCommand("/path/to/suite", config, nil, report)).Should(Equal("ginkgo --seed=1138 /path/to/suite"))
+	})
+})
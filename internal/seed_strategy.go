@@ -0,0 +1,75 @@
+package internal
+
+import (
+	"fmt"
+	"hash/fnv"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/onsi/ginkgo/v2/types"
+)
+
+// CIBuildIDEnvVars are the environment variables the ci-build seed strategy reads the CI system's build ID from, in order
+var CIBuildIDEnvVars = []string{"GINKGO_CI_BUILD_ID", "GITHUB_RUN_ID", "CI_PIPELINE_ID", "BUILD_ID", "BUILDKITE_BUILD_ID", "CIRCLE_WORKFLOW_ID", "TRAVIS_BUILD_ID"}
+
+// CIBranchEnvVars are the environment variables the branch seed strategy reads the branch from when git is on a detached HEAD (as it often is in CI), in order
+var CIBranchEnvVars = []string{"GITHUB_HEAD_REF", "GITHUB_REF_NAME", "CI_COMMIT_REF_NAME", "BRANCH_NAME", "BUILDKITE_BRANCH", "CIRCLE_BRANCH", "TRAVIS_BRANCH"}
+
+/*
+SeedForStrategy computes a random seed using the passed-in --seed-strategy:
+
+  - time (the default) uses the current time, so every run is ordered differently
+  - git-sha hashes the commit that is checked out, so every run of a commit is ordered the same way
+  - ci-build hashes the CI system's build ID (see CIBuildIDEnvVars), so retries of a build are ordered the same way
+  - branch hashes the branch that is checked out, so every run on a branch is ordered the same way
+*/
+func SeedForStrategy(strategy string) (int64, error) {
+	var value string
+	switch strings.ToLower(strategy) {
+	case "", types.SeedStrategyTime:
+		return time.Now().Unix(), nil
+	case types.SeedStrategyGitSHA:
+		sha, err := gitOutput("rev-parse", "HEAD")
+		if err != nil || sha == "" {
+			return 0, types.GinkgoErrors.SeedStrategyFailed(strategy, "could not determine the commit that is checked out - is this a git repository?")
+		}
+		value = sha
+	case types.SeedStrategyCIBuild:
+		value = firstEnvVar(CIBuildIDEnvVars)
+		if value == "" {
+			return 0, types.GinkgoErrors.SeedStrategyFailed(strategy, fmt.Sprintf("none of %s is set", strings.Join(CIBuildIDEnvVars, ", ")))
+		}
+	case types.SeedStrategyBranch:
+		branch, err := gitOutput("rev-parse", "--abbrev-ref", "HEAD")
+		if err != nil || branch == "HEAD" {
+			branch = firstEnvVar(CIBranchEnvVars)
+		}
+		if branch == "" {
+			return 0, types.GinkgoErrors.SeedStrategyFailed(strategy, fmt.Sprintf("git is not on a branch and none of %s is set", strings.Join(CIBranchEnvVars, ", ")))
+		}
+		value = branch
+	default:
+		return 0, types.GinkgoErrors.InvalidSeedStrategy(strategy)
+	}
+
+	// seeds are 32-bit so that they remain easy to pass back in with --seed
+	h := fnv.New32a()
+	h.Write([]byte(value))
+	return int64(h.Sum32()), nil
+}
+
+func gitOutput(args ...string) (string, error) {
+	out, err := exec.Command("git", args...).Output()
+	return strings.TrimSpace(string(out)), err
+}
+
+func firstEnvVar(names []string) string {
+	for _, name := range names {
+		if value := strings.TrimSpace(os.Getenv(name)); value != "" {
+			return value
+		}
+	}
+	return ""
+}
@@ -0,0 +1,69 @@
+package internal_test
+
+import (
+	"os"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/onsi/ginkgo/v2/internal"
+	"github.com/onsi/ginkgo/v2/types"
+)
+
+var _ = Describe("SeedForStrategy", func() {
+	BeforeEach(func() {
+		for _, name := range internal.CIBuildIDEnvVars {
+			if value, ok := os.LookupEnv(name); ok {
+				os.Unsetenv(name)
+				DeferCleanup(os.Setenv, name, value)
+			}
+		}
+	})
+
+	It("uses the current time by default", func() {
+		for _, strategy := range []string{"", "time"} {
+			seed, err := internal.SeedForStrategy(strategy)
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(seed).Should(BeNumerically("~", time.Now().Unix(), 1))
+		}
+	})
+
+	Describe("the ci-build strategy", func() {
+		It("computes a seed that is stable for a build ID and varies across build IDs", func() {
+			DeferCleanup(os.Unsetenv, "BUILD_ID")
+			os.Setenv("BUILD_ID", "1234")
+			first, err := internal.SeedForStrategy("ci-build")
+			Ω(err).ShouldNot(HaveOccurred())
+			again, err := internal.SeedForStrategy("ci-build")
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(again).Should(Equal(first))
+			Ω(first).Should(BeNumerically(">=", 0))
+
+			os.Setenv("BUILD_ID", "1235")
+			other, err := internal.SeedForStrategy("ci-build")
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(other).ShouldNot(Equal(first))
+		})
+
+		It("prefers GINKGO_CI_BUILD_ID to the CI system's variables", func() {
+			DeferCleanup(os.Unsetenv, "BUILD_ID")
+			DeferCleanup(os.Unsetenv, "GINKGO_CI_BUILD_ID")
+			os.Setenv("GINKGO_CI_BUILD_ID", "1234")
+			expected, _ := internal.SeedForStrategy("ci-build")
+			os.Setenv("BUILD_ID", "5678")
+			Ω(internal.SeedForStrategy("ci-build")).Should(Equal(expected))
+		})
+
+		It("errors when no build ID is available", func() {
+			_, err := internal.SeedForStrategy("ci-build")
+			Ω(err).Should(HaveOccurred())
+			Ω(err.Error()).Should(ContainSubstring("GITHUB_RUN_ID"))
+		})
+	})
+
+	It("errors for unknown strategies", func() {
+		_, err := internal.SeedForStrategy("dice")
+		Ω(err).Should(MatchError(types.GinkgoErrors.InvalidSeedStrategy("dice")))
+	})
+})
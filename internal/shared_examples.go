@@ -0,0 +1,34 @@
+package internal
+
+import (
+	"reflect"
+
+	"github.com/onsi/ginkgo/v2/types"
+)
+
+type sharedExampleGroup struct {
+	body         interface{}
+	codeLocation types.CodeLocation
+}
+
+// RegisterSharedExamples registers a group of shared examples that ItBehavesLike can expand into the spec tree.  body must be a function with no return values.
+func (suite *Suite) RegisterSharedExamples(name string, body interface{}, cl types.CodeLocation) error {
+	t := reflect.TypeOf(body)
+	if t == nil || t.Kind() != reflect.Func || t.NumOut() != 0 {
+		return types.GinkgoErrors.InvalidSharedExamplesBody(name, cl)
+	}
+	if existing, found := suite.sharedExamples[name]; found {
+		return types.GinkgoErrors.MultipleSharedExamplesWithName(name, existing.codeLocation, cl)
+	}
+	if suite.sharedExamples == nil {
+		suite.sharedExamples = map[string]sharedExampleGroup{}
+	}
+	suite.sharedExamples[name] = sharedExampleGroup{body: body, codeLocation: cl}
+	return nil
+}
+
+// SharedExamples returns the body of the shared examples registered with name
+func (suite *Suite) SharedExamples(name string) (interface{}, bool) {
+	group, found := suite.sharedExamples[name]
+	return group.body, found
+}
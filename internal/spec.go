@@ -1,7 +1,9 @@
 package internal
 
 import (
+	"fmt"
 	"strings"
+	"time"
 
 	"github.com/onsi/ginkgo/v2/types"
 )
@@ -35,11 +37,32 @@ func (s Spec) FlakeAttempts() int {
 		if s.Nodes[i].FlakeAttempts > 0 {
 			flakeAttempts = s.Nodes[i].FlakeAttempts
 		}
+		if s.Nodes[i].RetryPolicy.Attempts > 0 {
+			flakeAttempts = s.Nodes[i].RetryPolicy.Attempts
+		}
 	}
 
 	return flakeAttempts
 }
 
+// maxRetryBackoff caps the Retry decorator's exponential backoff so that specs with many attempts don't wait forever (or overflow)
+const maxRetryBackoff = 10 * time.Minute
+
+// RetryBackoff returns how long to wait before making the passed-in (0-indexed) attempt.  The Retry decorator's backoff doubles with each retry, up to maxRetryBackoff.
+func (s Spec) RetryBackoff(attempt int) time.Duration {
+	backoff := s.FirstNodeWithType(types.NodeTypeIt).RetryPolicy.Backoff
+	if attempt == 0 || backoff <= 0 {
+		return 0
+	}
+	for i := 1; i < attempt && backoff < maxRetryBackoff; i++ {
+		backoff *= 2
+		if backoff > maxRetryBackoff {
+			backoff = maxRetryBackoff
+		}
+	}
+	return backoff
+}
+
 type Specs []Spec
 
 func (s Specs) HasAnySpecsMarkedPending() bool {
@@ -62,6 +85,17 @@ func (s Specs) CountWithoutSkip() int {
 	return n
 }
 
+// DescriptionsWithoutSkip returns the text and location of every spec that will run
+func (s Specs) DescriptionsWithoutSkip() []string {
+	out := []string{}
+	for i := range s {
+		if !s[i].Skip {
+			out = append(out, fmt.Sprintf("%s %s", s[i].Text(), s[i].FirstNodeWithType(types.NodeTypeIt).CodeLocation))
+		}
+	}
+	return out
+}
+
 func (s Specs) AtIndices(indices SpecIndices) Specs {
 	out := make(Specs, len(indices))
 	for i, idx := range indices {
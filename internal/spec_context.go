@@ -0,0 +1,45 @@
+package internal
+
+import (
+	"context"
+
+	"github.com/onsi/ginkgo/v2/types"
+)
+
+type SpecContext interface {
+	context.Context
+
+	SpecReport() types.SpecReport
+}
+
+type specContext struct {
+	context.Context
+
+	suite *Suite
+
+	// report, if set, is a snapshot of the spec report that SpecReport returns instead of reading the suite's (which the runner may be updating concurrently)
+	report *types.SpecReport
+}
+
+func NewSpecContext(ctx context.Context, suite *Suite) SpecContext {
+	return specContext{
+		Context: ctx,
+		suite:   suite,
+	}
+}
+
+// newSpecContextWithReport returns a SpecContext whose SpecReport is the passed-in snapshot.  It is used by goroutines that run concurrently with the spec runner
+func newSpecContextWithReport(ctx context.Context, suite *Suite, report types.SpecReport) SpecContext {
+	return specContext{
+		Context: ctx,
+		suite:   suite,
+		report:  &report,
+	}
+}
+
+func (sc specContext) SpecReport() types.SpecReport {
+	if sc.report != nil {
+		return *sc.report
+	}
+	return sc.suite.CurrentSpecReport()
+}
@@ -0,0 +1,67 @@
+package internal
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/onsi/ginkgo/v2/types"
+)
+
+// SpecNamingRules are the naming conventions every It and container in the suite must follow.  Rules that are left empty are not enforced.
+type SpecNamingRules struct {
+	// ItPattern is a regular expression the text of every It - including those generated by table entries - must match
+	ItPattern string
+	// ContainerPattern is a regular expression the text of every container (Describe, Context, When, DescribeTable, ...) must match
+	ContainerPattern string
+	// MaxLength is the maximum length of the text of every It and container
+	MaxLength int
+}
+
+func (suite *Suite) SetSpecNamingRules(rules SpecNamingRules) {
+	suite.specNamingRules = rules
+}
+
+// vetSpecNaming records a structure error for every It and container whose text breaks the suite's naming rules
+func (suite *Suite) vetSpecNaming() error {
+	rules := suite.specNamingRules
+	patterns := map[types.NodeType]*regexp.Regexp{}
+	for _, rule := range []struct {
+		nodeType types.NodeType
+		pattern  string
+	}{{types.NodeTypeIt, rules.ItPattern}, {types.NodeTypeContainer, rules.ContainerPattern}} {
+		if rule.pattern == "" {
+			continue
+		}
+		re, err := regexp.Compile(rule.pattern)
+		if err != nil {
+			return types.GinkgoErrors.InvalidSpecNamingPattern(rule.pattern, err)
+		}
+		patterns[rule.nodeType] = re
+	}
+	if len(patterns) == 0 && rules.MaxLength <= 0 {
+		return nil
+	}
+
+	var vet func(trees TreeNodes)
+	vet = func(trees TreeNodes) {
+		for _, tree := range trees {
+			node := tree.Node
+			if node.NodeType.Is(types.NodeTypeIt | types.NodeTypeContainer) {
+				violations := []string{}
+				if re, ok := patterns[node.NodeType]; ok && !re.MatchString(node.Text) {
+					violations = append(violations, fmt.Sprintf("must match /%s/", re))
+				}
+				if rules.MaxLength > 0 && len(node.Text) > rules.MaxLength {
+					violations = append(violations, fmt.Sprintf("must be at most %d characters long - it is %d", rules.MaxLength, len(node.Text)))
+				}
+				if len(violations) > 0 {
+					suite.structureErrors = append(suite.structureErrors, types.GinkgoErrors.SpecNamingViolation(node.CodeLocation, node.NodeType, node.Text, strings.Join(violations, " and ")))
+				}
+			}
+			vet(tree.Children)
+		}
+	}
+	vet(suite.tree.Children)
+	return nil
+}
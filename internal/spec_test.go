@@ -1,6 +1,8 @@
 package internal_test
 
 import (
+	"time"
+
 	. "github.com/onsi/ginkgo/v2"
 	"github.com/onsi/ginkgo/v2/internal"
 	. "github.com/onsi/gomega"
@@ -77,6 +79,33 @@ var _ = Describe("Spec and Specs", func() {
 		})
 	})
 
+	Describe("spec.RetryBackoff", func() {
+		It("returns 0 when the spec has no Retry decorator", func() {
+			spec := S(N(ntCon), N(ntIt))
+			Ω(spec.RetryBackoff(0)).Should(BeZero())
+			Ω(spec.RetryBackoff(3)).Should(BeZero())
+		})
+
+		It("doubles the backoff before each retry", func() {
+			spec := S(N(ntCon), N(ntIt, Retry(4, time.Second)))
+			Ω(spec.RetryBackoff(0)).Should(BeZero())
+			Ω(spec.RetryBackoff(1)).Should(Equal(time.Second))
+			Ω(spec.RetryBackoff(2)).Should(Equal(2 * time.Second))
+			Ω(spec.RetryBackoff(3)).Should(Equal(4 * time.Second))
+		})
+
+		It("never waits more than ten minutes, however many attempts there are", func() {
+			spec := S(N(ntCon), N(ntIt, Retry(100, time.Second)))
+			Ω(spec.RetryBackoff(10)).Should(Equal(512 * time.Second))
+			Ω(spec.RetryBackoff(11)).Should(Equal(10 * time.Minute))
+			Ω(spec.RetryBackoff(39)).Should(Equal(10 * time.Minute))
+			Ω(spec.RetryBackoff(99)).Should(Equal(10 * time.Minute))
+
+			spec = S(N(ntCon), N(ntIt, Retry(3, time.Hour)))
+			Ω(spec.RetryBackoff(2)).Should(Equal(time.Hour))
+		})
+	})
+
 	Describe("specs.HasAnySpecsMarkedPending", func() {
 		Context("when there are no specs with any nodes marked pending", func() {
 			It("returns false", func() {
@@ -0,0 +1,122 @@
+package internal
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/onsi/ginkgo/v2/types"
+)
+
+// stepRecorder records the steps of the current attempt at running a spec (or suite node).  A step can outlive the attempt that started it -
+// when the spec times out, say - so the recorder is guarded by a lock and is closed once the attempt ends.  Steps that finish after that are not recorded.
+type stepRecorder struct {
+	lock         *sync.Mutex
+	steps        []types.SpecStep
+	nestingLevel int
+	closed       bool
+}
+
+func newStepRecorder() *stepRecorder {
+	return &stepRecorder{lock: &sync.Mutex{}}
+}
+
+func (r *stepRecorder) start(text string, cl types.CodeLocation) int {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.steps = append(r.steps, types.SpecStep{
+		Text:         text,
+		Location:     cl,
+		NestingLevel: r.nestingLevel,
+		StartTime:    time.Now(),
+	})
+	r.nestingLevel += 1
+	return len(r.steps) - 1
+}
+
+func (r *stepRecorder) finish(idx int, state types.SpecState, message string) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if r.closed {
+		return
+	}
+	r.nestingLevel -= 1
+	step := &r.steps[idx]
+	step.EndTime = time.Now()
+	step.RunTime = step.EndTime.Sub(step.StartTime)
+	step.State, step.Message = state, message
+}
+
+func (r *stepRecorder) snapshot() []types.SpecStep {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if len(r.steps) == 0 {
+		return nil
+	}
+	return append([]types.SpecStep{}, r.steps...)
+}
+
+// close stops the recorder from recording any more steps and returns the steps it recorded
+func (r *stepRecorder) close() []types.SpecStep {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.closed = true
+	return r.steps
+}
+
+// RunStep runs body as a Step of the current spec, recording its outcome in the current spec's report
+// Failures and panics in body are recorded and then re-raised so that they fail the spec as usual
+func (suite *Suite) RunStep(text string, cl types.CodeLocation, body func()) error {
+	if suite.phase != PhaseRun {
+		return types.GinkgoErrors.StepNotDuringRunPhase(cl)
+	}
+
+	// the spec may end (e.g. time out) while the step is still running, so the step holds on to the recorder for the attempt that started it
+	recorder := suite.steps
+	idx := recorder.start(text, cl)
+	initialState := suite.failer.GetState()
+
+	defer func() {
+		e := recover()
+		state, message := suite.stepOutcome(initialState, e)
+		recorder.finish(idx, state, message)
+		if e != nil {
+			panic(e)
+		}
+	}()
+
+	body()
+	return nil
+}
+
+// stepOutcome determines the state of a step from the failer - and from the panic, if any, that ended the step
+func (suite *Suite) stepOutcome(initialState types.SpecState, e interface{}) (types.SpecState, string) {
+	if state := suite.failer.GetState(); state != initialState && !state.Is(types.SpecStatePassed) {
+		return state, suite.failer.GetFailure().Message
+	}
+	if e == nil {
+		return types.SpecStatePassed, ""
+	}
+	if _, ok := AsStopTryingSignal(e); ok {
+		return types.SpecStateSkipped, fmt.Sprintf("%v", e)
+	}
+	return types.SpecStatePanicked, fmt.Sprintf("%v", e)
+}
+
+// finishAbandonedSteps records the outcome of steps that were still running when the current spec ended - typically because the spec timed out or was interrupted.
+// Such steps take on the spec's state.
+// The steps are moved into the current spec's report and a fresh recorder is set up for the next attempt.
+func (suite *Suite) finishAbandonedSteps() {
+	steps := suite.steps.close()
+	suite.steps = newStepRecorder()
+	for i := range steps {
+		step := &steps[i]
+		if step.State != types.SpecStateInvalid {
+			continue
+		}
+		step.State, step.Message = suite.currentSpecReport.State, suite.currentSpecReport.Failure.Message
+		step.EndTime = suite.currentSpecReport.EndTime
+		step.RunTime = step.EndTime.Sub(step.StartTime)
+	}
+	suite.currentSpecReport.Steps = steps
+}
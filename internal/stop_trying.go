@@ -0,0 +1,43 @@
+package internal
+
+import (
+	"errors"
+
+	"github.com/onsi/ginkgo/v2/types"
+)
+
+// StopTryingSignal is returned (or panicked) by helpers to signal that a spec can't make progress.  Ginkgo converts the signal into a
+// retry if the spec is eligible to be retried and into a skip otherwise.
+type StopTryingSignal struct {
+	Message      string
+	CodeLocation types.CodeLocation
+}
+
+func NewStopTryingSignal(message string, cl types.CodeLocation) StopTryingSignal {
+	return StopTryingSignal{
+		Message:      message,
+		CodeLocation: cl,
+	}
+}
+
+func (s StopTryingSignal) Error() string {
+	return s.Message
+}
+
+// Now panics with the signal, ending the current node immediately
+func (s StopTryingSignal) Now() {
+	panic(s)
+}
+
+// AsStopTryingSignal returns the StopTryingSignal carried by value - which may be the signal itself or an error that wraps it
+func AsStopTryingSignal(value interface{}) (StopTryingSignal, bool) {
+	err, ok := value.(error)
+	if !ok {
+		return StopTryingSignal{}, false
+	}
+	var signal StopTryingSignal
+	if errors.As(err, &signal) {
+		return signal, true
+	}
+	return StopTryingSignal{}, false
+}
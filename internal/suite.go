@@ -1,7 +1,13 @@
 package internal
 
 import (
+	"context"
 	"fmt"
+	"reflect"
+	"runtime"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/onsi/ginkgo/v2/formatter"
@@ -27,6 +33,7 @@ type Suite struct {
 
 	suiteNodes   Nodes
 	cleanupNodes Nodes
+	globalNodes  Nodes
 
 	failer            *Failer
 	reporter          reporters.Reporter
@@ -36,17 +43,63 @@ type Suite struct {
 	config            types.SuiteConfig
 
 	skipAll           bool
+	suiteSkipReason   string
 	report            types.Report
 	currentSpecReport types.SpecReport
 	currentNode       Node
 
+	steps *stepRecorder
+
 	client parallel_support.Client
+
+	specRateLimits  map[string]int
+	specRateLimiter *parallel_support.SpecRateLimiter
+	hostCapacity    types.ResourceRequirements
+	retryPolicies   map[string]types.FailureRetryPolicy
+
+	// the number of assertions made by the current attempt at running the current spec.  Assertions can be made from any goroutine so this is only accessed atomically
+	currentSpecAssertions int64
+	// set, atomically, once RecordAssertion has been called.  --zero-assertion-policy only applies from then on
+	recordsAssertions int32
+
+	chaosMonkey *ChaosMonkey
+
+	phaseOrder      PhaseOrder
+	specNamingRules SpecNamingRules
+
+	deprecations []types.TrackedDeprecation
+
+	redactions *OutputFilters
+
+	sharedExamples map[string]sharedExampleGroup
+
+	structureErrors []error
+
+	failureArtifactCollectors []registeredFailureArtifactCollector
+	networkCapturers          []registeredNetworkCapturer
+	requirementCheckers       map[string]registeredRequirementChecker
+	healthChecks              []registeredHealthCheck
+	assertionDefaultsHandler  AssertionDefaultsHandler
+	labelAssertionDefaults    []labelAssertionDefaults
+	preflightChecks           []registeredPreflightCheck
+	environmentNotReady       bool
+	healthMonitor             *healthMonitor
+	unmetRequirements         map[string]string
+	artifactsDir              string
+	currentSpecArtifactsDir   string
+
+	deadlineLock        *sync.Mutex
+	suiteDeadline       time.Time
+	currentNodeDeadline time.Time
 }
 
 func NewSuite() *Suite {
 	return &Suite{
-		tree:  &TreeNode{},
-		phase: PhaseBuildTopLevel,
+		tree:         &TreeNode{},
+		phase:        PhaseBuildTopLevel,
+		redactions:   NewOutputFilters(),
+		steps:        newStepRecorder(),
+		deadlineLock: &sync.Mutex{},
 	}
 }
 
@@ -55,11 +108,92 @@ func (suite *Suite) BuildTree() error {
 	// We now enter PhaseBuildTree where these top level containers are entered and added to the spec tree
 	suite.phase = PhaseBuildTree
 	for _, topLevelContainer := range suite.topLevelContainers {
-		err := suite.PushNode(topLevelContainer)
+		err := suite.pushNode(topLevelContainer)
 		if err != nil {
 			return err
 		}
 	}
+	// global nodes behave as though they were defined at the very top of the suite, ahead of any other top-level setup nodes
+	globalTreeNodes := TreeNodes{}
+	for _, node := range suite.globalNodes {
+		globalTreeNodes = append(globalTreeNodes, &TreeNode{Node: node, Parent: suite.tree})
+	}
+	suite.tree.Children = append(globalTreeNodes, suite.tree.Children...)
+	if err := suite.vetExecutionPhases(); err != nil {
+		return err
+	}
+	if err := suite.vetSpecNaming(); err != nil {
+		return err
+	}
+
+	switch len(suite.structureErrors) {
+	case 0:
+		return nil
+	case 1:
+		return suite.structureErrors[0]
+	default:
+		return types.SpecStructureErrors(suite.structureErrors)
+	}
+}
+
+// RecordNodeErrors records the errors Ginkgo found while constructing a node (e.g. decorators that are invalid for the node's type).
+// While the tree is being built the errors are held back so that BuildTree can report every invalid node at once - RecordNodeErrors returns false if it is too late to do so.
+func (suite *Suite) RecordNodeErrors(errors []error) bool {
+	if suite.phase == PhaseRun {
+		return false
+	}
+	suite.structureErrors = append(suite.structureErrors, errors...)
+	return true
+}
+
+// SetPhaseOrder declares the order in which the suite's execution phases run.  It must be called before BuildTree.
+func (suite *Suite) SetPhaseOrder(phaseOrder PhaseOrder) {
+	suite.phaseOrder = phaseOrder
+}
+
+// SetDeprecations records the deprecations tracked while building the suite so that they appear in the suite's report
+func (suite *Suite) SetDeprecations(deprecations []types.TrackedDeprecation) {
+	suite.deprecations = deprecations
+}
+
+// executionPhases returns the suite's phases in the order they run - specs that are not decorated with Phase run first.
+// It returns nil if the suite has not declared a PhaseOrder.
+func (suite *Suite) executionPhases() PhaseOrder {
+	if len(suite.phaseOrder) == 0 {
+		return nil
+	}
+	for _, phase := range suite.phaseOrder {
+		if phase == "" {
+			return suite.phaseOrder
+		}
+	}
+	return append(PhaseOrder{""}, suite.phaseOrder...)
+}
+
+func (suite *Suite) vetExecutionPhases() error {
+	declared := map[string]bool{}
+	for _, phase := range suite.phaseOrder {
+		if declared[phase] {
+			return types.GinkgoErrors.DuplicatePhaseInPhaseOrder(phase)
+		}
+		declared[phase] = true
+	}
+
+	var vet func(trees TreeNodes, inOrderedContainer bool)
+	vet = func(trees TreeNodes, inOrderedContainer bool) {
+		for _, tree := range trees {
+			node := tree.Node
+			if node.Phase != "" {
+				if inOrderedContainer {
+					suite.structureErrors = append(suite.structureErrors, types.GinkgoErrors.PhaseInOrderedContainer(node.CodeLocation, node.NodeType))
+				} else if !declared[node.Phase] {
+					suite.structureErrors = append(suite.structureErrors, types.GinkgoErrors.UnknownExecutionPhase(node.CodeLocation, node.NodeType, node.Phase, suite.phaseOrder))
+				}
+			}
+			vet(tree.Children, inOrderedContainer || node.MarkedOrdered)
+		}
+	}
+	vet(suite.tree.Children, false)
 	return nil
 }
 
@@ -79,12 +213,38 @@ func (suite *Suite) Run(description string, suiteLabels Labels, suitePath string
 	suite.outputInterceptor = outputInterceptor
 	suite.interruptHandler = interruptHandler
 	suite.config = suiteConfig
+	if suiteConfig.Timeout > 0 {
+		suite.suiteDeadline = time.Now().Add(suiteConfig.Timeout)
+	}
 
 	success := suite.runSpecs(description, suiteLabels, suitePath, hasProgrammaticFocus, specs)
 
 	return success, hasProgrammaticFocus
 }
 
+// Explain reports how Ginkgo's focus policy treats the specs that match query.  ginkgo explain calls it in place of Run.
+func (suite *Suite) Explain(description string, suiteLabels Labels, suiteConfig types.SuiteConfig, query string) []FocusExplanation {
+	if suite.phase != PhaseBuildTree {
+		panic("cannot explain before building the tree = call suite.BuildTree() first")
+	}
+	ApplyNestedFocusPolicyToTree(suite.tree)
+	specs := GenerateSpecsFromTreeRoot(suite.tree)
+	return ExplainFocus(specs, description, suiteLabels, suiteConfig, query)
+}
+
+func (suite *Suite) Describe(description string, suiteLabels Labels, suitePath string) types.SuiteDescription {
+	if suite.phase != PhaseBuildTree {
+		panic("cannot describe before building the tree = call suite.BuildTree() first")
+	}
+	specs := GenerateSpecsFromTreeRoot(suite.tree)
+	return DescribeSuite(specs, description, suiteLabels, suitePath)
+}
+
+// ExitReason returns the reason the suite's run ended the way it did.  It is only meaningful after Run has returned.
+func (suite *Suite) ExitReason() types.ExitReason {
+	return suite.report.ExitReason
+}
+
 /*
   Tree Construction methods
 
@@ -92,29 +252,38 @@ func (suite *Suite) Run(description string, suiteLabels Labels, suitePath string
 */
 
 func (suite *Suite) PushNode(node Node) error {
+	// during PhaseBuildTree every node must be pushed from within the body of a container node - nodes pushed from any other goroutine would land in the wrong container
+	if suite.phase == PhaseBuildTree && !isCalledFromContainerBody() {
+		return types.GinkgoErrors.PushingNodeFromAnotherGoroutine(node.NodeType, withStackTrace(node.CodeLocation))
+	}
+	return suite.pushNode(node)
+}
+
+func (suite *Suite) pushNode(node Node) error {
 	if node.NodeType.Is(types.NodeTypeCleanupInvalid | types.NodeTypeCleanupAfterEach | types.NodeTypeCleanupAfterAll | types.NodeTypeCleanupAfterSuite) {
 		return suite.pushCleanupNode(node)
 	}
 
-	if node.NodeType.Is(types.NodeTypeBeforeSuite | types.NodeTypeAfterSuite | types.NodeTypeSynchronizedBeforeSuite | types.NodeTypeSynchronizedAfterSuite | types.NodeTypeReportAfterSuite) {
+	if node.NodeType.Is(types.NodeTypeBeforeSuite | types.NodeTypeAfterSuite | types.NodeTypeSynchronizedBeforeSuite | types.NodeTypeSynchronizedAfterSuite | types.NodeTypeReportAfterSuite | types.NodeTypeReadinessGate) {
 		return suite.pushSuiteNode(node)
 	}
 
 	if suite.phase == PhaseRun {
-		return types.GinkgoErrors.PushingNodeInRunPhase(node.NodeType, node.CodeLocation)
+		return types.GinkgoErrors.PushingNodeInRunPhase(node.NodeType, withStackTrace(node.CodeLocation))
 	}
 
+	// decorators that are invalid where the node is placed are recorded - rather than returned - so that BuildTree can report all of them at once
 	if node.MarkedSerial {
 		firstOrderedNode := suite.tree.AncestorNodeChain().FirstNodeMarkedOrdered()
 		if !firstOrderedNode.IsZero() && !firstOrderedNode.MarkedSerial {
-			return types.GinkgoErrors.InvalidSerialNodeInNonSerialOrderedContainer(node.CodeLocation, node.NodeType)
+			suite.structureErrors = append(suite.structureErrors, types.GinkgoErrors.InvalidSerialNodeInNonSerialOrderedContainer(node.CodeLocation, node.NodeType))
 		}
 	}
 
 	if node.NodeType.Is(types.NodeTypeBeforeAll | types.NodeTypeAfterAll) {
 		firstOrderedNode := suite.tree.AncestorNodeChain().FirstNodeMarkedOrdered()
 		if firstOrderedNode.IsZero() {
-			return types.GinkgoErrors.SetupNodeNotInOrderedContainer(node.CodeLocation, node.NodeType)
+			suite.structureErrors = append(suite.structureErrors, types.GinkgoErrors.SetupNodeNotInOrderedContainer(node.CodeLocation, node.NodeType))
 		}
 	}
 
@@ -139,7 +308,7 @@ func (suite *Suite) PushNode(node Node) error {
 						err = types.GinkgoErrors.CaughtPanicDuringABuildPhase(e, node.CodeLocation)
 					}
 				}()
-				node.Body()
+				callContainerBody(node.Body)
 				return err
 			}()
 			suite.tree = parentTree
@@ -153,23 +322,36 @@ func (suite *Suite) PushNode(node Node) error {
 	return nil
 }
 
+// PushGlobalNode registers a setup node that applies to every spec in the suite, regardless of the container it is registered in
+func (suite *Suite) PushGlobalNode(node Node) error {
+	if suite.phase == PhaseRun {
+		return types.GinkgoErrors.PushingNodeInRunPhase(node.NodeType, withStackTrace(node.CodeLocation))
+	}
+	if !node.NodeType.Is(types.NodeTypesThatCanBeRegisteredGlobally) {
+		return types.GinkgoErrors.InvalidGlobalNodeType(node.CodeLocation, node.NodeType)
+	}
+	suite.globalNodes = append(suite.globalNodes, node)
+	return nil
+}
+
 func (suite *Suite) pushSuiteNode(node Node) error {
 	if suite.phase == PhaseBuildTree {
 		return types.GinkgoErrors.SuiteNodeInNestedContext(node.NodeType, node.CodeLocation)
 	}
 
 	if suite.phase == PhaseRun {
-		return types.GinkgoErrors.SuiteNodeDuringRunPhase(node.NodeType, node.CodeLocation)
+		return types.GinkgoErrors.SuiteNodeDuringRunPhase(node.NodeType, withStackTrace(node.CodeLocation))
 	}
 
+	// suites can have any number of BeforeSuite and AfterSuite nodes - but the parallel protocol only supports one of each Synchronized node
 	switch node.NodeType {
-	case types.NodeTypeBeforeSuite, types.NodeTypeSynchronizedBeforeSuite:
-		existingBefores := suite.suiteNodes.WithType(types.NodeTypeBeforeSuite | types.NodeTypeSynchronizedBeforeSuite)
+	case types.NodeTypeSynchronizedBeforeSuite:
+		existingBefores := suite.suiteNodes.WithType(types.NodeTypeSynchronizedBeforeSuite)
 		if len(existingBefores) > 0 {
 			return types.GinkgoErrors.MultipleBeforeSuiteNodes(node.NodeType, node.CodeLocation, existingBefores[0].NodeType, existingBefores[0].CodeLocation)
 		}
-	case types.NodeTypeAfterSuite, types.NodeTypeSynchronizedAfterSuite:
-		existingAfters := suite.suiteNodes.WithType(types.NodeTypeAfterSuite | types.NodeTypeSynchronizedAfterSuite)
+	case types.NodeTypeSynchronizedAfterSuite:
+		existingAfters := suite.suiteNodes.WithType(types.NodeTypeSynchronizedAfterSuite)
 		if len(existingAfters) > 0 {
 			return types.GinkgoErrors.MultipleAfterSuiteNodes(node.NodeType, node.CodeLocation, existingAfters[0].NodeType, existingAfters[0].CodeLocation)
 		}
@@ -179,13 +361,45 @@ func (suite *Suite) pushSuiteNode(node Node) error {
 	return nil
 }
 
+// withStackTrace attaches the stack of the goroutine that is pushing a node to the node's code location so that errors can point at the offending call
+func withStackTrace(cl types.CodeLocation) types.CodeLocation {
+	cl.FullStackTrace = types.NewCodeLocationWithStackTrace(2).FullStackTrace
+	return cl
+}
+
+// callContainerBody invokes the body of a container node during PhaseBuildTree.  Its frame marks the goroutine that is constructing the tree.
+//
+//go:noinline
+func callContainerBody(body func()) {
+	body()
+}
+
+var callContainerBodyFunc = runtime.FuncForPC(reflect.ValueOf(callContainerBody).Pointer())
+
+// isCalledFromContainerBody returns true if callContainerBody is on the current goroutine's stack.
+// Goroutines started within a container body begin with a fresh stack so they never are.
+func isCalledFromContainerBody() bool {
+	var pcs [8]uintptr
+	for skip := 2; ; skip += len(pcs) {
+		n := runtime.Callers(skip, pcs[:])
+		for _, pc := range pcs[:n] {
+			if runtime.FuncForPC(pc-1) == callContainerBodyFunc {
+				return true
+			}
+		}
+		if n < len(pcs) {
+			return false
+		}
+	}
+}
+
 func (suite *Suite) pushCleanupNode(node Node) error {
 	if suite.phase != PhaseRun || suite.currentNode.IsZero() {
 		return types.GinkgoErrors.PushingCleanupNodeDuringTreeConstruction(node.CodeLocation)
 	}
 
 	switch suite.currentNode.NodeType {
-	case types.NodeTypeBeforeSuite, types.NodeTypeSynchronizedBeforeSuite, types.NodeTypeAfterSuite, types.NodeTypeSynchronizedAfterSuite:
+	case types.NodeTypeBeforeSuite, types.NodeTypeSynchronizedBeforeSuite, types.NodeTypeAfterSuite, types.NodeTypeSynchronizedAfterSuite, types.NodeTypeReadinessGate:
 		node.NodeType = types.NodeTypeCleanupAfterSuite
 	case types.NodeTypeBeforeAll, types.NodeTypeAfterAll:
 		node.NodeType = types.NodeTypeCleanupAfterAll
@@ -193,13 +407,33 @@ func (suite *Suite) pushCleanupNode(node Node) error {
 		return types.GinkgoErrors.PushingCleanupInReportingNode(node.CodeLocation, suite.currentNode.NodeType)
 	case types.NodeTypeCleanupInvalid, types.NodeTypeCleanupAfterEach, types.NodeTypeCleanupAfterAll, types.NodeTypeCleanupAfterSuite:
 		return types.GinkgoErrors.PushingCleanupInCleanupNode(node.CodeLocation)
+	case types.NodeTypeAroundEach:
+		return types.GinkgoErrors.PushingCleanupInAroundEachNode(node.CodeLocation)
 	default:
 		node.NodeType = types.NodeTypeCleanupAfterEach
 	}
 
+	if node.CleanupOnProcess1 {
+		if node.NodeType != types.NodeTypeCleanupAfterSuite {
+			return types.GinkgoErrors.OnProcess1CleanupOutsideOfSuiteNode(node.CodeLocation, suite.currentNode.NodeType)
+		}
+		// process #1 already waits for the other processes to finish before running suite-level cleanup - so the other processes simply drop the cleanup
+		if suite.config.ParallelProcess != 1 {
+			return nil
+		}
+	}
+
 	node.NodeIDWhereCleanupWasGenerated = suite.currentNode.ID
 	node.NestingLevel = suite.currentNode.NestingLevel
-	suite.cleanupNodes = append(suite.cleanupNodes, node)
+	// cleanup nodes run in reverse order, so we keep higher priority nodes towards the front of the list
+	// this way they run after all lower priority nodes, while nodes with equal priority still run in LIFO order
+	idx := len(suite.cleanupNodes)
+	for idx > 0 && suite.cleanupNodes[idx-1].CleanupPriority < node.CleanupPriority {
+		idx--
+	}
+	suite.cleanupNodes = suite.cleanupNodes.CopyAppend(node)
+	copy(suite.cleanupNodes[idx+1:], suite.cleanupNodes[idx:])
+	suite.cleanupNodes[idx] = node
 
 	return nil
 }
@@ -210,11 +444,73 @@ func (suite *Suite) pushCleanupNode(node Node) error {
 func (suite *Suite) CurrentSpecReport() types.SpecReport {
 	report := suite.currentSpecReport
 	if suite.writer != nil {
-		report.CapturedGinkgoWriterOutput = string(suite.writer.Bytes())
+		report.CapturedGinkgoWriterOutput = suite.writer.String()
+	}
+	if report.LeafNodeType.Is(types.NodeTypeIt) {
+		report.NumAssertions = int(atomic.LoadInt64(&suite.currentSpecAssertions))
+	}
+	if steps := suite.steps.snapshot(); steps != nil {
+		report.Steps = steps
 	}
 	return report
 }
 
+// RecordAssertion counts an assertion made by the current spec and opts the suite in to --zero-assertion-policy
+func (suite *Suite) RecordAssertion() {
+	atomic.StoreInt32(&suite.recordsAssertions, 1)
+	atomic.AddInt64(&suite.currentSpecAssertions, 1)
+}
+
+// RecordFailedAssertion counts a call to Fail made by the current spec.  Unlike RecordAssertion it does not opt the suite in to --zero-assertion-policy:
+// Gomega only calls Fail for the assertions that fail, so a suite that doesn't call RecordAssertion can't tell Ginkgo about the ones that pass
+func (suite *Suite) RecordFailedAssertion() {
+	atomic.AddInt64(&suite.currentSpecAssertions, 1)
+}
+
+// checkForZeroAssertions applies the --zero-assertion-policy to the current spec once it has passed
+func (suite *Suite) checkForZeroAssertions(spec Spec) {
+	policy := suite.config.ZeroAssertionBehavior()
+	if policy == types.ZeroAssertionPolicyOff || atomic.LoadInt32(&suite.recordsAssertions) == 0 || suite.currentSpecReport.State != types.SpecStatePassed || suite.currentSpecReport.NumAssertions > 0 {
+		return
+	}
+	message := "This spec passed without making any assertions.  Ginkgo counts calls to Fail (including failed Gomega assertions) and to RecordAssertion."
+	leaf := spec.FirstNodeWithType(types.NodeTypeIt)
+	if policy == types.ZeroAssertionPolicyWarn {
+		suite.currentSpecReport.Warnings = append(suite.currentSpecReport.Warnings, types.Warning{Message: message, Location: leaf.CodeLocation})
+		return
+	}
+	suite.currentSpecReport.State = types.SpecStateFailed
+	suite.currentSpecReport.Failure = suite.failureForLeafNodeWithMessage(leaf, message)
+}
+
+// cpuTimeLimitFor returns the spec's CPUTimeLimit decoration, if set, and --cpu-time-limit otherwise
+func (suite *Suite) cpuTimeLimitFor(spec Spec) time.Duration {
+	if limit := spec.Nodes.CPUTimeLimit(); limit > 0 {
+		return limit
+	}
+	return suite.config.CPUTimeLimit
+}
+
+// checkCPUTimeLimit fails the current attempt at running spec if it passed but used more CPU time than its limit allows
+func (suite *Suite) checkCPUTimeLimit(spec Spec) {
+	limit := suite.cpuTimeLimitFor(spec)
+	if limit <= 0 || suite.currentSpecReport.State != types.SpecStatePassed || suite.currentSpecReport.CPUTime <= limit {
+		return
+	}
+	message := fmt.Sprintf("This spec used %s of CPU time, exceeding its CPU time limit of %s.  Look for busy-loops: unlike wall-clock time, CPU time does not accumulate while a spec waits.", suite.currentSpecReport.CPUTime.Round(time.Millisecond), limit)
+	suite.currentSpecReport.State = types.SpecStateFailed
+	suite.currentSpecReport.Failure = suite.failureForLeafNodeWithMessage(spec.FirstNodeWithType(types.NodeTypeIt), message)
+}
+
+// SkipSuite records that the suite is to be skipped in its entirety.  It can only be called from a BeforeSuite or SynchronizedBeforeSuite node.
+func (suite *Suite) SkipSuite(reason string, cl types.CodeLocation) error {
+	if suite.phase != PhaseRun || !suite.currentNode.NodeType.Is(types.NodeTypeBeforeSuite|types.NodeTypeSynchronizedBeforeSuite) {
+		return types.GinkgoErrors.SkipSuiteOutsideOfBeforeSuite(cl, suite.currentNode.NodeType)
+	}
+	suite.suiteSkipReason = reason
+	return nil
+}
+
 func (suite *Suite) AddReportEntry(entry ReportEntry) error {
 	if suite.phase != PhaseRun {
 		return types.GinkgoErrors.AddReportEntryNotDuringRunPhase(entry.Location)
@@ -223,18 +519,109 @@ func (suite *Suite) AddReportEntry(entry ReportEntry) error {
 	return nil
 }
 
+func (suite *Suite) AddSpecAnnotation(annotation types.SpecAnnotation) error {
+	if suite.phase != PhaseRun {
+		return types.GinkgoErrors.AddSpecAnnotationNotDuringRunPhase(annotation.Location)
+	}
+	suite.currentSpecReport.Annotations = append(suite.currentSpecReport.Annotations, annotation)
+	return nil
+}
+
+func (suite *Suite) AddWarning(warning types.Warning) error {
+	if suite.phase != PhaseRun {
+		return types.GinkgoErrors.GinkgoWarnNotDuringRunPhase(warning.Location)
+	}
+	suite.currentSpecReport.Warnings = append(suite.currentSpecReport.Warnings, warning)
+	return nil
+}
+
+// RegisterResource records that the current spec created resource in the suite's resource registry (if one is configured)
+func (suite *Suite) RegisterResource(resource types.Resource, cl types.CodeLocation) error {
+	return suite.recordResourceEvent(types.ResourceEventRegistered, resource, cl)
+}
+
+// ReleaseResource records that resource has been cleaned up in the suite's resource registry (if one is configured)
+func (suite *Suite) ReleaseResource(resource types.Resource, cl types.CodeLocation) error {
+	return suite.recordResourceEvent(types.ResourceEventReleased, resource, cl)
+}
+
+func (suite *Suite) recordResourceEvent(event string, resource types.Resource, cl types.CodeLocation) error {
+	if suite.config.ResourceRegistry == "" {
+		return nil
+	}
+	return types.AppendToResourceRegistry(suite.config.ResourceRegistry, types.ResourceRecord{
+		Event:           event,
+		Resource:        resource,
+		SpecText:        suite.currentSpecReport.FullText(),
+		CodeLocation:    cl,
+		ParallelProcess: suite.config.ParallelProcess,
+		Time:            time.Now(),
+	})
+}
+
+// Redact registers a redaction that is applied to every spec report before it is handed to ReportAfterEach nodes and reporters
+func (suite *Suite) Redact(redaction OutputFilter) {
+	suite.redactions.Add(redaction)
+}
+
+// Redactions returns the redactions registered with the suite
+func (suite *Suite) Redactions() *OutputFilters {
+	return suite.redactions
+}
+
+// Deadline returns the time by which the currently running node must complete: the earlier of the node's NodeTimeout deadline and the suite's --timeout deadline.  ok is false if neither applies.
+func (suite *Suite) Deadline() (deadline time.Time, ok bool) {
+	suite.deadlineLock.Lock()
+	defer suite.deadlineLock.Unlock()
+	deadline = suite.suiteDeadline
+	if !suite.currentNodeDeadline.IsZero() && (deadline.IsZero() || suite.currentNodeDeadline.Before(deadline)) {
+		deadline = suite.currentNodeDeadline
+	}
+	return deadline, !deadline.IsZero()
+}
+
+func (suite *Suite) setCurrentNodeDeadline(deadline time.Time) {
+	suite.deadlineLock.Lock()
+	defer suite.deadlineLock.Unlock()
+	suite.currentNodeDeadline = deadline
+}
+
 func (suite *Suite) isRunningInParallel() bool {
 	return suite.config.ParallelTotal > 1
 }
 
+// timeBoxExhausted returns true once --time-box has elapsed since the suite started.  Specs that have not started by then are not run.
+func (suite *Suite) timeBoxExhausted() bool {
+	return suite.config.TimeBox > 0 && time.Since(suite.report.StartTime) >= suite.config.TimeBox
+}
+
+// recordRerunCommand records the command to rerun the current spec, if it has failed
+func (suite *Suite) recordRerunCommand() {
+	if suite.currentSpecReport.RerunCommand == "" && suite.currentSpecReport.State.Is(types.SpecStateFailureStates) {
+		suite.currentSpecReport.RerunCommand = RerunCommand(suite.report.SuitePath, suite.config, suite.report.Environment.EnvVars, suite.currentSpecReport)
+	}
+}
+
+// recordFailureFingerprint fingerprints the current spec's (redacted) failure, if it has failed
+func (suite *Suite) recordFailureFingerprint() {
+	suite.currentSpecReport.FailureFingerprint = ""
+	if suite.currentSpecReport.State.Is(types.SpecStateFailureStates) {
+		suite.currentSpecReport.FailureFingerprint = suite.currentSpecReport.Failure.Fingerprint()
+	}
+}
+
 func (suite *Suite) processCurrentSpecReport() {
+	suite.recordRerunCommand()
+	suite.recordEnvironmentDegradations()
+	suite.currentSpecReport = RedactSpecReport(suite.currentSpecReport, suite.redactions).AttachBinaryOutput()
+	suite.recordFailureFingerprint()
 	suite.reporter.DidRun(suite.currentSpecReport)
 	if suite.isRunningInParallel() {
 		suite.client.PostDidRun(suite.currentSpecReport)
 	}
 	suite.report.SpecReports = append(suite.report.SpecReports, suite.currentSpecReport)
 
-	if suite.currentSpecReport.State.Is(types.SpecStateFailureStates) {
+	if suite.currentSpecReport.State.Is(types.SpecStateFailureStates) && !suite.currentSpecReport.FailureIgnored {
 		suite.report.SuiteSucceeded = false
 		if suite.config.FailFast || suite.currentSpecReport.State.Is(types.SpecStateAborted) {
 			suite.skipAll = true
@@ -247,6 +634,13 @@ func (suite *Suite) processCurrentSpecReport() {
 
 func (suite *Suite) runSpecs(description string, suiteLabels Labels, suitePath string, hasProgrammaticFocus bool, specs Specs) bool {
 	numSpecsThatWillBeRun := specs.CountWithoutSkip()
+	suite.specRateLimits, _ = suite.config.SpecRateLimits()
+	suite.specRateLimiter = parallel_support.NewSpecRateLimiter()
+	suite.hostCapacity, _ = suite.config.HostCapacity()
+	suite.retryPolicies, _ = suite.config.RetryPolicies()
+	if suite.config.Chaos {
+		suite.chaosMonkey = NewChaosMonkey(suite.config)
+	}
 
 	suite.report = types.Report{
 		SuitePath:                 suitePath,
@@ -254,11 +648,16 @@ func (suite *Suite) runSpecs(description string, suiteLabels Labels, suitePath s
 		SuiteLabels:               suiteLabels,
 		SuiteConfig:               suite.config,
 		SuiteHasProgrammaticFocus: hasProgrammaticFocus,
+		Environment:               NewEnvironmentFingerprint(suitePath, suite.config.FingerprintEnvVars),
+		GoTestCacheable:           IsGoTestCacheable(),
+		Deprecations:              suite.deprecations,
 		PreRunStats: types.PreRunStats{
 			TotalSpecs:       len(specs),
 			SpecsThatWillRun: numSpecsThatWillBeRun,
 		},
 		StartTime: time.Now(),
+		// SpecReports are large - preallocating avoids repeatedly copying them as the slice grows in suites with many specs
+		SpecReports: make(types.SpecReports, 0, len(specs)),
 	}
 
 	suite.reporter.SuiteWillBegin(suite.report)
@@ -267,15 +666,86 @@ func (suite *Suite) runSpecs(description string, suiteLabels Labels, suitePath s
 	}
 
 	suite.report.SuiteSucceeded = true
-	suite.runBeforeSuite(numSpecsThatWillBeRun)
+	if suite.config.RequireSingleSpec && numSpecsThatWillBeRun != 1 {
+		suite.report.SpecialSuiteFailureReasons = append(suite.report.SpecialSuiteFailureReasons, types.GinkgoErrors.SingleSpecRequired(specs.DescriptionsWithoutSkip()).Error())
+		suite.report.SuiteSucceeded = false
+	}
+	if suite.config.FilterValidationBehavior() != types.ValidateFiltersOff && suite.config.UnmatchedFiltersFile != "" {
+		// the CLI is running multiple suites and validates the filters across all of them - a filter only needs to match specs in one of the suites
+		if suite.config.ParallelProcess == 1 {
+			if err := WriteUnmatchedFilters(suite.config.UnmatchedFiltersFile, UnmatchedFilters(specs, description, suiteLabels, suite.config)); err != nil {
+				suite.report.SpecialSuiteFailureReasons = append(suite.report.SpecialSuiteFailureReasons, fmt.Sprintf("Failed to record unmatched filters:\n%s", err.Error()))
+				suite.report.SuiteSucceeded = false
+			}
+		}
+	} else if suite.config.FilterValidationBehavior() != types.ValidateFiltersOff {
+		suite.report.UnmatchedFilters = UnmatchedFilters(specs, description, suiteLabels, suite.config)
+		if len(suite.report.UnmatchedFilters) > 0 && suite.config.FilterValidationBehavior() == types.ValidateFiltersFail {
+			suite.report.SpecialSuiteFailureReasons = append(suite.report.SpecialSuiteFailureReasons, "Filters matched no specs and --validate-filters=fail is set")
+			suite.report.SuiteSucceeded = false
+		}
+	}
+	if suite.report.SuiteSucceeded {
+		suite.runPreflightChecks(numSpecsThatWillBeRun)
+	}
+	if suite.report.SuiteSucceeded {
+		suite.runReadinessGates(numSpecsThatWillBeRun)
+	}
+	stopHealthMonitor := suite.startHealthMonitor()
+	if suite.report.SuiteSucceeded {
+		suite.runBeforeSuite(numSpecsThatWillBeRun)
+	}
 
 	if suite.report.SuiteSucceeded {
+		suite.checkRequirements(specs)
 		groupedSpecIndices, serialGroupedSpecIndices := OrderSpecs(specs, suite.config)
+
+		// when the suite declares execution phases the groups are laid out phase by phase.
+		// phaseEnds tracks where each phase ends so that processes can synchronize before moving on to the next phase
+		// groups that carry a label pinned with --pin-label-to-process are pulled out of the shared schedule.
+		// the process they are pinned to runs them at the start of their phase, before it starts pulling shared groups
+		pins := map[string]int{}
+		if suite.isRunningInParallel() {
+			pins, _ = suite.config.ProcessPins()
+		}
+		phases := suite.executionPhases()
+		phaseEnds, serialGroupedSpecIndicesByPhase, pinnedGroupedSpecIndicesByPhase := []int{}, []GroupedSpecIndices{}, []GroupedSpecIndices{}
+		if len(phases) > 0 {
+			groupedSpecIndicesByPhase := PartitionGroupsByPhase(specs, groupedSpecIndices, phases)
+			serialGroupedSpecIndicesByPhase = PartitionGroupsByPhase(specs, serialGroupedSpecIndices, phases)
+			groupedSpecIndices = GroupedSpecIndices{}
+			for _, groups := range groupedSpecIndicesByPhase {
+				sharedGroups, pinnedGroups := PartitionPinnedGroups(specs, groups, pins, suite.config.ParallelProcess)
+				groupedSpecIndices = append(groupedSpecIndices, sharedGroups...)
+				phaseEnds = append(phaseEnds, len(groupedSpecIndices))
+				pinnedGroupedSpecIndicesByPhase = append(pinnedGroupedSpecIndicesByPhase, pinnedGroups)
+			}
+			serialGroupedSpecIndices = serialGroupedSpecIndicesByPhase[len(phases)-1]
+		} else {
+			var pinnedGroups GroupedSpecIndices
+			groupedSpecIndices, pinnedGroups = PartitionPinnedGroups(specs, groupedSpecIndices, pins, suite.config.ParallelProcess)
+			pinnedGroupedSpecIndicesByPhase = append(pinnedGroupedSpecIndicesByPhase, pinnedGroups)
+		}
+		currentPhase := 0
+
 		nextIndex := MakeIncrementingIndexCounter()
 		if suite.isRunningInParallel() {
-			nextIndex = suite.client.FetchNextCounter
+			numGroups := len(groupedSpecIndices)
+			nextIndex = func() (int, error) {
+				return suite.client.FetchNextCounter(suite.config.ParallelProcess, numGroups)
+			}
+			suite.report.ParallelSchedule = types.ParallelSchedule{
+				RandomSeed:    suite.config.RandomSeed,
+				ParallelTotal: suite.config.ParallelTotal,
+				NumGroups:     len(groupedSpecIndices),
+			}
 		}
+		if suite.config.ReplayParallelSchedule != "" {
+			nextIndex = suite.makeParallelScheduleReplayCounter(suitePath, description, len(groupedSpecIndices))
+		}
+		recordSchedule := suite.isRunningInParallel()
 
+		suite.runGroups(specs, pinnedGroupedSpecIndicesByPhase[0])
 		for {
 			groupedSpecIdx, err := nextIndex()
 			if err != nil {
@@ -284,20 +754,41 @@ func (suite *Suite) runSpecs(description string, suiteLabels Labels, suitePath s
 				break
 			}
 
+			if suite.isRunningInParallel() {
+				for currentPhase < len(phaseEnds)-1 && groupedSpecIdx >= phaseEnds[currentPhase] {
+					err = suite.completeExecutionPhase(currentPhase, serialGroupedSpecIndicesByPhase[currentPhase], specs)
+					if err != nil {
+						break
+					}
+					currentPhase += 1
+					suite.runGroups(specs, pinnedGroupedSpecIndicesByPhase[currentPhase])
+				}
+				if err != nil {
+					suite.report.SpecialSuiteFailureReasons = append(suite.report.SpecialSuiteFailureReasons, fmt.Sprintf("Failed to complete phase \"%s\":\n%s", phases[currentPhase], err.Error()))
+					suite.report.SuiteSucceeded = false
+					break
+				}
+			}
+
 			if groupedSpecIdx >= len(groupedSpecIndices) {
 				if suite.config.ParallelProcess == 1 && len(serialGroupedSpecIndices) > 0 {
 					groupedSpecIndices, serialGroupedSpecIndices, nextIndex = serialGroupedSpecIndices, GroupedSpecIndices{}, MakeIncrementingIndexCounter()
+					recordSchedule, phaseEnds = false, []int{}
 					suite.client.BlockUntilNonprimaryProcsHaveFinished()
 					continue
 				}
 				break
 			}
 
+			if recordSchedule {
+				suite.recordScheduledGroup(groupedSpecIdx)
+			}
+
 			// the complexity for running groups of specs is very high because of Ordered containers and FlakeAttempts
 			// we encapsulate that complexity in the notion of a Group that can run
 			// Group is really just an extension of suite so it gets passed a suite and has access to all its internals
 			// Note that group is stateful and intended for single use!
-			newGroup(suite).run(specs.AtIndices(groupedSpecIndices[groupedSpecIdx]))
+			suite.runGroup(specs.AtIndices(groupedSpecIndices[groupedSpecIdx]))
 		}
 
 		if specs.HasAnySpecsMarkedPending() && suite.config.FailOnPending {
@@ -307,12 +798,14 @@ func (suite *Suite) runSpecs(description string, suiteLabels Labels, suitePath s
 	}
 
 	suite.runAfterSuiteCleanup(numSpecsThatWillBeRun)
+	stopHealthMonitor()
 
 	interruptStatus := suite.interruptHandler.Status()
 	if interruptStatus.Interrupted {
 		suite.report.SpecialSuiteFailureReasons = append(suite.report.SpecialSuiteFailureReasons, interruptStatus.Cause.String())
 		suite.report.SuiteSucceeded = false
 	}
+	suite.report.ExitReason = exitReasonFor(suite.report, interruptStatus)
 	suite.report.EndTime = time.Now()
 	suite.report.RunTime = suite.report.EndTime.Sub(suite.report.StartTime)
 
@@ -327,10 +820,180 @@ func (suite *Suite) runSpecs(description string, suiteLabels Labels, suitePath s
 	return suite.report.SuiteSucceeded
 }
 
+// runGroup runs a group of specs, holding the resources they require for as long as the group runs
+func (suite *Suite) runGroup(groupSpecs Specs) {
+	releaseResources := suite.acquireResources(groupSpecs)
+	newGroup(suite).run(groupSpecs)
+	releaseResources()
+}
+
+// runGroups runs each group of specs in groupedSpecIndices, in order
+func (suite *Suite) runGroups(specs Specs, groupedSpecIndices GroupedSpecIndices) {
+	for _, specIndices := range groupedSpecIndices {
+		suite.runGroup(specs.AtIndices(specIndices))
+	}
+}
+
+// completeExecutionPhase synchronizes all parallel processes at the end of an execution phase.
+// Process #1 waits for all other processes to reach the end of the phase, runs the phase's serial specs, and then releases the other processes into the next phase.
+func (suite *Suite) completeExecutionPhase(phase int, serialGroupedSpecIndices GroupedSpecIndices, specs Specs) error {
+	if suite.config.ParallelProcess != 1 {
+		return suite.client.BlockUntilPhaseReleased(suite.config.ParallelProcess, phase)
+	}
+	err := suite.client.BlockUntilNonprimaryProcsReachedPhase(phase)
+	if err != nil {
+		return err
+	}
+	for _, groupedSpecIndices := range serialGroupedSpecIndices {
+		newGroup(suite).run(specs.AtIndices(groupedSpecIndices))
+	}
+	return suite.client.PostPhaseReleased(phase)
+}
+
+func exitReasonFor(report types.Report, interruptStatus interrupt_handler.InterruptStatus) types.ExitReason {
+	if interruptStatus.Interrupted {
+		switch interruptStatus.Cause {
+		case interrupt_handler.InterruptCauseSignal:
+			return types.ExitReasonInterrupted
+		case interrupt_handler.InterruptCauseTimeout:
+			return types.ExitReasonTimedOut
+		}
+	}
+	if !report.SuiteSucceeded {
+		return types.ExitReasonFailed
+	}
+	if report.SuiteHasProgrammaticFocus && report.SuiteConfig.ProgrammaticFocusBehavior() == types.FocusExitCodeFail {
+		return types.ExitReasonFocused
+	}
+	return types.ExitReasonPassed
+}
+
+func (suite *Suite) waitForSpecRatePermit(spec Spec) {
+	if len(suite.specRateLimits) == 0 {
+		return
+	}
+
+	var delay time.Duration
+	labels := spec.Nodes.UnionOfLabels()
+	if suite.isRunningInParallel() {
+		var err error
+		delay, err = suite.client.FetchSpecRateDelay(labels, suite.specRateLimits)
+		if err != nil {
+			return
+		}
+	} else {
+		delay = suite.specRateLimiter.Reserve(labels, suite.specRateLimits)
+	}
+
+	if delay > 0 {
+		select {
+		case <-time.After(delay):
+		case <-suite.interruptHandler.Status().Channel:
+		}
+	}
+}
+
+// allowsRetry returns true if the --retry-on policies allow the current, failed, spec to be retried.  The policies for the spec's labels take precedence over the global policy.
+func (suite *Suite) allowsRetry() bool {
+	if len(suite.retryPolicies) == 0 {
+		return true
+	}
+	report := suite.currentSpecReport
+	policy, labelled := types.FailureRetryPolicy{}, false
+	for _, label := range report.Labels() {
+		if labelPolicy, ok := suite.retryPolicies[strings.ToLower(label)]; ok {
+			policy.RetryOn = append(policy.RetryOn, labelPolicy.RetryOn...)
+			policy.NeverRetryOn = append(policy.NeverRetryOn, labelPolicy.NeverRetryOn...)
+			labelled = true
+		}
+	}
+	if !labelled {
+		policy = suite.retryPolicies[""]
+	}
+	return policy.AllowsRetry(report)
+}
+
+// acquireResources blocks until the host has the capacity to run the passed-in group of specs alongside the specs running on other processes.
+// The group holds the largest requirement of any of its specs for as long as it runs.  It returns a function that releases the resources.
+func (suite *Suite) acquireResources(specs Specs) func() {
+	requirements := types.ResourceRequirements{}
+	for _, spec := range specs {
+		if !spec.Skip {
+			requirements = requirements.Max(spec.Nodes.ResourceRequirements())
+		}
+	}
+	if requirements.IsZero() || !suite.isRunningInParallel() {
+		return func() {}
+	}
+
+	request := parallel_support.ResourceRequest{Process: suite.config.ParallelProcess, Requirements: requirements, Capacity: suite.hostCapacity}
+	// an interrupt stops the wait - the group's specs see the interrupt and do not run
+	if suite.client.BlockUntilResourcesAcquired(request, suite.interruptHandler.Status().Channel) != nil {
+		return func() {}
+	}
+	return func() { suite.client.PostResourcesReleased(request) }
+}
+
+// makeParallelScheduleReplayCounter loads the schedule recorded for this suite and returns a counter that replays this process's share of it.
+// If the schedule cannot be replayed the returned counter emits an error so that the suite fails without running any specs.
+func (suite *Suite) makeParallelScheduleReplayCounter(suitePath string, description string, numGroups int) func() (int, error) {
+	path := suite.config.ReplayParallelSchedule
+	schedule, err := LoadParallelSchedule(path, suitePath, description)
+	if err == nil && (schedule.RandomSeed != suite.config.RandomSeed || schedule.ParallelTotal != suite.config.ParallelTotal || schedule.NumGroups != numGroups) {
+		err = types.GinkgoErrors.ParallelScheduleMismatch(path, schedule, suite.config.RandomSeed, suite.config.ParallelTotal, numGroups)
+	}
+	if err != nil {
+		return func() (int, error) { return 0, err }
+	}
+	return MakeParallelScheduleReplayCounter(schedule, suite.config.ParallelProcess, numGroups)
+}
+
+func (suite *Suite) recordScheduledGroup(groupedSpecIdx int) {
+	if suite.report.ParallelSchedule.GroupIndices == nil {
+		suite.report.ParallelSchedule.GroupIndices = map[int][]int{}
+	}
+	process := suite.config.ParallelProcess
+	suite.report.ParallelSchedule.GroupIndices[process] = append(suite.report.ParallelSchedule.GroupIndices[process], groupedSpecIdx)
+}
+
+func (suite *Suite) runReadinessGates(numSpecsThatWillBeRun int) {
+	if numSpecsThatWillBeRun == 0 {
+		return
+	}
+	for _, gate := range suite.suiteNodes.WithType(types.NodeTypeReadinessGate) {
+		interruptStatus := suite.interruptHandler.Status()
+		if interruptStatus.Interrupted || !suite.report.SuiteSucceeded {
+			return
+		}
+		suite.currentSpecReport = types.SpecReport{
+			LeafNodeType:     gate.NodeType,
+			LeafNodeLocation: gate.CodeLocation,
+			ParallelProcess:  suite.config.ParallelProcess,
+		}
+		suite.reporter.WillRun(suite.currentSpecReport)
+		suite.runSuiteNode(gate, interruptStatus.Channel)
+		suite.processCurrentSpecReport()
+		if !suite.currentSpecReport.State.Is(types.SpecStatePassed) {
+			// the BeforeSuite nodes will not run so process #1 must tell the other processes not to wait for its SynchronizedBeforeSuite
+			suite.environmentNotReady = true
+			suite.abandonSynchronizedBeforeSuite(suite.suiteNodes.WithType(types.NodeTypeBeforeSuite | types.NodeTypeSynchronizedBeforeSuite))
+			return
+		}
+	}
+}
+
+// runBeforeSuite runs the BeforeSuite nodes in order, stopping as soon as one of them does not pass
 func (suite *Suite) runBeforeSuite(numSpecsThatWillBeRun int) {
-	interruptStatus := suite.interruptHandler.Status()
-	beforeSuiteNode := suite.suiteNodes.FirstNodeWithType(types.NodeTypeBeforeSuite | types.NodeTypeSynchronizedBeforeSuite)
-	if !beforeSuiteNode.IsZero() && !interruptStatus.Interrupted && numSpecsThatWillBeRun > 0 {
+	if numSpecsThatWillBeRun == 0 {
+		return
+	}
+	beforeSuiteNodes := suite.suiteNodes.WithType(types.NodeTypeBeforeSuite | types.NodeTypeSynchronizedBeforeSuite).SortedByOrder()
+	for i, beforeSuiteNode := range beforeSuiteNodes {
+		interruptStatus := suite.interruptHandler.Status()
+		if interruptStatus.Interrupted || !suite.report.SuiteSucceeded || suite.skipAll {
+			suite.abandonSynchronizedBeforeSuite(beforeSuiteNodes[i:])
+			return
+		}
 		suite.currentSpecReport = types.SpecReport{
 			LeafNodeType:     beforeSuiteNode.NodeType,
 			LeafNodeLocation: beforeSuiteNode.CodeLocation,
@@ -339,24 +1002,45 @@ func (suite *Suite) runBeforeSuite(numSpecsThatWillBeRun int) {
 		suite.reporter.WillRun(suite.currentSpecReport)
 		suite.runSuiteNode(beforeSuiteNode, interruptStatus.Channel)
 		if suite.currentSpecReport.State.Is(types.SpecStateSkipped) {
-			suite.report.SpecialSuiteFailureReasons = append(suite.report.SpecialSuiteFailureReasons, "Suite skipped in BeforeSuite")
+			if suite.suiteSkipReason != "" {
+				suite.report.SuiteSkipReason = suite.suiteSkipReason
+			} else {
+				suite.report.SpecialSuiteFailureReasons = append(suite.report.SpecialSuiteFailureReasons, "Suite skipped in BeforeSuite")
+			}
 			suite.skipAll = true
 		}
 		suite.processCurrentSpecReport()
 	}
 }
 
+// abandonSynchronizedBeforeSuite tells the other parallel processes that process #1 will not be running the SynchronizedBeforeSuite in nodes (if there is one) so that they don't wait for it forever
+func (suite *Suite) abandonSynchronizedBeforeSuite(nodes Nodes) {
+	if suite.config.ParallelTotal == 1 || suite.config.ParallelProcess != 1 || len(nodes.WithType(types.NodeTypeSynchronizedBeforeSuite)) == 0 {
+		return
+	}
+	state := types.SpecStateFailed
+	if suite.interruptHandler.Status().Interrupted {
+		state = types.SpecStateInterrupted
+	} else if suite.skipAll {
+		state = types.SpecStateSkipped
+	}
+	suite.client.PostSynchronizedBeforeSuiteCompleted(state, nil)
+}
+
+// runAfterSuiteCleanup runs all the AfterSuite nodes in order - regardless of whether earlier AfterSuite nodes have failed - followed by any cleanup registered during BeforeSuite
 func (suite *Suite) runAfterSuiteCleanup(numSpecsThatWillBeRun int) {
-	afterSuiteNode := suite.suiteNodes.FirstNodeWithType(types.NodeTypeAfterSuite | types.NodeTypeSynchronizedAfterSuite)
-	if !afterSuiteNode.IsZero() && numSpecsThatWillBeRun > 0 {
-		suite.currentSpecReport = types.SpecReport{
-			LeafNodeType:     afterSuiteNode.NodeType,
-			LeafNodeLocation: afterSuiteNode.CodeLocation,
-			ParallelProcess:  suite.config.ParallelProcess,
+	// when the preflight checks or a ReadinessGate fail the BeforeSuite nodes never ran, so there is nothing for the AfterSuite nodes to clean up
+	if numSpecsThatWillBeRun > 0 && !suite.environmentNotReady {
+		for _, afterSuiteNode := range suite.suiteNodes.WithType(types.NodeTypeAfterSuite | types.NodeTypeSynchronizedAfterSuite).SortedByOrder() {
+			suite.currentSpecReport = types.SpecReport{
+				LeafNodeType:     afterSuiteNode.NodeType,
+				LeafNodeLocation: afterSuiteNode.CodeLocation,
+				ParallelProcess:  suite.config.ParallelProcess,
+			}
+			suite.reporter.WillRun(suite.currentSpecReport)
+			suite.runAfterSuiteNode(afterSuiteNode)
+			suite.processCurrentSpecReport()
 		}
-		suite.reporter.WillRun(suite.currentSpecReport)
-		suite.runSuiteNode(afterSuiteNode, suite.interruptHandler.Status().Channel)
-		suite.processCurrentSpecReport()
 	}
 
 	afterSuiteCleanup := suite.cleanupNodes.WithType(types.NodeTypeCleanupAfterSuite).Reverse()
@@ -374,6 +1058,51 @@ func (suite *Suite) runAfterSuiteCleanup(numSpecsThatWillBeRun int) {
 	}
 }
 
+// runAfterSuiteNode runs the AfterSuite (or SynchronizedAfterSuite) node, applying the --after-suite-failure-policy if it fails
+func (suite *Suite) runAfterSuiteNode(node Node) {
+	policy := suite.config.AfterSuiteFailureBehavior()
+	maxAttempts := 1
+	if policy == types.AfterSuiteFailurePolicyRetry {
+		maxAttempts = 2
+	}
+
+	startTime, ginkgoWriterOutput := time.Now(), ""
+	for attempt := 0; attempt < maxAttempts; attempt++ {
+		suite.currentSpecReport.NumAttempts = attempt + 1
+		suite.currentSpecReport.State, suite.currentSpecReport.Failure = types.SpecStateInvalid, types.Failure{}
+		stdOutErrOffset := len(suite.currentSpecReport.CapturedStdOutErr)
+		if attempt > 0 {
+			ginkgoWriterOutput += fmt.Sprintf("\nGinkgo: Attempt #%d Failed.  Retrying...\n", attempt)
+		}
+
+		suite.runSuiteNode(node, suite.interruptHandler.Status().Channel)
+
+		ginkgoWriterOutput += suite.currentSpecReport.CapturedGinkgoWriterOutput
+		if maxAttempts > 1 {
+			suite.currentSpecReport.Attempts = append(suite.currentSpecReport.Attempts, types.SpecAttempt{
+				Attempt:                    attempt + 1,
+				State:                      suite.currentSpecReport.State,
+				StartTime:                  suite.currentSpecReport.StartTime,
+				EndTime:                    suite.currentSpecReport.EndTime,
+				RunTime:                    suite.currentSpecReport.RunTime,
+				Failure:                    suite.currentSpecReport.Failure,
+				CapturedGinkgoWriterOutput: suite.currentSpecReport.CapturedGinkgoWriterOutput,
+				CapturedStdOutErr:          suite.currentSpecReport.CapturedStdOutErr[stdOutErrOffset:],
+			})
+		}
+		if !suite.currentSpecReport.State.Is(types.SpecStateFailed | types.SpecStatePanicked | types.SpecStateTimedout) {
+			break
+		}
+	}
+
+	suite.currentSpecReport.StartTime = startTime
+	suite.currentSpecReport.RunTime = suite.currentSpecReport.EndTime.Sub(startTime)
+	suite.currentSpecReport.CapturedGinkgoWriterOutput = ginkgoWriterOutput
+	if policy == types.AfterSuiteFailurePolicyWarn && suite.currentSpecReport.State.Is(types.SpecStateFailed|types.SpecStatePanicked|types.SpecStateTimedout) {
+		suite.currentSpecReport.FailureIgnored = true
+	}
+}
+
 func (suite *Suite) runReportAfterSuite() {
 	for _, node := range suite.suiteNodes.WithType(types.NodeTypeReportAfterSuite) {
 		suite.currentSpecReport = types.SpecReport{
@@ -407,6 +1136,11 @@ func (suite *Suite) reportEach(spec Spec, nodeType types.NodeType) {
 	for i := range nodes {
 		suite.writer.Truncate()
 		suite.outputInterceptor.StartInterceptingOutput()
+		if nodeType == types.NodeTypeReportAfterEach {
+			suite.recordRerunCommand()
+			suite.currentSpecReport = RedactSpecReport(suite.currentSpecReport, suite.redactions)
+			suite.recordFailureFingerprint()
+		}
 		report := suite.currentSpecReport
 		nodes[i].Body = func() {
 			nodes[i].ReportEachBody(report)
@@ -424,7 +1158,7 @@ func (suite *Suite) reportEach(spec Spec, nodeType types.NodeType) {
 			suite.currentSpecReport.State = state
 			suite.currentSpecReport.Failure = failure
 		}
-		suite.currentSpecReport.CapturedGinkgoWriterOutput += string(suite.writer.Bytes())
+		suite.currentSpecReport.CapturedGinkgoWriterOutput += suite.writer.String()
 		suite.currentSpecReport.CapturedStdOutErr += suite.outputInterceptor.StopInterceptingAndReturnOutput()
 	}
 }
@@ -450,6 +1184,9 @@ func (suite *Suite) runSuiteNode(node Node, interruptChannel chan interface{}) {
 		if err == nil {
 			suite.currentSpecReport.State, suite.currentSpecReport.Failure = suite.runNode(node, interruptChannel, "")
 		}
+	case types.NodeTypeReadinessGate:
+		node.Body = func() { suite.pollReadinessGate(node, interruptChannel) }
+		suite.currentSpecReport.State, suite.currentSpecReport.Failure = suite.runNode(node, interruptChannel, "")
 	case types.NodeTypeSynchronizedBeforeSuite:
 		var data []byte
 		var runAllProcs bool
@@ -465,6 +1202,9 @@ func (suite *Suite) runSuiteNode(node Node, interruptChannel chan interface{}) {
 				suite.outputInterceptor.StartInterceptingOutput()
 				if suite.currentSpecReport.State.Is(types.SpecStatePassed) {
 					err = suite.client.PostSynchronizedBeforeSuiteCompleted(types.SpecStatePassed, data)
+				} else if suite.currentSpecReport.State.Is(types.SpecStateSkipped) && suite.suiteSkipReason != "" {
+					// the other processes need the reason to report the suite as skipped
+					err = suite.client.PostSynchronizedBeforeSuiteCompleted(types.SpecStateSkipped, []byte(suite.suiteSkipReason))
 				} else {
 					err = suite.client.PostSynchronizedBeforeSuiteCompleted(suite.currentSpecReport.State, nil)
 				}
@@ -476,10 +1216,13 @@ func (suite *Suite) runSuiteNode(node Node, interruptChannel chan interface{}) {
 			switch proc1State {
 			case types.SpecStatePassed:
 				runAllProcs = true
-			case types.SpecStateFailed, types.SpecStatePanicked:
+			case types.SpecStateFailed, types.SpecStatePanicked, types.SpecStateTimedout:
 				err = types.GinkgoErrors.SynchronizedBeforeSuiteFailedOnProc1()
 			case types.SpecStateInterrupted, types.SpecStateAborted, types.SpecStateSkipped:
 				suite.currentSpecReport.State = proc1State
+				if proc1State == types.SpecStateSkipped {
+					suite.suiteSkipReason = string(data)
+				}
 			}
 		}
 		if runAllProcs {
@@ -514,12 +1257,56 @@ func (suite *Suite) runSuiteNode(node Node, interruptChannel chan interface{}) {
 
 	suite.currentSpecReport.EndTime = time.Now()
 	suite.currentSpecReport.RunTime = suite.currentSpecReport.EndTime.Sub(suite.currentSpecReport.StartTime)
-	suite.currentSpecReport.CapturedGinkgoWriterOutput = string(suite.writer.Bytes())
+	suite.finishAbandonedSteps()
+	suite.currentSpecReport.CapturedGinkgoWriterOutput = suite.writer.String()
 	suite.currentSpecReport.CapturedStdOutErr += suite.outputInterceptor.StopInterceptingAndReturnOutput()
 
 	return
 }
 
+// readinessGateProgressInterval is how often a ReadinessGate that is not yet satisfied reports its progress through the reporter
+const readinessGateProgressInterval = 10 * time.Second
+
+func (suite *Suite) pollReadinessGate(node Node, interruptChannel chan interface{}) {
+	pollingInterval := node.ReadinessGateTimeout / 10
+	if pollingInterval > time.Second {
+		pollingInterval = time.Second
+	}
+	startTime := time.Now()
+	deadline := time.After(node.ReadinessGateTimeout)
+	// progress is written to the GinkgoWriter and, so that it is visible without -v, emitted through the reporter - at most once every readinessGateProgressInterval
+	progressReport := suite.newProgressReport(node, startTime)
+	var lastEmitted time.Time
+	logProgress := func(force bool, format string, args ...interface{}) {
+		message := fmt.Sprintf(format, args...)
+		fmt.Fprintln(suite.writer, message)
+		if force || time.Since(lastEmitted) >= readinessGateProgressInterval {
+			lastEmitted = time.Now()
+			report := progressReport
+			report.Time, report.Message = lastEmitted, suite.redactions.Apply(message)
+			suite.emitProgressReport(report)
+		}
+	}
+	for attempt := 1; ; attempt++ {
+		err := node.ReadinessGateCheck()
+		if err == nil {
+			if attempt > 1 {
+				logProgress(true, "ReadinessGate satisfied after %d attempts (%s)", attempt, time.Since(startTime).Round(time.Millisecond))
+			}
+			return
+		}
+		logProgress(false, "ReadinessGate not yet satisfied (attempt %d, %s elapsed): %s", attempt, time.Since(startTime).Round(time.Millisecond), err)
+		select {
+		case <-time.After(pollingInterval):
+		case <-deadline:
+			suite.failer.Fail(fmt.Sprintf("ReadinessGate was not satisfied within %s.\nLast error: %s", node.ReadinessGateTimeout, err), node.CodeLocation)
+			return
+		case <-interruptChannel:
+			return
+		}
+	}
+}
+
 func (suite *Suite) runReportAfterSuiteNode(node Node, report types.Report) {
 	if suite.config.DryRun {
 		suite.currentSpecReport.State = types.SpecStatePassed
@@ -549,7 +1336,7 @@ func (suite *Suite) runReportAfterSuiteNode(node Node, report types.Report) {
 
 	suite.currentSpecReport.EndTime = time.Now()
 	suite.currentSpecReport.RunTime = suite.currentSpecReport.EndTime.Sub(suite.currentSpecReport.StartTime)
-	suite.currentSpecReport.CapturedGinkgoWriterOutput = string(suite.writer.Bytes())
+	suite.currentSpecReport.CapturedGinkgoWriterOutput = suite.writer.String()
 	suite.currentSpecReport.CapturedStdOutErr = suite.outputInterceptor.StopInterceptingAndReturnOutput()
 
 	return
@@ -565,6 +1352,9 @@ func (suite *Suite) runNode(node Node, interruptChannel chan interface{}, text s
 		suite.currentNode = Node{}
 	}()
 
+	restoreEnv := setEnv(node.Env)
+	defer restoreEnv()
+
 	if suite.config.EmitSpecProgress {
 		if text == "" {
 			text = "TOP-LEVEL"
@@ -583,10 +1373,33 @@ func (suite *Suite) runNode(node Node, interruptChannel chan interface{}, text s
 		failure.FailureNodeContext, failure.FailureNodeContainerIndex = types.FailureNodeInContainer, node.NestingLevel-1
 	}
 
-	outcomeC := make(chan types.SpecState)
-	failureC := make(chan types.Failure)
+	timeout := suite.nodeTimeout(node)
+	if timeout > 0 {
+		suite.setCurrentNodeDeadline(time.Now().Add(timeout))
+		defer suite.setCurrentNodeDeadline(time.Time{})
+	}
+
+	// nodes that accept a SpecContext get a context that is cancelled when the node is interrupted or times out
+	var sc SpecContext
+	cancel := func() {}
+	if node.BodyWithContext != nil {
+		ctx, cancelCtx := context.WithCancel(context.Background())
+		if deadline, ok := suite.Deadline(); ok {
+			ctx, cancelCtx = context.WithDeadline(context.Background(), deadline)
+		}
+		sc, cancel = NewSpecContext(ctx, suite), cancelCtx
+	}
+	defer cancel()
+
+	outcomeC := make(chan types.SpecState, 1)
+	failureC := make(chan types.Failure, 1)
+
+	// the node's goroutine records its ID so that progress reports can include its stack
+	var nodeGoroutineID uint64
+	progressReport := suite.newProgressReport(node, time.Now())
 
 	go func() {
+		atomic.StoreUint64(&nodeGoroutineID, currentGoroutineID())
 		finished := false
 		defer func() {
 			if e := recover(); e != nil || !finished {
@@ -598,24 +1411,140 @@ func (suite *Suite) runNode(node Node, interruptChannel chan interface{}, text s
 			failureC <- failureFromRun
 		}()
 
-		node.Body()
+		if suite.chaosMonkey != nil && suite.injectChaos(node) {
+			finished = true
+			return
+		}
+
+		if node.BodyWithContext != nil {
+			node.BodyWithContext(sc)
+		} else {
+			node.Body()
+		}
 		finished = true
 	}()
 
-	select {
-	case outcome := <-outcomeC:
-		failureFromRun := <-failureC
-		if outcome == types.SpecStatePassed {
-			return outcome, types.Failure{}
+	var timeoutC <-chan time.Time
+	if timeout > 0 {
+		timer := time.NewTimer(timeout)
+		defer timer.Stop()
+		timeoutC = timer.C
+	}
+
+	var pollProgressC <-chan time.Time
+	if pollProgressAfter := suite.pollProgressAfter(); pollProgressAfter > 0 {
+		ticker := time.NewTicker(pollProgressAfter)
+		defer ticker.Stop()
+		pollProgressC = ticker.C
+	}
+	// progress reports are recorded once the node has returned so that the node's goroutine does not race with the spec report
+	progressReports := []types.ProgressReport{}
+	defer func() {
+		suite.currentSpecReport.ProgressReports = append(suite.currentSpecReport.ProgressReports, progressReports...)
+	}()
+
+	for {
+		select {
+		case outcome := <-outcomeC:
+			failureFromRun := <-failureC
+			if outcome == types.SpecStatePassed {
+				return outcome, types.Failure{}
+			}
+			failure.Message, failure.Location, failure.ForwardedPanic = failureFromRun.Message, failureFromRun.Location, failureFromRun.ForwardedPanic
+			failure.Payload, failure.Panic, failure.Error = failureFromRun.Payload, failureFromRun.Panic, failureFromRun.Error
+			return outcome, failure
+		case <-interruptChannel:
+			failure.Message, failure.Location = suite.interruptHandler.InterruptMessageWithStackTraces(), node.CodeLocation
+			if !suite.cancelAndAwaitNode(node, cancel, outcomeC, &failure) {
+				suite.failer.Abandon(atomic.LoadUint64(&nodeGoroutineID))
+			}
+			return types.SpecStateInterrupted, failure
+		case <-timeoutC:
+			failure.Message = fmt.Sprintf("%s timed out after %s\n\nHere's a stack trace of all running goroutines:\n%s", node.NodeType, timeout, interrupt_handler.StackTracesOfAllGoroutines())
+			failure.Location = node.CodeLocation
+			// the SpecContext shares the node's deadline so it expires on its own with context.DeadlineExceeded
+			if !suite.cancelAndAwaitNode(node, func() {}, outcomeC, &failure) {
+				suite.failer.Abandon(atomic.LoadUint64(&nodeGoroutineID))
+			}
+			return types.SpecStateTimedout, failure
+		case <-pollProgressC:
+			report := generateProgressReport(progressReport, atomic.LoadUint64(&nodeGoroutineID))
+			progressReports = append(progressReports, report)
+			suite.emitProgressReport(report)
 		}
-		failure.Message, failure.Location, failure.ForwardedPanic = failureFromRun.Message, failureFromRun.Location, failureFromRun.ForwardedPanic
-		return outcome, failure
-	case <-interruptChannel:
-		failure.Message, failure.Location = suite.interruptHandler.InterruptMessageWithStackTraces(), node.CodeLocation
-		return types.SpecStateInterrupted, failure
 	}
 }
 
+// cancelAndAwaitNode cancels the SpecContext of an interrupted or timed out node and gives it --grace-period to clean up and exit.  It returns false if the node is still running.
+// Nodes that do not accept a SpecContext cannot observe the cancellation so Ginkgo does not wait for them
+func (suite *Suite) cancelAndAwaitNode(node Node, cancel context.CancelFunc, outcomeC chan types.SpecState, failure *types.Failure) bool {
+	if node.BodyWithContext == nil {
+		return false
+	}
+	cancel()
+	gracePeriod := time.NewTimer(suite.config.GracePeriod)
+	defer gracePeriod.Stop()
+	select {
+	case <-outcomeC:
+		return true
+	case <-gracePeriod.C:
+		failure.Message += fmt.Sprintf("\n\n%s did not exit within the %s grace period after its SpecContext was cancelled.  Make sure it returns when <-ctx.Done() is closed.", node.NodeType, suite.config.GracePeriod)
+		return false
+	}
+}
+
+// waitForRetryBackoff waits out the backoff before a spec is retried.  It returns false if the suite is interrupted while waiting.
+func (suite *Suite) waitForRetryBackoff(backoff time.Duration) bool {
+	if backoff <= 0 {
+		return true
+	}
+	timer := time.NewTimer(backoff)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return true
+	case <-suite.interruptHandler.Status().Channel:
+		return false
+	}
+}
+
+// streamGinkgoWriterOutputFor switches the GinkgoWriter to streaming for specs decorated with NoCapture and returns a function that restores the previous mode
+// Output from parallel processes is always collated by the CLI so NoCapture has no effect when running in parallel
+func (suite *Suite) streamGinkgoWriterOutputFor(spec Spec) func() {
+	if !spec.Nodes.HasNodeMarkedNoCapture() || suite.isRunningInParallel() {
+		return func() {}
+	}
+	mode := suite.writer.Mode()
+	suite.writer.SetMode(WriterModeStreamAndBuffer)
+	return func() { suite.writer.SetMode(mode) }
+}
+
+// nodeTimeout returns the timeout for the passed-in node: its NodeTimeout decoration if set, or --suite-node-timeout for suite setup and cleanup nodes
+func (suite *Suite) nodeTimeout(node Node) time.Duration {
+	if node.NodeTimeout > 0 {
+		return node.NodeTimeout
+	}
+	if node.NodeType.Is(types.NodeTypesForSuiteSetupAndCleanup) {
+		return suite.config.SuiteNodeTimeout
+	}
+	return 0
+}
+
+// injectChaos applies the chaos monkey's perturbation to the node and returns true if the node should not be run
+func (suite *Suite) injectChaos(node Node) bool {
+	perturbation := suite.chaosMonkey.Perturbation(node)
+	if perturbation.Delay > 0 {
+		fmt.Fprintf(suite.writer, "[CHAOS] Pausing for %s before [%s]\n", perturbation.Delay.Round(time.Millisecond), node.NodeType)
+		time.Sleep(perturbation.Delay)
+	}
+	if perturbation.InjectFailure {
+		fmt.Fprintf(suite.writer, "[CHAOS] Injecting a simulated failure into [%s]\n", node.NodeType)
+		suite.failer.Fail(perturbation.FailureMessage, node.CodeLocation)
+		return true
+	}
+	return false
+}
+
 func (suite *Suite) failureForLeafNodeWithMessage(node Node, message string) types.Failure {
 	return types.Failure{
 		Message:             message,
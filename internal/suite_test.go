@@ -135,22 +135,28 @@ var _ = Describe("Suite", func() {
 				})
 
 				Context("when pushing more than one BeforeSuite node", func() {
-					It("errors", func() {
+					It("succeeds - but only allows one SynchronizedBeforeSuite node", func() {
 						err := suite.PushNode(N(types.NodeTypeBeforeSuite))
-						Ω(err).Should(HaveOccurred())
+						Ω(err).ShouldNot(HaveOccurred())
 
 						err = suite.PushNode(N(types.NodeTypeSynchronizedBeforeSuite))
-						Ω(err).Should(HaveOccurred())
+						Ω(err).ShouldNot(HaveOccurred())
+
+						err = suite.PushNode(N(types.NodeTypeSynchronizedBeforeSuite))
+						Ω(err).Should(MatchError(types.GinkgoErrors.MultipleBeforeSuiteNodes(types.NodeTypeSynchronizedBeforeSuite, cl, types.NodeTypeSynchronizedBeforeSuite, cl)))
 					})
 				})
 
 				Context("when pushing more than one AfterSuite node", func() {
-					It("errors", func() {
+					It("succeeds - but only allows one SynchronizedAfterSuite node", func() {
 						err := suite.PushNode(N(types.NodeTypeAfterSuite))
-						Ω(err).Should(HaveOccurred())
+						Ω(err).ShouldNot(HaveOccurred())
+
+						err = suite.PushNode(N(types.NodeTypeSynchronizedAfterSuite))
+						Ω(err).ShouldNot(HaveOccurred())
 
 						err = suite.PushNode(N(types.NodeTypeSynchronizedAfterSuite))
-						Ω(err).Should(HaveOccurred())
+						Ω(err).Should(MatchError(types.GinkgoErrors.MultipleAfterSuiteNodes(types.NodeTypeSynchronizedAfterSuite, cl, types.NodeTypeSynchronizedAfterSuite, cl)))
 					})
 				})
 			})
@@ -172,7 +178,7 @@ var _ = Describe("Suite", func() {
 				})
 
 				Context("when the outer-most ordered container is not marked serial", func() {
-					It("errors", func() {
+					It("errors when the tree is built", func() {
 						var errors = make([]error, 3)
 						errors[0] = suite.PushNode(N(ntCon, "top-level-container", Ordered, func() {
 							errors[1] = suite.PushNode(N(ntCon, "inner-container", func() {
@@ -180,9 +186,9 @@ var _ = Describe("Suite", func() {
 							}))
 						}))
 						Ω(errors[0]).ShouldNot(HaveOccurred())
-						Ω(suite.BuildTree()).Should(Succeed())
+						Ω(suite.BuildTree()).Should(MatchError(types.GinkgoErrors.InvalidSerialNodeInNonSerialOrderedContainer(cl, ntIt)))
 						Ω(errors[1]).ShouldNot(HaveOccurred())
-						Ω(errors[2]).Should(MatchError(types.GinkgoErrors.InvalidSerialNodeInNonSerialOrderedContainer(cl, ntIt)))
+						Ω(errors[2]).ShouldNot(HaveOccurred())
 					})
 				})
 			})
@@ -203,20 +209,38 @@ var _ = Describe("Suite", func() {
 				})
 
 				Context("anywhere else", func() {
-					It("errors", func() {
+					It("reports all the misplaced nodes at once when the tree is built", func() {
 						var errors = make([]error, 3)
 						errors[0] = suite.PushNode(N(ntCon, "top-level-container", func() {
 							errors[1] = suite.PushNode(N(types.NodeTypeBeforeAll, cl, func() {}))
 							errors[2] = suite.PushNode(N(types.NodeTypeAfterAll, cl, func() {}))
 						}))
 						Ω(errors[0]).ShouldNot(HaveOccurred())
-						Ω(suite.BuildTree()).Should(Succeed())
-						Ω(errors[1]).Should(MatchError(types.GinkgoErrors.SetupNodeNotInOrderedContainer(cl, types.NodeTypeBeforeAll)))
-						Ω(errors[2]).Should(MatchError(types.GinkgoErrors.SetupNodeNotInOrderedContainer(cl, types.NodeTypeAfterAll)))
+						Ω(suite.BuildTree()).Should(Equal(types.SpecStructureErrors{
+							types.GinkgoErrors.SetupNodeNotInOrderedContainer(cl, types.NodeTypeBeforeAll),
+							types.GinkgoErrors.SetupNodeNotInOrderedContainer(cl, types.NodeTypeAfterAll),
+						}))
+						Ω(errors[1]).ShouldNot(HaveOccurred())
+						Ω(errors[2]).ShouldNot(HaveOccurred())
 					})
 				})
 			})
 
+			Context("when node errors are recorded", func() {
+				It("reports them, along with any other structure errors, when the tree is built", func() {
+					Ω(suite.RecordNodeErrors([]error{types.GinkgoErrors.InvalidDecoratorForNodeType(cl, ntBef, "Focus")})).Should(BeTrue())
+					suite.PushNode(N(ntCon, "top-level-container", func() {
+						Ω(suite.RecordNodeErrors([]error{types.GinkgoErrors.InvalidDecoratorForNodeType(cl, ntAf, "Pending")})).Should(BeTrue())
+						suite.PushNode(N(types.NodeTypeBeforeAll, cl, func() {}))
+					}))
+					Ω(suite.BuildTree()).Should(Equal(types.SpecStructureErrors{
+						types.GinkgoErrors.InvalidDecoratorForNodeType(cl, ntBef, "Focus"),
+						types.GinkgoErrors.InvalidDecoratorForNodeType(cl, ntAf, "Pending"),
+						types.GinkgoErrors.SetupNodeNotInOrderedContainer(cl, types.NodeTypeBeforeAll),
+					}))
+				})
+			})
+
 			Context("when pushing a suite node during PhaseBuildTree", func() {
 				It("errors", func() {
 					var pushSuiteNodeErr error
@@ -243,6 +267,114 @@ var _ = Describe("Suite", func() {
 					Ω(pushSuiteNodeErr).Should(HaveOccurred())
 				})
 			})
+
+			Context("when pushing a node during PhaseRun", func() {
+				It("errors and includes the stack of the offending call", func() {
+					var pushNodeErr error
+					err := suite.PushNode(N(ntIt, "top-level it", func() {
+						pushNodeErr = suite.PushNode(N(ntIt, "nested it", cl))
+					}))
+
+					Ω(err).ShouldNot(HaveOccurred())
+					Ω(suite.BuildTree()).Should(Succeed())
+					suite.Run("suite", Labels{}, "/path/to/suite", failer, reporter, writer, outputInterceptor, interruptHandler, client, conf)
+					Ω(pushNodeErr).Should(HaveField("CodeLocation.FileName", cl.FileName))
+					Ω(pushNodeErr).Should(HaveField("CodeLocation.FullStackTrace", ContainSubstring("suite_test.go")))
+					Ω(pushNodeErr.Error()).Should(ContainSubstring("the specs started running"))
+					Ω(pushNodeErr.Error()).Should(ContainSubstring("Here's the stack of the offending call:"))
+				})
+			})
+
+			Context("when pushing a node from another goroutine during PhaseBuildTree", func() {
+				It("errors, includes the stack of the offending call, and leaves the tree untouched", func() {
+					var pushNodeErr error
+					err := suite.PushNode(N(ntCon, "top-level-container", func() {
+						done := make(chan interface{})
+						go func() {
+							pushNodeErr = suite.PushNode(N(ntIt, "it from a goroutine", cl))
+							close(done)
+						}()
+						<-done
+						suite.PushNode(N(ntIt, "it"))
+					}))
+
+					Ω(err).ShouldNot(HaveOccurred())
+					Ω(suite.BuildTree()).Should(Succeed())
+					Ω(pushNodeErr).Should(HaveField("CodeLocation.FileName", cl.FileName))
+					Ω(pushNodeErr).Should(HaveField("CodeLocation.FullStackTrace", ContainSubstring("suite_test.go")))
+					Ω(pushNodeErr.Error()).Should(ContainSubstring("from a goroutine"))
+
+					suite.Run("suite", Labels{}, "/path/to/suite", failer, reporter, writer, outputInterceptor, interruptHandler, client, conf)
+					Ω(reporter.Did.Names()).Should(Equal([]string{"it"}))
+				})
+			})
+		})
+
+		Describe("Global Nodes", func() {
+			Context("when pushing a global setup node", func() {
+				It("applies it to every spec", func() {
+					var errors = make([]error, 4)
+					errors[0] = suite.PushNode(N(ntCon, "container", func() {
+						errors[1] = suite.PushGlobalNode(N(ntBef, "global", rt.T("global")))
+						errors[2] = suite.PushNode(N(ntIt, "A", rt.T("A")))
+					}))
+					errors[3] = suite.PushNode(N(ntIt, "B", rt.T("B")))
+					Ω(suite.BuildTree()).Should(Succeed())
+					Ω(errors).Should(ConsistOf(BeNil(), BeNil(), BeNil(), BeNil()))
+
+					suite.Run("suite", Labels{}, "/path/to/suite", failer, reporter, writer, outputInterceptor, interruptHandler, client, conf)
+					Ω(rt.TrackedRuns()).Should(ConsistOf("global", "global", "A", "B"))
+				})
+			})
+
+			Context("when pushing a node that cannot be global", func() {
+				It("errors", func() {
+					err := suite.PushGlobalNode(N(ntIt, cl))
+					Ω(err).Should(MatchError(types.GinkgoErrors.InvalidGlobalNodeType(cl, ntIt)))
+				})
+			})
+
+			Context("when pushing a global node during PhaseRun", func() {
+				It("errors", func() {
+					var pushGlobalNodeErr error
+					err := suite.PushNode(N(ntIt, "top-level it", func() {
+						pushGlobalNodeErr = suite.PushGlobalNode(N(ntBef, cl))
+					}))
+
+					Ω(err).ShouldNot(HaveOccurred())
+					Ω(suite.BuildTree()).Should(Succeed())
+					suite.Run("suite", Labels{}, "/path/to/suite", failer, reporter, writer, outputInterceptor, interruptHandler, client, conf)
+					Ω(pushGlobalNodeErr).Should(HaveField("Heading", "Ginkgo detected an issue with your spec structure"))
+				})
+			})
+		})
+
+		Describe("Shared Examples", func() {
+			It("stores them by name", func() {
+				body := func(x int) {}
+				Ω(suite.RegisterSharedExamples("a thing", body, cl)).Should(Succeed())
+				found, ok := suite.SharedExamples("a thing")
+				Ω(ok).Should(BeTrue())
+				Ω(found).Should(BeAssignableToTypeOf(body))
+
+				_, ok = suite.SharedExamples("another thing")
+				Ω(ok).Should(BeFalse())
+			})
+
+			Context("when the body is not a function that returns nothing", func() {
+				It("errors", func() {
+					Ω(suite.RegisterSharedExamples("a thing", "not a function", cl)).Should(MatchError(types.GinkgoErrors.InvalidSharedExamplesBody("a thing", cl)))
+					Ω(suite.RegisterSharedExamples("a thing", func() error { return nil }, cl)).Should(MatchError(types.GinkgoErrors.InvalidSharedExamplesBody("a thing", cl)))
+				})
+			})
+
+			Context("when the name has already been registered", func() {
+				It("errors", func() {
+					cl2 := types.NewCodeLocation(0)
+					Ω(suite.RegisterSharedExamples("a thing", func() {}, cl)).Should(Succeed())
+					Ω(suite.RegisterSharedExamples("a thing", func() {}, cl2)).Should(MatchError(types.GinkgoErrors.MultipleSharedExamplesWithName("a thing", cl, cl2)))
+				})
+			})
 		})
 
 		Describe("Cleanup Nodes", func() {
@@ -331,6 +463,29 @@ var _ = Describe("Suite", func() {
 				})
 			})
 
+			Context("when pushing a cleanup node in an AroundEach node", func() {
+				It("errors", func() {
+					var errors = make([]error, 4)
+					aroundEachNode, _ := internal.NewAroundEachNode(func(runSpec func()) {
+						errors[3] = suite.PushNode(N(types.NodeTypeCleanupInvalid, cl))
+						runSpec()
+					}, types.NewCodeLocation(0))
+
+					errors[0] = suite.PushNode(N(ntCon, "container", func() {
+						errors[1] = suite.PushNode(aroundEachNode)
+						errors[2] = suite.PushNode(N(ntIt, "test"))
+					}))
+					Ω(errors[0]).ShouldNot(HaveOccurred())
+
+					Ω(suite.BuildTree()).Should(Succeed())
+					Ω(errors[1]).ShouldNot(HaveOccurred())
+					Ω(errors[2]).ShouldNot(HaveOccurred())
+
+					suite.Run("suite", Labels{}, "/path/to/suite", failer, reporter, writer, outputInterceptor, interruptHandler, client, conf)
+					Ω(errors[3]).Should(MatchError(types.GinkgoErrors.PushingCleanupInAroundEachNode(cl)))
+				})
+			})
+
 			Context("when pushing a cleanup node within a cleanup node", func() {
 				It("errors", func() {
 					var errors = make([]error, 3)
@@ -348,6 +503,20 @@ var _ = Describe("Suite", func() {
 					Ω(errors[2]).Should(MatchError(types.GinkgoErrors.PushingCleanupInCleanupNode(cl)))
 				})
 			})
+
+			Context("when pushing an OnProcess1 cleanup node outside of a suite node", func() {
+				It("errors", func() {
+					var errors = make([]error, 2)
+					errors[0] = suite.PushNode(N(ntIt, "It", func() {
+						cleanupNode, _ := internal.NewCleanupNode(nil, cl, internal.OnProcess1, func() {})
+						errors[1] = suite.PushNode(cleanupNode)
+					}))
+					Ω(errors[0]).ShouldNot(HaveOccurred())
+					Ω(suite.BuildTree()).Should(Succeed())
+					suite.Run("suite", Labels{}, "/path/to/suite", failer, reporter, writer, outputInterceptor, interruptHandler, client, conf)
+					Ω(errors[1]).Should(MatchError(types.GinkgoErrors.OnProcess1CleanupOutsideOfSuiteNode(cl, ntIt)))
+				})
+			})
 		})
 
 		Describe("ReportEntries", func() {
@@ -79,10 +79,11 @@ func (s Reports) WithLeafNodeType(nodeTypes types.NodeType) Reports {
 }
 
 type FakeReporter struct {
-	Begin types.Report
-	Will  Reports
-	Did   Reports
-	End   types.Report
+	Begin           types.Report
+	Will            Reports
+	Did             Reports
+	ProgressReports []types.ProgressReport
+	End             types.Report
 }
 
 func (r *FakeReporter) SuiteWillBegin(report types.Report) {
@@ -97,6 +98,10 @@ func (r *FakeReporter) DidRun(report types.SpecReport) {
 	r.Did = append(r.Did, report)
 }
 
+func (r *FakeReporter) EmitProgressReport(report types.ProgressReport) {
+	r.ProgressReports = append(r.ProgressReports, report)
+}
+
 func (r *FakeReporter) SuiteDidEnd(report types.Report) {
 	r.End = report
 }
@@ -270,6 +275,10 @@ func HaveFailed(options ...interface{}) OmegaMatcher {
 	return failureMatcherForState(types.SpecStateFailed, "Failure.Message", options...)
 }
 
+func HaveTimedOut(options ...interface{}) OmegaMatcher {
+	return failureMatcherForState(types.SpecStateTimedout, "Failure.Message", options...)
+}
+
 func HaveAborted(options ...interface{}) OmegaMatcher {
 	return failureMatcherForState(types.SpecStateAborted, "Failure.Message", options...)
 }
@@ -51,22 +51,24 @@ func GenerateSpecsFromTreeRoot(tree *TreeNode) Specs {
 			nodes[i].NestingLevel = nestingLevel
 		}
 
+		// setup nodes apply to every container and It at this level, in the order they appear.
+		// we partition them once, rather than once per sibling, so that levels with many siblings (e.g. large tables) don't go quadratic
+		setupNodes := nodes.WithoutType(types.NodeTypesForContainerAndIt)
+		numSetupNodesToTheLeft := 0
 		for i := range nodes {
 			if !nodes[i].NodeType.Is(types.NodeTypesForContainerAndIt) {
+				numSetupNodesToTheLeft++
 				continue
 			}
-			leftNodes, rightNodes := nodes.SplitAround(nodes[i])
-			leftNodes = leftNodes.WithoutType(types.NodeTypesForContainerAndIt)
-			rightNodes = rightNodes.WithoutType(types.NodeTypesForContainerAndIt)
-
-			leftNodes = lNodes.CopyAppend(leftNodes...)
-			rightNodes = rightNodes.CopyAppend(rNodes...)
-
 			if nodes[i].NodeType.Is(types.NodeTypeIt) {
-				tests = append(tests, Spec{Nodes: leftNodes.CopyAppend(nodes[i]).CopyAppend(rightNodes...)})
+				specNodes := make(Nodes, 0, len(lNodes)+len(setupNodes)+1+len(rNodes))
+				specNodes = append(append(specNodes, lNodes...), setupNodes[:numSetupNodesToTheLeft]...)
+				specNodes = append(append(specNodes, nodes[i]), setupNodes[numSetupNodesToTheLeft:]...)
+				tests = append(tests, Spec{Nodes: append(specNodes, rNodes...)})
 			} else {
-				treeNode := trees.WithID(nodes[i].ID)
-				tests = append(tests, walkTree(nestingLevel+1, leftNodes.CopyAppend(nodes[i]), rightNodes, treeNode.Children)...)
+				leftNodes := lNodes.CopyAppend(setupNodes[:numSetupNodesToTheLeft]...)
+				rightNodes := setupNodes[numSetupNodesToTheLeft:].CopyAppend(rNodes...)
+				tests = append(tests, walkTree(nestingLevel+1, leftNodes.CopyAppend(nodes[i]), rightNodes, trees[i].Children)...)
 			}
 		}
 
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"io"
 	"sync"
+	"sync/atomic"
 )
 
 type WriterMode uint
@@ -19,16 +20,42 @@ type WriterInterface interface {
 
 	Truncate()
 	Bytes() []byte
+	String() string
+	Len() int
+	Mode() WriterMode
+	SetMode(mode WriterMode)
 }
 
+//GinkgoWriterInterface is the interface implemented by GinkgoWriter - and by the writers returned by GinkgoWriterWithPrefix
+type GinkgoWriterInterface interface {
+	io.Writer
+
+	Print(a ...interface{})
+	Printf(format string, a ...interface{})
+	Println(a ...interface{})
+
+	TeeTo(writer io.Writer)
+	ClearTeeWriters()
+}
+
+//maxRetainedBufferSize is the largest buffer Truncate holds on to for reuse - so one very noisy spec doesn't pin its output in memory for the rest of the suite
+const maxRetainedBufferSize = 1 << 20
+
 //Writer implements WriterInterface and GinkgoWriterInterface
 type Writer struct {
 	buffer    *bytes.Buffer
 	outWriter io.Writer
 	lock      *sync.Mutex
-	mode      WriterMode
+	//mode is read on every write and read and set around every spec so it is accessed atomically, without taking the lock
+	mode uint32
 
 	teeWriters []io.Writer
+
+	redactions *OutputFilters
+
+	//midLine and linePrefix track the line currently being written so that output from prefixed writers is never attributed to the wrong writer
+	midLine    bool
+	linePrefix string
 }
 
 func NewWriter(outWriter io.Writer) *Writer {
@@ -36,25 +63,73 @@ func NewWriter(outWriter io.Writer) *Writer {
 		buffer:    &bytes.Buffer{},
 		lock:      &sync.Mutex{},
 		outWriter: outWriter,
-		mode:      WriterModeStreamAndBuffer,
+		mode:      uint32(WriterModeStreamAndBuffer),
 	}
 }
 
 func (w *Writer) SetMode(mode WriterMode) {
+	atomic.StoreUint32(&w.mode, uint32(mode))
+}
+
+func (w *Writer) Mode() WriterMode {
+	return WriterMode(atomic.LoadUint32(&w.mode))
+}
+
+//SetRedactions redacts everything subsequently written to the writer - including output that is streamed or sent to tee writers
+func (w *Writer) SetRedactions(redactions *OutputFilters) {
 	w.lock.Lock()
 	defer w.lock.Unlock()
-	w.mode = mode
+	w.redactions = redactions
 }
 
 func (w *Writer) Write(b []byte) (n int, err error) {
+	return w.writeWithPrefix("", b)
+}
+
+func (w *Writer) writeWithPrefix(prefix string, b []byte) (n int, err error) {
 	w.lock.Lock()
 	defer w.lock.Unlock()
 
+	n = len(b)
+	if prefix != "" || (w.midLine && w.linePrefix != "") {
+		b = w.attribute(prefix, b)
+	}
+	if len(b) > 0 {
+		w.midLine = b[len(b)-1] != '\n'
+		w.linePrefix = prefix
+	}
+
+	if w.redactions != nil && w.redactions.Len() > 0 {
+		b = []byte(w.redactions.Apply(string(b)))
+	}
+	_, err = w.write(b)
+	return n, err
+}
+
+//attribute tags every line in b with prefix.  If another writer left a line unfinished, b starts on a new line.
+func (w *Writer) attribute(prefix string, b []byte) []byte {
+	out := &bytes.Buffer{}
+	atLineStart := !w.midLine
+	if w.midLine && w.linePrefix != prefix {
+		out.WriteByte('\n')
+		atLineStart = true
+	}
+	for _, c := range b {
+		if atLineStart && prefix != "" {
+			out.WriteString("[" + prefix + "] ")
+		}
+		out.WriteByte(c)
+		atLineStart = c == '\n'
+	}
+	return out.Bytes()
+}
+
+func (w *Writer) write(b []byte) (n int, err error) {
 	for _, teeWriter := range w.teeWriters {
 		teeWriter.Write(b)
 	}
 
-	if w.mode == WriterModeStreamAndBuffer {
+	if w.Mode() == WriterModeStreamAndBuffer {
 		w.outWriter.Write(b)
 	}
 	return w.buffer.Write(b)
@@ -63,7 +138,12 @@ func (w *Writer) Write(b []byte) (n int, err error) {
 func (w *Writer) Truncate() {
 	w.lock.Lock()
 	defer w.lock.Unlock()
-	w.buffer.Reset()
+	if w.buffer.Cap() > maxRetainedBufferSize {
+		w.buffer = &bytes.Buffer{}
+	} else {
+		w.buffer.Reset()
+	}
+	w.midLine, w.linePrefix = false, ""
 }
 
 func (w *Writer) Bytes() []byte {
@@ -75,6 +155,23 @@ func (w *Writer) Bytes() []byte {
 	return copied
 }
 
+//Len returns the number of bytes written since the last Truncate, without copying them
+func (w *Writer) Len() int {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	return w.buffer.Len()
+}
+
+//String returns everything written since the last Truncate.  Unlike string(w.Bytes()) it copies the output once - and not at all if there isn't any.
+func (w *Writer) String() string {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	if w.buffer.Len() == 0 {
+		return ""
+	}
+	return w.buffer.String()
+}
+
 //GinkgoWriterInterface
 func (w *Writer) TeeTo(writer io.Writer) {
 	w.lock.Lock()
@@ -101,3 +198,91 @@ func (w *Writer) Printf(format string, a ...interface{}) {
 func (w *Writer) Println(a ...interface{}) {
 	fmt.Fprintln(w, a...)
 }
+
+//WithPrefix returns a writer that tags every line it writes with [prefix]
+func (w *Writer) WithPrefix(prefix string) PrefixedWriter {
+	return PrefixedWriter{writer: w, prefix: prefix}
+}
+
+//PrefixedWriter shares the Writer that created it - and so its buffer, mode, tee writers, and redactions
+type PrefixedWriter struct {
+	writer *Writer
+	prefix string
+}
+
+func (w PrefixedWriter) Write(b []byte) (n int, err error) {
+	return w.writer.writeWithPrefix(w.prefix, b)
+}
+
+func (w PrefixedWriter) Print(a ...interface{}) {
+	fmt.Fprint(w, a...)
+}
+
+func (w PrefixedWriter) Printf(format string, a ...interface{}) {
+	fmt.Fprintf(w, format, a...)
+}
+
+func (w PrefixedWriter) Println(a ...interface{}) {
+	fmt.Fprintln(w, a...)
+}
+
+func (w PrefixedWriter) TeeTo(writer io.Writer) {
+	w.writer.TeeTo(writer)
+}
+
+func (w PrefixedWriter) ClearTeeWriters() {
+	w.writer.ClearTeeWriters()
+}
+
+//WithPrefix nests prefix within this writer's prefix
+func (w PrefixedWriter) WithPrefix(prefix string) PrefixedWriter {
+	return w.writer.WithPrefix(w.prefix + "/" + prefix)
+}
+
+//NewPrefixingWriter returns a writer that tags every line it writes to writer with [prefix].  It is used when GinkgoWriter has been replaced with a writer
+//that is not a *Writer so, unlike a PrefixedWriter, it can't tell when another writer has left a line unfinished.
+func NewPrefixingWriter(writer GinkgoWriterInterface, prefix string) GinkgoWriterInterface {
+	return &prefixingWriter{writer: writer, prefix: prefix, lock: &sync.Mutex{}, atLineStart: true}
+}
+
+type prefixingWriter struct {
+	writer      GinkgoWriterInterface
+	prefix      string
+	lock        *sync.Mutex
+	atLineStart bool
+}
+
+func (w *prefixingWriter) Write(b []byte) (n int, err error) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	out := &bytes.Buffer{}
+	for _, c := range b {
+		if w.atLineStart {
+			out.WriteString("[" + w.prefix + "] ")
+		}
+		out.WriteByte(c)
+		w.atLineStart = c == '\n'
+	}
+	_, err = w.writer.Write(out.Bytes())
+	return len(b), err
+}
+
+func (w *prefixingWriter) Print(a ...interface{}) {
+	fmt.Fprint(w, a...)
+}
+
+func (w *prefixingWriter) Printf(format string, a ...interface{}) {
+	fmt.Fprintf(w, format, a...)
+}
+
+func (w *prefixingWriter) Println(a ...interface{}) {
+	fmt.Fprintln(w, a...)
+}
+
+func (w *prefixingWriter) TeeTo(writer io.Writer) {
+	w.writer.TeeTo(writer)
+}
+
+func (w *prefixingWriter) ClearTeeWriters() {
+	w.writer.ClearTeeWriters()
+}
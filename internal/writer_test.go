@@ -1,11 +1,20 @@
 package internal_test
 
 import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
 	"github.com/onsi/ginkgo/v2/internal"
 	"github.com/onsi/gomega/gbytes"
+	"github.com/onsi/gomega/gmeasure"
 )
 
 var _ = Describe("Writer", func() {
@@ -56,6 +65,36 @@ var _ = Describe("Writer", func() {
 				Ω(writer.Bytes()).Should(Equal([]byte("bar")))
 			})
 		})
+
+		Describe("String() and Len()", func() {
+			It("are empty when nothing has been written", func() {
+				Ω(writer.String()).Should(Equal(""))
+				Ω(writer.Len()).Should(Equal(0))
+			})
+
+			It("report all that's been written so far", func() {
+				writer.Write([]byte("foo"))
+				writer.Write([]byte("bar"))
+				Ω(writer.String()).Should(Equal("foobar"))
+				Ω(writer.Len()).Should(Equal(6))
+			})
+
+			It("clear when told to truncate", func() {
+				writer.Write([]byte("foo"))
+				writer.Truncate()
+				Ω(writer.String()).Should(Equal(""))
+				Ω(writer.Len()).Should(Equal(0))
+			})
+
+			It("keep working after truncating a very large buffer", func() {
+				writer.Write(bytes.Repeat([]byte("x"), 2<<20))
+				Ω(writer.Len()).Should(Equal(2 << 20))
+				writer.Truncate()
+				Ω(writer.Len()).Should(Equal(0))
+				writer.Write([]byte("bar"))
+				Ω(writer.String()).Should(Equal("bar"))
+			})
+		})
 	})
 
 	Describe("Teeing to additional writers", func() {
@@ -92,6 +131,101 @@ var _ = Describe("Writer", func() {
 		})
 	})
 
+	Describe("Redacting", func() {
+		var tee *gbytes.Buffer
+		BeforeEach(func() {
+			tee = gbytes.NewBuffer()
+			writer.TeeTo(tee)
+			redactions := internal.NewOutputFilters()
+			redactions.Add(internal.RedactionForValue("hunter2"))
+			writer.SetRedactions(redactions)
+		})
+
+		It("redacts what is streamed, teed, and buffered", func() {
+			n, err := writer.Write([]byte("password: hunter2"))
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(n).Should(Equal(len("password: hunter2")))
+			Ω(string(out.Contents())).Should(Equal("password: [REDACTED]"))
+			Ω(string(tee.Contents())).Should(Equal("password: [REDACTED]"))
+			Ω(string(writer.Bytes())).Should(Equal("password: [REDACTED]"))
+		})
+	})
+
+	Describe("Prefixed writers", func() {
+		It("tags every line with the prefix and shares the underlying writer", func() {
+			tee := gbytes.NewBuffer()
+			writer.TeeTo(tee)
+			worker := writer.WithPrefix("worker-3")
+			n, err := worker.Write([]byte("starting\nworking"))
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(n).Should(Equal(len("starting\nworking")))
+			worker.Println("...done")
+			Ω(string(writer.Bytes())).Should(Equal("[worker-3] starting\n[worker-3] working...done\n"))
+			Ω(string(out.Contents())).Should(Equal("[worker-3] starting\n[worker-3] working...done\n"))
+			Ω(string(tee.Contents())).Should(Equal("[worker-3] starting\n[worker-3] working...done\n"))
+		})
+
+		It("starts a new line when another writer has left a line unfinished", func() {
+			a, b := writer.WithPrefix("a"), writer.WithPrefix("b")
+			a.Print("a-1")
+			b.Print("b-1")
+			writer.Print("untagged")
+			a.Print(" a-2\n")
+			Ω(string(writer.Bytes())).Should(Equal("[a] a-1\n[b] b-1\nuntagged\n[a]  a-2\n"))
+		})
+
+		It("nests prefixes", func() {
+			writer.WithPrefix("worker-3").WithPrefix("db").Println("connected")
+			Ω(string(writer.Bytes())).Should(Equal("[worker-3/db] connected\n"))
+		})
+
+		It("attributes every line correctly when used from multiple goroutines", func() {
+			done := make(chan interface{})
+			for i := 0; i < 4; i++ {
+				go func(i int, worker internal.GinkgoWriterInterface) {
+					for j := 0; j < 50; j++ {
+						worker.Print(fmt.Sprintf("w%d-", i))
+						worker.Println(fmt.Sprintf("w%d", i))
+					}
+					done <- true
+				}(i, writer.WithPrefix(fmt.Sprintf("worker-%d", i)))
+			}
+			for i := 0; i < 4; i++ {
+				Eventually(done).Should(Receive())
+			}
+			lines := strings.Split(strings.TrimSuffix(string(writer.Bytes()), "\n"), "\n")
+			Ω(len(lines)).Should(BeNumerically(">=", 200))
+			for _, line := range lines {
+				Ω(line).Should(MatchRegexp(`^\[worker-(\d)\] (w\d-)?(w\d)?$`))
+				worker, content := line[len("[worker-"):len("[worker-0")], line[len("[worker-0] "):]
+				Ω(strings.ReplaceAll(content, "w"+worker, "")).Should(Or(BeEmpty(), Equal("-")))
+			}
+		})
+
+		It("does not affect untagged output", func() {
+			writer.Print("foo")
+			writer.Println("bar")
+			Ω(string(writer.Bytes())).Should(Equal("foobar\n"))
+		})
+	})
+
+	Describe("Prefixing writers that are not a *Writer", func() {
+		It("tags every line with the prefix and forwards tee writers", func() {
+			custom := &customGinkgoWriter{Buffer: gbytes.NewBuffer()}
+			worker := internal.NewPrefixingWriter(custom, "worker-3")
+			n, err := worker.Write([]byte("starting\nworking"))
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(n).Should(Equal(len("starting\nworking")))
+			worker.Println("...done")
+			worker.Printf("%d\n", 17)
+			Ω(string(custom.Contents())).Should(Equal("[worker-3] starting\n[worker-3] working...done\n[worker-3] 17\n"))
+
+			tee := gbytes.NewBuffer()
+			worker.TeeTo(tee)
+			Ω(custom.tees).Should(Equal([]io.Writer{tee}))
+		})
+	})
+
 	Describe("Convenience print methods", func() {
 		It("can Print", func() {
 			writer.Print("foo", "baz", " ", "bizzle")
@@ -109,3 +243,74 @@ var _ = Describe("Writer", func() {
 		})
 	})
 })
+
+// customGinkgoWriter stands in for a GinkgoWriter a suite has replaced with its own implementation
+type customGinkgoWriter struct {
+	*gbytes.Buffer
+	tees []io.Writer
+}
+
+func (w *customGinkgoWriter) Print(a ...interface{})                 { fmt.Fprint(w, a...) }
+func (w *customGinkgoWriter) Printf(format string, a ...interface{}) { fmt.Fprintf(w, format, a...) }
+func (w *customGinkgoWriter) Println(a ...interface{})               { fmt.Fprintln(w, a...) }
+func (w *customGinkgoWriter) TeeTo(writer io.Writer)                 { w.tees = append(w.tees, writer) }
+func (w *customGinkgoWriter) ClearTeeWriters()                       { w.tees = nil }
+
+var _ = Describe("Writer Performance", Serial, Label("performance"), func() {
+	BeforeEach(func() {
+		if os.Getenv("PERF") == "" {
+			Skip("")
+		}
+	})
+
+	var newWriter = func() *internal.Writer {
+		writer := internal.NewWriter(io.Discard)
+		writer.SetMode(internal.WriterModeBufferOnly)
+		writer.SetRedactions(internal.NewOutputFilters())
+		return writer
+	}
+
+	It("measures the cost of the writer bookkeeping Ginkgo does for every spec", func() {
+		experiment := gmeasure.NewExperiment("writer bookkeeping per 1000 specs")
+		writer := newWriter()
+		for _, logs := range []bool{false, true} {
+			name := "silent specs"
+			if logs {
+				name = "specs that log a line"
+			}
+			experiment.SampleDuration(name, func(_ int) {
+				for i := 0; i < 1000; i++ {
+					mode := writer.Mode()
+					writer.SetMode(internal.WriterModeStreamAndBuffer)
+					writer.Truncate()
+					if logs {
+						writer.Println("reticulating splines")
+					}
+					_ = writer.String()
+					writer.SetMode(mode)
+				}
+			}, gmeasure.SamplingConfig{N: 256}, gmeasure.Precision(time.Microsecond))
+			AddReportEntry(name, experiment.GetStats(name))
+		}
+	})
+
+	It("measures the cost of writing from many goroutines at once", func() {
+		experiment := gmeasure.NewExperiment("concurrent writes")
+		writer := newWriter()
+		experiment.SampleDuration("8 goroutines x 1000 lines", func(_ int) {
+			writer.Truncate()
+			wg := &sync.WaitGroup{}
+			for g := 0; g < 8; g++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					for i := 0; i < 1000; i++ {
+						writer.Println("reticulating splines")
+					}
+				}()
+			}
+			wg.Wait()
+		}, gmeasure.SamplingConfig{N: 64}, gmeasure.Precision(time.Microsecond))
+		AddReportEntry(experiment.Name, experiment.GetStats("8 goroutines x 1000 lines"))
+	})
+})
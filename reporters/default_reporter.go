@@ -10,13 +10,18 @@ package reporters
 import (
 	"fmt"
 	"io"
+	"regexp"
 	"runtime"
 	"strings"
+	"time"
 
 	"github.com/onsi/ginkgo/v2/formatter"
 	"github.com/onsi/ginkgo/v2/types"
 )
 
+// maxDigestReasonLength is the maximum length of the reason --quiet emits for each failure
+const maxDigestReasonLength = 200
+
 type DefaultReporter struct {
 	conf   types.ReporterConfig
 	writer io.Writer
@@ -26,9 +31,23 @@ type DefaultReporter struct {
 	lastEmissionWasDelimiter bool
 
 	// rendering
-	specDenoter  string
-	retryDenoter string
-	formatter    formatter.Formatter
+	specDenoter       string
+	retryDenoter      string
+	formatter         formatter.Formatter
+	stackTracePrune   []*regexp.Regexp
+	reportEntryFilter types.ReportEntryFilter
+	// the progress of the Ordered container running on each parallel process, used to summarize the container once its last spec completes
+	orderedContainers map[int]orderedContainerProgress
+
+	// captured at the start of the suite
+	parallelTotal int
+}
+
+// orderedContainerProgress tracks the specs in an Ordered container that actually ran - specs skipped after an earlier spec fails have neither a StartTime nor an EndTime
+type orderedContainerProgress struct {
+	startTime time.Time
+	endTime   time.Time
+	numRan    int
 }
 
 func NewDefaultReporterUnderTest(conf types.ReporterConfig, writer io.Writer) *DefaultReporter {
@@ -50,10 +69,19 @@ func NewDefaultReporter(conf types.ReporterConfig, writer io.Writer) *DefaultRep
 		retryDenoter: "↺",
 		formatter:    formatter.NewWithNoColorBool(conf.NoColor),
 	}
-	if runtime.GOOS == "windows" {
+	if runtime.GOOS == "windows" || conf.ASCII {
 		reporter.specDenoter = "+"
 		reporter.retryDenoter = "R"
 	}
+	for _, prune := range conf.StackTracePrune {
+		if re, err := regexp.Compile(prune); err == nil {
+			reporter.stackTracePrune = append(reporter.stackTracePrune, re)
+		}
+	}
+	reporter.reportEntryFilter, _ = conf.ConsoleReportEntryFilter()
+	if len(reporter.reportEntryFilter.Visibilities) == 0 {
+		reporter.reportEntryFilter.Visibilities = []types.ReportEntryVisibility{types.ReportEntryVisibilityAlways, types.ReportEntryVisibilityFailureOrVerbose}
+	}
 
 	return reporter
 }
@@ -61,6 +89,10 @@ func NewDefaultReporter(conf types.ReporterConfig, writer io.Writer) *DefaultRep
 /* The Reporter Interface */
 
 func (r *DefaultReporter) SuiteWillBegin(report types.Report) {
+	r.parallelTotal = report.SuiteConfig.ParallelTotal
+	if r.conf.Verbosity().Is(types.VerbosityLevelQuiet) {
+		return
+	}
 	if r.conf.Verbosity().Is(types.VerbosityLevelSuccinct) {
 		r.emit(r.f("[%d] {{bold}}%s{{/}} ", report.SuiteConfig.RandomSeed, report.SuiteDescription))
 		if len(report.SuiteLabels) > 0 {
@@ -93,15 +125,24 @@ func (r *DefaultReporter) SuiteWillBegin(report types.Report) {
 		if report.SuiteConfig.ParallelTotal > 1 {
 			r.emitBlock(r.f("Running in parallel across {{bold}}%d{{/}} processes", report.SuiteConfig.ParallelTotal))
 		}
+		if report.SuiteConfig.TableSample > 0 && report.SuiteConfig.TableSample < 1 {
+			r.emitBlock(r.f("Sampling {{bold}}%g%%{{/}} of each table's entries - table sample seed: {{bold}}%d{{/}}", report.SuiteConfig.TableSample*100, report.SuiteConfig.TableSamplingSeed()))
+		}
+		if report.SuiteConfig.Chaos {
+			r.emitBlock(r.f("{{magenta}}Running in chaos mode{{/}} - max delay: {{bold}}%s{{/}}, failure rate: {{bold}}%.2f{{/}}", report.SuiteConfig.ChaosMaxDelay, report.SuiteConfig.ChaosFailureRate))
+		}
 	}
 }
 
 func (r *DefaultReporter) WillRun(report types.SpecReport) {
-	if r.conf.Verbosity().LT(types.VerbosityLevelVerbose) || report.State.Is(types.SpecStatePending|types.SpecStateSkipped) {
+	if r.verbosityFor(report).LT(types.VerbosityLevelVerbose) || report.State.Is(types.SpecStatePending|types.SpecStateSkipped) {
 		return
 	}
 
 	r.emitDelimiter()
+	if report.OrderedContainerStep == 1 {
+		r.emitBlock(r.f("{{bold}}[Ordered]{{/}} %s {{gray}}- %d specs{{/}}", r.cycleJoin(report.ContainerHierarchyTexts, " "), report.OrderedContainerSteps))
+	}
 	indentation := uint(0)
 	if report.LeafNodeType.Is(types.NodeTypesForSuiteLevelNodes) {
 		r.emitBlock(r.f("{{bold}}[%s] %s{{/}}", report.LeafNodeType.String(), report.LeafNodeText))
@@ -111,27 +152,46 @@ func (r *DefaultReporter) WillRun(report types.SpecReport) {
 			indentation = 1
 		}
 		line := r.fi(indentation, "{{bold}}%s{{/}}", report.LeafNodeText)
+		if report.OrderedContainerSteps > 0 {
+			line = r.fi(indentation, "{{gray}}[%d/%d]{{/}} {{bold}}%s{{/}}", report.OrderedContainerStep, report.OrderedContainerSteps, report.LeafNodeText)
+		}
 		labels := report.Labels()
 		if len(labels) > 0 {
 			line += r.f(" {{coral}}[%s]{{/}}", strings.Join(labels, ", "))
 		}
+		if !report.ResourceRequirements.IsZero() {
+			line += r.f(" {{gray}}[requires %s]{{/}}", report.ResourceRequirements)
+		}
 		r.emitBlock(line)
 	}
 	r.emitBlock(r.fi(indentation, "{{gray}}%s{{/}}", report.LeafNodeLocation))
 }
 
 func (r *DefaultReporter) DidRun(report types.SpecReport) {
-	v := r.conf.Verbosity()
+	v := r.verbosityFor(report)
+	if v.Is(types.VerbosityLevelQuiet) {
+		return
+	}
+	if report.OrderedContainerSteps > 0 && v.GTE(types.VerbosityLevelVerbose) {
+		defer r.emitOrderedContainerSummary(report)
+	}
 	var header, highlightColor string
 	includeRuntime, emitGinkgoWriterOutput, stream, denoter := true, true, false, r.specDenoter
 	succinctLocationBlock := v.Is(types.VerbosityLevelSuccinct)
 
 	hasGW := report.CapturedGinkgoWriterOutput != ""
 	hasStd := report.CapturedStdOutErr != ""
-	hasEmittableReports := report.ReportEntries.HasVisibility(types.ReportEntryVisibilityAlways) || (report.ReportEntries.HasVisibility(types.ReportEntryVisibilityFailureOrVerbose) && (!report.Failure.IsZero() || v.GTE(types.VerbosityLevelVerbose)))
+	reportEntries := report.ReportEntries.Filter(r.reportEntryFilter)
+	if report.Failure.IsZero() && v.LT(types.VerbosityLevelVerbose) {
+		reportEntries = reportEntries.WithVisibility(types.ReportEntryVisibilityAlways, types.ReportEntryVisibilityNever)
+	}
+	hasEmittableReports := len(reportEntries) > 0
 
 	if report.LeafNodeType.Is(types.NodeTypesForSuiteLevelNodes) {
 		denoter = fmt.Sprintf("[%s]", report.LeafNodeType)
+		if report.LeafNodeType.Is(types.NodeTypeAfterSuite|types.NodeTypeSynchronizedAfterSuite) && r.parallelTotal > 1 {
+			denoter = fmt.Sprintf("[%s] on process #%d", report.LeafNodeType, report.ParallelProcess)
+		}
 	}
 
 	switch report.State {
@@ -139,7 +199,9 @@ func (r *DefaultReporter) DidRun(report types.SpecReport) {
 		highlightColor, succinctLocationBlock = "{{green}}", v.LT(types.VerbosityLevelVerbose)
 		emitGinkgoWriterOutput = (r.conf.AlwaysEmitGinkgoWriter || v.GTE(types.VerbosityLevelVerbose)) && hasGW
 		if report.LeafNodeType.Is(types.NodeTypesForSuiteLevelNodes) {
-			if v.GTE(types.VerbosityLevelVerbose) || hasStd || hasEmittableReports {
+			if report.NumAttempts > 1 {
+				header = fmt.Sprintf("%s PASSED [FLAKEY - TOOK %d ATTEMPTS TO PASS]", denoter, report.NumAttempts)
+			} else if v.GTE(types.VerbosityLevelVerbose) || hasStd || hasEmittableReports {
 				header = fmt.Sprintf("%s PASSED", denoter)
 			} else {
 				return
@@ -149,7 +211,7 @@ func (r *DefaultReporter) DidRun(report types.SpecReport) {
 			if report.NumAttempts > 1 {
 				header, stream = fmt.Sprintf("%s [FLAKEY TEST - TOOK %d ATTEMPTS TO PASS]", r.retryDenoter, report.NumAttempts), false
 			}
-			if report.RunTime > r.conf.SlowSpecThreshold {
+			if report.RunTime > r.slowSpecThresholdFor(report) {
 				header, stream = fmt.Sprintf("%s [SLOW TEST]", header), false
 			}
 		}
@@ -177,10 +239,16 @@ func (r *DefaultReporter) DidRun(report types.SpecReport) {
 		highlightColor, header = "{{magenta}}", fmt.Sprintf("%s! [PANICKED]", denoter)
 	case types.SpecStateInterrupted:
 		highlightColor, header = "{{orange}}", fmt.Sprintf("%s! [INTERRUPTED]", denoter)
+	case types.SpecStateTimedout:
+		highlightColor, header = "{{orange}}", fmt.Sprintf("%s [TIMEDOUT]", denoter)
 	case types.SpecStateAborted:
 		highlightColor, header = "{{coral}}", fmt.Sprintf("%s! [ABORTED]", denoter)
 	}
 
+	if report.FailureIgnored {
+		header += " [IGNORED - --after-suite-failure-policy=warn]"
+	}
+
 	// Emit stream and return
 	if stream {
 		r.emit(r.f(highlightColor + header + "{{/}}"))
@@ -189,6 +257,9 @@ func (r *DefaultReporter) DidRun(report types.SpecReport) {
 
 	// Emit header
 	r.emitDelimiter()
+	if report.OrderedContainerSteps > 0 && v.GTE(types.VerbosityLevelVerbose) {
+		header = fmt.Sprintf("%s [%d/%d]", header, report.OrderedContainerStep, report.OrderedContainerSteps)
+	}
 	if includeRuntime {
 		header = r.f("%s [%.3f seconds]", header, report.RunTime.Seconds())
 	}
@@ -216,10 +287,6 @@ func (r *DefaultReporter) DidRun(report types.SpecReport) {
 	if hasEmittableReports {
 		r.emitBlock("\n")
 		r.emitBlock(r.fi(1, "{{gray}}Begin Report Entries >>{{/}}"))
-		reportEntries := report.ReportEntries.WithVisibility(types.ReportEntryVisibilityAlways)
-		if !report.Failure.IsZero() || v.GTE(types.VerbosityLevelVerbose) {
-			reportEntries = report.ReportEntries.WithVisibility(types.ReportEntryVisibilityAlways, types.ReportEntryVisibilityFailureOrVerbose)
-		}
 		for _, entry := range reportEntries {
 			r.emitBlock(r.fi(2, "{{bold}}"+entry.Name+"{{gray}} - %s @ %s{{/}}", entry.Location, entry.Time.Format(types.GINKGO_TIME_FORMAT)))
 			if representation := entry.StringRepresentation(); representation != "" {
@@ -229,45 +296,300 @@ func (r *DefaultReporter) DidRun(report types.SpecReport) {
 		r.emitBlock(r.fi(1, "{{gray}}<< End Report Entries{{/}}"))
 	}
 
+	//Emit Attempt History
+	if len(report.Attempts) > 1 && v.GTE(types.VerbosityLevelVerbose) {
+		r.emitBlock("\n")
+		r.emitBlock(r.fi(1, "{{gray}}Begin Attempt History >>{{/}}"))
+		for _, attempt := range report.Attempts {
+			if attempt.Backoff > 0 {
+				r.emitBlock(r.fi(2, "{{bold}}Attempt #%d{{/}} [%s] {{gray}}[%.3f seconds] [after %s backoff]{{/}}", attempt.Attempt, strings.ToUpper(attempt.State.String()), attempt.RunTime.Seconds(), attempt.Backoff))
+			} else {
+				r.emitBlock(r.fi(2, "{{bold}}Attempt #%d{{/}} [%s] {{gray}}[%.3f seconds]{{/}}", attempt.Attempt, strings.ToUpper(attempt.State.String()), attempt.RunTime.Seconds()))
+			}
+			if !attempt.Failure.IsZero() {
+				r.emitBlock(r.fi(3, "%s", attempt.Failure.Message))
+				r.emitBlock(r.fi(3, "{{gray}}In [%s] at: %s{{/}}", attempt.Failure.FailureNodeType, attempt.Failure.Location))
+			}
+		}
+		r.emitBlock(r.fi(1, "{{gray}}<< End Attempt History{{/}}"))
+	}
+
+	//Emit Steps
+	if len(report.Steps) > 0 && (v.GTE(types.VerbosityLevelVerbose) || report.State.Is(types.SpecStateFailureStates)) {
+		r.emitBlock("\n")
+		r.emitBlock(r.fi(1, "{{gray}}Begin Steps >>{{/}}"))
+		for _, step := range report.Steps {
+			color := r.colorForStepState(step.State)
+			indentation := uint(2 + step.NestingLevel)
+			r.emitBlock(r.fi(indentation, color+"[%s]{{/}} %s {{gray}}[%.3f seconds]{{/}}", strings.ToUpper(step.State.String()), step.Text, step.RunTime.Seconds()))
+			if step.Message != "" {
+				r.emitBlock(r.fi(indentation+1, color+"%s{{/}}", step.Message))
+			}
+		}
+		r.emitBlock(r.fi(1, "{{gray}}<< End Steps{{/}}"))
+	}
+
 	// Emit Failure Message
 	if !report.Failure.IsZero() {
 		r.emitBlock("\n")
 		r.emitBlock(r.fi(1, highlightColor+"%s{{/}}", report.Failure.Message))
+		if report.Failure.Payload != nil && report.Failure.Payload.Representation != "" {
+			r.emitBlock(r.fi(1, "%s", report.Failure.Payload.Representation))
+		}
 		r.emitBlock(r.fi(1, highlightColor+"In {{bold}}[%s]{{/}}"+highlightColor+" at: {{bold}}%s{{/}}\n", report.Failure.FailureNodeType, report.Failure.Location))
+		if report.Failure.FailureNodeContext == types.FailureNodeIsLeafNode && report.Failure.FailureNodeLocation.Synthetic {
+			r.emitBlock(r.fi(1, highlightColor+"Generated from: {{bold}}%s{{/}}", report.Failure.FailureNodeLocation))
+		}
 		if report.Failure.ForwardedPanic != "" {
 			r.emitBlock("\n")
 			r.emitBlock(r.fi(1, highlightColor+"%s{{/}}", report.Failure.ForwardedPanic))
 		}
+		if report.RerunCommand != "" {
+			r.emitBlock(r.fi(1, "{{gray}}To rerun this spec:{{/}} %s\n", report.RerunCommand))
+		}
 
-		if r.conf.FullTrace || report.Failure.ForwardedPanic != "" {
+		if r.conf.FullTrace || report.Failure.ForwardedPanic != "" || r.emitsFullTraceFor(report.State) {
 			r.emitBlock("\n")
 			r.emitBlock(r.fi(1, highlightColor+"Full Stack Trace{{/}}"))
-			r.emitBlock(r.fi(2, "%s", report.Failure.Location.FullStackTrace))
+			r.emitBlock(r.fi(2, "%s", r.stackTrace(report.Failure.Location.FullStackTrace)))
+		}
+	}
+
+	// Emit Environment Degradations
+	if len(report.EnvironmentDegradations) > 0 {
+		r.emitBlock("\n")
+		r.emitBlock(r.fi(1, "{{orange}}The environment was degraded while this spec ran:{{/}}"))
+		for _, degradation := range report.EnvironmentDegradations {
+			r.emitBlock(r.fi(2, "{{bold}}%s{{/}}: %s", degradation.HealthCheck, degradation.Message))
+		}
+	}
+
+	// Emit Additional Failures
+	if len(report.AdditionalFailures) > 0 {
+		r.emitBlock("\n")
+		r.emitBlock(r.fi(1, "{{gray}}There were %d additional failures detected after the initial failure:{{/}}", len(report.AdditionalFailures)))
+		for _, additionalFailure := range report.AdditionalFailures {
+			color, heading := r.highlightForState(additionalFailure.State)
+			r.emitBlock(r.fi(2, color+"%s{{/}}", heading))
+			r.emitBlock(r.fi(2, color+"%s{{/}}", additionalFailure.Failure.Message))
+			if additionalFailure.Failure.ForwardedPanic != "" {
+				r.emitBlock(r.fi(2, color+"%s{{/}}", additionalFailure.Failure.ForwardedPanic))
+			}
+			r.emitBlock(r.fi(2, color+"In {{bold}}[%s]{{/}}"+color+" at: {{bold}}%s{{/}}", additionalFailure.Failure.FailureNodeType, additionalFailure.Failure.Location))
+		}
+	}
+
+	// Emit Failure Artifacts and Network Captures
+	if len(report.FailureArtifacts) > 0 {
+		r.emitBlock("\n")
+		r.emitBlock(r.fi(1, "{{gray}}Failure artifacts:{{/}}"))
+		for _, path := range report.FailureArtifacts {
+			r.emitBlock(r.fi(2, "%s", path))
+		}
+	}
+	if len(report.NetworkCaptures) > 0 {
+		r.emitBlock("\n")
+		r.emitBlock(r.fi(1, "{{gray}}Network captures:{{/}}"))
+		for _, path := range report.NetworkCaptures {
+			r.emitBlock(r.fi(2, "%s", path))
 		}
 	}
 
 	r.emitDelimiter()
 }
 
+// EmitProgressReport emits a ProgressReport for a spec that has been running for longer than its PollProgressAfter threshold
+func (r *DefaultReporter) EmitProgressReport(report types.ProgressReport) {
+	if r.conf.Verbosity().Is(types.VerbosityLevelQuiet) {
+		return
+	}
+	r.emitDelimiter()
+	header := r.f("{{coral}}{{bold}}Progress Report{{/}}")
+	if r.parallelTotal > 1 {
+		header += r.f(" for Ginkgo Process #{{bold}}%d{{/}}", report.ParallelProcess)
+	}
+	r.emitBlock(header)
+
+	texts := append([]string{}, report.ContainerHierarchyTexts...)
+	if report.LeafNodeText != "" {
+		texts = append(texts, report.LeafNodeText)
+	}
+	if len(texts) > 0 {
+		if report.OrderedContainerSteps > 0 {
+			r.emitBlock(r.fi(1, "%s {{gray}}(Ordered Container Step %d/%d, Spec Runtime: %s){{/}}", r.cycleJoin(texts, " "), report.OrderedContainerStep, report.OrderedContainerSteps, report.Time.Sub(report.SpecStartTime).Round(time.Millisecond)))
+		} else {
+			r.emitBlock(r.fi(1, "%s {{gray}}(Spec Runtime: %s){{/}}", r.cycleJoin(texts, " "), report.Time.Sub(report.SpecStartTime).Round(time.Millisecond)))
+		}
+		r.emitBlock(r.fi(2, "{{gray}}%s{{/}}", report.LeafNodeLocation))
+	}
+	node := r.f("In {{bold}}[%s]{{/}}", report.CurrentNodeType)
+	if report.CurrentNodeText != "" {
+		node += " " + report.CurrentNodeText
+	}
+	r.emitBlock(r.fi(1, "%s {{gray}}(Node Runtime: %s){{/}}", node, report.Time.Sub(report.CurrentNodeStartTime).Round(time.Millisecond)))
+	r.emitBlock(r.fi(2, "{{gray}}%s{{/}}", report.CurrentNodeLocation))
+	if report.Message != "" {
+		r.emitBlock(r.fi(1, "%s", report.Message))
+	}
+
+	if report.NodeGoroutineStack != "" {
+		r.emitBlock("\n")
+		r.emitBlock(r.fi(1, "{{bold}}Goroutine running the node:{{/}}"))
+		r.emitBlock(r.fi(2, "%s", report.NodeGoroutineStack))
+	}
+	r.emitDelimiter()
+}
+
+// emitOrderedContainerSummary tracks the specs of the Ordered container the passed-in spec belongs to and, once its last spec has completed, closes the container's block with the number of specs that ran and their elapsed time
+func (r *DefaultReporter) emitOrderedContainerSummary(report types.SpecReport) {
+	if r.orderedContainers == nil {
+		r.orderedContainers = map[int]orderedContainerProgress{}
+	}
+	if report.OrderedContainerStep == 1 {
+		r.orderedContainers[report.ParallelProcess] = orderedContainerProgress{}
+	}
+	progress := r.orderedContainers[report.ParallelProcess]
+	if !report.State.Is(types.SpecStateSkipped | types.SpecStatePending) {
+		progress.numRan += 1
+	}
+	if !report.StartTime.IsZero() && (progress.startTime.IsZero() || report.StartTime.Before(progress.startTime)) {
+		progress.startTime = report.StartTime
+	}
+	if report.EndTime.After(progress.endTime) {
+		progress.endTime = report.EndTime
+	}
+	r.orderedContainers[report.ParallelProcess] = progress
+	if report.OrderedContainerStep != report.OrderedContainerSteps {
+		return
+	}
+	delete(r.orderedContainers, report.ParallelProcess)
+	runTime := time.Duration(0)
+	if !progress.startTime.IsZero() {
+		runTime = progress.endTime.Sub(progress.startTime)
+	}
+	r.emitBlock(r.f("{{bold}}[Ordered]{{/}} %s {{gray}}- ran %d of %d specs in %.3f seconds{{/}}", r.cycleJoin(report.ContainerHierarchyTexts, " "), progress.numRan, report.OrderedContainerSteps, runTime.Seconds()))
+	r.emitDelimiter()
+}
+
+// verbosityFor returns the verbosity to use when emitting the passed-in spec - specs decorated with VerboseOutput are emitted as though -v had been set (unless --quiet is set)
+func (r *DefaultReporter) verbosityFor(report types.SpecReport) types.VerbosityLevel {
+	v := r.conf.Verbosity()
+	if report.VerboseOutput && v.GT(types.VerbosityLevelQuiet) && v.LT(types.VerbosityLevelVerbose) {
+		return types.VerbosityLevelVerbose
+	}
+	return v
+}
+
+// slowSpecThresholdFor returns the spec's SlowSpecThreshold decoration, if set, and --slow-spec-threshold otherwise
+func (r *DefaultReporter) slowSpecThresholdFor(report types.SpecReport) time.Duration {
+	if report.SlowSpecThreshold > 0 {
+		return report.SlowSpecThreshold
+	}
+	return r.conf.SlowSpecThreshold
+}
+
+func (r *DefaultReporter) highlightForState(state types.SpecState) (string, string) {
+	switch state {
+	case types.SpecStatePanicked:
+		return "{{magenta}}", "[PANICKED!]"
+	case types.SpecStateAborted:
+		return "{{coral}}", "[ABORTED]"
+	case types.SpecStateInterrupted:
+		return "{{orange}}", "[INTERRUPTED]"
+	case types.SpecStateTimedout:
+		return "{{orange}}", "[TIMEDOUT]"
+	}
+	return "{{red}}", "[FAIL]"
+}
+
+func (r *DefaultReporter) colorForStepState(state types.SpecState) string {
+	switch state {
+	case types.SpecStatePassed:
+		return "{{green}}"
+	case types.SpecStateSkipped:
+		return "{{cyan}}"
+	}
+	color, _ := r.highlightForState(state)
+	return color
+}
+
 func (r *DefaultReporter) SuiteDidEnd(report types.Report) {
 	failures := report.SpecReports.WithState(types.SpecStateFailureStates)
-	if len(failures) > 1 {
+	quiet := r.conf.Verbosity().Is(types.VerbosityLevelQuiet)
+	if quiet {
+		r.emitFailureDigest(failures)
+	} else if len(failures) > 1 {
 		r.emitBlock("\n\n")
 		r.emitBlock(r.f("{{red}}{{bold}}Summarizing %d Failures:{{/}}", len(failures)))
 		for _, specReport := range failures {
-			highlightColor, heading := "{{red}}", "[FAIL]"
-			switch specReport.State {
-			case types.SpecStatePanicked:
-				highlightColor, heading = "{{magenta}}", "[PANICKED!]"
-			case types.SpecStateAborted:
-				highlightColor, heading = "{{coral}}", "[ABORTED]"
-			case types.SpecStateInterrupted:
-				highlightColor, heading = "{{orange}}", "[INTERRUPTED]"
-			}
+			highlightColor, heading := r.highlightForState(specReport.State)
 			locationBlock := r.codeLocationBlock(specReport, highlightColor, true, true)
 			r.emitBlock(r.fi(1, highlightColor+"%s{{/}} %s", heading, locationBlock))
 		}
 	}
+	r.emitFailureGroups(failures)
+
+	if numWarnings := report.SpecReports.CountOfWarnings(); numWarnings > 0 && !quiet {
+		r.emitBlock("\n")
+		r.emitBlock(r.f("{{orange}}{{bold}}Summarizing %d Warnings:{{/}}", numWarnings))
+		for _, specReport := range report.SpecReports {
+			text := specReport.FullText()
+			if text == "" {
+				text = fmt.Sprintf("[%s]", specReport.LeafNodeType)
+			}
+			for _, warning := range specReport.Warnings {
+				r.emitBlock(r.fi(1, "{{orange}}[WARNING]{{/}} %s {{gray}}%s{{/}}", text, warning.Location))
+				r.emitBlock(r.fi(2, "%s", warning.Message))
+			}
+		}
+	}
+
+	if r.conf.LabelSummary {
+		r.emitLabelSummary(report.SpecReports.SummarizeByLabel())
+	}
+
+	if report.SuiteSucceeded && report.SuiteHasProgrammaticFocus && report.SuiteConfig.ProgrammaticFocusBehavior() == types.FocusExitCodeWarn {
+		r.emitBlock("\n")
+		r.emitBlock(r.f("{{orange}}{{bold}}Detected Programmatic Focus{{/}}{{orange}} - the suite will not fail because --focus-exit-code=warn is set{{/}}"))
+	}
+
+	if len(report.UnmatchedFilters) > 0 {
+		color := "{{orange}}"
+		if !report.SuiteSucceeded {
+			color = "{{red}}"
+		}
+		r.emitBlock("\n")
+		r.emitBlock(r.f(color + "{{bold}}Some filters did not match any specs:{{/}}"))
+		for _, filter := range report.UnmatchedFilters {
+			r.emitBlock(r.fi(1, color+"%s{{/}}", filter))
+		}
+	}
+
+	if len(report.PreflightFailures) > 0 {
+		r.emitBlock("\n")
+		r.emitBlock(r.f("{{red}}{{bold}}Environment not ready - some preflight checks failed:{{/}}"))
+		for _, failure := range report.PreflightFailures {
+			r.emitBlock(r.fi(1, "{{red}}%s{{/}}: %s {{gray}}%s{{/}}", failure.Check, failure.Message, failure.Location))
+		}
+	}
+
+	if len(report.UnmetRequirements) > 0 {
+		r.emitBlock("\n")
+		r.emitBlock(r.f("{{orange}}{{bold}}Some requirements were not met - the specs that need them were skipped:{{/}}"))
+		for _, requirement := range report.UnmetRequirements {
+			r.emitBlock(r.fi(1, "{{orange}}%s{{/}}: %s", requirement.Requirement, requirement.Reason))
+		}
+	}
+
+	if notRun := report.SpecReports.CountNotRunDueToTimeBox(); notRun > 0 {
+		r.emitBlock("\n")
+		r.emitBlock(r.f("{{orange}}{{bold}}The %s time box was exhausted - %d specs were not run{{/}}", report.SuiteConfig.TimeBox, notRun))
+	}
+
+	if report.GoTestCacheable && r.conf.Verbosity().GTE(types.VerbosityLevelVerbose) {
+		r.emitBlock("\n")
+		r.emitBlock(r.f("{{gray}}go test may cache these results and replay them without re-running the suite.  Pass -count=1 to go test, or use ginkgo, to guarantee fresh execution.{{/}}"))
+	}
 
 	//summarize the suite
 	if r.conf.Verbosity().Is(types.VerbosityLevelSuccinct) && report.SuiteSucceeded {
@@ -288,16 +610,24 @@ func (r *DefaultReporter) SuiteDidEnd(report types.Report) {
 		report.RunTime.Seconds()),
 	)
 
-	switch len(report.SpecialSuiteFailureReasons) {
+	reasons := report.SpecialSuiteFailureReasons
+	if report.SuiteSkipReason != "" {
+		reasons = append([]string{"Suite skipped: " + report.SuiteSkipReason}, reasons...)
+	}
+	switch len(reasons) {
 	case 0:
 		r.emit(r.f(color+"%s{{/}} -- ", status))
 	case 1:
-		r.emit(r.f(color+"%s - %s{{/}} -- ", status, report.SpecialSuiteFailureReasons[0]))
+		r.emit(r.f(color+"%s - %s{{/}} -- ", status, reasons[0]))
 	default:
-		r.emitBlock(r.f(color+"%s - %s{{/}}\n", status, strings.Join(report.SpecialSuiteFailureReasons, ", ")))
+		r.emitBlock(r.f(color+"%s - %s{{/}}\n", status, strings.Join(reasons, ", ")))
 	}
 
-	if len(specs) == 0 && report.SpecReports.WithLeafNodeType(types.NodeTypeBeforeSuite|types.NodeTypeSynchronizedBeforeSuite).CountWithState(types.SpecStateFailureStates) > 0 {
+	if len(specs) == 0 && len(report.PreflightFailures) > 0 {
+		r.emit(r.f("{{cyan}}{{bold}}The environment was not ready so all tests were skipped.{{/}}\n"))
+	} else if len(specs) == 0 && report.SpecReports.WithLeafNodeType(types.NodeTypeReadinessGate).CountWithState(types.SpecStateFailureStates) > 0 {
+		r.emit(r.f("{{cyan}}{{bold}}A ReadinessGate was not satisfied so all tests were skipped.{{/}}\n"))
+	} else if len(specs) == 0 && report.SpecReports.WithLeafNodeType(types.NodeTypeBeforeSuite|types.NodeTypeSynchronizedBeforeSuite).CountWithState(types.SpecStateFailureStates) > 0 {
 		r.emit(r.f("{{cyan}}{{bold}}A BeforeSuite node failed so all tests were skipped.{{/}}\n"))
 	} else {
 		r.emit(r.f("{{green}}{{bold}}%d Passed{{/}} | ", specs.CountWithState(types.SpecStatePassed)))
@@ -310,8 +640,98 @@ func (r *DefaultReporter) SuiteDidEnd(report types.Report) {
 	}
 }
 
+// emitFailureDigest emits one compact block per failure - used by --quiet in lieu of the output Ginkgo usually emits as each spec fails
+func (r *DefaultReporter) emitFailureDigest(failures types.SpecReports) {
+	if len(failures) == 0 {
+		return
+	}
+	r.emitBlock(r.f("{{red}}{{bold}}%d Failures:{{/}}", len(failures)))
+	for _, specReport := range failures {
+		highlightColor, heading := r.highlightForState(specReport.State)
+		text := specReport.FullText()
+		if text == "" {
+			text = fmt.Sprintf("[%s]", specReport.LeafNodeType)
+		}
+		location := specReport.Failure.Location
+		if location.FileName == "" {
+			location = specReport.LeafNodeLocation
+		}
+		r.emitBlock(r.f(highlightColor+"%s{{/}} %s", heading, text))
+		r.emitBlock(r.fi(1, "%s", digestReason(specReport.Failure)))
+		r.emitBlock(r.fi(1, "{{gray}}%s{{/}}", location))
+		if specReport.RerunCommand != "" {
+			r.emitBlock(r.fi(1, "{{gray}}%s{{/}}", specReport.RerunCommand))
+		}
+	}
+}
+
+// emitFailureGroups emits the failures that multiple specs share - so that, say, a broken dependency that fails hundreds of specs in the same way is easy to spot
+func (r *DefaultReporter) emitFailureGroups(failures types.SpecReports) {
+	groups := []types.FailureGroup{}
+	for _, group := range failures.GroupByFailureFingerprint() {
+		if len(group.SpecReports) > 1 {
+			groups = append(groups, group)
+		}
+	}
+	if len(groups) == 0 {
+		return
+	}
+	r.emitBlock("\n")
+	r.emitBlock(r.f("{{red}}{{bold}}Identical Failures:{{/}}"))
+	for _, group := range groups {
+		failure := group.SpecReports[0].Failure
+		r.emitBlock(r.fi(1, "{{red}}{{bold}}%d{{/}}{{red}} specs failed with fingerprint {{bold}}%s{{/}}", len(group.SpecReports), group.Fingerprint))
+		r.emitBlock(r.fi(2, "%s", digestReason(failure)))
+		r.emitBlock(r.fi(2, "{{gray}}%s{{/}}", failure.Location))
+	}
+}
+
+// digestReason returns the first line of the failure message, truncated to keep the digest compact
+func digestReason(failure types.Failure) string {
+	reason := strings.TrimSpace(failure.Message)
+	if reason == "" {
+		reason = strings.TrimSpace(failure.ForwardedPanic)
+	}
+	if idx := strings.Index(reason, "\n"); idx > -1 {
+		reason = strings.TrimSpace(reason[:idx]) + " ..."
+	}
+	if len(reason) > maxDigestReasonLength {
+		reason = reason[:maxDigestReasonLength] + "..."
+	}
+	return reason
+}
+
+func (r *DefaultReporter) emitLabelSummary(summaries []types.LabelSummary) {
+	if len(summaries) == 0 {
+		return
+	}
+	labels, width := []string{}, 0
+	for _, summary := range summaries {
+		label := summary.Label
+		if label == "" {
+			label = "(no labels)"
+		}
+		labels = append(labels, label)
+		if len(label) > width {
+			width = len(label)
+		}
+	}
+	r.emitBlock("\n\n")
+	r.emitBlock(r.f("{{bold}}Summarizing Results by Label:{{/}}"))
+	for i, summary := range summaries {
+		r.emitBlock(r.fi(1, "{{coral}}%-*s{{/}} {{green}}%d Passed{{/}} | {{red}}%d Failed{{/}} | {{yellow}}%d Pending{{/}} | {{cyan}}%d Skipped{{/}} {{gray}}[%.3f seconds]{{/}}",
+			width, labels[i], summary.Passed, summary.Failed, summary.Pending, summary.Skipped, summary.RunTime.Seconds()))
+	}
+}
+
 /* Emitting to the writer */
 func (r *DefaultReporter) emit(s string) {
+	if r.conf.NoCursorControl {
+		s = formatter.StripCursorControl(s)
+	}
+	if r.conf.ASCII {
+		s = formatter.ASCII(s)
+	}
 	if len(s) > 0 {
 		r.lastChar = s[len(s)-1:]
 		r.lastEmissionWasDelimiter = false
@@ -352,6 +772,51 @@ func (r *DefaultReporter) cycleJoin(elements []string, joiner string) string {
 	return r.formatter.CycleJoin(elements, joiner, []string{"{{/}}", "{{gray}}"})
 }
 
+func (r *DefaultReporter) emitsFullTraceFor(state types.SpecState) bool {
+	for _, s := range r.conf.FullTraceOn {
+		if s == state.String() {
+			return true
+		}
+	}
+	return false
+}
+
+// stackTrace applies the configured pruning, depth, and argument settings to a stack trace generated by types.PruneStack
+func (r *DefaultReporter) stackTrace(fullStackTrace string) string {
+	if len(r.stackTracePrune) == 0 && r.conf.StackTraceDepth <= 0 && !r.conf.StackTraceOmitArgs {
+		return fullStackTrace
+	}
+	lines := strings.Split(fullStackTrace, "\n")
+	out, elided := []string{}, 0
+	for i := 0; i+1 < len(lines); i += 2 {
+		function, location := lines[i], lines[i+1]
+		pruned := false
+		for _, re := range r.stackTracePrune {
+			if re.MatchString(location) {
+				pruned = true
+				break
+			}
+		}
+		if pruned {
+			continue
+		}
+		if r.conf.StackTraceDepth > 0 && len(out)/2 >= r.conf.StackTraceDepth {
+			elided += 1
+			continue
+		}
+		if r.conf.StackTraceOmitArgs {
+			if idx := strings.LastIndex(function, "("); idx > 0 && idx < len(function)-2 && strings.HasSuffix(function, ")") {
+				function = function[:idx] + "(...)"
+			}
+		}
+		out = append(out, function, location)
+	}
+	if elided > 0 {
+		out = append(out, fmt.Sprintf("...%d more frames elided (see --stack-trace-depth)", elided))
+	}
+	return strings.Join(out, "\n")
+}
+
 func (r *DefaultReporter) codeLocationBlock(report types.SpecReport, highlightColor string, succinct bool, usePreciseFailureLocation bool) string {
 	texts, locations, labels := []string{}, []types.CodeLocation{}, [][]string{}
 	texts, locations, labels = append(texts, report.ContainerHierarchyTexts...), append(locations, report.ContainerHierarchyLocations...), append(labels, report.ContainerHierarchyLabels...)
@@ -364,7 +829,7 @@ func (r *DefaultReporter) codeLocationBlock(report types.SpecReport, highlightCo
 	locations = append(locations, report.LeafNodeLocation)
 
 	failureLocation := report.Failure.FailureNodeLocation
-	if usePreciseFailureLocation {
+	if usePreciseFailureLocation && !(report.Failure.FailureNodeContext == types.FailureNodeIsLeafNode && failureLocation.Synthetic) {
 		failureLocation = report.Failure.Location
 	}
 
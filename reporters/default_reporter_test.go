@@ -25,6 +25,7 @@ var cl1 = types.CodeLocation{FileName: "cl1.go", LineNumber: 37, FullStackTrace:
 var cl2 = types.CodeLocation{FileName: "cl2.go", LineNumber: 80, FullStackTrace: "full-trace\ncl-2"}
 var cl3 = types.CodeLocation{FileName: "cl3.go", LineNumber: 103, FullStackTrace: "full-trace\ncl-3"}
 var cl4 = types.CodeLocation{FileName: "cl4.go", LineNumber: 144, FullStackTrace: "full-trace\ncl-4"}
+var clSynthetic = types.NewSyntheticCodeLocation("testdata/cases.csv", 17)
 
 func CLS(cls ...types.CodeLocation) []types.CodeLocation { return cls }
 func CTS(componentTexts ...string) []string              { return componentTexts }
@@ -55,6 +56,8 @@ func F(options ...interface{}) types.Failure {
 			failure.FailureNodeLocation = types.CodeLocation(option.(FailureNodeLocation))
 		case reflect.TypeOf(types.NodeTypeIt):
 			failure.FailureNodeType = option.(types.NodeType)
+		case reflect.TypeOf(&types.FailurePayload{}):
+			failure.Payload = option.(*types.FailurePayload)
 		}
 	}
 	return failure
@@ -62,6 +65,11 @@ func F(options ...interface{}) types.Failure {
 
 type STD string
 type GW string
+type VerboseSpec bool
+type SlowThreshold time.Duration
+type Artifacts []string
+type Rerun string
+type Fingerprint string
 
 // convenience helper to quickly make summaries
 func S(options ...interface{}) types.SpecReport {
@@ -104,6 +112,26 @@ func S(options ...interface{}) types.SpecReport {
 			report.CapturedGinkgoWriterOutput = string(option.(GW))
 		case reflect.TypeOf(types.ReportEntry{}):
 			report.ReportEntries = append(report.ReportEntries, option.(types.ReportEntry))
+		case reflect.TypeOf(types.SpecAttempt{}):
+			report.Attempts = append(report.Attempts, option.(types.SpecAttempt))
+		case reflect.TypeOf(types.AdditionalFailure{}):
+			report.AdditionalFailures = append(report.AdditionalFailures, option.(types.AdditionalFailure))
+		case reflect.TypeOf(types.ResourceRequirements{}):
+			report.ResourceRequirements = option.(types.ResourceRequirements)
+		case reflect.TypeOf(VerboseSpec(false)):
+			report.VerboseOutput = bool(option.(VerboseSpec))
+		case reflect.TypeOf(SlowThreshold(0)):
+			report.SlowSpecThreshold = time.Duration(option.(SlowThreshold))
+		case reflect.TypeOf(Artifacts{}):
+			report.FailureArtifacts = []string(option.(Artifacts))
+		case reflect.TypeOf(Rerun("")):
+			report.RerunCommand = string(option.(Rerun))
+		case reflect.TypeOf(Fingerprint("")):
+			report.FailureFingerprint = string(option.(Fingerprint))
+		case reflect.TypeOf(types.SpecStep{}):
+			report.Steps = append(report.Steps, option.(types.SpecStep))
+		case reflect.TypeOf(types.EnvironmentDegradation{}):
+			report.EnvironmentDegradations = append(report.EnvironmentDegradations, option.(types.EnvironmentDegradation))
 		}
 	}
 	if len(report.ContainerHierarchyLabels) == 0 {
@@ -120,7 +148,7 @@ func RE(name string, cl types.CodeLocation, args ...interface{}) types.ReportEnt
 	return entry
 }
 
-type ConfigFlags uint8
+type ConfigFlags uint16
 
 const (
 	Succinct ConfigFlags = 1 << iota
@@ -128,6 +156,10 @@ const (
 	VeryVerbose
 	ReportPassed
 	FullTrace
+	LabelSummary
+	Quiet
+	ASCII
+	NoCursorControl
 )
 
 func (cf ConfigFlags) Has(flag ConfigFlags) bool { return cf&flag != 0 }
@@ -142,16 +174,26 @@ func C(flags ...ConfigFlags) types.ReporterConfig {
 	Ω(f.Has(VeryVerbose) && f.Has(Verbose)).Should(BeFalse(), "Setting more than one of Succinct, Verbose, or VeryVerbose is a configuration error")
 	return types.ReporterConfig{
 		NoColor:                true,
-		SlowSpecThreshold:      SlowSpecThreshold,
+		SlowSpecThreshold:      TestSlowSpecThreshold,
 		Succinct:               f.Has(Succinct),
 		Verbose:                f.Has(Verbose),
 		VeryVerbose:            f.Has(VeryVerbose),
 		AlwaysEmitGinkgoWriter: f.Has(ReportPassed),
 		FullTrace:              f.Has(FullTrace),
+		LabelSummary:           f.Has(LabelSummary),
+		Quiet:                  f.Has(Quiet),
+		ASCII:                  f.Has(ASCII),
+		NoCursorControl:        f.Has(NoCursorControl),
 	}
 }
 
-const SlowSpecThreshold = 3 * time.Second
+func CRE(conf types.ReporterConfig, visibilities []string, skip ...string) types.ReporterConfig {
+	conf.ConsoleReportEntryVisibilities = visibilities
+	conf.ConsoleReportEntrySkip = skip
+	return conf
+}
+
+const TestSlowSpecThreshold = 3 * time.Second
 
 var _ = Describe("DefaultReporter", func() {
 	var DENOTER = "•"
@@ -249,6 +291,34 @@ var _ = Describe("DefaultReporter", func() {
 			"Running in parallel across {{bold}}3{{/}} processes",
 			"",
 		),
+		Entry("when configured to run in chaos mode",
+			C(),
+			types.Report{
+				SuiteDescription: "My Suite", SuitePath: "/path/to/suite", PreRunStats: types.PreRunStats{SpecsThatWillRun: 15, TotalSpecs: 20},
+				SuiteConfig: types.SuiteConfig{RandomSeed: 17, ParallelTotal: 1, Chaos: true, ChaosMaxDelay: 100 * time.Millisecond, ChaosFailureRate: 0.05},
+			},
+			"Running Suite: My Suite - /path/to/suite",
+			"========================================",
+			"Random Seed: {{bold}}17{{/}}",
+			"",
+			"Will run {{bold}}15{{/}} of {{bold}}20{{/}} specs",
+			"{{magenta}}Running in chaos mode{{/}} - max delay: {{bold}}100ms{{/}}, failure rate: {{bold}}0.05{{/}}",
+			"",
+		),
+		Entry("when sampling table entries",
+			C(),
+			types.Report{
+				SuiteDescription: "My Suite", SuitePath: "/path/to/suite", PreRunStats: types.PreRunStats{SpecsThatWillRun: 15, TotalSpecs: 20},
+				SuiteConfig: types.SuiteConfig{RandomSeed: 17, ParallelTotal: 1, TableSample: 0.25},
+			},
+			"Running Suite: My Suite - /path/to/suite",
+			"========================================",
+			"Random Seed: {{bold}}17{{/}}",
+			"",
+			"Will run {{bold}}15{{/}} of {{bold}}20{{/}} specs",
+			"Sampling {{bold}}25%{{/}} of each table's entries - table sample seed: {{bold}}17{{/}}",
+			"",
+		),
 		Entry("when succinct and in series",
 			C(Succinct),
 			types.Report{
@@ -274,6 +344,13 @@ var _ = Describe("DefaultReporter", func() {
 			},
 			"[17] {{bold}}My Suite{{/}} {{coral}}[dog, fish]{{/}} - 15/20 specs - 3 procs ",
 		),
+		Entry("when quiet",
+			C(Quiet),
+			types.Report{
+				SuiteDescription: "My Suite", SuitePath: "/path/to/suite", PreRunStats: types.PreRunStats{SpecsThatWillRun: 15, TotalSpecs: 20},
+				SuiteConfig: types.SuiteConfig{RandomSeed: 17, ParallelTotal: 1},
+			},
+		),
 	)
 
 	DescribeTable("WillRun",
@@ -283,6 +360,7 @@ var _ = Describe("DefaultReporter", func() {
 			verifyExpectedOutput(output)
 		},
 		Entry("when not verbose, it emits nothing", C(), S(CTS("A"), CLS(cl0))),
+		Entry("when quiet, it emits nothing - even for specs decorated with VerboseOutput", C(Quiet), S(CTS("A"), CLS(cl0), VerboseSpec(true))),
 		Entry("pending specs are not emitted", C(Verbose), S(types.SpecStatePending)),
 		Entry("skipped specs are not emitted", C(Verbose), S(types.SpecStateSkipped)),
 		Entry("setup nodes", C(Verbose),
@@ -299,6 +377,13 @@ var _ = Describe("DefaultReporter", func() {
 			"{{gray}}"+cl0.String()+"{{/}}",
 			"",
 		),
+		Entry("specs decorated with VerboseOutput, when not verbose", C(),
+			S("My Test", cl0, VerboseSpec(true)),
+			DELIMITER,
+			"{{bold}}My Test{{/}}",
+			"{{gray}}"+cl0.String()+"{{/}}",
+			"",
+		),
 		Entry("top-level it nodes", C(Verbose),
 			S("My Test", cl0),
 			DELIMITER,
@@ -322,6 +407,13 @@ var _ = Describe("DefaultReporter", func() {
 			"  {{gray}}"+cl2.String()+"{{/}}",
 			"",
 		),
+		Entry("specs with resource requirements", C(Verbose),
+			S("My Test", cl0, Label("gpu"), types.ResourceRequirements{CPU: 4, MemoryBytes: 8 << 30, GPU: 1}),
+			DELIMITER,
+			"{{bold}}My Test{{/}} {{coral}}[gpu]{{/}} {{gray}}[requires cpu=4, memory=8Gi, gpu=1]{{/}}",
+			"{{gray}}"+cl0.String()+"{{/}}",
+			"",
+		),
 	)
 
 	DescribeTable("DidRun",
@@ -330,6 +422,7 @@ var _ = Describe("DefaultReporter", func() {
 			reporter.DidRun(report)
 			verifyExpectedOutput(output)
 		},
+		Entry("when quiet, failing tests emit nothing", C(Quiet), S(CTS("A"), CLS(cl0), "The Test", cl1, types.SpecStateFailed, F("boom", cl1), GW("gw"), STD("std"))),
 		// Passing Tests
 		Entry("a passing test",
 			C(),
@@ -346,6 +439,64 @@ var _ = Describe("DefaultReporter", func() {
 			DELIMITER,
 			"",
 		),
+		Entry("a passing test that was retried, with Verbose configured",
+			C(Verbose),
+			S(CTS("A"), "B", CLS(cl0), cl1, 2,
+				types.SpecAttempt{Attempt: 1, State: types.SpecStateFailed, RunTime: time.Second, Failure: F("failure\nmessage", cl2, types.NodeTypeIt)},
+				types.SpecAttempt{Attempt: 2, State: types.SpecStatePassed, RunTime: 2 * time.Second},
+			),
+			DELIMITER,
+			"{{green}}"+RETRY_DENOTER+" [FLAKEY TEST - TOOK 2 ATTEMPTS TO PASS] [1.000 seconds]{{/}}",
+			"A",
+			"{{gray}}"+cl0.String()+"{{/}}",
+			"  B",
+			"  {{gray}}"+cl1.String()+"{{/}}",
+			"",
+			"  {{gray}}Begin Attempt History >>{{/}}",
+			"    {{bold}}Attempt #1{{/}} [FAILED] {{gray}}[1.000 seconds]{{/}}",
+			"      failure",
+			"      message",
+			"      {{gray}}In [It] at: "+cl2.String()+"{{/}}",
+			"    {{bold}}Attempt #2{{/}} [PASSED] {{gray}}[2.000 seconds]{{/}}",
+			"  {{gray}}<< End Attempt History{{/}}",
+			DELIMITER,
+			"",
+		),
+		Entry("a passing test that was retried with a backoff, with Verbose configured",
+			C(Verbose),
+			S(CTS("A"), "B", CLS(cl0), cl1, 2,
+				types.SpecAttempt{Attempt: 1, State: types.SpecStateFailed, RunTime: time.Second, Failure: F("failure", cl2, types.NodeTypeIt)},
+				types.SpecAttempt{Attempt: 2, State: types.SpecStatePassed, Backoff: 500 * time.Millisecond, RunTime: 2 * time.Second},
+			),
+			DELIMITER,
+			"{{green}}"+RETRY_DENOTER+" [FLAKEY TEST - TOOK 2 ATTEMPTS TO PASS] [1.000 seconds]{{/}}",
+			"A",
+			"{{gray}}"+cl0.String()+"{{/}}",
+			"  B",
+			"  {{gray}}"+cl1.String()+"{{/}}",
+			"",
+			"  {{gray}}Begin Attempt History >>{{/}}",
+			"    {{bold}}Attempt #1{{/}} [FAILED] {{gray}}[1.000 seconds]{{/}}",
+			"      failure",
+			"      {{gray}}In [It] at: "+cl2.String()+"{{/}}",
+			"    {{bold}}Attempt #2{{/}} [PASSED] {{gray}}[2.000 seconds] [after 500ms backoff]{{/}}",
+			"  {{gray}}<< End Attempt History{{/}}",
+			DELIMITER,
+			"",
+		),
+		Entry("a passing test that was retried does not emit its attempt history when not verbose",
+			C(),
+			S(CTS("A"), "B", CLS(cl0), cl1, 2,
+				types.SpecAttempt{Attempt: 1, State: types.SpecStateFailed, RunTime: time.Second, Failure: F("failure", cl2, types.NodeTypeIt)},
+				types.SpecAttempt{Attempt: 2, State: types.SpecStatePassed, RunTime: 2 * time.Second},
+			),
+			DELIMITER,
+			"{{green}}"+RETRY_DENOTER+" [FLAKEY TEST - TOOK 2 ATTEMPTS TO PASS] [1.000 seconds]{{/}}",
+			"{{/}}A {{gray}}B{{/}}",
+			"{{gray}}"+cl1.String()+"{{/}}",
+			DELIMITER,
+			"",
+		),
 		Entry("a passing test that has ginkgo writer output and/or non-visible report entries",
 			C(),
 			S("A", cl0, GW("GINKGO-WRITER-OUTPUT"), RE("fail-report-name", cl1, types.ReportEntryVisibilityFailureOrVerbose), RE("hidden-report-name", cl2, types.ReportEntryVisibilityNever)),
@@ -385,6 +536,40 @@ var _ = Describe("DefaultReporter", func() {
 			DELIMITER,
 			"",
 		),
+		Entry("a passing test decorated with VerboseOutput",
+			C(),
+			S("A", cl0, VerboseSpec(true), GW("GINKGO-WRITER-OUTPUT\nSHOULD EMIT"), RE("failure-or-verbose-report-name", cl1, types.ReportEntryVisibilityFailureOrVerbose), RE("hidden-report-name", cl2, types.ReportEntryVisibilityNever)),
+			DELIMITER,
+			"{{green}}"+DENOTER+" [1.000 seconds]{{/}}",
+			"A",
+			"{{gray}}"+cl0.String()+"{{/}}",
+			"",
+			"  {{gray}}Begin Captured GinkgoWriter Output >>{{/}}",
+			"    GINKGO-WRITER-OUTPUT",
+			"    SHOULD EMIT",
+			"  {{gray}}<< End Captured GinkgoWriter Output{{/}}",
+			"",
+			"  {{gray}}Begin Report Entries >>{{/}}",
+			"    {{bold}}failure-or-verbose-report-name{{gray}} - "+cl1.String()+" @ "+FORMATTED_TIME+"{{/}}",
+			"  {{gray}}<< End Report Entries{{/}}",
+			DELIMITER,
+			"",
+		),
+		Entry("a passing test that exceeds its SlowSpecThreshold decoration",
+			C(),
+			S(CTS("A"), "B", CLS(cl0), cl1, 2*time.Second, SlowThreshold(time.Second)),
+			DELIMITER,
+			"{{green}}"+DENOTER+" [SLOW TEST] [2.000 seconds]{{/}}",
+			"{{/}}A {{gray}}B{{/}}",
+			"{{gray}}"+cl1.String()+"{{/}}",
+			DELIMITER,
+			"",
+		),
+		Entry("a passing test that is within its SlowSpecThreshold decoration",
+			C(),
+			S(CTS("A"), "B", CLS(cl0), cl1, time.Minute, SlowThreshold(2*time.Minute)),
+			"{{green}}"+DENOTER+"{{/}}",
+		),
 		Entry("a slow passing test",
 			C(),
 			S(CTS("A"), "B", CLS(cl0), cl1, time.Minute, GW("GINKGO-WRITER-OUTPUT")),
@@ -410,6 +595,40 @@ var _ = Describe("DefaultReporter", func() {
 			DELIMITER,
 			"",
 		),
+		Entry("a passing test with ASCII configured",
+			C(ASCII),
+			S("A", cl0),
+			"{{green}}+{{/}}",
+		),
+		Entry("a passing test with non-ASCII text and captured stdout, with ASCII configured",
+			C(ASCII),
+			S(CTS("Café"), "launches 🚀", CLS(cl0), cl1, STD("done ✓")),
+			DELIMITER,
+			"{{green}}+ [1.000 seconds]{{/}}",
+			"{{/}}Caf\\u00e9 {{gray}}launches \\U0001f680{{/}}",
+			"{{gray}}"+cl1.String()+"{{/}}",
+			"",
+			"  {{gray}}Begin Captured StdOut/StdErr Output >>{{/}}",
+			"    done v",
+			"  {{gray}}<< End Captured StdOut/StdErr Output{{/}}",
+			DELIMITER,
+			"",
+		),
+		Entry("a passing test with captured stdout that moves the cursor, with NoCursorControl configured",
+			C(NoCursorControl),
+			S(CTS("A"), "B", CLS(cl0), cl1, STD("10%\r\x1b[2K100%\n\x1b[1m\x1b[?25ldone\x1b[?25h\x1b[0m")),
+			DELIMITER,
+			"{{green}}"+DENOTER+" [1.000 seconds]{{/}}",
+			"{{/}}A {{gray}}B{{/}}",
+			"{{gray}}"+cl1.String()+"{{/}}",
+			"",
+			"  {{gray}}Begin Captured StdOut/StdErr Output >>{{/}}",
+			"    10%100%",
+			"    \x1b[1mdone\x1b[0m",
+			"  {{gray}}<< End Captured StdOut/StdErr Output{{/}}",
+			DELIMITER,
+			"",
+		),
 		Entry("a passing test with a ReportEntry that is always visible",
 			C(),
 			S(CTS("A"), "B", CLS(cl0), cl1, GW("GINKGO-WRITER-OUTPUT"), RE("report-name", cl2, "report-content"), RE("other-report-name", cl3), RE("fail-report-name", cl4, types.ReportEntryVisibilityFailureOrVerbose)),
@@ -426,6 +645,40 @@ var _ = Describe("DefaultReporter", func() {
 			DELIMITER,
 			"",
 		),
+		Entry("a passing test whose visible ReportEntries are all skipped by name",
+			CRE(C(), nil, "^report-", "other"),
+			S(CTS("A"), "B", CLS(cl0), cl1, GW("GINKGO-WRITER-OUTPUT"), RE("report-name", cl2, "report-content"), RE("other-report-name", cl3)),
+			"{{green}}"+DENOTER+"{{/}}",
+		),
+		Entry("a passing test with ReportEntries filtered by name",
+			CRE(C(), nil, "^other"),
+			S(CTS("A"), "B", CLS(cl0), cl1, RE("report-name", cl2, "report-content"), RE("other-report-name", cl3)),
+			DELIMITER,
+			"{{green}}"+DENOTER+" [1.000 seconds]{{/}}",
+			"{{/}}A {{gray}}B{{/}}",
+			"{{gray}}"+cl1.String()+"{{/}}",
+			"",
+			"  {{gray}}Begin Report Entries >>{{/}}",
+			"    {{bold}}report-name{{gray}} - "+cl2.String()+" @ "+FORMATTED_TIME+"{{/}}",
+			"      report-content",
+			"  {{gray}}<< End Report Entries{{/}}",
+			DELIMITER,
+			"",
+		),
+		Entry("a passing test with ReportEntries filtered by visibility",
+			CRE(C(Verbose), []string{"failure-or-verbose"}),
+			S("A", cl0, RE("report-name", cl1), RE("fail-report-name", cl2, types.ReportEntryVisibilityFailureOrVerbose)),
+			DELIMITER,
+			"{{green}}"+DENOTER+" [1.000 seconds]{{/}}",
+			"A",
+			"{{gray}}"+cl0.String()+"{{/}}",
+			"",
+			"  {{gray}}Begin Report Entries >>{{/}}",
+			"    {{bold}}fail-report-name{{gray}} - "+cl2.String()+" @ "+FORMATTED_TIME+"{{/}}",
+			"  {{gray}}<< End Report Entries{{/}}",
+			DELIMITER,
+			"",
+		),
 		Entry("a passing suite setup emits nothing",
 			C(),
 			S(types.NodeTypeBeforeSuite, cl0, GW("GINKGO-WRITER-OUTPUT")),
@@ -695,6 +948,169 @@ var _ = Describe("DefaultReporter", func() {
 			"",
 		),
 		//Failed tests
+		Entry("when a test with a synthetic code location has failed in an It",
+			C(),
+			S(CTS("Describe A"), "The Test", CLS(cl0), clSynthetic,
+				types.SpecStateFailed, 1,
+				F("FAILURE MESSAGE", types.FailureNodeIsLeafNode, types.NodeTypeIt, FailureNodeLocation(clSynthetic), cl3),
+			),
+			DELIMITER,
+			"{{red}}"+DENOTER+" [FAILED] [1.000 seconds]{{/}}",
+			"Describe A",
+			"{{gray}}"+cl0.String()+"{{/}}",
+			"  {{red}}{{bold}}[It] The Test{{/}}",
+			"  {{gray}}testdata/cases.csv:17{{/}}",
+			"",
+			"  {{red}}FAILURE MESSAGE{{/}}",
+			"  {{red}}In {{bold}}[It]{{/}}{{red}} at: {{bold}}"+cl3.String()+"{{/}}",
+			"  {{red}}Generated from: {{bold}}testdata/cases.csv:17{{/}}",
+			DELIMITER,
+			"",
+		),
+		Entry("when a test has failed and subsequent cleanup nodes have also failed",
+			C(),
+			S(CTS("Describe A"), "The Test", CLS(cl0), cl1, types.SpecStateFailed,
+				F("FAILURE MESSAGE", types.FailureNodeIsLeafNode, types.NodeTypeIt, FailureNodeLocation(cl1), cl2),
+				types.AdditionalFailure{State: types.SpecStateFailed, Failure: F("AFTER EACH FAILURE", types.FailureNodeInContainer, types.NodeTypeAfterEach, FailureNodeLocation(cl3), cl3)},
+				types.AdditionalFailure{State: types.SpecStatePanicked, Failure: F("Test Panicked", ForwardedPanic("boom"), types.FailureNodeAtTopLevel, types.NodeTypeCleanupAfterEach, FailureNodeLocation(cl4), cl4)},
+			),
+			DELIMITER,
+			"{{red}}"+DENOTER+" [FAILED] [1.000 seconds]{{/}}",
+			"Describe A",
+			"{{gray}}"+cl0.String()+"{{/}}",
+			"  {{red}}{{bold}}[It] The Test{{/}}",
+			"  {{gray}}"+cl1.String()+"{{/}}",
+			"",
+			"  {{red}}FAILURE MESSAGE{{/}}",
+			"  {{red}}In {{bold}}[It]{{/}}{{red}} at: {{bold}}"+cl2.String()+"{{/}}",
+			"",
+			"  {{gray}}There were 2 additional failures detected after the initial failure:{{/}}",
+			"    {{red}}[FAIL]{{/}}",
+			"    {{red}}AFTER EACH FAILURE{{/}}",
+			"    {{red}}In {{bold}}[AfterEach]{{/}}{{red}} at: {{bold}}"+cl3.String()+"{{/}}",
+			"    {{magenta}}[PANICKED!]{{/}}",
+			"    {{magenta}}Test Panicked{{/}}",
+			"    {{magenta}}boom{{/}}",
+			"    {{magenta}}In {{bold}}[DeferCleanup]{{/}}{{magenta}} at: {{bold}}"+cl4.String()+"{{/}}",
+			DELIMITER,
+			"",
+		),
+		Entry("when a test has failed and failure artifacts were collected",
+			C(),
+			S(CTS("Describe A"), "The Test", CLS(cl0), cl1, types.SpecStateFailed,
+				F("FAILURE MESSAGE", types.FailureNodeIsLeafNode, types.NodeTypeIt, FailureNodeLocation(cl1), cl2),
+				Artifacts{"/artifacts/The_Test/screenshot.png", "/artifacts/The_Test/page.html"},
+			),
+			DELIMITER,
+			"{{red}}"+DENOTER+" [FAILED] [1.000 seconds]{{/}}",
+			"Describe A",
+			"{{gray}}"+cl0.String()+"{{/}}",
+			"  {{red}}{{bold}}[It] The Test{{/}}",
+			"  {{gray}}"+cl1.String()+"{{/}}",
+			"",
+			"  {{red}}FAILURE MESSAGE{{/}}",
+			"  {{red}}In {{bold}}[It]{{/}}{{red}} at: {{bold}}"+cl2.String()+"{{/}}",
+			"",
+			"  {{gray}}Failure artifacts:{{/}}",
+			"    /artifacts/The_Test/screenshot.png",
+			"    /artifacts/The_Test/page.html",
+			DELIMITER,
+			"",
+		),
+		Entry("when a test has failed while the environment was degraded",
+			C(),
+			S(CTS("Describe A"), "The Test", CLS(cl0), cl1, types.SpecStateFailed,
+				F("FAILURE MESSAGE", types.FailureNodeIsLeafNode, types.NodeTypeIt, FailureNodeLocation(cl1), cl2),
+				types.EnvironmentDegradation{HealthCheck: "postgres", Message: "connection refused"},
+				types.EnvironmentDegradation{HealthCheck: "cluster", Message: "timed out"},
+			),
+			DELIMITER,
+			"{{red}}"+DENOTER+" [FAILED] [1.000 seconds]{{/}}",
+			"Describe A",
+			"{{gray}}"+cl0.String()+"{{/}}",
+			"  {{red}}{{bold}}[It] The Test{{/}}",
+			"  {{gray}}"+cl1.String()+"{{/}}",
+			"",
+			"  {{red}}FAILURE MESSAGE{{/}}",
+			"  {{red}}In {{bold}}[It]{{/}}{{red}} at: {{bold}}"+cl2.String()+"{{/}}",
+			"",
+			"  {{orange}}The environment was degraded while this spec ran:{{/}}",
+			"    {{bold}}postgres{{/}}: connection refused",
+			"    {{bold}}cluster{{/}}: timed out",
+			DELIMITER,
+			"",
+		),
+		Entry("when a test has failed and has steps",
+			C(),
+			S(CTS("Describe A"), "The Test", CLS(cl0), cl1, types.SpecStateFailed,
+				F("FAILURE MESSAGE", types.FailureNodeIsLeafNode, types.NodeTypeIt, FailureNodeLocation(cl1), cl2),
+				types.SpecStep{Text: "log in", State: types.SpecStatePassed, RunTime: time.Second},
+				types.SpecStep{Text: "check out", State: types.SpecStateFailed, RunTime: 2 * time.Second, Message: "FAILURE MESSAGE"},
+				types.SpecStep{Text: "confirm", NestingLevel: 1, State: types.SpecStateFailed, RunTime: time.Second, Message: "FAILURE MESSAGE"},
+			),
+			DELIMITER,
+			"{{red}}"+DENOTER+" [FAILED] [1.000 seconds]{{/}}",
+			"Describe A",
+			"{{gray}}"+cl0.String()+"{{/}}",
+			"  {{red}}{{bold}}[It] The Test{{/}}",
+			"  {{gray}}"+cl1.String()+"{{/}}",
+			"",
+			"  {{gray}}Begin Steps >>{{/}}",
+			"    {{green}}[PASSED]{{/}} log in {{gray}}[1.000 seconds]{{/}}",
+			"    {{red}}[FAILED]{{/}} check out {{gray}}[2.000 seconds]{{/}}",
+			"      {{red}}FAILURE MESSAGE{{/}}",
+			"      {{red}}[FAILED]{{/}} confirm {{gray}}[1.000 seconds]{{/}}",
+			"        {{red}}FAILURE MESSAGE{{/}}",
+			"  {{gray}}<< End Steps{{/}}",
+			"",
+			"  {{red}}FAILURE MESSAGE{{/}}",
+			"  {{red}}In {{bold}}[It]{{/}}{{red}} at: {{bold}}"+cl2.String()+"{{/}}",
+			DELIMITER,
+			"",
+		),
+		Entry("when a test passes and has steps, but is not verbose",
+			C(),
+			S(CTS("Describe A"), "The Test", CLS(cl0), cl1, types.SpecStep{Text: "log in", State: types.SpecStatePassed, RunTime: time.Second}),
+			"{{green}}"+DENOTER+"{{/}}",
+		),
+		Entry("when a test has failed and has a rerun command",
+			C(),
+			S(CTS("Describe A"), "The Test", CLS(cl0), cl1, types.SpecStateFailed,
+				F("FAILURE MESSAGE", types.FailureNodeIsLeafNode, types.NodeTypeIt, FailureNodeLocation(cl1), cl2),
+				Rerun("ginkgo --seed=17 --focus-file=cl1.go:37 /path/to/suite"),
+			),
+			DELIMITER,
+			"{{red}}"+DENOTER+" [FAILED] [1.000 seconds]{{/}}",
+			"Describe A",
+			"{{gray}}"+cl0.String()+"{{/}}",
+			"  {{red}}{{bold}}[It] The Test{{/}}",
+			"  {{gray}}"+cl1.String()+"{{/}}",
+			"",
+			"  {{red}}FAILURE MESSAGE{{/}}",
+			"  {{red}}In {{bold}}[It]{{/}}{{red}} at: {{bold}}"+cl2.String()+"{{/}}",
+			"  {{gray}}To rerun this spec:{{/}} ginkgo --seed=17 --focus-file=cl1.go:37 /path/to/suite",
+			DELIMITER,
+			"",
+		),
+		Entry("when a test has failed with a payload",
+			C(),
+			S(CTS("Describe A"), "The Test", CLS(cl0), cl1, types.SpecStateFailed,
+				F("FAILURE MESSAGE", types.FailureNodeIsLeafNode, types.NodeTypeIt, FailureNodeLocation(cl1), cl2, &types.FailurePayload{Type: "diff", AsJSON: `{"a":1}`, Representation: "{{green}}- a: 2{{/}}\n{{red}}+ a: 1{{/}}"}),
+			),
+			DELIMITER,
+			"{{red}}"+DENOTER+" [FAILED] [1.000 seconds]{{/}}",
+			"Describe A",
+			"{{gray}}"+cl0.String()+"{{/}}",
+			"  {{red}}{{bold}}[It] The Test{{/}}",
+			"  {{gray}}"+cl1.String()+"{{/}}",
+			"",
+			"  {{red}}FAILURE MESSAGE{{/}}",
+			"  {{green}}- a: 2{{/}}",
+			"  {{red}}+ a: 1{{/}}",
+			"  {{red}}In {{bold}}[It]{{/}}{{red}} at: {{bold}}"+cl2.String()+"{{/}}",
+			DELIMITER,
+			"",
+		),
 		Entry("when a test has failed in an It",
 			C(),
 			S(CTS("Describe A", "Context B"), "The Test", CLS(cl0, cl1), cl2, CLabels(Label("dog", "cat"), Label("cat", "cow")),
@@ -1027,6 +1443,39 @@ var _ = Describe("DefaultReporter", func() {
 			"",
 		),
 
+		Entry("when a test times out",
+			C(),
+			S(CTS("Describe A", "Context B"), "The Test", CLS(cl0, cl1), cl2,
+				types.SpecStateTimedout, 2,
+				GW("GW-OUTPUT\nIS EMITTED"), STD("STD-OUTPUT\nIS EMITTED"),
+				F("FAILURE MESSAGE\nWITH DETAILS", types.FailureNodeInContainer, FailureNodeLocation(cl3), types.NodeTypeJustBeforeEach, 1, cl4),
+			),
+			DELIMITER,
+			"{{orange}}"+DENOTER+" [TIMEDOUT] [1.000 seconds]{{/}}",
+			"Describe A",
+			"{{gray}}"+cl0.String()+"{{/}}",
+			"  {{orange}}{{bold}}Context B [JustBeforeEach]{{/}}",
+			"  {{gray}}"+cl3.String()+"{{/}}",
+			"    The Test",
+			"    {{gray}}"+cl2.String()+"{{/}}",
+			"",
+			"  {{gray}}Begin Captured StdOut/StdErr Output >>{{/}}",
+			"    STD-OUTPUT",
+			"    IS EMITTED",
+			"  {{gray}}<< End Captured StdOut/StdErr Output{{/}}",
+			"",
+			"  {{gray}}Begin Captured GinkgoWriter Output >>{{/}}",
+			"    GW-OUTPUT",
+			"    IS EMITTED",
+			"  {{gray}}<< End Captured GinkgoWriter Output{{/}}",
+			"",
+			"  {{orange}}FAILURE MESSAGE",
+			"  WITH DETAILS{{/}}",
+			"  {{orange}}In {{bold}}[JustBeforeEach]{{/}}{{orange}} at: {{bold}}"+cl4.String()+"{{/}}",
+			DELIMITER,
+			"",
+		),
+
 		Entry("when a test is aborted",
 			C(),
 			S(CTS("Describe A", "Context B"), "The Test", CLS(cl0, cl1), cl2,
@@ -1060,6 +1509,263 @@ var _ = Describe("DefaultReporter", func() {
 			"",
 		))
 
+	Describe("Rendering AfterSuite outcomes", func() {
+		var reporter *reporters.DefaultReporter
+		BeforeEach(func() {
+			reporter = reporters.NewDefaultReporterUnderTest(C(), buf)
+		})
+
+		It("names the process the AfterSuite ran on when running in parallel", func() {
+			reporter.SuiteWillBegin(types.Report{SuiteConfig: types.SuiteConfig{ParallelTotal: 3}})
+			report := S(types.NodeTypeAfterSuite, cl0, types.SpecStateFailed, F("boom", types.FailureNodeIsLeafNode, FailureNodeLocation(cl0), types.NodeTypeAfterSuite, cl1))
+			report.ParallelProcess = 2
+			reporter.DidRun(report)
+			Ω(string(buf.Contents())).Should(ContainSubstring("{{red}}[AfterSuite] on process #2 [FAILED] [1.000 seconds]{{/}}"))
+		})
+
+		It("does not name the process when running in series", func() {
+			reporter.SuiteWillBegin(types.Report{SuiteConfig: types.SuiteConfig{ParallelTotal: 1}})
+			reporter.DidRun(S(types.NodeTypeAfterSuite, cl0, types.SpecStateFailed, F("boom", types.FailureNodeIsLeafNode, FailureNodeLocation(cl0), types.NodeTypeAfterSuite, cl1)))
+			Ω(string(buf.Contents())).Should(ContainSubstring("{{red}}[AfterSuite] [FAILED] [1.000 seconds]{{/}}"))
+		})
+
+		It("marks failures that were ignored because of --after-suite-failure-policy=warn", func() {
+			report := S(types.NodeTypeAfterSuite, cl0, types.SpecStateFailed, F("boom", types.FailureNodeIsLeafNode, FailureNodeLocation(cl0), types.NodeTypeAfterSuite, cl1))
+			report.FailureIgnored = true
+			reporter.DidRun(report)
+			Ω(string(buf.Contents())).Should(ContainSubstring("{{red}}[AfterSuite] [FAILED] [IGNORED - --after-suite-failure-policy=warn] [1.000 seconds]{{/}}"))
+		})
+
+		It("emits AfterSuites that took multiple attempts to pass", func() {
+			reporter.DidRun(S(types.NodeTypeAfterSuite, cl0, 2))
+			Ω(string(buf.Contents())).Should(ContainSubstring("{{green}}[AfterSuite] PASSED [FLAKEY - TOOK 2 ATTEMPTS TO PASS] [1.000 seconds]{{/}}"))
+		})
+	})
+
+	Describe("Rendering progress reports", func() {
+		var report types.ProgressReport
+		BeforeEach(func() {
+			now := time.Now()
+			report = types.ProgressReport{
+				ParallelProcess:         2,
+				ContainerHierarchyTexts: []string{"Container"},
+				LeafNodeText:            "A",
+				LeafNodeLocation:        cl0,
+				SpecStartTime:           now.Add(-5 * time.Second),
+				CurrentNodeType:         types.NodeTypeIt,
+				CurrentNodeText:         "A",
+				CurrentNodeLocation:     cl1,
+				CurrentNodeStartTime:    now.Add(-3 * time.Second),
+				Time:                    now,
+				NodeGoroutineStack:      "goroutine 17 [sleep]:\ntime.Sleep(0x3b9aca00)",
+			}
+		})
+
+		It("identifies the spec and the running node and includes the node's goroutine stack", func() {
+			reporter := reporters.NewDefaultReporterUnderTest(C(), buf)
+			reporter.EmitProgressReport(report)
+			verifyExpectedOutput([]string{
+				DELIMITER,
+				"{{coral}}{{bold}}Progress Report{{/}}",
+				"  {{/}}Container {{gray}}A{{/}} {{gray}}(Spec Runtime: 5s){{/}}",
+				"    {{gray}}" + cl0.String() + "{{/}}",
+				"  In {{bold}}[It]{{/}} A {{gray}}(Node Runtime: 3s){{/}}",
+				"    {{gray}}" + cl1.String() + "{{/}}",
+				"",
+				"  {{bold}}Goroutine running the node:{{/}}",
+				"    goroutine 17 [sleep]:",
+				"    time.Sleep(0x3b9aca00)",
+				DELIMITER,
+				"",
+			})
+		})
+
+		It("names the process when running in parallel", func() {
+			reporter := reporters.NewDefaultReporterUnderTest(C(), buf)
+			reporter.SuiteWillBegin(types.Report{SuiteConfig: types.SuiteConfig{ParallelTotal: 3}})
+			reporter.EmitProgressReport(report)
+			Ω(string(buf.Contents())).Should(ContainSubstring("{{coral}}{{bold}}Progress Report{{/}} for Ginkgo Process #{{bold}}2{{/}}"))
+		})
+
+		It("emits nothing when quiet", func() {
+			reporter := reporters.NewDefaultReporterUnderTest(C(Quiet), buf)
+			reporter.EmitProgressReport(report)
+			verifyExpectedOutput([]string{})
+		})
+
+		It("includes the message when there is one", func() {
+			report.Message = "ReadinessGate not yet satisfied (attempt 1, 0s elapsed): connection refused"
+			reporter := reporters.NewDefaultReporterUnderTest(C(), buf)
+			reporter.EmitProgressReport(report)
+			Ω(string(buf.Contents())).Should(ContainSubstring("    {{gray}}" + cl1.String() + "{{/}}\n  ReadinessGate not yet satisfied (attempt 1, 0s elapsed): connection refused\n"))
+		})
+
+		It("includes the spec's step when it is in an Ordered container", func() {
+			report.OrderedContainerStep, report.OrderedContainerSteps = 3, 7
+			reporter := reporters.NewDefaultReporterUnderTest(C(), buf)
+			reporter.EmitProgressReport(report)
+			Ω(string(buf.Contents())).Should(ContainSubstring("  {{/}}Container {{gray}}A{{/}} {{gray}}(Ordered Container Step 3/7, Spec Runtime: 5s){{/}}"))
+		})
+	})
+
+	Describe("Rendering Ordered containers", func() {
+		var reports []types.SpecReport
+		BeforeEach(func() {
+			start := time.Now()
+			reports = []types.SpecReport{}
+			for i, text := range []string{"first", "second"} {
+				report := S(CTS("Ordered Container"), CLS(cl0), text, cl1)
+				report.IsInOrderedContainer = true
+				report.OrderedContainerStep, report.OrderedContainerSteps = i+1, 2
+				report.StartTime = start.Add(time.Duration(i) * time.Second)
+				report.EndTime = report.StartTime.Add(time.Second)
+				reports = append(reports, report)
+			}
+		})
+
+		It("groups the container's specs, numbers each step, and reports the container's elapsed time when verbose", func() {
+			reporter := reporters.NewDefaultReporterUnderTest(C(Verbose), buf)
+			for _, report := range reports {
+				reporter.WillRun(report)
+				reporter.DidRun(report)
+			}
+			verifyExpectedOutput([]string{
+				DELIMITER,
+				"{{bold}}[Ordered]{{/}} {{/}}Ordered Container{{/}} {{gray}}- 2 specs{{/}}",
+				"{{/}}Ordered Container{{/}}",
+				"  {{gray}}[1/2]{{/}} {{bold}}first{{/}}",
+				"  {{gray}}" + cl1.String() + "{{/}}",
+				"{{green}}" + DENOTER + "{{/}}",
+				DELIMITER,
+				"{{/}}Ordered Container{{/}}",
+				"  {{gray}}[2/2]{{/}} {{bold}}second{{/}}",
+				"  {{gray}}" + cl1.String() + "{{/}}",
+				"{{green}}" + DENOTER + "{{/}}",
+				"{{bold}}[Ordered]{{/}} {{/}}Ordered Container{{/}} {{gray}}- ran 2 of 2 specs in 2.000 seconds{{/}}",
+				DELIMITER,
+				"",
+			})
+		})
+
+		It("only counts the specs that ran, and their runtime, when later specs are skipped", func() {
+			third := S(CTS("Ordered Container"), CLS(cl0), "third", cl1, types.SpecStateSkipped)
+			third.IsInOrderedContainer = true
+			third.OrderedContainerStep, third.OrderedContainerSteps = 3, 3
+			reports[0].OrderedContainerSteps, reports[1].OrderedContainerSteps = 3, 3
+			reports = append(reports, third)
+			reporter := reporters.NewDefaultReporterUnderTest(C(Verbose), buf)
+			for _, report := range reports {
+				reporter.WillRun(report)
+				reporter.DidRun(report)
+			}
+			Ω(string(buf.Contents())).Should(ContainSubstring("{{bold}}[Ordered]{{/}} {{/}}Ordered Container{{/}} {{gray}}- ran 2 of 3 specs in 2.000 seconds{{/}}"))
+		})
+
+		It("tracks the Ordered containers running on each process separately", func() {
+			reporter := reporters.NewDefaultReporterUnderTest(C(Verbose), buf)
+			other := reports[0]
+			other.ParallelProcess, other.StartTime = 2, reports[0].StartTime.Add(-time.Minute)
+			for _, report := range []types.SpecReport{reports[0], other, reports[1]} {
+				reporter.DidRun(report)
+			}
+			Ω(string(buf.Contents())).Should(ContainSubstring("- ran 2 of 2 specs in 2.000 seconds{{/}}"))
+		})
+
+		It("includes the step in the header of specs that are not streamed", func() {
+			reporter := reporters.NewDefaultReporterUnderTest(C(Verbose), buf)
+			reports[0].State, reports[0].Failure = types.SpecStateFailed, F("boom", cl1, types.NodeTypeIt)
+			reporter.DidRun(reports[0])
+			Ω(string(buf.Contents())).Should(ContainSubstring("{{red}}" + DENOTER + " [FAILED] [1/2] [1.000 seconds]{{/}}"))
+		})
+
+		It("renders the container's specs as usual when not verbose", func() {
+			reporter := reporters.NewDefaultReporterUnderTest(C(), buf)
+			for _, report := range reports {
+				reporter.WillRun(report)
+				reporter.DidRun(report)
+			}
+			verifyExpectedOutput([]string{"{{green}}" + DENOTER + "{{/}}{{green}}" + DENOTER + "{{/}}"})
+		})
+	})
+
+	Describe("Rendering full stack traces", func() {
+		var stackTrace = strings.Join([]string{
+			"github.com/foo/wrapper.Helper(0xc000123456, {0x1, 0x2})",
+			"\t/src/github.com/foo/wrapper/helper.go:17 +0x1d",
+			"github.com/foo/bar.glob..func1.1()",
+			"\t/src/github.com/foo/bar/bar_test.go:24 +0x3f",
+			"github.com/foo/bar.(*Baz).Run(0xc000654321)",
+			"\t/src/github.com/foo/bar/baz.go:89 +0x2a",
+		}, "\n")
+		var report types.SpecReport
+
+		BeforeEach(func() {
+			cl := types.CodeLocation{FileName: "bar_test.go", LineNumber: 24, FullStackTrace: stackTrace}
+			report = S("A", cl0, types.SpecStateFailed, F("FAILURE MESSAGE", cl, types.NodeTypeIt))
+		})
+
+		DescribeTable("applying the stack trace configuration",
+			func(modify func(*types.ReporterConfig), expected ...string) {
+				conf := C()
+				modify(&conf)
+				reporter := reporters.NewDefaultReporterUnderTest(conf, buf)
+				reporter.DidRun(report)
+				output := string(buf.Contents())
+				if len(expected) == 0 {
+					Ω(output).ShouldNot(ContainSubstring("Full Stack Trace"))
+				} else {
+					Ω(output).Should(HaveSuffix(strings.Join(append(expected, DELIMITER, ""), "\n")))
+				}
+			},
+			Entry("by default, no full trace is emitted", func(conf *types.ReporterConfig) {}),
+			Entry("when the state is configured with --trace-on", func(conf *types.ReporterConfig) {
+				conf.FullTraceOn = []string{"failed"}
+			},
+				"  {{red}}Full Stack Trace{{/}}",
+				"    github.com/foo/wrapper.Helper(0xc000123456, {0x1, 0x2})",
+				"    \t/src/github.com/foo/wrapper/helper.go:17 +0x1d",
+				"    github.com/foo/bar.glob..func1.1()",
+				"    \t/src/github.com/foo/bar/bar_test.go:24 +0x3f",
+				"    github.com/foo/bar.(*Baz).Run(0xc000654321)",
+				"    \t/src/github.com/foo/bar/baz.go:89 +0x2a",
+			),
+			Entry("when a different state is configured with --trace-on", func(conf *types.ReporterConfig) {
+				conf.FullTraceOn = []string{"panicked"}
+			}),
+			Entry("with --stack-trace-prune", func(conf *types.ReporterConfig) {
+				conf.FullTrace = true
+				conf.StackTracePrune = []string{`/foo/wrapper/`}
+			},
+				"  {{red}}Full Stack Trace{{/}}",
+				"    github.com/foo/bar.glob..func1.1()",
+				"    \t/src/github.com/foo/bar/bar_test.go:24 +0x3f",
+				"    github.com/foo/bar.(*Baz).Run(0xc000654321)",
+				"    \t/src/github.com/foo/bar/baz.go:89 +0x2a",
+			),
+			Entry("with --stack-trace-depth", func(conf *types.ReporterConfig) {
+				conf.FullTrace = true
+				conf.StackTraceDepth = 1
+			},
+				"  {{red}}Full Stack Trace{{/}}",
+				"    github.com/foo/wrapper.Helper(0xc000123456, {0x1, 0x2})",
+				"    \t/src/github.com/foo/wrapper/helper.go:17 +0x1d",
+				"    ...2 more frames elided (see --stack-trace-depth)",
+			),
+			Entry("with --stack-trace-omit-args", func(conf *types.ReporterConfig) {
+				conf.FullTrace = true
+				conf.StackTraceOmitArgs = true
+			},
+				"  {{red}}Full Stack Trace{{/}}",
+				"    github.com/foo/wrapper.Helper(...)",
+				"    \t/src/github.com/foo/wrapper/helper.go:17 +0x1d",
+				"    github.com/foo/bar.glob..func1.1()",
+				"    \t/src/github.com/foo/bar/bar_test.go:24 +0x3f",
+				"    github.com/foo/bar.(*Baz).Run(...)",
+				"    \t/src/github.com/foo/bar/baz.go:89 +0x2a",
+			),
+		)
+	})
+
 	DescribeTable("Rendering SuiteDidEnd",
 		func(conf types.ReporterConfig, report types.Report, expected ...string) {
 			reporter := reporters.NewDefaultReporterUnderTest(conf, buf)
@@ -1115,6 +1821,162 @@ var _ = Describe("DefaultReporter", func() {
 			"{{green}}{{bold}}SUCCESS!{{/}} -- {{green}}{{bold}}5 Passed{{/}} | {{red}}{{bold}}0 Failed{{/}} | {{light-yellow}}{{bold}}2 Flaked{{/}} | {{yellow}}{{bold}}2 Pending{{/}} | {{cyan}}{{bold}}3 Skipped{{/}}",
 			"",
 		),
+		Entry("the suite passes and a label summary is requested",
+			C(LabelSummary),
+			types.Report{
+				SuiteSucceeded: true,
+				PreRunStats:    types.PreRunStats{TotalSpecs: 6, SpecsThatWillRun: 5},
+				RunTime:        time.Minute,
+				SpecReports: types.SpecReports{
+					S(types.NodeTypeBeforeSuite, Label("cat")),
+					S(Label("cat"), types.SpecStatePassed),
+					S(CTS("Container"), CLabels(Label("dog")), Label("cat"), types.SpecStatePassed, 2*time.Second),
+					S(Label("dog"), types.SpecStatePending, time.Duration(0)),
+					S(types.SpecStateSkipped, time.Duration(0)),
+					S(types.SpecStatePassed),
+				},
+			},
+			"",
+			"",
+			"{{bold}}Summarizing Results by Label:{{/}}",
+			"  {{coral}}cat        {{/}} {{green}}2 Passed{{/}} | {{red}}0 Failed{{/}} | {{yellow}}0 Pending{{/}} | {{cyan}}0 Skipped{{/}} {{gray}}[3.000 seconds]{{/}}",
+			"  {{coral}}dog        {{/}} {{green}}1 Passed{{/}} | {{red}}0 Failed{{/}} | {{yellow}}1 Pending{{/}} | {{cyan}}0 Skipped{{/}} {{gray}}[2.000 seconds]{{/}}",
+			"  {{coral}}(no labels){{/}} {{green}}1 Passed{{/}} | {{red}}0 Failed{{/}} | {{yellow}}0 Pending{{/}} | {{cyan}}1 Skipped{{/}} {{gray}}[1.000 seconds]{{/}}",
+			"",
+			"{{green}}{{bold}}Ran 3 of 6 Specs in 60.000 seconds{{/}}",
+			"{{green}}{{bold}}SUCCESS!{{/}} -- {{green}}{{bold}}3 Passed{{/}} | {{red}}{{bold}}0 Failed{{/}} | {{yellow}}{{bold}}1 Pending{{/}} | {{cyan}}{{bold}}1 Skipped{{/}}",
+			"",
+		),
+		Entry("the suite passes with programmatic focus and --focus-exit-code=warn",
+			C(),
+			types.Report{
+				SuiteSucceeded:            true,
+				SuiteHasProgrammaticFocus: true,
+				SuiteConfig:               types.SuiteConfig{FocusExitCode: "warn"},
+				PreRunStats:               types.PreRunStats{TotalSpecs: 2, SpecsThatWillRun: 1},
+				RunTime:                   time.Minute,
+				SpecReports: types.SpecReports{
+					S(types.SpecStatePassed), S(types.SpecStateSkipped),
+				},
+			},
+			"",
+			"{{orange}}{{bold}}Detected Programmatic Focus{{/}}{{orange}} - the suite will not fail because --focus-exit-code=warn is set{{/}}",
+			"",
+			"{{green}}{{bold}}Ran 1 of 2 Specs in 60.000 seconds{{/}}",
+			"{{green}}{{bold}}SUCCESS!{{/}} -- {{green}}{{bold}}1 Passed{{/}} | {{red}}{{bold}}0 Failed{{/}} | {{yellow}}{{bold}}0 Pending{{/}} | {{cyan}}{{bold}}1 Skipped{{/}}",
+			"",
+		),
+		Entry("the suite passes with filters that match no specs and --validate-filters=warn",
+			C(),
+			types.Report{
+				SuiteSucceeded:   true,
+				UnmatchedFilters: []string{`--focus="chekout"`, `--label-filter="intergation"`},
+				PreRunStats:      types.PreRunStats{TotalSpecs: 2, SpecsThatWillRun: 1},
+				RunTime:          time.Minute,
+				SpecReports: types.SpecReports{
+					S(types.SpecStatePassed), S(types.SpecStateSkipped),
+				},
+			},
+			"",
+			"{{orange}}{{bold}}Some filters did not match any specs:{{/}}",
+			`  {{orange}}--focus="chekout"{{/}}`,
+			`  {{orange}}--label-filter="intergation"{{/}}`,
+			"",
+			"{{green}}{{bold}}Ran 1 of 2 Specs in 60.000 seconds{{/}}",
+			"{{green}}{{bold}}SUCCESS!{{/}} -- {{green}}{{bold}}1 Passed{{/}} | {{red}}{{bold}}0 Failed{{/}} | {{yellow}}{{bold}}0 Pending{{/}} | {{cyan}}{{bold}}1 Skipped{{/}}",
+			"",
+		),
+		Entry("the suite passes with unmet requirements",
+			C(),
+			types.Report{
+				SuiteSucceeded: true,
+				UnmetRequirements: types.UnmetRequirements{
+					{Requirement: "docker", Reason: "Cannot connect to the Docker daemon"},
+					{Requirement: "postgres>=14", Reason: "found postgres 12"},
+				},
+				PreRunStats: types.PreRunStats{TotalSpecs: 2, SpecsThatWillRun: 2},
+				RunTime:     time.Minute,
+				SpecReports: types.SpecReports{
+					S(types.SpecStatePassed), S(types.SpecStateSkipped),
+				},
+			},
+			"",
+			"{{orange}}{{bold}}Some requirements were not met - the specs that need them were skipped:{{/}}",
+			"  {{orange}}docker{{/}}: Cannot connect to the Docker daemon",
+			"  {{orange}}postgres>=14{{/}}: found postgres 12",
+			"",
+			"{{green}}{{bold}}Ran 1 of 2 Specs in 60.000 seconds{{/}}",
+			"{{green}}{{bold}}SUCCESS!{{/}} -- {{green}}{{bold}}1 Passed{{/}} | {{red}}{{bold}}0 Failed{{/}} | {{yellow}}{{bold}}0 Pending{{/}} | {{cyan}}{{bold}}1 Skipped{{/}}",
+			"",
+		),
+		Entry("the suite passes with specs that were not run due to the time box",
+			C(),
+			types.Report{
+				SuiteSucceeded: true,
+				SuiteConfig:    types.SuiteConfig{TimeBox: 10 * time.Minute},
+				PreRunStats:    types.PreRunStats{TotalSpecs: 3, SpecsThatWillRun: 3},
+				RunTime:        time.Minute,
+				SpecReports: types.SpecReports{
+					S(types.SpecStatePassed),
+					S(types.SpecStateSkipped, F(types.TimeBoxSkipMessage)),
+					S(types.SpecStateSkipped, F(types.TimeBoxSkipMessage)),
+				},
+			},
+			"",
+			"{{orange}}{{bold}}The 10m0s time box was exhausted - 2 specs were not run{{/}}",
+			"",
+			"{{green}}{{bold}}Ran 1 of 3 Specs in 60.000 seconds{{/}}",
+			"{{green}}{{bold}}SUCCESS!{{/}} -- {{green}}{{bold}}1 Passed{{/}} | {{red}}{{bold}}0 Failed{{/}} | {{yellow}}{{bold}}0 Pending{{/}} | {{cyan}}{{bold}}2 Skipped{{/}}",
+			"",
+		),
+		Entry("the suite passes with warnings",
+			C(),
+			types.Report{
+				SuiteSucceeded: true,
+				PreRunStats:    types.PreRunStats{TotalSpecs: 2, SpecsThatWillRun: 2},
+				RunTime:        time.Minute,
+				SpecReports: types.SpecReports{
+					types.SpecReport{LeafNodeType: types.NodeTypeBeforeSuite, State: types.SpecStatePassed, Warnings: []types.Warning{
+						{Message: "the database is running in degraded mode", Location: types.CodeLocation{FileName: "suite_test.go", LineNumber: 12}},
+					}},
+					types.SpecReport{ContainerHierarchyTexts: []string{"Container"}, LeafNodeText: "A", LeafNodeType: types.NodeTypeIt, State: types.SpecStatePassed, Warnings: []types.Warning{
+						{Message: "uses the legacy fixture", Location: types.CodeLocation{FileName: "a_test.go", LineNumber: 3}},
+						{Message: "uses the legacy client", Location: types.CodeLocation{FileName: "a_test.go", LineNumber: 4}},
+					}},
+					S(types.SpecStatePassed),
+				},
+			},
+			"",
+			"{{orange}}{{bold}}Summarizing 3 Warnings:{{/}}",
+			"  {{orange}}[WARNING]{{/}} [BeforeSuite] {{gray}}suite_test.go:12{{/}}",
+			"    the database is running in degraded mode",
+			"  {{orange}}[WARNING]{{/}} Container A {{gray}}a_test.go:3{{/}}",
+			"    uses the legacy fixture",
+			"  {{orange}}[WARNING]{{/}} Container A {{gray}}a_test.go:4{{/}}",
+			"    uses the legacy client",
+			"",
+			"{{green}}{{bold}}Ran 2 of 2 Specs in 60.000 seconds{{/}}",
+			"{{green}}{{bold}}SUCCESS!{{/}} -- {{green}}{{bold}}2 Passed{{/}} | {{red}}{{bold}}0 Failed{{/}} | {{yellow}}{{bold}}0 Pending{{/}} | {{cyan}}{{bold}}0 Skipped{{/}}",
+			"",
+		),
+		Entry("the suite passes but go test may cache the results",
+			C(Verbose),
+			types.Report{
+				SuiteSucceeded:  true,
+				GoTestCacheable: true,
+				PreRunStats:     types.PreRunStats{TotalSpecs: 1, SpecsThatWillRun: 1},
+				RunTime:         time.Minute,
+				SpecReports: types.SpecReports{
+					S(types.SpecStatePassed),
+				},
+			},
+			"",
+			"{{gray}}go test may cache these results and replay them without re-running the suite.  Pass -count=1 to go test, or use ginkgo, to guarantee fresh execution.{{/}}",
+			"",
+			"{{green}}{{bold}}Ran 1 of 1 Specs in 60.000 seconds{{/}}",
+			"{{green}}{{bold}}SUCCESS!{{/}} -- {{green}}{{bold}}1 Passed{{/}} | {{red}}{{bold}}0 Failed{{/}} | {{yellow}}{{bold}}0 Pending{{/}} | {{cyan}}{{bold}}0 Skipped{{/}}",
+			"",
+		),
 		Entry("the suite fails with one failed test",
 			C(),
 			types.Report{
@@ -1167,12 +2029,16 @@ var _ = Describe("DefaultReporter", func() {
 						types.SpecStateAborted, 2,
 						F("FAILURE MESSAGE\nWITH DETAILS", types.FailureNodeIsLeafNode, FailureNodeLocation(cl0), types.NodeTypeIt, cl1),
 					),
+					S("The Generated Test", clSynthetic,
+						types.SpecStateFailed, 1,
+						F("FAILURE MESSAGE", types.FailureNodeIsLeafNode, FailureNodeLocation(clSynthetic), types.NodeTypeIt, cl1),
+					),
 					S(types.NodeTypeAfterSuite),
 				},
 			},
 			"",
 			"",
-			"{{red}}{{bold}}Summarizing 4 Failures:{{/}}",
+			"{{red}}{{bold}}Summarizing 5 Failures:{{/}}",
 			"  {{red}}[FAIL]{{/}} {{/}}Describe A {{gray}}{{red}}{{bold}}Context B [JustBeforeEach]{{/}} {{/}}The Test{{/}} {{coral}}[cat, dog, fish, giraffe]{{/}}",
 			"  {{gray}}"+cl4.String()+"{{/}}",
 			"  {{magenta}}[PANICKED!]{{/}} {{/}}Describe A {{gray}}{{magenta}}{{bold}}[It] The Test{{/}}{{/}}",
@@ -1181,9 +2047,88 @@ var _ = Describe("DefaultReporter", func() {
 			"  {{gray}}"+cl1.String()+"{{/}}",
 			"  {{coral}}[ABORTED]{{/}} {{/}}{{coral}}{{bold}}[It] The Test{{/}}{{/}}",
 			"  {{gray}}"+cl1.String()+"{{/}}",
+			"  {{red}}[FAIL]{{/}} {{/}}{{red}}{{bold}}[It] The Generated Test{{/}}{{/}}",
+			"  {{gray}}testdata/cases.csv:17{{/}}",
+			"",
+			"{{red}}{{bold}}Ran 10 of 13 Specs in 60.000 seconds{{/}}",
+			"{{red}}{{bold}}FAIL!{{/}} -- {{green}}{{bold}}5 Passed{{/}} | {{red}}{{bold}}5 Failed{{/}} | {{light-yellow}}{{bold}}2 Flaked{{/}} | {{yellow}}{{bold}}2 Pending{{/}} | {{cyan}}{{bold}}3 Skipped{{/}}",
+			"",
+		),
+		Entry("the suite fails and is quiet",
+			C(Quiet),
+			types.Report{
+				SuiteSucceeded: false,
+				SuitePath:      "/path/to/suite",
+				PreRunStats:    types.PreRunStats{TotalSpecs: 5, SpecsThatWillRun: 5},
+				RunTime:        time.Minute,
+				SpecReports: types.SpecReports{
+					S(types.NodeTypeBeforeSuite),
+					S(types.SpecStatePassed), S(types.SpecStatePassed),
+					S(CTS("Describe A", "Context B"), "The Test", CLS(cl0, cl1), cl2,
+						types.SpecStateFailed, 2,
+						F("FAILURE MESSAGE\nWITH DETAILS", types.FailureNodeInContainer, FailureNodeLocation(cl3), types.NodeTypeJustBeforeEach, 1, cl4),
+						Rerun("ginkgo --seed=17 --focus-file=cl2.go:80 /path/to/suite"),
+					),
+					S(CTS("Describe A"), "The Test", CLS(cl0), cl1,
+						types.SpecStatePanicked,
+						F("Test Panicked", types.FailureNodeIsLeafNode, FailureNodeLocation(cl1), types.NodeTypeIt, cl2, ForwardedPanic("boom")),
+						Rerun("ginkgo --seed=17 --focus-file=cl1.go:37 /path/to/suite"),
+					),
+					S(types.NodeTypeAfterSuite, cl3, types.SpecStateFailed,
+						F(strings.Repeat("x", 250), types.FailureNodeIsLeafNode, FailureNodeLocation(cl3), types.NodeTypeAfterSuite, cl4),
+						Rerun("ginkgo --seed=17 /path/to/suite"),
+					),
+				},
+				SpecialSuiteFailureReasons: []string{},
+			},
+			"{{red}}{{bold}}3 Failures:{{/}}",
+			"{{red}}[FAIL]{{/}} Describe A Context B The Test",
+			"  FAILURE MESSAGE ...",
+			"  {{gray}}"+cl4.String()+"{{/}}",
+			"  {{gray}}ginkgo --seed=17 --focus-file=cl2.go:80 /path/to/suite{{/}}",
+			"{{magenta}}[PANICKED!]{{/}} Describe A The Test",
+			"  Test Panicked",
+			"  {{gray}}"+cl2.String()+"{{/}}",
+			"  {{gray}}ginkgo --seed=17 --focus-file=cl1.go:37 /path/to/suite{{/}}",
+			"{{red}}[FAIL]{{/}} [AfterSuite]",
+			"  "+strings.Repeat("x", 200)+"...",
+			"  {{gray}}"+cl4.String()+"{{/}}",
+			"  {{gray}}ginkgo --seed=17 /path/to/suite{{/}}",
+			"",
+			"{{red}}{{bold}}Ran 4 of 5 Specs in 60.000 seconds{{/}}",
+			"{{red}}{{bold}}FAIL!{{/}} -- {{green}}{{bold}}2 Passed{{/}} | {{red}}{{bold}}2 Failed{{/}} | {{yellow}}{{bold}}0 Pending{{/}} | {{cyan}}{{bold}}0 Skipped{{/}}",
+			"",
+		),
+		Entry("when quiet, the suite fails with specs that share a failure",
+			C(Quiet),
+			types.Report{
+				SuiteSucceeded: false,
+				PreRunStats:    types.PreRunStats{TotalSpecs: 3, SpecsThatWillRun: 3},
+				RunTime:        time.Minute,
+				SpecReports: types.SpecReports{
+					S("A", cl0, types.SpecStateFailed, F("connection refused\nDETAILS", cl1), Fingerprint("f00d")),
+					S("B", cl2, types.SpecStateFailed, F("boom", cl3), Fingerprint("beef")),
+					S("C", cl4, types.SpecStateFailed, F("connection refused\nDETAILS", cl1), Fingerprint("f00d")),
+				},
+			},
+			"{{red}}{{bold}}3 Failures:{{/}}",
+			"{{red}}[FAIL]{{/}} A",
+			"  connection refused ...",
+			"  {{gray}}"+cl1.String()+"{{/}}",
+			"{{red}}[FAIL]{{/}} B",
+			"  boom",
+			"  {{gray}}"+cl3.String()+"{{/}}",
+			"{{red}}[FAIL]{{/}} C",
+			"  connection refused ...",
+			"  {{gray}}"+cl1.String()+"{{/}}",
 			"",
-			"{{red}}{{bold}}Ran 9 of 13 Specs in 60.000 seconds{{/}}",
-			"{{red}}{{bold}}FAIL!{{/}} -- {{green}}{{bold}}5 Passed{{/}} | {{red}}{{bold}}4 Failed{{/}} | {{light-yellow}}{{bold}}2 Flaked{{/}} | {{yellow}}{{bold}}2 Pending{{/}} | {{cyan}}{{bold}}3 Skipped{{/}}",
+			"{{red}}{{bold}}Identical Failures:{{/}}",
+			"  {{red}}{{bold}}2{{/}}{{red}} specs failed with fingerprint {{bold}}f00d{{/}}",
+			"    connection refused ...",
+			"    {{gray}}"+cl1.String()+"{{/}}",
+			"",
+			"{{red}}{{bold}}Ran 3 of 3 Specs in 60.000 seconds{{/}}",
+			"{{red}}{{bold}}FAIL!{{/}} -- {{green}}{{bold}}0 Passed{{/}} | {{red}}{{bold}}3 Failed{{/}} | {{yellow}}{{bold}}0 Pending{{/}} | {{cyan}}{{bold}}0 Skipped{{/}}",
 			"",
 		),
 		Entry("the suite fails with failed suite setups",
@@ -1218,6 +2163,23 @@ var _ = Describe("DefaultReporter", func() {
 			"{{red}}{{bold}}FAIL!{{/}} -- {{cyan}}{{bold}}A BeforeSuite node failed so all tests were skipped.{{/}}",
 			"",
 		),
+		Entry("the suite fails with an unsatisfied readiness gate",
+			C(),
+			types.Report{
+				SuiteSucceeded: false,
+				PreRunStats:    types.PreRunStats{TotalSpecs: 10, SpecsThatWillRun: 5},
+				RunTime:        time.Minute,
+				SpecReports: types.SpecReports{
+					S(types.NodeTypeReadinessGate, cl0, types.SpecStateFailed, 2,
+						F("ReadinessGate was not satisfied within 1m0s.\nLast error: boom", types.FailureNodeIsLeafNode, FailureNodeLocation(cl0), types.NodeTypeReadinessGate, 1, cl0),
+					),
+				},
+			},
+			"",
+			"{{red}}{{bold}}Ran 0 of 10 Specs in 60.000 seconds{{/}}",
+			"{{red}}{{bold}}FAIL!{{/}} -- {{cyan}}{{bold}}A ReadinessGate was not satisfied so all tests were skipped.{{/}}",
+			"",
+		),
 
 		Entry("when the suite includes a special failure reason",
 			C(),
@@ -1256,5 +2218,21 @@ var _ = Describe("DefaultReporter", func() {
 			"{{green}}{{bold}}3 Passed{{/}} | {{red}}{{bold}}0 Failed{{/}} | {{yellow}}{{bold}}2 Pending{{/}} | {{cyan}}{{bold}}0 Skipped{{/}}",
 			"",
 		),
+		Entry("when the suite was skipped with SkipSuite",
+			C(),
+			types.Report{
+				SuiteSucceeded:  true,
+				SuiteSkipReason: "no database available",
+				PreRunStats:     types.PreRunStats{TotalSpecs: 2, SpecsThatWillRun: 2},
+				RunTime:         time.Minute,
+				SpecReports: types.SpecReports{
+					S(types.SpecStateSkipped), S(types.SpecStateSkipped),
+				},
+			},
+			"",
+			"{{green}}{{bold}}Ran 0 of 2 Specs in 60.000 seconds{{/}}",
+			"{{green}}{{bold}}SUCCESS! - Suite skipped: no database available{{/}} -- {{green}}{{bold}}0 Passed{{/}} | {{red}}{{bold}}0 Failed{{/}} | {{yellow}}{{bold}}0 Pending{{/}} | {{cyan}}{{bold}}2 Skipped{{/}}",
+			"",
+		),
 	)
 })
@@ -103,7 +103,7 @@ func ReportViaDeprecatedReporter(reporter DeprecatedReporter, report types.Repor
 				summary.NumberOfPendingSpecs += 1
 			case types.SpecStateSkipped:
 				summary.NumberOfSkippedSpecs += 1
-			case types.SpecStateFailed, types.SpecStatePanicked, types.SpecStateInterrupted:
+			case types.SpecStateFailed, types.SpecStatePanicked, types.SpecStateInterrupted, types.SpecStateTimedout:
 				summary.NumberOfFailedSpecs += 1
 			case types.SpecStatePassed:
 				summary.NumberOfPassedSpecs += 1
@@ -0,0 +1,156 @@
+/*
+
+IDE Protocol Reporter for Ginkgo
+
+Emits one JSON-encoded IDEProtocolEvent per line as the suite runs.  The protocol is intended for editor and IDE plugins and is
+decoupled from Ginkgo's human-readable output.  When the destination is stdout each event is prefixed with IDEProtocolStdoutPrefix so that
+plugins can pick events out of the rest of the output.
+*/
+
+package reporters
+
+import (
+	"encoding/json"
+	"io"
+	"net"
+	"os"
+	"sync"
+
+	"github.com/onsi/ginkgo/v2/types"
+)
+
+const IDEProtocolStdoutPrefix = "##ginkgo-ide "
+
+const (
+	IDEProtocolEventSuiteStart  = "suite-start"
+	IDEProtocolEventSpecStart   = "spec-start"
+	IDEProtocolEventSpecFailure = "spec-failure"
+	IDEProtocolEventSpecFinish  = "spec-finish"
+	IDEProtocolEventSuiteFinish = "suite-finish"
+)
+
+type IDEProtocolEvent struct {
+	Event string `json:"event"`
+
+	//populated for suite events
+	Suite            string `json:"suite,omitempty"`
+	SuitePath        string `json:"suitePath,omitempty"`
+	TotalSpecs       int    `json:"totalSpecs,omitempty"`
+	SpecsThatWillRun int    `json:"specsThatWillRun,omitempty"`
+
+	//populated for spec events
+	Text            string   `json:"text,omitempty"`
+	NodeType        string   `json:"nodeType,omitempty"`
+	Location        string   `json:"location,omitempty"`
+	Labels          []string `json:"labels,omitempty"`
+	ParallelProcess int      `json:"parallelProcess,omitempty"`
+
+	//populated for spec-failure events
+	Message         string `json:"message,omitempty"`
+	FailureLocation string `json:"failureLocation,omitempty"`
+
+	//populated for finish events.  State is "passed" or "failed" for suite-finish events and the spec's state for spec-finish events
+	State   string  `json:"state,omitempty"`
+	RunTime float64 `json:"runTime,omitempty"`
+}
+
+type IDEProtocolReporter struct {
+	writer io.Writer
+	prefix string
+	closer io.Closer
+	lock   *sync.Mutex
+}
+
+// NewIDEProtocolReporter connects to the destination configured with --ide-protocol
+func NewIDEProtocolReporter(reporterConfig types.ReporterConfig) (*IDEProtocolReporter, error) {
+	network, address, err := reporterConfig.IDEProtocolDestination()
+	if err != nil {
+		return nil, err
+	}
+	if network == "stdout" {
+		return NewIDEProtocolReporterWithWriter(os.Stdout, IDEProtocolStdoutPrefix), nil
+	}
+	conn, err := net.Dial(network, address)
+	if err != nil {
+		return nil, types.GinkgoErrors.FailedToConnectToIDEProtocolDestination(reporterConfig.IDEProtocol, err)
+	}
+	reporter := NewIDEProtocolReporterWithWriter(conn, "")
+	reporter.closer = conn
+	return reporter, nil
+}
+
+func NewIDEProtocolReporterWithWriter(writer io.Writer, prefix string) *IDEProtocolReporter {
+	return &IDEProtocolReporter{
+		writer: writer,
+		prefix: prefix,
+		lock:   &sync.Mutex{},
+	}
+}
+
+func (r *IDEProtocolReporter) emit(event IDEProtocolEvent) {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.writer.Write([]byte(r.prefix + string(data) + "\n"))
+}
+
+func (r *IDEProtocolReporter) specEvent(event string, report types.SpecReport) IDEProtocolEvent {
+	return IDEProtocolEvent{
+		Event:           event,
+		Text:            report.FullText(),
+		NodeType:        report.LeafNodeType.String(),
+		Location:        report.LeafNodeLocation.String(),
+		Labels:          report.Labels(),
+		ParallelProcess: report.ParallelProcess,
+	}
+}
+
+func (r *IDEProtocolReporter) SuiteWillBegin(report types.Report) {
+	r.emit(IDEProtocolEvent{
+		Event:            IDEProtocolEventSuiteStart,
+		Suite:            report.SuiteDescription,
+		SuitePath:        report.SuitePath,
+		TotalSpecs:       report.PreRunStats.TotalSpecs,
+		SpecsThatWillRun: report.PreRunStats.SpecsThatWillRun,
+	})
+}
+
+func (r *IDEProtocolReporter) WillRun(report types.SpecReport) {
+	r.emit(r.specEvent(IDEProtocolEventSpecStart, report))
+}
+
+func (r *IDEProtocolReporter) DidRun(report types.SpecReport) {
+	if report.State.Is(types.SpecStateFailureStates) {
+		event := r.specEvent(IDEProtocolEventSpecFailure, report)
+		event.Message = report.Failure.Message
+		event.FailureLocation = report.Failure.Location.String()
+		r.emit(event)
+	}
+	event := r.specEvent(IDEProtocolEventSpecFinish, report)
+	event.State = report.State.String()
+	event.RunTime = report.RunTime.Seconds()
+	r.emit(event)
+}
+
+// EmitProgressReport is a no-op: progress reports are not part of the IDE protocol
+func (r *IDEProtocolReporter) EmitProgressReport(report types.ProgressReport) {}
+
+func (r *IDEProtocolReporter) SuiteDidEnd(report types.Report) {
+	state := "passed"
+	if !report.SuiteSucceeded {
+		state = "failed"
+	}
+	r.emit(IDEProtocolEvent{
+		Event:     IDEProtocolEventSuiteFinish,
+		Suite:     report.SuiteDescription,
+		SuitePath: report.SuitePath,
+		State:     state,
+		RunTime:   report.RunTime.Seconds(),
+	})
+	if r.closer != nil {
+		r.closer.Close()
+	}
+}
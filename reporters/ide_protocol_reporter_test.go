@@ -0,0 +1,118 @@
+package reporters_test
+
+import (
+	"bufio"
+	"encoding/json"
+	"net"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	"github.com/onsi/ginkgo/v2/reporters"
+	"github.com/onsi/ginkgo/v2/types"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+)
+
+var _ = Describe("IDEProtocolReporter", func() {
+	var buf *gbytes.Buffer
+	var reporter *reporters.IDEProtocolReporter
+
+	events := func() []reporters.IDEProtocolEvent {
+		out := []reporters.IDEProtocolEvent{}
+		for _, line := range strings.Split(strings.TrimSpace(string(buf.Contents())), "\n") {
+			Ω(line).Should(HavePrefix(reporters.IDEProtocolStdoutPrefix))
+			event := reporters.IDEProtocolEvent{}
+			Ω(json.Unmarshal([]byte(strings.TrimPrefix(line, reporters.IDEProtocolStdoutPrefix)), &event)).Should(Succeed())
+			out = append(out, event)
+		}
+		return out
+	}
+
+	BeforeEach(func() {
+		buf = gbytes.NewBuffer()
+		reporter = reporters.NewIDEProtocolReporterWithWriter(buf, reporters.IDEProtocolStdoutPrefix)
+	})
+
+	It("emits one event per line for the suite and each spec", func() {
+		passing := types.SpecReport{
+			ContainerHierarchyTexts: []string{"container"},
+			LeafNodeType:            types.NodeTypeIt,
+			LeafNodeText:            "passes",
+			LeafNodeLocation:        cl0,
+			LeafNodeLabels:          []string{"fast"},
+			State:                   types.SpecStatePassed,
+			RunTime:                 time.Second,
+			ParallelProcess:         1,
+		}
+		failing := types.SpecReport{
+			ContainerHierarchyTexts: []string{"container"},
+			LeafNodeType:            types.NodeTypeIt,
+			LeafNodeText:            "fails",
+			LeafNodeLocation:        cl1,
+			State:                   types.SpecStateFailed,
+			RunTime:                 2 * time.Second,
+			Failure:                 types.Failure{Message: "boom", Location: cl2},
+			ParallelProcess:         1,
+		}
+
+		reporter.SuiteWillBegin(types.Report{SuiteDescription: "suite", SuitePath: "/path/to/suite", PreRunStats: types.PreRunStats{TotalSpecs: 3, SpecsThatWillRun: 2}})
+		reporter.WillRun(passing)
+		reporter.DidRun(passing)
+		reporter.WillRun(failing)
+		reporter.DidRun(failing)
+		reporter.SuiteDidEnd(types.Report{SuiteDescription: "suite", SuitePath: "/path/to/suite", SuiteSucceeded: false, RunTime: 3 * time.Second})
+
+		Ω(events()).Should(Equal([]reporters.IDEProtocolEvent{
+			{Event: "suite-start", Suite: "suite", SuitePath: "/path/to/suite", TotalSpecs: 3, SpecsThatWillRun: 2},
+			{Event: "spec-start", Text: "container passes", NodeType: "It", Location: cl0.String(), Labels: []string{"fast"}, ParallelProcess: 1},
+			{Event: "spec-finish", Text: "container passes", NodeType: "It", Location: cl0.String(), Labels: []string{"fast"}, ParallelProcess: 1, State: "passed", RunTime: 1},
+			{Event: "spec-start", Text: "container fails", NodeType: "It", Location: cl1.String(), ParallelProcess: 1},
+			{Event: "spec-failure", Text: "container fails", NodeType: "It", Location: cl1.String(), ParallelProcess: 1, Message: "boom", FailureLocation: cl2.String()},
+			{Event: "spec-finish", Text: "container fails", NodeType: "It", Location: cl1.String(), ParallelProcess: 1, State: "failed", RunTime: 2},
+			{Event: "suite-finish", Suite: "suite", SuitePath: "/path/to/suite", State: "failed", RunTime: 3},
+		}))
+	})
+
+	Describe("connecting to a destination", func() {
+		It("can stream events to a TCP socket", func() {
+			listener, err := net.Listen("tcp", "127.0.0.1:0")
+			Ω(err).ShouldNot(HaveOccurred())
+			defer listener.Close()
+
+			lines := make(chan string, 10)
+			go func() {
+				conn, err := listener.Accept()
+				if err != nil {
+					return
+				}
+				scanner := bufio.NewScanner(conn)
+				for scanner.Scan() {
+					lines <- scanner.Text()
+				}
+				close(lines)
+			}()
+
+			reporter, err := reporters.NewIDEProtocolReporter(types.ReporterConfig{IDEProtocol: "tcp://" + listener.Addr().String()})
+			Ω(err).ShouldNot(HaveOccurred())
+			reporter.SuiteWillBegin(types.Report{SuiteDescription: "suite"})
+			reporter.SuiteDidEnd(types.Report{SuiteDescription: "suite", SuiteSucceeded: true})
+
+			Eventually(lines).Should(Receive(Equal(`{"event":"suite-start","suite":"suite"}`)))
+			Eventually(lines).Should(Receive(Equal(`{"event":"suite-finish","suite":"suite","state":"passed"}`)))
+			Eventually(lines).Should(BeClosed())
+		})
+
+		It("errors when it can't connect", func() {
+			listener, err := net.Listen("tcp", "127.0.0.1:0")
+			Ω(err).ShouldNot(HaveOccurred())
+			address := listener.Addr().String()
+			listener.Close()
+
+			reporter, err := reporters.NewIDEProtocolReporter(types.ReporterConfig{IDEProtocol: "tcp://" + address})
+			Ω(reporter).Should(BeNil())
+			Ω(err).Should(HaveOccurred())
+			Ω(err.Error()).Should(ContainSubstring("Failed to connect to --ide-protocol destination tcp://" + address))
+		})
+	})
+})
@@ -25,6 +25,20 @@ func GenerateJSONReport(report types.Report, destination string) error {
 	return f.Close()
 }
 
+//ReadJSONReport reads the reports stored in a JSON-formatted report generated by GenerateJSONReport or MergeAndCleanupJSONReports
+func ReadJSONReport(source string) ([]types.Report, error) {
+	data, err := os.ReadFile(source)
+	if err != nil {
+		return nil, err
+	}
+	reports := []types.Report{}
+	err = json.Unmarshal(data, &reports)
+	if err != nil {
+		return nil, fmt.Errorf("%s is not a valid Ginkgo JSON report: %w", source, err)
+	}
+	return reports, nil
+}
+
 //MergeJSONReports produces a single JSON-formatted report at the passed in destination by merging the JSON-formatted reports provided in sources
 //It skips over reports that fail to decode but reports on them via the returned messages []string
 func MergeAndCleanupJSONReports(sources []string, destination string) ([]string, error) {
@@ -18,6 +18,7 @@ import (
 	"time"
 
 	"github.com/onsi/ginkgo/v2/config"
+	"github.com/onsi/ginkgo/v2/formatter"
 	"github.com/onsi/ginkgo/v2/types"
 )
 
@@ -138,6 +139,7 @@ func GenerateJUnitReport(report types.Report, dst string) error {
 				{"SuiteSucceeded", fmt.Sprintf("%t", report.SuiteSucceeded)},
 				{"SuiteHasProgrammaticFocus", fmt.Sprintf("%t", report.SuiteHasProgrammaticFocus)},
 				{"SpecialSuiteFailureReason", strings.Join(report.SpecialSuiteFailureReasons, ",")},
+				{"SuiteSkipReason", report.SuiteSkipReason},
 				{"SuiteLabels", fmt.Sprintf("[%s]", strings.Join(report.SuiteLabels, ","))},
 				{"RandomSeed", fmt.Sprintf("%d", report.SuiteConfig.RandomSeed)},
 				{"RandomizeAllSpecs", fmt.Sprintf("%t", report.SuiteConfig.RandomizeAllSpecs)},
@@ -153,6 +155,13 @@ func GenerateJUnitReport(report types.Report, dst string) error {
 				{"DryRun", fmt.Sprintf("%t", report.SuiteConfig.DryRun)},
 				{"ParallelTotal", fmt.Sprintf("%d", report.SuiteConfig.ParallelTotal)},
 				{"OutputInterceptorMode", report.SuiteConfig.OutputInterceptorMode},
+				{"GoVersion", report.Environment.GoVersion},
+				{"Platform", fmt.Sprintf("%s/%s", report.Environment.GOOS, report.Environment.GOARCH)},
+				{"NumCPU", fmt.Sprintf("%d", report.Environment.NumCPU)},
+				{"GitCommit", report.Environment.GitCommit},
+				{"GitDirty", fmt.Sprintf("%t", report.Environment.GitDirty)},
+				{"Warnings", fmt.Sprintf("%d", report.SpecReports.CountOfWarnings())},
+				{"GoTestCacheable", fmt.Sprintf("%t", report.GoTestCacheable)},
 			},
 		},
 	}
@@ -161,18 +170,21 @@ func GenerateJUnitReport(report types.Report, dst string) error {
 		if spec.FullText() != "" {
 			name = name + " " + spec.FullText()
 		}
+		if spec.LeafNodeType.Is(types.NodeTypeAfterSuite|types.NodeTypeSynchronizedAfterSuite) && report.SuiteConfig.ParallelTotal > 1 {
+			name = name + fmt.Sprintf(" (process #%d)", spec.ParallelProcess)
+		}
 		labels := spec.Labels()
 		if len(labels) > 0 {
 			name = name + " [" + strings.Join(labels, ", ") + "]"
 		}
 
 		test := JUnitTestCase{
-			Name:      name,
+			Name:      types.SanitizeForXML(name),
 			Classname: report.SuiteDescription,
 			Status:    spec.State.String(),
 			Time:      spec.RunTime.Seconds(),
-			SystemOut: systemOutForUnstructureReporters(spec),
-			SystemErr: spec.CapturedGinkgoWriterOutput,
+			SystemOut: types.SanitizeForXML(systemOutForUnstructureReporters(spec) + junitAttachmentsFor(spec)),
+			SystemErr: types.SanitizeForXML(spec.CapturedGinkgoWriterOutput),
 		}
 		suite.Tests += 1
 
@@ -182,17 +194,27 @@ func GenerateJUnitReport(report types.Report, dst string) error {
 			if spec.Failure.Message != "" {
 				message += " - " + spec.Failure.Message
 			}
-			test.Skipped = &JUnitSkipped{Message: message}
+			test.Skipped = &JUnitSkipped{Message: types.SanitizeForXML(message)}
 			suite.Skipped += 1
 		case types.SpecStatePending:
 			test.Skipped = &JUnitSkipped{Message: "pending"}
 			suite.Disabled += 1
 		case types.SpecStateFailed:
 			test.Failure = &JUnitFailure{
-				Message:     spec.Failure.Message,
+				Message:     types.SanitizeForXML(spec.Failure.Message),
 				Type:        "failed",
 				Description: fmt.Sprintf("%s\n%s", spec.Failure.Location.String(), spec.Failure.Location.FullStackTrace),
 			}
+			if spec.Failure.Payload != nil {
+				test.Failure.Description = fmt.Sprintf("%s\n%s", formatter.New(formatter.ColorModeNone).F("%s", spec.Failure.Payload.Representation), test.Failure.Description)
+			}
+			suite.Failures += 1
+		case types.SpecStateTimedout:
+			test.Failure = &JUnitFailure{
+				Message:     types.SanitizeForXML(spec.Failure.Message),
+				Type:        "timedout",
+				Description: fmt.Sprintf("%s\n%s", spec.Failure.Location.String(), spec.Failure.Location.FullStackTrace),
+			}
 			suite.Failures += 1
 		case types.SpecStateInterrupted:
 			test.Error = &JUnitError{
@@ -203,20 +225,26 @@ func GenerateJUnitReport(report types.Report, dst string) error {
 			suite.Errors += 1
 		case types.SpecStateAborted:
 			test.Failure = &JUnitFailure{
-				Message:     spec.Failure.Message,
+				Message:     types.SanitizeForXML(spec.Failure.Message),
 				Type:        "aborted",
 				Description: fmt.Sprintf("%s\n%s", spec.Failure.Location.String(), spec.Failure.Location.FullStackTrace),
 			}
 			suite.Errors += 1
 		case types.SpecStatePanicked:
 			test.Error = &JUnitError{
-				Message:     spec.Failure.ForwardedPanic,
+				Message:     types.SanitizeForXML(spec.Failure.ForwardedPanic),
 				Type:        "panicked",
 				Description: fmt.Sprintf("%s\n%s", spec.Failure.Location.String(), spec.Failure.Location.FullStackTrace),
 			}
 			suite.Errors += 1
 		}
 
+		if test.Failure != nil {
+			test.Failure.Description = types.SanitizeForXML(test.Failure.Description)
+		}
+		if test.Error != nil {
+			test.Error.Description = types.SanitizeForXML(test.Error.Description)
+		}
 		suite.TestCases = append(suite.TestCases, test)
 	}
 
@@ -280,6 +308,22 @@ func MergeAndCleanupJUnitReports(sources []string, dst string) ([]string, error)
 
 func systemOutForUnstructureReporters(spec types.SpecReport) string {
 	systemOut := spec.CapturedStdOutErr
+	if len(spec.Warnings) > 0 {
+		systemOut += "\nWarnings:\n"
+		for _, warning := range spec.Warnings {
+			systemOut += fmt.Sprintf("%s\n%s\n", warning.Location, warning.Message)
+		}
+	}
+	if len(spec.Steps) > 0 {
+		systemOut += "\nSteps:\n"
+		for _, step := range spec.Steps {
+			indentation := strings.Repeat("  ", step.NestingLevel)
+			systemOut += fmt.Sprintf("%s[%s] %s (%.3fs) %s\n", indentation, strings.ToUpper(step.State.String()), step.Text, step.RunTime.Seconds(), step.Location)
+			if step.Message != "" {
+				systemOut += fmt.Sprintf("%s  %s\n", indentation, step.Message)
+			}
+		}
+	}
 	if len(spec.ReportEntries) > 0 {
 		systemOut += "\nReport Entries:\n"
 		for i, entry := range spec.ReportEntries {
@@ -295,6 +339,15 @@ func systemOutForUnstructureReporters(spec types.SpecReport) string {
 	return systemOut
 }
 
+// junitAttachmentsFor lists the spec's failure artifacts and network captures using the [[ATTACHMENT|path]] convention understood by JUnit attachment plugins
+func junitAttachmentsFor(spec types.SpecReport) string {
+	attachments := ""
+	for _, path := range append(append([]string{}, spec.FailureArtifacts...), spec.NetworkCaptures...) {
+		attachments += fmt.Sprintf("[[ATTACHMENT|%s]]\n", path)
+	}
+	return attachments
+}
+
 // Deprecated JUnitReporter (so folks can still compile their suites)
 type JUnitReporter struct{}
 
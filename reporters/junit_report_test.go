@@ -0,0 +1,166 @@
+package reporters_test
+
+import (
+	"encoding/xml"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	"github.com/onsi/ginkgo/v2/reporters"
+	"github.com/onsi/ginkgo/v2/types"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("JUnit Reports", func() {
+	var dir string
+	BeforeEach(func() {
+		var err error
+		dir, err = os.MkdirTemp("", "ginkgo-junit")
+		Ω(err).ShouldNot(HaveOccurred())
+		DeferCleanup(os.RemoveAll, dir)
+	})
+
+	It("sanitizes content that would otherwise corrupt the XML", func() {
+		report := types.Report{
+			SuiteDescription: "My Suite",
+			StartTime:        time.Now(),
+			SpecReports: types.SpecReports{
+				{
+					LeafNodeText:               "emits \x1b[31mgarbage\x1b[0m",
+					LeafNodeType:               types.NodeTypeIt,
+					State:                      types.SpecStateFailed,
+					Failure:                    types.Failure{Message: "bad \x00\x01 message"},
+					CapturedStdOutErr:          "\x1b[1mbold\x1b[0m \x07\xff",
+					CapturedGinkgoWriterOutput: "writer \x08output",
+				},
+			},
+		}
+		path := filepath.Join(dir, "report.xml")
+		Ω(reporters.GenerateJUnitReport(report, path)).Should(Succeed())
+
+		content, err := os.ReadFile(path)
+		Ω(err).ShouldNot(HaveOccurred())
+		decoded := reporters.JUnitTestSuites{}
+		Ω(xml.Unmarshal(content, &decoded)).Should(Succeed())
+
+		testCase := decoded.TestSuites[0].TestCases[0]
+		Ω(testCase.Name).Should(Equal("[It] emits garbage"))
+		Ω(testCase.Failure.Message).Should(Equal("bad  message"))
+		Ω(testCase.SystemOut).Should(Equal("bold �"))
+		Ω(testCase.SystemErr).Should(Equal("writer output"))
+	})
+
+	It("includes warnings", func() {
+		report := types.Report{
+			SuiteDescription: "My Suite",
+			StartTime:        time.Now(),
+			SpecReports: types.SpecReports{
+				{
+					LeafNodeText: "A",
+					LeafNodeType: types.NodeTypeIt,
+					State:        types.SpecStatePassed,
+					Warnings:     []types.Warning{{Message: "uses the legacy fixture", Location: types.CodeLocation{FileName: "a_test.go", LineNumber: 3}}},
+				},
+			},
+		}
+		path := filepath.Join(dir, "report.xml")
+		Ω(reporters.GenerateJUnitReport(report, path)).Should(Succeed())
+
+		content, err := os.ReadFile(path)
+		Ω(err).ShouldNot(HaveOccurred())
+		decoded := reporters.JUnitTestSuites{}
+		Ω(xml.Unmarshal(content, &decoded)).Should(Succeed())
+
+		Ω(decoded.TestSuites[0].Properties.WithName("Warnings")).Should(Equal("1"))
+		Ω(decoded.TestSuites[0].TestCases[0].SystemOut).Should(Equal("\nWarnings:\na_test.go:3\nuses the legacy fixture\n"))
+	})
+
+	It("attaches failure artifacts", func() {
+		report := types.Report{
+			SuiteDescription: "My Suite",
+			StartTime:        time.Now(),
+			SpecReports: types.SpecReports{
+				{
+					LeafNodeText:     "A",
+					LeafNodeType:     types.NodeTypeIt,
+					State:            types.SpecStateFailed,
+					Failure:          types.Failure{Message: "boom"},
+					FailureArtifacts: []string{"/artifacts/A/screenshot.png", "/artifacts/A/page.html"},
+				},
+			},
+		}
+		path := filepath.Join(dir, "report.xml")
+		Ω(reporters.GenerateJUnitReport(report, path)).Should(Succeed())
+
+		content, err := os.ReadFile(path)
+		Ω(err).ShouldNot(HaveOccurred())
+		decoded := reporters.JUnitTestSuites{}
+		Ω(xml.Unmarshal(content, &decoded)).Should(Succeed())
+
+		Ω(decoded.TestSuites[0].TestCases[0].SystemOut).Should(Equal("[[ATTACHMENT|/artifacts/A/screenshot.png]]\n[[ATTACHMENT|/artifacts/A/page.html]]\n"))
+	})
+
+	It("attaches network captures", func() {
+		report := types.Report{
+			SuiteDescription: "My Suite",
+			StartTime:        time.Now(),
+			SpecReports: types.SpecReports{
+				{
+					LeafNodeText:    "A",
+					LeafNodeType:    types.NodeTypeIt,
+					State:           types.SpecStatePassed,
+					NetworkCaptures: []string{"/artifacts/A/capture.pcap"},
+				},
+			},
+		}
+		path := filepath.Join(dir, "report.xml")
+		Ω(reporters.GenerateJUnitReport(report, path)).Should(Succeed())
+
+		content, err := os.ReadFile(path)
+		Ω(err).ShouldNot(HaveOccurred())
+		decoded := reporters.JUnitTestSuites{}
+		Ω(xml.Unmarshal(content, &decoded)).Should(Succeed())
+
+		Ω(decoded.TestSuites[0].TestCases[0].SystemOut).Should(Equal("[[ATTACHMENT|/artifacts/A/capture.pcap]]\n"))
+	})
+
+	It("lists the spec's steps", func() {
+		report := types.Report{
+			SuiteDescription: "My Suite",
+			StartTime:        time.Now(),
+			SpecReports: types.SpecReports{
+				{
+					LeafNodeText: "A",
+					LeafNodeType: types.NodeTypeIt,
+					State:        types.SpecStateFailed,
+					Failure:      types.Failure{Message: "boom"},
+					Steps: []types.SpecStep{
+						{Text: "log in", Location: types.CodeLocation{FileName: "a_test.go", LineNumber: 3}, State: types.SpecStatePassed, RunTime: time.Second},
+						{Text: "check out", Location: types.CodeLocation{FileName: "a_test.go", LineNumber: 7}, State: types.SpecStateFailed, RunTime: 2 * time.Second, Message: "boom"},
+						{Text: "confirm", Location: types.CodeLocation{FileName: "a_test.go", LineNumber: 8}, NestingLevel: 1, State: types.SpecStateFailed, RunTime: time.Second, Message: "boom"},
+					},
+				},
+			},
+		}
+		path := filepath.Join(dir, "report.xml")
+		Ω(reporters.GenerateJUnitReport(report, path)).Should(Succeed())
+
+		content, err := os.ReadFile(path)
+		Ω(err).ShouldNot(HaveOccurred())
+		decoded := reporters.JUnitTestSuites{}
+		Ω(xml.Unmarshal(content, &decoded)).Should(Succeed())
+
+		Ω(decoded.TestSuites[0].TestCases[0].SystemOut).Should(Equal(strings.Join([]string{
+			"",
+			"Steps:",
+			"[PASSED] log in (1.000s) a_test.go:3",
+			"[FAILED] check out (2.000s) a_test.go:7",
+			"  boom",
+			"  [FAILED] confirm (1.000s) a_test.go:8",
+			"    boom",
+			"",
+		}, "\n")))
+	})
+})
@@ -0,0 +1,76 @@
+package reporters
+
+import (
+	"io"
+	"net"
+	"os"
+
+	"github.com/onsi/ginkgo/v2/formatter"
+	"github.com/onsi/ginkgo/v2/types"
+)
+
+// NewDefaultReporterWithOutputSinks returns a default reporter that writes to writer and to each of the sinks configured with --output-sink.
+// Each sink gets its own default reporter so that it can have its own verbosity and color settings.
+func NewDefaultReporterWithOutputSinks(conf types.ReporterConfig, writer io.Writer) (Reporter, error) {
+	sinks, err := conf.OutputSinkDestinations()
+	if err != nil {
+		return nil, err
+	}
+	if len(sinks) == 0 {
+		return NewDefaultReporter(conf, writer), nil
+	}
+
+	reporter := CompositeReporter{NewDefaultReporter(conf, writer)}
+	for _, sink := range sinks {
+		sinkWriter, closer, err := openOutputSink(sink)
+		if err != nil {
+			for _, opened := range reporter[1:] {
+				opened.(*outputSinkReporter).close()
+			}
+			return nil, types.GinkgoErrors.FailedToOpenOutputSink(sink.Value, err)
+		}
+		reporter = append(reporter, &outputSinkReporter{
+			DefaultReporter: NewDefaultReporter(sink.Config, sinkWriter),
+			closer:          closer,
+		})
+	}
+	return reporter, nil
+}
+
+func openOutputSink(sink types.OutputSink) (io.Writer, io.Closer, error) {
+	switch sink.Network {
+	case "stdout":
+		return formatter.ColorableStdOut, nil, nil
+	case "stderr":
+		return formatter.ColorableStdErr, nil, nil
+	case "file":
+		f, err := os.Create(sink.Address)
+		if err != nil {
+			return nil, nil, err
+		}
+		return f, f, nil
+	default:
+		conn, err := net.Dial(sink.Network, sink.Address)
+		if err != nil {
+			return nil, nil, err
+		}
+		return conn, conn, nil
+	}
+}
+
+// outputSinkReporter is a default reporter that closes its sink when the suite ends
+type outputSinkReporter struct {
+	*DefaultReporter
+	closer io.Closer
+}
+
+func (r *outputSinkReporter) SuiteDidEnd(report types.Report) {
+	r.DefaultReporter.SuiteDidEnd(report)
+	r.close()
+}
+
+func (r *outputSinkReporter) close() {
+	if r.closer != nil {
+		r.closer.Close()
+	}
+}
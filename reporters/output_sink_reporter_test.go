@@ -0,0 +1,67 @@
+package reporters_test
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	"github.com/onsi/ginkgo/v2/reporters"
+	"github.com/onsi/ginkgo/v2/types"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+)
+
+var _ = Describe("Output sinks", func() {
+	var dir string
+
+	BeforeEach(func() {
+		dir = GinkgoT().TempDir()
+	})
+
+	It("writes to each sink with its own verbosity and color settings", func() {
+		buf := gbytes.NewBuffer()
+		path := filepath.Join(dir, "ginkgo.log")
+		conf := types.ReporterConfig{Succinct: true, OutputSinks: []string{"file://" + path + ",very-verbose"}}
+		reporter, err := reporters.NewDefaultReporterWithOutputSinks(conf, buf)
+		Ω(err).ShouldNot(HaveOccurred())
+
+		report := types.Report{SuiteDescription: "My Suite", SuitePath: "/path/to/suite", SuiteConfig: types.SuiteConfig{RandomSeed: 17, ParallelTotal: 1}, PreRunStats: types.PreRunStats{TotalSpecs: 1, SpecsThatWillRun: 1}}
+		spec := types.SpecReport{
+			LeafNodeType:               types.NodeTypeIt,
+			LeafNodeText:               "passes",
+			LeafNodeLocation:           cl0,
+			State:                      types.SpecStatePassed,
+			RunTime:                    time.Second,
+			CapturedGinkgoWriterOutput: "hello from the GinkgoWriter",
+		}
+		reporter.SuiteWillBegin(report)
+		reporter.WillRun(spec)
+		reporter.DidRun(spec)
+		report.SpecReports = types.SpecReports{spec}
+		report.SuiteSucceeded = true
+		reporter.SuiteDidEnd(report)
+
+		Ω(string(buf.Contents())).Should(ContainSubstring("[17] "))
+		Ω(string(buf.Contents())).ShouldNot(ContainSubstring("hello from the GinkgoWriter"))
+
+		log, err := os.ReadFile(path)
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(string(log)).Should(ContainSubstring("Running Suite: My Suite - /path/to/suite"))
+		Ω(string(log)).Should(ContainSubstring("hello from the GinkgoWriter"))
+		Ω(string(log)).ShouldNot(ContainSubstring("\x1b["))
+	})
+
+	It("returns just the default reporter when there are no sinks", func() {
+		reporter, err := reporters.NewDefaultReporterWithOutputSinks(types.ReporterConfig{}, gbytes.NewBuffer())
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(reporter).Should(BeAssignableToTypeOf(&reporters.DefaultReporter{}))
+	})
+
+	It("errors if a sink can't be opened", func() {
+		conf := types.ReporterConfig{OutputSinks: []string{"file://" + filepath.Join(dir, "missing", "ginkgo.log")}}
+		_, err := reporters.NewDefaultReporterWithOutputSinks(conf, gbytes.NewBuffer())
+		Ω(err).Should(HaveOccurred())
+		Ω(err.Error()).Should(ContainSubstring("Failed to open --output-sink"))
+	})
+})
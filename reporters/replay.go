@@ -0,0 +1,38 @@
+package reporters
+
+import (
+	"time"
+
+	"github.com/onsi/ginkgo/v2/types"
+)
+
+/*
+ReplayReport feeds a saved report through the passed-in reporter as though the suite were running again: SuiteWillBegin, then WillRun and DidRun for each spec in the order the specs were reported, then SuiteDidEnd.
+
+If speed is positive the replay reproduces the timing of the original run, sped up by a factor of speed: each spec is reported once the (scaled) time at which it ended in the original run has elapsed.  Otherwise the report is replayed instantly.
+*/
+func ReplayReport(reporter Reporter, report types.Report, speed float64) {
+	beginningReport := report
+	beginningReport.SpecReports = nil
+	reporter.SuiteWillBegin(beginningReport)
+
+	replayStart := time.Now()
+	for _, specReport := range report.SpecReports {
+		if speed > 0 && !specReport.EndTime.IsZero() && !report.StartTime.IsZero() {
+			offset := time.Duration(float64(specReport.EndTime.Sub(report.StartTime)) / speed)
+			if wait := offset - time.Since(replayStart); wait > 0 {
+				time.Sleep(wait)
+			}
+		}
+		reporter.WillRun(specReport)
+		reporter.DidRun(specReport)
+	}
+
+	if speed > 0 && !report.EndTime.IsZero() && !report.StartTime.IsZero() {
+		offset := time.Duration(float64(report.EndTime.Sub(report.StartTime)) / speed)
+		if wait := offset - time.Since(replayStart); wait > 0 {
+			time.Sleep(wait)
+		}
+	}
+	reporter.SuiteDidEnd(report)
+}
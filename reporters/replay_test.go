@@ -0,0 +1,92 @@
+package reporters_test
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	"github.com/onsi/ginkgo/v2/internal/test_helpers"
+	"github.com/onsi/ginkgo/v2/reporters"
+	"github.com/onsi/ginkgo/v2/types"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Replaying reports", func() {
+	var report types.Report
+	var start time.Time
+
+	BeforeEach(func() {
+		start = time.Now().Add(-time.Hour)
+		report = types.Report{
+			SuiteDescription: "My Suite",
+			SuiteSucceeded:   false,
+			StartTime:        start,
+			EndTime:          start.Add(300 * time.Millisecond),
+			SpecReports: types.SpecReports{
+				{LeafNodeText: "A", LeafNodeType: types.NodeTypeIt, State: types.SpecStatePassed, StartTime: start, EndTime: start.Add(100 * time.Millisecond)},
+				{LeafNodeText: "B", LeafNodeType: types.NodeTypeIt, State: types.SpecStateFailed, StartTime: start.Add(100 * time.Millisecond), EndTime: start.Add(200 * time.Millisecond)},
+			},
+		}
+	})
+
+	Describe("ReplayReport", func() {
+		It("feeds the report through the reporter", func() {
+			reporter := &test_helpers.FakeReporter{}
+			reporters.ReplayReport(reporter, report, 0)
+
+			Ω(reporter.Begin.SuiteDescription).Should(Equal("My Suite"))
+			Ω(reporter.Begin.SpecReports).Should(BeEmpty())
+			Ω(reporter.Will.Names()).Should(Equal([]string{"A", "B"}))
+			Ω(reporter.Did.Names()).Should(Equal([]string{"A", "B"}))
+			Ω(reporter.Did.Find("B").State).Should(Equal(types.SpecStateFailed))
+			Ω(reporter.End).Should(Equal(report))
+		})
+
+		It("replays instantly when speed is zero", func() {
+			t := time.Now()
+			reporters.ReplayReport(&test_helpers.FakeReporter{}, report, 0)
+			Ω(time.Since(t)).Should(BeNumerically("<", 100*time.Millisecond))
+		})
+
+		It("reproduces the original timing, scaled by speed", func() {
+			t := time.Now()
+			reporters.ReplayReport(&test_helpers.FakeReporter{}, report, 2)
+			Ω(time.Since(t)).Should(BeNumerically(">=", 150*time.Millisecond))
+		})
+	})
+
+	Describe("ReadJSONReport", func() {
+		var dir string
+		BeforeEach(func() {
+			var err error
+			dir, err = os.MkdirTemp("", "ginkgo-replay")
+			Ω(err).ShouldNot(HaveOccurred())
+			DeferCleanup(os.RemoveAll, dir)
+		})
+
+		It("reads reports generated by GenerateJSONReport", func() {
+			path := filepath.Join(dir, "report.json")
+			Ω(reporters.GenerateJSONReport(report, path)).Should(Succeed())
+
+			reports, err := reporters.ReadJSONReport(path)
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(reports).Should(HaveLen(1))
+			Ω(reports[0].SuiteDescription).Should(Equal("My Suite"))
+			Ω(reports[0].SpecReports).Should(HaveLen(2))
+		})
+
+		It("errors when the file is not a JSON report", func() {
+			path := filepath.Join(dir, "report.json")
+			Ω(os.WriteFile(path, []byte("not json"), 0644)).Should(Succeed())
+
+			_, err := reporters.ReadJSONReport(path)
+			Ω(err).Should(MatchError(ContainSubstring("is not a valid Ginkgo JSON report")))
+		})
+
+		It("errors when the file does not exist", func() {
+			_, err := reporters.ReadJSONReport(filepath.Join(dir, "missing.json"))
+			Ω(err).Should(HaveOccurred())
+		})
+	})
+})
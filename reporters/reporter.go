@@ -8,12 +8,47 @@ type Reporter interface {
 	SuiteWillBegin(report types.Report)
 	WillRun(report types.SpecReport)
 	DidRun(report types.SpecReport)
+	EmitProgressReport(report types.ProgressReport)
 	SuiteDidEnd(report types.Report)
 }
 
 type NoopReporter struct{}
 
-func (n NoopReporter) SuiteWillBegin(report types.Report) {}
-func (n NoopReporter) WillRun(report types.SpecReport)    {}
-func (n NoopReporter) DidRun(report types.SpecReport)     {}
-func (n NoopReporter) SuiteDidEnd(report types.Report)    {}
+func (n NoopReporter) SuiteWillBegin(report types.Report)             {}
+func (n NoopReporter) WillRun(report types.SpecReport)                {}
+func (n NoopReporter) DidRun(report types.SpecReport)                 {}
+func (n NoopReporter) EmitProgressReport(report types.ProgressReport) {}
+func (n NoopReporter) SuiteDidEnd(report types.Report)                {}
+
+// CompositeReporter forwards every event to each of its reporters, in order
+type CompositeReporter []Reporter
+
+func (c CompositeReporter) SuiteWillBegin(report types.Report) {
+	for _, reporter := range c {
+		reporter.SuiteWillBegin(report)
+	}
+}
+
+func (c CompositeReporter) WillRun(report types.SpecReport) {
+	for _, reporter := range c {
+		reporter.WillRun(report)
+	}
+}
+
+func (c CompositeReporter) DidRun(report types.SpecReport) {
+	for _, reporter := range c {
+		reporter.DidRun(report)
+	}
+}
+
+func (c CompositeReporter) EmitProgressReport(report types.ProgressReport) {
+	for _, reporter := range c {
+		reporter.EmitProgressReport(report)
+	}
+}
+
+func (c CompositeReporter) SuiteDidEnd(report types.Report) {
+	for _, reporter := range c {
+		reporter.SuiteDidEnd(report)
+	}
+}
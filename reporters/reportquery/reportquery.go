@@ -0,0 +1,213 @@
+/*
+Package reportquery loads Ginkgo's JSON reports and queries the specs they contain.
+
+Tools that post-process test results - flake trackers, dashboards, ownership bots - can use it instead of decoding the JSON
+report by hand:
+
+	specs, err := reportquery.Load("report.json")
+	if err != nil {
+		return err
+	}
+	slow := specs.Subjects().WithState(types.SpecStatePassed).SlowerThan(time.Minute).SortedByRunTime()
+	for _, spec := range slow {
+		fmt.Println(spec.FullText(), spec.RunTime, spec.Owners())
+	}
+
+Every query returns a new Specs so queries can be chained.  The underlying types.SpecReport of each Spec is embedded so all
+its fields and helpers are available.
+*/
+package reportquery
+
+import (
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/onsi/ginkgo/v2/reporters"
+	"github.com/onsi/ginkgo/v2/types"
+)
+
+// OwnersReportEntry is the name of the report entry the CLI's enrich-owners report transform attaches to each spec
+const OwnersReportEntry = "Owners"
+
+// Spec is a SpecReport along with the suite it belongs to
+type Spec struct {
+	types.SpecReport
+
+	SuitePath        string
+	SuiteDescription string
+}
+
+// Owners returns the owners of the spec, as attached by the enrich-owners report transform.  It returns nil if the spec has no owners.
+func (spec Spec) Owners() []string {
+	entry, ok := spec.ReportEntry(OwnersReportEntry)
+	if !ok {
+		return nil
+	}
+	owners := []string{}
+	for _, owner := range strings.Split(entry.StringRepresentation(), ",") {
+		if owner = strings.TrimSpace(owner); owner != "" {
+			owners = append(owners, owner)
+		}
+	}
+	return owners
+}
+
+// ReportEntry returns the last report entry attached to the spec with the passed-in name
+func (spec Spec) ReportEntry(name string) (types.ReportEntry, bool) {
+	for i := len(spec.ReportEntries) - 1; i >= 0; i-- {
+		if spec.ReportEntries[i].Name == name {
+			return spec.ReportEntries[i], true
+		}
+	}
+	return types.ReportEntry{}, false
+}
+
+// Annotation returns the value of the spec's annotation with the passed-in key.  See types.SpecAnnotations.Get for how values are decoded.
+func (spec Spec) Annotation(key string) (interface{}, bool) {
+	return spec.Annotations.Get(key)
+}
+
+type Specs []Spec
+
+// Load reads the JSON reports at the passed-in paths - as generated by --json-report - and returns all the specs they contain
+func Load(paths ...string) (Specs, error) {
+	reports := []types.Report{}
+	for _, path := range paths {
+		r, err := reporters.ReadJSONReport(path)
+		if err != nil {
+			return nil, err
+		}
+		reports = append(reports, r...)
+	}
+	return FromReports(reports...), nil
+}
+
+// FromReports returns all the specs in the passed-in reports
+func FromReports(reports ...types.Report) Specs {
+	specs := Specs{}
+	for _, report := range reports {
+		for _, specReport := range report.SpecReports {
+			specs = append(specs, Spec{SpecReport: specReport, SuitePath: report.SuitePath, SuiteDescription: report.SuiteDescription})
+		}
+	}
+	return specs
+}
+
+// Filter returns the specs for which the passed-in function returns true
+func (specs Specs) Filter(f func(Spec) bool) Specs {
+	out := Specs{}
+	for _, spec := range specs {
+		if f(spec) {
+			out = append(out, spec)
+		}
+	}
+	return out
+}
+
+// Subjects returns the specs generated by Its - dropping the reports of suite-level nodes like BeforeSuite and ReportAfterSuite
+func (specs Specs) Subjects() Specs {
+	return specs.WithLeafNodeType(types.NodeTypeIt)
+}
+
+// WithLeafNodeType returns the specs with a LeafNodeType matching one of the requested NodeTypes
+func (specs Specs) WithLeafNodeType(nodeTypes types.NodeType) Specs {
+	return specs.Filter(func(spec Spec) bool { return spec.LeafNodeType.Is(nodeTypes) })
+}
+
+// WithState returns the specs with a State matching one of the requested SpecStates
+func (specs Specs) WithState(states types.SpecState) Specs {
+	return specs.Filter(func(spec Spec) bool { return spec.State.Is(states) })
+}
+
+// WithLabel returns the specs that have the passed-in label, either directly or via one of their containers
+func (specs Specs) WithLabel(label string) Specs {
+	return specs.Filter(func(spec Spec) bool {
+		for _, l := range spec.Labels() {
+			if l == label {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// WithLabelFilter returns the specs that match the passed-in label filter query - the same syntax accepted by --label-filter
+func (specs Specs) WithLabelFilter(query string) (Specs, error) {
+	filter, err := types.ParseLabelFilter(query)
+	if err != nil {
+		return nil, err
+	}
+	return specs.Filter(func(spec Spec) bool { return filter(spec.Labels()) }), nil
+}
+
+// WithOwner returns the specs owned by the passed-in owner
+func (specs Specs) WithOwner(owner string) Specs {
+	return specs.Filter(func(spec Spec) bool {
+		for _, o := range spec.Owners() {
+			if o == owner {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// InSuite returns the specs that belong to the suite at the passed-in path
+func (specs Specs) InSuite(suitePath string) Specs {
+	return specs.Filter(func(spec Spec) bool { return spec.SuitePath == suitePath })
+}
+
+// DefinedIn returns the specs with at least one node - a container, a setup node, or the subject - defined in one of the passed-in files.
+// Files match if they are equal or if one is a path suffix of the other, so repository-relative paths (e.g. from "git diff --name-only") match the absolute paths in the report.
+func (specs Specs) DefinedIn(files ...string) Specs {
+	return specs.Filter(func(spec Spec) bool {
+		for _, sourceFile := range spec.SourceFiles {
+			for _, file := range files {
+				if sameSourceFile(sourceFile, file) {
+					return true
+				}
+			}
+		}
+		return false
+	})
+}
+
+func sameSourceFile(a, b string) bool {
+	a, b = filepath.ToSlash(filepath.Clean(a)), filepath.ToSlash(filepath.Clean(b))
+	if len(a) < len(b) {
+		a, b = b, a
+	}
+	return a == b || strings.HasSuffix(a, "/"+b)
+}
+
+// SlowerThan returns the specs that took longer than the passed-in duration to run
+func (specs Specs) SlowerThan(duration time.Duration) Specs {
+	return specs.Filter(func(spec Spec) bool { return spec.RunTime > duration })
+}
+
+// SortedByRunTime returns the specs sorted by RunTime, slowest first
+func (specs Specs) SortedByRunTime() Specs {
+	out := append(Specs{}, specs...)
+	sort.SliceStable(out, func(i, j int) bool { return out[i].RunTime > out[j].RunTime })
+	return out
+}
+
+// TotalRunTime returns the sum of the specs' RunTimes
+func (specs Specs) TotalRunTime() time.Duration {
+	total := time.Duration(0)
+	for _, spec := range specs {
+		total += spec.RunTime
+	}
+	return total
+}
+
+// SpecReports returns the underlying SpecReports
+func (specs Specs) SpecReports() types.SpecReports {
+	out := types.SpecReports{}
+	for _, spec := range specs {
+		out = append(out, spec.SpecReport)
+	}
+	return out
+}
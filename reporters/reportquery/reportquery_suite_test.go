@@ -0,0 +1,13 @@
+package reportquery_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestReportQuery(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "ReportQuery Suite")
+}
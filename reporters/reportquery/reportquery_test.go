@@ -0,0 +1,160 @@
+package reportquery_test
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/onsi/ginkgo/v2/reporters"
+	"github.com/onsi/ginkgo/v2/reporters/reportquery"
+	"github.com/onsi/ginkgo/v2/types"
+)
+
+func texts(specs reportquery.Specs) []string {
+	out := []string{}
+	for _, spec := range specs {
+		out = append(out, spec.LeafNodeText)
+	}
+	return out
+}
+
+func owners(owners string) types.ReportEntry {
+	return types.ReportEntry{Name: reportquery.OwnersReportEntry, Value: types.WrapEntryValue(owners)}
+}
+
+var _ = Describe("Querying reports", func() {
+	var reportA, reportB types.Report
+
+	BeforeEach(func() {
+		reportA = types.Report{
+			SuitePath:        "/path/to/a",
+			SuiteDescription: "A Suite",
+			SpecReports: types.SpecReports{
+				{LeafNodeType: types.NodeTypeBeforeSuite, State: types.SpecStatePassed, RunTime: time.Second},
+				{LeafNodeText: "A1", LeafNodeType: types.NodeTypeIt, State: types.SpecStatePassed, RunTime: 2 * time.Second, LeafNodeLabels: []string{"slow"}, ReportEntries: types.ReportEntries{owners("@db-team, @platform")}},
+				{LeafNodeText: "A2", LeafNodeType: types.NodeTypeIt, State: types.SpecStateFailed, RunTime: 3 * time.Second, SourceFiles: []string{"/src/a/a_suite_test.go", "/src/a/db_test.go"}, ContainerHierarchyLabels: [][]string{{"integration"}}, ReportEntries: types.ReportEntries{owners("@platform")}},
+				{LeafNodeText: "A3", LeafNodeType: types.NodeTypeIt, State: types.SpecStateSkipped},
+			},
+		}
+		reportB = types.Report{
+			SuitePath:        "/path/to/b",
+			SuiteDescription: "B Suite",
+			SpecReports: types.SpecReports{
+				{LeafNodeText: "B1", LeafNodeType: types.NodeTypeIt, State: types.SpecStatePanicked, RunTime: time.Second, SourceFiles: []string{"/src/b/b_test.go"}, LeafNodeLabels: []string{"slow", "integration"}},
+			},
+		}
+	})
+
+	Describe("Load", func() {
+		var dir string
+		BeforeEach(func() {
+			var err error
+			dir, err = os.MkdirTemp("", "reportquery")
+			Ω(err).ShouldNot(HaveOccurred())
+			DeferCleanup(os.RemoveAll, dir)
+		})
+
+		It("loads the specs from all the passed-in JSON reports, along with their suites", func() {
+			Ω(reporters.GenerateJSONReport(reportA, filepath.Join(dir, "a.json"))).Should(Succeed())
+			Ω(reporters.GenerateJSONReport(reportB, filepath.Join(dir, "b.json"))).Should(Succeed())
+
+			specs, err := reportquery.Load(filepath.Join(dir, "a.json"), filepath.Join(dir, "b.json"))
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(texts(specs)).Should(Equal([]string{"", "A1", "A2", "A3", "B1"}))
+			Ω(specs[1].SuitePath).Should(Equal("/path/to/a"))
+			Ω(specs[1].SuiteDescription).Should(Equal("A Suite"))
+			Ω(specs[1].Owners()).Should(Equal([]string{"@db-team", "@platform"}))
+			Ω(specs[4].SuiteDescription).Should(Equal("B Suite"))
+		})
+
+		It("returns an error if a report can't be read", func() {
+			Ω(os.WriteFile(filepath.Join(dir, "bad.json"), []byte("{"), 0644)).Should(Succeed())
+			specs, err := reportquery.Load(filepath.Join(dir, "bad.json"))
+			Ω(err).Should(MatchError(ContainSubstring("is not a valid Ginkgo JSON report")))
+			Ω(specs).Should(BeNil())
+		})
+	})
+
+	Describe("queries", func() {
+		var specs reportquery.Specs
+		BeforeEach(func() {
+			specs = reportquery.FromReports(reportA, reportB)
+		})
+
+		It("can select the subject specs", func() {
+			Ω(texts(specs.Subjects())).Should(Equal([]string{"A1", "A2", "A3", "B1"}))
+			Ω(specs.WithLeafNodeType(types.NodeTypeBeforeSuite)).Should(HaveLen(1))
+		})
+
+		It("can filter by state", func() {
+			Ω(texts(specs.WithState(types.SpecStateFailureStates))).Should(Equal([]string{"A2", "B1"}))
+		})
+
+		It("can filter by label, including labels inherited from containers", func() {
+			Ω(texts(specs.WithLabel("integration"))).Should(Equal([]string{"A2", "B1"}))
+
+			filtered, err := specs.WithLabelFilter("slow && !integration")
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(texts(filtered)).Should(Equal([]string{"A1"}))
+
+			_, err = specs.WithLabelFilter("(slow")
+			Ω(err).Should(HaveOccurred())
+		})
+
+		It("can filter by owner", func() {
+			Ω(texts(specs.WithOwner("@platform"))).Should(Equal([]string{"A1", "A2"}))
+			Ω(texts(specs.WithOwner("@db-team"))).Should(Equal([]string{"A1"}))
+			Ω(specs[3].Owners()).Should(BeNil())
+		})
+
+		It("can filter by suite", func() {
+			Ω(texts(specs.InSuite("/path/to/b"))).Should(Equal([]string{"B1"}))
+		})
+
+		It("can filter by the source files that define the specs", func() {
+			Ω(texts(specs.DefinedIn("/src/a/db_test.go"))).Should(Equal([]string{"A2"}))
+			Ω(texts(specs.DefinedIn("a/db_test.go", "src/b/b_test.go"))).Should(Equal([]string{"A2", "B1"}))
+			Ω(texts(specs.DefinedIn("db_test.go"))).Should(Equal([]string{"A2"}))
+			Ω(specs.DefinedIn("b_test.go/nope", "/src/a/other_test.go", "_test.go")).Should(BeEmpty())
+		})
+
+		It("can filter and sort by run time", func() {
+			Ω(texts(specs.Subjects().SlowerThan(time.Second))).Should(Equal([]string{"A1", "A2"}))
+			Ω(texts(specs.Subjects().SortedByRunTime())).Should(Equal([]string{"A2", "A1", "B1", "A3"}))
+			Ω(specs.Subjects().TotalRunTime()).Should(Equal(6 * time.Second))
+		})
+
+		It("can chain queries and apply custom filters", func() {
+			failedIntegration := specs.Subjects().WithLabel("integration").WithState(types.SpecStateFailureStates).Filter(func(spec reportquery.Spec) bool {
+				return spec.SuiteDescription == "A Suite"
+			})
+			Ω(texts(failedIntegration)).Should(Equal([]string{"A2"}))
+			Ω(failedIntegration.SpecReports()).Should(Equal(types.SpecReports{reportA.SpecReports[2]}))
+		})
+	})
+
+	Describe("typed accessors", func() {
+		It("returns report entries and annotations by name", func() {
+			spec := reportquery.FromReports(types.Report{SpecReports: types.SpecReports{{
+				ReportEntries: types.ReportEntries{
+					{Name: "db", Value: types.WrapEntryValue("first")},
+					{Name: "db", Value: types.WrapEntryValue("second")},
+				},
+				Annotations: types.SpecAnnotations{{Key: "ticket", Value: types.WrapEntryValue("JIRA-123")}},
+			}}})[0]
+
+			entry, ok := spec.ReportEntry("db")
+			Ω(ok).Should(BeTrue())
+			Ω(entry.StringRepresentation()).Should(Equal("second"))
+			_, ok = spec.ReportEntry("missing")
+			Ω(ok).Should(BeFalse())
+
+			value, ok := spec.Annotation("ticket")
+			Ω(ok).Should(BeTrue())
+			Ω(value).Should(Equal("JIRA-123"))
+		})
+	})
+})
@@ -0,0 +1,154 @@
+/*
+
+TAP Reporter for Ginkgo
+
+Generates reports in the Test Anything Protocol, version 13
+https://testanything.org/tap-version-13-specification.html
+*/
+
+package reporters
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/onsi/ginkgo/v2/types"
+)
+
+const tapVersion = "TAP version 13"
+
+var tapPlanRe = regexp.MustCompile(`^1\.\.\d+`)
+var tapTestLineRe = regexp.MustCompile(`^(ok|not ok) \d+`)
+
+// values that can be emitted as plain YAML scalars (so long as they don't contain ": ") - anything else is emitted as a literal block scalar
+var tapPlainScalarRe = regexp.MustCompile(`^[a-zA-Z0-9_./][a-zA-Z0-9_. /\-:]*[a-zA-Z0-9_./\-]$|^[a-zA-Z0-9_./]$`)
+
+// tapEscape escapes a test description so that it fits on a single line and can't be mistaken for a directive
+func tapEscape(s string) string {
+	s = strings.Replace(s, "\\", "\\\\", -1)
+	s = strings.Replace(s, "#", "\\#", -1)
+	s = strings.Replace(s, "\r", "", -1)
+	s = strings.Replace(s, "\n", " ", -1)
+	return s
+}
+
+// tapYAMLBlock renders the YAML diagnostic block that follows a test line.  Multi-line values are emitted as literal block scalars
+func tapYAMLBlock(fields [][2]string) string {
+	out := &strings.Builder{}
+	out.WriteString("  ---\n")
+	for _, field := range fields {
+		key, value := field[0], strings.TrimRight(field[1], "\n")
+		if value == "" {
+			continue
+		}
+		if tapPlainScalarRe.MatchString(value) && !strings.Contains(value, ": ") {
+			fmt.Fprintf(out, "  %s: %s\n", key, value)
+			continue
+		}
+		fmt.Fprintf(out, "  %s: |\n", key)
+		for _, line := range strings.Split(value, "\n") {
+			line = strings.Replace(line, "\r", "", -1)
+			if line == "" {
+				out.WriteString("\n")
+				continue
+			}
+			fmt.Fprintf(out, "    %s\n", line)
+		}
+	}
+	out.WriteString("  ...\n")
+	return out.String()
+}
+
+func GenerateTAPReport(report types.Report, dst string) error {
+	f, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+
+	name := report.SuiteDescription
+	if len(report.SuiteLabels) > 0 {
+		name = name + " [" + strings.Join(report.SuiteLabels, ", ") + "]"
+	}
+	fmt.Fprintln(f, tapVersion)
+	fmt.Fprintf(f, "1..%d\n", len(report.SpecReports))
+	fmt.Fprintf(f, "# %s\n", tapEscape(name))
+	for i, spec := range report.SpecReports {
+		name := fmt.Sprintf("[%s]", spec.LeafNodeType)
+		if spec.FullText() != "" {
+			name = name + " " + spec.FullText()
+		}
+		labels := spec.Labels()
+		if len(labels) > 0 {
+			name = name + " [" + strings.Join(labels, ", ") + "]"
+		}
+		name = tapEscape(name)
+
+		switch spec.State {
+		case types.SpecStatePassed:
+			fmt.Fprintf(f, "ok %d - %s\n", i+1, name)
+		case types.SpecStatePending:
+			fmt.Fprintf(f, "ok %d - %s # SKIP pending\n", i+1, name)
+		case types.SpecStateSkipped:
+			message := "skipped"
+			if spec.Failure.Message != "" {
+				message += " - " + spec.Failure.Message
+			}
+			fmt.Fprintf(f, "ok %d - %s # SKIP %s\n", i+1, name, tapEscape(message))
+		default:
+			fmt.Fprintf(f, "not ok %d - %s\n", i+1, name)
+			message := spec.Failure.Message
+			if spec.State == types.SpecStatePanicked {
+				message = spec.Failure.ForwardedPanic
+			}
+			location := ""
+			if spec.Failure.Location.FileName != "" {
+				location = spec.Failure.Location.String()
+			}
+			fmt.Fprint(f, tapYAMLBlock([][2]string{
+				{"severity", spec.State.String()},
+				{"message", message},
+				{"location", location},
+				{"duration_ms", strconv.Itoa(int(spec.RunTime.Seconds() * 1000.0))},
+				{"stack", spec.Failure.Location.FullStackTrace},
+				{"output", systemOutForUnstructureReporters(spec)},
+				{"ginkgo_writer_output", spec.CapturedGinkgoWriterOutput},
+			}))
+		}
+
+		for _, warning := range spec.Warnings {
+			fmt.Fprintf(f, "# WARNING: %s - %s\n", tapEscape(warning.Message), warning.Location)
+		}
+	}
+
+	return f.Close()
+}
+
+// MergeAndCleanupTAPReports merges the TAP reports generated by several suites into a single report, renumbering their tests and replacing their plans with a single plan
+func MergeAndCleanupTAPReports(sources []string, dst string) ([]string, error) {
+	messages := []string{}
+	numTests := 0
+	merged := &strings.Builder{}
+	for _, source := range sources {
+		data, err := os.ReadFile(source)
+		if err != nil {
+			messages = append(messages, fmt.Sprintf("Could not open %s:\n%s", source, err.Error()))
+			continue
+		}
+		os.Remove(source)
+		for _, line := range strings.Split(strings.TrimSuffix(string(data), "\n"), "\n") {
+			if line == tapVersion || tapPlanRe.MatchString(line) {
+				continue
+			}
+			if match := tapTestLineRe.FindStringSubmatch(line); match != nil {
+				numTests += 1
+				line = fmt.Sprintf("%s %d%s", match[1], numTests, line[len(match[0]):])
+			}
+			merged.WriteString(line + "\n")
+		}
+	}
+	out := fmt.Sprintf("%s\n1..%d\n%s", tapVersion, numTests, merged.String())
+	return messages, os.WriteFile(dst, []byte(out), 0666)
+}
@@ -0,0 +1,114 @@
+package reporters_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	"github.com/onsi/ginkgo/v2/reporters"
+	"github.com/onsi/ginkgo/v2/types"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("TAP Reports", func() {
+	var dir string
+	BeforeEach(func() {
+		var err error
+		dir, err = os.MkdirTemp("", "ginkgo-tap")
+		Ω(err).ShouldNot(HaveOccurred())
+		DeferCleanup(os.RemoveAll, dir)
+	})
+
+	readLines := func(path string) []string {
+		content, err := os.ReadFile(path)
+		Ω(err).ShouldNot(HaveOccurred())
+		return strings.Split(strings.TrimSuffix(string(content), "\n"), "\n")
+	}
+
+	report := func(description string, specs ...types.SpecReport) types.Report {
+		return types.Report{
+			SuiteDescription: description,
+			StartTime:        time.Now(),
+			SpecReports:      specs,
+		}
+	}
+
+	It("emits a plan, a test line per spec, and a YAML block for failures", func() {
+		path := filepath.Join(dir, "report.tap")
+		Ω(reporters.GenerateTAPReport(report("My Suite",
+			types.SpecReport{LeafNodeType: types.NodeTypeIt, LeafNodeText: "passes #1", LeafNodeLabels: []string{"fast"}, State: types.SpecStatePassed},
+			types.SpecReport{LeafNodeType: types.NodeTypeIt, LeafNodeText: "is pending", State: types.SpecStatePending},
+			types.SpecReport{LeafNodeType: types.NodeTypeIt, LeafNodeText: "is skipped", State: types.SpecStateSkipped, Failure: types.Failure{Message: "not today"}},
+			types.SpecReport{
+				LeafNodeType: types.NodeTypeIt,
+				LeafNodeText: "fails",
+				State:        types.SpecStateFailed,
+				RunTime:      1500 * time.Millisecond,
+				Failure: types.Failure{
+					Message:  "expected\n  true to be false",
+					Location: types.CodeLocation{FileName: "foo_test.go", LineNumber: 17},
+				},
+				Warnings: []types.Warning{{Message: "watch out", Location: types.CodeLocation{FileName: "foo_test.go", LineNumber: 12}}},
+			},
+		), path)).Should(Succeed())
+
+		Ω(readLines(path)).Should(Equal([]string{
+			"TAP version 13",
+			"1..4",
+			"# My Suite",
+			"ok 1 - [It] passes \\#1 [fast]",
+			"ok 2 - [It] is pending # SKIP pending",
+			"ok 3 - [It] is skipped # SKIP skipped - not today",
+			"not ok 4 - [It] fails",
+			"  ---",
+			"  severity: failed",
+			"  message: |",
+			"    expected",
+			"      true to be false",
+			"  location: foo_test.go:17",
+			"  duration_ms: 1500",
+			"  output: |",
+			"",
+			"    Warnings:",
+			"    foo_test.go:12",
+			"    watch out",
+			"  ...",
+			"# WARNING: watch out - foo_test.go:12",
+		}))
+	})
+
+	It("merges reports into a single stream with one plan and renumbered tests", func() {
+		first, second := filepath.Join(dir, "first.tap"), filepath.Join(dir, "second.tap")
+		Ω(reporters.GenerateTAPReport(report("First",
+			types.SpecReport{LeafNodeType: types.NodeTypeIt, LeafNodeText: "a", State: types.SpecStatePassed},
+			types.SpecReport{LeafNodeType: types.NodeTypeIt, LeafNodeText: "b", State: types.SpecStateFailed},
+		), first)).Should(Succeed())
+		Ω(reporters.GenerateTAPReport(report("Second",
+			types.SpecReport{LeafNodeType: types.NodeTypeIt, LeafNodeText: "c", State: types.SpecStatePassed},
+		), second)).Should(Succeed())
+
+		merged := filepath.Join(dir, "merged.tap")
+		messages, err := reporters.MergeAndCleanupTAPReports([]string{first, second, filepath.Join(dir, "missing.tap")}, merged)
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(messages).Should(HaveLen(1))
+		Ω(messages[0]).Should(ContainSubstring("missing.tap"))
+
+		Ω(readLines(merged)).Should(Equal([]string{
+			"TAP version 13",
+			"1..3",
+			"# First",
+			"ok 1 - [It] a",
+			"not ok 2 - [It] b",
+			"  ---",
+			"  severity: failed",
+			"  duration_ms: 0",
+			"  ...",
+			"# Second",
+			"ok 3 - [It] c",
+		}))
+		Ω(first).ShouldNot(BeAnExistingFile())
+		Ω(second).ShouldNot(BeAnExistingFile())
+	})
+})
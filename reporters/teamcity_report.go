@@ -67,11 +67,20 @@ func GenerateTeamcityReport(report types.Report, dst string) error {
 			fmt.Fprintf(f, "##teamcity[testFailed name='%s' message='panicked - %s' details='%s']\n", name, tcEscape(spec.Failure.ForwardedPanic), tcEscape(details))
 		case types.SpecStateInterrupted:
 			fmt.Fprintf(f, "##teamcity[testFailed name='%s' message='interrupted' details='%s']\n", name, tcEscape(spec.Failure.Message))
+		case types.SpecStateTimedout:
+			details := fmt.Sprintf("%s\n%s", spec.Failure.Location.String(), spec.Failure.Location.FullStackTrace)
+			fmt.Fprintf(f, "##teamcity[testFailed name='%s' message='timedout - %s' details='%s']\n", name, tcEscape(spec.Failure.Message), tcEscape(details))
 		case types.SpecStateAborted:
 			details := fmt.Sprintf("%s\n%s", spec.Failure.Location.String(), spec.Failure.Location.FullStackTrace)
 			fmt.Fprintf(f, "##teamcity[testFailed name='%s' message='aborted - %s' details='%s']\n", name, tcEscape(spec.Failure.Message), tcEscape(details))
 		}
 
+		for _, warning := range spec.Warnings {
+			fmt.Fprintf(f, "##teamcity[message text='%s' status='WARNING']\n", tcEscape(fmt.Sprintf("%s - %s", warning.Message, warning.Location)))
+		}
+		for _, path := range append(append([]string{}, spec.FailureArtifacts...), spec.NetworkCaptures...) {
+			fmt.Fprintf(f, "##teamcity[publishArtifacts '%s']\n", tcEscape(path))
+		}
 		fmt.Fprintf(f, "##teamcity[testStdOut name='%s' out='%s']\n", name, tcEscape(systemOutForUnstructureReporters(spec)))
 		fmt.Fprintf(f, "##teamcity[testStdErr name='%s' out='%s']\n", name, tcEscape(spec.CapturedGinkgoWriterOutput))
 		fmt.Fprintf(f, "##teamcity[testFinished name='%s' duration='%d']\n", name, int(spec.RunTime.Seconds()*1000.0))
@@ -72,6 +72,80 @@ func AddReportEntry(name string, args ...interface{}) {
 	}
 }
 
+/*
+AddSpecAnnotation attaches a piece of typed metadata to the running spec.  It is the runtime equivalent of the Annotate decorator and overrides
+any annotation with the same key attached by a decorator.  Annotations are recorded in the spec's SpecReport.Annotations.
+
+AddSpecAnnotation must be called within a Subject or Setup node - not in a Container node.
+
+You can learn more about annotations here: https://onsi.github.io/ginkgo/#annotating-specs
+*/
+func AddSpecAnnotation(key string, value interface{}) {
+	cl := types.NewCodeLocation(1)
+	err := global.Suite.AddSpecAnnotation(types.SpecAnnotation{Key: key, Value: types.WrapEntryValue(value), Location: cl})
+	if err != nil {
+		Fail(fmt.Sprintf("Failed to add Spec Annotation:\n%s", err.Error()), 1)
+	}
+}
+
+/*
+FailureArtifactCollector is implemented by integrations - browser and UI drivers, for example - that can capture artifacts like screenshots
+or page sources when a spec fails.  CollectFailureArtifacts is passed the failing spec's SpecContext and a directory, unique to the spec, to write
+its artifacts to.  It returns the paths of the artifacts it wrote.
+
+You can learn more about FailureArtifactCollectors here: https://onsi.github.io/ginkgo/#collecting-failure-artifacts
+*/
+type FailureArtifactCollector = internal.FailureArtifactCollector
+
+/*
+RegisterFailureArtifactCollector registers a FailureArtifactCollector with Ginkgo.  Whenever a spec fails Ginkgo calls each registered collector
+immediately - before the spec's AfterEach and DeferCleanup nodes run - and records the artifacts they return in the spec's SpecReport.FailureArtifacts.
+Failure artifacts are listed in Ginkgo's console output and are attached to the spec in JUnit and Teamcity reports.
+
+Artifacts are written under --artifacts-dir, or a temporary directory if it isn't set.  A collector that returns an error, or panics, is reported as
+a warning and never changes the outcome of the spec.
+
+RegisterFailureArtifactCollector returns true so that it can be called at the top level of a file:
+
+	var _ = RegisterFailureArtifactCollector(browserScreenshotter)
+
+You can learn more about FailureArtifactCollectors here: https://onsi.github.io/ginkgo/#collecting-failure-artifacts
+*/
+func RegisterFailureArtifactCollector(collector FailureArtifactCollector) bool {
+	global.Suite.RegisterFailureArtifactCollector(collector, types.NewCodeLocation(1))
+	return true
+}
+
+/*
+NetworkCapturer is implemented by integrations that can capture network traffic - with a packet capture or an HTTP proxy, for example - around
+individual specs.  StartCapture is passed the spec's SpecContext and a directory, unique to the spec, to save its captures in.  StopCapture returns
+the paths of the captures it saved.
+
+You can learn more about NetworkCapturers here: https://onsi.github.io/ginkgo/#capturing-network-traffic
+*/
+type NetworkCapturer = internal.NetworkCapturer
+
+/*
+RegisterNetworkCapture registers a NetworkCapturer that runs around every spec whose labels match labelFilter.  labelFilter uses the same syntax
+as --label-filter.  Ginkgo starts the capture before any of the spec's setup nodes run and stops it after all its cleanup nodes have run.  Each
+attempt at running a flaky spec is captured separately.
+
+Captures are saved under --artifacts-dir, or a temporary directory if it isn't set, and their paths are recorded in the spec's SpecReport.NetworkCaptures.
+A capturer that returns an error, or panics, is reported as a warning and never changes the outcome of the spec.
+
+RegisterNetworkCapture returns true so that it can be called at the top level of a file:
+
+	var _ = RegisterNetworkCapture("e2e && network", proxyCapturer)
+
+You can learn more about NetworkCapturers here: https://onsi.github.io/ginkgo/#capturing-network-traffic
+*/
+func RegisterNetworkCapture(labelFilter string, capturer NetworkCapturer) bool {
+	filter, err := types.ParseLabelFilter(labelFilter)
+	exitIfErr(err)
+	global.Suite.RegisterNetworkCapturer(capturer, filter, types.NewCodeLocation(1))
+	return true
+}
+
 /*
 ReportBeforeEach nodes are run for each spec, even if the spec is skipped or pending.  ReportBeforeEach nodes take a function that
 receives a SpecReport.  They are called before the spec starts.
@@ -103,7 +177,7 @@ ReportAftersuite nodes must be created at the top-level (i.e. not nested in a Co
 When running in parallel, Ginkgo ensures that only one of the parallel nodes runs the ReportAfterSuite and that it is passed a report that is aggregated across
 all parallel nodes
 
-In addition to using ReportAfterSuite to programmatically generate suite reports, you can also generate JSON, JUnit, and Teamcity formatted reports using the --json-report, --junit-report, and --teamcity-report ginkgo CLI flags.
+In addition to using ReportAfterSuite to programmatically generate suite reports, you can also generate JSON, JUnit, Teamcity, and TAP formatted reports using the --json-report, --junit-report, --teamcity-report, and --tap-report ginkgo CLI flags.
 
 You cannot nest any other Ginkgo nodes within a ReportAfterSuite node's closure.
 You can learn more about ReportAfterSuite here: https://onsi.github.io/ginkgo/#generating-reports-programmatically
@@ -116,13 +190,15 @@ func ReportAfterSuite(text string, body func(Report)) bool {
 func registerReportAfterSuiteNodeForAutogeneratedReports(reporterConfig types.ReporterConfig) {
 	body := func(report Report) {
 		if reporterConfig.JSONReport != "" {
-			err := reporters.GenerateJSONReport(report, reporterConfig.JSONReport)
+			filter, _ := reporterConfig.JSONReportEntryFilter()
+			err := reporters.GenerateJSONReport(report.WithFilteredReportEntries(filter), reporterConfig.JSONReport)
 			if err != nil {
 				Fail(fmt.Sprintf("Failed to generate JSON report:\n%s", err.Error()))
 			}
 		}
 		if reporterConfig.JUnitReport != "" {
-			err := reporters.GenerateJUnitReport(report, reporterConfig.JUnitReport)
+			filter, _ := reporterConfig.JUnitReportEntryFilter()
+			err := reporters.GenerateJUnitReport(report.WithFilteredReportEntries(filter), reporterConfig.JUnitReport)
 			if err != nil {
 				Fail(fmt.Sprintf("Failed to generate JUnit report:\n%s", err.Error()))
 			}
@@ -133,6 +209,12 @@ func registerReportAfterSuiteNodeForAutogeneratedReports(reporterConfig types.Re
 				Fail(fmt.Sprintf("Failed to generate Teamcity report:\n%s", err.Error()))
 			}
 		}
+		if reporterConfig.TAPReport != "" {
+			err := reporters.GenerateTAPReport(report, reporterConfig.TAPReport)
+			if err != nil {
+				Fail(fmt.Sprintf("Failed to generate TAP report:\n%s", err.Error()))
+			}
+		}
 	}
 
 	flags := []string{}
@@ -145,6 +227,9 @@ func registerReportAfterSuiteNodeForAutogeneratedReports(reporterConfig types.Re
 	if reporterConfig.TeamcityReport != "" {
 		flags = append(flags, "--teamcity-report")
 	}
+	if reporterConfig.TAPReport != "" {
+		flags = append(flags, "--tap-report")
+	}
 	pushNode(internal.NewReportAfterSuiteNode(
 		fmt.Sprintf("Autogenerated ReportAfterSuite for %s", strings.Join(flags, " ")),
 		body,
@@ -0,0 +1,85 @@
+package ginkgo
+
+import (
+	"github.com/onsi/ginkgo/v2/internal"
+	"github.com/onsi/ginkgo/v2/internal/global"
+	"github.com/onsi/ginkgo/v2/types"
+)
+
+/*
+SharedExamples defines a named group of specs that can be included in any number of containers with ItBehavesLike.  body can contain any
+Ginkgo nodes a Describe can contain and can accept any number of parameters - ItBehavesLike passes its arguments into body.
+
+SharedExamples is typically used to write a conformance suite for an interface once and run it against each implementation:
+
+	var _ = SharedExamples("a key-value store", func(newStore func() Store) {
+		var store Store
+		BeforeEach(func() {
+			store = newStore()
+		})
+
+		It("returns the values it stores", func() {
+			store.Set("key", "value")
+			Ω(store.Get("key")).Should(Equal("value"))
+		})
+	})
+
+	var _ = Describe("the in-memory store", func() {
+		ItBehavesLike("a key-value store", NewInMemoryStore)
+	})
+
+SharedExamples returns true so that it can be called at the top level of a file.  Names must be unique within a suite.
+
+You can learn more about SharedExamples here: https://onsi.github.io/ginkgo/#shared-examples-itbehaveslike
+*/
+func SharedExamples(name string, body interface{}) bool {
+	exitIfErr(global.Suite.RegisterSharedExamples(name, body, types.NewCodeLocation(1)))
+	return true
+}
+
+/*
+ItBehavesLike expands the SharedExamples registered with name into a container node with the text "behaves like <name>".  Any decorators passed to ItBehavesLike
+are applied to the container.  The remaining arguments are passed into the SharedExamples body and must match its parameters.
+
+You can learn more about ItBehavesLike here: https://onsi.github.io/ginkgo/#shared-examples-itbehaveslike
+*/
+func ItBehavesLike(name string, args ...interface{}) bool {
+	return pushSharedExamples(name, args...)
+}
+
+/*
+You can focus shared examples with `FItBehavesLike`.  This is equivalent to `FDescribe`.
+*/
+func FItBehavesLike(name string, args ...interface{}) bool {
+	return pushSharedExamples(name, append(args, internal.Focus)...)
+}
+
+/*
+You can mark shared examples as pending with `PItBehavesLike`.  This is equivalent to `PDescribe`.
+*/
+func PItBehavesLike(name string, args ...interface{}) bool {
+	return pushSharedExamples(name, append(args, internal.Pending)...)
+}
+
+/*
+You can mark shared examples as pending with `XItBehavesLike`.  This is equivalent to `XDescribe`.
+*/
+var XItBehavesLike = PItBehavesLike
+
+func pushSharedExamples(name string, args ...interface{}) bool {
+	cl := types.NewCodeLocation(2)
+	decorations, parameters := internal.PartitionDecorations(args...)
+	containerNodeArgs := []interface{}{cl}
+	containerNodeArgs = append(containerNodeArgs, decorations...)
+	containerNodeArgs = append(containerNodeArgs, func() {
+		// the shared examples are looked up when the container is entered so that they can be defined in any file in the package
+		body, found := global.Suite.SharedExamples(name)
+		if !found {
+			exitIfErr(types.GinkgoErrors.UnknownSharedExamples(name, cl))
+		}
+		exitIfErr(validateParameters(body, parameters, "SharedExamples function", cl))
+		invokeFunction(body, parameters)
+	})
+
+	return pushNode(internal.NewNode(deprecationTracker, types.NodeTypeContainer, "behaves like "+name, containerNodeArgs...))
+}
@@ -1,11 +1,18 @@
 package ginkgo
 
 import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
 	"fmt"
+	"os"
+	"path/filepath"
 	"reflect"
 	"strings"
 
 	"github.com/onsi/ginkgo/v2/internal"
+	"github.com/onsi/ginkgo/v2/internal/global"
 	"github.com/onsi/ginkgo/v2/types"
 )
 
@@ -26,6 +33,26 @@ func (ed EntryDescription) render(args ...interface{}) string {
 	return fmt.Sprintf(string(ed), args...)
 }
 
+/*
+The GoldenFiles decorator turns a DescribeTable into a snapshot table.  The table's body returns a value and, rather than asserting on it, Ginkgo compares it
+against the entry's golden file in the passed-in directory:
+
+    DescribeTable("rendering invoices",
+        func(invoice Invoice) string {
+            return Render(invoice)
+        },
+        GoldenFiles("testdata/invoices"),
+        Entry("an empty invoice", Invoice{}),
+        Entry("a discounted invoice", Invoice{Discount: 10}),
+    )
+
+compares the rendered invoices against testdata/invoices/an_empty_invoice.golden and testdata/invoices/a_discounted_invoice.golden.
+Strings and byte slices are compared as-is, anything else is compared as indented JSON.  Run with --update-snapshots to (re)write the golden files.
+
+You can learn more about GoldenFiles here: https://onsi.github.io/ginkgo/#snapshot-testing-tables-with-goldenfiles
+*/
+type GoldenFiles string
+
 /*
 DescribeTable describes a table-driven spec.
 
@@ -40,11 +67,14 @@ For example:
         Entry("x < y", 0, 1, false),
     )
 
+If the table's body accepts a SpecContext (or context.Context) as its first parameter, Ginkgo passes each spec's context to it ahead of the Entry's parameters.
+The context is cancelled when the spec is interrupted or when an Entry decorated with NodeTimeout runs out of time.
+
 You can learn more about DescribeTable here: https://onsi.github.io/ginkgo/#table-specs
 And can explore some Table patterns here: https://onsi.github.io/ginkgo/#table-specs-patterns
 */
 func DescribeTable(description string, args ...interface{}) bool {
-	generateTable(description, args...)
+	generateTable(description, false, args...)
 	return true
 }
 
@@ -53,7 +83,7 @@ You can focus a table with `FDescribeTable`.  This is equivalent to `FDescribe`.
 */
 func FDescribeTable(description string, args ...interface{}) bool {
 	args = append(args, internal.Focus)
-	generateTable(description, args...)
+	generateTable(description, false, args...)
 	return true
 }
 
@@ -62,7 +92,7 @@ You can mark a table as pending with `PDescribeTable`.  This is equivalent to `P
 */
 func PDescribeTable(description string, args ...interface{}) bool {
 	args = append(args, internal.Pending)
-	generateTable(description, args...)
+	generateTable(description, false, args...)
 	return true
 }
 
@@ -71,6 +101,61 @@ You can mark a table as pending with `XDescribeTable`.  This is equivalent to `X
 */
 var XDescribeTable = PDescribeTable
 
+/*
+DescribeTableSubtree describes a table-driven container.  Where each Entry in a DescribeTable generates a single It, each Entry in a
+DescribeTableSubtree generates a container.  The table's body is called with the Entry's parameters and can define any nodes a Describe can:
+
+    DescribeTableSubtree("a storage backend",
+        func(newBackend func() Backend) {
+            var backend Backend
+            BeforeEach(func() {
+                backend = newBackend()
+            })
+
+            It("stores objects", func() {
+                Ω(backend.Put("key", "value")).Should(Succeed())
+            })
+
+            It("lists objects", func() {
+                ...
+            })
+        },
+        Entry("in memory", NewMemoryBackend),
+        Entry("on disk", NewDiskBackend, Label("slow")),
+    )
+
+Decorators passed to an Entry are applied to the container it generates.
+
+You can learn more about DescribeTableSubtree here: https://onsi.github.io/ginkgo/#table-specs-with-subtrees-describetablesubtree
+*/
+func DescribeTableSubtree(description string, args ...interface{}) bool {
+	generateTable(description, true, args...)
+	return true
+}
+
+/*
+You can focus a table with `FDescribeTableSubtree`.  This is equivalent to `FDescribe`.
+*/
+func FDescribeTableSubtree(description string, args ...interface{}) bool {
+	args = append(args, internal.Focus)
+	generateTable(description, true, args...)
+	return true
+}
+
+/*
+You can mark a table as pending with `PDescribeTableSubtree`.  This is equivalent to `PDescribe`.
+*/
+func PDescribeTableSubtree(description string, args ...interface{}) bool {
+	args = append(args, internal.Pending)
+	generateTable(description, true, args...)
+	return true
+}
+
+/*
+You can mark a table as pending with `XDescribeTableSubtree`.  This is equivalent to `XDescribe`.
+*/
+var XDescribeTableSubtree = PDescribeTableSubtree
+
 /*
 TableEntry represents an entry in a table test.  You generally use the `Entry` constructor.
 */
@@ -78,6 +163,7 @@ type TableEntry struct {
 	description  interface{}
 	decorations  []interface{}
 	parameters   []interface{}
+	subtree      interface{}
 	codeLocation types.CodeLocation
 }
 
@@ -86,6 +172,9 @@ Entry constructs a TableEntry.
 
 The first argument is a description.  This can be a string, a function that accepts the parameters passed to the TableEntry and returns a string, an EntryDescription format string, or nil.  If nil is provided then the name of the Entry is derived using the table-level entry description.
 Subsequent arguments accept any Ginkgo decorators.  These are filtered out and the remaining arguments are passed into the Spec function associated with the table.
+Decorators apply only to the spec the Entry generates, so you can label, retry, serialize, or time out a single problematic entry:
+
+    Entry("against the slow backend", Label("slow"), FlakeAttempts(3), Serial, NodeTimeout(time.Minute), "slow-backend", true),
 
 Each Entry ends up generating an individual Ginkgo It.  The body of the it is the Table Body function with the Entry parameters passed in.
 
@@ -119,12 +208,498 @@ You can mark a particular entry as pending with XEntry.  This is equivalent to X
 */
 var XEntry = PEntry
 
-func generateTable(description string, args ...interface{}) {
+/*
+SubtreeEntry constructs a TableEntry that generates a container instead of a single It.  It accepts the same description, decorators, and parameters as Entry
+followed by a body function - the last argument - that can define any nodes a Describe can.  The body is called with the Entry's parameters (or with none, if it accepts none)
+and the table's body is then added to the container as an It that runs after the entry's setup:
+
+    DescribeTable("importing a document",
+        func(path string, pages int) {
+            Expect(importer.Import(path)).To(HaveLen(pages))
+        },
+        Entry("a plain document", "plain.pdf", 3),
+        SubtreeEntry("an encrypted document", "encrypted.pdf", 2, func() {
+            BeforeEach(func() {
+                importer.SetPassword("sesame")
+            })
+
+            It("records that the document was decrypted", func() {
+                ...
+            })
+        }),
+    )
+
+In a DescribeTableSubtree the table's body and the entry's body both populate the entry's container.  Decorators passed to a SubtreeEntry apply to its container - except for
+NodeTimeout which, in a DescribeTable, bounds the It generated for the table's body.
+
+You can learn more about SubtreeEntry here: https://onsi.github.io/ginkgo/#entries-with-their-own-specs-subtreeentry
+*/
+func SubtreeEntry(description interface{}, args ...interface{}) TableEntry {
+	return newSubtreeEntry(description, args, types.NewCodeLocation(1))
+}
+
+/*
+You can focus a particular subtree entry with FSubtreeEntry.  This is equivalent to FDescribe.
+*/
+func FSubtreeEntry(description interface{}, args ...interface{}) TableEntry {
+	entry := newSubtreeEntry(description, args, types.NewCodeLocation(1))
+	entry.decorations = append(entry.decorations, internal.Focus)
+	return entry
+}
+
+/*
+You can mark a particular subtree entry as pending with PSubtreeEntry.  This is equivalent to PDescribe.
+*/
+func PSubtreeEntry(description interface{}, args ...interface{}) TableEntry {
+	entry := newSubtreeEntry(description, args, types.NewCodeLocation(1))
+	entry.decorations = append(entry.decorations, internal.Pending)
+	return entry
+}
+
+/*
+You can mark a particular subtree entry as pending with XSubtreeEntry.  This is equivalent to XDescribe.
+*/
+var XSubtreeEntry = PSubtreeEntry
+
+func newSubtreeEntry(description interface{}, args []interface{}, cl types.CodeLocation) TableEntry {
+	if len(args) == 0 || reflect.TypeOf(args[len(args)-1]) == nil || reflect.TypeOf(args[len(args)-1]).Kind() != reflect.Func {
+		exitIfErr(types.GinkgoErrors.MissingSubtreeEntryBody(cl))
+	}
+	decorations, parameters := internal.PartitionDecorations(args[:len(args)-1]...)
+	return TableEntry{description: description, decorations: decorations, parameters: parameters, subtree: args[len(args)-1], codeLocation: cl}
+}
+
+/*
+EntriesFromJSON loads table entries from a JSON fixture file.  The file must contain an array with one element per entry.  Each element is either
+an array of the entry's parameters or an object with an optional "description" and a "parameters" array:
+
+    [
+        {"description": "x > y", "parameters": [1, 0, true]},
+        [0, 0, false]
+    ]
+
+Entries without a description are named using the table-level entry description.  Any decorators passed to EntriesFromJSON are applied to every entry.
+
+The file is read when the table is constructed.  Parameters are decoded into the types the table's body function expects, using encoding/json.
+
+You can learn more about loading entries from fixture files here: https://onsi.github.io/ginkgo/#loading-table-entries-from-fixture-files
+*/
+func EntriesFromJSON(path string, decorations ...interface{}) []TableEntry {
+	cl := types.NewCodeLocation(1)
+	decorations = tableFixtureDecorations(path, decorations, cl)
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		exitIfErr(types.GinkgoErrors.InvalidTableFixture(path, err, cl))
+	}
+	var rows []json.RawMessage
+	if err := json.Unmarshal(data, &rows); err != nil {
+		exitIfErr(types.GinkgoErrors.InvalidTableFixture(path, err, cl))
+	}
+
+	entries := []TableEntry{}
+	for i, row := range rows {
+		var description interface{}
+		var rawParameters []json.RawMessage
+		if trimmed := bytes.TrimSpace(row); len(trimmed) > 0 && trimmed[0] == '{' {
+			var object struct {
+				Description *string
+				Parameters  []json.RawMessage
+			}
+			err = json.Unmarshal(row, &object)
+			if object.Description != nil {
+				description = *object.Description
+			}
+			rawParameters = object.Parameters
+		} else {
+			err = json.Unmarshal(row, &rawParameters)
+		}
+		if err != nil {
+			exitIfErr(types.GinkgoErrors.InvalidTableFixture(path, fmt.Errorf("entry #%d: %w", i+1, err), cl))
+		}
+
+		parameters := []interface{}{}
+		for _, rawParameter := range rawParameters {
+			parameters = append(parameters, fixtureParameter{json: rawParameter})
+		}
+		entries = append(entries, TableEntry{description: description, decorations: decorations, parameters: parameters, codeLocation: cl})
+	}
+	return entries
+}
+
+/*
+EntriesFromCSV loads table entries from a CSV fixture file.  The first row of the file is a header and each subsequent row is an entry.
+The values in a column named "description" are used as the entries' descriptions - the values in the remaining columns, in order, are the entries' parameters:
+
+    description,x,y,expected
+    x > y,1,0,true
+    ,0,0,false
+
+Entries with an empty description (or all entries, if there is no "description" column) are named using the table-level entry description.
+Any decorators passed to EntriesFromCSV are applied to every entry.
+
+The file is read when the table is constructed.  Values are passed as-is to string parameters, and are otherwise decoded into the types the table's body function expects as JSON.
+So 1.5, true and {"name": "a"} can be decoded into a float64, a bool, and a struct.  Empty values are decoded as the zero value of the expected type.
+
+You can learn more about loading entries from fixture files here: https://onsi.github.io/ginkgo/#loading-table-entries-from-fixture-files
+*/
+func EntriesFromCSV(path string, decorations ...interface{}) []TableEntry {
+	cl := types.NewCodeLocation(1)
+	decorations = tableFixtureDecorations(path, decorations, cl)
+
+	f, err := os.Open(path)
+	if err != nil {
+		exitIfErr(types.GinkgoErrors.InvalidTableFixture(path, err, cl))
+	}
+	defer f.Close()
+	rows, err := csv.NewReader(f).ReadAll()
+	if err != nil {
+		exitIfErr(types.GinkgoErrors.InvalidTableFixture(path, err, cl))
+	}
+
+	entries := []TableEntry{}
+	if len(rows) == 0 {
+		return entries
+	}
+	descriptionColumn := -1
+	for i, column := range rows[0] {
+		if strings.TrimSpace(column) == "description" {
+			descriptionColumn = i
+		}
+	}
+	for _, row := range rows[1:] {
+		var description interface{}
+		parameters := []interface{}{}
+		for i, value := range row {
+			if i == descriptionColumn {
+				if value != "" {
+					description = value
+				}
+				continue
+			}
+			parameters = append(parameters, fixtureParameter{text: value, isText: true})
+		}
+		entries = append(entries, TableEntry{description: description, decorations: decorations, parameters: parameters, codeLocation: cl})
+	}
+	return entries
+}
+
+/*
+EntriesFromFuzzCorpus generates a table entry for each input in a Go fuzz corpus directory - typically testdata/fuzz/FuzzX, where "go test -fuzz" stores the inputs that make a fuzz target fail.
+This lets the regression cases discovered by fuzzing run as named specs:
+
+    DescribeTable("parsing",
+        func(input []byte, strict bool) {
+            _, err := Parse(input, strict)
+            Expect(err).NotTo(HaveOccurred())
+        },
+        EntriesFromFuzzCorpus("testdata/fuzz/FuzzParse"),
+    )
+
+Each entry is named after its corpus file and its parameters are the values in the file, in order - so the table's body function should have the same parameters as the fuzz target.
+Any decorators passed to EntriesFromFuzzCorpus are applied to every entry.
+
+The directory is read when the table is constructed.  Files must use the "go test fuzz v1" encoding that the go tool writes.
+
+You can learn more about loading entries from fixture files here: https://onsi.github.io/ginkgo/#loading-table-entries-from-fixture-files
+*/
+func EntriesFromFuzzCorpus(dir string, decorations ...interface{}) []TableEntry {
+	cl := types.NewCodeLocation(1)
+	decorations = tableFixtureDecorations(dir, decorations, cl)
+
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		exitIfErr(types.GinkgoErrors.InvalidTableFixture(dir, err, cl))
+	}
+
+	entries := []TableEntry{}
+	for _, file := range files {
+		if file.IsDir() {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(dir, file.Name()))
+		var parameters []interface{}
+		if err == nil {
+			parameters, err = internal.ParseFuzzCorpusEntry(data)
+		}
+		if err != nil {
+			exitIfErr(types.GinkgoErrors.InvalidTableFixture(dir, fmt.Errorf("%s: %w", file.Name(), err), cl))
+		}
+		entries = append(entries, TableEntry{description: file.Name(), decorations: decorations, parameters: parameters, codeLocation: cl})
+	}
+	return entries
+}
+
+/*
+CombinatorialEntries generates a table entry for every combination of the values in the passed-in dimensions - i.e. their cartesian product.
+Each entry's parameters are one value from each dimension, in order:
+
+    DescribeTable("uploading",
+        func(os string, arch string, compressed bool) {
+            ...
+        },
+        CombinatorialEntries(
+            []interface{}{"linux", "darwin", "windows"},
+            []interface{}{"amd64", "arm64"},
+            []interface{}{true, false},
+        ),
+    )
+
+generates 12 entries.  The first dimension varies slowest, just like nested loops.  Entries are named using the table-level entry description.
+
+When the full product is too large, use PairwiseEntries instead.
+
+You can learn more about generating entries here: https://onsi.github.io/ginkgo/#generating-combinations-of-entries
+*/
+func CombinatorialEntries(dimensions ...[]interface{}) []TableEntry {
+	cl := types.NewCodeLocation(1)
+	entries := []TableEntry{}
+	for _, indices := range productCombinations(dimensions) {
+		entries = append(entries, combinationEntry(dimensions, indices, cl))
+	}
+	return entries
+}
+
+/*
+PairwiseEntries generates table entries for the passed-in dimensions, just like CombinatorialEntries, but rather than generating every combination
+it generates a much smaller set of entries in which every pair of values from any two dimensions appears at least once.  Most compatibility bugs are
+triggered by the interaction of two parameters so pairwise testing catches most of them at a fraction of the cost of the full product.
+
+The set of entries is deterministic: the same dimensions always generate the same entries.
+
+You can learn more about generating entries here: https://onsi.github.io/ginkgo/#generating-combinations-of-entries
+*/
+func PairwiseEntries(dimensions ...[]interface{}) []TableEntry {
+	cl := types.NewCodeLocation(1)
+	entries := []TableEntry{}
+	for _, indices := range pairwiseCombinations(dimensions) {
+		entries = append(entries, combinationEntry(dimensions, indices, cl))
+	}
+	return entries
+}
+
+// productCombinations returns every combination, as indices into each dimension, of the values in the passed-in dimensions.  The first dimension varies slowest.
+func productCombinations(dimensions [][]interface{}) [][]int {
+	combinations := [][]int{}
+	if len(dimensions) == 0 {
+		return combinations
+	}
+	for _, dimension := range dimensions {
+		if len(dimension) == 0 {
+			return combinations
+		}
+	}
+	indices := make([]int, len(dimensions))
+	for {
+		combinations = append(combinations, append([]int{}, indices...))
+		d := len(dimensions) - 1
+		for ; d >= 0; d-- {
+			indices[d] += 1
+			if indices[d] < len(dimensions[d]) {
+				break
+			}
+			indices[d] = 0
+		}
+		if d < 0 {
+			return combinations
+		}
+	}
+}
+
+func combinationEntry(dimensions [][]interface{}, indices []int, cl types.CodeLocation) TableEntry {
+	parameters := make([]interface{}, len(dimensions))
+	for d, i := range indices {
+		parameters[d] = dimensions[d][i]
+	}
+	return TableEntry{description: nil, decorations: []interface{}{}, parameters: parameters, codeLocation: cl}
+}
+
+// pairwiseCombinations returns combinations, as indices into each dimension, that cover every pair of values from any two dimensions.
+// It uses the In-Parameter-Order strategy: the combinations for the first two dimensions are their product and each subsequent dimension
+// is added by first extending the existing combinations with the values that cover the most uncovered pairs, and then adding combinations for any pairs that remain.
+func pairwiseCombinations(dimensions [][]interface{}) [][]int {
+	const unset = -1
+	if len(dimensions) < 3 {
+		// with fewer than three dimensions every combination is needed to cover every pair
+		return productCombinations(dimensions)
+	}
+	for _, dimension := range dimensions {
+		if len(dimension) == 0 {
+			return [][]int{}
+		}
+	}
+
+	newCombination := func() []int {
+		combination := make([]int, len(dimensions))
+		for d := range combination {
+			combination[d] = unset
+		}
+		return combination
+	}
+
+	combinations := [][]int{}
+	for i := range dimensions[0] {
+		for j := range dimensions[1] {
+			combination := newCombination()
+			combination[0], combination[1] = i, j
+			combinations = append(combinations, combination)
+		}
+	}
+
+	for k := 2; k < len(dimensions); k++ {
+		// uncovered[d][a][v] is true if value a of dimension d has not yet appeared alongside value v of dimension k
+		uncovered := make([][][]bool, k)
+		for d := 0; d < k; d++ {
+			uncovered[d] = make([][]bool, len(dimensions[d]))
+			for a := range uncovered[d] {
+				uncovered[d][a] = make([]bool, len(dimensions[k]))
+				for v := range uncovered[d][a] {
+					uncovered[d][a][v] = true
+				}
+			}
+		}
+		cover := func(combination []int) {
+			for d := 0; d < k; d++ {
+				if combination[d] != unset {
+					uncovered[d][combination[d]][combination[k]] = false
+				}
+			}
+		}
+
+		// horizontal growth: extend each combination with the value of dimension k that covers the most uncovered pairs
+		for _, combination := range combinations {
+			best, bestCount := 0, -1
+			for v := range dimensions[k] {
+				count := 0
+				for d := 0; d < k; d++ {
+					if combination[d] != unset && uncovered[d][combination[d]][v] {
+						count += 1
+					}
+				}
+				if count > bestCount {
+					best, bestCount = v, count
+				}
+			}
+			combination[k] = best
+			cover(combination)
+		}
+
+		// vertical growth: add the remaining pairs, reusing combinations that don't yet have a value for the pair's dimension where possible
+		for d := 0; d < k; d++ {
+			for a := range dimensions[d] {
+				for v := range dimensions[k] {
+					if !uncovered[d][a][v] {
+						continue
+					}
+					var target []int
+					for _, combination := range combinations {
+						if combination[k] == v && combination[d] == unset {
+							target = combination
+							break
+						}
+					}
+					if target == nil {
+						target = newCombination()
+						target[k] = v
+						combinations = append(combinations, target)
+					}
+					target[d] = a
+					cover(target)
+				}
+			}
+		}
+	}
+
+	for _, combination := range combinations {
+		for d := range combination {
+			if combination[d] == unset {
+				combination[d] = 0
+			}
+		}
+	}
+	return combinations
+}
+
+func tableFixtureDecorations(path string, args []interface{}, cl types.CodeLocation) []interface{} {
+	decorations, remainingArgs := internal.PartitionDecorations(args...)
+	if len(remainingArgs) > 0 {
+		exitIfErr(types.GinkgoErrors.InvalidTableFixture(path, fmt.Errorf("only decorators can be passed in along with the fixture file, but got <%T>", remainingArgs[0]), cl))
+	}
+	return decorations
+}
+
+// fixtureParameter is a parameter loaded from a fixture file.  It is decoded once the type the table's body function expects is known.
+type fixtureParameter struct {
+	json   json.RawMessage
+	text   string
+	isText bool
+}
+
+func (p fixtureParameter) decode(t reflect.Type) (interface{}, error) {
+	if p.isText && (t.Kind() == reflect.String || t.Kind() == reflect.Interface) {
+		return reflect.ValueOf(p.text).Convert(t).Interface(), nil
+	}
+	value := reflect.New(t)
+	data := []byte(p.json)
+	if p.isText {
+		if strings.TrimSpace(p.text) == "" {
+			return value.Elem().Interface(), nil
+		}
+		data = []byte(p.text)
+	}
+	if err := json.Unmarshal(data, value.Interface()); err != nil {
+		return nil, err
+	}
+	return value.Elem().Interface(), nil
+}
+
+// decodeFixtureParameters decodes any parameters loaded from a fixture file into the types expected by the table's body function
+func decodeFixtureParameters(function interface{}, parameters []interface{}, cl types.CodeLocation) ([]interface{}, error) {
+	funcType := reflect.TypeOf(function)
+	if funcType == nil || funcType.Kind() != reflect.Func {
+		return parameters, nil
+	}
+	limit := funcType.NumIn()
+	if funcType.IsVariadic() {
+		limit = limit - 1
+	}
+
+	decoded := make([]interface{}, len(parameters))
+	for i, parameter := range parameters {
+		decoded[i] = parameter
+		p, ok := parameter.(fixtureParameter)
+		if !ok {
+			continue
+		}
+		var t reflect.Type
+		switch {
+		case i < limit:
+			t = funcType.In(i)
+		case funcType.IsVariadic():
+			t = funcType.In(limit).Elem()
+		default:
+			// validateParameters will report that there are too many parameters
+			continue
+		}
+		value, err := p.decode(t)
+		if err != nil {
+			return parameters, types.GinkgoErrors.InvalidTableFixtureParameter(i+1, t, err, cl)
+		}
+		decoded[i] = value
+	}
+	return decoded, nil
+}
+
+// generateTable generates a container with an It - or, if isSubtree is true, a container - for each entry
+func generateTable(description string, isSubtree bool, args ...interface{}) {
 	cl := types.NewCodeLocation(2)
 	containerNodeArgs := []interface{}{cl}
 
 	entries := []TableEntry{}
-	var itBody interface{}
+	var entryBody interface{}
+	var goldenFiles *GoldenFiles
+	// the table-level entry descriptions, in the order they were passed in - the last one wins
+	var entryDescriptions []interface{}
 
 	var tableLevelEntryDescription interface{}
 	tableLevelEntryDescription = func(args ...interface{}) string {
@@ -144,25 +719,70 @@ func generateTable(description string, args ...interface{}) {
 		case t == reflect.TypeOf([]TableEntry{}):
 			entries = append(entries, arg.([]TableEntry)...)
 		case t == reflect.TypeOf(EntryDescription("")):
-			tableLevelEntryDescription = arg.(EntryDescription).render
+			entryDescriptions = append(entryDescriptions, arg)
+		case t == reflect.TypeOf(GoldenFiles("")):
+			dir := arg.(GoldenFiles)
+			goldenFiles = &dir
 		case t.Kind() == reflect.Func && t.NumOut() == 1 && t.Out(0) == reflect.TypeOf(""):
-			tableLevelEntryDescription = arg
+			entryDescriptions = append(entryDescriptions, arg)
 		case t.Kind() == reflect.Func:
-			if itBody != nil {
+			if entryBody != nil {
 				exitIfErr(types.GinkgoErrors.MultipleEntryBodyFunctionsForTable(cl))
 			}
-			itBody = arg
+			entryBody = arg
 		default:
 			containerNodeArgs = append(containerNodeArgs, arg)
 		}
 	}
 
+	// the body of a GoldenFiles table can return a string, in which case it is the first function that does so
+	if goldenFiles != nil && entryBody == nil {
+		for i, entryDescription := range entryDescriptions {
+			if _, isFormatString := entryDescription.(EntryDescription); !isFormatString {
+				entryBody, entryDescriptions = entryDescription, append(entryDescriptions[:i:i], entryDescriptions[i+1:]...)
+				break
+			}
+		}
+	}
+	if len(entryDescriptions) > 0 {
+		tableLevelEntryDescription = entryDescriptions[len(entryDescriptions)-1]
+		if format, isFormatString := tableLevelEntryDescription.(EntryDescription); isFormatString {
+			tableLevelEntryDescription = format.render
+		}
+	}
+	if goldenFiles != nil && isSubtree {
+		exitIfErr(types.GinkgoErrors.InvalidGoldenFilesTable("GoldenFiles can only decorate DescribeTable.  The body of a DescribeTableSubtree defines specs rather than returning a value to compare.", cl))
+	}
+	if t := reflect.TypeOf(entryBody); goldenFiles != nil && t != nil && t.NumOut() != 1 {
+		exitIfErr(types.GinkgoErrors.InvalidGoldenFilesTable("The body of a table decorated with GoldenFiles must return exactly one value - the value to compare against the entry's golden file.", cl))
+	}
+
+	// a table body whose first parameter is a SpecContext (or context.Context) is passed the spec's context ahead of the entry's parameters
+	// the entry's parameters are validated against the remaining parameters
+	parametersBody, bodyAcceptsContext := entryBody, false
+	if t := reflect.TypeOf(entryBody); !isSubtree && t != nil && t.Kind() == reflect.Func && t.NumIn() > 0 && (t.In(0) == specContextType || t.In(0) == contextType) {
+		in, out := []reflect.Type{}, []reflect.Type{}
+		for i := 1; i < t.NumIn(); i++ {
+			in = append(in, t.In(i))
+		}
+		for i := 0; i < t.NumOut(); i++ {
+			out = append(out, t.Out(i))
+		}
+		parametersBody, bodyAcceptsContext = reflect.Zero(reflect.FuncOf(in, out, t.IsVariadic())).Interface(), true
+	}
+
 	containerNodeArgs = append(containerNodeArgs, func() {
+		goldenFileEntries := map[string]types.CodeLocation{}
 		for _, entry := range entries {
 			var err error
 			entry := entry
+			entry.parameters, err = decodeFixtureParameters(parametersBody, entry.parameters, entry.codeLocation)
 			var description string
 			switch t := reflect.TypeOf(entry.description); {
+			case t == reflect.TypeOf(""):
+				description = entry.description.(string)
+			case err != nil:
+				// the entry's parameters could not be decoded from its fixture file so there is nothing to generate the description from
 			case t == nil:
 				err = validateParameters(tableLevelEntryDescription, entry.parameters, "Entry Description function", entry.codeLocation)
 				if err == nil {
@@ -170,8 +790,6 @@ func generateTable(description string, args ...interface{}) {
 				}
 			case t == reflect.TypeOf(EntryDescription("")):
 				description = entry.description.(EntryDescription).render(entry.parameters...)
-			case t == reflect.TypeOf(""):
-				description = entry.description.(string)
 			case t.Kind() == reflect.Func && t.NumOut() == 1 && t.Out(0) == reflect.TypeOf(""):
 				err = validateParameters(entry.description, entry.parameters, "Entry Description function", entry.codeLocation)
 				if err == nil {
@@ -182,24 +800,98 @@ func generateTable(description string, args ...interface{}) {
 			}
 
 			if err == nil {
-				err = validateParameters(itBody, entry.parameters, "Table Body function", entry.codeLocation)
+				err = validateParameters(parametersBody, entry.parameters, "Table Body function", entry.codeLocation)
+			}
+			var subtreeParameters []interface{}
+			if err == nil && entry.subtree != nil && reflect.TypeOf(entry.subtree).NumIn() > 0 {
+				subtreeParameters = entry.parameters
+				err = validateParameters(entry.subtree, entry.parameters, "SubtreeEntry body function", entry.codeLocation)
 			}
-			itNodeArgs := []interface{}{entry.codeLocation}
-			itNodeArgs = append(itNodeArgs, entry.decorations...)
-			itNodeArgs = append(itNodeArgs, func() {
-				if err != nil {
-					panic(err)
+			var goldenFile string
+			if goldenFiles != nil && err == nil {
+				goldenFile = filepath.Join(string(*goldenFiles), internal.GoldenFileName(description))
+				if other, ok := goldenFileEntries[goldenFile]; ok {
+					exitIfErr(types.GinkgoErrors.InvalidGoldenFilesTable(fmt.Sprintf("The entry at %s shares its golden file, %s, with the entry at %s.  Give the entries descriptions that differ in more than punctuation.", entry.codeLocation, goldenFile, other), entry.codeLocation))
 				}
-				invokeFunction(itBody, entry.parameters)
-			})
+				goldenFileEntries[goldenFile] = entry.codeLocation
+			}
+			nodeType := types.NodeTypeIt
+			if isSubtree {
+				nodeType = types.NodeTypeContainer
+			}
+			var body interface{}
+			if bodyAcceptsContext {
+				body = func(ctx SpecContext) {
+					if err != nil {
+						panic(err)
+					}
+					results := invokeFunction(entryBody, append([]interface{}{ctx}, entry.parameters...))
+					if goldenFile != "" {
+						compareWithGoldenFile(goldenFile, results[0], entry.codeLocation)
+					}
+				}
+			} else {
+				body = func() {
+					if err != nil && isSubtree {
+						// subtree bodies run while the tree is being constructed - so we stop right away, just as we do for other tree construction errors
+						exitIfErr(err)
+					}
+					if err != nil {
+						panic(err)
+					}
+					results := invokeFunction(entryBody, entry.parameters)
+					if goldenFile != "" {
+						compareWithGoldenFile(goldenFile, results[0], entry.codeLocation)
+					}
+				}
+			}
 
-			pushNode(internal.NewNode(deprecationTracker, types.NodeTypeIt, description, itNodeArgs...))
+			entryNodeArgs := []interface{}{entry.codeLocation, internal.TableEntryNode}
+			entryNodeArgs = append(entryNodeArgs, entry.decorations...)
+			if entry.subtree == nil {
+				pushNode(internal.NewNode(deprecationTracker, nodeType, description, append(entryNodeArgs, body)...))
+				continue
+			}
+			// a SubtreeEntry generates a container populated by the entry's own body - the table's body then joins the entry's nodes as an It
+			// (or, in a DescribeTableSubtree, populates the container too).  A NodeTimeout bounds that It as containers can't time out.
+			subtreeNodeArgs, specNodeArgs := []interface{}{entry.codeLocation, internal.TableEntryNode}, []interface{}{entry.codeLocation, body}
+			for _, decoration := range entry.decorations {
+				if _, isNodeTimeout := decoration.(internal.NodeTimeout); isNodeTimeout && !isSubtree {
+					specNodeArgs = append(specNodeArgs, decoration)
+				} else {
+					subtreeNodeArgs = append(subtreeNodeArgs, decoration)
+				}
+			}
+			pushNode(internal.NewNode(deprecationTracker, types.NodeTypeContainer, description, append(subtreeNodeArgs, func() {
+				exitIfErr(err)
+				invokeFunction(entry.subtree, subtreeParameters)
+				if isSubtree {
+					invokeFunction(entryBody, entry.parameters)
+				} else {
+					pushNode(internal.NewNode(deprecationTracker, types.NodeTypeIt, "", specNodeArgs...))
+				}
+			})...))
 		}
 	})
 
 	pushNode(internal.NewNode(deprecationTracker, types.NodeTypeContainer, description, containerNodeArgs...))
 }
 
+// compareWithGoldenFile fails the current spec if the value returned by the table's body does not match its golden file
+func compareWithGoldenFile(path string, result reflect.Value, cl types.CodeLocation) {
+	actual, err := internal.FormatGoldenValue(result.Interface())
+	if err == nil {
+		err = global.Suite.CompareWithGoldenFile(path, actual)
+	}
+	if err != nil {
+		global.Failer.Fail(err.Error(), cl)
+		panic(types.GinkgoErrors.UncaughtGinkgoPanic(cl))
+	}
+}
+
+var specContextType = reflect.TypeOf((*SpecContext)(nil)).Elem()
+var contextType = reflect.TypeOf((*context.Context)(nil)).Elem()
+
 func invokeFunction(function interface{}, parameters []interface{}) []reflect.Value {
 	inValues := make([]reflect.Value, len(parameters))
 
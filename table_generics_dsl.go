@@ -0,0 +1,98 @@
+//go:build go1.21
+// +build go1.21
+
+package ginkgo
+
+// go1.21 toolchains let a file use a newer language version than the module's go directive when its build constraint requires that version.
+// That is what allows the generic tables to live alongside code that supports older versions of Go.
+
+import (
+	"github.com/onsi/ginkgo/v2/internal"
+	"github.com/onsi/ginkgo/v2/types"
+)
+
+/*
+DescribeTableT describes a type-safe table-driven spec.  Each TypedEntry carries a single parameter of type T which is passed to the table's body:
+
+	type addition struct{ a, b, expected int }
+
+	DescribeTableT("addition", func(c addition) {
+		Ω(c.a + c.b).Should(Equal(c.expected))
+	},
+		EntryT("small numbers", addition{1, 2, 3}),
+		EntryT("negative numbers", addition{-1, -2, -3}, Label("negative")),
+	)
+
+Unlike DescribeTable, a mismatch between the entries and the body is a compile-time error rather than a runtime failure.  Use a struct (as above) when the body needs more than one parameter.
+
+DescribeTableT requires go1.21 or later.
+
+You can learn more about DescribeTableT here: https://onsi.github.io/ginkgo/#type-safe-tables-describetablet
+*/
+func DescribeTableT[T any](description string, body func(T), entries ...TypedEntry[T]) bool {
+	generateTable(description, false, typedTableArgs(body, entries)...)
+	return true
+}
+
+/*
+You can focus a table with `FDescribeTableT`.  This is equivalent to `FDescribe`.
+*/
+func FDescribeTableT[T any](description string, body func(T), entries ...TypedEntry[T]) bool {
+	generateTable(description, false, append(typedTableArgs(body, entries), internal.Focus)...)
+	return true
+}
+
+/*
+You can mark a table as pending with `PDescribeTableT`.  This is equivalent to `PDescribe`.
+*/
+func PDescribeTableT[T any](description string, body func(T), entries ...TypedEntry[T]) bool {
+	generateTable(description, false, append(typedTableArgs(body, entries), internal.Pending)...)
+	return true
+}
+
+/*
+TypedEntry represents an entry in a DescribeTableT table.  You generally use the `EntryT` constructor.
+*/
+type TypedEntry[T any] struct {
+	entry TableEntry
+}
+
+/*
+EntryT constructs a TypedEntry with the passed-in description and parameter.  Any decorators passed in after the parameter apply to the spec the entry generates.
+
+If the description is empty the entry is named after its parameter.
+*/
+func EntryT[T any](description string, parameter T, decorators ...interface{}) TypedEntry[T] {
+	return newTypedEntry(description, parameter, decorators, types.NewCodeLocation(1))
+}
+
+/*
+You can focus a particular entry with FEntryT.  This is equivalent to FIt.
+*/
+func FEntryT[T any](description string, parameter T, decorators ...interface{}) TypedEntry[T] {
+	return newTypedEntry(description, parameter, append(decorators, internal.Focus), types.NewCodeLocation(1))
+}
+
+/*
+You can mark a particular entry as pending with PEntryT.  This is equivalent to PIt.
+*/
+func PEntryT[T any](description string, parameter T, decorators ...interface{}) TypedEntry[T] {
+	return newTypedEntry(description, parameter, append(decorators, internal.Pending), types.NewCodeLocation(1))
+}
+
+func newTypedEntry[T any](description string, parameter T, decorators []interface{}, cl types.CodeLocation) TypedEntry[T] {
+	var entryDescription interface{}
+	if description != "" {
+		entryDescription = description
+	}
+	// unlike Entry, the parameter is never partitioned out as a decorator - so a T that happens to be a decorator type still reaches the body
+	return TypedEntry[T]{entry: TableEntry{description: entryDescription, decorations: decorators, parameters: []interface{}{parameter}, codeLocation: cl}}
+}
+
+func typedTableArgs[T any](body func(T), entries []TypedEntry[T]) []interface{} {
+	tableEntries := []TableEntry{}
+	for _, entry := range entries {
+		tableEntries = append(tableEntries, entry.entry)
+	}
+	return []interface{}{body, tableEntries}
+}
@@ -0,0 +1,37 @@
+package types
+
+// SpecAnnotation is a piece of typed metadata attached to a spec with the Annotate decorator or with AddSpecAnnotation.
+// The value is wrapped in a ReportEntryValue so that it can be encoded safely into reports and sent between parallel processes.
+type SpecAnnotation struct {
+	Key      string
+	Value    ReportEntryValue
+	Location CodeLocation
+}
+
+type SpecAnnotations []SpecAnnotation
+
+// Get returns the raw value of the annotation with the passed-in key.  When a key is annotated more than once the most
+// specific annotation wins: annotations added at runtime override the spec's decorators which override those of its containers.
+//
+// Note that values decoded from a JSON report have generic JSON types - numbers become float64 and structs become map[string]interface{}.
+func (annotations SpecAnnotations) Get(key string) (interface{}, bool) {
+	for i := len(annotations) - 1; i >= 0; i-- {
+		if annotations[i].Key == key {
+			return annotations[i].Value.GetRawValue(), true
+		}
+	}
+	return nil, false
+}
+
+// Keys returns the distinct annotation keys in the order they were first added
+func (annotations SpecAnnotations) Keys() []string {
+	keys := []string{}
+	seen := map[string]bool{}
+	for _, annotation := range annotations {
+		if !seen[annotation.Key] {
+			seen[annotation.Key] = true
+			keys = append(keys, annotation.Key)
+		}
+	}
+	return keys
+}
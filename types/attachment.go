@@ -0,0 +1,90 @@
+package types
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Attachment captures binary output emitted by a spec.  Data is base64-encoded when the report is serialized to JSON.
+type Attachment struct {
+	// Name identifies the output that was moved into the attachment - one of AttachmentNameStdOutErr or AttachmentNameGinkgoWriter
+	Name string
+	Data []byte
+}
+
+const AttachmentNameStdOutErr = "stdout-stderr"
+const AttachmentNameGinkgoWriter = "ginkgo-writer"
+
+// binaryOutputControlCharacterThreshold is the fraction of control characters above which output is considered binary
+const binaryOutputControlCharacterThreshold = 0.1
+
+/*
+IsBinaryOutput returns true if s is not valid UTF-8, contains a NUL byte, or if more than 10% of its characters are control characters.
+
+Newlines, carriage returns, tabs, and the escape character (which introduces ANSI color codes) are not counted as control characters.
+*/
+func IsBinaryOutput(s string) bool {
+	if s == "" {
+		return false
+	}
+	if !utf8.ValidString(s) || strings.ContainsRune(s, 0) {
+		return true
+	}
+	total, control := 0, 0
+	for _, r := range s {
+		total += 1
+		if isBinaryControlCharacter(r) {
+			control += 1
+		}
+	}
+	return float64(control)/float64(total) > binaryOutputControlCharacterThreshold
+}
+
+func isBinaryControlCharacter(r rune) bool {
+	switch r {
+	case '\n', '\r', '\t', '\x1b':
+		return false
+	}
+	return unicode.IsControl(r)
+}
+
+// AttachBinaryOutput moves any binary captured output into attachments, leaving a short note in its place
+func (report SpecReport) AttachBinaryOutput() SpecReport {
+	if IsBinaryOutput(report.CapturedStdOutErr) {
+		report.Attachments = append(report.Attachments, Attachment{Name: AttachmentNameStdOutErr, Data: []byte(report.CapturedStdOutErr)})
+		report.CapturedStdOutErr = binaryOutputNote(len(report.CapturedStdOutErr), AttachmentNameStdOutErr)
+	}
+	if IsBinaryOutput(report.CapturedGinkgoWriterOutput) {
+		report.Attachments = append(report.Attachments, Attachment{Name: AttachmentNameGinkgoWriter, Data: []byte(report.CapturedGinkgoWriterOutput)})
+		report.CapturedGinkgoWriterOutput = binaryOutputNote(len(report.CapturedGinkgoWriterOutput), AttachmentNameGinkgoWriter)
+	}
+	return report
+}
+
+func binaryOutputNote(size int, name string) string {
+	return fmt.Sprintf("[Ginkgo captured %d bytes of binary output - it is stored in the %q attachment of this spec's report]\n", size, name)
+}
+
+var ansiEscapeSequenceRegExp = regexp.MustCompile("\x1b\\[[0-9;]*[a-zA-Z]")
+
+/*
+SanitizeForXML strips ANSI escape sequences from s, replaces invalid UTF-8 with the unicode replacement character, and removes every character that XML 1.0 does not allow.
+*/
+func SanitizeForXML(s string) string {
+	s = ansiEscapeSequenceRegExp.ReplaceAllString(s, "")
+	if !utf8.ValidString(s) {
+		s = strings.ToValidUTF8(s, "�")
+	}
+	return strings.Map(func(r rune) rune {
+		if r == '\t' || r == '\n' || r == '\r' ||
+			(r >= 0x20 && r <= 0xD7FF) ||
+			(r >= 0xE000 && r <= 0xFFFD) ||
+			(r >= 0x10000 && r <= 0x10FFFF) {
+			return r
+		}
+		return -1
+	}, s)
+}
@@ -0,0 +1,72 @@
+package types_test
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/onsi/ginkgo/v2/types"
+)
+
+var _ = Describe("Binary output", func() {
+	DescribeTable("IsBinaryOutput",
+		func(s string, expected bool) {
+			Ω(types.IsBinaryOutput(s)).Should(Equal(expected))
+		},
+		Entry("empty output", "", false),
+		Entry("plain text", "hello\nworld\r\n\tindented", false),
+		Entry("text with ANSI color codes", "\x1b[31mred\x1b[0m\n", false),
+		Entry("unicode text", "héllo wörld ✓", false),
+		Entry("invalid UTF-8", "hello \xff\xfe world", true),
+		Entry("a NUL byte", "hello\x00world", true),
+		Entry("a few control characters", "a\x01bcdefghijklmnopqrstuvwxyz", false),
+		Entry("mostly control characters", "\x01\x02\x03\x04abc", true),
+	)
+
+	Describe("AttachBinaryOutput", func() {
+		It("leaves text output alone", func() {
+			report := types.SpecReport{CapturedStdOutErr: "hello\n", CapturedGinkgoWriterOutput: "world\n"}
+			Ω(report.AttachBinaryOutput()).Should(Equal(report))
+		})
+
+		It("moves binary output into attachments", func() {
+			report := types.SpecReport{CapturedStdOutErr: "\x00\x01\x02", CapturedGinkgoWriterOutput: "\xff\xfe"}.AttachBinaryOutput()
+			Ω(report.Attachments).Should(Equal([]types.Attachment{
+				{Name: types.AttachmentNameStdOutErr, Data: []byte("\x00\x01\x02")},
+				{Name: types.AttachmentNameGinkgoWriter, Data: []byte("\xff\xfe")},
+			}))
+			Ω(report.CapturedStdOutErr).Should(Equal("[Ginkgo captured 3 bytes of binary output - it is stored in the \"stdout-stderr\" attachment of this spec's report]\n"))
+			Ω(report.CapturedGinkgoWriterOutput).Should(Equal("[Ginkgo captured 2 bytes of binary output - it is stored in the \"ginkgo-writer\" attachment of this spec's report]\n"))
+		})
+
+		It("round-trips attachments through JSON", func() {
+			report := types.SpecReport{CapturedStdOutErr: "\x00\x01\x02\xff"}.AttachBinaryOutput()
+			encoded, err := json.Marshal(report)
+			Ω(err).ShouldNot(HaveOccurred())
+			decoded := types.SpecReport{}
+			Ω(json.Unmarshal(encoded, &decoded)).Should(Succeed())
+			Ω(decoded.Attachments).Should(Equal(report.Attachments))
+		})
+	})
+
+	Describe("SanitizeForXML", func() {
+		It("strips ANSI escape sequences, invalid UTF-8, and characters XML does not allow", func() {
+			Ω(types.SanitizeForXML("\x1b[1;31mred\x1b[0m \x00\x01bad\xff\tok\n")).Should(Equal("red bad�\tok\n"))
+		})
+
+		It("produces content that survives an XML round-trip", func() {
+			input := strings.Repeat("\x00\x07\x1b[31m\xc3\x28 text", 10)
+			type doc struct {
+				Content string `xml:",chardata"`
+			}
+			encoded, err := xml.Marshal(doc{Content: types.SanitizeForXML(input)})
+			Ω(err).ShouldNot(HaveOccurred())
+			decoded := doc{}
+			Ω(xml.Unmarshal(encoded, &decoded)).Should(Succeed())
+			Ω(decoded.Content).Should(Equal(types.SanitizeForXML(input)))
+		})
+	})
+})
@@ -7,6 +7,7 @@ import (
 	"runtime"
 	"runtime/debug"
 	"strings"
+	"sync"
 )
 
 type CodeLocation struct {
@@ -14,6 +15,7 @@ type CodeLocation struct {
 	LineNumber     int    `json:",omitempty"`
 	FullStackTrace string `json:",omitempty"`
 	CustomMessage  string `json:",omitempty"`
+	Synthetic      bool   `json:",omitempty"`
 }
 
 func (codeLocation CodeLocation) String() string {
@@ -44,15 +46,94 @@ func NewCustomCodeLocation(message string) CodeLocation {
 	}
 }
 
+// NewSyntheticCodeLocation returns a CodeLocation that refers to an arbitrary source file and line number - for example, the row in a data file that was used to generate a table entry.
+// When a spec's leaf node is decorated with a synthetic CodeLocation, Ginkgo reports failures in that node at the synthetic location instead of the (shared) location of the code that failed.
+func NewSyntheticCodeLocation(fileName string, lineNumber int) CodeLocation {
+	return CodeLocation{FileName: fileName, LineNumber: lineNumber, Synthetic: true}
+}
+
+// codeLocationLocator resolves code locations from the call stack, skipping over the functions that have been marked as helpers with MarkAsHelper
+type codeLocationLocator struct {
+	lock    *sync.RWMutex
+	pcs     map[uintptr]bool
+	helpers map[string]bool
+}
+
+var clLocator = &codeLocationLocator{
+	lock:    &sync.RWMutex{},
+	pcs:     map[uintptr]bool{},
+	helpers: map[string]bool{},
+}
+
+func (c *codeLocationLocator) addHelper(pc uintptr) {
+	c.lock.RLock()
+	seen := c.pcs[pc]
+	c.lock.RUnlock()
+	if seen {
+		return
+	}
+	f := runtime.FuncForPC(pc)
+	if f == nil {
+		return
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.pcs[pc] = true
+	c.helpers[f.Name()] = true
+}
+
+func (c *codeLocationLocator) isHelper(function string) bool {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	return c.helpers[function]
+}
+
+// getCodeLocation returns the location of the first frame, skip frames up from the caller, that is not in a helper
+func (c *codeLocationLocator) getCodeLocation(skip int) CodeLocation {
+	pcs := make([]uintptr, 40)
+	n := runtime.Callers(skip+2, pcs)
+	if n == 0 {
+		return CodeLocation{}
+	}
+	frames := runtime.CallersFrames(pcs[:n])
+	first := CodeLocation{}
+	for {
+		frame, more := frames.Next()
+		if first.FileName == "" {
+			first = CodeLocation{FileName: frame.File, LineNumber: frame.Line}
+		}
+		if !c.isHelper(frame.Function) {
+			return CodeLocation{FileName: frame.File, LineNumber: frame.Line}
+		}
+		if !more {
+			// every frame is a helper - fall back to the innermost one
+			return first
+		}
+	}
+}
+
+// MarkAsHelper marks the function that calls it (or, with optionalSkip, a function further up the call stack) as a helper.
+// Helpers are skipped when Ginkgo resolves code locations so that failures that occur in a helper are reported at the line that called the helper.
+// It is called by GinkgoHelper and GinkgoT().Helper()
+func MarkAsHelper(optionalSkip ...int) {
+	skip := 1
+	if len(optionalSkip) > 0 {
+		skip += optionalSkip[0]
+	}
+	pc, _, _, ok := runtime.Caller(skip)
+	if ok {
+		clLocator.addHelper(pc)
+	}
+}
+
 func NewCodeLocation(skip int) CodeLocation {
-	_, file, line, _ := runtime.Caller(skip + 1)
-	return CodeLocation{FileName: file, LineNumber: line}
+	return clLocator.getCodeLocation(skip + 1)
 }
 
 func NewCodeLocationWithStackTrace(skip int) CodeLocation {
-	_, file, line, _ := runtime.Caller(skip + 1)
-	stackTrace := PruneStack(string(debug.Stack()), skip+1)
-	return CodeLocation{FileName: file, LineNumber: line, FullStackTrace: stackTrace}
+	cl := clLocator.getCodeLocation(skip + 1)
+	cl.FullStackTrace = PruneStack(string(debug.Stack()), skip+1)
+	return cl
 }
 
 // PruneStack removes references to functions that are internal to Ginkgo
@@ -49,6 +49,18 @@ var _ = Describe("CodeLocation", func() {
 		})
 	})
 
+	Describe("synthetic code locations", func() {
+		BeforeEach(func() {
+			codeLocation = types.NewSyntheticCodeLocation("testdata/cases.csv", 17)
+		})
+
+		It("refers to the passed-in file and line number and is marked as synthetic", func() {
+			Ω(codeLocation.String()).Should(Equal("testdata/cases.csv:17"))
+			Ω(codeLocation.Synthetic).Should(BeTrue())
+			Ω(types.NewCodeLocation(0).Synthetic).Should(BeFalse())
+		})
+	})
+
 	Describe("Fetching the line from the file in question", func() {
 		It("works", func() {
 			codeLocation = types.NewCodeLocation(0)
@@ -69,6 +81,48 @@ var _ = Describe("CodeLocation", func() {
 		})
 	})
 
+	Describe("helper functions", func() {
+		var helper, nestedHelper, markCaller func()
+
+		BeforeEach(func() {
+			helper = func() {
+				types.MarkAsHelper()
+				codeLocation = types.NewCodeLocation(0)
+			}
+			nestedHelper = func() {
+				types.MarkAsHelper()
+				helper()
+			}
+			markCaller = func() {
+				types.MarkAsHelper(1)
+			}
+		})
+
+		It("skips functions marked as helpers", func() {
+			_, expectedFileName, expectedLineNumber, _ = runtime.Caller(0)
+			helper()
+			Ω(codeLocation.FileName).Should(Equal(expectedFileName))
+			Ω(codeLocation.LineNumber).Should(Equal(expectedLineNumber + 1))
+		})
+
+		It("skips nested helpers", func() {
+			_, expectedFileName, expectedLineNumber, _ = runtime.Caller(0)
+			nestedHelper()
+			Ω(codeLocation.FileName).Should(Equal(expectedFileName))
+			Ω(codeLocation.LineNumber).Should(Equal(expectedLineNumber + 1))
+		})
+
+		It("can mark the caller of the function calling MarkAsHelper", func() {
+			markingHelper := func() {
+				markCaller()
+				codeLocation = types.NewCodeLocation(0)
+			}
+			_, expectedFileName, expectedLineNumber, _ = runtime.Caller(0)
+			markingHelper()
+			Ω(codeLocation.LineNumber).Should(Equal(expectedLineNumber + 1))
+		})
+	})
+
 	Describe("PruneStack", func() {
 		It("should remove any references to ginkgo and pkg/testing and pkg/runtime", func() {
 			// Hard-coded string, loosely based on what debug.Stack() produces.
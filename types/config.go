@@ -7,7 +7,9 @@ package types
 
 import (
 	"flag"
+	"fmt"
 	"os"
+	"regexp"
 	"runtime"
 	"strconv"
 	"strings"
@@ -23,13 +25,56 @@ type SuiteConfig struct {
 	FocusFiles            []string
 	SkipFiles             []string
 	LabelFilter           string
+	TableSample           float64
+	TableSampleSeed       int64
+	SeedStrategy          string
 	FailOnPending         bool
 	FailFast              bool
 	FlakeAttempts         int
+	RetryOn               []string
+	UpdateSnapshots       bool
 	EmitSpecProgress      bool
 	DryRun                bool
+	ExplainSpec           string
+	DescribeSuite         bool
 	Timeout               time.Duration
+	SuiteNodeTimeout      time.Duration
+	CPUTimeLimit          time.Duration
+	TimeBox               time.Duration
+	GracePeriod           time.Duration
+	PollProgressAfter     time.Duration
 	OutputInterceptorMode string
+	FocusExitCode         string
+
+	AfterSuiteFailurePolicy string
+	ValidateFilters         string
+	UnmatchedFiltersFile    string
+	ZeroAssertionPolicy     string
+
+	SkipOrderedCleanupOnAbort bool
+
+	MaxSpecsPerMinute        int
+	MaxSpecsPerMinuteByLabel []string
+
+	PinLabelToProcess []string
+
+	CapacityCPU    int
+	CapacityMemory string
+	CapacityGPU    int
+
+	Chaos            bool
+	ChaosMaxDelay    time.Duration
+	ChaosFailureRate float64
+
+	FingerprintEnvVars []string
+
+	ReplayParallelSchedule string
+
+	ResourceRegistry string
+
+	ArtifactsDir string
+
+	RequireSingleSpec bool
 
 	ParallelProcess int
 	ParallelTotal   int
@@ -40,15 +85,238 @@ func NewDefaultSuiteConfig() SuiteConfig {
 	return SuiteConfig{
 		RandomSeed:      time.Now().Unix(),
 		Timeout:         time.Hour,
+		GracePeriod:     30 * time.Second,
+		ChaosMaxDelay:   100 * time.Millisecond,
 		ParallelProcess: 1,
 		ParallelTotal:   1,
 	}
 }
 
+// The supported values for --focus-exit-code
+const (
+	FocusExitCodeFail = "fail"
+	FocusExitCodeWarn = "warn"
+	FocusExitCodeOff  = "off"
+)
+
+// ProgrammaticFocusBehavior returns the normalized --focus-exit-code setting: one of FocusExitCodeFail (the default), FocusExitCodeWarn, or FocusExitCodeOff
+func (suiteConfig SuiteConfig) ProgrammaticFocusBehavior() string {
+	switch strings.ToLower(suiteConfig.FocusExitCode) {
+	case FocusExitCodeWarn:
+		return FocusExitCodeWarn
+	case FocusExitCodeOff:
+		return FocusExitCodeOff
+	}
+	return FocusExitCodeFail
+}
+
+// The supported values for --seed-strategy
+const (
+	SeedStrategyTime    = "time"
+	SeedStrategyGitSHA  = "git-sha"
+	SeedStrategyCIBuild = "ci-build"
+	SeedStrategyBranch  = "branch"
+)
+
+// The supported values for --after-suite-failure-policy
+const (
+	AfterSuiteFailurePolicyFail  = "fail"
+	AfterSuiteFailurePolicyWarn  = "warn"
+	AfterSuiteFailurePolicyRetry = "retry"
+)
+
+// The supported values for --validate-filters
+const (
+	ValidateFiltersOff  = "off"
+	ValidateFiltersWarn = "warn"
+	ValidateFiltersFail = "fail"
+)
+
+// FilterValidationBehavior returns the normalized --validate-filters setting: one of ValidateFiltersOff (the default), ValidateFiltersWarn, or ValidateFiltersFail
+func (suiteConfig SuiteConfig) FilterValidationBehavior() string {
+	switch strings.ToLower(suiteConfig.ValidateFilters) {
+	case ValidateFiltersWarn:
+		return ValidateFiltersWarn
+	case ValidateFiltersFail:
+		return ValidateFiltersFail
+	}
+	return ValidateFiltersOff
+}
+
+// The supported values for --zero-assertion-policy
+const (
+	ZeroAssertionPolicyOff  = "off"
+	ZeroAssertionPolicyWarn = "warn"
+	ZeroAssertionPolicyFail = "fail"
+)
+
+// ZeroAssertionBehavior returns the normalized --zero-assertion-policy setting: one of ZeroAssertionPolicyOff (the default), ZeroAssertionPolicyWarn, or ZeroAssertionPolicyFail
+func (suiteConfig SuiteConfig) ZeroAssertionBehavior() string {
+	switch strings.ToLower(suiteConfig.ZeroAssertionPolicy) {
+	case ZeroAssertionPolicyWarn:
+		return ZeroAssertionPolicyWarn
+	case ZeroAssertionPolicyFail:
+		return ZeroAssertionPolicyFail
+	}
+	return ZeroAssertionPolicyOff
+}
+
+// AfterSuiteFailureBehavior returns the normalized --after-suite-failure-policy setting: one of AfterSuiteFailurePolicyFail (the default), AfterSuiteFailurePolicyWarn, or AfterSuiteFailurePolicyRetry
+func (suiteConfig SuiteConfig) AfterSuiteFailureBehavior() string {
+	switch strings.ToLower(suiteConfig.AfterSuiteFailurePolicy) {
+	case AfterSuiteFailurePolicyWarn:
+		return AfterSuiteFailurePolicyWarn
+	case AfterSuiteFailurePolicyRetry:
+		return AfterSuiteFailurePolicyRetry
+	}
+	return AfterSuiteFailurePolicyFail
+}
+
+// TableSamplingSeed returns the seed used to pick the table entries to run when --table-sample is set: --table-sample-seed if set, and the suite's random seed otherwise
+func (suiteConfig SuiteConfig) TableSamplingSeed() int64 {
+	if suiteConfig.TableSampleSeed != 0 {
+		return suiteConfig.TableSampleSeed
+	}
+	return suiteConfig.RandomSeed
+}
+
+// HostCapacity returns the resources available to specs that declare requirements with the Requires decorator.  CPU capacity defaults to the number of CPUs on the host; memory and GPUs are unconstrained unless --capacity-memory and --capacity-gpu are set.
+func (suiteConfig SuiteConfig) HostCapacity() (ResourceRequirements, error) {
+	capacity := ResourceRequirements{CPU: suiteConfig.CapacityCPU, GPU: suiteConfig.CapacityGPU}
+	if suiteConfig.CapacityCPU < 0 {
+		return capacity, GinkgoErrors.InvalidCapacityConfiguration("capacity-cpu", strconv.Itoa(suiteConfig.CapacityCPU))
+	}
+	if suiteConfig.CapacityCPU == 0 {
+		capacity.CPU = runtime.NumCPU()
+	}
+	if suiteConfig.CapacityGPU < 0 {
+		return capacity, GinkgoErrors.InvalidCapacityConfiguration("capacity-gpu", strconv.Itoa(suiteConfig.CapacityGPU))
+	}
+	if suiteConfig.CapacityMemory != "" {
+		bytes, err := ParseMemoryQuantity(suiteConfig.CapacityMemory)
+		if err != nil {
+			return capacity, GinkgoErrors.InvalidCapacityConfiguration("capacity-memory", suiteConfig.CapacityMemory)
+		}
+		capacity.MemoryBytes = bytes
+	}
+	return capacity, nil
+}
+
+// SpecRateLimits returns the configured per-minute spec rate limits keyed by lowercased label.  The global limit is keyed by the empty string.
+func (suiteConfig SuiteConfig) SpecRateLimits() (map[string]int, error) {
+	limits := map[string]int{}
+	if suiteConfig.MaxSpecsPerMinute < 0 {
+		return nil, GinkgoErrors.InvalidMaxSpecsPerMinuteConfiguration(strconv.Itoa(suiteConfig.MaxSpecsPerMinute))
+	}
+	if suiteConfig.MaxSpecsPerMinute > 0 {
+		limits[""] = suiteConfig.MaxSpecsPerMinute
+	}
+	for _, value := range suiteConfig.MaxSpecsPerMinuteByLabel {
+		idx := strings.LastIndex(value, ":")
+		if idx <= 0 {
+			return nil, GinkgoErrors.InvalidMaxSpecsPerMinuteByLabelConfiguration(value)
+		}
+		label := strings.ToLower(strings.TrimSpace(value[:idx]))
+		limit, err := strconv.Atoi(strings.TrimSpace(value[idx+1:]))
+		if label == "" || err != nil || limit <= 0 {
+			return nil, GinkgoErrors.InvalidMaxSpecsPerMinuteByLabelConfiguration(value)
+		}
+		limits[label] = limit
+	}
+	return limits, nil
+}
+
+// ProcessPins returns the parallel process each pinned label is pinned to, keyed by lowercased label.
+func (suiteConfig SuiteConfig) ProcessPins() (map[string]int, error) {
+	pins := map[string]int{}
+	for _, value := range suiteConfig.PinLabelToProcess {
+		idx := strings.LastIndex(value, ":")
+		if idx <= 0 {
+			return nil, GinkgoErrors.InvalidPinLabelToProcessConfiguration(value, suiteConfig.ParallelTotal)
+		}
+		label := strings.ToLower(strings.TrimSpace(value[:idx]))
+		process, err := strconv.Atoi(strings.TrimSpace(value[idx+1:]))
+		if label == "" || err != nil || process < 1 || (suiteConfig.ParallelTotal > 1 && process > suiteConfig.ParallelTotal) {
+			return nil, GinkgoErrors.InvalidPinLabelToProcessConfiguration(value, suiteConfig.ParallelTotal)
+		}
+		if existing, ok := pins[label]; ok && existing != process {
+			return nil, GinkgoErrors.InvalidPinLabelToProcessConfiguration(value, suiteConfig.ParallelTotal)
+		}
+		pins[label] = process
+	}
+	return pins, nil
+}
+
+// FailureRetryPolicy decides which failures FlakeAttempts and the Retry decorator may retry.  A failure may be retried if it
+// matches one of RetryOn (or RetryOn is empty) and matches none of NeverRetryOn.  Rules are failure categories, "infrastructure", or failure fingerprints.
+type FailureRetryPolicy struct {
+	RetryOn      []string
+	NeverRetryOn []string
+}
+
+// AllowsRetry returns true if the policy allows the failed spec described by report to be retried
+func (policy FailureRetryPolicy) AllowsRetry(report SpecReport) bool {
+	category, fingerprint := report.FailureCategory(), report.Failure.Fingerprint()
+	matches := func(rule string) bool {
+		return category.matches(rule) || (fingerprint != "" && rule == fingerprint)
+	}
+	for _, rule := range policy.NeverRetryOn {
+		if matches(rule) {
+			return false
+		}
+	}
+	if len(policy.RetryOn) == 0 {
+		return true
+	}
+	for _, rule := range policy.RetryOn {
+		if matches(rule) {
+			return true
+		}
+	}
+	return false
+}
+
+var failureFingerprintRe = regexp.MustCompile(`^[0-9a-f]{16}$`)
+
+// RetryPolicies returns the configured --retry-on policies keyed by lowercased label.  The global policy is keyed by the empty string.
+func (suiteConfig SuiteConfig) RetryPolicies() (map[string]FailureRetryPolicy, error) {
+	policies := map[string]FailureRetryPolicy{}
+	for _, value := range suiteConfig.RetryOn {
+		label, rules := "", value
+		if idx := strings.LastIndex(value, ":"); idx >= 0 {
+			label, rules = strings.ToLower(strings.TrimSpace(value[:idx])), value[idx+1:]
+			if label == "" {
+				return nil, GinkgoErrors.InvalidRetryOnConfiguration(value)
+			}
+		}
+		policy := policies[label]
+		for _, rule := range strings.Split(rules, ",") {
+			rule = strings.ToLower(strings.TrimSpace(rule))
+			never := strings.HasPrefix(rule, "!")
+			rule = strings.TrimPrefix(rule, "!")
+			switch FailureCategory(rule) {
+			case FailureCategoryAssertion, FailureCategoryError, FailureCategoryPanic, FailureCategoryTimeout, FailureCategoryInfrastructure:
+			default:
+				if !failureFingerprintRe.MatchString(rule) {
+					return nil, GinkgoErrors.InvalidRetryOnConfiguration(value)
+				}
+			}
+			if never {
+				policy.NeverRetryOn = append(policy.NeverRetryOn, rule)
+			} else {
+				policy.RetryOn = append(policy.RetryOn, rule)
+			}
+		}
+		policies[label] = policy
+	}
+	return policies, nil
+}
+
 type VerbosityLevel uint
 
 const (
-	VerbosityLevelSuccinct VerbosityLevel = iota
+	VerbosityLevelQuiet VerbosityLevel = iota
+	VerbosityLevelSuccinct
 	VerbosityLevelNormal
 	VerbosityLevelVerbose
 	VerbosityLevelVeryVerbose
@@ -77,20 +345,41 @@ func (vl VerbosityLevel) LT(comp VerbosityLevel) bool {
 // Configuration for Ginkgo's reporter
 type ReporterConfig struct {
 	NoColor                bool
+	ASCII                  bool
+	NoCursorControl        bool
 	SlowSpecThreshold      time.Duration
+	Quiet                  bool
 	Succinct               bool
 	Verbose                bool
 	VeryVerbose            bool
 	FullTrace              bool
+	FullTraceOn            []string
+	StackTraceDepth        int
+	StackTracePrune        []string
+	StackTraceOmitArgs     bool
 	AlwaysEmitGinkgoWriter bool
+	LabelSummary           bool
 
 	JSONReport     string
 	JUnitReport    string
 	TeamcityReport string
+	TAPReport      string
+
+	IDEProtocol string
+	OutputSinks []string
+
+	ConsoleReportEntryVisibilities []string
+	JSONReportEntryVisibilities    []string
+	JUnitReportEntryVisibilities   []string
+	ConsoleReportEntrySkip         []string
+	JSONReportEntrySkip            []string
+	JUnitReportEntrySkip           []string
 }
 
 func (rc ReporterConfig) Verbosity() VerbosityLevel {
-	if rc.Succinct {
+	if rc.Quiet {
+		return VerbosityLevelQuiet
+	} else if rc.Succinct {
 		return VerbosityLevelSuccinct
 	} else if rc.Verbose {
 		return VerbosityLevelVerbose
@@ -100,8 +389,124 @@ func (rc ReporterConfig) Verbosity() VerbosityLevel {
 	return VerbosityLevelNormal
 }
 
+// ConsoleReportEntryFilter returns the filter governing which ReportEntries the default reporter emits to the console
+func (rc ReporterConfig) ConsoleReportEntryFilter() (ReportEntryFilter, error) {
+	return newReportEntryFilter("console", rc.ConsoleReportEntryVisibilities, rc.ConsoleReportEntrySkip)
+}
+
+// JSONReportEntryFilter returns the filter governing which ReportEntries are included in the report generated by --json-report
+func (rc ReporterConfig) JSONReportEntryFilter() (ReportEntryFilter, error) {
+	return newReportEntryFilter("json", rc.JSONReportEntryVisibilities, rc.JSONReportEntrySkip)
+}
+
+// JUnitReportEntryFilter returns the filter governing which ReportEntries are included in the report generated by --junit-report
+func (rc ReporterConfig) JUnitReportEntryFilter() (ReportEntryFilter, error) {
+	return newReportEntryFilter("junit", rc.JUnitReportEntryVisibilities, rc.JUnitReportEntrySkip)
+}
+
+func newReportEntryFilter(destination string, visibilities []string, skips []string) (ReportEntryFilter, error) {
+	filter := ReportEntryFilter{}
+	for _, value := range visibilities {
+		visibility, ok := ParseReportEntryVisibility(value)
+		if !ok {
+			return ReportEntryFilter{}, GinkgoErrors.InvalidReportEntryVisibilityConfiguration(destination, value)
+		}
+		filter.Visibilities = append(filter.Visibilities, visibility)
+	}
+	for _, value := range skips {
+		skip, err := regexp.Compile(value)
+		if err != nil {
+			return ReportEntryFilter{}, GinkgoErrors.InvalidReportEntrySkipConfiguration(destination, value, err)
+		}
+		filter.Skip = append(filter.Skip, skip)
+	}
+	return filter, nil
+}
+
+// IDEProtocolDestination parses the --ide-protocol destination.  The returned network is "stdout", "tcp", or "unix".
+func (rc ReporterConfig) IDEProtocolDestination() (network string, address string, err error) {
+	switch {
+	case rc.IDEProtocol == "stdout":
+		return "stdout", "", nil
+	case strings.HasPrefix(rc.IDEProtocol, "tcp://") && len(rc.IDEProtocol) > len("tcp://"):
+		return "tcp", strings.TrimPrefix(rc.IDEProtocol, "tcp://"), nil
+	case strings.HasPrefix(rc.IDEProtocol, "unix://") && len(rc.IDEProtocol) > len("unix://"):
+		return "unix", strings.TrimPrefix(rc.IDEProtocol, "unix://"), nil
+	}
+	return "", "", GinkgoErrors.InvalidIDEProtocolConfiguration(rc.IDEProtocol)
+}
+
+// OutputSink is an additional destination for the default reporter's output, configured with --output-sink
+type OutputSink struct {
+	// Value is the --output-sink value the sink was parsed from
+	Value string
+	// Network is "stdout", "stderr", "file", "tcp", or "unix"
+	Network string
+	Address string
+	// Config is the ReporterConfig the default reporter uses when writing to this sink
+	Config ReporterConfig
+}
+
+var outputSinkVerbosities = map[string]VerbosityLevel{
+	"quiet":        VerbosityLevelQuiet,
+	"succinct":     VerbosityLevelSuccinct,
+	"normal":       VerbosityLevelNormal,
+	"v":            VerbosityLevelVerbose,
+	"verbose":      VerbosityLevelVerbose,
+	"vv":           VerbosityLevelVeryVerbose,
+	"very-verbose": VerbosityLevelVeryVerbose,
+}
+
+// OutputSinkDestinations parses the --output-sink values.  Each value is a destination followed by an optional, comma-separated, list of options
+// (e.g. "file://ginkgo.log,very-verbose").  Sinks inherit the console's configuration unless their options override it - except that
+// file, tcp, and unix sinks default to no color.
+func (rc ReporterConfig) OutputSinkDestinations() ([]OutputSink, error) {
+	sinks := []OutputSink{}
+	for _, value := range rc.OutputSinks {
+		components := strings.Split(value, ",")
+		destination := strings.TrimSpace(components[0])
+		sink := OutputSink{Value: value, Config: rc}
+		sink.Config.OutputSinks = nil
+		switch {
+		case destination == "stdout" || destination == "stderr":
+			sink.Network = destination
+		case strings.HasPrefix(destination, "file://") && len(destination) > len("file://"):
+			sink.Network, sink.Address = "file", strings.TrimPrefix(destination, "file://")
+		case strings.HasPrefix(destination, "tcp://") && len(destination) > len("tcp://"):
+			sink.Network, sink.Address = "tcp", strings.TrimPrefix(destination, "tcp://")
+		case strings.HasPrefix(destination, "unix://") && len(destination) > len("unix://"):
+			sink.Network, sink.Address = "unix", strings.TrimPrefix(destination, "unix://")
+		default:
+			return nil, GinkgoErrors.InvalidOutputSinkConfiguration(value)
+		}
+		if sink.Network != "stdout" && sink.Network != "stderr" {
+			sink.Config.NoColor = true
+		}
+		for _, option := range components[1:] {
+			option = strings.TrimSpace(option)
+			if verbosity, ok := outputSinkVerbosities[option]; ok {
+				sink.Config.Quiet = verbosity == VerbosityLevelQuiet
+				sink.Config.Succinct = verbosity == VerbosityLevelSuccinct
+				sink.Config.Verbose = verbosity == VerbosityLevelVerbose
+				sink.Config.VeryVerbose = verbosity == VerbosityLevelVeryVerbose
+				continue
+			}
+			switch option {
+			case "color":
+				sink.Config.NoColor = false
+			case "no-color":
+				sink.Config.NoColor = true
+			default:
+				return nil, GinkgoErrors.InvalidOutputSinkConfiguration(value)
+			}
+		}
+		sinks = append(sinks, sink)
+	}
+	return sinks, nil
+}
+
 func (rc ReporterConfig) WillGenerateReport() bool {
-	return rc.JSONReport != "" || rc.JUnitReport != "" || rc.TeamcityReport != ""
+	return rc.JSONReport != "" || rc.JUnitReport != "" || rc.TeamcityReport != "" || rc.TAPReport != ""
 }
 
 func NewDefaultReporterConfig() ReporterConfig {
@@ -117,14 +522,16 @@ type CLIConfig struct {
 	SkipPackage  string
 	RequireSuite bool
 	NumCompilers int
+	NoCache      bool
 
 	//for run and watch only
-	Procs                     int
+	Procs                     ProcCount
 	Parallel                  bool
 	AfterRunHook              string
 	OutputDir                 string
 	KeepSeparateCoverprofiles bool
 	KeepSeparateReports       bool
+	ConfigFile                string
 
 	//for run only
 	KeepGoing       bool
@@ -135,6 +542,43 @@ type CLIConfig struct {
 	//for watch only
 	Depth       int
 	WatchRegExp string
+	Interactive bool
+
+	//for debug only
+	Delve bool
+
+	//for sweep only
+	SweepDryRun bool
+
+	//for report replay only
+	ReplaySpeed float64
+}
+
+// ProcCount is the number of parallel processes requested with --procs.  In addition to a number it accepts "auto" (AutoProcs)
+// which has the CLI pick and adapt the number of processes based on the host's resources.
+type ProcCount int
+
+// AutoProcs represents --procs=auto
+const AutoProcs ProcCount = -1
+
+func (p ProcCount) String() string {
+	if p == AutoProcs {
+		return "auto"
+	}
+	return strconv.Itoa(int(p))
+}
+
+func (p *ProcCount) Set(s string) error {
+	if s == "auto" {
+		*p = AutoProcs
+		return nil
+	}
+	n, err := strconv.Atoi(s)
+	if err != nil || n < 0 {
+		return fmt.Errorf("must be a non-negative number or \"auto\"")
+	}
+	*p = ProcCount(n)
+	return nil
 }
 
 func NewDefaultCLIConfig() CLIConfig {
@@ -144,9 +588,14 @@ func NewDefaultCLIConfig() CLIConfig {
 	}
 }
 
+// ComputedProcs returns the number of parallel processes to launch.  With --procs=auto this is the maximum number of processes
+// the CLI will run specs on at once - see AutoTunedProcs
 func (g CLIConfig) ComputedProcs() int {
 	if g.Procs > 0 {
-		return g.Procs
+		return int(g.Procs)
+	}
+	if g.AutoTunedProcs() {
+		return runtime.NumCPU()
 	}
 
 	n := 1
@@ -159,6 +608,11 @@ func (g CLIConfig) ComputedProcs() int {
 	return n
 }
 
+// AutoTunedProcs returns true if --procs=auto was requested
+func (g CLIConfig) AutoTunedProcs() bool {
+	return g.Procs == AutoProcs
+}
+
 func (g CLIConfig) ComputedNumCompilers() int {
 	if g.NumCompilers > 0 {
 		return g.NumCompilers
@@ -242,12 +696,14 @@ var FlagSections = GinkgoFlagSections{
 	{Key: "low-level-parallel", Style: "{{yellow}}", Heading: "Controlling Test Parallelism",
 		Description: "These are set by the Ginkgo CLI, {{red}}{{bold}}do not set them manually{{/}} via go test.\nUse ginkgo -p or ginkgo -procs=N instead."},
 	{Key: "filter", Style: "{{cyan}}", Heading: "Filtering Tests"},
+	{Key: "low-level-filter", Style: "{{cyan}}", Heading: "Filtering Tests",
+		Description: "These are set by the Ginkgo CLI, {{red}}{{bold}}do not set them manually{{/}} via go test."},
 	{Key: "failure", Style: "{{red}}", Heading: "Failure Handling"},
 	{Key: "output", Style: "{{magenta}}", Heading: "Controlling Output Formatting"},
 	{Key: "code-and-coverage-analysis", Style: "{{orange}}", Heading: "Code and Coverage Analysis"},
 	{Key: "performance-analysis", Style: "{{coral}}", Heading: "Performance Analysis"},
 	{Key: "debug", Style: "{{blue}}", Heading: "Debugging Tests",
-		Description: "In addition to these flags, Ginkgo supports a few debugging environment variables.  To change the parallel server protocol set {{blue}}GINKGO_PARALLEL_PROTOCOL{{/}} to {{bold}}HTTP{{/}}.  To avoid pruning callstacks set {{blue}}GINKGO_PRUNE_STACK{{/}} to {{bold}}FALSE{{/}}."},
+		Description: "In addition to these flags, Ginkgo supports a few debugging environment variables.  To change the parallel server protocol set {{blue}}GINKGO_PARALLEL_PROTOCOL{{/}} to {{bold}}HTTP{{/}}.  To avoid pruning callstacks set {{blue}}GINKGO_PRUNE_STACK{{/}} to {{bold}}FALSE{{/}}.  To run a suite compiled against a different version of Ginkgo than the CLI set {{blue}}GINKGO_ALLOW_VERSION_MISMATCH{{/}} to {{bold}}TRUE{{/}}."},
 	{Key: "watch", Style: "{{light-yellow}}", Heading: "Controlling Ginkgo Watch"},
 	{Key: "misc", Style: "{{light-gray}}", Heading: "Miscellaneous"},
 	{Key: "go-build", Style: "{{light-gray}}", Heading: "Go Build Flags", Succinct: true,
@@ -258,22 +714,83 @@ var FlagSections = GinkgoFlagSections{
 var SuiteConfigFlags = GinkgoFlags{
 	{KeyPath: "S.RandomSeed", Name: "seed", SectionKey: "order", UsageDefaultValue: "randomly generated by Ginkgo",
 		Usage: "The seed used to randomize the spec suite."},
+	{KeyPath: "S.SeedStrategy", Name: "seed-strategy", SectionKey: "order", UsageDefaultValue: "time",
+		Usage: "How Ginkgo computes the random seed when --seed is not set.  One of 'time' (a new seed every run), 'git-sha' (a stable seed per commit), 'ci-build' (a stable seed per CI build ID), or 'branch' (a stable seed per branch)."},
 	{KeyPath: "S.RandomizeAllSpecs", Name: "randomize-all", SectionKey: "order", DeprecatedName: "randomizeAllSpecs", DeprecatedDocLink: "changed-command-line-flags",
 		Usage: "If set, ginkgo will randomize all specs together.  By default, ginkgo only randomizes the top level Describe, Context and When containers."},
+	{KeyPath: "S.TimeBox", Name: "time-box", SectionKey: "order", UsageArgument: "duration", UsageDefaultValue: "0 - no time box",
+		Usage: "If set, ginkgo will run specs - those with the highest SpecPriority first - until this much time has passed since the suite started.  Specs that have not started by then are not run and are reported as skipped.  Useful for getting a fast signal from a very large suite."},
 
 	{KeyPath: "S.FailOnPending", Name: "fail-on-pending", SectionKey: "failure", DeprecatedName: "failOnPending", DeprecatedDocLink: "changed-command-line-flags",
 		Usage: "If set, ginkgo will mark the test suite as failed if any specs are pending."},
 	{KeyPath: "S.FailFast", Name: "fail-fast", SectionKey: "failure", DeprecatedName: "failFast", DeprecatedDocLink: "changed-command-line-flags",
 		Usage: "If set, ginkgo will stop running a test suite after a failure occurs."},
+	{KeyPath: "S.FocusExitCode", Name: "focus-exit-code", SectionKey: "failure", UsageArgument: "fail, warn, or off", UsageDefaultValue: "fail",
+		Usage: "Controls what happens when a passing suite contains programmatically focused specs (e.g. FIt or FDescribe).  fail exits with status 197, warn emits a warning but exits with status 0, and off exits with status 0 silently."},
+	{KeyPath: "S.ValidateFilters", Name: "validate-filters", SectionKey: "failure", UsageArgument: "off, warn, or fail", UsageDefaultValue: "off",
+		Usage: "Controls what happens when a --focus, --skip, --focus-file, --skip-file, or --label-filter expression matches no specs - usually a sign of a typo.  warn lists the unmatched expressions at the end of the run and fail also fails the suite without running any specs.  When running multiple suites an expression only needs to match specs in one of them: ginkgo validates the expressions once all the suites have run."},
+	{KeyPath: "S.AfterSuiteFailurePolicy", Name: "after-suite-failure-policy", SectionKey: "failure", UsageArgument: "fail, warn, or retry", UsageDefaultValue: "fail",
+		Usage: "Controls what happens when AfterSuite or SynchronizedAfterSuite fails on a process.  fail fails the suite, warn reports the failure but does not fail the suite, and retry runs the failed node once more on that process and fails the suite only if the retry fails too."},
+	{KeyPath: "S.SkipOrderedCleanupOnAbort", Name: "skip-ordered-cleanup-on-abort", SectionKey: "failure",
+		Usage: "By default, when the suite is interrupted, aborted, or runs out of time partway through an Ordered container, ginkgo still runs the container's pending AfterAll nodes and the DeferCleanups registered in its BeforeAll and AfterAll nodes, and reports their outcome on the first skipped spec.  If set, ginkgo skips them instead."},
+	{KeyPath: "S.ResourceRegistry", Name: "resource-registry", SectionKey: "failure", UsageArgument: "path to registry file",
+		Usage: "If set, ginkgo will record the resources registered with RegisterResource in this file, and mark them as released once they are cleaned up.  If a run crashes before cleaning up, run ginkgo sweep on the registry to delete the orphaned resources.  When run via the ginkgo CLI, relative paths are relative to the current working directory so all the suites share one registry."},
+	{KeyPath: "S.ArtifactsDir", Name: "artifacts-dir", SectionKey: "failure", UsageArgument: "directory", UsageDefaultValue: "a temporary directory",
+		Usage: "If set, the FailureArtifactCollectors registered with RegisterFailureArtifactCollector write the artifacts they collect for failing specs under this directory.  Relative paths are relative to the suite's package directory."},
 	{KeyPath: "S.FlakeAttempts", Name: "flake-attempts", SectionKey: "failure", UsageDefaultValue: "0 - failed tests are not retried", DeprecatedName: "flakeAttempts", DeprecatedDocLink: "changed-command-line-flags",
 		Usage: "Make up to this many attempts to run each spec. If any of the attempts succeed, the suite will not be failed."},
+	{KeyPath: "S.ZeroAssertionPolicy", Name: "zero-assertion-policy", SectionKey: "failure", UsageArgument: "off, warn, or fail", UsageDefaultValue: "off",
+		Usage: "Controls what happens when a spec passes without making any assertions - usually a sign of a vacuous test.  warn adds a warning to the spec and fail fails it.  Ginkgo counts calls to Fail and RecordAssertion.  Since Gomega only tells Ginkgo about the assertions that fail this only applies to suites that call RecordAssertion to count the assertions that pass."},
+	{KeyPath: "S.UpdateSnapshots", Name: "update-snapshots", SectionKey: "failure",
+		Usage: "If set, tables decorated with GoldenFiles write the values their entries return to their golden files instead of comparing against them.  Review the changes to the golden files before committing them."},
+	{KeyPath: "S.RetryOn", Name: "retry-on", SectionKey: "failure", UsageArgument: "[label:]rule,rule", UsageDefaultValue: "all failures are retried",
+		Usage: "If set, --flake-attempts and the Retry decorator only retry failures that match these rules.  Rules are failure categories (assertion, error, panic, timeout, or infrastructure for error and timeout), or failure fingerprints.  Prefix a rule with ! to never retry failures that match it, e.g. '!assertion'.  Prefix the rules with a label and a colon to apply them only to specs with that label, e.g. 'network:infrastructure'.  Can be specified multiple times."},
+
+	{KeyPath: "S.MaxSpecsPerMinute", Name: "max-specs-per-minute", SectionKey: "parallel", UsageDefaultValue: "0 (no limit)",
+		Usage: "If set, ginkgo will start at most this many specs per minute.  When running in parallel the limit applies across all processes."},
+	{KeyPath: "S.MaxSpecsPerMinuteByLabel", Name: "max-specs-per-minute-by-label", SectionKey: "parallel", UsageArgument: "label:N",
+		Usage: "If set, ginkgo will start at most N specs with the given label per minute.  When running in parallel the limit applies across all processes.  Multiple labels can be specified with multiple flags."},
+
+	{KeyPath: "S.PinLabelToProcess", Name: "pin-label-to-process", SectionKey: "parallel", UsageArgument: "label:N",
+		Usage: "If set, specs with the given label will only run on parallel process #N.  Useful when a single process owns a shared resource, like a browser.  Multiple labels can be specified with multiple flags."},
+	{KeyPath: "S.CapacityCPU", Name: "capacity-cpu", SectionKey: "parallel", UsageDefaultValue: "number of CPUs on the host",
+		Usage: "The number of CPUs available to specs that declare CPU requirements with the Requires decorator.  When running in parallel ginkgo will not start a spec if doing so would oversubscribe this capacity."},
+	{KeyPath: "S.CapacityMemory", Name: "capacity-memory", SectionKey: "parallel", UsageArgument: "quantity, e.g. 32Gi", UsageDefaultValue: "unconstrained",
+		Usage: "The memory available to specs that declare memory requirements with the Requires decorator.  When running in parallel ginkgo will not start a spec if doing so would oversubscribe this capacity."},
+	{KeyPath: "S.CapacityGPU", Name: "capacity-gpu", SectionKey: "parallel", UsageDefaultValue: "0 - unconstrained",
+		Usage: "The number of GPUs available to specs that declare GPU requirements with the Requires decorator.  When running in parallel ginkgo will not start a spec if doing so would oversubscribe this capacity."},
+
+	{KeyPath: "S.ReplayParallelSchedule", Name: "replay-parallel-schedule", SectionKey: "parallel", UsageArgument: "path to JSON report",
+		Usage: "If set, ginkgo will replay the parallel schedule recorded in the passed-in JSON report: each parallel process will run the same specs, in the same order, as in the recorded run.  Must be used with the same --seed and --procs as the recorded run."},
 
 	{KeyPath: "S.DryRun", Name: "dry-run", SectionKey: "debug", DeprecatedName: "dryRun", DeprecatedDocLink: "changed-command-line-flags",
 		Usage: "If set, ginkgo will walk the test hierarchy without actually running anything.  Best paired with -v."},
+	{KeyPath: "S.ExplainSpec", Name: "explain", SectionKey: "debug", UsageArgument: "spec text or location",
+		Usage: "If set, ginkgo will explain which of the focus, skip, and label filters include or exclude the specs that match the passed-in text or location (e.g. my_test.go:42) instead of running the suite.  Used by ginkgo explain."},
+	{KeyPath: "S.DescribeSuite", Name: "describe-suite", SectionKey: "debug",
+		Usage: "If set, ginkgo will print a JSON description of the suite (the Ginkgo version, suite name, spec counts by label, and environment variables declared with RequiresEnv) instead of running it.  Useful for introspecting precompiled test binaries."},
 	{KeyPath: "S.EmitSpecProgress", Name: "progress", SectionKey: "debug",
 		Usage: "If set, ginkgo will emit progress information as each spec runs to the GinkgoWriter."},
 	{KeyPath: "S.Timeout", Name: "timeout", SectionKey: "debug", UsageDefaultValue: "1h",
 		Usage: "Test suite fails if it does not complete within the specified timeout."},
+	{KeyPath: "S.SuiteNodeTimeout", Name: "suite-node-timeout", SectionKey: "debug", UsageDefaultValue: "0 - no timeout",
+		Usage: "If set, BeforeSuite, AfterSuite, and their Synchronized variants fail if they do not complete within the specified timeout.  Use the NodeTimeout decorator to override this for an individual node."},
+	{KeyPath: "S.CPUTimeLimit", Name: "cpu-time-limit", SectionKey: "debug", UsageArgument: "duration", UsageDefaultValue: "0 - no limit",
+		Usage: "If set, specs that pass but use more than this much CPU time (user and system time, measured with getrusage) are failed - to catch accidental busy-loops.  Use the CPUTimeLimit decorator to override this for individual specs and containers."},
+	{KeyPath: "S.GracePeriod", Name: "grace-period", SectionKey: "debug", UsageDefaultValue: "30s",
+		Usage: "When a node that accepts a SpecContext is interrupted or times out, Ginkgo cancels the context and waits up to this long for the node to exit before moving on."},
+	{KeyPath: "S.PollProgressAfter", Name: "poll-progress-after", SectionKey: "debug", UsageArgument: "duration", UsageDefaultValue: "0 - no progress reports",
+		Usage: "If set, ginkgo will emit a progress report - including the stack of the goroutine running the current node - when a spec runs for longer than this, and again each time the duration elapses until the node completes.  Use the PollProgressAfter decorator to override this for individual specs and containers."},
+	{KeyPath: "S.FingerprintEnvVars", Name: "fingerprint-env", SectionKey: "debug", UsageArgument: "environment variable name",
+		Usage: "If set, ginkgo will capture the value of this environment variable in the report's environment fingerprint (in addition to a default set of Go-related environment variables).  Multiple environment variables can be specified with multiple flags."},
+	{KeyPath: "S.Chaos", Name: "chaos", SectionKey: "debug",
+		Usage: "If set, ginkgo will run in chaos mode: pausing for a random amount of time before each spec node and, if --chaos-failure-rate is set, failing spec nodes at random.  Use this to validate that your suite is robust to timing variance.  Chaotic runs are reproducible with --seed."},
+	{KeyPath: "S.ChaosMaxDelay", Name: "chaos-max-delay", SectionKey: "debug", UsageDefaultValue: "100ms",
+		Usage: "In chaos mode, the maximum amount of time ginkgo will pause before each spec node."},
+	{KeyPath: "S.ChaosFailureRate", Name: "chaos-failure-rate", SectionKey: "debug", UsageDefaultValue: "0 - no simulated failures",
+		Usage: "In chaos mode, the probability (between 0 and 1) that ginkgo will fail a spec node instead of running it."},
+	{KeyPath: "S.RequireSingleSpec", Name: "require-single-spec", SectionKey: "debug",
+		Usage: "If set, ginkgo will fail the suite without running anything unless the filters select exactly one spec.  Set automatically by ginkgo debug."},
 	{KeyPath: "S.OutputInterceptorMode", Name: "output-interceptor-mode", SectionKey: "debug", UsageArgument: "dup, swap, or none",
 		Usage: "If set, ginkgo will use the specified output interception strategy when running in parallel.  Defaults to dup on unix and swap on windows."},
 
@@ -287,6 +804,10 @@ var SuiteConfigFlags = GinkgoFlags{
 		Usage: "If set, ginkgo will only run specs in matching files. Can be specified multiple times, values are ORed."},
 	{KeyPath: "S.SkipFiles", Name: "skip-file", SectionKey: "filter", UsageArgument: "file (regexp) | file:line | file:lineA-lineB | file:line,line,line",
 		Usage: "If set, ginkgo will skip specs in matching files. Can be specified multiple times, values are ORed."},
+	{KeyPath: "S.TableSample", Name: "table-sample", SectionKey: "filter", UsageArgument: "fraction", UsageDefaultValue: "0 - run every entry",
+		Usage: "If set to a fraction between 0 and 1, ginkgo will only run a random sample of each table's entries - e.g. 0.1 runs a tenth of the entries of each DescribeTable (and at least one).  The entries that are not sampled are skipped.  Useful for running huge generated tables in presubmit while the full matrix runs nightly."},
+	{KeyPath: "S.TableSampleSeed", Name: "table-sample-seed", SectionKey: "filter", UsageDefaultValue: "the random seed",
+		Usage: "The seed used to pick the table entries to run when --table-sample is set.  Pass the same seed to sample the same entries again."},
 
 	{KeyPath: "D.RegexScansFilePath", DeprecatedName: "regexScansFilePath", DeprecatedDocLink: "removed--regexscansfilepath", DeprecatedVersion: "2.0.0"},
 	{KeyPath: "D.DebugParallel", DeprecatedName: "debug", DeprecatedDocLink: "removed--debug", DeprecatedVersion: "2.0.0"},
@@ -300,12 +821,18 @@ var ParallelConfigFlags = GinkgoFlags{
 		Usage: "The total number of worker processes.  For running specs in parallel."},
 	{KeyPath: "S.ParallelHost", Name: "parallel.host", SectionKey: "low-level-parallel", UsageDefaultValue: "set by Ginkgo CLI",
 		Usage: "The address for the server that will synchronize the processes."},
+	{KeyPath: "S.UnmatchedFiltersFile", Name: "unmatched-filters-file", SectionKey: "low-level-filter", UsageDefaultValue: "set by Ginkgo CLI",
+		Usage: "When the Ginkgo CLI runs multiple suites with --validate-filters it validates the filters across all the suites.  Each suite records the filters that matched none of its specs in this file instead of warning about, or failing on, them."},
 }
 
 // ReporterConfigFlags provides flags for the Ginkgo test process, and CLI
 var ReporterConfigFlags = GinkgoFlags{
 	{KeyPath: "R.NoColor", Name: "no-color", SectionKey: "output", DeprecatedName: "noColor", DeprecatedDocLink: "changed-command-line-flags",
 		Usage: "If set, suppress color output in default reporter."},
+	{KeyPath: "R.ASCII", Name: "ascii", SectionKey: "output",
+		Usage: "If set, default reporter replaces the unicode glyphs and emoji in its output with ASCII equivalents.  Any other non-ASCII characters are escaped (e.g. as \\u00e9)."},
+	{KeyPath: "R.NoCursorControl", Name: "no-cursor-control", SectionKey: "output",
+		Usage: "If set, default reporter strips carriage returns and terminal control sequences that move the cursor or clear the screen from its output - including captured output.  Pair with --no-color to emit plain text."},
 	{KeyPath: "R.SlowSpecThreshold", Name: "slow-spec-threshold", SectionKey: "output", UsageArgument: "duration", UsageDefaultValue: "5s",
 		Usage: "Specs that take longer to run than this threshold are flagged as slow by the default reporter."},
 	{KeyPath: "R.Verbose", Name: "v", SectionKey: "output",
@@ -314,10 +841,22 @@ var ReporterConfigFlags = GinkgoFlags{
 		Usage: "If set, emits with maximal verbosity - includes skipped and pending tests."},
 	{KeyPath: "R.Succinct", Name: "succinct", SectionKey: "output",
 		Usage: "If set, default reporter prints out a very succinct report"},
+	{KeyPath: "R.Quiet", Name: "quiet", SectionKey: "output",
+		Usage: "If set, default reporter prints nothing while the suite runs.  When the suite ends it prints a compact digest of each failure - including a command to rerun the failed spec - followed by the usual summary.  Intended for very large suites whose output would exceed CI log limits."},
 	{KeyPath: "R.FullTrace", Name: "trace", SectionKey: "output",
 		Usage: "If set, default reporter prints out the full stack trace when a failure occurs"},
+	{KeyPath: "R.FullTraceOn", Name: "trace-on", SectionKey: "output", UsageArgument: "spec state",
+		Usage: "If set, default reporter prints out the full stack trace when a spec ends in the given state.  One of 'failed', 'panicked', 'interrupted', 'aborted', or 'timedout'.  Multiple states can be specified with multiple flags."},
+	{KeyPath: "R.StackTraceDepth", Name: "stack-trace-depth", SectionKey: "output", UsageDefaultValue: "0 (no limit)",
+		Usage: "The maximum number of stack frames the default reporter emits when printing out a full stack trace."},
+	{KeyPath: "R.StackTracePrune", Name: "stack-trace-prune", SectionKey: "output", UsageArgument: "regexp",
+		Usage: "If set, default reporter omits stack frames whose source location matches this regular expression when printing out a full stack trace.  Ginkgo's own frames are always pruned.  Multiple regular expressions can be specified with multiple flags."},
+	{KeyPath: "R.StackTraceOmitArgs", Name: "stack-trace-omit-args", SectionKey: "output",
+		Usage: "If set, default reporter omits function arguments when printing out a full stack trace."},
 	{KeyPath: "R.AlwaysEmitGinkgoWriter", Name: "always-emit-ginkgo-writer", SectionKey: "output", DeprecatedName: "reportPassed", DeprecatedDocLink: "renamed--reportpassed",
 		Usage: "If set, default reporter prints out captured output of passed tests."},
+	{KeyPath: "R.LabelSummary", Name: "label-summary", SectionKey: "output",
+		Usage: "If set, default reporter prints out a summary of the results of the run grouped by label - including pass/fail/skip counts and the total duration of the specs with each label."},
 
 	{KeyPath: "R.JSONReport", Name: "json-report", UsageArgument: "filename.json", SectionKey: "output",
 		Usage: "If set, Ginkgo will generate a JSON-formatted test report at the specified location."},
@@ -325,6 +864,25 @@ var ReporterConfigFlags = GinkgoFlags{
 		Usage: "If set, Ginkgo will generate a conformant junit test report in the specified file."},
 	{KeyPath: "R.TeamcityReport", Name: "teamcity-report", UsageArgument: "filename", SectionKey: "output",
 		Usage: "If set, Ginkgo will generate a Teamcity-formatted test report at the specified location."},
+	{KeyPath: "R.TAPReport", Name: "tap-report", UsageArgument: "filename.tap", SectionKey: "output",
+		Usage: "If set, Ginkgo will generate a TAP (Test Anything Protocol) version 13 test report at the specified location."},
+
+	{KeyPath: "R.ConsoleReportEntryVisibilities", Name: "console-report-entry-visibility", UsageArgument: "always | failure-or-verbose | never", SectionKey: "output",
+		Usage: "If set, the default reporter only emits ReportEntries with this visibility to the console.  Entries with the failure-or-verbose visibility are still only emitted when the spec fails or when running with -v.  Multiple visibilities can be specified with multiple flags."},
+	{KeyPath: "R.ConsoleReportEntrySkip", Name: "console-report-entry-skip", UsageArgument: "regexp", SectionKey: "output",
+		Usage: "If set, the default reporter does not emit ReportEntries whose name matches this regular expression to the console.  Multiple regular expressions can be specified with multiple flags."},
+	{KeyPath: "R.JSONReportEntryVisibilities", Name: "json-report-entry-visibility", UsageArgument: "always | failure-or-verbose | never", SectionKey: "output",
+		Usage: "If set, the report generated by --json-report only includes ReportEntries with this visibility.  Multiple visibilities can be specified with multiple flags."},
+	{KeyPath: "R.JSONReportEntrySkip", Name: "json-report-entry-skip", UsageArgument: "regexp", SectionKey: "output",
+		Usage: "If set, the report generated by --json-report does not include ReportEntries whose name matches this regular expression.  Multiple regular expressions can be specified with multiple flags."},
+	{KeyPath: "R.JUnitReportEntryVisibilities", Name: "junit-report-entry-visibility", UsageArgument: "always | failure-or-verbose | never", SectionKey: "output",
+		Usage: "If set, the report generated by --junit-report only includes ReportEntries with this visibility.  Multiple visibilities can be specified with multiple flags."},
+	{KeyPath: "R.JUnitReportEntrySkip", Name: "junit-report-entry-skip", UsageArgument: "regexp", SectionKey: "output",
+		Usage: "If set, the report generated by --junit-report does not include ReportEntries whose name matches this regular expression.  Multiple regular expressions can be specified with multiple flags."},
+	{KeyPath: "R.IDEProtocol", Name: "ide-protocol", UsageArgument: "stdout | tcp://host:port | unix:///path/to/socket", SectionKey: "output",
+		Usage: "If set, Ginkgo will emit machine-readable events as each spec starts and finishes to the specified destination.  Intended for editor and IDE integrations."},
+	{KeyPath: "R.OutputSinks", Name: "output-sink", UsageArgument: "destination[,options]", SectionKey: "output",
+		Usage: "If set, the default reporter also writes its output to the specified destination: 'stdout', 'stderr', 'file://path', 'tcp://host:port', or 'unix:///path/to/socket'.  Append a verbosity ('quiet', 'succinct', 'normal', 'verbose', or 'very-verbose') and/or 'color' or 'no-color' to configure the sink independently of the console (e.g. 'file://ginkgo.log,very-verbose').  Multiple sinks can be specified with multiple flags."},
 
 	{KeyPath: "D.SlowSpecThresholdWithFLoatUnits", DeprecatedName: "slowSpecThreshold", DeprecatedDocLink: "changed--slowspecthreshold",
 		Usage: "use --slow-spec-threshold instead and pass in a duration string (e.g. '5s', not '5.0')"},
@@ -402,14 +960,103 @@ func VetConfig(flagSet GinkgoFlagSet, suiteConfig SuiteConfig, reporterConfig Re
 		}
 	}
 
+	if suiteConfig.TimeBox < 0 {
+		errors = append(errors, GinkgoErrors.InvalidTimeBox())
+	}
+
+	if suiteConfig.TableSample < 0 || suiteConfig.TableSample > 1 {
+		errors = append(errors, GinkgoErrors.InvalidTableSample(suiteConfig.TableSample))
+	}
+
+	switch strings.ToLower(suiteConfig.SeedStrategy) {
+	case "", SeedStrategyTime, SeedStrategyGitSHA, SeedStrategyCIBuild, SeedStrategyBranch:
+	default:
+		errors = append(errors, GinkgoErrors.InvalidSeedStrategy(suiteConfig.SeedStrategy))
+	}
+
+	if suiteConfig.ChaosMaxDelay < 0 || suiteConfig.ChaosFailureRate < 0 || suiteConfig.ChaosFailureRate > 1 {
+		errors = append(errors, GinkgoErrors.InvalidChaosConfiguration())
+	}
+
+	if _, err := suiteConfig.SpecRateLimits(); err != nil {
+		errors = append(errors, err)
+	}
+
+	if _, err := suiteConfig.ProcessPins(); err != nil {
+		errors = append(errors, err)
+	}
+
+	if _, err := suiteConfig.RetryPolicies(); err != nil {
+		errors = append(errors, err)
+	}
+
+	if _, err := suiteConfig.HostCapacity(); err != nil {
+		errors = append(errors, err)
+	}
+
 	switch strings.ToLower(suiteConfig.OutputInterceptorMode) {
 	case "", "dup", "swap", "none":
 	default:
 		errors = append(errors, GinkgoErrors.InvalidOutputInterceptorModeConfiguration(suiteConfig.OutputInterceptorMode))
 	}
 
+	switch strings.ToLower(suiteConfig.FocusExitCode) {
+	case "", FocusExitCodeFail, FocusExitCodeWarn, FocusExitCodeOff:
+	default:
+		errors = append(errors, GinkgoErrors.InvalidFocusExitCodeConfiguration(suiteConfig.FocusExitCode))
+	}
+
+	switch strings.ToLower(suiteConfig.ValidateFilters) {
+	case "", ValidateFiltersOff, ValidateFiltersWarn, ValidateFiltersFail:
+	default:
+		errors = append(errors, GinkgoErrors.InvalidValidateFiltersConfiguration(suiteConfig.ValidateFilters))
+	}
+
+	switch strings.ToLower(suiteConfig.ZeroAssertionPolicy) {
+	case "", ZeroAssertionPolicyOff, ZeroAssertionPolicyWarn, ZeroAssertionPolicyFail:
+	default:
+		errors = append(errors, GinkgoErrors.InvalidZeroAssertionPolicyConfiguration(suiteConfig.ZeroAssertionPolicy))
+	}
+
+	switch strings.ToLower(suiteConfig.AfterSuiteFailurePolicy) {
+	case "", AfterSuiteFailurePolicyFail, AfterSuiteFailurePolicyWarn, AfterSuiteFailurePolicyRetry:
+	default:
+		errors = append(errors, GinkgoErrors.InvalidAfterSuiteFailurePolicyConfiguration(suiteConfig.AfterSuiteFailurePolicy))
+	}
+
+	for _, state := range reporterConfig.FullTraceOn {
+		switch state {
+		case "failed", "panicked", "interrupted", "aborted", "timedout":
+		default:
+			errors = append(errors, GinkgoErrors.InvalidFullTraceOnConfiguration(state))
+		}
+	}
+
+	for _, prune := range reporterConfig.StackTracePrune {
+		_, err := regexp.Compile(prune)
+		if err != nil {
+			errors = append(errors, GinkgoErrors.InvalidStackTracePruneConfiguration(prune, err))
+		}
+	}
+
+	for _, reportEntryFilter := range []func() (ReportEntryFilter, error){reporterConfig.ConsoleReportEntryFilter, reporterConfig.JSONReportEntryFilter, reporterConfig.JUnitReportEntryFilter} {
+		if _, err := reportEntryFilter(); err != nil {
+			errors = append(errors, err)
+		}
+	}
+
+	if reporterConfig.IDEProtocol != "" {
+		if _, _, err := reporterConfig.IDEProtocolDestination(); err != nil {
+			errors = append(errors, err)
+		}
+	}
+
+	if _, err := reporterConfig.OutputSinkDestinations(); err != nil {
+		errors = append(errors, err)
+	}
+
 	numVerbosity := 0
-	for _, v := range []bool{reporterConfig.Succinct, reporterConfig.Verbose, reporterConfig.VeryVerbose} {
+	for _, v := range []bool{reporterConfig.Quiet, reporterConfig.Succinct, reporterConfig.Verbose, reporterConfig.VeryVerbose} {
 		if v {
 			numVerbosity++
 		}
@@ -432,13 +1079,15 @@ var GinkgoCLISharedFlags = GinkgoFlags{
 		Usage: "If set, Ginkgo fails if there are ginkgo tests in a directory but no invocation of RunSpecs."},
 	{KeyPath: "C.NumCompilers", Name: "compilers", SectionKey: "multiple-suites", UsageDefaultValue: "0 (will autodetect)",
 		Usage: "When running multiple packages, the number of concurrent compilations to perform."},
+	{KeyPath: "C.NoCache", Name: "no-cache", SectionKey: "multiple-suites",
+		Usage: "If set, Ginkgo forces a fresh build of every suite and its dependencies (go test -a) so that no results can come from stale cached builds."},
 }
 
 // GinkgoCLIRunAndWatchFlags provides flags shared by the Ginkgo CLI's build and watch commands (but not run)
 var GinkgoCLIRunAndWatchFlags = GinkgoFlags{
-	{KeyPath: "C.Procs", Name: "procs", SectionKey: "parallel", UsageDefaultValue: "1 (run in series)",
-		Usage: "The number of parallel test nodes to run."},
-	{KeyPath: "C.Procs", Name: "nodes", SectionKey: "parallel", UsageDefaultValue: "1 (run in series)",
+	{KeyPath: "C.Procs", Name: "procs", SectionKey: "parallel", UsageDefaultValue: "1 (run in series)", UsageArgument: "int|auto",
+		Usage: "The number of parallel test nodes to run.  Set to auto to have Ginkgo pick the number of nodes based on the available CPUs and memory and adapt it to the resources the specs use and the load on the host as the suite runs."},
+	{KeyPath: "C.Procs", Name: "nodes", SectionKey: "parallel", UsageDefaultValue: "1 (run in series)", UsageArgument: "int|auto",
 		Usage: "--nodes is an alias for --procs"},
 	{KeyPath: "C.Parallel", Name: "p", SectionKey: "parallel",
 		Usage: "If set, ginkgo will run in parallel with an auto-detected number of nodes."},
@@ -450,6 +1099,8 @@ var GinkgoCLIRunAndWatchFlags = GinkgoFlags{
 		Usage: "If set, Ginkgo does not merge coverprofiles into one monolithic coverprofile.  The coverprofiles will remain in their respective package directories or in -output-dir if set."},
 	{KeyPath: "C.KeepSeparateReports", Name: "keep-separate-reports", SectionKey: "output",
 		Usage: "If set, Ginkgo does not merge per-suite reports (e.g. -json-report) into one monolithic report for the entire testrun.  The reports will remain in their respective package directories or in -output-dir if set."},
+	{KeyPath: "C.ConfigFile", Name: "config", SectionKey: "output", UsageArgument: "file",
+		Usage: "A Ginkgo config file (JSON).  Its report-pipeline lists transforms (redact, truncate, enrich-owners, split-by-label) that Ginkgo applies, in order, to each suite's report before generating the -json-report, -junit-report, -teamcity-report, and -tap-report reports."},
 
 	{KeyPath: "D.Stream", DeprecatedName: "stream", DeprecatedDocLink: "removed--stream", DeprecatedVersion: "2.0.0"},
 	{KeyPath: "D.Notify", DeprecatedName: "notify", DeprecatedDocLink: "removed--notify", DeprecatedVersion: "2.0.0"},
@@ -475,6 +1126,26 @@ var GinkgoCLIWatchFlags = GinkgoFlags{
 		UsageArgument:     "Regular Expression",
 		UsageDefaultValue: `\.go$`,
 		Usage:             "Only files matching this regular expression will be watched for changes."},
+	{KeyPath: "C.Interactive", Name: "interactive", SectionKey: "watch",
+		Usage: "If set, ginkgo watch will read commands from stdin that let you re-run the last failures, toggle verbose output, and change the focus filter without restarting."},
+}
+
+// GinkgoCLIDebugFlags provides flags for Ginkgo CLI's debug command that aren't shared by any other commands
+var GinkgoCLIDebugFlags = GinkgoFlags{
+	{KeyPath: "C.Delve", Name: "delve", SectionKey: "debug",
+		Usage: "If set, ginkgo will run the spec under the delve debugger.  dlv must be on your PATH."},
+}
+
+// GinkgoCLISweepFlags provides flags for Ginkgo CLI's sweep command
+var GinkgoCLISweepFlags = GinkgoFlags{
+	{KeyPath: "C.SweepDryRun", Name: "dry-run", SectionKey: "misc",
+		Usage: "If set, ginkgo will list the orphaned resources and the commands it would run to sweep them without running anything."},
+}
+
+// GinkgoCLIReportFlags provides flags for Ginkgo CLI's report command
+var GinkgoCLIReportFlags = GinkgoFlags{
+	{KeyPath: "C.ReplaySpeed", Name: "speed", SectionKey: "misc", UsageDefaultValue: "0 - replay instantly",
+		Usage: "If set, ginkgo report replay reproduces the timing of the original run, sped up by this factor.  Use 1 to replay the run in real time and 10 to replay it ten times faster."},
 }
 
 // GoBuildFlags provides flags for the Ginkgo CLI build, run, and watch commands that capture go's build-time flags.  These are passed to go test -c by the ginkgo CLI
@@ -571,6 +1242,11 @@ func VetAndInitializeCLIAndGoConfig(cliConfig CLIConfig, goFlagsConfig GoFlagsCo
 		}
 	}
 
+	//--no-cache forces a fresh build.  the CLI runs the compiled binaries directly so results never come from go test's cache
+	if cliConfig.NoCache {
+		goFlagsConfig.A = true
+	}
+
 	//ensure cover mode is configured appropriately
 	if goFlagsConfig.CoverMode != "" || goFlagsConfig.CoverPkg != "" || goFlagsConfig.CoverProfile != "" {
 		goFlagsConfig.Cover = true
@@ -678,6 +1354,43 @@ func BuildWatchCommandFlagSet(suiteConfig *SuiteConfig, reporterConfig *Reporter
 	return NewGinkgoFlagSet(flags, bindings, FlagSections)
 }
 
+// BuildDebugCommandFlagSet builds the FlagSet for the `ginkgo debug` command
+func BuildDebugCommandFlagSet(suiteConfig *SuiteConfig, reporterConfig *ReporterConfig, cliConfig *CLIConfig, goFlagsConfig *GoFlagsConfig) (GinkgoFlagSet, error) {
+	flags := SuiteConfigFlags
+	flags = flags.CopyAppend(ReporterConfigFlags...)
+	flags = flags.CopyAppend(GinkgoCLISharedFlags...)
+	flags = flags.CopyAppend(GinkgoCLIDebugFlags...)
+	flags = flags.CopyAppend(GoBuildFlags...)
+
+	bindings := map[string]interface{}{
+		"S":  suiteConfig,
+		"R":  reporterConfig,
+		"C":  cliConfig,
+		"Go": goFlagsConfig,
+		"D":  &deprecatedConfig{},
+	}
+
+	return NewGinkgoFlagSet(flags, bindings, FlagSections)
+}
+
+// BuildExplainCommandFlagSet builds the FlagSet for the `ginkgo explain` command
+func BuildExplainCommandFlagSet(suiteConfig *SuiteConfig, reporterConfig *ReporterConfig, cliConfig *CLIConfig, goFlagsConfig *GoFlagsConfig) (GinkgoFlagSet, error) {
+	flags := SuiteConfigFlags
+	flags = flags.CopyAppend(ReporterConfigFlags...)
+	flags = flags.CopyAppend(GinkgoCLISharedFlags...)
+	flags = flags.CopyAppend(GoBuildFlags...)
+
+	bindings := map[string]interface{}{
+		"S":  suiteConfig,
+		"R":  reporterConfig,
+		"C":  cliConfig,
+		"Go": goFlagsConfig,
+		"D":  &deprecatedConfig{},
+	}
+
+	return NewGinkgoFlagSet(flags, bindings, FlagSections)
+}
+
 // BuildBuildCommandFlagSet builds the FlagSet for the `ginkgo build` command
 func BuildBuildCommandFlagSet(cliConfig *CLIConfig, goFlagsConfig *GoFlagsConfig) (GinkgoFlagSet, error) {
 	flags := GinkgoCLISharedFlags
@@ -704,6 +1417,41 @@ func BuildBuildCommandFlagSet(cliConfig *CLIConfig, goFlagsConfig *GoFlagsConfig
 	return NewGinkgoFlagSet(flags, bindings, flagSections)
 }
 
+// BuildSweepCommandFlagSet builds the FlagSet for the `ginkgo sweep` command
+func BuildSweepCommandFlagSet(cliConfig *CLIConfig) (GinkgoFlagSet, error) {
+	bindings := map[string]interface{}{
+		"C": cliConfig,
+	}
+
+	return NewGinkgoFlagSet(GinkgoCLISweepFlags, bindings, FlagSections)
+}
+
+// BuildDoctorCommandFlagSet builds the FlagSet for the `ginkgo doctor` command
+func BuildDoctorCommandFlagSet(reporterConfig *ReporterConfig, cliConfig *CLIConfig) (GinkgoFlagSet, error) {
+	flags := ReporterConfigFlags.SubsetWithNames("no-color")
+	flags = flags.CopyAppend(GinkgoCLIRunAndWatchFlags.SubsetWithNames("output-dir")...)
+
+	bindings := map[string]interface{}{
+		"R": reporterConfig,
+		"C": cliConfig,
+	}
+
+	return NewGinkgoFlagSet(flags, bindings, FlagSections)
+}
+
+// BuildReportCommandFlagSet builds the FlagSet for the `ginkgo report` command
+func BuildReportCommandFlagSet(reporterConfig *ReporterConfig, cliConfig *CLIConfig) (GinkgoFlagSet, error) {
+	flags := ReporterConfigFlags.SubsetWithNames("no-color", "v", "vv", "succinct", "trace", "slow-spec-threshold", "always-emit-ginkgo-writer", "label-summary", "console-report-entry-visibility", "console-report-entry-skip")
+	flags = flags.CopyAppend(GinkgoCLIReportFlags...)
+
+	bindings := map[string]interface{}{
+		"R": reporterConfig,
+		"C": cliConfig,
+	}
+
+	return NewGinkgoFlagSet(flags, bindings, FlagSections)
+}
+
 func BuildLabelsCommandFlagSet(cliConfig *CLIConfig) (GinkgoFlagSet, error) {
 	flags := GinkgoCLISharedFlags.SubsetWithNames("r", "skip-package")
 
@@ -3,6 +3,8 @@ package types_test
 import (
 	"flag"
 	"net/http"
+	"runtime"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	"github.com/onsi/ginkgo/v2/types"
@@ -42,6 +44,9 @@ var _ = Describe("Config", func() {
 
 				repConf = types.ReporterConfig{TeamcityReport: "foo"}
 				Ω(repConf.WillGenerateReport()).Should(BeTrue())
+
+				repConf = types.ReporterConfig{TAPReport: "foo"}
+				Ω(repConf.WillGenerateReport()).Should(BeTrue())
 			})
 		})
 
@@ -210,6 +215,90 @@ var _ = Describe("Config", func() {
 			})
 		})
 
+		Describe("validating --focus-exit-code", func() {
+			It("errors if an invalid focus exit code behavior is specified", func() {
+				suiteConf.FocusExitCode = "DURP"
+				errors := types.VetConfig(flagSet, suiteConf, repConf)
+				Ω(errors).Should(ConsistOf(types.GinkgoErrors.InvalidFocusExitCodeConfiguration("DURP")))
+
+				for _, value := range []string{"", "fail", "FAIL", "warn", "WARN", "off", "OFF"} {
+					suiteConf.FocusExitCode = value
+					errors = types.VetConfig(flagSet, suiteConf, repConf)
+					Ω(errors).Should(BeEmpty())
+				}
+			})
+
+			It("normalizes the behavior, defaulting to fail", func() {
+				Ω(types.SuiteConfig{}.ProgrammaticFocusBehavior()).Should(Equal(types.FocusExitCodeFail))
+				Ω(types.SuiteConfig{FocusExitCode: "WARN"}.ProgrammaticFocusBehavior()).Should(Equal(types.FocusExitCodeWarn))
+				Ω(types.SuiteConfig{FocusExitCode: "off"}.ProgrammaticFocusBehavior()).Should(Equal(types.FocusExitCodeOff))
+			})
+		})
+
+		Describe("validating --validate-filters", func() {
+			It("errors if an invalid value is specified", func() {
+				suiteConf.ValidateFilters = "DURP"
+				errors := types.VetConfig(flagSet, suiteConf, repConf)
+				Ω(errors).Should(ConsistOf(types.GinkgoErrors.InvalidValidateFiltersConfiguration("DURP")))
+
+				for _, value := range []string{"", "off", "warn", "WARN", "fail", "Fail"} {
+					suiteConf.ValidateFilters = value
+					errors = types.VetConfig(flagSet, suiteConf, repConf)
+					Ω(errors).Should(BeEmpty())
+				}
+			})
+
+			It("normalizes the value", func() {
+				Ω(suiteConf.FilterValidationBehavior()).Should(Equal(types.ValidateFiltersOff))
+				suiteConf.ValidateFilters = "WARN"
+				Ω(suiteConf.FilterValidationBehavior()).Should(Equal(types.ValidateFiltersWarn))
+				suiteConf.ValidateFilters = "fail"
+				Ω(suiteConf.FilterValidationBehavior()).Should(Equal(types.ValidateFiltersFail))
+			})
+		})
+
+		Describe("validating --zero-assertion-policy", func() {
+			It("errors if an invalid policy is specified", func() {
+				suiteConf.ZeroAssertionPolicy = "DURP"
+				errors := types.VetConfig(flagSet, suiteConf, repConf)
+				Ω(errors).Should(ConsistOf(types.GinkgoErrors.InvalidZeroAssertionPolicyConfiguration("DURP")))
+
+				for _, value := range []string{"", "off", "warn", "WARN", "fail", "Fail"} {
+					suiteConf.ZeroAssertionPolicy = value
+					errors = types.VetConfig(flagSet, suiteConf, repConf)
+					Ω(errors).Should(BeEmpty())
+				}
+			})
+
+			It("normalizes the policy", func() {
+				Ω(suiteConf.ZeroAssertionBehavior()).Should(Equal(types.ZeroAssertionPolicyOff))
+				suiteConf.ZeroAssertionPolicy = "WARN"
+				Ω(suiteConf.ZeroAssertionBehavior()).Should(Equal(types.ZeroAssertionPolicyWarn))
+				suiteConf.ZeroAssertionPolicy = "fail"
+				Ω(suiteConf.ZeroAssertionBehavior()).Should(Equal(types.ZeroAssertionPolicyFail))
+			})
+		})
+
+		Describe("validating --after-suite-failure-policy", func() {
+			It("errors if an invalid policy is specified", func() {
+				suiteConf.AfterSuiteFailurePolicy = "DURP"
+				errors := types.VetConfig(flagSet, suiteConf, repConf)
+				Ω(errors).Should(ConsistOf(types.GinkgoErrors.InvalidAfterSuiteFailurePolicyConfiguration("DURP")))
+
+				for _, value := range []string{"", "fail", "FAIL", "warn", "WARN", "retry", "Retry"} {
+					suiteConf.AfterSuiteFailurePolicy = value
+					errors = types.VetConfig(flagSet, suiteConf, repConf)
+					Ω(errors).Should(BeEmpty())
+				}
+			})
+
+			It("normalizes the behavior, defaulting to fail", func() {
+				Ω(types.SuiteConfig{}.AfterSuiteFailureBehavior()).Should(Equal(types.AfterSuiteFailurePolicyFail))
+				Ω(types.SuiteConfig{AfterSuiteFailurePolicy: "WARN"}.AfterSuiteFailureBehavior()).Should(Equal(types.AfterSuiteFailurePolicyWarn))
+				Ω(types.SuiteConfig{AfterSuiteFailurePolicy: "retry"}.AfterSuiteFailureBehavior()).Should(Equal(types.AfterSuiteFailurePolicyRetry))
+			})
+		})
+
 		Describe("validating --output-interceptor-mode", func() {
 			It("errors if an invalid output interceptor mode is specified", func() {
 				suiteConf.OutputInterceptorMode = "DURP"
@@ -224,6 +313,265 @@ var _ = Describe("Config", func() {
 			})
 		})
 
+		Describe("validating the time box", func() {
+			It("errors if a negative --time-box is specified", func() {
+				suiteConf.TimeBox = -time.Minute
+				errors := types.VetConfig(flagSet, suiteConf, repConf)
+				Ω(errors).Should(ConsistOf(types.GinkgoErrors.InvalidTimeBox()))
+			})
+
+			It("does not error for a positive --time-box", func() {
+				suiteConf.TimeBox = 10 * time.Minute
+				errors := types.VetConfig(flagSet, suiteConf, repConf)
+				Ω(errors).Should(BeEmpty())
+			})
+		})
+
+		Describe("validating the seed strategy", func() {
+			It("errors if --seed-strategy is not a known strategy", func() {
+				suiteConf.SeedStrategy = "dice"
+				errors := types.VetConfig(flagSet, suiteConf, repConf)
+				Ω(errors).Should(ConsistOf(types.GinkgoErrors.InvalidSeedStrategy("dice")))
+			})
+
+			It("does not error for the known strategies", func() {
+				for _, strategy := range []string{"", "time", "git-sha", "CI-Build", "branch"} {
+					suiteConf.SeedStrategy = strategy
+					Ω(types.VetConfig(flagSet, suiteConf, repConf)).Should(BeEmpty())
+				}
+			})
+		})
+
+		Describe("validating the table sample", func() {
+			It("errors if --table-sample is not between 0 and 1", func() {
+				for _, sample := range []float64{-0.1, 1.5} {
+					suiteConf.TableSample = sample
+					errors := types.VetConfig(flagSet, suiteConf, repConf)
+					Ω(errors).Should(ConsistOf(types.GinkgoErrors.InvalidTableSample(sample)))
+				}
+			})
+
+			It("does not error for a fraction between 0 and 1", func() {
+				for _, sample := range []float64{0, 0.1, 1} {
+					suiteConf.TableSample = sample
+					Ω(types.VetConfig(flagSet, suiteConf, repConf)).Should(BeEmpty())
+				}
+			})
+
+			It("defaults the sampling seed to the random seed", func() {
+				suiteConf.RandomSeed = 17
+				Ω(suiteConf.TableSamplingSeed()).Should(Equal(int64(17)))
+				suiteConf.TableSampleSeed = 42
+				Ω(suiteConf.TableSamplingSeed()).Should(Equal(int64(42)))
+			})
+		})
+
+		Describe("validating spec rate limits", func() {
+			It("errors if an invalid --max-specs-per-minute is specified", func() {
+				suiteConf.MaxSpecsPerMinute = -1
+				errors := types.VetConfig(flagSet, suiteConf, repConf)
+				Ω(errors).Should(ConsistOf(types.GinkgoErrors.InvalidMaxSpecsPerMinuteConfiguration("-1")))
+			})
+
+			It("errors if an invalid --max-specs-per-minute-by-label is specified", func() {
+				for _, value := range []string{"db", ":3", "db:", "db:0", "db:three"} {
+					suiteConf.MaxSpecsPerMinuteByLabel = []string{"cache:10", value}
+					errors := types.VetConfig(flagSet, suiteConf, repConf)
+					Ω(errors).Should(ConsistOf(types.GinkgoErrors.InvalidMaxSpecsPerMinuteByLabelConfiguration(value)))
+				}
+			})
+
+			It("parses the limits, keying the global limit by the empty string", func() {
+				suiteConf.MaxSpecsPerMinute = 120
+				suiteConf.MaxSpecsPerMinuteByLabel = []string{"DB:30", "ns:slow: 2"}
+				Ω(types.VetConfig(flagSet, suiteConf, repConf)).Should(BeEmpty())
+				Ω(suiteConf.SpecRateLimits()).Should(Equal(map[string]int{"": 120, "db": 30, "ns:slow": 2}))
+			})
+		})
+
+		Describe("validating --pin-label-to-process", func() {
+			BeforeEach(func() {
+				suiteConf.ParallelTotal = 3
+				suiteConf.ParallelHost = "http://127.0.0.1:8888"
+			})
+
+			It("errors if an invalid pin is specified", func() {
+				for _, value := range []string{"ui", ":1", "ui:", "ui:0", "ui:4", "ui:one"} {
+					suiteConf.PinLabelToProcess = []string{"device:2", value}
+					errors := types.VetConfig(flagSet, suiteConf, repConf)
+					Ω(errors).Should(ConsistOf(types.GinkgoErrors.InvalidPinLabelToProcessConfiguration(value, 3)))
+				}
+			})
+
+			It("errors if a label is pinned to more than one process", func() {
+				suiteConf.PinLabelToProcess = []string{"ui:1", "UI:2"}
+				errors := types.VetConfig(flagSet, suiteConf, repConf)
+				Ω(errors).Should(ConsistOf(types.GinkgoErrors.InvalidPinLabelToProcessConfiguration("UI:2", 3)))
+			})
+
+			It("parses the pins, keyed by lowercased label", func() {
+				suiteConf.PinLabelToProcess = []string{"UI:1", "usb-device: 3", "ui:1"}
+				Ω(types.VetConfig(flagSet, suiteConf, repConf)).Should(BeEmpty())
+				Ω(suiteConf.ProcessPins()).Should(Equal(map[string]int{"ui": 1, "usb-device": 3}))
+			})
+
+			It("allows any process number when running in series", func() {
+				suiteConf.ParallelTotal, suiteConf.ParallelHost = 1, ""
+				suiteConf.PinLabelToProcess = []string{"ui:4"}
+				Ω(types.VetConfig(flagSet, suiteConf, repConf)).Should(BeEmpty())
+			})
+		})
+
+		Describe("validating --retry-on", func() {
+			It("errors if an invalid policy is specified", func() {
+				for _, value := range []string{"flaky", ":timeout", "network:", "timeout,,error", "!", "5f0c2e9a"} {
+					suiteConf.RetryOn = []string{"timeout", value}
+					errors := types.VetConfig(flagSet, suiteConf, repConf)
+					Ω(errors).Should(ConsistOf(types.GinkgoErrors.InvalidRetryOnConfiguration(value)))
+				}
+			})
+
+			It("parses the policies, keying the global policy by the empty string", func() {
+				suiteConf.RetryOn = []string{"!Assertion", "Network:infrastructure, 5f0c2e9a1d3b7c44", "ns:slow:timeout", "network:!panic"}
+				Ω(types.VetConfig(flagSet, suiteConf, repConf)).Should(BeEmpty())
+				Ω(suiteConf.RetryPolicies()).Should(Equal(map[string]types.FailureRetryPolicy{
+					"":        {NeverRetryOn: []string{"assertion"}},
+					"network": {RetryOn: []string{"infrastructure", "5f0c2e9a1d3b7c44"}, NeverRetryOn: []string{"panic"}},
+					"ns:slow": {RetryOn: []string{"timeout"}},
+				}))
+			})
+		})
+
+		Describe("validating resource capacity", func() {
+			It("errors if a negative capacity is specified", func() {
+				suiteConf.CapacityCPU = -1
+				errors := types.VetConfig(flagSet, suiteConf, repConf)
+				Ω(errors).Should(ConsistOf(types.GinkgoErrors.InvalidCapacityConfiguration("capacity-cpu", "-1")))
+
+				suiteConf.CapacityCPU = 0
+				suiteConf.CapacityGPU = -2
+				errors = types.VetConfig(flagSet, suiteConf, repConf)
+				Ω(errors).Should(ConsistOf(types.GinkgoErrors.InvalidCapacityConfiguration("capacity-gpu", "-2")))
+			})
+
+			It("errors if an invalid memory capacity is specified", func() {
+				suiteConf.CapacityMemory = "lots"
+				errors := types.VetConfig(flagSet, suiteConf, repConf)
+				Ω(errors).Should(ConsistOf(types.GinkgoErrors.InvalidCapacityConfiguration("capacity-memory", "lots")))
+			})
+
+			It("defaults the cpu capacity to the number of CPUs and leaves the rest unconstrained", func() {
+				Ω(suiteConf.HostCapacity()).Should(Equal(types.ResourceRequirements{CPU: runtime.NumCPU()}))
+
+				suiteConf.CapacityCPU, suiteConf.CapacityMemory, suiteConf.CapacityGPU = 16, "64Gi", 2
+				Ω(types.VetConfig(flagSet, suiteConf, repConf)).Should(BeEmpty())
+				Ω(suiteConf.HostCapacity()).Should(Equal(types.ResourceRequirements{CPU: 16, MemoryBytes: 64 << 30, GPU: 2}))
+			})
+		})
+
+		Describe("validating stack trace configuration", func() {
+			It("errors if an invalid --trace-on state is specified", func() {
+				repConf.FullTraceOn = []string{"failed", "panicked", "interrupted", "aborted", "timedout"}
+				errors := types.VetConfig(flagSet, suiteConf, repConf)
+				Ω(errors).Should(BeEmpty())
+
+				repConf.FullTraceOn = []string{"failed", "passed"}
+				errors = types.VetConfig(flagSet, suiteConf, repConf)
+				Ω(errors).Should(ConsistOf(types.GinkgoErrors.InvalidFullTraceOnConfiguration("passed")))
+			})
+
+			It("errors if an invalid --stack-trace-prune regular expression is specified", func() {
+				repConf.StackTracePrune = []string{`/vendor/`, `(`}
+				errors := types.VetConfig(flagSet, suiteConf, repConf)
+				Ω(errors).Should(HaveLen(1))
+				Ω(errors[0].Error()).Should(ContainSubstring("Invalid value '(' for --stack-trace-prune."))
+			})
+		})
+
+		Describe("validating --ide-protocol", func() {
+			It("accepts stdout, tcp, and unix destinations", func() {
+				for _, destination := range []string{"stdout", "tcp://127.0.0.1:9999", "unix:///tmp/ginkgo.sock"} {
+					repConf.IDEProtocol = destination
+					Ω(types.VetConfig(flagSet, suiteConf, repConf)).Should(BeEmpty())
+				}
+
+				repConf.IDEProtocol = "unix:///tmp/ginkgo.sock"
+				network, address, err := repConf.IDEProtocolDestination()
+				Ω(err).ShouldNot(HaveOccurred())
+				Ω(network).Should(Equal("unix"))
+				Ω(address).Should(Equal("/tmp/ginkgo.sock"))
+			})
+
+			It("errors if the destination is invalid", func() {
+				for _, destination := range []string{"stderr", "tcp://", "http://127.0.0.1:9999"} {
+					repConf.IDEProtocol = destination
+					Ω(types.VetConfig(flagSet, suiteConf, repConf)).Should(ConsistOf(types.GinkgoErrors.InvalidIDEProtocolConfiguration(destination)))
+				}
+			})
+		})
+
+		Describe("validating --output-sink", func() {
+			It("parses each sink's destination and options", func() {
+				repConf.Succinct = true
+				repConf.OutputSinks = []string{"stderr", "file://ginkgo.log,very-verbose", "tcp://127.0.0.1:9999,v,color", "unix:///tmp/ginkgo.sock,quiet"}
+				Ω(types.VetConfig(flagSet, suiteConf, repConf)).Should(BeEmpty())
+
+				sinks, err := repConf.OutputSinkDestinations()
+				Ω(err).ShouldNot(HaveOccurred())
+				Ω(sinks).Should(HaveLen(4))
+
+				Ω(sinks[0].Network).Should(Equal("stderr"))
+				Ω(sinks[0].Config.Verbosity()).Should(Equal(types.VerbosityLevelSuccinct))
+				Ω(sinks[0].Config.NoColor).Should(BeFalse())
+				Ω(sinks[0].Config.OutputSinks).Should(BeEmpty())
+
+				Ω(sinks[1].Network).Should(Equal("file"))
+				Ω(sinks[1].Address).Should(Equal("ginkgo.log"))
+				Ω(sinks[1].Config.Verbosity()).Should(Equal(types.VerbosityLevelVeryVerbose))
+				Ω(sinks[1].Config.NoColor).Should(BeTrue())
+
+				Ω(sinks[2].Network).Should(Equal("tcp"))
+				Ω(sinks[2].Address).Should(Equal("127.0.0.1:9999"))
+				Ω(sinks[2].Config.Verbosity()).Should(Equal(types.VerbosityLevelVerbose))
+				Ω(sinks[2].Config.NoColor).Should(BeFalse())
+
+				Ω(sinks[3].Network).Should(Equal("unix"))
+				Ω(sinks[3].Config.Verbosity()).Should(Equal(types.VerbosityLevelQuiet))
+			})
+
+			It("errors if the destination or an option is invalid", func() {
+				for _, sink := range []string{"file://", "http://127.0.0.1:9999", "file://ginkgo.log,loud"} {
+					repConf.OutputSinks = []string{sink}
+					Ω(types.VetConfig(flagSet, suiteConf, repConf)).Should(ConsistOf(types.GinkgoErrors.InvalidOutputSinkConfiguration(sink)))
+				}
+			})
+		})
+
+		Describe("validating report entry filters", func() {
+			It("accepts valid visibilities and regular expressions", func() {
+				repConf.ConsoleReportEntryVisibilities = []string{"always", "failure-or-verbose"}
+				repConf.JSONReportEntryVisibilities = []string{"never"}
+				repConf.JUnitReportEntrySkip = []string{"^metrics-"}
+				Ω(types.VetConfig(flagSet, suiteConf, repConf)).Should(BeEmpty())
+
+				filter, err := repConf.ConsoleReportEntryFilter()
+				Ω(err).ShouldNot(HaveOccurred())
+				Ω(filter.Visibilities).Should(Equal([]types.ReportEntryVisibility{types.ReportEntryVisibilityAlways, types.ReportEntryVisibilityFailureOrVerbose}))
+			})
+
+			It("errors if an invalid visibility is specified", func() {
+				repConf.JSONReportEntryVisibilities = []string{"sometimes"}
+				Ω(types.VetConfig(flagSet, suiteConf, repConf)).Should(ConsistOf(types.GinkgoErrors.InvalidReportEntryVisibilityConfiguration("json", "sometimes")))
+			})
+
+			It("errors if an invalid regular expression is specified", func() {
+				repConf.ConsoleReportEntrySkip = []string{"("}
+				errors := types.VetConfig(flagSet, suiteConf, repConf)
+				Ω(errors).Should(HaveLen(1))
+				Ω(errors[0].Error()).Should(ContainSubstring("for --console-report-entry-skip"))
+			})
+		})
+
 		Context("when more than one verbosity flag is set", func() {
 			It("errors", func() {
 				repConf.Succinct, repConf.Verbose, repConf.VeryVerbose = true, true, false
@@ -244,4 +592,54 @@ var _ = Describe("Config", func() {
 			})
 		})
 	})
+
+	Describe("CLIConfig", func() {
+		Describe("--procs", func() {
+			var parse = func(args ...string) (types.CLIConfig, error) {
+				cliConf := types.NewDefaultCLIConfig()
+				flagSet, err := types.NewGinkgoFlagSet(types.GinkgoCLIRunAndWatchFlags.SubsetWithNames("procs", "nodes"), map[string]interface{}{"C": &cliConf}, types.FlagSections)
+				Ω(err).ShouldNot(HaveOccurred())
+				_, err = flagSet.Parse(args)
+				return cliConf, err
+			}
+
+			It("accepts a number of processes", func() {
+				cliConf, err := parse("--procs=3")
+				Ω(err).ShouldNot(HaveOccurred())
+				Ω(cliConf.ComputedProcs()).Should(Equal(3))
+				Ω(cliConf.AutoTunedProcs()).Should(BeFalse())
+			})
+
+			It("accepts auto, and launches a process per CPU", func() {
+				cliConf, err := parse("--procs=auto")
+				Ω(err).ShouldNot(HaveOccurred())
+				Ω(cliConf.Procs).Should(Equal(types.AutoProcs))
+				Ω(cliConf.AutoTunedProcs()).Should(BeTrue())
+				Ω(cliConf.ComputedProcs()).Should(Equal(runtime.NumCPU()))
+			})
+
+			It("rejects anything else", func() {
+				_, err := parse("--procs=many")
+				Ω(err).Should(HaveOccurred())
+				_, err = parse("--procs=-2")
+				Ω(err).Should(HaveOccurred())
+			})
+		})
+	})
+
+	Describe("VetAndInitializeCLIAndGoConfig", func() {
+		Context("when --no-cache is set", func() {
+			It("forces a fresh build", func() {
+				cliConf := types.NewDefaultCLIConfig()
+				cliConf.NoCache = true
+				_, goFlagsConf, errors := types.VetAndInitializeCLIAndGoConfig(cliConf, types.NewDefaultGoFlagsConfig())
+				Ω(errors).Should(BeEmpty())
+				Ω(goFlagsConf.A).Should(BeTrue())
+
+				args, err := types.GenerateGoTestCompileArgs(goFlagsConf, "suite.test", "./")
+				Ω(err).ShouldNot(HaveOccurred())
+				Ω(args).Should(ContainElement("--a"))
+			})
+		})
+	})
 })
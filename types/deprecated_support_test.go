@@ -75,6 +75,14 @@ var _ = Describe("Deprecation Support", func() {
 				}, "\n")))
 			})
 
+			It("returns the tracked deprecations, with links to the migration docs, in a stable order", func() {
+				Ω(tracker.TrackedDeprecations()).Should(Equal([]types.TrackedDeprecation{
+					{Message: "Deprecation 3", CodeLocations: []types.CodeLocation{{FileName: "baz.go", LineNumber: 72}}},
+					{Message: "Deprecation 1", DocLink: "https://onsi.github.io/ginkgo/MIGRATING_TO_V2#doclink-1", CodeLocations: []types.CodeLocation{{FileName: "foo.go", LineNumber: 17}, {FileName: "bar.go", LineNumber: 30}}},
+					{Message: "Deprecation 2", DocLink: "https://onsi.github.io/ginkgo/MIGRATING_TO_V2#doclink-2", CodeLocations: []types.CodeLocation{}},
+				}))
+			})
+
 			It("validates that all deprecations point to working documentation", func() {
 				v := reflect.ValueOf(types.Deprecations)
 				Ω(v.NumMethod()).Should(BeNumerically(">", 0))
@@ -89,6 +97,23 @@ var _ = Describe("Deprecation Support", func() {
 			})
 		})
 
+		Describe("merging tracked deprecations", func() {
+			It("de-duplicates deprecations and their code locations", func() {
+				a := []types.TrackedDeprecation{
+					{Message: "Deprecation 1", DocLink: "doclink-1", CodeLocations: []types.CodeLocation{{FileName: "foo.go", LineNumber: 17}}},
+				}
+				b := []types.TrackedDeprecation{
+					{Message: "Deprecation 1", DocLink: "doclink-1", CodeLocations: []types.CodeLocation{{FileName: "foo.go", LineNumber: 17}, {FileName: "bar.go", LineNumber: 30}}},
+					{Message: "Deprecation 2", CodeLocations: []types.CodeLocation{}},
+				}
+				Ω(types.MergeTrackedDeprecations(a, b)).Should(Equal([]types.TrackedDeprecation{
+					{Message: "Deprecation 1", DocLink: "doclink-1", CodeLocations: []types.CodeLocation{{FileName: "foo.go", LineNumber: 17}, {FileName: "bar.go", LineNumber: 30}}},
+					{Message: "Deprecation 2"},
+				}))
+				Ω(types.MergeTrackedDeprecations(nil, nil)).Should(BeNil())
+			})
+		})
+
 		Context("when ACK_GINKGO_DEPRECATIONS is set", func() {
 			var origEnv string
 			BeforeEach(func() {
@@ -78,7 +78,7 @@ func (s DeprecatedSpecSummary) HasFailureState() bool {
 }
 
 func (s DeprecatedSpecSummary) TimedOut() bool {
-	return false
+	return s.State == SpecStateTimedout
 }
 
 func (s DeprecatedSpecSummary) Panicked() bool {
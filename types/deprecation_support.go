@@ -2,6 +2,7 @@ package types
 
 import (
 	"os"
+	"sort"
 	"strconv"
 	"strings"
 	"unicode"
@@ -15,6 +16,57 @@ type Deprecation struct {
 	Version string
 }
 
+// DocURL returns the full URL of the migration documentation for this deprecation, or "" if it has no DocLink
+func (d Deprecation) DocURL() string {
+	if d.DocLink == "" {
+		return ""
+	}
+	return "https://onsi.github.io/ginkgo/MIGRATING_TO_V2#" + d.DocLink
+}
+
+// TrackedDeprecation captures a deprecation that was tracked during a test run along with the code locations that triggered it.
+// Ginkgo includes these in the suite's Report so that they appear in JSON reports.
+type TrackedDeprecation struct {
+	Message string
+	//DocLink is the full URL of the documentation describing how to migrate away from the deprecated functionality
+	DocLink       string `json:",omitempty"`
+	Version       string `json:",omitempty"`
+	CodeLocations []CodeLocation
+}
+
+// MergeTrackedDeprecations combines two sets of TrackedDeprecations, de-duplicating deprecations and their code locations
+func MergeTrackedDeprecations(a []TrackedDeprecation, b []TrackedDeprecation) []TrackedDeprecation {
+	out := []TrackedDeprecation{}
+	indices := map[string]int{}
+	for _, deprecation := range append(append([]TrackedDeprecation{}, a...), b...) {
+		key := deprecation.Message + "\x00" + deprecation.DocLink
+		idx, ok := indices[key]
+		if !ok {
+			indices[key] = len(out)
+			out = append(out, TrackedDeprecation{Message: deprecation.Message, DocLink: deprecation.DocLink, Version: deprecation.Version})
+			idx = len(out) - 1
+		}
+		for _, cl := range deprecation.CodeLocations {
+			if !containsCodeLocation(out[idx].CodeLocations, cl) {
+				out[idx].CodeLocations = append(out[idx].CodeLocations, cl)
+			}
+		}
+	}
+	if len(out) == 0 {
+		return nil
+	}
+	return out
+}
+
+func containsCodeLocation(cls []CodeLocation, cl CodeLocation) bool {
+	for _, c := range cls {
+		if c.FileName == cl.FileName && c.LineNumber == cl.LineNumber {
+			return true
+		}
+	}
+	return false
+}
+
 type deprecations struct{}
 
 var Deprecations = deprecations{}
@@ -113,13 +165,33 @@ func (d *DeprecationTracker) DidTrackDeprecations() bool {
 	return len(d.deprecations) > 0
 }
 
+// TrackedDeprecations returns the tracked deprecations in a stable order so that they can be included in machine-readable reports
+func (d *DeprecationTracker) TrackedDeprecations() []TrackedDeprecation {
+	out := []TrackedDeprecation{}
+	for deprecation, locations := range d.deprecations {
+		out = append(out, TrackedDeprecation{
+			Message:       deprecation.Message,
+			DocLink:       deprecation.DocURL(),
+			Version:       deprecation.Version,
+			CodeLocations: append([]CodeLocation{}, locations...),
+		})
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].DocLink == out[j].DocLink {
+			return out[i].Message < out[j].Message
+		}
+		return out[i].DocLink < out[j].DocLink
+	})
+	return out
+}
+
 func (d *DeprecationTracker) DeprecationsReport() string {
 	out := formatter.F("{{light-yellow}}You're using deprecated Ginkgo functionality:{{/}}\n")
 	out += formatter.F("{{light-yellow}}============================================={{/}}\n")
 	for deprecation, locations := range d.deprecations {
 		out += formatter.Fi(1, "{{yellow}}"+deprecation.Message+"{{/}}\n")
 		if deprecation.DocLink != "" {
-			out += formatter.Fi(1, "{{bold}}Learn more at:{{/}} {{cyan}}{{underline}}https://onsi.github.io/ginkgo/MIGRATING_TO_V2#%s{{/}}\n", deprecation.DocLink)
+			out += formatter.Fi(1, "{{bold}}Learn more at:{{/}} {{cyan}}{{underline}}%s{{/}}\n", deprecation.DocURL())
 		}
 		for _, location := range locations {
 			out += formatter.Fi(2, "{{gray}}%s{{/}}\n", location)
@@ -35,6 +35,17 @@ func (g GinkgoError) Error() string {
 	return out
 }
 
+// SpecStructureErrors collects every problem Ginkgo found while building the spec tree (e.g. invalid decorators on several nodes) so that they can all be reported at once
+type SpecStructureErrors []error
+
+func (errs SpecStructureErrors) Error() string {
+	out := formatter.F("{{bold}}{{red}}Ginkgo found %d problems with your spec structure{{/}}\n\n", len(errs))
+	for _, err := range errs {
+		out += err.Error() + "\n"
+	}
+	return out
+}
+
 type ginkgoErrors struct{}
 
 var GinkgoErrors = ginkgoErrors{}
@@ -82,12 +93,36 @@ to the Ginkgo spec tree in a leaf node {{bold}}after{{/}} the specs started runn
 To enable randomization and parallelization Ginkgo requires the spec tree
 to be fully constructed up front.  In practice, this means that you can
 only create nodes like {{bold}}[%s]{{/}} at the top-level or within the
-body of a {{bold}}Describe{{/}}, {{bold}}Context{{/}}, or {{bold}}When{{/}}.`, nodeType, nodeType),
+body of a {{bold}}Describe{{/}}, {{bold}}Context{{/}}, or {{bold}}When{{/}}.`, nodeType, nodeType) + offendingStackTrace(cl),
 		CodeLocation: cl,
 		DocLink:      "mental-model-how-ginkgo-traverses-the-spec-hierarchy",
 	}
 }
 
+func (g ginkgoErrors) PushingNodeFromAnotherGoroutine(nodeType NodeType, cl CodeLocation) error {
+	return GinkgoError{
+		Heading: "Ginkgo detected an issue with your spec structure",
+		Message: formatter.F(
+			`It looks like you are trying to add a {{bold}}[%s]{{/}} node
+to the Ginkgo spec tree from a goroutine.
+
+Ginkgo builds the spec tree by invoking the body of each container node in turn
+and adding the nodes it creates to that container.  Nodes created from another
+goroutine could end up in the wrong container - so you must create nodes like
+{{bold}}[%s]{{/}} synchronously at the top-level or within the body of a
+{{bold}}Describe{{/}}, {{bold}}Context{{/}}, or {{bold}}When{{/}}.`, nodeType, nodeType) + offendingStackTrace(cl),
+		CodeLocation: cl,
+		DocLink:      "mental-model-how-ginkgo-traverses-the-spec-hierarchy",
+	}
+}
+
+func offendingStackTrace(cl CodeLocation) string {
+	if cl.FullStackTrace == "" {
+		return ""
+	}
+	return formatter.F("\n\n{{bold}}Here's the stack of the offending call:{{/}}\n%s", cl.FullStackTrace)
+}
+
 func (g ginkgoErrors) CaughtPanicDuringABuildPhase(caughtPanic interface{}, cl CodeLocation) error {
 	return GinkgoError{
 		Heading: "Assertion or Panic detected during tree construction",
@@ -117,7 +152,7 @@ func (g ginkgoErrors) SuiteNodeInNestedContext(nodeType NodeType, cl CodeLocatio
 		Message: formatter.F(
 			`It looks like you are trying to add a {{bold}}[%s]{{/}} node within a container node.
 
-{{bold}}%s{{/}} can only be called at the top level.`, nodeType, nodeType),
+{{bold}}%s{{/}} can only be called at the top level.`, nodeType, nodeType) + offendingStackTrace(cl),
 		CodeLocation: cl,
 		DocLink:      docLink,
 	}
@@ -141,23 +176,51 @@ func (g ginkgoErrors) SuiteNodeDuringRunPhase(nodeType NodeType, cl CodeLocation
 }
 
 func (g ginkgoErrors) MultipleBeforeSuiteNodes(nodeType NodeType, cl CodeLocation, earlierNodeType NodeType, earlierCodeLocation CodeLocation) error {
-	return ginkgoErrorMultipleSuiteNodes("setup", nodeType, cl, earlierNodeType, earlierCodeLocation)
+	return ginkgoErrorMultipleSuiteNodes(NodeTypeSynchronizedBeforeSuite, NodeTypeBeforeSuite, nodeType, cl, earlierNodeType, earlierCodeLocation)
 }
 
 func (g ginkgoErrors) MultipleAfterSuiteNodes(nodeType NodeType, cl CodeLocation, earlierNodeType NodeType, earlierCodeLocation CodeLocation) error {
-	return ginkgoErrorMultipleSuiteNodes("teardown", nodeType, cl, earlierNodeType, earlierCodeLocation)
+	return ginkgoErrorMultipleSuiteNodes(NodeTypeSynchronizedAfterSuite, NodeTypeAfterSuite, nodeType, cl, earlierNodeType, earlierCodeLocation)
 }
 
-func ginkgoErrorMultipleSuiteNodes(setupOrTeardown string, nodeType NodeType, cl CodeLocation, earlierNodeType NodeType, earlierCodeLocation CodeLocation) error {
+func ginkgoErrorMultipleSuiteNodes(synchronizedNodeType NodeType, unsynchronizedNodeType NodeType, nodeType NodeType, cl CodeLocation, earlierNodeType NodeType, earlierCodeLocation CodeLocation) error {
 	return GinkgoError{
 		Heading: "Ginkgo detected an issue with your spec structure",
 		Message: formatter.F(
 			`It looks like you are trying to add a {{bold}}[%s]{{/}} node but
 you already have a {{bold}}[%s]{{/}} node defined at: {{gray}}%s{{/}}.
 
-Ginkgo only allows you to define one suite %s node.`, nodeType, earlierNodeType, earlierCodeLocation, setupOrTeardown),
+Ginkgo only allows you to define one {{bold}}%s{{/}} node.  You can define as many
+{{bold}}%s{{/}} nodes as you need.`, nodeType, earlierNodeType, earlierCodeLocation, synchronizedNodeType, unsynchronizedNodeType),
+		CodeLocation: cl,
+		DocLink:      "multiple-suite-setup-and-cleanup-nodes-the-order-decorator",
+	}
+}
+
+func (g ginkgoErrors) InvalidReadinessGate(cl CodeLocation) error {
+	return GinkgoError{
+		Heading:      "Invalid ReadinessGate",
+		Message:      "[ReadinessGate] must be passed a non-nil check function and a positive timeout.",
+		CodeLocation: cl,
+		DocLink:      "waiting-for-the-environment-readinessgate",
+	}
+}
+
+func (g ginkgoErrors) InvalidGlobalNodeType(cl CodeLocation, nodeType NodeType) error {
+	return GinkgoError{
+		Heading:      "Invalid Global Node",
+		Message:      formatter.F(`[%s] nodes cannot be registered globally.  {{bold}}RegisterGlobalNode{{/}} only accepts BeforeEach, JustBeforeEach, AfterEach, and JustAfterEach nodes.`, nodeType),
+		CodeLocation: cl,
+		DocLink:      "global-setup-and-cleanup-registerglobalnode",
+	}
+}
+
+func (g ginkgoErrors) InvalidAroundEach(cl CodeLocation) error {
+	return GinkgoError{
+		Heading:      "Invalid AroundEach",
+		Message:      "[AroundEach] must be passed a non-nil {{bold}}func(runSpec func()){{/}}.",
 		CodeLocation: cl,
-		DocLink:      "suite-setup-and-cleanup-beforesuite-and-aftersuite",
+		DocLink:      "wrapping-specs-aroundeach",
 	}
 }
 
@@ -171,6 +234,15 @@ func (g ginkgoErrors) InvalidDecoratorForNodeType(cl CodeLocation, nodeType Node
 	}
 }
 
+func (g ginkgoErrors) InvalidSkipIfCondition(cl CodeLocation) error {
+	return GinkgoError{
+		Heading:      "Invalid SkipIf",
+		Message:      "[SkipIf] must be passed a non-nil {{bold}}func() bool{{/}} condition.",
+		CodeLocation: cl,
+		DocLink:      "skipping-specs-conditionally-skipif",
+	}
+}
+
 func (g ginkgoErrors) InvalidDeclarationOfFocusedAndPending(cl CodeLocation, nodeType NodeType) error {
 	return GinkgoError{
 		Heading:      "Invalid Combination of Decorators: Focused and Pending",
@@ -190,9 +262,13 @@ func (g ginkgoErrors) UnknownDecorator(cl CodeLocation, nodeType NodeType, decor
 }
 
 func (g ginkgoErrors) InvalidBodyType(t reflect.Type, cl CodeLocation, nodeType NodeType) error {
+	mustBe := "{{bold}}func(){{/}} - i.e. functions that take nothing and return nothing"
+	if nodeType.Is(NodeTypesThatAcceptContexts) {
+		mustBe = "{{bold}}func(){{/}}, {{bold}}func(SpecContext){{/}}, or {{bold}}func(context.Context){{/}} - i.e. functions that take nothing or a context and return nothing"
+	}
 	return GinkgoError{
 		Heading: "Invalid Function",
-		Message: formatter.F(`[%s] node must be passed {{bold}}func(){{/}} - i.e. functions that take nothing and return nothing.
+		Message: formatter.F(`[%s] node must be passed `+mustBe+`.
 You passed {{bold}}%s{{/}} instead.`, nodeType, t),
 		CodeLocation: cl,
 		DocLink:      "node-decorators-overview",
@@ -236,6 +312,59 @@ func (g ginkgoErrors) SetupNodeNotInOrderedContainer(cl CodeLocation, nodeType N
 	}
 }
 
+/* Execution Phase errors */
+func (g ginkgoErrors) InvalidResourceRequirement(cl CodeLocation, nodeType NodeType, message string) error {
+	return GinkgoError{
+		Heading:      "Invalid Resource Requirement",
+		Message:      fmt.Sprintf("[%s] node was decorated with an invalid resource requirement: %s.  Requires accepts CPU(n), Memory(\"quantity\"), and GPU(n) with positive values, and named requirements like \"docker\" or \"postgres>=14\".", nodeType, message),
+		CodeLocation: cl,
+		DocLink:      "declaring-resource-requirements",
+	}
+}
+
+func (g ginkgoErrors) UnknownExecutionPhase(cl CodeLocation, nodeType NodeType, phase string, phaseOrder []string) error {
+	return GinkgoError{
+		Heading:      "Unknown Execution Phase",
+		Message:      fmt.Sprintf("[%s] node was decorated with Phase(\"%s\") but this phase does not appear in the PhaseOrder passed to RunSpecs: %v.  Every phase must be declared in the suite's PhaseOrder so that Ginkgo knows when to run it.", nodeType, phase, phaseOrder),
+		CodeLocation: cl,
+		DocLink:      "execution-phases",
+	}
+}
+
+func (g ginkgoErrors) InvalidSpecNamingPattern(pattern string, err error) error {
+	return GinkgoError{
+		Heading: "Invalid SpecNamingRules",
+		Message: fmt.Sprintf("The pattern /%s/ in the SpecNamingRules passed to RunSpecs is not a valid regular expression:\n%v", pattern, err),
+		DocLink: "enforcing-spec-naming-conventions",
+	}
+}
+
+func (g ginkgoErrors) SpecNamingViolation(cl CodeLocation, nodeType NodeType, text string, violation string) error {
+	return GinkgoError{
+		Heading:      "Spec Naming Violation",
+		Message:      fmt.Sprintf("[%s] node has the text %q but, according to the SpecNamingRules passed to RunSpecs, its text %s.", nodeType, text, violation),
+		CodeLocation: cl,
+		DocLink:      "enforcing-spec-naming-conventions",
+	}
+}
+
+func (g ginkgoErrors) PhaseInOrderedContainer(cl CodeLocation, nodeType NodeType) error {
+	return GinkgoError{
+		Heading:      "Phase decorator in Ordered Container",
+		Message:      fmt.Sprintf("[%s] node was decorated with Phase but occurs in an Ordered container.  Specs in an Ordered container always run together - decorate the outer-most Ordered container with Phase instead.", nodeType),
+		CodeLocation: cl,
+		DocLink:      "execution-phases",
+	}
+}
+
+func (g ginkgoErrors) DuplicatePhaseInPhaseOrder(phase string) error {
+	return GinkgoError{
+		Heading: "Duplicate Phase in PhaseOrder",
+		Message: fmt.Sprintf("The PhaseOrder passed to RunSpecs lists phase \"%s\" more than once.  Each phase can only appear once.", phase),
+		DocLink: "execution-phases",
+	}
+}
+
 /* DeferCleanup errors */
 func (g ginkgoErrors) DeferCleanupInvalidFunction(cl CodeLocation) error {
 	return GinkgoError{
@@ -264,6 +393,33 @@ func (g ginkgoErrors) PushingCleanupInReportingNode(cl CodeLocation, nodeType No
 	}
 }
 
+func (g ginkgoErrors) PushingCleanupInAroundEachNode(cl CodeLocation) error {
+	return GinkgoError{
+		Heading:      "DeferCleanup cannot be called in AroundEach",
+		Message:      "Please use defer instead - the code in an AroundEach after runSpec returns already runs once the spec and its cleanup have completed.",
+		CodeLocation: cl,
+		DocLink:      "wrapping-specs-aroundeach",
+	}
+}
+
+func (g ginkgoErrors) SkipSuiteOutsideOfBeforeSuite(cl CodeLocation, nodeType NodeType) error {
+	return GinkgoError{
+		Heading:      "SkipSuite outside of BeforeSuite",
+		Message:      formatter.F(`SkipSuite was called in a {{bold}}[%s]{{/}} node.  SkipSuite can only be called in a BeforeSuite or in a SynchronizedBeforeSuite - use Skip to skip individual specs.`, nodeType),
+		CodeLocation: cl,
+		DocLink:      "skipping-the-entire-suite-skipsuite",
+	}
+}
+
+func (g ginkgoErrors) OnProcess1CleanupOutsideOfSuiteNode(cl CodeLocation, nodeType NodeType) error {
+	return GinkgoError{
+		Heading:      "DeferCleanup(OnProcess1) outside of a suite node",
+		Message:      formatter.F(`DeferCleanup was called with OnProcess1 in a {{bold}}[%s]{{/}} node.  OnProcess1 only applies to suite-level cleanup - call it from BeforeSuite, SynchronizedBeforeSuite, AfterSuite, or SynchronizedAfterSuite.`, nodeType),
+		CodeLocation: cl,
+		DocLink:      "process-1-only-cleanup-onprocess1",
+	}
+}
+
 func (g ginkgoErrors) PushingCleanupInCleanupNode(cl CodeLocation) error {
 	return GinkgoError{
 		Heading:      "DeferCleanup cannot be called in a DeferCleanup callback",
@@ -292,6 +448,33 @@ func (g ginkgoErrors) AddReportEntryNotDuringRunPhase(cl CodeLocation) error {
 	}
 }
 
+func (g ginkgoErrors) GinkgoWarnNotDuringRunPhase(cl CodeLocation) error {
+	return GinkgoError{
+		Heading:      "Ginkgo detected an issue with your spec structure",
+		Message:      formatter.F(`It looks like you are calling {{bold}}GinkgoWarn{{/}} outside of a running spec.  Make sure you call {{bold}}GinkgoWarn{{/}} inside a runnable node such as It or BeforeEach and not inside the body of a container such as Describe or Context.`),
+		CodeLocation: cl,
+		DocLink:      "emitting-warnings",
+	}
+}
+
+func (g ginkgoErrors) StepNotDuringRunPhase(cl CodeLocation) error {
+	return GinkgoError{
+		Heading:      "Ginkgo detected an issue with your spec structure",
+		Message:      formatter.F(`It looks like you are calling {{bold}}Step{{/}} outside of a running spec.  Make sure you call {{bold}}Step{{/}} inside a runnable node such as It or BeforeEach and not inside the body of a container such as Describe or Context.`),
+		CodeLocation: cl,
+		DocLink:      "structuring-long-specs-step",
+	}
+}
+
+func (g ginkgoErrors) AddSpecAnnotationNotDuringRunPhase(cl CodeLocation) error {
+	return GinkgoError{
+		Heading:      "Ginkgo detected an issue with your spec structure",
+		Message:      formatter.F(`It looks like you are calling {{bold}}AddSpecAnnotation{{/}} outside of a running spec.  Make sure you call {{bold}}AddSpecAnnotation{{/}} inside a runnable node such as It or BeforeEach and not inside the body of a container such as Describe or Context.  To annotate a container use the {{bold}}Annotate{{/}} decorator instead.`),
+		CodeLocation: cl,
+		DocLink:      "annotating-specs",
+	}
+}
+
 /* FileFilter and SkipFilter errors */
 func (g ginkgoErrors) InvalidFileFilter(filter string) error {
 	return GinkgoError{
@@ -351,6 +534,34 @@ func (g ginkgoErrors) InvalidEmptyLabel(cl CodeLocation) error {
 	}
 }
 
+/* Shared Examples errors */
+func (g ginkgoErrors) InvalidSharedExamplesBody(name string, cl CodeLocation) error {
+	return GinkgoError{
+		Heading:      "Invalid SharedExamples body",
+		Message:      fmt.Sprintf("SharedExamples \"%s\" must be given a function that returns nothing.  The function can take any parameters - ItBehavesLike passes its arguments into the function.", name),
+		CodeLocation: cl,
+		DocLink:      "shared-examples-itbehaveslike",
+	}
+}
+
+func (g ginkgoErrors) MultipleSharedExamplesWithName(name string, existing CodeLocation, cl CodeLocation) error {
+	return GinkgoError{
+		Heading:      "Duplicate SharedExamples",
+		Message:      fmt.Sprintf("SharedExamples \"%s\" has already been defined at:\n%s\n\nEach set of SharedExamples must have a unique name.", name, existing),
+		CodeLocation: cl,
+		DocLink:      "shared-examples-itbehaveslike",
+	}
+}
+
+func (g ginkgoErrors) UnknownSharedExamples(name string, cl CodeLocation) error {
+	return GinkgoError{
+		Heading:      "Unknown SharedExamples",
+		Message:      fmt.Sprintf("ItBehavesLike could not find any SharedExamples named \"%s\".  Make sure the SharedExamples are defined at the top level of a file in the suite's package (e.g. with var _ = SharedExamples(...)).", name),
+		CodeLocation: cl,
+		DocLink:      "shared-examples-itbehaveslike",
+	}
+}
+
 /* Table errors */
 func (g ginkgoErrors) MultipleEntryBodyFunctionsForTable(cl CodeLocation) error {
 	return GinkgoError{
@@ -370,6 +581,24 @@ func (g ginkgoErrors) InvalidEntryDescription(cl CodeLocation) error {
 	}
 }
 
+func (g ginkgoErrors) InvalidGoldenFilesTable(reason string, cl CodeLocation) error {
+	return GinkgoError{
+		Heading:      "Invalid use of GoldenFiles",
+		Message:      reason,
+		CodeLocation: cl,
+		DocLink:      "snapshot-testing-tables-with-goldenfiles",
+	}
+}
+
+func (g ginkgoErrors) MissingSubtreeEntryBody(cl CodeLocation) error {
+	return GinkgoError{
+		Heading:      "SubtreeEntry is missing its body",
+		Message:      "The last argument passed to SubtreeEntry must be a function that defines the entry's setup and specs.",
+		CodeLocation: cl,
+		DocLink:      "entries-with-their-own-specs-subtreeentry",
+	}
+}
+
 func (g ginkgoErrors) IncorrectParameterTypeForTable(i int, name string, cl CodeLocation) error {
 	return GinkgoError{
 		Heading:      "DescribeTable passed incorrect parameter type",
@@ -415,6 +644,24 @@ func (g ginkgoErrors) IncorrectVariadicParameterTypeToTableFunction(expected, ac
 	}
 }
 
+func (g ginkgoErrors) InvalidTableFixture(path string, err error, cl CodeLocation) error {
+	return GinkgoError{
+		Heading:      "Invalid table fixture",
+		Message:      fmt.Sprintf("Ginkgo could not load table entries from %s:\n%v", path, err),
+		CodeLocation: cl,
+		DocLink:      "loading-table-entries-from-fixture-files",
+	}
+}
+
+func (g ginkgoErrors) InvalidTableFixtureParameter(i int, expected reflect.Type, err error, cl CodeLocation) error {
+	return GinkgoError{
+		Heading:      "Invalid table fixture parameter",
+		Message:      fmt.Sprintf("Parameter #%d loaded from the fixture file could not be converted to <%s>:\n%v", i, expected, err),
+		CodeLocation: cl,
+		DocLink:      "loading-table-entries-from-fixture-files",
+	}
+}
+
 /* Parallel Synchronization errors */
 
 func (g ginkgoErrors) AggregatedReportUnavailableDueToNodeDisappearing() error {
@@ -438,6 +685,20 @@ func (g ginkgoErrors) SynchronizedBeforeSuiteDisappearedOnProc1() error {
 	}
 }
 
+func (g ginkgoErrors) PreflightChecksDisappearedOnProc1() error {
+	return GinkgoError{
+		Heading: "Process #1 disappeared before the preflight checks could report back",
+		Message: "Ginkgo parallel process #1 disappeared before the preflight checks completed.  This suite will now abort.",
+	}
+}
+
+func (g ginkgoErrors) ExecutionPhaseDisappearedOnProc1() error {
+	return GinkgoError{
+		Heading: "Process #1 disappeared before releasing the next execution phase",
+		Message: "Ginkgo parallel process #1 disappeared before all the specs in the current execution phase completed.  This suite will now abort.",
+	}
+}
+
 /* Configuration errors */
 
 func (g ginkgoErrors) UnknownTypePassedToRunSpecs(value interface{}) error {
@@ -481,6 +742,47 @@ func (g ginkgoErrors) UnreachableParallelHost(host string) error {
 	}
 }
 
+func (g ginkgoErrors) CLIProtocolMismatch(cliVersion string, cliProtocolVersion int) error {
+	return GinkgoError{
+		Heading: "Ginkgo CLI and library versions are incompatible",
+		Message: formatter.F(`This suite was compiled against Ginkgo {{bold}}%s{{/}} (protocol version %d) but is being run by the Ginkgo CLI {{bold}}%s{{/}} (protocol version %d).
+
+Install the CLI that matches the version of Ginkgo in your go.mod:
+  {{bold}}go install github.com/onsi/ginkgo/v2/ginkgo@v%s{{/}}
+or set {{bold}}%s=true{{/}} to try to run the suite anyway.`, VERSION, PROTOCOL_VERSION, cliVersion, cliProtocolVersion, VERSION, AllowVersionMismatchEnvVar),
+		DocLink: "ginkgo-cli-and-library-versions",
+	}
+}
+
+func (g ginkgoErrors) ParallelProtocolMismatch(serverProtocolVersion int) error {
+	serverDescription := fmt.Sprintf("protocol version %d", serverProtocolVersion)
+	if serverProtocolVersion == 0 {
+		serverDescription = "an older protocol that does not support version negotiation"
+	}
+	return GinkgoError{
+		Heading: "Parallel protocol mismatch",
+		Message: fmt.Sprintf(`This suite speaks parallel protocol version %d but the Ginkgo CLI orchestrating the parallel run speaks %s.
+
+Install the CLI that matches the version of Ginkgo in your go.mod:
+  go install github.com/onsi/ginkgo/v2/ginkgo@v%s
+or set %s=true to try to run the suite anyway.`, PROTOCOL_VERSION, serverDescription, VERSION, AllowVersionMismatchEnvVar),
+		DocLink: "ginkgo-cli-and-library-versions",
+	}
+}
+
+func (g ginkgoErrors) ParallelProcessDrift(process int, referenceProcess int, drift []string) error {
+	message := fmt.Sprintf("Parallel process #%d is not running the same suite as parallel process #%d:\n", process, referenceProcess)
+	for _, d := range drift {
+		message += fmt.Sprintf("  %s\n", d)
+	}
+	message += "\nAll parallel processes must be compiled from the same test binary and run with identical flags.  This usually means a stale binary or mismatched flags made it into a distributed run."
+	return GinkgoError{
+		Heading: "Parallel processes drifted",
+		Message: message,
+		DocLink: "spec-parallelization",
+	}
+}
+
 func (g ginkgoErrors) DryRunInParallelConfiguration() error {
 	return GinkgoError{
 		Heading: "Ginkgo only performs -dryRun in serial mode.",
@@ -491,7 +793,7 @@ func (g ginkgoErrors) DryRunInParallelConfiguration() error {
 func (g ginkgoErrors) ConflictingVerbosityConfiguration() error {
 	return GinkgoError{
 		Heading: "Conflicting reporter verbosity settings.",
-		Message: "You can't set more than one of -v, -vv and --succinct.  Please pick one!",
+		Message: "You can't set more than one of -v, -vv, --succinct and --quiet.  Please pick one!",
 	}
 }
 
@@ -502,6 +804,223 @@ func (g ginkgoErrors) InvalidOutputInterceptorModeConfiguration(value string) er
 	}
 }
 
+func (g ginkgoErrors) InvalidFocusExitCodeConfiguration(value string) error {
+	return GinkgoError{
+		Heading: fmt.Sprintf("Invalid value '%s' for --focus-exit-code.", value),
+		Message: "You must choose one of 'fail', 'warn', or 'off'.",
+		DocLink: "focused-specs",
+	}
+}
+
+func (g ginkgoErrors) InvalidValidateFiltersConfiguration(value string) error {
+	return GinkgoError{
+		Heading: fmt.Sprintf("Invalid value '%s' for --validate-filters.", value),
+		Message: "You must choose one of 'off', 'warn', or 'fail'.",
+		DocLink: "validating-filters",
+	}
+}
+
+func (g ginkgoErrors) InvalidZeroAssertionPolicyConfiguration(value string) error {
+	return GinkgoError{
+		Heading: fmt.Sprintf("Invalid value '%s' for --zero-assertion-policy.", value),
+		Message: "You must choose one of 'off', 'warn', or 'fail'.",
+		DocLink: "detecting-specs-without-assertions",
+	}
+}
+
+func (g ginkgoErrors) InvalidAfterSuiteFailurePolicyConfiguration(value string) error {
+	return GinkgoError{
+		Heading: fmt.Sprintf("Invalid value '%s' for --after-suite-failure-policy.", value),
+		Message: "You must choose one of 'fail', 'warn', or 'retry'.",
+		DocLink: "aftersuite-failure-policy",
+	}
+}
+
+func (g ginkgoErrors) InvalidHealthCheckInterval(name string, cl CodeLocation) error {
+	return GinkgoError{
+		Heading:      fmt.Sprintf("Invalid interval for health check \"%s\".", name),
+		Message:      "RegisterHealthCheck must be passed a positive interval.",
+		CodeLocation: cl,
+		DocLink:      "monitoring-the-health-of-external-dependencies",
+	}
+}
+
+func (g ginkgoErrors) InvalidTimeBox() error {
+	return GinkgoError{
+		Heading: "Invalid --time-box.",
+		Message: "--time-box must not be negative.  Pass 0 to run the suite without a time box.",
+	}
+}
+
+func (g ginkgoErrors) InvalidTableSample(sample float64) error {
+	return GinkgoError{
+		Heading: "Invalid --table-sample.",
+		Message: fmt.Sprintf("--table-sample must be between 0 and 1 but was %g.  Pass 0 to run every table entry.", sample),
+		DocLink: "sampling-table-entries",
+	}
+}
+
+func (g ginkgoErrors) InvalidSeedStrategy(strategy string) error {
+	return GinkgoError{
+		Heading: "Invalid --seed-strategy.",
+		Message: fmt.Sprintf("--seed-strategy must be one of time, git-sha, ci-build, or branch but was %q.", strategy),
+		DocLink: "seed-strategies",
+	}
+}
+
+func (g ginkgoErrors) SeedStrategyFailed(strategy string, reason string) error {
+	return GinkgoError{
+		Heading: "Could not compute the random seed.",
+		Message: fmt.Sprintf("Ginkgo could not compute a random seed with --seed-strategy=%s: %s.  Pass --seed explicitly or pick a different --seed-strategy.", strategy, reason),
+		DocLink: "seed-strategies",
+	}
+}
+
+func (g ginkgoErrors) InvalidChaosConfiguration() error {
+	return GinkgoError{
+		Heading: "Invalid chaos configuration.",
+		Message: "--chaos-max-delay must not be negative and --chaos-failure-rate must be between 0 and 1.",
+	}
+}
+
+func (g ginkgoErrors) InvalidMaxSpecsPerMinuteConfiguration(value string) error {
+	return GinkgoError{
+		Heading: fmt.Sprintf("Invalid value '%s' for --max-specs-per-minute.", value),
+		Message: "You must pass in a positive number of specs per minute, or 0 for no limit.",
+	}
+}
+
+func (g ginkgoErrors) InvalidCapacityConfiguration(flag string, value string) error {
+	return GinkgoError{
+		Heading: fmt.Sprintf("Invalid value '%s' for --%s.", value, flag),
+		Message: "You must pass in a positive capacity, or 0 to leave the resource unconstrained.  Memory capacities are quantities like 32Gi.",
+		DocLink: "declaring-resource-requirements",
+	}
+}
+
+func (g ginkgoErrors) InvalidMaxSpecsPerMinuteByLabelConfiguration(value string) error {
+	return GinkgoError{
+		Heading: fmt.Sprintf("Invalid value '%s' for --max-specs-per-minute-by-label.", value),
+		Message: "You must pass in a label and a positive number of specs per minute, separated by a colon.  e.g. 'database:30'.",
+	}
+}
+
+func (g ginkgoErrors) InvalidPinLabelToProcessConfiguration(value string, parallelTotal int) error {
+	return GinkgoError{
+		Heading: fmt.Sprintf("Invalid value '%s' for --pin-label-to-process.", value),
+		Message: fmt.Sprintf("You must pass in a label and a parallel process number between 1 and %d, separated by a colon.  e.g. 'ui:1'.  Each label can only be pinned to one process.", parallelTotal),
+		DocLink: "pinning-specs-to-a-parallel-process",
+	}
+}
+
+func (g ginkgoErrors) InvalidRetryOnConfiguration(value string) error {
+	return GinkgoError{
+		Heading: fmt.Sprintf("Invalid value '%s' for --retry-on.", value),
+		Message: "You must pass in a comma-separated list of failure categories (assertion, error, panic, timeout, or infrastructure) and failure fingerprints, each optionally prefixed with ! - optionally preceded by a label and a colon.  e.g. 'network:infrastructure,!assertion'.",
+		DocLink: "retrying-only-some-failures",
+	}
+}
+
+func (g ginkgoErrors) ParallelScheduleUnavailable(path string, reason string) error {
+	return GinkgoError{
+		Heading: "Could not replay parallel schedule",
+		Message: fmt.Sprintf("Ginkgo could not load a parallel schedule for this suite from %s:\n%s", path, reason),
+		DocLink: "replaying-a-parallel-schedule",
+	}
+}
+
+func (g ginkgoErrors) ParallelScheduleMismatch(path string, schedule ParallelSchedule, randomSeed int64, parallelTotal int, numGroups int) error {
+	return GinkgoError{
+		Heading: "Parallel schedule does not match this run",
+		Message: formatter.F(`The parallel schedule recorded in %s was recorded with {{bold}}--seed=%d --procs=%d{{/}} and contained %d groups of specs.  This run has {{bold}}--seed=%d --procs=%d{{/}} and %d groups of specs.
+
+Re-run with the recorded seed and number of processes, and make sure the same set of specs is selected.`,
+			path, schedule.RandomSeed, schedule.ParallelTotal, schedule.NumGroups, randomSeed, parallelTotal, numGroups),
+		DocLink: "replaying-a-parallel-schedule",
+	}
+}
+
+func (g ginkgoErrors) SingleSpecRequired(matchingSpecs []string) error {
+	if len(matchingSpecs) == 0 {
+		return GinkgoError{
+			Heading: "No specs matched",
+			Message: "--require-single-spec is set but no specs matched the filters.  Check the spec text or file:line you passed to ginkgo debug.",
+			DocLink: "debugging-a-single-spec",
+		}
+	}
+	message := fmt.Sprintf("--require-single-spec is set but %d specs matched the filters.  Narrow the filters so that they select exactly one of:\n", len(matchingSpecs))
+	for _, spec := range matchingSpecs {
+		message += fmt.Sprintf("  %s\n", spec)
+	}
+	return GinkgoError{
+		Heading: "More than one spec matched",
+		Message: message,
+		DocLink: "debugging-a-single-spec",
+	}
+}
+
+func (g ginkgoErrors) InvalidReportEntryVisibilityConfiguration(destination string, value string) error {
+	return GinkgoError{
+		Heading: fmt.Sprintf("Invalid value '%s' for --%s-report-entry-visibility.", value, destination),
+		Message: "You must choose one of 'always', 'failure-or-verbose', or 'never'.",
+		DocLink: "controlling-where-report-entries-appear",
+	}
+}
+
+func (g ginkgoErrors) InvalidReportEntrySkipConfiguration(destination string, value string, err error) error {
+	return GinkgoError{
+		Heading: fmt.Sprintf("Invalid value '%s' for --%s-report-entry-skip.", value, destination),
+		Message: fmt.Sprintf("You must pass in a valid regular expression:\n%v", err),
+		DocLink: "controlling-where-report-entries-appear",
+	}
+}
+
+func (g ginkgoErrors) InvalidIDEProtocolConfiguration(value string) error {
+	return GinkgoError{
+		Heading: fmt.Sprintf("Invalid value '%s' for --ide-protocol.", value),
+		Message: "You must pass in 'stdout', a TCP address (e.g. 'tcp://127.0.0.1:9999'), or a unix socket (e.g. 'unix:///tmp/ginkgo.sock').",
+		DocLink: "editor-and-ide-integration",
+	}
+}
+
+func (g ginkgoErrors) FailedToConnectToIDEProtocolDestination(value string, err error) error {
+	return GinkgoError{
+		Heading: fmt.Sprintf("Failed to connect to --ide-protocol destination %s", value),
+		Message: fmt.Sprintf("Ginkgo could not connect to the --ide-protocol destination:\n%v", err),
+		DocLink: "editor-and-ide-integration",
+	}
+}
+
+func (g ginkgoErrors) InvalidOutputSinkConfiguration(value string) error {
+	return GinkgoError{
+		Heading: fmt.Sprintf("Invalid value '%s' for --output-sink.", value),
+		Message: "You must pass in 'stdout', 'stderr', a file (e.g. 'file://ginkgo.log'), a TCP address (e.g. 'tcp://127.0.0.1:9999'), or a unix socket (e.g. 'unix:///tmp/ginkgo.sock') - optionally followed by a comma-separated list of options.  The valid options are 'quiet', 'succinct', 'normal', 'verbose', 'very-verbose', 'color', and 'no-color'.",
+		DocLink: "writing-output-to-multiple-sinks",
+	}
+}
+
+func (g ginkgoErrors) FailedToOpenOutputSink(value string, err error) error {
+	return GinkgoError{
+		Heading: fmt.Sprintf("Failed to open --output-sink %s", value),
+		Message: fmt.Sprintf("Ginkgo could not open the --output-sink destination:\n%v", err),
+		DocLink: "writing-output-to-multiple-sinks",
+	}
+}
+
+func (g ginkgoErrors) InvalidFullTraceOnConfiguration(value string) error {
+	return GinkgoError{
+		Heading: fmt.Sprintf("Invalid value '%s' for --trace-on.", value),
+		Message: "You must choose one of 'failed', 'panicked', 'interrupted', 'aborted', or 'timedout'.",
+	}
+}
+
+func (g ginkgoErrors) InvalidStackTracePruneConfiguration(value string, err error) error {
+	return GinkgoError{
+		Heading: fmt.Sprintf("Invalid value '%s' for --stack-trace-prune.", value),
+		Message: fmt.Sprintf("--stack-trace-prune must be a valid regular expression.  regexp.Compile error: %s", err),
+	}
+}
+
 func (g ginkgoErrors) InvalidGoFlagCount() error {
 	return GinkgoError{
 		Heading: "Use of go test -count",
@@ -199,6 +199,13 @@ func bindFlagSet(f GinkgoFlagSet, flagSet *flag.FlagSet) (GinkgoFlagSet, error)
 			if deprecatedName != "" {
 				f.flagSet.Var(stringSliceVar{value}, deprecatedName, deprecatedUsage)
 			}
+		case reflect.TypeOf(ProcCount(0)):
+			if name != "" {
+				f.flagSet.Var(addr.(*ProcCount), name, flag.Usage)
+			}
+			if deprecatedName != "" {
+				f.flagSet.Var(addr.(*ProcCount), deprecatedName, deprecatedUsage)
+			}
 		default:
 			return GinkgoFlagSet{}, fmt.Errorf("unsupported type %T", iface)
 		}
@@ -480,6 +487,10 @@ func GenerateFlagArgs(flags GinkgoFlags, bindings interface{}) ([]string, error)
 			for _, s := range strings {
 				result = append(result, fmt.Sprintf("--%s=%s", name, s))
 			}
+		case reflect.TypeOf(ProcCount(0)):
+			if iface.(ProcCount) != 0 {
+				result = append(result, fmt.Sprintf("--%s=%s", name, iface))
+			}
 		default:
 			return []string{}, fmt.Errorf("unsupported type %T", iface)
 		}
@@ -0,0 +1,43 @@
+package types
+
+import (
+	"strconv"
+	"strings"
+)
+
+// The environment variables the Ginkgo CLI uses to tell the suites it runs which version of Ginkgo it is
+const (
+	CLIVersionEnvVar         = "GINKGO_CLI_VERSION"
+	CLIProtocolVersionEnvVar = "GINKGO_CLI_PROTOCOL_VERSION"
+
+	// Set AllowVersionMismatchEnvVar to true to turn protocol mismatches into warnings
+	AllowVersionMismatchEnvVar = "GINKGO_ALLOW_VERSION_MISMATCH"
+)
+
+// CLIProtocolEnvironment returns the environment variables the Ginkgo CLI sets when it runs a suite
+func CLIProtocolEnvironment() []string {
+	return []string{
+		CLIVersionEnvVar + "=" + VERSION,
+		CLIProtocolVersionEnvVar + "=" + strconv.Itoa(PROTOCOL_VERSION),
+	}
+}
+
+// CheckCLIProtocolVersion is called by suites to verify that the Ginkgo CLI running them speaks the same protocol.
+// It returns nil if the suite is not being run by the Ginkgo CLI.
+func CheckCLIProtocolVersion(getenv func(string) string) error {
+	cliVersion := getenv(CLIVersionEnvVar)
+	if cliVersion == "" {
+		return nil
+	}
+	cliProtocolVersion, err := strconv.Atoi(getenv(CLIProtocolVersionEnvVar))
+	if err != nil || cliProtocolVersion != PROTOCOL_VERSION {
+		return GinkgoErrors.CLIProtocolMismatch(cliVersion, cliProtocolVersion)
+	}
+	return nil
+}
+
+// AllowVersionMismatch returns true if the user has opted in to running mismatched versions of the Ginkgo CLI and library
+func AllowVersionMismatch(getenv func(string) string) bool {
+	value := strings.ToLower(strings.TrimSpace(getenv(AllowVersionMismatchEnvVar)))
+	return value == "true" || value == "1"
+}
@@ -0,0 +1,56 @@
+package types_test
+
+import (
+	"fmt"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	"github.com/onsi/ginkgo/v2/types"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Protocol versions", func() {
+	var env map[string]string
+	getenv := func(key string) string { return env[key] }
+
+	BeforeEach(func() {
+		env = map[string]string{}
+	})
+
+	Describe("CheckCLIProtocolVersion", func() {
+		It("succeeds when the suite is not run by the Ginkgo CLI", func() {
+			Ω(types.CheckCLIProtocolVersion(getenv)).Should(Succeed())
+		})
+
+		It("succeeds when the CLI speaks the same protocol", func() {
+			for _, kv := range types.CLIProtocolEnvironment() {
+				pair := strings.SplitN(kv, "=", 2)
+				env[pair[0]] = pair[1]
+			}
+			Ω(types.CheckCLIProtocolVersion(getenv)).Should(Succeed())
+		})
+
+		It("errors when the CLI speaks a different protocol", func() {
+			env[types.CLIVersionEnvVar] = "2.99.0"
+			env[types.CLIProtocolVersionEnvVar] = fmt.Sprintf("%d", types.PROTOCOL_VERSION+1)
+			Ω(types.CheckCLIProtocolVersion(getenv)).Should(MatchError(types.GinkgoErrors.CLIProtocolMismatch("2.99.0", types.PROTOCOL_VERSION+1)))
+		})
+
+		It("treats a CLI that does not report a protocol version as version 0", func() {
+			env[types.CLIVersionEnvVar] = "2.99.0"
+			Ω(types.CheckCLIProtocolVersion(getenv)).Should(MatchError(types.GinkgoErrors.CLIProtocolMismatch("2.99.0", 0)))
+		})
+	})
+
+	DescribeTable("AllowVersionMismatch",
+		func(value string, expected bool) {
+			env[types.AllowVersionMismatchEnvVar] = value
+			Ω(types.AllowVersionMismatch(getenv)).Should(Equal(expected))
+		},
+		Entry(nil, "", false),
+		Entry(nil, "false", false),
+		Entry(nil, "true", true),
+		Entry(nil, "TRUE", true),
+		Entry(nil, "1", true),
+	)
+})
@@ -3,6 +3,7 @@ package types
 import (
 	"encoding/json"
 	"fmt"
+	"regexp"
 	"time"
 )
 
@@ -41,6 +42,30 @@ func (rev ReportEntryValue) String() string {
 	return fmt.Sprintf("%+v", rev.raw)
 }
 
+// Redacted returns a copy of the value with redact applied to its string and JSON representations.
+// If redact leaves both representations unchanged the value is returned as-is (so pointers continue to be rendered at reporting-time)
+func (rev ReportEntryValue) Redacted(redact func(string) string) ReportEntryValue {
+	representation := rev.String()
+	asJSON := rev.AsJSON
+	if rev.raw != nil {
+		if encoded, err := json.Marshal(rev.raw); err == nil {
+			asJSON = string(encoded)
+		}
+	}
+	redactedRepresentation, redactedAsJSON := redact(representation), redact(asJSON)
+	if redactedRepresentation == representation && redactedAsJSON == asJSON {
+		return rev
+	}
+	out := ReportEntryValue{
+		AsJSON:         redactedAsJSON,
+		Representation: redactedRepresentation,
+	}
+	if json.Unmarshal([]byte(redactedAsJSON), &(out.raw)) != nil {
+		out.raw = redactedRepresentation
+	}
+	return out
+}
+
 func (rev ReportEntryValue) MarshalJSON() ([]byte, error) {
 	//All this to capture the representation at encoding-time, not creating time
 	//This way users can Report on pointers and get their final values at reporting-time
@@ -144,6 +169,45 @@ func (re ReportEntries) WithVisibility(visibilities ...ReportEntryVisibility) Re
 	return out
 }
 
+// Filter returns the ReportEntries selected by the passed-in filter
+func (re ReportEntries) Filter(filter ReportEntryFilter) ReportEntries {
+	if filter.IsZero() {
+		return re
+	}
+	out := ReportEntries{}
+	for _, entry := range re {
+		if filter.Selects(entry) {
+			out = append(out, entry)
+		}
+	}
+	return out
+}
+
+// ReportEntryFilter selects the ReportEntries emitted to a particular destination (e.g. the console or a JSON report).
+// It is configured with the --console-report-entry-*, --json-report-entry-*, and --junit-report-entry-* flags.
+type ReportEntryFilter struct {
+	// Visibilities lists the visibilities that are selected.  All visibilities are selected if Visibilities is empty.
+	Visibilities []ReportEntryVisibility
+	// Skip lists regular expressions.  Entries whose name matches any of them are not selected.
+	Skip []*regexp.Regexp
+}
+
+func (filter ReportEntryFilter) IsZero() bool {
+	return len(filter.Visibilities) == 0 && len(filter.Skip) == 0
+}
+
+func (filter ReportEntryFilter) Selects(entry ReportEntry) bool {
+	if len(filter.Visibilities) > 0 && !entry.Visibility.Is(filter.Visibilities...) {
+		return false
+	}
+	for _, skip := range filter.Skip {
+		if skip.MatchString(entry.Name) {
+			return false
+		}
+	}
+	return true
+}
+
 // ReportEntryVisibility governs the visibility of ReportEntries in Ginkgo's console reporter
 type ReportEntryVisibility uint
 
@@ -162,6 +226,16 @@ var revEnumSupport = NewEnumSupport(map[uint]string{
 	uint(ReportEntryVisibilityNever):            "never",
 })
 
+// ParseReportEntryVisibility parses the string representation of a ReportEntryVisibility (e.g. "failure-or-verbose")
+func ParseReportEntryVisibility(s string) (ReportEntryVisibility, bool) {
+	for _, visibility := range []ReportEntryVisibility{ReportEntryVisibilityAlways, ReportEntryVisibilityFailureOrVerbose, ReportEntryVisibilityNever} {
+		if visibility.String() == s {
+			return visibility, true
+		}
+	}
+	return ReportEntryVisibilityAlways, false
+}
+
 func (rev ReportEntryVisibility) String() string {
 	return revEnumSupport.String(uint(rev))
 }
@@ -0,0 +1,111 @@
+package types
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+)
+
+// Resource describes an externally created resource (e.g. a cloud VM or a Kubernetes namespace) that a spec registers with RegisterResource
+type Resource struct {
+	// Kind and Name identify the resource - e.g. Kind: "namespace", Name: "test-namespace-17"
+	Kind string
+	Name string
+
+	// SweepCommand is the command `ginkgo sweep` runs to delete the resource if the run that created it never cleaned it up
+	SweepCommand []string
+}
+
+func (r Resource) String() string {
+	return r.Kind + "/" + r.Name
+}
+
+// The events recorded in a resource registry
+const (
+	ResourceEventRegistered = "registered"
+	ResourceEventReleased   = "released"
+)
+
+// ResourceRecord is a single entry in a resource registry
+type ResourceRecord struct {
+	Event    string
+	Resource Resource
+
+	SpecText        string
+	CodeLocation    CodeLocation
+	ParallelProcess int
+	Time            time.Time
+}
+
+/*
+AppendToResourceRegistry appends record to the resource registry at path, creating the registry if necessary.
+
+Registries are append-only journals with one JSON-encoded record per line.  Each record is written with a single append so that
+multiple parallel processes can safely share a registry.
+*/
+func AppendToResourceRegistry(path string, record ResourceRecord) error {
+	encoded, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open resource registry %s: %w", path, err)
+	}
+	defer f.Close()
+	_, err = f.Write(append(encoded, '\n'))
+	if err != nil {
+		return fmt.Errorf("failed to write to resource registry %s: %w", path, err)
+	}
+	return nil
+}
+
+// LoadOrphanedResources replays the resource registry at path and returns the records of the resources that were registered but never released, in the order they were registered
+func LoadOrphanedResources(path string) ([]ResourceRecord, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open resource registry %s: %w", path, err)
+	}
+	defer f.Close()
+
+	keys := []string{}
+	outstanding := map[string]ResourceRecord{}
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	line := 0
+	for scanner.Scan() {
+		line += 1
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var record ResourceRecord
+		err := json.Unmarshal(scanner.Bytes(), &record)
+		if err != nil {
+			return nil, fmt.Errorf("resource registry %s is corrupt at line %d: %w", path, line, err)
+		}
+		key := record.Resource.String()
+		switch record.Event {
+		case ResourceEventRegistered:
+			if _, ok := outstanding[key]; !ok {
+				keys = append(keys, key)
+			}
+			outstanding[key] = record
+		case ResourceEventReleased:
+			delete(outstanding, key)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read resource registry %s: %w", path, err)
+	}
+
+	orphans := []ResourceRecord{}
+	for _, key := range keys {
+		if record, ok := outstanding[key]; ok {
+			orphans = append(orphans, record)
+			delete(outstanding, key)
+		}
+	}
+	return orphans, nil
+}
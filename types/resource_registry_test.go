@@ -0,0 +1,49 @@
+package types_test
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	"github.com/onsi/ginkgo/v2/types"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ResourceRegistry", func() {
+	var path string
+	record := func(event string, kind string, name string) types.ResourceRecord {
+		return types.ResourceRecord{Event: event, Resource: types.Resource{Kind: kind, Name: name}}
+	}
+
+	BeforeEach(func() {
+		path = filepath.Join(GinkgoT().TempDir(), "registry.jsonl")
+	})
+
+	It("returns the resources that were registered but never released, in the order they were registered", func() {
+		Ω(types.AppendToResourceRegistry(path, record(types.ResourceEventRegistered, "vm", "a"))).Should(Succeed())
+		Ω(types.AppendToResourceRegistry(path, record(types.ResourceEventRegistered, "vm", "b"))).Should(Succeed())
+		Ω(types.AppendToResourceRegistry(path, record(types.ResourceEventRegistered, "namespace", "a"))).Should(Succeed())
+		Ω(types.AppendToResourceRegistry(path, record(types.ResourceEventReleased, "vm", "a"))).Should(Succeed())
+		Ω(types.AppendToResourceRegistry(path, record(types.ResourceEventReleased, "vm", "b"))).Should(Succeed())
+		Ω(types.AppendToResourceRegistry(path, record(types.ResourceEventRegistered, "vm", "b"))).Should(Succeed())
+
+		orphans, err := types.LoadOrphanedResources(path)
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(orphans).Should(Equal([]types.ResourceRecord{
+			record(types.ResourceEventRegistered, "vm", "b"),
+			record(types.ResourceEventRegistered, "namespace", "a"),
+		}))
+	})
+
+	It("errors if the registry does not exist", func() {
+		_, err := types.LoadOrphanedResources(path)
+		Ω(err).Should(MatchError(ContainSubstring("failed to open resource registry")))
+	})
+
+	It("errors if the registry is corrupt", func() {
+		Ω(types.AppendToResourceRegistry(path, record(types.ResourceEventRegistered, "vm", "a"))).Should(Succeed())
+		Ω(os.WriteFile(path, []byte("{\"Event\":\"registered\"}\n{"), 0644)).Should(Succeed())
+		_, err := types.LoadOrphanedResources(path)
+		Ω(err).Should(MatchError(ContainSubstring("is corrupt at line 2")))
+	})
+})
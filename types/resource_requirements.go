@@ -0,0 +1,130 @@
+package types
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+/*
+ResourceRequirements captures the host resources a spec needs while it runs.  Specs declare them with the Requires decorator.
+
+A zero value in any dimension means the spec places no demand on that resource.
+*/
+type ResourceRequirements struct {
+	CPU         int   `json:",omitempty"`
+	MemoryBytes int64 `json:",omitempty"`
+	GPU         int   `json:",omitempty"`
+}
+
+func (r ResourceRequirements) IsZero() bool {
+	return r.CPU == 0 && r.MemoryBytes == 0 && r.GPU == 0
+}
+
+func (r ResourceRequirements) String() string {
+	out := []string{}
+	if r.CPU > 0 {
+		out = append(out, fmt.Sprintf("cpu=%d", r.CPU))
+	}
+	if r.MemoryBytes > 0 {
+		out = append(out, fmt.Sprintf("memory=%s", FormatMemoryQuantity(r.MemoryBytes)))
+	}
+	if r.GPU > 0 {
+		out = append(out, fmt.Sprintf("gpu=%d", r.GPU))
+	}
+	return strings.Join(out, ", ")
+}
+
+// Max returns the larger of the two requirements in each dimension
+func (r ResourceRequirements) Max(other ResourceRequirements) ResourceRequirements {
+	if other.CPU > r.CPU {
+		r.CPU = other.CPU
+	}
+	if other.MemoryBytes > r.MemoryBytes {
+		r.MemoryBytes = other.MemoryBytes
+	}
+	if other.GPU > r.GPU {
+		r.GPU = other.GPU
+	}
+	return r
+}
+
+func (r ResourceRequirements) Add(other ResourceRequirements) ResourceRequirements {
+	return ResourceRequirements{
+		CPU:         r.CPU + other.CPU,
+		MemoryBytes: r.MemoryBytes + other.MemoryBytes,
+		GPU:         r.GPU + other.GPU,
+	}
+}
+
+func (r ResourceRequirements) Subtract(other ResourceRequirements) ResourceRequirements {
+	return ResourceRequirements{
+		CPU:         r.CPU - other.CPU,
+		MemoryBytes: r.MemoryBytes - other.MemoryBytes,
+		GPU:         r.GPU - other.GPU,
+	}
+}
+
+// FitsWithin returns true if the requirements do not exceed the passed-in capacity.  Dimensions with zero capacity are unconstrained.
+func (r ResourceRequirements) FitsWithin(capacity ResourceRequirements) bool {
+	if capacity.CPU > 0 && r.CPU > capacity.CPU {
+		return false
+	}
+	if capacity.MemoryBytes > 0 && r.MemoryBytes > capacity.MemoryBytes {
+		return false
+	}
+	if capacity.GPU > 0 && r.GPU > capacity.GPU {
+		return false
+	}
+	return true
+}
+
+// ClampedTo caps the requirements at the passed-in capacity so that a spec that requires more than the host has can still run - on its own
+func (r ResourceRequirements) ClampedTo(capacity ResourceRequirements) ResourceRequirements {
+	if capacity.CPU > 0 && r.CPU > capacity.CPU {
+		r.CPU = capacity.CPU
+	}
+	if capacity.MemoryBytes > 0 && r.MemoryBytes > capacity.MemoryBytes {
+		r.MemoryBytes = capacity.MemoryBytes
+	}
+	if capacity.GPU > 0 && r.GPU > capacity.GPU {
+		r.GPU = capacity.GPU
+	}
+	return r
+}
+
+var memoryQuantitySuffixes = []struct {
+	suffix     string
+	multiplier int64
+}{
+	{"Ki", 1 << 10}, {"Mi", 1 << 20}, {"Gi", 1 << 30}, {"Ti", 1 << 40},
+	{"K", 1000}, {"M", 1000 * 1000}, {"G", 1000 * 1000 * 1000}, {"T", 1000 * 1000 * 1000 * 1000},
+}
+
+// ParseMemoryQuantity parses memory quantities like "512Mi", "8Gi", "2G", or "1024" (bytes) into a number of bytes
+func ParseMemoryQuantity(quantity string) (int64, error) {
+	trimmed := strings.TrimSpace(quantity)
+	multiplier := int64(1)
+	for _, s := range memoryQuantitySuffixes {
+		if strings.HasSuffix(trimmed, s.suffix) {
+			trimmed, multiplier = strings.TrimSuffix(trimmed, s.suffix), s.multiplier
+			break
+		}
+	}
+	value, err := strconv.ParseInt(trimmed, 10, 64)
+	if err != nil || value <= 0 {
+		return 0, fmt.Errorf("invalid memory quantity '%s' - use a positive number of bytes, optionally with a Ki, Mi, Gi, Ti, K, M, G, or T suffix", quantity)
+	}
+	return value * multiplier, nil
+}
+
+// FormatMemoryQuantity renders a number of bytes using the largest binary suffix that represents it exactly
+func FormatMemoryQuantity(bytes int64) string {
+	binarySuffixes := memoryQuantitySuffixes[:4]
+	for i := len(binarySuffixes) - 1; i >= 0; i-- {
+		if bytes >= binarySuffixes[i].multiplier && bytes%binarySuffixes[i].multiplier == 0 {
+			return fmt.Sprintf("%d%s", bytes/binarySuffixes[i].multiplier, binarySuffixes[i].suffix)
+		}
+	}
+	return fmt.Sprintf("%d", bytes)
+}
@@ -0,0 +1,72 @@
+package types_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	"github.com/onsi/ginkgo/v2/types"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ResourceRequirements", func() {
+	DescribeTable("parsing memory quantities",
+		func(quantity string, expected int64) {
+			Ω(types.ParseMemoryQuantity(quantity)).Should(Equal(expected))
+		},
+		Entry(nil, "1024", int64(1024)),
+		Entry(nil, "512Mi", int64(512<<20)),
+		Entry(nil, "8Gi", int64(8<<30)),
+		Entry(nil, " 1Ti ", int64(1<<40)),
+		Entry(nil, "2K", int64(2000)),
+		Entry(nil, "2G", int64(2000000000)),
+	)
+
+	DescribeTable("rejecting invalid memory quantities",
+		func(quantity string) {
+			_, err := types.ParseMemoryQuantity(quantity)
+			Ω(err).Should(MatchError(ContainSubstring("invalid memory quantity '%s'", quantity)))
+		},
+		Entry(nil, ""),
+		Entry(nil, "0"),
+		Entry(nil, "-1Gi"),
+		Entry(nil, "lots"),
+		Entry(nil, "8Gb"),
+	)
+
+	It("formats memory quantities using the largest exact binary suffix", func() {
+		Ω(types.FormatMemoryQuantity(8 << 30)).Should(Equal("8Gi"))
+		Ω(types.FormatMemoryQuantity(1536 << 20)).Should(Equal("1536Mi"))
+		Ω(types.FormatMemoryQuantity(1000)).Should(Equal("1000"))
+	})
+
+	It("renders only the dimensions that are set", func() {
+		Ω(types.ResourceRequirements{CPU: 4, MemoryBytes: 8 << 30, GPU: 1}.String()).Should(Equal("cpu=4, memory=8Gi, gpu=1"))
+		Ω(types.ResourceRequirements{GPU: 2}.String()).Should(Equal("gpu=2"))
+		Ω(types.ResourceRequirements{}.String()).Should(Equal(""))
+	})
+
+	It("computes the max in each dimension", func() {
+		a := types.ResourceRequirements{CPU: 4, MemoryBytes: 1 << 30}
+		b := types.ResourceRequirements{CPU: 2, MemoryBytes: 2 << 30, GPU: 1}
+		Ω(a.Max(b)).Should(Equal(types.ResourceRequirements{CPU: 4, MemoryBytes: 2 << 30, GPU: 1}))
+	})
+
+	Describe("FitsWithin and ClampedTo", func() {
+		var capacity types.ResourceRequirements
+		BeforeEach(func() {
+			capacity = types.ResourceRequirements{CPU: 8, MemoryBytes: 16 << 30}
+		})
+
+		It("fits requirements that don't exceed the capacity", func() {
+			Ω(types.ResourceRequirements{CPU: 8, MemoryBytes: 16 << 30}.FitsWithin(capacity)).Should(BeTrue())
+			Ω(types.ResourceRequirements{CPU: 9}.FitsWithin(capacity)).Should(BeFalse())
+			Ω(types.ResourceRequirements{MemoryBytes: 17 << 30}.FitsWithin(capacity)).Should(BeFalse())
+		})
+
+		It("treats dimensions with zero capacity as unconstrained", func() {
+			Ω(types.ResourceRequirements{GPU: 100}.FitsWithin(capacity)).Should(BeTrue())
+		})
+
+		It("clamps requirements to the capacity", func() {
+			Ω(types.ResourceRequirements{CPU: 32, MemoryBytes: 1 << 30, GPU: 4}.ClampedTo(capacity)).Should(Equal(types.ResourceRequirements{CPU: 8, MemoryBytes: 1 << 30, GPU: 4}))
+		})
+	})
+})
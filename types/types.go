@@ -2,11 +2,21 @@ package types
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"regexp"
+	"runtime"
+	"sort"
 	"strings"
 	"time"
 )
 
 const GINKGO_FOCUS_EXIT_CODE = 197
+const GINKGO_INTERRUPTED_EXIT_CODE = 198
+const GINKGO_TIMED_OUT_EXIT_CODE = 199
+const GINKGO_COMPILATION_ERROR_EXIT_CODE = 200
+const GINKGO_CONFIGURATION_ERROR_EXIT_CODE = 201
 const GINKGO_TIME_FORMAT = "01/02/06 15:04:05.999"
 
 // Report captures information about a Ginkgo test run
@@ -29,6 +39,10 @@ type Report struct {
 	//(i.e an `FIt` or an `FDescribe`
 	SuiteHasProgrammaticFocus bool
 
+	//UnmatchedFilters captures the --focus, --skip, --focus-file, --skip-file, and --label-filter expressions that did not match any specs
+	//It is only populated when --validate-filters is set
+	UnmatchedFilters []string
+
 	//SpecialSuiteFailureReasons may contain special failure reasons
 	//For example, a test suite might be considered "failed" even if none of the individual specs
 	//have a failure state.  For example, if the user has configured --fail-on-pending the test suite
@@ -38,6 +52,23 @@ type Report struct {
 	//Since multiple special failure reasons can occur, this field is a slice.
 	SpecialSuiteFailureReasons []string
 
+	//SuiteSkipReason is the reason passed to SkipSuite if the suite was skipped in its entirety from a BeforeSuite
+	//The specs in a skipped suite are all reported as skipped, and the suite succeeds.
+	SuiteSkipReason string `json:",omitempty"`
+
+	//UnmetRequirements captures the named requirements declared with Requires (e.g. Requires("docker")) that were not met.
+	//Specs that declare an unmet requirement are skipped.
+	UnmetRequirements UnmetRequirements `json:",omitempty"`
+
+	//PreflightFailures captures the preflight checks registered with RegisterPreflightCheck that failed.
+	//When a preflight check fails the environment is not ready: the suite fails without running BeforeSuite or any specs.
+	//When running in parallel only process #1 runs the preflight checks.
+	PreflightFailures []PreflightFailure `json:",omitempty"`
+
+	//ExitReason captures why the test run ended the way it did (e.g. failed specs vs. an interrupt or a timeout)
+	//Each ExitReason maps onto a distinct process exit code - see ExitReason.ExitCode()
+	ExitReason ExitReason
+
 	//PreRunStats contains a set of stats captured before the test run begins.  This is primarily used
 	//by Ginkgo's reporter to tell the user how many specs are in the current suite (PreRunStats.TotalSpecs)
 	//and how many it intends to run (PreRunStats.SpecsThatWillRun) after applying any relevant focus or skip filters.
@@ -55,10 +86,82 @@ type Report struct {
 	//such as the random seed and any filters applied during the test run
 	SuiteConfig SuiteConfig
 
+	//Environment captures a fingerprint of the environment the test run ran in
+	//When results differ between machines, comparing the Environment of the two reports can help
+	//identify what differed (e.g. the go version, number of CPUs, or whether the working tree was dirty)
+	Environment EnvironmentFingerprint
+
+	//GoTestCacheable captures whether go test recorded this run for its test cache.
+	//When true, subsequent go test invocations may replay these results without re-running the suite.
+	//Pass -count=1 to go test, or use the ginkgo CLI, to guarantee fresh execution.
+	GoTestCacheable bool
+
+	//Deprecations captures the deprecated Ginkgo functionality used by the suite, along with the code locations that use it
+	//and links to the relevant migration documentation.  Platform teams can aggregate these across JSON reports to track migration progress.
+	Deprecations []TrackedDeprecation `json:",omitempty"`
+
+	//ResourceUsage captures the wall time, CPU time, and peak memory consumed by the process(es) that ran the suite.
+	//It is recorded by the Ginkgo CLI and is nil when the suite is not run via the CLI.
+	ResourceUsage *ResourceUsage `json:",omitempty"`
+
+	//ParallelSchedule records which parallel process ran each group of specs, and in what order.
+	//It is only populated when running in parallel and can be used to replay a parallel run deterministically with --replay-parallel-schedule
+	ParallelSchedule ParallelSchedule
+
 	//SpecReports is a list of all SpecReports generated by this test run
 	SpecReports SpecReports
 }
 
+// TimeBoxSkipMessage is the failure message attached to specs that were skipped because the suite's --time-box was exhausted before they could start
+const TimeBoxSkipMessage = "Spec not run due to time box"
+
+// PreflightFailure records a preflight check registered with RegisterPreflightCheck that failed, along with the error it returned
+type PreflightFailure struct {
+	Check    string
+	Message  string
+	Location CodeLocation
+}
+
+// UnmetRequirement records a named requirement declared with Requires that was not met, along with the reason its RequirementChecker gave
+type UnmetRequirement struct {
+	Requirement string
+	Reason      string
+}
+
+type UnmetRequirements []UnmetRequirement
+
+// Add returns the union of the two sets of unmet requirements, keeping the first reason recorded for each requirement
+func (u UnmetRequirements) Add(other UnmetRequirements) UnmetRequirements {
+	out := UnmetRequirements{}
+	seen := map[string]bool{}
+	for _, requirements := range []UnmetRequirements{u, other} {
+		for _, requirement := range requirements {
+			if !seen[requirement.Requirement] {
+				seen[requirement.Requirement] = true
+				out = append(out, requirement)
+			}
+		}
+	}
+	if len(out) == 0 {
+		return nil
+	}
+	return out
+}
+
+//WithFilteredReportEntries returns a copy of the report in which each SpecReport only includes the ReportEntries selected by the passed-in filter
+func (report Report) WithFilteredReportEntries(filter ReportEntryFilter) Report {
+	if filter.IsZero() {
+		return report
+	}
+	specReports := make(SpecReports, len(report.SpecReports))
+	for i := range report.SpecReports {
+		specReports[i] = report.SpecReports[i]
+		specReports[i].ReportEntries = report.SpecReports[i].ReportEntries.Filter(filter)
+	}
+	report.SpecReports = specReports
+	return report
+}
+
 //PreRunStats contains a set of stats captured before the test run begins.  This is primarily used
 //by Ginkgo's reporter to tell the user how many specs are in the current suite (PreRunStats.TotalSpecs)
 //and how many it intends to run (PreRunStats.SpecsThatWillRun) after applying any relevant focus or skip filters.
@@ -67,6 +170,95 @@ type PreRunStats struct {
 	SpecsThatWillRun int
 }
 
+//ResourceUsage captures the resources consumed by the process(es) that ran a suite.
+type ResourceUsage struct {
+	//WallTime is the time that elapsed while the suite ran
+	WallTime time.Duration
+	//CPUTime is the user and system CPU time consumed by all of the suite's processes
+	CPUTime time.Duration
+	//MaxRSS is the peak resident set size, in bytes, of the largest of the suite's processes.  It is zero on platforms that do not report it.
+	MaxRSS int64
+}
+
+//EnvironmentFingerprint captures details about the environment a test run ran in.
+type EnvironmentFingerprint struct {
+	GoVersion  string
+	GOOS       string
+	GOARCH     string
+	NumCPU     int
+	GOMAXPROCS int
+	Hostname   string
+
+	//GitCommit and GitDirty capture the HEAD commit of the git repository containing the suite
+	//and whether its working tree had uncommitted changes.  GitCommit is empty if the suite is not in a git repository.
+	GitCommit string
+	GitDirty  bool
+
+	//EnvVars captures the values of environment variables that can influence the test run.
+	//Only environment variables that are set are captured.  See DefaultFingerprintEnvVars and --fingerprint-env.
+	EnvVars map[string]string `json:",omitempty"`
+}
+
+//DefaultFingerprintEnvVars is the set of environment variables that Ginkgo always captures in the EnvironmentFingerprint
+var DefaultFingerprintEnvVars = []string{"CI", "CGO_ENABLED", "GOFLAGS", "GOGC", "GOMAXPROCS", "GODEBUG", "TZ", "LANG"}
+
+//SuiteDescription is the machine-readable description of a suite printed by --describe-suite.
+//It is generated without running any specs so that tooling can introspect precompiled test binaries.
+type SuiteDescription struct {
+	//GinkgoVersion is the version of Ginkgo the suite was compiled against
+	GinkgoVersion string
+
+	//SuiteDescription and SuiteLabels capture the description and labels passed to RunSpecs
+	SuiteDescription string
+	SuitePath        string
+	SuiteLabels      []string
+
+	//TotalSpecs is the number of specs in the suite, irrespective of any filters
+	TotalSpecs int
+
+	//SpecsByLabel maps each label to the number of specs that carry it - including labels inherited from containers and the suite
+	SpecsByLabel map[string]int
+
+	//RequiredEnv lists the environment variables declared by specs with the RequiresEnv decorator
+	RequiredEnv []string
+}
+
+//ParallelSchedule records the scheduling decisions made during a parallel test run.
+type ParallelSchedule struct {
+	//RandomSeed, ParallelTotal, and NumGroups identify the run the schedule was recorded in.  A schedule can only be replayed by a run
+	//with the same random seed, the same number of parallel processes, and the same set of specs.
+	RandomSeed    int64
+	ParallelTotal int
+	NumGroups     int
+
+	//GroupIndices maps each parallel process to the indices of the groups of specs it ran, in the order it ran them.
+	//Groups of Serial specs always run on process #1 after all other processes have finished and are not recorded.
+	GroupIndices map[int][]int `json:",omitempty"`
+}
+
+//IsZero returns true if no schedule was recorded
+func (schedule ParallelSchedule) IsZero() bool {
+	return schedule.ParallelTotal == 0
+}
+
+//Add merges the groups recorded by another parallel process into the schedule
+func (schedule ParallelSchedule) Add(other ParallelSchedule) ParallelSchedule {
+	if schedule.IsZero() {
+		schedule.RandomSeed, schedule.ParallelTotal, schedule.NumGroups = other.RandomSeed, other.ParallelTotal, other.NumGroups
+	}
+	if len(schedule.GroupIndices) == 0 && len(other.GroupIndices) == 0 {
+		return schedule
+	}
+	groupIndices := map[int][]int{}
+	for _, indices := range []map[int][]int{schedule.GroupIndices, other.GroupIndices} {
+		for process, idxs := range indices {
+			groupIndices[process] = append(groupIndices[process], idxs...)
+		}
+	}
+	schedule.GroupIndices = groupIndices
+	return schedule
+}
+
 //Add is ued by Ginkgo's parallel aggregation mechanisms to combine test run reports form individual parallel processes
 //to form a complete final report.
 func (report Report) Add(other Report) Report {
@@ -91,8 +283,26 @@ func (report Report) Add(other Report) Report {
 		}
 	}
 	report.SpecialSuiteFailureReasons = specialSuiteFailureReasons
+	if report.SuiteSkipReason == "" {
+		report.SuiteSkipReason = other.SuiteSkipReason
+	}
+	report.UnmetRequirements = report.UnmetRequirements.Add(other.UnmetRequirements)
+	if len(report.PreflightFailures) == 0 {
+		report.PreflightFailures = other.PreflightFailures
+	}
+	report.ExitReason = report.ExitReason.Combine(other.ExitReason)
 	report.RunTime = report.EndTime.Sub(report.StartTime)
 
+	if report.Environment.GoVersion == "" {
+		report.Environment = other.Environment
+	}
+	report.GoTestCacheable = report.GoTestCacheable || other.GoTestCacheable
+	if len(report.UnmatchedFilters) == 0 {
+		report.UnmatchedFilters = other.UnmatchedFilters
+	}
+	report.Deprecations = MergeTrackedDeprecations(report.Deprecations, other.Deprecations)
+	report.ParallelSchedule = report.ParallelSchedule.Add(other.ParallelSchedule)
+
 	reports := make(SpecReports, len(report.SpecReports)+len(other.SpecReports))
 	for i := range report.SpecReports {
 		reports[i] = report.SpecReports[i]
@@ -137,6 +347,26 @@ type SpecReport struct {
 	// IsInOrderedContainer captures whether the spec appears in an Ordered container
 	IsInOrderedContainer bool
 
+	// OrderedContainerStep and OrderedContainerSteps locate specs in an Ordered container within the container's sequence of specs.
+	// OrderedContainerStep is 1-indexed - both are zero for specs that are not in an Ordered container
+	OrderedContainerStep  int
+	OrderedContainerSteps int
+
+	// VerboseOutput captures whether the spec, or one of its containers, has the VerboseOutput decorator.
+	// Ginkgo's console reporter emits such specs as though -v had been set
+	VerboseOutput bool
+
+	// SlowSpecThreshold captures the threshold set by the SlowSpecThreshold decorator on the spec or its innermost decorated container.
+	// When non-zero it overrides --slow-spec-threshold for this spec
+	SlowSpecThreshold time.Duration
+
+	// PollProgressAfter captures the threshold set by the PollProgressAfter decorator on the spec or its innermost decorated container.
+	// When non-zero it overrides --poll-progress-after for this spec
+	PollProgressAfter time.Duration
+
+	// ResourceRequirements captures the host resources the spec declared with the Requires decorator
+	ResourceRequirements ResourceRequirements
+
 	// StartTime and EndTime capture the start and end time of the spec
 	StartTime time.Time
 	EndTime   time.Time
@@ -151,10 +381,30 @@ type SpecReport struct {
 	//It includes detailed information about the Failure
 	Failure Failure
 
+	// AdditionalFailures captures any failures that occurred after the spec's initial Failure - for example, in subsequent AfterEach or DeferCleanup nodes.
+	// The initial Failure determines the spec's State.  AdditionalFailures are recorded so that cleanup failure cascades don't hide root causes.
+	AdditionalFailures []AdditionalFailure
+
+	// FailureIgnored is true if the spec failed but the failure did not fail the suite.  This happens when an AfterSuite fails
+	// and --after-suite-failure-policy=warn is set
+	FailureIgnored bool
+
 	// NumAttempts captures the number of times this Spec was run.  Flakey specs can be retried with
 	// ginkgo --flake-attempts=N
 	NumAttempts int
 
+	// NumAssertions captures the number of assertions the final attempt at running this Spec made.  Ginkgo counts every call to Fail
+	// (and so every failed Gomega assertion) and every call to RecordAssertion - Gomega does not tell Ginkgo about the assertions that pass.
+	NumAssertions int
+
+	// CPUTime captures the CPU time (user and system) the Ginkgo process used while running the final attempt at this Spec, as measured by getrusage.
+	// It is zero on platforms where Ginkgo can't measure CPU time.
+	CPUTime time.Duration
+
+	// Attempts captures the history of every attempt made at running this Spec.  It is only populated
+	// for specs that were eligible to be retried (i.e. via the FlakeAttempts decorator or --flake-attempts)
+	Attempts SpecAttempts
+
 	// CapturedGinkgoWriterOutput contains text printed to the GinkgoWriter
 	CapturedGinkgoWriterOutput string
 
@@ -165,6 +415,46 @@ type SpecReport struct {
 
 	// ReportEntries contains any reports added via `AddReportEntry`
 	ReportEntries ReportEntries
+
+	// Warnings contains any non-fatal warnings emitted via `GinkgoWarn`
+	Warnings []Warning
+
+	// Annotations contains the typed metadata attached to the spec via the Annotate decorator (on the spec or its containers) and via `AddSpecAnnotation`
+	// Use Annotations.Get(key) to look up a value
+	Annotations SpecAnnotations
+
+	// FailureFingerprint identifies the spec's failure independently of the spec that failed.  It is computed from the failure's message - with volatile details
+	// like numbers, memory addresses, and UUIDs normalized away - and its location, so specs that fail in the same way share a fingerprint.  It is empty for specs that did not fail
+	FailureFingerprint string
+
+	// SourceFiles is the sorted set of source files that define the spec's nodes - its containers, its setup and teardown nodes, and its subject.
+	// Tooling can use it to map a failing spec back to the files (and teams) that own it, or to select the specs affected by a change
+	SourceFiles []string
+
+	// Attachments contains any captured output that Ginkgo deemed to be binary.
+	// Such output is moved out of CapturedGinkgoWriterOutput/CapturedStdOutErr and stored here instead
+	Attachments []Attachment
+
+	// FailureArtifacts contains the paths of any files written by FailureArtifactCollectors when the spec failed
+	FailureArtifacts []string
+
+	// NetworkCaptures contains the paths of any network captures saved by NetworkCapturers that ran around the spec
+	NetworkCaptures []string
+
+	// Steps contains the Steps run by the spec, in the order they started.  Nested Steps follow their parent Step and have a higher NestingLevel.
+	// For specs that are retried, Steps only contains the Steps run by the last attempt
+	Steps []SpecStep
+
+	// EnvironmentDegradations contains the periods during which a health check registered with RegisterHealthCheck was failing while the spec ran.
+	// Use them to distinguish failures caused by infrastructure blips from product failures
+	EnvironmentDegradations []EnvironmentDegradation
+
+	// ProgressReports contains the ProgressReports Ginkgo emitted while the spec ran longer than its PollProgressAfter threshold
+	ProgressReports []ProgressReport
+
+	// RerunCommand is populated if the spec failed.  It is a copy-pasteable command that reruns just this spec with the same seed, relevant flags,
+	// and environment variables (those captured in Report.Environment.EnvVars)
+	RerunCommand string
 }
 
 func (report SpecReport) MarshalJSON() ([]byte, error) {
@@ -178,15 +468,37 @@ func (report SpecReport) MarshalJSON() ([]byte, error) {
 		LeafNodeLabels              []string
 		LeafNodeText                string
 		State                       SpecState
+		ResourceRequirements        *ResourceRequirements `json:",omitempty"`
+		VerboseOutput               bool                  `json:",omitempty"`
+		SlowSpecThreshold           time.Duration         `json:",omitempty"`
+		PollProgressAfter           time.Duration         `json:",omitempty"`
+		OrderedContainerStep        int                   `json:",omitempty"`
+		OrderedContainerSteps       int                   `json:",omitempty"`
 		StartTime                   time.Time
 		EndTime                     time.Time
 		RunTime                     time.Duration
 		ParallelProcess             int
-		Failure                     *Failure `json:",omitempty"`
+		Failure                     *Failure            `json:",omitempty"`
+		AdditionalFailures          []AdditionalFailure `json:",omitempty"`
+		FailureIgnored              bool                `json:",omitempty"`
 		NumAttempts                 int
-		CapturedGinkgoWriterOutput  string        `json:",omitempty"`
-		CapturedStdOutErr           string        `json:",omitempty"`
-		ReportEntries               ReportEntries `json:",omitempty"`
+		NumAssertions               int                      `json:",omitempty"`
+		CPUTime                     time.Duration            `json:",omitempty"`
+		Attempts                    SpecAttempts             `json:",omitempty"`
+		CapturedGinkgoWriterOutput  string                   `json:",omitempty"`
+		CapturedStdOutErr           string                   `json:",omitempty"`
+		ReportEntries               ReportEntries            `json:",omitempty"`
+		Warnings                    []Warning                `json:",omitempty"`
+		Annotations                 SpecAnnotations          `json:",omitempty"`
+		FailureFingerprint          string                   `json:",omitempty"`
+		SourceFiles                 []string                 `json:",omitempty"`
+		Attachments                 []Attachment             `json:",omitempty"`
+		FailureArtifacts            []string                 `json:",omitempty"`
+		NetworkCaptures             []string                 `json:",omitempty"`
+		Steps                       []SpecStep               `json:",omitempty"`
+		EnvironmentDegradations     []EnvironmentDegradation `json:",omitempty"`
+		ProgressReports             []ProgressReport         `json:",omitempty"`
+		RerunCommand                string                   `json:",omitempty"`
 	}{
 		ContainerHierarchyTexts:     report.ContainerHierarchyTexts,
 		ContainerHierarchyLocations: report.ContainerHierarchyLocations,
@@ -196,15 +508,36 @@ func (report SpecReport) MarshalJSON() ([]byte, error) {
 		LeafNodeLabels:              report.LeafNodeLabels,
 		LeafNodeText:                report.LeafNodeText,
 		State:                       report.State,
+		VerboseOutput:               report.VerboseOutput,
+		SlowSpecThreshold:           report.SlowSpecThreshold,
+		PollProgressAfter:           report.PollProgressAfter,
+		OrderedContainerStep:        report.OrderedContainerStep,
+		OrderedContainerSteps:       report.OrderedContainerSteps,
 		StartTime:                   report.StartTime,
 		EndTime:                     report.EndTime,
 		RunTime:                     report.RunTime,
 		ParallelProcess:             report.ParallelProcess,
 		Failure:                     nil,
+		AdditionalFailures:          report.AdditionalFailures,
+		FailureIgnored:              report.FailureIgnored,
 		ReportEntries:               nil,
 		NumAttempts:                 report.NumAttempts,
+		NumAssertions:               report.NumAssertions,
+		CPUTime:                     report.CPUTime,
+		Attempts:                    report.Attempts,
 		CapturedGinkgoWriterOutput:  report.CapturedGinkgoWriterOutput,
 		CapturedStdOutErr:           report.CapturedStdOutErr,
+		Warnings:                    report.Warnings,
+		Annotations:                 report.Annotations,
+		FailureFingerprint:          report.FailureFingerprint,
+		SourceFiles:                 report.SourceFiles,
+		Attachments:                 report.Attachments,
+		FailureArtifacts:            report.FailureArtifacts,
+		NetworkCaptures:             report.NetworkCaptures,
+		Steps:                       report.Steps,
+		EnvironmentDegradations:     report.EnvironmentDegradations,
+		ProgressReports:             report.ProgressReports,
+		RerunCommand:                report.RerunCommand,
 	}
 
 	if !report.Failure.IsZero() {
@@ -213,6 +546,9 @@ func (report SpecReport) MarshalJSON() ([]byte, error) {
 	if len(report.ReportEntries) > 0 {
 		out.ReportEntries = report.ReportEntries
 	}
+	if !report.ResourceRequirements.IsZero() {
+		out.ResourceRequirements = &(report.ResourceRequirements)
+	}
 
 	return json.Marshal(out)
 }
@@ -231,11 +567,26 @@ func (report SpecReport) CombinedOutput() string {
 }
 
 //Failed returns true if report.State is one of the SpecStateFailureStates
-// (SpecStateFailed, SpecStatePanicked, SpecStateinterrupted, SpecStateAborted)
+// (SpecStateFailed, SpecStatePanicked, SpecStateinterrupted, SpecStateAborted, SpecStateTimedout)
 func (report SpecReport) Failed() bool {
 	return report.State.Is(SpecStateFailureStates)
 }
 
+// FailureCategory returns the kind of failure the spec ended in, or FailureCategoryNone if it did not fail (or was aborted or interrupted)
+func (report SpecReport) FailureCategory() FailureCategory {
+	switch {
+	case report.State.Is(SpecStateTimedout):
+		return FailureCategoryTimeout
+	case report.State.Is(SpecStatePanicked):
+		return FailureCategoryPanic
+	case report.State.Is(SpecStateFailed) && report.Failure.Error != nil:
+		return FailureCategoryError
+	case report.State.Is(SpecStateFailed):
+		return FailureCategoryAssertion
+	}
+	return FailureCategoryNone
+}
+
 //FullText returns a concatenation of all the report.ContainerHierarchyTexts and report.LeafNodeText
 func (report SpecReport) FullText() string {
 	texts := []string{}
@@ -349,6 +700,103 @@ func (reports SpecReports) CountWithState(states SpecState) int {
 	return n
 }
 
+// CountNotRunDueToTimeBox returns the number of specs that were skipped because the suite's --time-box was exhausted
+func (reports SpecReports) CountNotRunDueToTimeBox() int {
+	n := 0
+	for i := range reports {
+		if reports[i].State == SpecStateSkipped && reports[i].Failure.Message == TimeBoxSkipMessage {
+			n += 1
+		}
+	}
+	return n
+}
+
+// LabelSummary aggregates the results of the specs that share a label
+type LabelSummary struct {
+	// Label is the label shared by the specs.  Label is empty for the summary of specs that have no labels
+	Label string
+
+	Passed  int
+	Failed  int
+	Pending int
+	Skipped int
+
+	// RunTime is the total duration of the specs that share the label
+	RunTime time.Duration
+}
+
+// SummarizeByLabel groups the subject (i.e. It) SpecReports by label and returns a summary for each label, sorted by label.
+// A spec with multiple labels (including labels inherited from its containers) counts towards each of them.
+// Specs that have no labels are summarized in a final LabelSummary with an empty Label.
+func (reports SpecReports) SummarizeByLabel() []LabelSummary {
+	summaries := map[string]*LabelSummary{}
+	labels := []string{}
+	for _, report := range reports.WithLeafNodeType(NodeTypeIt) {
+		reportLabels := report.Labels()
+		if len(reportLabels) == 0 {
+			reportLabels = []string{""}
+		}
+		for _, label := range reportLabels {
+			summary, ok := summaries[label]
+			if !ok {
+				summary = &LabelSummary{Label: label}
+				summaries[label] = summary
+				if label != "" {
+					labels = append(labels, label)
+				}
+			}
+			switch {
+			case report.State.Is(SpecStatePassed):
+				summary.Passed += 1
+			case report.State.Is(SpecStateFailureStates):
+				summary.Failed += 1
+			case report.State.Is(SpecStatePending):
+				summary.Pending += 1
+			case report.State.Is(SpecStateSkipped):
+				summary.Skipped += 1
+			}
+			summary.RunTime += report.RunTime
+		}
+	}
+	sort.Strings(labels)
+	out := []LabelSummary{}
+	for _, label := range labels {
+		out = append(out, *summaries[label])
+	}
+	if unlabeled, ok := summaries[""]; ok {
+		out = append(out, *unlabeled)
+	}
+	return out
+}
+
+// FailureGroup captures the SpecReports that share a FailureFingerprint
+type FailureGroup struct {
+	Fingerprint string
+	SpecReports SpecReports
+}
+
+// GroupByFailureFingerprint groups the failed SpecReports by FailureFingerprint.  The largest groups come first, groups of the same size are ordered by their first failure.
+func (reports SpecReports) GroupByFailureFingerprint() []FailureGroup {
+	groups := []FailureGroup{}
+	indices := map[string]int{}
+	for _, report := range reports.WithState(SpecStateFailureStates) {
+		if report.FailureFingerprint == "" {
+			continue
+		}
+		idx, ok := indices[report.FailureFingerprint]
+		if !ok {
+			idx = len(groups)
+			indices[report.FailureFingerprint] = idx
+			groups = append(groups, FailureGroup{Fingerprint: report.FailureFingerprint})
+		}
+		groups[idx].SpecReports = append(groups[idx].SpecReports, report)
+	}
+	sort.SliceStable(groups, func(i, j int) bool {
+		return len(groups[i].SpecReports) > len(groups[j].SpecReports)
+	})
+	return groups
+}
+
 //CountWithState returns the number of SpecReports that passed after multiple attempts
 func (reports SpecReports) CountOfFlakedSpecs() int {
 	n := 0
@@ -360,6 +808,168 @@ func (reports SpecReports) CountOfFlakedSpecs() int {
 	return n
 }
 
+//CountOfWarnings returns the total number of warnings emitted across all SpecReports
+func (reports SpecReports) CountOfWarnings() int {
+	n := 0
+	for i := range reports {
+		n += len(reports[i].Warnings)
+	}
+	return n
+}
+
+// Warning captures a non-fatal warning emitted by a spec via GinkgoWarn
+type Warning struct {
+	// Message is the message passed to GinkgoWarn
+	Message string
+	// Location is the location of the GinkgoWarn call
+	Location CodeLocation
+}
+
+// SpecStep captures information about a Step run by a spec
+type SpecStep struct {
+	// Text is the text passed to Step
+	Text string
+
+	// Location is the location of the call to Step
+	Location CodeLocation
+
+	// NestingLevel is 0 for Steps called directly by a node and is incremented for each enclosing Step
+	NestingLevel int
+
+	// State captures the outcome of the Step.  Steps that were still running when the spec ended (e.g. because the spec timed out) take on the spec's State
+	State SpecState
+
+	// StartTime and EndTime capture the start and end time of the Step
+	StartTime time.Time
+	EndTime   time.Time
+
+	// RunTime captures the duration of the Step
+	RunTime time.Duration
+
+	// Message is populated if the Step did not pass.  It is the failure message (or the panic) that ended the Step
+	Message string `json:",omitempty"`
+}
+
+// EnvironmentDegradation captures a period during which a health check registered with RegisterHealthCheck was failing
+type EnvironmentDegradation struct {
+	// HealthCheck is the name the health check was registered with
+	HealthCheck string
+
+	// Message is the error returned by the first failing run of the health check
+	Message string
+
+	// StartTime is the time of the first failing run of the health check.  EndTime is the time of the first passing run after that
+	// and is zero if the health check was still failing when the spec ended
+	StartTime time.Time
+	EndTime   time.Time
+}
+
+// ProgressReport captures a snapshot of a spec that has been running for longer than its PollProgressAfter threshold.
+// Ginkgo emits ProgressReports periodically until the node that is running completes
+type ProgressReport struct {
+	// ParallelProcess is the parallel process the spec is running on
+	ParallelProcess int
+
+	// ContainerHierarchyTexts, LeafNodeText, LeafNodeLocation and SpecStartTime identify the running spec
+	ContainerHierarchyTexts []string
+	LeafNodeText            string
+	LeafNodeLocation        CodeLocation
+	SpecStartTime           time.Time
+
+	// OrderedContainerStep and OrderedContainerSteps locate the running spec within its Ordered container (both are zero for specs that are not in an Ordered container)
+	OrderedContainerStep  int `json:",omitempty"`
+	OrderedContainerSteps int `json:",omitempty"`
+
+	// CurrentNodeType, CurrentNodeText, CurrentNodeLocation and CurrentNodeStartTime identify the node that is running
+	CurrentNodeType      NodeType
+	CurrentNodeText      string
+	CurrentNodeLocation  CodeLocation
+	CurrentNodeStartTime time.Time
+
+	// Time is the time at which the ProgressReport was generated
+	Time time.Time
+
+	// NodeGoroutineStack is the stack of the goroutine running the current node
+	NodeGoroutineStack string
+
+	// Message describes what the node is waiting on (e.g. the latest unsuccessful attempt at satisfying a ReadinessGate)
+	Message string `json:",omitempty"`
+}
+
+// SpecAttempt captures information about an individual attempt at running a spec.
+// SpecAttempts are recorded for specs that are eligible to be retried and can be used to understand how a flakey spec behaved across attempts.
+type SpecAttempt struct {
+	// Attempt is the (1-indexed) number of this attempt
+	Attempt int
+
+	// State captures the outcome of this attempt
+	State SpecState
+
+	// Backoff captures how long Ginkgo waited, per the spec's Retry decorator, before making this attempt
+	Backoff time.Duration
+
+	// StartTime and EndTime capture the start and end time of this attempt
+	StartTime time.Time
+	EndTime   time.Time
+
+	// RunTime captures the duration of this attempt
+	RunTime time.Duration
+
+	// Failure is populated if this attempt did not pass
+	Failure Failure
+
+	// AdditionalFailures captures any failures that occurred after Failure during this attempt
+	AdditionalFailures []AdditionalFailure `json:",omitempty"`
+
+	// CapturedGinkgoWriterOutput and CapturedStdOutErr contain the output emitted during this attempt
+	CapturedGinkgoWriterOutput string
+	CapturedStdOutErr          string
+}
+
+func (attempt SpecAttempt) MarshalJSON() ([]byte, error) {
+	//All this to avoid emitting an empty Failure struct in the JSON
+	out := struct {
+		Attempt                    int
+		State                      SpecState
+		Backoff                    time.Duration `json:",omitempty"`
+		StartTime                  time.Time
+		EndTime                    time.Time
+		RunTime                    time.Duration
+		Failure                    *Failure `json:",omitempty"`
+		CapturedGinkgoWriterOutput string   `json:",omitempty"`
+		CapturedStdOutErr          string   `json:",omitempty"`
+	}{
+		Attempt:                    attempt.Attempt,
+		State:                      attempt.State,
+		Backoff:                    attempt.Backoff,
+		StartTime:                  attempt.StartTime,
+		EndTime:                    attempt.EndTime,
+		RunTime:                    attempt.RunTime,
+		Failure:                    nil,
+		CapturedGinkgoWriterOutput: attempt.CapturedGinkgoWriterOutput,
+		CapturedStdOutErr:          attempt.CapturedStdOutErr,
+	}
+
+	if !attempt.Failure.IsZero() {
+		out.Failure = &(attempt.Failure)
+	}
+
+	return json.Marshal(out)
+}
+
+type SpecAttempts []SpecAttempt
+
+//Failed returns the subset of SpecAttempts that did not pass
+func (attempts SpecAttempts) Failed() SpecAttempts {
+	out := SpecAttempts{}
+	for _, attempt := range attempts {
+		if attempt.State.Is(SpecStateFailureStates) {
+			out = append(out, attempt)
+		}
+	}
+	return out
+}
+
 // Failure captures failure information for an individual test
 type Failure struct {
 	// Message - the failure message passed into Fail(...).  When using a matcher library
@@ -388,12 +998,164 @@ type Failure struct {
 	FailureNodeType           NodeType
 	FailureNodeLocation       CodeLocation
 	FailureNodeContainerIndex int
+
+	// Payload - if the failure was generated by FailWithPayload then Payload captures the structured value attached to the failure.
+	Payload *FailurePayload `json:",omitempty"`
+
+	// Panic - if the failure represents a captured panic then Panic captures structured information about the value passed to panic.
+	// The formatted stack trace of the panic is available in Location.FullStackTrace
+	Panic *PanicValue `json:",omitempty"`
+
+	// Error - if the failure was generated by FailWith then Error captures structured information about the error passed to FailWith.
+	Error *FailureError `json:",omitempty"`
+}
+
+// PanicValue captures structured information about a recovered panic so that tooling can distinguish, say, nil-pointer
+// dereferences from custom domain panics without parsing ForwardedPanic
+type PanicValue struct {
+	// Type - the Go type of the value passed to panic (e.g. "runtime.boundsError" or "*mypackage.DomainError")
+	Type string
+
+	// Error - if the value passed to panic is an error, the result of calling Error() on it
+	Error string `json:",omitempty"`
+
+	// IsRuntimeError - true if the value passed to panic is a runtime.Error (e.g. a nil-pointer dereference or an out-of-bounds index)
+	IsRuntimeError bool `json:",omitempty"`
+
+	raw interface{} //unexported to prevent gob from freaking out about unregistered types
+}
+
+func NewPanicValue(value interface{}) *PanicValue {
+	out := &PanicValue{
+		Type: fmt.Sprintf("%T", value),
+		raw:  value,
+	}
+	if err, ok := value.(error); ok {
+		out.Error = err.Error()
+	}
+	if _, ok := value.(runtime.Error); ok {
+		out.IsRuntimeError = true
+	}
+	return out
+}
+
+// GetRawValue returns the value that was passed to panic.  This is only available in the process in which the panic occurred -
+// it is nil in reports that have been decoded from JSON or sent across processes when running in parallel.
+func (pv PanicValue) GetRawValue() interface{} {
+	return pv.raw
+}
+
+// FailureError captures structured information about the error passed to FailWith so that tooling can classify failures by
+// the type of error that caused them without parsing the failure message
+type FailureError struct {
+	// Type - the Go type of the error (e.g. "*fs.PathError")
+	Type string
+
+	// Error - the result of calling Error() on the error
+	Error string
+
+	// WrappedTypes - the Go types of the errors wrapped by the error, outermost first, as returned by successive calls to errors.Unwrap
+	WrappedTypes []string `json:",omitempty"`
+
+	raw error //unexported to prevent gob from freaking out about unregistered types
+}
+
+func NewFailureError(err error) *FailureError {
+	out := &FailureError{
+		Type:  fmt.Sprintf("%T", err),
+		Error: err.Error(),
+		raw:   err,
+	}
+	for wrapped := errors.Unwrap(err); wrapped != nil; wrapped = errors.Unwrap(wrapped) {
+		out.WrappedTypes = append(out.WrappedTypes, fmt.Sprintf("%T", wrapped))
+	}
+	return out
+}
+
+// GetRawError returns the error that was passed to FailWith.  This is only available in the process in which the failure occurred -
+// it is nil in reports that have been decoded from JSON or sent across processes when running in parallel.
+func (fe FailureError) GetRawError() error {
+	return fe.raw
+}
+
+// FailurePayload captures a structured value attached to a failure via FailWithPayload.
+// The value is rendered when the failure occurs (using the renderer registered with RegisterFailureRenderer, if any) and
+// JSON-encoded so that it survives the trip across parallel processes and into machine-readable reports.
+type FailurePayload struct {
+	// Type - the Go type of the value
+	Type string
+	// AsJSON - the JSON encoding of the value.  Decode this to reconstitute the original value.
+	AsJSON string `json:",omitempty"`
+	// Representation - the human-readable rendering of the value.  This is passed through Ginkgo's formatter and can include color codes.
+	Representation string
 }
 
 func (f Failure) IsZero() bool {
 	return f == Failure{}
 }
 
+// FailureCategory classifies failures so that retry policies can distinguish genuine regressions from infrastructure trouble
+type FailureCategory string
+
+const (
+	FailureCategoryNone FailureCategory = ""
+	// FailureCategoryAssertion - the spec failed via Fail (e.g. a failed Gomega assertion)
+	FailureCategoryAssertion FailureCategory = "assertion"
+	// FailureCategoryError - the spec failed via FailWith
+	FailureCategoryError FailureCategory = "error"
+	// FailureCategoryPanic - the spec panicked
+	FailureCategoryPanic FailureCategory = "panic"
+	// FailureCategoryTimeout - the spec timed out
+	FailureCategoryTimeout FailureCategory = "timeout"
+	// FailureCategoryInfrastructure is not a category in its own right: in a --retry-on policy it matches both error and timeout failures
+	FailureCategoryInfrastructure FailureCategory = "infrastructure"
+)
+
+func (c FailureCategory) matches(rule string) bool {
+	if FailureCategory(rule) == FailureCategoryInfrastructure {
+		return c == FailureCategoryError || c == FailureCategoryTimeout
+	}
+	return c != FailureCategoryNone && FailureCategory(rule) == c
+}
+
+// the details of failure messages that tend to differ between otherwise identical failures, in the order in which they are normalized
+var volatileFailureDetails = []struct {
+	re          *regexp.Regexp
+	replacement string
+}{
+	{regexp.MustCompile(`(?i)[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}`), "<uuid>"},
+	{regexp.MustCompile(`0x[0-9a-fA-F]+`), "0x?"},
+	{regexp.MustCompile(`[0-9]+`), "N"},
+	{regexp.MustCompile(`\s+`), " "},
+}
+
+// Fingerprint returns an identifier for the failure that is shared by failures with the same location and the same message, once numbers, memory addresses, and UUIDs are normalized away.
+// Fingerprint returns "" for the zero Failure
+func (f Failure) Fingerprint() string {
+	if f.IsZero() {
+		return ""
+	}
+	message := f.Message + "\n" + f.ForwardedPanic
+	for _, detail := range volatileFailureDetails {
+		message = detail.re.ReplaceAllString(message, detail.replacement)
+	}
+	h := fnv.New64a()
+	fmt.Fprintf(h, "%s:%d\n%s", f.Location.FileName, f.Location.LineNumber, strings.TrimSpace(message))
+	return fmt.Sprintf("%016x", h.Sum64())
+}
+
+// AdditionalFailure captures a failure that occurred after a spec had already failed - for example, in an AfterEach or DeferCleanup node
+type AdditionalFailure struct {
+	// State - the state the spec would have ended in had this been the initial failure
+	State SpecState
+
+	// Failure - the failure itself, including the location of the failure and of the node in which it occurred
+	Failure Failure
+
+	// CapturedGinkgoWriterOutput - the GinkgoWriter output emitted by the node in which the failure occurred
+	CapturedGinkgoWriterOutput string `json:",omitempty"`
+}
+
 // FailureNodeContext captures the location context for the node containing the failing line of code
 type FailureNodeContext uint
 
@@ -438,6 +1200,7 @@ const (
 	SpecStateAborted
 	SpecStatePanicked
 	SpecStateInterrupted
+	SpecStateTimedout
 )
 
 var ssEnumSupport = NewEnumSupport(map[uint]string{
@@ -449,6 +1212,7 @@ var ssEnumSupport = NewEnumSupport(map[uint]string{
 	uint(SpecStateAborted):     "aborted",
 	uint(SpecStatePanicked):    "panicked",
 	uint(SpecStateInterrupted): "interrupted",
+	uint(SpecStateTimedout):    "timedout",
 })
 
 func (ss SpecState) String() string {
@@ -463,12 +1227,108 @@ func (ss SpecState) MarshalJSON() ([]byte, error) {
 	return ssEnumSupport.MarshJSON(uint(ss))
 }
 
-var SpecStateFailureStates = SpecStateFailed | SpecStateAborted | SpecStatePanicked | SpecStateInterrupted
+var SpecStateFailureStates = SpecStateFailed | SpecStateAborted | SpecStatePanicked | SpecStateInterrupted | SpecStateTimedout
 
 func (ss SpecState) Is(states SpecState) bool {
 	return ss&states != 0
 }
 
+// ExitReason captures why a Ginkgo test run ended the way it did
+// ExitReasons are ordered by precedence: when combining the ExitReasons of multiple processes or suites the reason with the highest precedence wins.
+// This ensures that infrastructure problems (e.g. compilation errors or timeouts) are not masked by ordinary spec failures.
+type ExitReason uint
+
+const (
+	ExitReasonInvalid ExitReason = iota
+
+	ExitReasonPassed
+	ExitReasonFocused
+	ExitReasonFailed
+	ExitReasonTimedOut
+	ExitReasonInterrupted
+	ExitReasonCompilationError
+	ExitReasonConfigurationError
+)
+
+var erEnumSupport = NewEnumSupport(map[uint]string{
+	uint(ExitReasonInvalid):            "INVALID EXIT REASON",
+	uint(ExitReasonPassed):             "passed",
+	uint(ExitReasonFocused):            "focused",
+	uint(ExitReasonFailed):             "failed",
+	uint(ExitReasonTimedOut):           "timed-out",
+	uint(ExitReasonInterrupted):        "interrupted",
+	uint(ExitReasonCompilationError):   "compilation-error",
+	uint(ExitReasonConfigurationError): "configuration-error",
+})
+
+func (er ExitReason) String() string {
+	return erEnumSupport.String(uint(er))
+}
+func (er *ExitReason) UnmarshalJSON(b []byte) error {
+	out, err := erEnumSupport.UnmarshJSON(b)
+	*er = ExitReason(out)
+	return err
+}
+func (er ExitReason) MarshalJSON() ([]byte, error) {
+	return erEnumSupport.MarshJSON(uint(er))
+}
+
+// ExitCode returns the process exit code associated with the ExitReason
+func (er ExitReason) ExitCode() int {
+	switch er {
+	case ExitReasonPassed:
+		return 0
+	case ExitReasonFocused:
+		return GINKGO_FOCUS_EXIT_CODE
+	case ExitReasonTimedOut:
+		return GINKGO_TIMED_OUT_EXIT_CODE
+	case ExitReasonInterrupted:
+		return GINKGO_INTERRUPTED_EXIT_CODE
+	case ExitReasonCompilationError:
+		return GINKGO_COMPILATION_ERROR_EXIT_CODE
+	case ExitReasonConfigurationError:
+		return GINKGO_CONFIGURATION_ERROR_EXIT_CODE
+	}
+	return 1
+}
+
+// Is returns true if the ExitReason is any of the passed-in reasons
+func (er ExitReason) Is(reasons ...ExitReason) bool {
+	for _, reason := range reasons {
+		if er == reason {
+			return true
+		}
+	}
+	return false
+}
+
+// Combine returns whichever of the two ExitReasons takes precedence
+func (er ExitReason) Combine(other ExitReason) ExitReason {
+	if other > er {
+		return other
+	}
+	return er
+}
+
+// ExitReasonForExitCode maps a process exit code back onto an ExitReason.  Any unrecognized non-zero exit code is treated as a failure.
+func ExitReasonForExitCode(exitCode int) ExitReason {
+	switch exitCode {
+	case 0:
+		return ExitReasonPassed
+	case GINKGO_FOCUS_EXIT_CODE:
+		return ExitReasonFocused
+	case GINKGO_TIMED_OUT_EXIT_CODE:
+		return ExitReasonTimedOut
+	case GINKGO_INTERRUPTED_EXIT_CODE:
+		return ExitReasonInterrupted
+	case GINKGO_COMPILATION_ERROR_EXIT_CODE:
+		return ExitReasonCompilationError
+	case GINKGO_CONFIGURATION_ERROR_EXIT_CODE:
+		return ExitReasonConfigurationError
+	}
+	return ExitReasonFailed
+}
+
 // NodeType captures the type of a given Ginkgo Node
 type NodeType uint
 
@@ -499,10 +1359,20 @@ const (
 	NodeTypeCleanupAfterEach
 	NodeTypeCleanupAfterAll
 	NodeTypeCleanupAfterSuite
+
+	NodeTypeReadinessGate
+
+	NodeTypeAroundEach
 )
 
 var NodeTypesForContainerAndIt = NodeTypeContainer | NodeTypeIt
-var NodeTypesForSuiteLevelNodes = NodeTypeBeforeSuite | NodeTypeSynchronizedBeforeSuite | NodeTypeAfterSuite | NodeTypeSynchronizedAfterSuite | NodeTypeReportAfterSuite | NodeTypeCleanupAfterSuite
+var NodeTypesForChaos = NodeTypeIt | NodeTypeBeforeEach | NodeTypeJustBeforeEach | NodeTypeAfterEach | NodeTypeJustAfterEach | NodeTypeBeforeAll | NodeTypeAfterAll | NodeTypeCleanupAfterEach | NodeTypeCleanupAfterAll
+var NodeTypesThatAcceptContexts = NodeTypeIt | NodeTypeBeforeEach | NodeTypeJustBeforeEach | NodeTypeAfterEach | NodeTypeJustAfterEach | NodeTypeBeforeAll | NodeTypeAfterAll | NodeTypeBeforeSuite | NodeTypeAfterSuite
+var NodeTypesThatAcceptNodeTimeouts = NodeTypeIt | NodeTypeBeforeEach | NodeTypeJustBeforeEach | NodeTypeAfterEach | NodeTypeJustAfterEach | NodeTypeBeforeAll | NodeTypeAfterAll | NodeTypesForSuiteSetupAndCleanup
+var NodeTypesThatCanBeRegisteredGlobally = NodeTypeBeforeEach | NodeTypeJustBeforeEach | NodeTypeAfterEach | NodeTypeJustAfterEach
+var NodeTypesThatAcceptEnv = NodeTypesForContainerAndIt | NodeTypeBeforeEach | NodeTypeJustBeforeEach | NodeTypeAfterEach | NodeTypeJustAfterEach | NodeTypeBeforeAll | NodeTypeAfterAll | NodeTypesForSuiteSetupAndCleanup
+var NodeTypesForSuiteSetupAndCleanup = NodeTypeBeforeSuite | NodeTypeSynchronizedBeforeSuite | NodeTypeAfterSuite | NodeTypeSynchronizedAfterSuite
+var NodeTypesForSuiteLevelNodes = NodeTypeBeforeSuite | NodeTypeSynchronizedBeforeSuite | NodeTypeAfterSuite | NodeTypeSynchronizedAfterSuite | NodeTypeReportAfterSuite | NodeTypeCleanupAfterSuite | NodeTypeReadinessGate
 
 var ntEnumSupport = NewEnumSupport(map[uint]string{
 	uint(NodeTypeInvalid):                 "INVALID NODE TYPE",
@@ -525,6 +1395,8 @@ var ntEnumSupport = NewEnumSupport(map[uint]string{
 	uint(NodeTypeCleanupAfterEach):        "DeferCleanup",
 	uint(NodeTypeCleanupAfterAll):         "DeferCleanup (All)",
 	uint(NodeTypeCleanupAfterSuite):       "DeferCleanup (Suite)",
+	uint(NodeTypeReadinessGate):           "ReadinessGate",
+	uint(NodeTypeAroundEach):              "AroundEach",
 })
 
 func (nt NodeType) String() string {
@@ -2,6 +2,7 @@ package types_test
 
 import (
 	"encoding/json"
+	"strings"
 	"time"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -56,6 +57,86 @@ var _ = Describe("Types", func() {
 				}))
 
 			})
+
+			It("keeps the first non-empty environment fingerprint", func() {
+				reportA := types.Report{}
+				reportB := types.Report{Environment: types.EnvironmentFingerprint{GoVersion: "go1.17", NumCPU: 4}}
+				reportC := types.Report{Environment: types.EnvironmentFingerprint{GoVersion: "go1.16", NumCPU: 8}}
+
+				Ω(reportA.Add(reportB).Environment).Should(Equal(reportB.Environment))
+				Ω(reportB.Add(reportC).Environment).Should(Equal(reportB.Environment))
+			})
+
+			It("keeps the first non-empty set of unmatched filters", func() {
+				reportA := types.Report{}
+				reportB := types.Report{UnmatchedFilters: []string{`--focus="Q"`}}
+
+				Ω(reportA.Add(reportB).UnmatchedFilters).Should(Equal(reportB.UnmatchedFilters))
+				Ω(reportB.Add(reportA).UnmatchedFilters).Should(Equal(reportB.UnmatchedFilters))
+			})
+
+			It("merges the unmet requirements recorded by each process, without duplicates", func() {
+				reportA := types.Report{UnmetRequirements: types.UnmetRequirements{{Requirement: "docker", Reason: "no daemon"}}}
+				reportB := types.Report{UnmetRequirements: types.UnmetRequirements{{Requirement: "postgres>=14", Reason: "found 12"}, {Requirement: "docker", Reason: "timed out"}}}
+
+				Ω(reportA.Add(reportB).UnmetRequirements).Should(Equal(types.UnmetRequirements{{Requirement: "docker", Reason: "no daemon"}, {Requirement: "postgres>=14", Reason: "found 12"}}))
+				Ω(types.Report{}.Add(types.Report{}).UnmetRequirements).Should(BeNil())
+			})
+
+			It("merges the parallel schedules recorded by each process", func() {
+				reportA := types.Report{ParallelSchedule: types.ParallelSchedule{RandomSeed: 17, ParallelTotal: 2, NumGroups: 4, GroupIndices: map[int][]int{1: {0, 3}}}}
+				reportB := types.Report{ParallelSchedule: types.ParallelSchedule{RandomSeed: 17, ParallelTotal: 2, NumGroups: 4, GroupIndices: map[int][]int{2: {1, 2}}}}
+
+				Ω(types.Report{}.Add(reportA).Add(reportB).ParallelSchedule).Should(Equal(types.ParallelSchedule{
+					RandomSeed:    17,
+					ParallelTotal: 2,
+					NumGroups:     4,
+					GroupIndices:  map[int][]int{1: {0, 3}, 2: {1, 2}},
+				}))
+				Ω(reportA.ParallelSchedule.GroupIndices).Should(Equal(map[int][]int{1: {0, 3}}))
+			})
+
+			It("keeps the exit reason with the highest precedence", func() {
+				reportA := types.Report{ExitReason: types.ExitReasonFailed}
+				reportB := types.Report{ExitReason: types.ExitReasonInterrupted}
+
+				Ω(reportA.Add(reportB).ExitReason).Should(Equal(types.ExitReasonInterrupted))
+				Ω(reportB.Add(reportA).ExitReason).Should(Equal(types.ExitReasonInterrupted))
+				Ω(types.Report{}.Add(reportA).ExitReason).Should(Equal(types.ExitReasonFailed))
+			})
+		})
+	})
+
+	Describe("ExitReason", func() {
+		DescribeTable("Representation, Encoding, and Exit Codes", func(exitReason types.ExitReason, expectedString string, expectedExitCode int) {
+			Ω(exitReason.String()).Should(Equal(expectedString))
+
+			marshalled, err := json.Marshal(exitReason)
+			Ω(err).ShouldNot(HaveOccurred())
+			var unmarshalled types.ExitReason
+			json.Unmarshal(marshalled, &unmarshalled)
+			Ω(unmarshalled).Should(Equal(exitReason))
+
+			Ω(exitReason.ExitCode()).Should(Equal(expectedExitCode))
+			Ω(types.ExitReasonForExitCode(expectedExitCode)).Should(Equal(exitReason))
+		},
+			Entry("Passed", types.ExitReasonPassed, "passed", 0),
+			Entry("Focused", types.ExitReasonFocused, "focused", types.GINKGO_FOCUS_EXIT_CODE),
+			Entry("Failed", types.ExitReasonFailed, "failed", 1),
+			Entry("TimedOut", types.ExitReasonTimedOut, "timed-out", types.GINKGO_TIMED_OUT_EXIT_CODE),
+			Entry("Interrupted", types.ExitReasonInterrupted, "interrupted", types.GINKGO_INTERRUPTED_EXIT_CODE),
+			Entry("CompilationError", types.ExitReasonCompilationError, "compilation-error", types.GINKGO_COMPILATION_ERROR_EXIT_CODE),
+			Entry("ConfigurationError", types.ExitReasonConfigurationError, "configuration-error", types.GINKGO_CONFIGURATION_ERROR_EXIT_CODE),
+		)
+
+		It("treats unrecognized exit codes as failures", func() {
+			Ω(types.ExitReasonForExitCode(2)).Should(Equal(types.ExitReasonFailed))
+		})
+
+		It("combines exit reasons by precedence", func() {
+			Ω(types.ExitReasonPassed.Combine(types.ExitReasonFocused)).Should(Equal(types.ExitReasonFocused))
+			Ω(types.ExitReasonFailed.Combine(types.ExitReasonFocused)).Should(Equal(types.ExitReasonFailed))
+			Ω(types.ExitReasonTimedOut.Combine(types.ExitReasonCompilationError)).Should(Equal(types.ExitReasonCompilationError))
 		})
 	})
 
@@ -247,15 +328,19 @@ var _ = Describe("Types", func() {
 						types.NewCodeLocationWithStackTrace(0),
 						types.NewCustomCodeLocation("welp"),
 					},
-					LeafNodeType:               types.NodeTypeIt,
-					LeafNodeLocation:           types.NewCodeLocation(0),
-					LeafNodeText:               "C",
-					State:                      types.SpecStateFailed,
-					StartTime:                  time.Date(2012, 06, 19, 05, 32, 12, 0, time.UTC),
-					EndTime:                    time.Date(2012, 06, 19, 05, 33, 12, 0, time.UTC),
-					RunTime:                    time.Minute,
-					ParallelProcess:            2,
-					NumAttempts:                3,
+					LeafNodeType:     types.NodeTypeIt,
+					LeafNodeLocation: types.NewCodeLocation(0),
+					LeafNodeText:     "C",
+					State:            types.SpecStateFailed,
+					StartTime:        time.Date(2012, 06, 19, 05, 32, 12, 0, time.UTC),
+					EndTime:          time.Date(2012, 06, 19, 05, 33, 12, 0, time.UTC),
+					RunTime:          time.Minute,
+					ParallelProcess:  2,
+					NumAttempts:      3,
+					Attempts: types.SpecAttempts{
+						{Attempt: 1, State: types.SpecStateFailed, RunTime: time.Second, Failure: types.Failure{Message: "first", Location: types.NewCodeLocation(0)}, CapturedGinkgoWriterOutput: "gw-1"},
+						{Attempt: 2, State: types.SpecStatePassed, RunTime: time.Second, CapturedStdOutErr: "std-2"},
+					},
 					CapturedGinkgoWriterOutput: "gw",
 					CapturedStdOutErr:          "std",
 					Failure: types.Failure{
@@ -284,6 +369,7 @@ var _ = Describe("Types", func() {
 			Context("without a failure", func() {
 				BeforeEach(func() {
 					report.Failure = types.Failure{}
+					report.Attempts = types.SpecAttempts{{Attempt: 1, State: types.SpecStatePassed, RunTime: time.Second}}
 				})
 				It("round-trips correctly and doesn't include the Failure struct", func() {
 					marshalled, err := json.Marshal(report)
@@ -360,5 +446,121 @@ var _ = Describe("Types", func() {
 				Ω(reports.CountOfFlakedSpecs()).Should(Equal(2))
 			})
 		})
+
+		Describe("GroupByFailureFingerprint", func() {
+			It("groups the failed reports by fingerprint, largest group first", func() {
+				reports := types.SpecReports{
+					{LeafNodeText: "A", State: types.SpecStateFailed, FailureFingerprint: "a"},
+					{LeafNodeText: "B", State: types.SpecStatePanicked, FailureFingerprint: "b"},
+					{LeafNodeText: "C", State: types.SpecStateTimedout, FailureFingerprint: "b"},
+					{LeafNodeText: "D", State: types.SpecStatePassed, FailureFingerprint: "a"},
+					{LeafNodeText: "E", State: types.SpecStateFailed},
+					{LeafNodeText: "F", State: types.SpecStateFailed, FailureFingerprint: "c"},
+				}
+
+				groups := reports.GroupByFailureFingerprint()
+				Ω(groups).Should(HaveLen(3))
+				Ω(groups[0].Fingerprint).Should(Equal("b"))
+				Ω(groups[0].SpecReports).Should(HaveLen(2))
+				Ω(groups[1].Fingerprint).Should(Equal("a"))
+				Ω(groups[1].SpecReports).Should(HaveLen(1))
+				Ω(groups[2].Fingerprint).Should(Equal("c"))
+			})
+		})
+	})
+
+	Describe("FailureCategory", func() {
+		It("classifies the failure the spec ended in", func() {
+			Ω(types.SpecReport{State: types.SpecStatePassed}.FailureCategory()).Should(Equal(types.FailureCategoryNone))
+			Ω(types.SpecReport{State: types.SpecStateInterrupted}.FailureCategory()).Should(Equal(types.FailureCategoryNone))
+			Ω(types.SpecReport{State: types.SpecStateFailed}.FailureCategory()).Should(Equal(types.FailureCategoryAssertion))
+			Ω(types.SpecReport{State: types.SpecStateFailed, Failure: types.Failure{Error: &types.FailureError{}}}.FailureCategory()).Should(Equal(types.FailureCategoryError))
+			Ω(types.SpecReport{State: types.SpecStatePanicked}.FailureCategory()).Should(Equal(types.FailureCategoryPanic))
+			Ω(types.SpecReport{State: types.SpecStateTimedout}.FailureCategory()).Should(Equal(types.FailureCategoryTimeout))
+		})
+	})
+
+	Describe("FailureRetryPolicy", func() {
+		failure := types.Failure{Message: "connection refused", Location: types.CodeLocation{FileName: "foo_test.go", LineNumber: 17}}
+		assertion := types.SpecReport{State: types.SpecStateFailed, Failure: failure}
+		timeout := types.SpecReport{State: types.SpecStateTimedout}
+
+		It("allows everything to be retried by default", func() {
+			Ω(types.FailureRetryPolicy{}.AllowsRetry(assertion)).Should(BeTrue())
+			Ω(types.FailureRetryPolicy{}.AllowsRetry(timeout)).Should(BeTrue())
+		})
+
+		It("only allows failures that match RetryOn, and treats infrastructure as errors and timeouts", func() {
+			policy := types.FailureRetryPolicy{RetryOn: []string{"infrastructure"}}
+			Ω(policy.AllowsRetry(assertion)).Should(BeFalse())
+			Ω(policy.AllowsRetry(timeout)).Should(BeTrue())
+		})
+
+		It("never allows failures that match NeverRetryOn", func() {
+			policy := types.FailureRetryPolicy{NeverRetryOn: []string{failure.Fingerprint()}}
+			Ω(policy.AllowsRetry(assertion)).Should(BeFalse())
+			Ω(policy.AllowsRetry(timeout)).Should(BeTrue())
+		})
+	})
+
+	Describe("Failure", func() {
+		Describe("Fingerprint", func() {
+			cl := types.CodeLocation{FileName: "foo_test.go", LineNumber: 17}
+
+			It("is empty for the zero failure", func() {
+				Ω(types.Failure{}.Fingerprint()).Should(BeEmpty())
+			})
+
+			It("is shared by failures whose messages differ only in numbers, memory addresses, UUIDs, and whitespace", func() {
+				a := types.Failure{Message: "dial tcp 127.0.0.1:4021: connection refused\n<*Client | 0xc000123456> request 0b8e2a3e-5d1c-4f7a-9b2e-3c4d5e6f7a8b", Location: cl}
+				b := types.Failure{Message: "dial tcp 127.0.0.1:39877:   connection refused\n<*Client | 0xc0009abcde> request 9C1D2E3F-4A5B-4C6D-8E7F-0A1B2C3D4E5F", Location: cl}
+				Ω(a.Fingerprint()).ShouldNot(BeEmpty())
+				Ω(a.Fingerprint()).Should(Equal(b.Fingerprint()))
+			})
+
+			It("differs for failures with different messages or locations", func() {
+				a := types.Failure{Message: "connection refused", Location: cl}
+				Ω(a.Fingerprint()).ShouldNot(Equal(types.Failure{Message: "connection reset", Location: cl}.Fingerprint()))
+				Ω(a.Fingerprint()).ShouldNot(Equal(types.Failure{Message: "connection refused", Location: types.CodeLocation{FileName: "foo_test.go", LineNumber: 18}}.Fingerprint()))
+			})
+		})
+	})
+
+	Describe("ReportEntryValue", func() {
+		Describe("Redacted", func() {
+			redact := func(s string) string { return strings.ReplaceAll(s, "hunter2", "***") }
+
+			It("returns the value as-is if nothing is redacted", func() {
+				value := &struct{ Count int }{Count: 17}
+				rev := types.WrapEntryValue(value)
+				redacted := rev.Redacted(redact)
+				value.Count = 18
+				Ω(redacted.String()).Should(Equal("&{Count:18}"), "pointers should still be rendered at reporting time")
+			})
+
+			It("redacts the string and JSON representations", func() {
+				rev := types.WrapEntryValue(map[string]string{"password": "hunter2"})
+				redacted := rev.Redacted(redact)
+				Ω(redacted.String()).Should(Equal("map[password:***]"))
+				Ω(redacted.String()).ShouldNot(ContainSubstring("hunter2"))
+
+				encoded, err := json.Marshal(redacted)
+				Ω(err).ShouldNot(HaveOccurred())
+				Ω(string(encoded)).ShouldNot(ContainSubstring("hunter2"))
+				Ω(redacted.GetRawValue()).Should(Equal(map[string]interface{}{"password": "***"}))
+			})
+
+			It("redacts values that were decoded from JSON", func() {
+				rev := types.WrapEntryValue("the password is hunter2")
+				encoded, err := json.Marshal(rev)
+				Ω(err).ShouldNot(HaveOccurred())
+				decoded := types.ReportEntryValue{}
+				Ω(json.Unmarshal(encoded, &decoded)).Should(Succeed())
+
+				redacted := decoded.Redacted(redact)
+				Ω(redacted.String()).Should(Equal("the password is ***"))
+				Ω(redacted.AsJSON).Should(Equal(`"the password is ***"`))
+			})
+		})
 	})
 })
@@ -1,3 +1,8 @@
 package types
 
 const VERSION = "2.1.1"
+
+// PROTOCOL_VERSION versions the contract between the Ginkgo CLI and the suites it runs: the flags it passes, the environment
+// it sets up, and the parallel support server the processes report to.  It is bumped whenever that contract changes in a way
+// that would break a CLI and a suite compiled against different versions of Ginkgo.
+const PROTOCOL_VERSION = 1